@@ -24,6 +24,6 @@ func main() {
 	cancel() // Ensure cleanup always happens
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(cmd.ExitCode(err))
 	}
 }
@@ -3,22 +3,33 @@ package client
 
 import (
 	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
 
 	"github.com/spf13/viper"
 
 	"github.com/ginsys/forward-email/internal/keyring"
+	"github.com/ginsys/forward-email/internal/version"
 	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/apitest"
 	"github.com/ginsys/forward-email/pkg/auth"
 	"github.com/ginsys/forward-email/pkg/config"
 )
 
+// DefaultBaseURL is the public Forward Email API endpoint.
+const DefaultBaseURL = api.DefaultBaseURL
+
 // Test mode configuration variables for unit testing and development.
 // These allow the client to be configured with mock servers and authentication
 // providers for testing without making real API calls.
 var (
-	testMode    bool          // Flag indicating if client is in test mode
-	testBaseURL string        // Mock server URL for testing
-	testAuth    auth.Provider // Mock authentication provider for testing
+	testMode     bool              // Flag indicating if client is in test mode
+	testBaseURL  string            // Mock server URL for testing
+	testAuth     auth.Provider     // Mock authentication provider for testing
+	testProfiles map[string]string // Mock server URLs for specific profiles
 )
 
 // SetTestMode configures the client factory for testing with a mock server.
@@ -30,12 +41,34 @@ func SetTestMode(baseURL string, authProvider auth.Provider) {
 	testAuth = authProvider
 }
 
+// SetTestProfile points clients created for the named profile with
+// NewAPIClientForProfile at their own mock server, so tests can simulate
+// several accounts. SetTestMode must be called as well.
+func SetTestProfile(profile, baseURL string) {
+	if testProfiles == nil {
+		testProfiles = map[string]string{}
+	}
+	testProfiles[profile] = baseURL
+}
+
+// The cassette recorder is shared by every client of the process, so that a
+// command talking to several profiles records into one cassette.
+var (
+	cassetteMu       sync.Mutex
+	cassetteRecorder *apitest.Recorder
+	cassettePath     string
+)
+
 // ResetTestMode disables test mode and returns the client factory to normal operation.
 // This should be called in test cleanup to ensure tests don't interfere with each other.
 func ResetTestMode() {
 	testMode = false
 	testBaseURL = ""
 	testAuth = nil
+	testProfiles = nil
+	cassetteMu.Lock()
+	cassetteRecorder, cassettePath = nil, ""
+	cassetteMu.Unlock()
 }
 
 // NewAPIClient creates a new Forward Email API client with proper authentication setup.
@@ -45,7 +78,10 @@ func ResetTestMode() {
 func NewAPIClient() (*api.Client, error) {
 	// If in test mode, return test client
 	if testMode {
-		return api.NewClient(testBaseURL, testAuth)
+		return newClient(api.WithBaseURL(testBaseURL), api.WithAuth(testAuth))
+	}
+	if c, ok, err := newReplayClient(); ok {
+		return c, err
 	}
 
 	profile := viper.GetString("profile")
@@ -60,11 +96,49 @@ func NewAPIClient() (*api.Client, error) {
 	if profile == "" {
 		profile = cfg.CurrentProfile
 		if profile == "" {
+			if c, ok, err := newEnvKeyClient(); ok {
+				return c, err
+			}
 			return nil, fmt.Errorf("no profile configured. Use 'forward-email profile create <name>' to create a profile and " +
 				"'forward-email profile switch <name>' to set it as current")
 		}
 	}
 
+	return newProfileClient(cfg, profile)
+}
+
+// NewAPIClientForProfile creates an API client for the named profile regardless of
+// --profile and the current profile, e.g. to work with a second account.
+func NewAPIClientForProfile(profile string) (*api.Client, error) {
+	if testMode {
+		baseURL := testBaseURL
+		if u, ok := testProfiles[profile]; ok {
+			baseURL = u
+		}
+		return newClient(api.WithBaseURL(baseURL), api.WithAuth(testAuth))
+	}
+	if c, ok, err := newReplayClient(); ok {
+		return c, err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	if _, err := cfg.GetProfile(profile); err != nil {
+		return nil, err
+	}
+	return newProfileClient(cfg, profile)
+}
+
+// newClient creates an API client that identifies this build in its
+// User-Agent.
+func newClient(opts ...api.ClientOption) (*api.Client, error) {
+	return api.NewClient(append([]api.ClientOption{api.WithUserAgent(version.UserAgent())}, opts...)...)
+}
+
+// newProfileClient creates a client authenticated with the profile's credentials.
+func newProfileClient(cfg *config.Config, profile string) (*api.Client, error) {
 	// Initialize keyring
 	kr, err := keyring.New(keyring.Config{})
 	if err != nil {
@@ -81,10 +155,120 @@ func NewAPIClient() (*api.Client, error) {
 		return nil, fmt.Errorf("failed to create auth provider: %w", err)
 	}
 
-	baseURL := viper.GetString("api_base_url")
-	if baseURL == "" {
-		baseURL = "https://api.forwardemail.net"
+	opts := []api.ClientOption{api.WithBaseURL(ResolveBaseURL(cfg, profile)), api.WithAuth(authProvider)}
+	if p, ok := cfg.Profiles[profile]; ok && p.DNSRecords != nil {
+		opts = append(opts, api.WithDNSExpectations(api.DNSExpectations{
+			MXHosts:    p.DNSRecords.MXHosts,
+			SPFInclude: p.DNSRecords.SPFInclude,
+		}))
+	}
+	rec, err := cassette()
+	if err != nil {
+		return nil, err
+	}
+	if rec != nil {
+		opts = append(opts, api.WithHTTPClient(cassetteHTTPClient(rec)))
+	} else if cache := responseCache(); cache != nil {
+		opts = append(opts, api.WithCache(cache))
 	}
+	return newClient(opts...)
+}
 
-	return api.NewClient(baseURL, authProvider)
+// responseCache returns the on-disk cache for conditional GET requests, shared
+// by every run so completions and repeated lookups skip unchanged bodies. It is
+// nil when disabled with --no-cache or when no cache directory is available.
+// Cassette sessions never use it, so recordings stay independent of earlier runs.
+func responseCache() api.ResponseCache {
+	if viper.GetBool("no_cache") {
+		return nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return nil
+	}
+	return api.NewFileCache(filepath.Join(dir, "forwardemail", "http"))
+}
+
+// newReplayClient returns a client answering from the --cassette file when
+// one is replayed; ok is false otherwise. Replays need no profile or
+// credentials, so automation can be exercised without an account.
+func newReplayClient() (c *api.Client, ok bool, err error) {
+	rec, err := cassette()
+	if err != nil {
+		return nil, true, err
+	}
+	if rec == nil || rec.Mode() != apitest.ModeReplay {
+		return nil, false, nil
+	}
+	c, err = newClient(
+		api.WithBaseURL(ResolveBaseURL(nil, "")),
+		api.WithAPIKey("replay"),
+		api.WithHTTPClient(cassetteHTTPClient(rec)),
+	)
+	return c, true, err
+}
+
+// newEnvKeyClient returns a client authenticated with FORWARDEMAIL_API_KEY
+// when --api-url is set but no profile is configured, e.g. to try the CLI
+// against 'forward-email mock-server'; ok is false otherwise.
+func newEnvKeyClient() (c *api.Client, ok bool, err error) {
+	key := os.Getenv("FORWARDEMAIL_API_KEY")
+	if key == "" || viper.GetString("api_base_url") == "" {
+		return nil, false, nil
+	}
+	c, err = newClient(api.WithBaseURL(ResolveBaseURL(nil, "")), api.WithAPIKey(key))
+	return c, true, err
+}
+
+// cassette returns the recorder for --cassette, or nil when none is set.
+func cassette() (*apitest.Recorder, error) {
+	path := viper.GetString("cassette")
+	if path == "" {
+		return nil, nil
+	}
+	cassetteMu.Lock()
+	defer cassetteMu.Unlock()
+	if cassetteRecorder != nil && cassettePath == path {
+		return cassetteRecorder, nil
+	}
+	mode, err := apitest.ParseMode(viper.GetString("cassette_mode"))
+	if err != nil {
+		return nil, err
+	}
+	rec, err := apitest.New(path, mode, nil)
+	if err != nil {
+		return nil, err
+	}
+	cassetteRecorder, cassettePath = rec, path
+	return rec, nil
+}
+
+func cassetteHTTPClient(rec *apitest.Recorder) *http.Client {
+	return &http.Client{Timeout: 30 * time.Second, Transport: rec}
+}
+
+// NewAPIClientWithKey creates an API client that authenticates with the given API key
+// instead of resolving credentials from a profile. It is used to validate a key before
+// it is stored, e.g. by the setup wizard.
+func NewAPIClientWithKey(apiKey string) (*api.Client, error) {
+	if testMode {
+		return newClient(api.WithBaseURL(testBaseURL), api.WithAPIKey(apiKey))
+	}
+
+	return newClient(api.WithBaseURL(ResolveBaseURL(nil, "")), api.WithAPIKey(apiKey))
+}
+
+// ResolveBaseURL returns the API base URL to use for the given profile.
+// The --api-url flag (or FORWARDEMAIL_API_BASE_URL) takes precedence over the
+// profile's base_url, which in turn overrides DefaultBaseURL. cfg may be nil.
+func ResolveBaseURL(cfg *config.Config, profile string) string {
+	if u := viper.GetString("api_base_url"); u != "" {
+		return u
+	}
+	if cfg != nil {
+		if p, ok := cfg.Profiles[profile]; ok && p.BaseURL != "" {
+			return p.BaseURL
+		}
+	}
+	return DefaultBaseURL
 }
@@ -1,9 +1,13 @@
 package client
 
 import (
+	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/ginsys/forward-email/internal/testutil"
+	"github.com/ginsys/forward-email/pkg/auth"
+	"github.com/ginsys/forward-email/pkg/config"
 	"github.com/spf13/viper"
 )
 
@@ -28,6 +32,7 @@ func TestNewAPIClient(t *testing.T) {
 			name: "no profile configured",
 			setupEnv: func() {
 				testutil.ResetViper()
+				t.Setenv("FORWARDEMAIL_API_KEY", "")
 			},
 			setupConfig: func() string {
 				// Create empty config
@@ -41,6 +46,30 @@ profiles: {}
 			expectedError: "no profile configured",
 			shouldSucceed: false,
 		},
+		{
+			name: "no profile, API key from the environment and --api-url",
+			setupEnv: func() {
+				testutil.ResetViper()
+				t.Setenv("FORWARDEMAIL_API_KEY", "demo")
+				viper.Set("api_base_url", "http://127.0.0.1:8080")
+			},
+			setupConfig: func() string {
+				return testutil.SetupTempConfig(t)
+			},
+			shouldSucceed: true,
+		},
+		{
+			name: "no profile and no --api-url",
+			setupEnv: func() {
+				testutil.ResetViper()
+				t.Setenv("FORWARDEMAIL_API_KEY", "demo")
+			},
+			setupConfig: func() string {
+				return testutil.SetupTempConfig(t)
+			},
+			expectedError: "no profile configured",
+			shouldSucceed: false,
+		},
 		{
 			name: "profile specified via viper flag",
 			setupEnv: func() {
@@ -210,6 +239,76 @@ profiles:
 	}
 }
 
+func TestNewAPIClient_DNSRecords(t *testing.T) {
+	testutil.ResetViper()
+	defer testutil.ResetViper()
+	tempDir := testutil.SetupTempConfig(t)
+	testutil.WriteTestConfig(t, tempDir, `current_profile: "selfhosted"
+profiles:
+  selfhosted:
+    base_url: "https://api.mail.example.net"
+    api_key: "key"
+    dns_records:
+      mx_hosts: [mx1.mail.example.net, mx2.mail.example.net]
+      spf_include: spf.mail.example.net
+`)
+
+	c, err := NewAPIClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.DNS.MXHosts) != 2 || c.DNS.MXHosts[0] != "mx1.mail.example.net" || c.DNS.SPFInclude != "spf.mail.example.net" {
+		t.Errorf("DNS = %+v, want the profile's dns_records", c.DNS)
+	}
+}
+
+func TestNewAPIClient_UserAgent(t *testing.T) {
+	SetTestMode("http://127.0.0.1:1", auth.MockProvider("test"))
+	t.Cleanup(ResetTestMode)
+
+	c, err := NewAPIClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "forward-email/dev (" + runtime.GOOS + "; " + runtime.GOARCH + "; go"
+	if !strings.HasPrefix(c.UserAgent, want) {
+		t.Errorf("User-Agent = %q, want prefix %q", c.UserAgent, want)
+	}
+}
+
+func TestResolveBaseURL(t *testing.T) {
+	defer testutil.ResetViper()
+
+	cfg := &config.Config{Profiles: map[string]config.Profile{
+		"staging": {BaseURL: "https://mail.example.com/api"},
+		"prod":    {},
+	}}
+
+	tests := []struct {
+		name    string
+		profile string
+		flag    string
+		want    string
+	}{
+		{name: "default when profile has no base_url", profile: "prod", want: DefaultBaseURL},
+		{name: "profile base_url", profile: "staging", want: "https://mail.example.com/api"},
+		{name: "flag overrides profile", profile: "staging", flag: "http://localhost:3000", want: "http://localhost:3000"},
+		{name: "unknown profile", profile: "missing", want: DefaultBaseURL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutil.ResetViper()
+			if tt.flag != "" {
+				viper.Set("api_base_url", tt.flag)
+			}
+			if got := ResolveBaseURL(cfg, tt.profile); got != tt.want {
+				t.Errorf("ResolveBaseURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 // Helper function to check if a string contains a substring
 func containsString(haystack, needle string) bool {
 	return len(haystack) >= len(needle) &&
@@ -0,0 +1,59 @@
+// Package clipboard copies text to the system clipboard using the platform's
+// standard command-line tools.
+package clipboard
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// ErrUnavailable is returned when no clipboard tool is installed.
+var ErrUnavailable = errors.New("no clipboard tool found (install wl-clipboard, xclip or xsel)")
+
+// commands lists the clipboard writers to try for goos, in order of preference.
+func commands(goos string) [][]string {
+	switch goos {
+	case "darwin":
+		return [][]string{{"pbcopy"}}
+	case "windows":
+		return [][]string{{"clip.exe"}}
+	default:
+		return [][]string{
+			{"wl-copy"},
+			{"xclip", "-selection", "clipboard"},
+			{"xsel", "--clipboard", "--input"},
+		}
+	}
+}
+
+// lookup returns the first installed clipboard writer and its arguments.
+func lookup() (path string, args []string, ok bool) {
+	for _, c := range commands(runtime.GOOS) {
+		if p, err := exec.LookPath(c[0]); err == nil {
+			return p, c[1:], true
+		}
+	}
+	return "", nil, false
+}
+
+// Available reports whether a clipboard tool is installed.
+func Available() bool {
+	_, _, ok := lookup()
+	return ok
+}
+
+// Write places text on the system clipboard.
+func Write(text string) error {
+	if path, args, ok := lookup(); ok {
+		cmd := exec.Command(path, args...) //nolint:gosec // path comes from the fixed list above
+		cmd.Stdin = strings.NewReader(text)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("%s failed: %v: %s", path, err, strings.TrimSpace(string(out)))
+		}
+		return nil
+	}
+	return ErrUnavailable
+}
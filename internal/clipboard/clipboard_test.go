@@ -0,0 +1,54 @@
+package clipboard
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestCommands(t *testing.T) {
+	if got := commands("darwin"); len(got) != 1 || got[0][0] != "pbcopy" {
+		t.Errorf("darwin: %v", got)
+	}
+	if got := commands("linux"); len(got) != 3 || got[0][0] != "wl-copy" {
+		t.Errorf("linux: %v", got)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as a fake clipboard tool")
+	}
+
+	origPath := os.Getenv("PATH")
+	t.Setenv("PATH", t.TempDir())
+	if Available() {
+		t.Fatal("expected no clipboard tool on an empty PATH")
+	}
+	if err := Write("secret"); !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("expected ErrUnavailable without tools, got %v", err)
+	}
+
+	dir := t.TempDir()
+	dest := filepath.Join(dir, "clipboard.txt")
+	tool := commands(runtime.GOOS)[0][0]
+	script := "#!/bin/sh\ncat > " + dest + "\n"
+	if err := os.WriteFile(filepath.Join(dir, tool), []byte(script), 0o700); err != nil { //nolint:gosec // test helper must be executable
+		t.Fatal(err)
+	}
+	// The fake tool is found first but still needs cat from the original PATH
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+origPath)
+
+	if !Available() {
+		t.Fatal("expected the fake clipboard tool to be found")
+	}
+	if err := Write("secret"); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	got, err := os.ReadFile(dest) //nolint:gosec // path is inside the test's temp dir
+	if err != nil || string(got) != "secret" {
+		t.Errorf("clipboard contents = %q, %v", got, err)
+	}
+}
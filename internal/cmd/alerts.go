@@ -0,0 +1,287 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/notify"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+// Quota metrics understood by alerts check
+const (
+	alertMetricEmails  = "emails"  // account daily sending limit
+	alertMetricStorage = "storage" // per-alias IMAP storage
+)
+
+var (
+	alertThresholds []string
+	alertAllDomains bool
+	alertWebhook    string
+)
+
+// alertsCmd represents the alerts command
+var alertsCmd = &cobra.Command{
+	Use:   "alerts",
+	Short: "Check usage against alert thresholds",
+	Long:  `Check account and alias usage against thresholds, for use from cron or CI.`,
+}
+
+var alertsCheckCmd = &cobra.Command{
+	Use:   "check [domain...] --threshold <metric>=<percent>",
+	Short: "Check quotas and exit non-zero when a threshold is crossed",
+	Long: `Check quota usage against thresholds and exit with a non-zero status when any
+quota reaches its threshold, so cron can alert on it.
+
+Metrics:
+  emails   daily sending limit of the account
+  storage  IMAP storage of each alias with IMAP enabled in the given domains
+
+Breaches are printed, and posted to --webhook as JSON with a Slack-compatible
+"text" field. --webhook accepts the same URLs as the global --notify option.`,
+	Example: `  forward-email alerts check --threshold emails=90%
+  forward-email alerts check --threshold storage=80% --threshold emails=90% --all-domains
+  forward-email alerts check example.com --threshold storage=80% --webhook https://hooks.slack.com/services/...`,
+	SilenceUsage: true,
+	RunE:         runAlertsCheck,
+}
+
+func init() {
+	rootCmd.AddCommand(alertsCmd)
+	alertsCmd.AddCommand(alertsCheckCmd)
+
+	alertsCheckCmd.Flags().StringArrayVar(&alertThresholds, "threshold", nil,
+		"Alert threshold as <metric>=<percent> (metrics: emails, storage); repeatable")
+	alertsCheckCmd.Flags().BoolVar(&alertAllDomains, "all-domains", false, "Check alias storage in all available domains")
+	alertsCheckCmd.Flags().StringVar(&alertWebhook, "webhook", "", "Post breaches to this webhook URL (e.g. a Slack incoming webhook)")
+}
+
+// parseAlertThresholds parses --threshold values such as storage=80%.
+func parseAlertThresholds(values []string) (map[string]float64, error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("at least one --threshold is required (e.g. --threshold emails=90%%)")
+	}
+	thresholds := make(map[string]float64, len(values))
+	for _, v := range values {
+		metric, pct, ok := strings.Cut(v, "=")
+		metric = strings.ToLower(strings.TrimSpace(metric))
+		if !ok || (metric != alertMetricEmails && metric != alertMetricStorage) {
+			return nil, fmt.Errorf("invalid --threshold %q (use emails=<percent> or storage=<percent>)", v)
+		}
+		n, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(pct), "%"), 64)
+		if err != nil || n <= 0 || n > 100 {
+			return nil, fmt.Errorf("invalid --threshold %q: percent must be between 0 and 100", v)
+		}
+		thresholds[metric] = n
+	}
+	return thresholds, nil
+}
+
+// newQuotaUsage measures used against limit; quotas without a limit never breach.
+func newQuotaUsage(scope, metric string, used, limit int64, threshold float64) output.QuotaUsage {
+	u := output.QuotaUsage{Scope: scope, Metric: metric, Used: used, Limit: limit, Threshold: threshold}
+	if limit > 0 {
+		u.Percent = float64(used) * 100 / float64(limit)
+		u.Breached = u.Percent >= threshold
+	}
+	return u
+}
+
+func runAlertsCheck(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	thresholds, err := parseAlertThresholds(alertThresholds)
+	if err != nil {
+		return err
+	}
+	if _, checkStorage := thresholds[alertMetricStorage]; checkStorage && len(args) == 0 && !alertAllDomains {
+		if p, _ := activeProfile(); p.DefaultDomain == "" {
+			return fmt.Errorf("the storage threshold needs domain arguments, --all-domains or a default_domain")
+		}
+	}
+
+	allProfiles, err := allProfilesSet(cmd)
+	if err != nil {
+		return err
+	}
+	format, err := output.ParseFormat(viper.GetString("output"))
+	if err != nil {
+		return fmt.Errorf("invalid output format: %v", err)
+	}
+
+	var usage []output.QuotaUsage
+	var results []accountResult[output.QuotaUsage]
+	var fanErr error
+	if allProfiles {
+		results, fanErr = fanOutProfiles(ctx, cmd, func(ctx context.Context, c *api.Client) ([]output.QuotaUsage, error) {
+			return measureQuotaUsage(ctx, cmd, c, args, thresholds)
+		})
+		for i := range results {
+			for j := range results[i].items {
+				results[i].items[j].Account = results[i].account
+			}
+			usage = append(usage, results[i].items...)
+		}
+	} else {
+		apiClient, err := client.NewAPIClient()
+		if err != nil {
+			return fmt.Errorf("failed to create API client: %v", err)
+		}
+		if usage, err = measureQuotaUsage(ctx, cmd, apiClient, args, thresholds); err != nil {
+			return err
+		}
+	}
+
+	var breaches []output.QuotaUsage
+	for _, u := range usage {
+		if u.Breached {
+			breaches = append(breaches, u)
+		}
+	}
+
+	if format == output.FormatGHA {
+		if err := startGHASummary("Quota alerts"); err != nil {
+			return err
+		}
+	}
+	if allProfiles {
+		if err := formatAccountResults(cmd, results, output.FormatQuotaUsage); err != nil {
+			return err
+		}
+	} else {
+		formatter := output.NewFormatter(format, cmd.OutOrStdout())
+		if format == output.FormatJSON || format == output.FormatYAML {
+			if err := formatter.Format(usage); err != nil {
+				return err
+			}
+		} else {
+			tableData, err := output.FormatQuotaUsage(usage, format)
+			if err != nil {
+				return fmt.Errorf("failed to format output: %v", err)
+			}
+			if err := formatter.Format(tableData); err != nil {
+				return err
+			}
+		}
+	}
+
+	if format == output.FormatGHA {
+		if err := finishGHASummary(cmd, alertNote(breaches), alertAnnotations(breaches)); err != nil {
+			return err
+		}
+	}
+
+	if len(breaches) == 0 {
+		return fanErr
+	}
+	notifyDetails = breaches
+	if alertWebhook != "" {
+		if err := postAlertWebhook(ctx, alertWebhook, breaches); err != nil {
+			slog.Warn("failed to post alert webhook", "error", err)
+		}
+	}
+	return fmt.Errorf("%d quota(s) at or above threshold", len(breaches))
+}
+
+// measureQuotaUsage measures the account's sending quota and, with a storage
+// threshold, the IMAP storage of every alias in the given domains.
+func measureQuotaUsage(ctx context.Context, cmd *cobra.Command, c *api.Client, args []string, thresholds map[string]float64) ([]output.QuotaUsage, error) {
+	storageThreshold, checkStorage := thresholds[alertMetricStorage]
+	usage := []output.QuotaUsage{}
+	if threshold, ok := thresholds[alertMetricEmails]; ok {
+		quota, quotaErr := c.Emails.GetEmailQuota(ctx)
+		if quotaErr != nil {
+			return nil, fmt.Errorf("failed to get email quota: %v", quotaErr)
+		}
+		usage = append(usage, newQuotaUsage("account", alertMetricEmails,
+			int64(quota.EmailsSent), int64(quota.EmailsLimit), threshold))
+	}
+
+	if checkStorage {
+		domains, domainsErr := resolveAliasDomains(ctx, cmd, c, args, alertAllDomains)
+		if domainsErr != nil {
+			return nil, domainsErr
+		}
+		for _, domain := range domains {
+			aliases, listErr := listAllAliases(ctx, c, domain)
+			if listErr != nil {
+				return nil, fmt.Errorf("failed to list aliases for %s: %v", domain, listErr)
+			}
+			for i := range aliases {
+				a := &aliases[i]
+				if !a.HasIMAP {
+					continue
+				}
+				quota := a.Quota
+				if quota == nil {
+					var err error
+					if quota, err = c.Aliases.GetAliasQuota(ctx, domain, a.ID); err != nil {
+						return nil, fmt.Errorf("failed to get quota for %s@%s: %v", a.Name, domain, err)
+					}
+				}
+				usage = append(usage, newQuotaUsage(a.Name+"@"+domain, alertMetricStorage,
+					quota.StorageUsed, quota.StorageLimit, storageThreshold))
+			}
+		}
+	}
+	return usage, nil
+}
+
+// postAlertWebhook posts breaches as a notification event. The "text" field
+// makes the payload usable as-is with Slack and compatible incoming webhooks.
+func postAlertWebhook(ctx context.Context, url string, breaches []output.QuotaUsage) error {
+	return postNotification(ctx, []string{url}, notify.Event{
+		Time:    time.Now().UTC(),
+		Command: "alerts check",
+		Text:    alertSummary(breaches),
+		Details: breaches,
+		Error:   fmt.Sprintf("%d quota(s) at or above threshold", len(breaches)),
+	})
+}
+
+// alertSummary renders breaches as one line each under a heading.
+func alertSummary(breaches []output.QuotaUsage) string {
+	lines := make([]string, 0, len(breaches)+1)
+	lines = append(lines, fmt.Sprintf("forward-email: %d quota alert(s)", len(breaches)))
+	for _, b := range breaches {
+		lines = append(lines, "• "+alertLine(b))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// alertLine describes one breach.
+func alertLine(b output.QuotaUsage) string {
+	scope := b.Scope
+	if b.Account != "" {
+		scope = b.Account + ": " + scope
+	}
+	return fmt.Sprintf("%s %s at %.1f%% (threshold %g%%)", scope, b.Metric, b.Percent, b.Threshold)
+}
+
+// alertNote is the closing line of the step summary in --output gha.
+func alertNote(breaches []output.QuotaUsage) string {
+	if len(breaches) == 0 {
+		return "✅ All quotas are below their thresholds."
+	}
+	return fmt.Sprintf("❌ %d quota(s) at or above threshold.", len(breaches))
+}
+
+// alertAnnotations turns breaches into error annotations for --output gha.
+func alertAnnotations(breaches []output.QuotaUsage) []output.Annotation {
+	annotations := make([]output.Annotation, 0, len(breaches))
+	for _, b := range breaches {
+		annotations = append(annotations, output.Annotation{
+			Level: output.AnnotationError, Title: "Quota alert", Message: alertLine(b),
+		})
+	}
+	return annotations
+}
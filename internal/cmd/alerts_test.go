@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+func TestParseAlertThresholds(t *testing.T) {
+	got, err := parseAlertThresholds([]string{"storage=80%", "Emails=92.5"})
+	if err != nil || got["storage"] != 80 || got["emails"] != 92.5 {
+		t.Fatalf("parseAlertThresholds = %v, %v", got, err)
+	}
+	for _, bad := range [][]string{nil, {"storage"}, {"disk=80%"}, {"emails=0%"}, {"emails=120"}} {
+		if _, err := parseAlertThresholds(bad); err == nil {
+			t.Errorf("expected %v to be rejected", bad)
+		}
+	}
+}
+
+func TestAlertsCheck(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    aliases:
+      - name: full
+        recipients: [me@example.org]
+        has_imap: true
+        quota: {storage_used: 900, storage_limit: 1000}
+      - name: roomy
+        recipients: [me@example.org]
+        has_imap: true
+      - name: forward-only
+        recipients: [me@example.org]
+quota:
+  emails_sent: 5
+  emails_limit: 10
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	var posted map[string]any
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&posted)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer hook.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		alertThresholds, alertAllDomains, alertWebhook = nil, false, ""
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	})
+
+	var buf, errBuf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetErr(&errBuf)
+	rootCmd.SetArgs([]string{"alerts", "check", "--all-domains", "-o", "json",
+		"--threshold", "storage=80%", "--threshold", "emails=90%", "--webhook", hook.URL})
+	err = rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "1 quota(s)") {
+		t.Fatalf("expected one breach to fail the check, got %v\n%s", err, errBuf.String())
+	}
+
+	var usage []output.QuotaUsage
+	if err := json.Unmarshal([]byte(buf.String()), &usage); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if len(usage) != 3 {
+		t.Fatalf("expected account emails plus two IMAP aliases, got %+v", usage)
+	}
+	for _, u := range usage {
+		if u.Breached != (u.Scope == "full@example.com") {
+			t.Errorf("unexpected breach state: %+v", u)
+		}
+	}
+	if text, _ := posted["text"].(string); !strings.Contains(text, "full@example.com storage at 90.0%") {
+		t.Errorf("unexpected webhook payload: %v", posted)
+	}
+}
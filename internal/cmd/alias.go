@@ -5,18 +5,28 @@ import (
 	"bufio"
 	"context"
 	"encoding/csv"
+	"errors"
 	"fmt"
+	"log/slog"
+	"net/mail"
+	"net/url"
 	"os"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"github.com/ginsys/forward-email/internal/client"
 	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/i18n"
 	"github.com/ginsys/forward-email/pkg/output"
+	"github.com/ginsys/forward-email/pkg/planner"
+	"github.com/ginsys/forward-email/pkg/schema"
+	"github.com/ginsys/forward-email/pkg/webhook"
 )
 
 // Global variables for alias command flags.
@@ -35,6 +45,9 @@ var (
 	aliasAllDomains bool   // Include aliases from all domains
 	aliasColumns    string // Custom column selection for output
 	aliasOrderBy    string // Alternative sort field specification
+	aliasOwner      string // Owner filter (matches the owner: label)
+	aliasTeam       string // Team filter (matches the team: label)
+	aliasWorkers    int    // Domains queried at once when listing several
 
 	// Create/Update operation flags
 	aliasRecipients   []string // Recipient email addresses or webhooks
@@ -47,32 +60,31 @@ var (
 	aliasPublicKey    string   // PGP public key for encryption
 	aliasImportFile   string
 	aliasExportFile   string
+	aliasExportFormat string
 	aliasImportDryRun bool
+	aliasImportAtomic bool
+	aliasImportResume string
+	aliasSyncResume   string
 	aliasSyncYes      bool
+	aliasInteractive  bool // Prompt for alias fields step by step
+	aliasUpdateDryRun bool
+	aliasUpdateFile   string
 )
 
-type syncAction struct {
-	typ        string
-	domain     string
-	aliasID    string
-	name       string
-	recipients []string
-	enabled    *bool
-	labels     []string
-}
-
 // aliasCmd represents the alias command
 var aliasCmd = &cobra.Command{
-	Use:   "alias",
-	Short: "Manage Forward Email aliases",
+	Use:     "alias",
+	Aliases: []string{"a"},
+	Short:   "Manage Forward Email aliases",
 	Long: `Manage Forward Email aliases including creating, listing, updating, 
 and configuring alias settings and recipients.`,
 }
 
 // aliasListCmd represents the alias list command
 var aliasListCmd = &cobra.Command{
-	Use:   "list [domain...]",
-	Short: "List aliases",
+	Use:     "list [domain...]",
+	Aliases: []string{"ls"},
+	Short:   "List aliases",
 	Long: `List aliases for one or more domains, or all available domains.
 
 Examples:
@@ -89,13 +101,17 @@ Examples:
 
 // aliasGetCmd represents the alias get command
 var aliasGetCmd = &cobra.Command{
-	Use:   "get [domain] <alias-id>",
+	Use:   "get [domain] <alias-id|name>",
 	Short: "Get alias details",
-	Long: `Get detailed information about a specific alias.
+	Long: `Get detailed information about a specific alias, including its full address,
+which recipients are webhooks and, for IMAP aliases, the server settings for a
+mail client.
 	
-You can specify the domain either as a positional argument or using the --domain flag:
+You can specify the domain either as a positional argument or using the --domain flag,
+or give the alias as a full address:
   forward-email alias get example.com alias123
-  forward-email alias get alias123 --domain example.com`,
+  forward-email alias get alias123 --domain example.com
+  forward-email alias get sales@example.com`,
 	Args: cobra.RangeArgs(1, 2),
 	RunE: runAliasGet,
 }
@@ -108,8 +124,11 @@ var aliasCreateCmd = &cobra.Command{
 	
 You can specify the domain either as a positional argument or using the --domain flag:
   forward-email alias create example.com sales --recipients sales@company.com
-  forward-email alias create sales --domain example.com --recipients sales@company.com`,
-	Args: cobra.RangeArgs(1, 2),
+  forward-email alias create sales --domain example.com --recipients sales@company.com
+
+Use --interactive to be prompted step by step for anything not given on the command line:
+  forward-email alias create --interactive`,
+	Args: cobra.RangeArgs(0, 2),
 	RunE: runAliasCreate,
 }
 
@@ -121,15 +140,21 @@ var aliasUpdateCmd = &cobra.Command{
 	
 You can specify the domain either as a positional argument or using the --domain flag:
   forward-email alias update example.com alias123 --enable
-  forward-email alias update alias123 --domain example.com --enable`,
+  forward-email alias update alias123 --domain example.com --enable
+
+Use -f to apply a partial spec from a YAML or JSON file (or - for stdin) with the
+fields recipients, labels, description, public_key, is_enabled, has_imap and
+has_pgp. Flags given alongside override the file:
+  forward-email alias update example.com sales -f patch.yaml --dry-run`,
 	Args: cobra.RangeArgs(1, 2),
 	RunE: runAliasUpdate,
 }
 
 // aliasDeleteCmd represents the alias delete command
 var aliasDeleteCmd = &cobra.Command{
-	Use:   "delete [domain] <alias-id>",
-	Short: "Delete an alias",
+	Use:     "delete [domain] <alias-id>",
+	Aliases: []string{"rm"},
+	Short:   "Delete an alias",
 	Long: `Delete an alias from a domain.
 	
 You can specify the domain either as a positional argument or using the --domain flag:
@@ -183,7 +208,12 @@ var aliasPasswordCmd = &cobra.Command{
 	Use:   "password [domain] <alias-id>",
 	Short: "Generate IMAP password",
 	Long: `Generate a new IMAP password for an alias.
-	
+
+By default the server generates the password. Use --length and/or --classes to
+generate one locally, or --password-stdin to supply your own. Local and supplied
+passwords must be 12-128 characters and mix at least 3 of lowercase, uppercase,
+digits and symbols (20+ character passphrases need only 2).
+
 You can specify the domain either as a positional argument or using the --domain flag:
   forward-email alias password example.com alias123
   forward-email alias password alias123 --domain example.com`,
@@ -199,8 +229,12 @@ var aliasQuotaCmd = &cobra.Command{
 	
 You can specify the domain either as a positional argument or using the --domain flag:
   forward-email alias quota example.com alias123
-  forward-email alias quota alias123 --domain example.com`,
-	Args: cobra.RangeArgs(1, 2),
+  forward-email alias quota alias123 --domain example.com
+
+With --all, show the quotas of every IMAP-enabled alias in a domain, fullest
+mailbox first; --over keeps only aliases whose storage use reaches a percentage:
+  forward-email alias quota --all example.com --over 90%`,
+	Args: cobra.RangeArgs(0, 2),
 	RunE: runAliasQuota,
 }
 
@@ -217,163 +251,46 @@ You can specify the domain either as a positional argument or using the --domain
 	RunE: runAliasStats,
 }
 
-// aliasImportCmd represents importing aliases from CSV
+// aliasImportCmd represents importing aliases from CSV, YAML or JSON
 var aliasImportCmd = &cobra.Command{
 	Use:   "import <domain> --file <path>",
-	Short: "Import aliases from CSV",
+	Short: "Import aliases from CSV, YAML or JSON",
 	Long: "Import aliases into a domain from a CSV file with columns: " +
 		"Name, Recipients (comma-separated), Enabled (true/false), " +
-		"Labels (comma-separated), Description.",
+		"Labels (comma-separated), Description, IMAP, PGP, Public Key, " +
+		"Vacation Enabled, Vacation Subject, Vacation Message, Vacation Start, Vacation End. " +
+		"Only Name and Recipients are required.\n\n" +
+		"Files ending in .yaml, .yml or .json hold an 'aliases' list instead " +
+		"(see 'forward-email schema print alias-import'). The whole file is " +
+		"validated before any alias is changed.",
 	Args: cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		domain := strings.TrimSpace(args[0])
-		if domain == "" {
-			return fmt.Errorf("domain is required")
-		}
-		if aliasImportFile == "" {
-			return fmt.Errorf("--file is required")
-		}
-
-		f, err := os.Open(aliasImportFile)
-		if err != nil {
-			return fmt.Errorf("failed to open CSV: %v", err)
-		}
-		defer func() { _ = f.Close() }()
-
-		r := csv.NewReader(f)
-		r.FieldsPerRecord = -1
-		rows, err := r.ReadAll()
-		if err != nil {
-			return fmt.Errorf("failed to read CSV: %v", err)
-		}
-		if len(rows) == 0 {
-			return fmt.Errorf("empty CSV")
-		}
-		// Map header
-		header := make(map[string]int)
-		for i, h := range rows[0] {
-			header[strings.ToLower(strings.TrimSpace(h))] = i
-		}
-		required := []string{"name", "recipients"}
-		for _, k := range required {
-			if _, ok := header[k]; !ok {
-				return fmt.Errorf("missing required column: %s", k)
-			}
-		}
-
-		ctx := context.Background()
-		apiClient, err := client.NewAPIClient()
-		if err != nil {
-			return fmt.Errorf("failed to create API client: %v", err)
-		}
-
-		// Fetch existing aliases to decide create/update
-		existing, err := listAllAliases(ctx, apiClient, domain)
-		if err != nil {
-			return fmt.Errorf("failed to list aliases for %s: %v", domain, err)
-		}
-		byName := mapAliasesByName(existing)
-
-		// Plan and process rows
-		type impAction struct{ typ, name string }
-		var impPlan []impAction
-		for _, row := range rows[1:] {
-			if len(row) == 0 {
-				continue
-			}
-			name := strings.TrimSpace(row[header["name"]])
-			if name == "" {
-				continue
-			}
-			var recStr string
-			if idx, ok := header["recipients"]; ok && idx < len(row) {
-				recStr = row[idx]
-			}
-			recipients := splitCSVList(recStr)
-			if len(recipients) == 0 {
-				return fmt.Errorf("alias %s: at least one recipient required", name)
-			}
-			var labels []string
-			if idx, ok := header["labels"]; ok && idx < len(row) {
-				labels = splitCSVList(row[idx])
-			}
-			var enabledPtr *bool
-			if idx, ok := header["enabled"]; ok && idx < len(row) {
-				s := strings.TrimSpace(strings.ToLower(row[idx]))
-				if s != "" {
-					v := s == "true" || s == "1" || s == "yes"
-					enabledPtr = &v
-				}
-			}
-			var descPtr *string
-			if idx, ok := header["description"]; ok && idx < len(row) {
-				d := strings.TrimSpace(row[idx])
-				if d != "" {
-					descPtr = &d
-				}
-			}
-
-			if ex, ok := byName[name]; ok {
-				// Update
-				req := &api.UpdateAliasRequest{Recipients: recipients, Labels: labels}
-				if enabledPtr != nil {
-					req.IsEnabled = enabledPtr
-				}
-				if descPtr != nil {
-					req.Description = descPtr
-				}
-				if aliasImportDryRun {
-					impPlan = append(impPlan, impAction{typ: "UPDATE", name: name})
-				} else {
-					if _, err := apiClient.Aliases.UpdateAlias(ctx, domain, ex.ID, req); err != nil {
-						return fmt.Errorf("update %s failed: %v", name, err)
-					}
-				}
-			} else {
-				// Create
-				req := &api.CreateAliasRequest{Name: name, Recipients: recipients, Labels: labels, IsEnabled: true}
-				if enabledPtr != nil {
-					req.IsEnabled = *enabledPtr
-				}
-				if descPtr != nil {
-					req.Description = *descPtr
-				}
-				if aliasImportDryRun {
-					impPlan = append(impPlan, impAction{typ: "CREATE", name: name})
-				} else {
-					if _, err := apiClient.Aliases.CreateAlias(ctx, domain, req); err != nil {
-						return fmt.Errorf("create %s failed: %v", name, err)
-					}
-				}
-			}
-		}
-		if aliasImportDryRun {
-			headers := []string{"ACTION", "ALIAS"}
-			tbl := output.NewTableData(headers)
-			for _, a := range impPlan {
-				tbl.AddRow([]string{a.typ, a.name})
-			}
-			formatter := output.NewFormatter(output.FormatTable, cmd.OutOrStdout())
-			return formatter.Format(tbl)
-		}
-		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Imported aliases into %s from %s\n", domain, aliasImportFile)
-		return nil
-	},
+	RunE: runAliasImport,
 }
 
 // aliasExportCmd represents exporting aliases to CSV
 var aliasExportCmd = &cobra.Command{
 	Use:   "export <domain> --file <path>",
-	Short: "Export aliases to CSV",
-	Long: "Export aliases from a domain to a CSV file with columns: " +
-		"Name, Recipients (comma-separated), Enabled, Labels (comma-separated), Description.",
+	Short: "Export aliases to CSV, YAML, JSON or an address book",
+	Long: "Export every setting of a domain's aliases so that 'alias import' can restore them. " +
+		"Files ending in .yaml, .yml or .json use the import file format; others are CSV " +
+		"with the import columns and a Format Version column.\n\n" +
+		"--format vcf|muttrc|aliases writes the enabled aliases as address book entries instead " +
+		"(vCard, mutt aliases or mailx aliases), with the description as the contact name, " +
+		"to --file or to stdout. Files ending in .vcf choose vcf on their own.",
+	Example: `  forward-email alias export example.com --file aliases.yaml
+  forward-email alias export example.com --format vcf > aliases.vcf
+  forward-email alias export example.com --format muttrc >> ~/.muttrc`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		domain := strings.TrimSpace(args[0])
 		if domain == "" {
 			return fmt.Errorf("domain is required")
 		}
-		if aliasExportFile == "" {
+		addressBook, err := aliasExportAddressBook()
+		if err != nil {
+			return err
+		}
+		if aliasExportFile == "" && addressBook == "" {
 			return fmt.Errorf("--file is required")
 		}
 
@@ -382,15 +299,22 @@ var aliasExportCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("failed to create API client: %v", err)
 		}
+		stop := startSpinner(cmd, "Fetching aliases from "+domain)
 		aliases, err := listAllAliases(ctx, apiClient, domain)
+		stop()
 		if err != nil {
 			return fmt.Errorf("failed to list aliases for %s: %v", domain, err)
 		}
+		// The API order carries no meaning; sorting keeps consecutive exports comparable
+		sort.SliceStable(aliases, func(i, j int) bool { return aliases[i].Name < aliases[j].Name })
 
-		if err := writeAliasesCSV(aliasExportFile, aliases); err != nil {
-			return fmt.Errorf("failed to write CSV: %v", err)
+		if addressBook != "" {
+			return writeAddressBookExport(cmd, domain, addressBook, aliases)
+		}
+		if err := writeAliasesFile(aliasExportFile, aliases); err != nil {
+			return fmt.Errorf("failed to write export file: %v", err)
 		}
-		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Exported %d aliases from %s to %s\n", len(aliases), domain, aliasExportFile)
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Exported %d aliases from %s to %s\n", len(aliases), domain, aliasExportFile)
 		return nil
 	},
 }
@@ -409,7 +333,17 @@ Modes:
 Examples:
   forward-email alias sync example.com target.com --mode merge --dry-run
   forward-email alias sync example.com target.com --mode replace
-  forward-email alias sync example.com target.com --mode preserve --conflicts
+  forward-email alias sync example.com target.com --mode preserve --conflicts interactive
+
+Conflicts, aliases that differ between the domains, are resolved with
+--conflicts overwrite|skip|merge. Without it each conflict is shown field by
+field and you choose: o(verwrite), s(kip), m(erge), a to apply your last
+choice to all remaining conflicts, or q to quit before anything is changed.
+--conflicts interactive prompts even with --dry-run.
+
+An overwrite copies the source alias over the target one; --prefer target
+keeps the target's instead and --prefer newest the one updated last. In merge
+mode the losing side is updated, so each alias changes once.
 `,
 	Args: cobra.ExactArgs(2),
 	RunE: runAliasSync,
@@ -418,7 +352,8 @@ Examples:
 var (
 	aliasSyncMode     string
 	aliasSyncDryRun   bool
-	aliasSyncStrategy string // overwrite|skip|merge
+	aliasSyncStrategy string // overwrite|skip|merge|interactive
+	aliasSyncPrefer   string // source|target|newest
 )
 
 func init() {
@@ -444,13 +379,21 @@ func init() {
 	aliasCmd.AddCommand(aliasSyncCmd)
 	aliasSyncCmd.Flags().StringVar(&aliasSyncMode, "mode", "merge", "Sync mode: merge|replace|preserve")
 	aliasSyncCmd.Flags().BoolVar(&aliasSyncDryRun, "dry-run", false, "Show planned changes without applying")
-	aliasSyncCmd.Flags().StringVar(&aliasSyncStrategy, "conflicts", "", "Conflict strategy: overwrite|skip|merge")
+	aliasSyncCmd.Flags().StringVar(&aliasSyncStrategy, "conflicts", "", "Conflict strategy: overwrite|skip|merge|interactive")
+	aliasSyncCmd.Flags().StringVar(&aliasSyncPrefer, "prefer", planner.PreferSource, "Side whose alias wins an overwrite: source|target|newest")
 	aliasSyncCmd.Flags().BoolVar(&aliasSyncYes, "yes", false, "Do not prompt; apply --conflicts strategy to all")
+	aliasSyncCmd.Flags().StringVar(&aliasSyncResume, "resume", "", "Resume an interrupted sync from its journal file")
 
 	// CSV flags
-	aliasImportCmd.Flags().StringVar(&aliasImportFile, "file", "", "Path to input CSV file")
+	aliasImportCmd.Flags().StringVar(&aliasImportFile, "file", "", "Path to input CSV, YAML or JSON file")
 	aliasImportCmd.Flags().BoolVar(&aliasImportDryRun, "dry-run", false, "Preview import without applying changes")
-	aliasExportCmd.Flags().StringVar(&aliasExportFile, "file", "", "Path to output CSV file")
+	aliasImportCmd.Flags().BoolVar(&aliasImportAtomic, "atomic", false,
+		"Roll back every change made by the import if any alias fails")
+	aliasImportCmd.Flags().StringVar(&aliasImportResume, "resume", "", "Resume an interrupted import from its journal file")
+	aliasImportCmd.MarkFlagsMutuallyExclusive("atomic", "resume")
+	aliasExportCmd.Flags().StringVar(&aliasExportFile, "file", "", "Path to output CSV, YAML or JSON file")
+	aliasExportCmd.Flags().StringVar(&aliasExportFormat, "format", "",
+		"Address book format: "+strings.Join(output.AddressBookFormats, ", ")+" (default: the import format chosen by --file)")
 
 	// Global flags (output inherited from root command)
 	aliasCmd.PersistentFlags().StringVarP(&aliasDomain, "domain", "d", "",
@@ -459,7 +402,7 @@ func init() {
 	// List command flags
 	aliasListCmd.Flags().IntVar(&aliasPage, "page", 1, "Page number")
 	aliasListCmd.Flags().IntVar(&aliasLimit, "limit", 25, "Number of aliases per page")
-	aliasListCmd.Flags().StringVar(&aliasSort, "sort", "name", "Sort by (name, created, updated)")
+	aliasListCmd.Flags().StringVar(&aliasSort, "sort", "name", "Sort by ("+strings.Join(aliasSortFields, ", ")+")")
 	aliasListCmd.Flags().StringVar(&aliasOrder, "order", "asc", "Sort order (asc, desc)")
 	aliasListCmd.Flags().StringVar(&aliasSearch, "search", "", "Search alias names")
 	aliasListCmd.Flags().StringVar(&aliasEnabled, "enabled", "", "Filter by enabled status (true/false)")
@@ -470,15 +413,19 @@ func init() {
 		"Specify columns to display (name,domain,recipients,enabled,imap,labels,created)")
 	aliasListCmd.Flags().StringVar(&aliasOrderBy, "order-by", "",
 		"Sort by columns (e.g., 'domain,name' or 'enabled:desc,created:asc')")
+	aliasListCmd.Flags().StringVar(&aliasOwner, "owner", "", "Filter by owner (see 'alias owner set')")
+	aliasListCmd.Flags().StringVar(&aliasTeam, "team", "", "Filter by team (see 'alias owner set')")
+	aliasListCmd.Flags().IntVar(&aliasWorkers, "concurrency", 4, "Number of domains to query at once")
 
 	// Create command flags
-	aliasCreateCmd.Flags().StringSliceVar(&aliasRecipients, "recipients", nil, "Recipient email addresses")
+	aliasRecipientListVar(aliasCreateCmd.Flags(), &aliasRecipients, "recipients", "Recipient email addresses")
 	aliasCreateCmd.Flags().StringSliceVar(&aliasLabelsFlag, "labels", nil, "Labels for the alias")
 	aliasCreateCmd.Flags().StringVar(&aliasDescription, "description", "", "Description for the alias")
 	aliasCreateCmd.Flags().BoolVar(&aliasEnableFlag, "enabled", true, "Enable the alias")
 	aliasCreateCmd.Flags().BoolVar(&aliasIMAPFlag, "imap", false, "Enable IMAP access")
 	aliasCreateCmd.Flags().BoolVar(&aliasPGPFlag, "pgp", false, "Enable PGP encryption")
 	aliasCreateCmd.Flags().StringVar(&aliasPublicKey, "public-key", "", "PGP public key")
+	aliasCreateCmd.Flags().BoolVarP(&aliasInteractive, "interactive", "i", false, "Prompt for alias settings step by step")
 	// Validation is handled in runAliasCreate to produce clear error messages
 	aliasCreateCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
 		if strings.Contains(err.Error(), "required flag(s) \"recipients\"") {
@@ -488,7 +435,7 @@ func init() {
 	})
 
 	// Update command flags
-	aliasUpdateCmd.Flags().StringSliceVar(&aliasRecipients, "recipients", nil, "Update recipient email addresses")
+	aliasRecipientListVar(aliasUpdateCmd.Flags(), &aliasRecipients, "recipients", "Update recipient email addresses")
 	aliasUpdateCmd.Flags().StringSliceVar(&aliasLabelsFlag, "labels", nil, "Update labels for the alias")
 	aliasUpdateCmd.Flags().StringVar(&aliasDescription, "description", "", "Update description for the alias")
 	aliasUpdateCmd.Flags().BoolVar(&aliasEnableFlag, "enable", false, "Enable the alias")
@@ -496,9 +443,17 @@ func init() {
 	aliasUpdateCmd.Flags().BoolVar(&aliasIMAPFlag, "imap", false, "Enable IMAP access")
 	aliasUpdateCmd.Flags().BoolVar(&aliasPGPFlag, "pgp", false, "Enable PGP encryption")
 	aliasUpdateCmd.Flags().StringVar(&aliasPublicKey, "public-key", "", "Update PGP public key")
+	aliasUpdateCmd.Flags().BoolVar(&aliasUpdateDryRun, "dry-run", false, "Show the changes without applying them")
+	aliasUpdateCmd.Flags().StringVarP(&aliasUpdateFile, "file", "f", "", "Apply a partial alias spec from a YAML/JSON file (- for stdin)")
+
+	// Delete command flags
+	aliasDeleteCmd.Flags().BoolP("force", "f", false, "Delete without confirmation")
+
+	// Password command flags
+	addSecretFlags(aliasPasswordCmd, "password")
 
 	// Recipients command flags
-	aliasRecipientsCmd.Flags().StringSliceVar(&aliasRecipients, "recipients", nil, "New recipient email addresses")
+	aliasRecipientListVar(aliasRecipientsCmd.Flags(), &aliasRecipients, "recipients", "New recipient email addresses")
 	// Validation is handled in runAliasRecipients to produce clear error messages
 	aliasRecipientsCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
 		if strings.Contains(err.Error(), "required flag(s) \"recipients\"") {
@@ -530,293 +485,223 @@ func runAliasSync(cmd *cobra.Command, args []string) error {
 	}
 	if aliasSyncStrategy != "" {
 		s := strings.ToLower(aliasSyncStrategy)
-		if s != "overwrite" && s != "skip" && s != "merge" {
-			return fmt.Errorf("invalid --conflicts strategy: %s (valid: overwrite|skip|merge)", aliasSyncStrategy)
+		if s != "overwrite" && s != "skip" && s != "merge" && s != conflictInteractive {
+			return fmt.Errorf("invalid --conflicts strategy: %s (valid: overwrite|skip|merge|interactive)", aliasSyncStrategy)
+		}
+		if s == conflictInteractive && aliasSyncYes {
+			return fmt.Errorf("--conflicts interactive cannot be used with --yes")
 		}
 	}
+	switch strings.ToLower(aliasSyncPrefer) {
+	case planner.PreferSource, planner.PreferTarget, planner.PreferNewest:
+	default:
+		return fmt.Errorf("invalid --prefer: %s (valid: source|target|newest)", aliasSyncPrefer)
+	}
 
-	ctx := context.Background()
+	ctx := cmd.Context()
 	apiClient, err := client.NewAPIClient()
 	if err != nil {
 		return fmt.Errorf("failed to create API client: %v", err)
 	}
 
 	// Fetch aliases for both domains
+	stop := startSpinner(cmd, "Fetching aliases from "+src+" and "+dst)
 	srcAliases, err := listAllAliases(ctx, apiClient, src)
 	if err != nil {
+		stop()
 		return fmt.Errorf("failed to list aliases for %s: %v", src, err)
 	}
 	dstAliases, err := listAllAliases(ctx, apiClient, dst)
+	stop()
 	if err != nil {
 		return fmt.Errorf("failed to list aliases for %s: %v", dst, err)
 	}
 
-	// Index by name
-	srcByName := mapAliasesByName(srcAliases)
-	dstByName := mapAliasesByName(dstAliases)
-
-	// Build plan
-	var plan []syncAction
-
-	addCreate := func(domain, name string, a api.Alias) {
-		plan = append(plan, syncAction{
-			typ:        "create",
-			domain:     domain,
-			name:       name,
-			recipients: a.Recipients,
-			enabled:    &a.IsEnabled,
-			labels:     a.Labels,
-		})
-	}
-	addUpdate := func(domain string, id string, name string, desired api.Alias) {
-		plan = append(plan, syncAction{
-			typ:        "update",
-			domain:     domain,
-			aliasID:    id,
-			name:       name,
-			recipients: desired.Recipients,
-			enabled:    &desired.IsEnabled,
-			labels:     desired.Labels,
-		})
-	}
-	addDelete := func(domain, id, name string) {
-		plan = append(plan, syncAction{typ: "delete", domain: domain, aliasID: id, name: name})
+	plan, err := planAliasSync(cmd, mode, src, dst, srcAliases, dstAliases)
+	if errors.Is(err, errSyncAborted) {
+		cmd.PrintErrln("Alias sync aborted; nothing was changed")
+		return nil
 	}
-
-	switch mode {
-	case "merge":
-		// union of names
-		names := make(map[string]struct{})
-		for n := range srcByName {
-			names[n] = struct{}{}
-		}
-		for n := range dstByName {
-			names[n] = struct{}{}
-		}
-		for name := range names {
-			s, sOK := srcByName[name]
-			d, dOK := dstByName[name]
-			switch {
-			case sOK && !dOK:
-				addCreate(dst, name, s)
-			case !sOK && dOK:
-				addCreate(src, name, d)
-			case sOK && dOK:
-				// conflict: compare recipients/flags
-				diff := !equalStringSets(s.Recipients, d.Recipients) ||
-					s.IsEnabled != d.IsEnabled ||
-					!equalStringSets(s.Labels, d.Labels)
-				if diff {
-					strategy := strings.ToLower(aliasSyncStrategy)
-					if strategy == "" && !aliasSyncDryRun && !aliasSyncYes {
-						sChosen, applyAll, perr := promptConflict(cmd, name, s, d)
-						if perr != nil {
-							return perr
-						}
-						strategy = sChosen
-						if applyAll {
-							aliasSyncStrategy = strategy
-						}
-					}
-					switch strategy {
-					case "overwrite":
-						addUpdate(dst, d.ID, d.Name, s)
-						addUpdate(src, s.ID, s.Name, d) // keep symmetric? For merge, prefer source? We'll merge both ways below
-					case "skip":
-						// do nothing
-					case "merge":
-						merged := mergeRecipients(s.Recipients, d.Recipients)
-						sDesired := s
-						sDesired.Recipients = merged
-						dDesired := d
-						dDesired.Recipients = merged
-						addUpdate(src, s.ID, s.Name, sDesired)
-						addUpdate(dst, d.ID, d.Name, dDesired)
-					default:
-						// default to merge behavior for merge mode when unspecified
-						merged := mergeRecipients(s.Recipients, d.Recipients)
-						sDesired := s
-						sDesired.Recipients = merged
-						dDesired := d
-						dDesired.Recipients = merged
-						addUpdate(src, s.ID, s.Name, sDesired)
-						addUpdate(dst, d.ID, d.Name, dDesired)
-					}
-				}
-			}
-		}
-	case "replace", "preserve":
-		// one-way from src to dst
-		for name, s := range srcByName {
-			if d, ok := dstByName[name]; !ok {
-				addCreate(dst, name, s)
-			} else {
-				// exists: update if different per strategy
-				diff := !equalStringSets(s.Recipients, d.Recipients) ||
-					s.IsEnabled != d.IsEnabled ||
-					!equalStringSets(s.Labels, d.Labels)
-				if diff {
-					strategy := strings.ToLower(aliasSyncStrategy)
-					if strategy == "" && !aliasSyncDryRun && !aliasSyncYes {
-						sChosen, applyAll, perr := promptConflict(cmd, name, s, d)
-						if perr != nil {
-							return perr
-						}
-						strategy = sChosen
-						if applyAll {
-							aliasSyncStrategy = strategy
-						}
-					}
-					switch strategy {
-					case "overwrite":
-						addUpdate(dst, d.ID, d.Name, s)
-					case "merge":
-						desired := d
-						desired.Recipients = mergeRecipients(s.Recipients, d.Recipients)
-						addUpdate(dst, d.ID, d.Name, desired)
-					case "skip":
-						// no-op
-					default:
-						// default to overwrite in one-way
-						addUpdate(dst, d.ID, d.Name, s)
-					}
-				}
-			}
-		}
-		if mode == "replace" {
-			for name, d := range dstByName {
-				if _, ok := srcByName[name]; !ok {
-					addDelete(dst, d.ID, d.Name)
-				}
-			}
-		}
+	if err != nil {
+		return err
 	}
 
 	if aliasSyncDryRun {
 		return printSyncPlan(cmd, src, dst, plan)
 	}
 
-	// Execute plan
-	for _, a := range plan {
-		switch a.typ {
-		case "create":
-			req := &api.CreateAliasRequest{Recipients: a.recipients, Labels: a.labels, Name: a.name, IsEnabled: true}
-			if a.enabled != nil {
-				req.IsEnabled = *a.enabled
-			}
-			if _, err := apiClient.Aliases.CreateAlias(ctx, a.domain, req); err != nil {
-				return fmt.Errorf("create %s@%s failed: %v", a.name, a.domain, err)
-			}
-		case "update":
-			req := &api.UpdateAliasRequest{Recipients: a.recipients}
-			if a.enabled != nil {
-				req.IsEnabled = a.enabled
-			}
-			if len(a.labels) > 0 {
-				req.Labels = a.labels
-			}
-			if _, err := apiClient.Aliases.UpdateAlias(ctx, a.domain, a.aliasID, req); err != nil {
-				return fmt.Errorf("update %s in %s failed: %v", a.aliasID, a.domain, err)
-			}
-		case "delete":
-			if err := apiClient.Aliases.DeleteAlias(ctx, a.domain, a.aliasID); err != nil {
-				return fmt.Errorf("delete %s in %s failed: %v", a.aliasID, a.domain, err)
-			}
-		}
+	journal, err := startJournal("sync", src+" -> "+dst, aliasSyncResume)
+	if err != nil {
+		return err
+	}
+	prog := newProgress(cmd, "Syncing", plan.Len())
+	err = applySyncPlan(ctx, apiClient, plan, journal, prog)
+	prog.Finish()
+	journal.Finish(cmd.ErrOrStderr(), err)
+	if err != nil {
+		return err
 	}
 
 	_, _ = fmt.Fprintf(
-		cmd.OutOrStdout(),
+		cmd.ErrOrStderr(),
 		"Alias sync completed: %s -> %s (mode=%s, actions=%d)\n",
-		src, dst, mode, len(plan),
+		src, dst, mode, plan.Len(),
 	)
 	return nil
 }
 
-func listAllAliases(ctx context.Context, c *api.Client, domain string) ([]api.Alias, error) {
-	// Fetch single page large enough for most cases; could loop if needed
-	resp, err := c.Aliases.ListAliases(ctx, &api.ListAliasesOptions{Domain: domain, Limit: 1000})
-	if err != nil {
-		return nil, err
-	}
-	return resp.Aliases, nil
+// planAliasSync computes the actions needed to bring the target domain in line
+// with the source according to mode. Conflicts are resolved with the --conflicts
+// strategy, prompting interactively when none was given, and --prefer picks the
+// side that wins an overwrite.
+func planAliasSync(cmd *cobra.Command, mode, src, dst string, srcAliases, dstAliases []api.Alias) (planner.Plan, error) {
+	prefer := strings.ToLower(strings.TrimSpace(aliasSyncPrefer))
+	conflicts := newConflictResolver(cmd, aliasSyncStrategy, prefer, aliasSyncDryRun, aliasSyncYes)
+	return planner.PlanSync(srcAliases, dstAliases, planner.SyncOptions{
+		Mode:    mode,
+		Source:  src,
+		Target:  dst,
+		Prefer:  prefer,
+		Resolve: conflicts.resolve,
+	})
 }
 
-func mapAliasesByName(list []api.Alias) map[string]api.Alias {
-	m := make(map[string]api.Alias, len(list))
-	for _, a := range list {
-		m[a.Name] = a
-	}
-	return m
+// applySyncPlan executes the planned sync actions in order, stopping at the first failure.
+// Actions on aliases recorded in journal by an earlier run are skipped, and each completed
+// action is recorded. Each action is shown on prog.
+func applySyncPlan(ctx context.Context, apiClient *api.Client, plan planner.Plan, journal *opJournal, prog *progress) error {
+	item := func(a planner.Action) string { return a.Domain + "/" + a.Name }
+	return planner.Apply(ctx, plan, planner.APIExecutor{Aliases: apiClient.Aliases}, planner.ApplyHooks{
+		Skip: func(a planner.Action) bool {
+			prog.Item(string(a.Type) + " " + a.Name + "@" + a.Domain)
+			return journal.Done(item(a))
+		},
+		Done: func(a planner.Action, id string) error {
+			reportMutation(actionResource(a, id))
+			return journal.Record(item(a))
+		},
+	})
 }
 
-func equalStringSets(a, b []string) bool {
-	if len(a) != len(b) {
-		return false
+// listAllAliases fetches every alias of domain, following the API's next page
+// by cursor or page number. Pages are large enough that most domains need one.
+func listAllAliases(ctx context.Context, c *api.Client, domain string) ([]api.Alias, error) {
+	opts := &api.ListAliasesOptions{Domain: domain, Page: 1, Limit: 1000}
+	var all []api.Alias
+	for {
+		resp, err := c.Aliases.ListAliases(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, resp.Aliases...)
+		p := resp.Pagination
+		switch {
+		case p.NextCursor != "" && p.NextCursor != opts.Cursor:
+			opts.Cursor = p.NextCursor
+		case opts.Cursor == "" && p.HasNext && len(resp.Aliases) > 0:
+			opts.Page = p.Page + 1
+		default:
+			return all, nil
+		}
 	}
-	ma := make(map[string]int, len(a))
-	for _, s := range a {
-		ma[strings.ToLower(strings.TrimSpace(s))]++
+}
+
+// resolveAliasDomains returns the domains named by args (comma-separated
+// values allowed), the --domain flag, every domain when allDomains is set, or
+// else the profile's default domain.
+func resolveAliasDomains(ctx context.Context, cmd *cobra.Command, c *api.Client, args []string, allDomains bool) ([]string, error) {
+	if allDomains && (len(args) > 0 || aliasDomain != "") {
+		return nil, fmt.Errorf("cannot use --all-domains with domain arguments or --domain flag")
 	}
-	for _, s := range b {
-		k := strings.ToLower(strings.TrimSpace(s))
-		if ma[k] == 0 {
-			return false
+
+	var domains []string
+	switch {
+	case allDomains:
+		list, err := c.Domains.ListDomains(ctx, &api.ListDomainsOptions{Page: 1, Limit: 1000})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch domains: %v", err)
 		}
-		ma[k]--
-		if ma[k] < 0 {
-			return false
+		for _, d := range list.Domains {
+			domains = append(domains, d.Name)
 		}
-	}
-	for _, v := range ma {
-		if v != 0 {
-			return false
+	case len(args) > 0:
+		for _, a := range args {
+			domains = append(domains, splitCSVList(a)...)
 		}
+	case aliasDomain != "":
+		domains = splitCSVList(aliasDomain)
+	default:
+		domain := withDefaultDomain(cmd, "")
+		if domain == "" {
+			return nil, fmt.Errorf("domain is required - specify as argument, use --domain flag, or use --all-domains")
+		}
+		domains = []string{domain}
 	}
-	return true
+	return domains, nil
 }
 
-func mergeRecipients(a, b []string) []string {
-	m := make(map[string]struct{}, len(a)+len(b))
-	for _, s := range a {
-		m[strings.ToLower(strings.TrimSpace(s))] = struct{}{}
-	}
-	for _, s := range b {
-		m[strings.ToLower(strings.TrimSpace(s))] = struct{}{}
-	}
-	out := make([]string, 0, len(m))
-	for k := range m {
-		out = append(out, k)
+func mapAliasesByName(list []api.Alias) map[string]api.Alias {
+	m := make(map[string]api.Alias, len(list))
+	for _, a := range list {
+		m[a.Name] = a
 	}
-	sort.Strings(out)
-	return out
+	return m
 }
 
-func printSyncPlan(cmd *cobra.Command, src, dst string, plan []syncAction) error {
+func printSyncPlan(cmd *cobra.Command, src, dst string, plan planner.Plan) error {
 	headers := []string{"ACTION", "DOMAIN", "ALIAS", "DETAILS"}
 	tbl := output.NewTableData(headers)
-	for _, a := range plan {
-		alias := a.name
+	for _, a := range plan.Actions {
+		alias := a.Name
 		if alias == "" {
-			alias = a.aliasID
+			alias = a.AliasID
 		}
 		details := ""
-		switch a.typ {
-		case "create":
-			details = fmt.Sprintf("recipients=%v enabled=%v", a.recipients, derefBool(a.enabled))
-		case "update":
-			details = fmt.Sprintf("recipients=%v enabled=%v", a.recipients, derefBool(a.enabled))
-		case "delete":
+		switch a.Type {
+		case planner.ActionCreate:
+			details = fmt.Sprintf("recipients=%v enabled=%v", a.Recipients, derefBool(a.Enabled))
+		case planner.ActionUpdate:
+			details = fmt.Sprintf("recipients=%v enabled=%v", a.Recipients, derefBool(a.Enabled))
+		case planner.ActionDelete:
 			details = "remove alias"
 		}
-		tbl.AddRow([]string{strings.ToUpper(a.typ), a.domain, alias, details})
+		tbl.AddRow([]string{strings.ToUpper(string(a.Type)), a.Domain, alias, details})
+	}
+	_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "DRY RUN: Alias Sync Plan (%s -> %s, actions=%d)\n", src, dst, plan.Len())
+	_, _ = fmt.Fprintln(cmd.ErrOrStderr())
+	if viper.GetString("output") == string(output.FormatGHA) {
+		return printSyncPlanGHA(cmd, src, dst, plan, tbl)
 	}
-	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "DRY RUN: Alias Sync Plan (%s -> %s, actions=%d)\n", src, dst, len(plan))
-	_, _ = fmt.Fprintln(cmd.OutOrStdout())
 	formatter := output.NewFormatter(output.FormatTable, cmd.OutOrStdout())
 	return formatter.Format(tbl)
 }
 
+// printSyncPlanGHA prints the plan for GitHub Actions: the table in the step
+// summary, a warning annotation per deletion and a notice per other change.
+func printSyncPlanGHA(cmd *cobra.Command, src, dst string, plan planner.Plan, tbl *output.TableData) error {
+	if err := startGHASummary(fmt.Sprintf("Alias sync plan: %s → %s", src, dst)); err != nil {
+		return err
+	}
+	if err := output.NewFormatter(output.FormatGHA, cmd.OutOrStdout()).Format(tbl); err != nil {
+		return err
+	}
+	annotations := make([]output.Annotation, 0, plan.Len())
+	for _, a := range plan.Actions {
+		level := output.AnnotationNotice
+		if a.Type == planner.ActionDelete {
+			level = output.AnnotationWarning
+		}
+		name := a.Name
+		if name == "" {
+			name = a.AliasID
+		}
+		annotations = append(annotations, output.Annotation{
+			Level: level, Title: "Alias sync", Message: fmt.Sprintf("%s %s@%s", a.Type, name, a.Domain),
+		})
+	}
+	return finishGHASummary(cmd, fmt.Sprintf("%d planned action(s); nothing was changed (dry run).", plan.Len()), annotations)
+}
+
 func derefBool(p *bool) bool {
 	if p == nil {
 		return false
@@ -832,13 +717,24 @@ func writeAliasesCSV(path string, aliases []api.Alias) error {
 	defer func() { _ = f.Close() }()
 	w := csv.NewWriter(f)
 	defer w.Flush()
-	if err := w.Write([]string{"Name", "Recipients", "Enabled", "Labels", "Description"}); err != nil {
+	if err := w.Write(aliasCSVHeader); err != nil {
 		return err
 	}
 	for _, a := range aliases {
 		rec := strings.Join(a.Recipients, ",")
 		lab := strings.Join(a.Labels, ",")
-		if err := w.Write([]string{a.Name, rec, fmt.Sprintf("%v", a.IsEnabled), lab, a.Description}); err != nil {
+		record := []string{
+			a.Name, rec, fmt.Sprintf("%v", a.IsEnabled), lab, a.Description,
+			fmt.Sprintf("%v", a.HasIMAP), fmt.Sprintf("%v", a.HasPGP), a.PublicKey,
+		}
+		if v := a.Vacation; v != nil {
+			record = append(record, fmt.Sprintf("%v", v.IsEnabled), v.Subject, v.Message,
+				formatVacationDate(v.StartDate), formatVacationDate(v.EndDate))
+		} else {
+			record = append(record, "", "", "", "", "")
+		}
+		record = append(record, strconv.Itoa(aliasFileVersion))
+		if err := w.Write(record); err != nil {
 			return err
 		}
 	}
@@ -861,41 +757,11 @@ func splitCSVList(s string) []string {
 	return out
 }
 
-// promptConflict interactively asks the user how to resolve a conflict.
-// Returns strategy (overwrite|skip|merge) and whether to apply to all.
-func promptConflict(cmd *cobra.Command, alias string, src, dst api.Alias) (strategy string, applyToAll bool, err error) {
-	r := bufio.NewReader(cmd.InOrStdin())
-	out := cmd.OutOrStdout()
-	_, _ = fmt.Fprintf(out, "Conflict for alias '%s':\n", alias)
-	_, _ = fmt.Fprintf(out, "  source → %v\n", src.Recipients)
-	_, _ = fmt.Fprintf(out, "  target → %v\n", dst.Recipients)
-	_, _ = fmt.Fprintln(out, "Choose: [o] overwrite target, [s] skip, [m] merge, [a] apply to all (with last choice)")
-	for {
-		_, _ = fmt.Fprint(out, "Choice [o/s/m/a]: ")
-		line, err := r.ReadString('\n')
-		if err != nil {
-			return "", false, err
-		}
-		c := strings.ToLower(strings.TrimSpace(line))
-		switch c {
-		case "o":
-			return "overwrite", false, nil
-		case "s":
-			return "skip", false, nil
-		case "m":
-			return "merge", false, nil
-		case "a":
-			// apply-all uses last non-empty choice; default to merge
-			return "merge", true, nil
-		}
-	}
-}
-
 // formatAliasListMultiDomain formats aliases from multiple domains with proper domain resolution
 func formatAliasListMultiDomain(
 	aliases []api.Alias, format output.Format, domainMap map[string]string,
 ) (*output.TableData, error) {
-	if format != output.FormatTable && format != output.FormatCSV {
+	if !format.Tabular() {
 		return nil, fmt.Errorf("use direct JSON/YAML encoding for aliases")
 	}
 
@@ -916,7 +782,7 @@ func formatAliasListMultiDomain(
 		if alias.CreatedAt.IsZero() {
 			created = "-"
 		} else {
-			created = alias.CreatedAt.Format("2006-01-02")
+			created = output.FormatTime(alias.CreatedAt, "2006-01-02")
 		}
 
 		// Resolve domain ID to domain name using the mapping
@@ -944,7 +810,7 @@ func formatAliasListMultiDomain(
 func formatAliasListWithCustomColumns(
 	aliases []api.Alias, format output.Format, domainMap map[string]string, columnsStr string,
 ) (*output.TableData, error) {
-	if format != output.FormatTable && format != output.FormatCSV {
+	if !format.Tabular() {
 		return nil, fmt.Errorf("use direct JSON/YAML encoding for aliases")
 	}
 
@@ -963,7 +829,8 @@ func formatAliasListWithCustomColumns(
 
 	for _, col := range columnNames {
 		if !validColumns[col] {
-			return nil, fmt.Errorf("invalid column '%s'. Valid columns: %s", col, validColumnsList)
+			return nil, fmt.Errorf("invalid column '%s'%s Valid columns: %s",
+				strings.ToLower(col), didYouMean(col, strings.Split(validColumnsList, ", ")), validColumnsList)
 		}
 	}
 
@@ -996,13 +863,13 @@ func formatAliasListWithCustomColumns(
 				if alias.CreatedAt.IsZero() {
 					row[i] = "-"
 				} else {
-					row[i] = alias.CreatedAt.Format("2006-01-02")
+					row[i] = output.FormatTime(alias.CreatedAt, "2006-01-02")
 				}
 			case "UPDATED":
 				if alias.UpdatedAt.IsZero() {
 					row[i] = "-"
 				} else {
-					row[i] = alias.UpdatedAt.Format("2006-01-02")
+					row[i] = output.FormatTime(alias.UpdatedAt, "2006-01-02")
 				}
 			case "DESCRIPTION":
 				row[i] = alias.Description
@@ -1055,14 +922,42 @@ type sortCriterion struct {
 	descending bool
 }
 
+// aliasOrderByFields are the fields accepted by 'alias list --order-by'.
+var aliasOrderByFields = []string{"name", "domain", "enabled", "imap", "created", "updated", "recipients", "labels"}
+
+// aliasSortFields are the server-side sort fields accepted by 'alias list --sort'.
+var aliasSortFields = []string{"name", "created", "updated"}
+
+// validateAliasListFlags checks --sort, --order-by and --columns before any
+// API call, so a typo is reported without waiting for the listing.
+func validateAliasListFlags() error {
+	if err := validateSortField(aliasSort, aliasSortFields); err != nil {
+		return err
+	}
+	if aliasOrderBy != "" {
+		if _, err := parseSortCriteria(aliasOrderBy); err != nil {
+			return err
+		}
+	}
+	if aliasColumns != "" {
+		if _, err := formatAliasListWithCustomColumns(nil, output.FormatTable, nil, aliasColumns); err != nil {
+			return err
+		}
+	}
+	if aliasWorkers < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+	return nil
+}
+
 // parseSortCriteria parses the order-by string into sort criteria
 func parseSortCriteria(orderBy string) ([]sortCriterion, error) {
 	parts := strings.Split(orderBy, ",")
 	criteria := make([]sortCriterion, len(parts))
 
-	validFields := map[string]bool{
-		"name": true, "domain": true, "enabled": true, "imap": true,
-		"created": true, "updated": true, "recipients": true, "labels": true,
+	validFields := make(map[string]bool, len(aliasOrderByFields))
+	for _, f := range aliasOrderByFields {
+		validFields[f] = true
 	}
 
 	for i, part := range parts {
@@ -1088,8 +983,8 @@ func parseSortCriteria(orderBy string) ([]sortCriterion, error) {
 
 		fieldName = strings.ToLower(fieldName)
 		if !validFields[fieldName] {
-			validFieldsList := "name, domain, enabled, imap, created, updated, recipients, labels"
-			return nil, fmt.Errorf("invalid sort field '%s'. Valid fields: %s", fieldName, validFieldsList)
+			return nil, fmt.Errorf("invalid sort field '%s'%s Valid fields: %s",
+				fieldName, didYouMean(fieldName, aliasOrderByFields), strings.Join(aliasOrderByFields, ", "))
 		}
 
 		criteria[i] = sortCriterion{
@@ -1173,8 +1068,21 @@ func compareAliases(a, b api.Alias, criterion sortCriterion, domainMap map[strin
 	}
 }
 
-func runAliasList(cmd *cobra.Command, args []string) error {
+func runAliasList(cmd *cobra.Command, args []string) (err error) {
 	ctx := context.Background()
+	start := time.Now()
+
+	if err := validateAliasListFlags(); err != nil {
+		return err
+	}
+	format, err := output.ParseFormat(viper.GetString("output"))
+	if err != nil {
+		return fmt.Errorf("invalid output format: %v", err)
+	}
+	envelope, err := envelopeSet(cmd, format)
+	if err != nil {
+		return err
+	}
 
 	apiClient, err := client.NewAPIClient()
 	if err != nil {
@@ -1201,7 +1109,10 @@ func runAliasList(cmd *cobra.Command, args []string) error {
 			domains = append(domains, domain.Name)
 		}
 		if len(domains) == 0 {
-			fmt.Println("No domains found")
+			if envelope {
+				return writeEnvelope(cmd, start, []api.Alias{}, listPagination(aliasPage, aliasLimit, 0, 0), nil)
+			}
+			cmd.PrintErrln("No domains found")
 			return nil
 		}
 	} else {
@@ -1210,7 +1121,7 @@ func runAliasList(cmd *cobra.Command, args []string) error {
 		if len(args) > 0 {
 			domain = args[0]
 		}
-		if domain == "" {
+		if domain = withDefaultDomain(cmd, domain); domain == "" {
 			return fmt.Errorf("domain is required - specify as argument, use --domain flag, or use --all-domains")
 		}
 
@@ -1220,6 +1131,10 @@ func runAliasList(cmd *cobra.Command, args []string) error {
 			domains[i] = strings.TrimSpace(d)
 		}
 	}
+	cursor := listCursor(cmd)
+	if cursor != "" && (aliasAllDomains || len(domains) > 1) {
+		return fmt.Errorf("--cursor pages through a single domain")
+	}
 
 	// Parse boolean flags
 	var enabled *bool
@@ -1236,37 +1151,54 @@ func runAliasList(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Ownership filters are narrowed server-side via labels and enforced locally
+	labels := splitCSVList(aliasLabels)
+	if aliasOwner != "" {
+		labels = append(labels, ownerLabelPrefix+aliasOwner)
+	}
+	if aliasTeam != "" {
+		labels = append(labels, teamLabelPrefix+aliasTeam)
+	}
+
 	// Create a mapping from domain ID to domain name
 	var domainMap map[string]string
 	var allAliases []api.Alias
 	var totalCount int
 	var totalPages int
+	var single *api.Pagination // the API's pagination when listing one domain
+	var failures []partialFailure
 
 	// Initialize domain mapping - will be populated as we fetch aliases
 	domainMap = make(map[string]string)
 
-	for _, domain := range domains {
-		opts := &api.ListAliasesOptions{
-			Domain:  domain,
-			Page:    aliasPage,
-			Limit:   aliasLimit,
-			Sort:    aliasSort,
-			Order:   aliasOrder,
-			Search:  aliasSearch,
-			Enabled: enabled,
-			Labels:  aliasLabels,
-			HasIMAP: hasIMAP,
-		}
-
-		response, listErr := apiClient.Aliases.ListAliases(ctx, opts)
-		if listErr != nil {
-			fmt.Printf("Warning: failed to list aliases for domain %s: %v\n", domain, listErr)
+	opts := api.ListAliasesOptions{
+		Page:    aliasPage,
+		Limit:   aliasLimit,
+		Sort:    aliasSort,
+		Order:   aliasOrder,
+		Search:  aliasSearch,
+		Enabled: enabled,
+		Labels:  strings.Join(labels, ","),
+		HasIMAP: hasIMAP,
+		Cursor:  cursor,
+	}
+	// Results are gathered per domain and merged in the order the domains were
+	// given, so the output does not depend on which request finished first.
+	for i, result := range listAliasesConcurrently(ctx, apiClient, domains, opts, aliasWorkers) {
+		domain := domains[i]
+		if result.err != nil {
+			slog.Debug("failed to list aliases", "domain", domain, "error", result.err)
+			failures = append(failures, newPartialFailure(domain, "", result.err))
 			continue
 		}
+		response := result.response
 
 		// Add aliases to the list with proper domain tracking
 		// WORKAROUND: Forward Email API doesn't populate domain_id field, so we set it ourselves
 		for _, alias := range response.Aliases {
+			if !matchesOwnership(&alias, aliasOwner, aliasTeam) {
+				continue
+			}
 			// Set the DomainID to the domain we queried so mapping works
 			alias.DomainID = domain
 			allAliases = append(allAliases, alias)
@@ -1279,11 +1211,9 @@ func runAliasList(cmd *cobra.Command, args []string) error {
 		if response.TotalPages > totalPages {
 			totalPages = response.TotalPages
 		}
-	}
-
-	if len(allAliases) == 0 {
-		fmt.Println("No aliases found")
-		return nil
+		if len(domains) == 1 {
+			single = &response.Pagination
+		}
 	}
 
 	// Apply custom sorting if specified
@@ -1293,9 +1223,27 @@ func runAliasList(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	format, err := output.ParseFormat(viper.GetString("output"))
-	if err != nil {
-		return fmt.Errorf("invalid output format: %v", err)
+	if envelope {
+		if allAliases == nil {
+			allAliases = []api.Alias{}
+		}
+		page := single
+		if page == nil {
+			page = listPagination(aliasPage, aliasLimit, totalCount, totalPages)
+		}
+		if err := writeEnvelope(cmd, start, allAliases, page, failures); err != nil {
+			return err
+		}
+		return partialResult(failures)
+	}
+
+	// Failed domains are reported together once the list has been written,
+	// rather than between rows of it.
+	defer reportPartial(cmd, fmt.Sprintf("%d domain(s) could not be listed", len(failures)), failures, &err)
+
+	if len(allAliases) == 0 {
+		cmd.PrintErrln("No aliases found")
+		return nil
 	}
 
 	if format == output.FormatJSON || format == output.FormatYAML {
@@ -1335,18 +1283,77 @@ func runAliasList(cmd *cobra.Command, args []string) error {
 	// Show pagination info for non-JSON/YAML formats
 	if len(allAliases) > 0 {
 		if len(domains) == 1 {
-			fmt.Printf("\nShowing %d aliases from domain %s\n", len(allAliases), domains[0])
+			cmd.PrintErrf("\nShowing %d aliases from domain %s\n", len(allAliases), domains[0])
 		} else {
-			fmt.Printf("\nShowing %d aliases from %d domains\n", len(allAliases), len(domains))
+			cmd.PrintErrf("\nShowing %d aliases from %d domains\n", len(allAliases), len(domains))
 		}
-		if totalCount > len(allAliases) {
-			fmt.Printf("Total: %d aliases (use --page to see more)\n", totalCount)
+		switch {
+		case single != nil && single.NextCursor != "":
+			cmd.PrintErrf("Use --cursor %s to see more results\n", single.NextCursor)
+		case totalCount > len(allAliases):
+			cmd.PrintErrf("Total: %d aliases (use --page to see more)\n", totalCount)
 		}
 	}
 
 	return nil
 }
 
+// domainAliases is the outcome of listing one domain's aliases.
+type domainAliases struct {
+	response *api.ListAliasesResponse
+	err      error
+}
+
+// listAliasesConcurrently lists the aliases of each domain with opts, running
+// at most workers requests at a time. Results are indexed like domains.
+func listAliasesConcurrently(
+	ctx context.Context, c *api.Client, domains []string, opts api.ListAliasesOptions, workers int,
+) []domainAliases {
+	results := make([]domainAliases, len(domains))
+	runConcurrently(len(domains), workers, func(i int) {
+		domainOpts := opts
+		domainOpts.Domain = domains[i]
+		resp, err := c.Aliases.ListAliases(ctx, &domainOpts)
+		results[i] = domainAliases{response: resp, err: err}
+	})
+	return results
+}
+
+// runConcurrently calls fn for every index below n, with at most workers calls
+// running at once, and returns when all have finished. fn stores its own
+// results, typically in a slice indexed like the input, which keeps output
+// order independent of completion order.
+func runConcurrently(n, workers int, fn func(i int)) {
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(max(workers, 1), n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// printWarnings reports warnings on stderr under heading, after a command's
+// output rather than between its rows.
+func printWarnings(cmd *cobra.Command, heading string, warnings []string) {
+	if len(warnings) == 0 {
+		return
+	}
+	cmd.PrintErrf("\n⚠️  %s:\n", heading)
+	for _, w := range warnings {
+		cmd.PrintErrf("  - %s\n", w)
+	}
+}
+
 func runAliasGet(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
@@ -1360,8 +1367,15 @@ func runAliasGet(cmd *cobra.Command, args []string) error {
 		domain = args[0]
 		aliasID = args[1]
 	case 1:
-		// Only one argument - could be alias ID with --domain flag, or domain+aliasID
-		if domain == "" {
+		// A full address names both; otherwise the alias ID needs --domain or a default domain
+		if name, addrDomain, ok := splitAliasAddress(args[0]); ok {
+			if domain != "" && !strings.EqualFold(domain, addrDomain) {
+				return fmt.Errorf("address %s is not in domain %s", args[0], domain)
+			}
+			domain, aliasID = addrDomain, name
+			break
+		}
+		if domain = withDefaultDomain(cmd, domain); domain == "" {
 			return fmt.Errorf("domain is required - specify as first argument or use --domain flag")
 		}
 		aliasID = args[0]
@@ -1382,6 +1396,7 @@ func runAliasGet(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to get alias: %v", err)
 	}
+	details := newAliasDetails(alias, domain)
 
 	format, err := output.ParseFormat(viper.GetString("output"))
 	if err != nil {
@@ -1390,11 +1405,11 @@ func runAliasGet(cmd *cobra.Command, args []string) error {
 
 	if format == output.FormatJSON || format == output.FormatYAML {
 		formatter := output.NewFormatter(format, cmd.OutOrStdout())
-		return formatter.Format(alias)
+		return formatter.Format(details)
 	}
 
 	// Format as table
-	tableData, err := output.FormatAliasDetails(alias, format)
+	tableData, err := output.FormatAliasFullDetails(details, format)
 	if err != nil {
 		return fmt.Errorf("failed to format output: %v", err)
 	}
@@ -1403,6 +1418,165 @@ func runAliasGet(cmd *cobra.Command, args []string) error {
 	return formatter.Format(tableData)
 }
 
+// Forward Email's mail servers, all with implicit TLS, for the mail client
+// settings of IMAP aliases
+const (
+	mailClientIMAP = "imap.forwardemail.net:993"
+	mailClientPOP3 = "pop3.forwardemail.net:995"
+	mailClientSMTP = "smtp.forwardemail.net:465"
+)
+
+// splitAliasAddress splits an alias address such as sales@example.com into
+// the alias name and the domain.
+func splitAliasAddress(s string) (name, domain string, ok bool) {
+	i := strings.LastIndex(s, "@")
+	if i <= 0 || i == len(s)-1 {
+		return "", "", false
+	}
+	return s[:i], strings.ToLower(s[i+1:]), true
+}
+
+// newAliasDetails adds what alias get shows beyond the API fields: the
+// address, the webhook recipients and, for IMAP aliases, the mail client
+// settings.
+func newAliasDetails(alias *api.Alias, domain string) *output.AliasDetails {
+	address := alias.Name + "@" + strings.ToLower(domain)
+	d := &output.AliasDetails{Alias: *alias, Address: address}
+	for _, r := range alias.Recipients {
+		if webhook.IsWebhook(r) {
+			d.WebhookRecipients = append(d.WebhookRecipients, r)
+		}
+	}
+	if alias.HasIMAP {
+		d.MailClient = &output.MailClientSettings{
+			Username: address,
+			IMAP:     mailClientIMAP,
+			POP3:     mailClientPOP3,
+			SMTP:     mailClientSMTP,
+		}
+	}
+	return d
+}
+
+// promptForAlias walks through the alias fields interactively, mirroring the email
+// composer. Domain and name are only asked for when missing; other values given as
+// flags are offered as defaults. It returns the domain and whether the user confirmed.
+func promptForAlias(cmd *cobra.Command, domain string, req *api.CreateAliasRequest) (string, bool, error) {
+	in := bufio.NewReader(cmd.InOrStdin())
+	out := cmd.ErrOrStderr()
+
+	ask := func(label, current string) string {
+		if current != "" {
+			_, _ = fmt.Fprintf(out, "%s [%s]: ", label, current)
+		} else {
+			_, _ = fmt.Fprintf(out, "%s: ", label)
+		}
+		answer, _ := readPromptLine(in)
+		if answer == "" {
+			return current
+		}
+		return answer
+	}
+
+	_, _ = fmt.Fprintln(out, "🏷️  Interactive Alias Creator")
+	_, _ = fmt.Fprintln(out)
+
+	if domain == "" {
+		p, _ := activeProfile()
+		if domain = ask("Domain", p.DefaultDomain); domain == "" {
+			return "", false, fmt.Errorf("domain is required")
+		}
+	}
+	if req.Name == "" {
+		if req.Name = ask("Alias name", ""); req.Name == "" {
+			return "", false, fmt.Errorf("alias name is required")
+		}
+	}
+
+	// Recipients are entered one per line (commas also accepted) until a blank line
+	if len(req.Recipients) > 0 {
+		_, _ = fmt.Fprintf(out, "Recipients so far: %s\n", strings.Join(req.Recipients, ", "))
+	}
+	_, _ = fmt.Fprintln(out, "Recipients (email address or webhook URL, blank line to finish):")
+	for {
+		_, _ = fmt.Fprint(out, "  recipient: ")
+		line, err := readPromptLine(in)
+		if err != nil || line == "" {
+			if len(req.Recipients) > 0 || err != nil {
+				break
+			}
+			_, _ = fmt.Fprintln(out, "  at least one recipient is required")
+			continue
+		}
+		recipients, perr := parseAliasRecipientList(line)
+		if perr != nil {
+			_, _ = fmt.Fprintf(out, "  ✗ %v\n", perr)
+			continue
+		}
+		for _, r := range recipients {
+			if verr := validateAliasRecipient(r); verr != nil {
+				_, _ = fmt.Fprintf(out, "  ✗ %v\n", verr)
+				continue
+			}
+			req.Recipients = append(req.Recipients, r)
+		}
+	}
+	if len(req.Recipients) == 0 {
+		return "", false, fmt.Errorf("at least one recipient is required")
+	}
+
+	if labels := ask("Labels (comma-separated, optional)", strings.Join(req.Labels, ",")); labels != "" {
+		req.Labels = splitCSVList(labels)
+	}
+	req.Description = ask("Description (optional)", req.Description)
+	req.HasIMAP = promptYesNoDefault(in, out, "Enable IMAP access?", req.HasIMAP)
+	req.HasPGP = promptYesNoDefault(in, out, "Enable PGP encryption?", req.HasPGP)
+	if req.HasPGP && req.PublicKey == "" {
+		_, _ = fmt.Fprintln(out, "PGP public key (enter 'END' on a new line to finish):")
+		var keyLines []string
+		for {
+			line, err := in.ReadString('\n')
+			line = strings.TrimRight(line, "\r\n")
+			if line == "END" || (err != nil && line == "") {
+				break
+			}
+			keyLines = append(keyLines, line)
+		}
+		req.PublicKey = strings.Join(keyLines, "\n")
+	}
+
+	_, _ = fmt.Fprintln(out)
+	_, _ = fmt.Fprintln(out, "📋 Alias Summary:")
+	_, _ = fmt.Fprintf(out, "Alias: %s@%s\n", req.Name, domain)
+	_, _ = fmt.Fprintf(out, "Recipients: %s\n", strings.Join(req.Recipients, ", "))
+	if len(req.Labels) > 0 {
+		_, _ = fmt.Fprintf(out, "Labels: %s\n", strings.Join(req.Labels, ", "))
+	}
+	if req.Description != "" {
+		_, _ = fmt.Fprintf(out, "Description: %s\n", req.Description)
+	}
+	_, _ = fmt.Fprintf(out, "Enabled: %s  IMAP: %s  PGP: %s\n",
+		output.FormatValue(req.IsEnabled), output.FormatValue(req.HasIMAP), output.FormatValue(req.HasPGP))
+	_, _ = fmt.Fprintln(out)
+
+	return domain, promptYesNo(in, out, "Create this alias?"), nil
+}
+
+// validateAliasRecipient accepts the recipient kinds used by the wizard: email
+// addresses and http(s) webhook URLs.
+func validateAliasRecipient(r string) error {
+	if strings.HasPrefix(r, "http://") || strings.HasPrefix(r, "https://") {
+		if u, err := url.Parse(r); err != nil || u.Host == "" {
+			return fmt.Errorf("invalid webhook URL: %s", r)
+		}
+		return nil
+	}
+	if _, err := mail.ParseAddress(r); err != nil {
+		return fmt.Errorf("invalid email address: %s", r)
+	}
+	return nil
+}
+
 func runAliasCreate(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
@@ -1417,9 +1591,15 @@ func runAliasCreate(cmd *cobra.Command, args []string) error {
 		aliasName = args[1]
 	case 1:
 		// One argument provided; decide whether it's domain or alias name
+		if !aliasInteractive {
+			domain = withDefaultDomain(cmd, domain)
+		}
 		if domain != "" {
 			// Domain was provided via flag; single arg must be alias name
 			aliasName = args[0]
+		} else if aliasInteractive {
+			// The wizard asks for the name, so a lone argument is the domain
+			domain = args[0]
 		} else if len(aliasRecipients) > 0 {
 			// Creating an alias (recipients given) but no domain specified
 			return fmt.Errorf("domain is required - specify as first argument or use --domain flag")
@@ -1428,31 +1608,65 @@ func runAliasCreate(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("alias name is required")
 		}
 	default:
-		return fmt.Errorf("alias name is required")
+		if !aliasInteractive {
+			return fmt.Errorf("alias name is required")
+		}
+	}
+
+	req := &api.CreateAliasRequest{
+		Name:        aliasName,
+		Recipients:  aliasRecipients,
+		Labels:      aliasLabelsFlag,
+		Description: aliasDescription,
+		IsEnabled:   aliasEnableFlag,
+		HasIMAP:     aliasIMAPFlag,
+		HasPGP:      aliasPGPFlag,
+		PublicKey:   aliasPublicKey,
+	}
+
+	if aliasInteractive {
+		var confirmed bool
+		var err error
+		domain, confirmed, err = promptForAlias(cmd, domain, req)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			cmd.PrintErrln(i18n.T("Alias creation canceled"))
+			return nil
+		}
 	}
 
 	if domain == "" {
 		return fmt.Errorf("domain is required - specify as first argument or use --domain flag")
 	}
 
-	if len(aliasRecipients) == 0 {
+	if len(req.Recipients) == 0 {
 		return fmt.Errorf("at least one recipient is required")
 	}
+	if err := validateWebhookRecipients(ctx, req.Recipients); err != nil {
+		return err
+	}
 
-	apiClient, err := client.NewAPIClient()
+	if aliasExpiresIn != "" {
+		labels, err := expiryLabels(req.Labels, aliasExpiresIn, time.Now())
+		if err != nil {
+			return err
+		}
+		req.Labels = labels
+	}
+
+	namingPolicy, err := loadAliasPolicy()
 	if err != nil {
-		return fmt.Errorf("failed to create API client: %v", err)
+		return err
+	}
+	if err := policyError(namingPolicy.Check(req.Name, req.Labels)); err != nil {
+		return err
 	}
 
-	req := &api.CreateAliasRequest{
-		Name:        aliasName,
-		Recipients:  aliasRecipients,
-		Labels:      aliasLabelsFlag,
-		Description: aliasDescription,
-		IsEnabled:   aliasEnableFlag,
-		HasIMAP:     aliasIMAPFlag,
-		HasPGP:      aliasPGPFlag,
-		PublicKey:   aliasPublicKey,
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %v", err)
 	}
 
 	alias, err := apiClient.Aliases.CreateAlias(ctx, domain, req)
@@ -1460,7 +1674,11 @@ func runAliasCreate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create alias: %v", err)
 	}
 
-	cmd.Printf("✅ Alias '%s' created successfully\n", alias.Name)
+	reportMutation(hookResource{Type: "alias", Action: "create", Domain: domain, Name: alias.Name, ID: alias.ID, Data: alias})
+	cmd.PrintErrf("✅ Alias '%s' created successfully\n", alias.Name)
+	if done, err := printIDOnly(cmd, alias.ID); done {
+		return err
+	}
 
 	format, err := output.ParseFormat(viper.GetString("output"))
 	if err != nil {
@@ -1520,6 +1738,11 @@ func runAliasUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	req := &api.UpdateAliasRequest{}
+	if aliasUpdateFile != "" {
+		if _, err := readPatchFile(cmd, aliasUpdateFile, schema.AliasPatch, req); err != nil {
+			return err
+		}
+	}
 
 	// Check what flags were set and update accordingly
 	if len(aliasRecipients) > 0 {
@@ -1548,13 +1771,43 @@ func runAliasUpdate(cmd *cobra.Command, args []string) error {
 	if cmd.Flags().Changed("public-key") {
 		req.PublicKey = &aliasPublicKey
 	}
+	if err := validateWebhookRecipients(ctx, req.Recipients); err != nil {
+		return err
+	}
+
+	current, err := apiClient.Aliases.GetAlias(ctx, domain, aliasID)
+	if err != nil {
+		return fmt.Errorf("failed to get alias: %v", err)
+	}
+	if req.Name != nil && !strings.EqualFold(*req.Name, current.Name) {
+		labels := current.Labels
+		if req.Labels != nil {
+			labels = req.Labels
+		}
+		namingPolicy, err := loadAliasPolicy()
+		if err != nil {
+			return err
+		}
+		if err := policyError(namingPolicy.Check(*req.Name, labels)); err != nil {
+			return err
+		}
+	}
+	changes, err := diffUpdate(current, req)
+	if err != nil {
+		return err
+	}
+	title := fmt.Sprintf("Changes to alias '%s@%s'", current.Name, domain)
+	if stop, err := showUpdateDiff(cmd, title, changes, aliasUpdateDryRun); stop || err != nil {
+		return err
+	}
 
 	alias, err := apiClient.Aliases.UpdateAlias(ctx, domain, aliasID, req)
 	if err != nil {
 		return fmt.Errorf("failed to update alias: %v", err)
 	}
 
-	cmd.Printf("✅ Alias '%s' updated successfully\n", alias.Name)
+	reportMutation(hookResource{Type: "alias", Action: "update", Domain: domain, Name: alias.Name, ID: alias.ID, Data: alias})
+	cmd.PrintErrf("✅ Alias '%s' updated successfully\n", alias.Name)
 
 	format, err := output.ParseFormat(viper.GetString("output"))
 	if err != nil {
@@ -1590,7 +1843,7 @@ func runAliasDelete(cmd *cobra.Command, args []string) error {
 		aliasID = args[1]
 	case 1:
 		// Only one argument - could be alias ID with --domain flag
-		if domain == "" {
+		if domain = withDefaultDomain(cmd, domain); domain == "" {
 			return fmt.Errorf("domain is required - specify as first argument or use --domain flag")
 		}
 		aliasID = args[0]
@@ -1613,15 +1866,12 @@ func runAliasDelete(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get alias: %v", err)
 	}
 
-	cmd.Printf("⚠️  Are you sure you want to delete alias '%s'? This action cannot be undone.\n", alias.Name)
-	cmd.Print("Type 'yes' to confirm: ")
-
-	reader := bufio.NewReader(cmd.InOrStdin())
-	line, _ := reader.ReadString('\n')
-	confirmation := strings.TrimSpace(line)
-
-	if !strings.EqualFold(confirmation, yesStr) {
-		cmd.Printf("❌ Deletion canceled\n")
+	ok, err := confirm(cmd, "⚠️  "+i18n.T("Are you sure you want to delete alias '%s'? This action cannot be undone.", alias.Name))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		cmd.PrintErrln("❌ " + i18n.T("Deletion canceled"))
 		return nil
 	}
 
@@ -1630,7 +1880,8 @@ func runAliasDelete(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to delete alias: %v", err)
 	}
 
-	cmd.Printf("✅ Alias '%s' deleted successfully\n", alias.Name)
+	reportMutation(hookResource{Type: "alias", Action: "delete", Domain: domain, Name: alias.Name, ID: alias.ID, Data: alias})
+	cmd.PrintErrf("✅ Alias '%s' deleted successfully\n", alias.Name)
 	return nil
 }
 
@@ -1648,7 +1899,7 @@ func runAliasEnable(cmd *cobra.Command, args []string) error {
 		aliasID = args[1]
 	case 1:
 		// Only one argument - could be alias ID with --domain flag
-		if domain == "" {
+		if domain = withDefaultDomain(cmd, domain); domain == "" {
 			return fmt.Errorf("domain is required - specify as first argument or use --domain flag")
 		}
 		aliasID = args[0]
@@ -1670,7 +1921,8 @@ func runAliasEnable(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to enable alias: %v", err)
 	}
 
-	cmd.Printf("✅ Alias '%s' enabled successfully\n", alias.Name)
+	reportMutation(hookResource{Type: "alias", Action: "enable", Domain: domain, Name: alias.Name, ID: alias.ID, Data: alias})
+	cmd.PrintErrf("✅ Alias '%s' enabled successfully\n", alias.Name)
 	return nil
 }
 
@@ -1688,7 +1940,7 @@ func runAliasDisable(cmd *cobra.Command, args []string) error {
 		aliasID = args[1]
 	case 1:
 		// Only one argument - could be alias ID with --domain flag
-		if domain == "" {
+		if domain = withDefaultDomain(cmd, domain); domain == "" {
 			return fmt.Errorf("domain is required - specify as first argument or use --domain flag")
 		}
 		aliasID = args[0]
@@ -1710,7 +1962,8 @@ func runAliasDisable(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to disable alias: %v", err)
 	}
 
-	cmd.Printf("✅ Alias '%s' disabled successfully\n", alias.Name)
+	reportMutation(hookResource{Type: "alias", Action: "disable", Domain: domain, Name: alias.Name, ID: alias.ID, Data: alias})
+	cmd.PrintErrf("✅ Alias '%s' disabled successfully\n", alias.Name)
 	return nil
 }
 
@@ -1728,7 +1981,7 @@ func runAliasRecipients(cmd *cobra.Command, args []string) error {
 		aliasID = args[1]
 	case 1:
 		// Only one argument - could be alias ID with --domain flag
-		if domain == "" {
+		if domain = withDefaultDomain(cmd, domain); domain == "" {
 			return fmt.Errorf("domain is required - specify as first argument or use --domain flag")
 		}
 		aliasID = args[0]
@@ -1743,6 +1996,9 @@ func runAliasRecipients(cmd *cobra.Command, args []string) error {
 	if len(aliasRecipients) == 0 {
 		return fmt.Errorf("at least one recipient is required")
 	}
+	if err := validateWebhookRecipients(ctx, aliasRecipients); err != nil {
+		return err
+	}
 
 	apiClient, err := client.NewAPIClient()
 	if err != nil {
@@ -1754,8 +2010,9 @@ func runAliasRecipients(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to update recipients: %v", err)
 	}
 
-	cmd.Printf("✅ Recipients updated for alias '%s'\n", alias.Name)
-	cmd.Printf("New recipients: %s\n", strings.Join(alias.Recipients, ", "))
+	reportMutation(hookResource{Type: "alias", Action: "update", Domain: domain, Name: alias.Name, ID: alias.ID, Data: alias})
+	cmd.PrintErrf("✅ Recipients updated for alias '%s'\n", alias.Name)
+	cmd.PrintErrf("New recipients: %s\n", strings.Join(alias.Recipients, ", "))
 	return nil
 }
 
@@ -1773,7 +2030,7 @@ func runAliasPassword(cmd *cobra.Command, args []string) error {
 		aliasID = args[1]
 	case 1:
 		// Only one argument - could be alias ID with --domain flag
-		if domain == "" {
+		if domain = withDefaultDomain(cmd, domain); domain == "" {
 			return fmt.Errorf("domain is required - specify as first argument or use --domain flag")
 		}
 		aliasID = args[0]
@@ -1784,25 +2041,71 @@ func runAliasPassword(cmd *cobra.Command, args []string) error {
 	if domain == "" {
 		return fmt.Errorf("domain is required - specify as first argument or use --domain flag")
 	}
+	if err := checkSecretFlags(cmd); err != nil {
+		return err
+	}
+	pwReq, err := aliasPasswordRequest(cmd)
+	if err != nil {
+		return err
+	}
 
 	apiClient, err := client.NewAPIClient()
 	if err != nil {
 		return fmt.Errorf("failed to create API client: %v", err)
 	}
 
-	response, err := apiClient.Aliases.GeneratePassword(ctx, domain, aliasID)
+	// A password chosen here must not contain the alias name or address, so
+	// resolve the alias argument, which may be an ID, first.
+	var name string
+	if pwReq != nil {
+		alias, err := apiClient.Aliases.GetAlias(ctx, domain, aliasID)
+		if err != nil {
+			return fmt.Errorf("failed to get alias: %v", err)
+		}
+		name = alias.Name
+		if err := checkPasswordStrength(pwReq.NewPassword, alias.Name, alias.Name+"@"+domain); err != nil {
+			return err
+		}
+	}
+
+	var response *api.GeneratePasswordResponse
+	if pwReq != nil {
+		response, err = apiClient.Aliases.SetPassword(ctx, domain, aliasID, pwReq)
+	} else {
+		response, err = apiClient.Aliases.GeneratePassword(ctx, domain, aliasID)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to generate password: %v", err)
 	}
+	// The response holds the password, so it is not passed to hooks.
+	reportMutation(hookResource{Type: "alias", Action: "password", Domain: domain, Name: name, ID: aliasID})
+
+	if aliasPasswordStdin {
+		// The caller already has the password; never echo it back
+		cmd.PrintErrln("✅ IMAP password updated")
+		return nil
+	}
 
-	cmd.Printf("✅ New IMAP password generated\n")
-	cmd.Printf("Password: %s\n", response.Password)
-	cmd.Println("⚠️  Store this password securely - it cannot be retrieved again")
+	cmd.PrintErrf("✅ New IMAP password generated\n")
+	echo, err := shareSecret(cmd, "password", response.Password)
+	if err != nil {
+		return err
+	}
+	if echo {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Password: %s\n", response.Password)
+	}
+	cmd.PrintErrln("⚠️  Store this password securely - it cannot be retrieved again")
 	return nil
 }
 
 func runAliasQuota(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
+	if aliasQuotaAll {
+		return runAliasQuotaReport(cmd, args)
+	}
+	if aliasQuotaOver != "" {
+		return fmt.Errorf("--over requires --all")
+	}
 
 	// Parse domain and alias ID from positional arguments or flags
 	domain := aliasDomain
@@ -1815,7 +2118,7 @@ func runAliasQuota(cmd *cobra.Command, args []string) error {
 		aliasID = args[1]
 	case 1:
 		// Only one argument - could be alias ID with --domain flag
-		if domain == "" {
+		if domain = withDefaultDomain(cmd, domain); domain == "" {
 			return fmt.Errorf("domain is required - specify as first argument or use --domain flag")
 		}
 		aliasID = args[0]
@@ -1871,7 +2174,7 @@ func runAliasStats(cmd *cobra.Command, args []string) error {
 		aliasID = args[1]
 	case 1:
 		// Only one argument - could be alias ID with --domain flag
-		if domain == "" {
+		if domain = withDefaultDomain(cmd, domain); domain == "" {
 			return fmt.Errorf("domain is required - specify as first argument or use --domain flag")
 		}
 		aliasID = args[0]
@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/term"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/imapscan"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+// imapPasswordEnv holds the IMAP password for alias discover, so scripts need
+// neither a prompt nor stdin.
+const imapPasswordEnv = "FORWARDEMAIL_IMAP_PASSWORD"
+
+var (
+	aliasDiscoverHost          string
+	aliasDiscoverUser          string
+	aliasDiscoverMailboxes     []string
+	aliasDiscoverSince         string
+	aliasDiscoverNoTLS         bool
+	aliasDiscoverPasswordStdin bool
+	aliasDiscoverPlan          string
+	aliasDiscoverRecipient     string
+)
+
+var aliasDiscoverCmd = &cobra.Command{
+	Use:   "discover <domain> --imap-host <host> --user <user>",
+	Short: "Find the addresses a domain receives mail at in an existing mailbox",
+	Long: `Scan the To, Cc, Delivered-To and X-Original-To headers of the mail in an
+existing IMAP mailbox for addresses at a domain being migrated, so rarely used
+addresses get an alias before mail to them bounces. Each address is listed with
+its message count, when it last received mail, and whether an alias already
+exists, the catch-all would take it, or it is new.
+
+The scan is read-only: mailboxes are opened with EXAMINE and messages are not
+marked as read. The password is read from --password-stdin, the
+FORWARDEMAIL_IMAP_PASSWORD environment variable, or a prompt.
+
+--plan writes the new addresses as an 'alias import' file forwarding to
+--recipient (default: --user), to review before importing it.`,
+	Example: `  forward-email alias discover example.com --imap-host imap.example.com --user me@example.com
+  forward-email alias discover example.com --imap-host imap.example.com --user me@example.com \
+    --since 2y --mailbox INBOX --mailbox Archive --plan discovered.yaml
+  forward-email alias import example.com --file discovered.yaml --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAliasDiscover,
+}
+
+func init() {
+	aliasCmd.AddCommand(aliasDiscoverCmd)
+	f := aliasDiscoverCmd.Flags()
+	f.StringVar(&aliasDiscoverHost, "imap-host", "", "IMAP server, as host or host:port (default port 993, or 143 with --no-tls)")
+	f.StringVar(&aliasDiscoverUser, "user", "", "IMAP user name")
+	f.StringSliceVar(&aliasDiscoverMailboxes, "mailbox", []string{"INBOX"}, "Mailbox to scan (repeatable)")
+	f.StringVar(&aliasDiscoverSince, "since", "1y", "Only scan mail received since this date or span (e.g. 2024-01-01, 90d, 1y)")
+	f.BoolVar(&aliasDiscoverNoTLS, "no-tls", false, "Connect without TLS, e.g. to a local IMAP bridge")
+	f.BoolVar(&aliasDiscoverPasswordStdin, "password-stdin", false, "Read the IMAP password from stdin")
+	f.StringVar(&aliasDiscoverPlan, "plan", "", "Write the new addresses as an alias import file (.yaml or .json)")
+	f.StringVar(&aliasDiscoverRecipient, "recipient", "", "Recipient of the aliases in --plan (default: --user)")
+	_ = aliasDiscoverCmd.MarkFlagRequired("imap-host")
+	_ = aliasDiscoverCmd.MarkFlagRequired("user")
+}
+
+func runAliasDiscover(cmd *cobra.Command, args []string) error {
+	format, err := output.ParseFormat(viper.GetString("output"))
+	if err != nil {
+		return fmt.Errorf("invalid output format: %v", err)
+	}
+	domain := strings.ToLower(strings.TrimSpace(args[0]))
+	since, err := parseDateSpec(aliasDiscoverSince, time.Now())
+	if err != nil {
+		return fmt.Errorf("invalid --since: %v", err)
+	}
+	recipient := aliasDiscoverRecipient
+	if recipient == "" && strings.Contains(aliasDiscoverUser, "@") {
+		recipient = aliasDiscoverUser
+	}
+	if aliasDiscoverPlan != "" {
+		if recipient == "" {
+			return fmt.Errorf("--recipient is required with --plan when --user is not an email address")
+		}
+		if ext := strings.ToLower(filepath.Ext(aliasDiscoverPlan)); ext != ".yaml" && ext != ".yml" && ext != ".json" {
+			return fmt.Errorf("--plan must be a .yaml, .yml or .json file")
+		}
+	}
+	password, err := imapPassword(cmd)
+	if err != nil {
+		return err
+	}
+
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %v", err)
+	}
+	aliases, err := listAllAliases(cmd.Context(), apiClient, domain)
+	if err != nil {
+		return fmt.Errorf("failed to list aliases for %s: %v", domain, err)
+	}
+	messages, err := scanMailboxes(cmd, password, since)
+	if err != nil {
+		return err
+	}
+	discovered := classifyDiscovered(imapscan.Addresses(messages, domain), domain, aliases)
+	cmd.PrintErrf("Scanned %d message(s); found %d address(es) at %s\n", len(messages), len(discovered), domain)
+
+	if aliasDiscoverPlan != "" {
+		if err := writeDiscoverPlan(cmd, domain, discovered, recipient); err != nil {
+			return err
+		}
+	}
+
+	formatter := output.NewFormatter(format, cmd.OutOrStdout())
+	if format == output.FormatJSON || format == output.FormatYAML {
+		return formatter.Format(discovered)
+	}
+	if len(discovered) == 0 {
+		return nil
+	}
+	tableData, err := output.FormatDiscoveredAddresses(discovered, format)
+	if err != nil {
+		return fmt.Errorf("failed to format output: %v", err)
+	}
+	return formatter.Format(tableData)
+}
+
+// imapPassword returns the IMAP password from stdin, the environment or a
+// terminal prompt.
+func imapPassword(cmd *cobra.Command) (string, error) {
+	if aliasDiscoverPasswordStdin {
+		return readPasswordStdin(cmd.InOrStdin())
+	}
+	if p := os.Getenv(imapPasswordEnv); p != "" {
+		return p, nil
+	}
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("no IMAP password: use --password-stdin or set %s", imapPasswordEnv)
+	}
+	cmd.PrintErrf("IMAP password for %s (will not echo): ", aliasDiscoverUser)
+	p, err := term.ReadPassword(fd)
+	cmd.PrintErrln()
+	if err != nil {
+		return "", fmt.Errorf("failed to read password: %v", err)
+	}
+	return string(p), nil
+}
+
+// scanMailboxes fetches the address headers of the mail received since since
+// in each --mailbox.
+func scanMailboxes(cmd *cobra.Command, password string, since time.Time) ([]imapscan.Message, error) {
+	addr := aliasDiscoverHost
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		port := "993"
+		if aliasDiscoverNoTLS {
+			port = "143"
+		}
+		addr = net.JoinHostPort(addr, port)
+	}
+	var tlsConfig *tls.Config
+	if !aliasDiscoverNoTLS {
+		host, _, _ := net.SplitHostPort(addr)
+		tlsConfig = &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}
+	}
+
+	ctx := cmd.Context()
+	c, err := imapscan.Dial(ctx, addr, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = c.Logout() }()
+	if err := c.Login(aliasDiscoverUser, password); err != nil {
+		return nil, err
+	}
+
+	fields := append(append([]string{}, imapscan.AddressHeaders...), "Date")
+	var messages []imapscan.Message
+	for _, mailbox := range aliasDiscoverMailboxes {
+		if err := c.Examine(mailbox); err != nil {
+			return nil, err
+		}
+		uids, err := c.SearchSince(since)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", mailbox, err)
+		}
+		stop := startSpinner(cmd, fmt.Sprintf("Scanning %d message(s) in %s", len(uids), mailbox))
+		fetched, err := c.FetchHeaders(uids, fields)
+		stop()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", mailbox, err)
+		}
+		messages = append(messages, fetched...)
+	}
+	return messages, nil
+}
+
+// classifyDiscovered marks each address as covered by an alias, taken by the
+// catch-all, or new.
+func classifyDiscovered(uses []imapscan.AddressUse, domain string, aliases []api.Alias) []output.DiscoveredAddress {
+	names := map[string]bool{}
+	catchAll := false
+	for _, a := range aliases {
+		names[strings.ToLower(a.Name)] = true
+		catchAll = catchAll || (a.Name == "*" && a.IsEnabled)
+	}
+	discovered := make([]output.DiscoveredAddress, 0, len(uses))
+	for _, u := range uses {
+		d := output.DiscoveredAddress{Address: u.Address, Messages: u.Messages, Status: output.DiscoveredNew}
+		if !u.LastSeen.IsZero() {
+			lastSeen := u.LastSeen
+			d.LastSeen = &lastSeen
+		}
+		switch local := strings.TrimSuffix(u.Address, "@"+domain); {
+		case names[local]:
+			d.Status = output.DiscoveredExists
+		case catchAll:
+			d.Status = output.DiscoveredCatchAll
+		}
+		discovered = append(discovered, d)
+	}
+	return discovered
+}
+
+// writeDiscoverPlan writes the new addresses to --plan as an alias import
+// file forwarding to recipient.
+func writeDiscoverPlan(cmd *cobra.Command, domain string, discovered []output.DiscoveredAddress, recipient string) error {
+	doc := aliasFile{Version: aliasFileVersion, Aliases: []aliasImportRow{}}
+	for _, d := range discovered {
+		if d.Status != output.DiscoveredNew {
+			continue
+		}
+		description := fmt.Sprintf("Discovered in %d message(s)", d.Messages)
+		doc.Aliases = append(doc.Aliases, aliasImportRow{
+			Name:        strings.TrimSuffix(d.Address, "@"+domain),
+			Recipients:  []string{recipient},
+			Description: &description,
+		})
+	}
+	if len(doc.Aliases) == 0 {
+		cmd.PrintErrf("No new addresses at %s; no plan written\n", domain)
+		return nil
+	}
+	if err := writeAliasDocument(aliasDiscoverPlan, doc); err != nil {
+		return fmt.Errorf("failed to write plan: %v", err)
+	}
+	cmd.PrintErrf("Wrote %d new alias(es) to %s; review it, then run: forward-email alias import %s --file %s\n",
+		len(doc.Aliases), aliasDiscoverPlan, domain, aliasDiscoverPlan)
+	return nil
+}
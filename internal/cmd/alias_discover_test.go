@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/internal/testutil"
+	"github.com/ginsys/forward-email/pkg/auth"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+func TestAliasDiscover(t *testing.T) {
+	t.Setenv(imapPasswordEnv, "")
+	imapAddr := testutil.IMAPServer(t, "me@example.com", "secret", []string{
+		"Date: Mon, 02 Mar 2026 10:00:00 +0000\nTo: sales@example.com, info@example.com\n",
+		"Date: Tue, 03 Mar 2026 10:00:00 +0000\nDelivered-To: old-orders@example.com\nCc: friend@example.org\n",
+		"Date: Wed, 04 Mar 2026 10:00:00 +0000\nTo: sales@example.com\n",
+	})
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    aliases:
+      - name: sales
+        recipients: [me@example.org]
+        is_enabled: true
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetCommandFlags(aliasDiscoverCmd)
+		rootCmd.SetIn(nil)
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	})
+
+	plan := filepath.Join(t.TempDir(), "plan.yaml")
+	run := func(args ...string) (string, string, error) {
+		t.Helper()
+		resetCommandFlags(aliasDiscoverCmd)
+		var stdout, stderr bytes.Buffer
+		rootCmd.SetOut(&stdout)
+		rootCmd.SetErr(&stderr)
+		rootCmd.SetIn(strings.NewReader("secret\n"))
+		rootCmd.SetArgs(append([]string{"alias", "discover", "example.com", "--imap-host", imapAddr,
+			"--user", "me@example.com", "--no-tls", "--password-stdin", "--mailbox", "INBOX"}, args...))
+		err := rootCmd.Execute()
+		return stdout.String(), stderr.String(), err
+	}
+
+	out, stderr, err := run("--plan", plan, "-o", "json")
+	if err != nil {
+		t.Fatalf("alias discover: %v\n%s", err, stderr)
+	}
+	var found []output.DiscoveredAddress
+	if err := json.Unmarshal([]byte(out), &found); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	var got []string
+	for _, d := range found {
+		got = append(got, d.Address+"="+d.Status)
+	}
+	want := "sales@example.com=alias exists,info@example.com=new,old-orders@example.com=new"
+	if strings.Join(got, ",") != want {
+		t.Errorf("discovered %v, want %s", got, want)
+	}
+	if !strings.Contains(stderr, "Scanned 3 message(s); found 3 address(es) at example.com") {
+		t.Errorf("unexpected stderr %q", stderr)
+	}
+
+	rows, err := readAliasImportFile(plan)
+	if err != nil {
+		t.Fatalf("the plan is not a valid import file: %v", err)
+	}
+	if len(rows) != 2 || rows[0].Name != "info" || rows[1].Name != "old-orders" ||
+		strings.Join(rows[0].Recipients, ",") != "me@example.com" {
+		t.Errorf("unexpected plan rows %+v", rows)
+	}
+
+	if _, _, err := run("--plan", filepath.Join(t.TempDir(), "plan.csv")); err == nil {
+		t.Error("expected a CSV plan to be rejected")
+	}
+	if _, err := os.Stat(plan); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/output"
+	"github.com/ginsys/forward-email/pkg/units"
+)
+
+// expiresLabelPrefix marks the managed label holding an alias expiry as an
+// RFC 3339 UTC timestamp, e.g. expires:2025-01-31T12:00:00Z.
+const expiresLabelPrefix = "expires:"
+
+var (
+	aliasExpiresIn        string
+	aliasExpireAction     string
+	aliasExpireDryRun     bool
+	aliasExpireAllDomains bool
+)
+
+// aliasExpireCmd groups the alias expiry commands
+var aliasExpireCmd = &cobra.Command{
+	Use:   "expire",
+	Short: "Manage expiring aliases",
+	Long: `Aliases created with --expires-in carry an expires:<timestamp> label.
+'alias expire run' disables or deletes the ones that have expired.`,
+}
+
+var aliasExpireRunCmd = &cobra.Command{
+	Use:   "run [domain...]",
+	Short: "Disable or delete expired aliases",
+	Long: `Find aliases whose expires: label is in the past and disable (default) or
+delete them, reporting every alias acted on. Aliases that are already disabled
+are skipped with --action disable. Safe to run from cron.`,
+	Example: `  forward-email alias expire run example.com
+  forward-email alias expire run --all-domains --action delete --dry-run`,
+	RunE: runAliasExpireRun,
+}
+
+func init() {
+	aliasCmd.AddCommand(aliasExpireCmd)
+	aliasExpireCmd.AddCommand(aliasExpireRunCmd)
+
+	aliasCreateCmd.Flags().StringVar(&aliasExpiresIn, "expires-in", "",
+		"Expire the alias after this long (e.g. 7d, 2w, 12h); see 'alias expire run'")
+
+	aliasExpireRunCmd.Flags().StringVar(&aliasExpireAction, "action", "disable", "What to do with expired aliases: disable|delete")
+	aliasExpireRunCmd.Flags().BoolVar(&aliasExpireDryRun, "dry-run", false, "Report expired aliases without changing them")
+	aliasExpireRunCmd.Flags().BoolVar(&aliasExpireAllDomains, "all-domains", false, "Process aliases from all available domains")
+}
+
+// parseExpiresIn parses a positive duration, accepting d (days) and w (weeks)
+// in addition to the units understood by time.ParseDuration.
+func parseExpiresIn(s string) (time.Duration, error) {
+	d, err := units.ParseDuration(s)
+	if err != nil || d == 0 {
+		return 0, fmt.Errorf("invalid duration %q (use e.g. 30m, 12h, 7d or 2w)", strings.TrimSpace(s))
+	}
+	return d, nil
+}
+
+// aliasExpiry returns the expiry recorded in labels, if any.
+func aliasExpiry(labels []string) (time.Time, bool) {
+	for _, l := range labels {
+		if v, ok := strings.CutPrefix(l, expiresLabelPrefix); ok {
+			if t, err := time.Parse(time.RFC3339, v); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// expiryLabels returns labels with an expires: label set to now+expiresIn.
+func expiryLabels(labels []string, expiresIn string, now time.Time) ([]string, error) {
+	d, err := parseExpiresIn(expiresIn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --expires-in: %v", err)
+	}
+	return withManagedLabel(labels, expiresLabelPrefix, now.Add(d).UTC().Format(time.RFC3339)), nil
+}
+
+func runAliasExpireRun(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	action := strings.ToLower(strings.TrimSpace(aliasExpireAction))
+	if action != "disable" && action != "delete" {
+		return fmt.Errorf("invalid --action: %s (valid: disable|delete)", aliasExpireAction)
+	}
+
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %v", err)
+	}
+
+	domains, err := resolveAliasDomains(ctx, cmd, apiClient, args, aliasExpireAllDomains)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	results := []output.AliasExpiryResult{}
+	failed := 0
+	for _, domain := range domains {
+		aliases, listErr := listAllAliases(ctx, apiClient, domain)
+		if listErr != nil {
+			return fmt.Errorf("failed to list aliases for %s: %v", domain, listErr)
+		}
+		for i := range aliases {
+			a := &aliases[i]
+			expiresAt, ok := aliasExpiry(a.Labels)
+			if !ok || expiresAt.After(now) || (action == "disable" && !a.IsEnabled) {
+				continue
+			}
+
+			res := output.AliasExpiryResult{Alias: a.Name, Domain: domain, ExpiresAt: expiresAt}
+			var actErr error
+			switch {
+			case aliasExpireDryRun:
+				res.Action = "would " + action
+			case action == "delete":
+				res.Action = "deleted"
+				actErr = apiClient.Aliases.DeleteAlias(ctx, domain, a.ID)
+			default:
+				res.Action = "disabled"
+				disabled := false
+				_, actErr = apiClient.Aliases.UpdateAlias(ctx, domain, a.ID, &api.UpdateAliasRequest{IsEnabled: &disabled})
+			}
+			if actErr != nil {
+				res.Error = actErr.Error()
+				failed++
+			} else if !aliasExpireDryRun {
+				reportMutation(hookResource{Type: "alias", Action: action, Domain: domain, Name: a.Name, ID: a.ID})
+			}
+			results = append(results, res)
+		}
+	}
+
+	format, err := output.ParseFormat(viper.GetString("output"))
+	if err != nil {
+		return fmt.Errorf("invalid output format: %v", err)
+	}
+	formatter := output.NewFormatter(format, cmd.OutOrStdout())
+	if format == output.FormatJSON || format == output.FormatYAML {
+		if err := formatter.Format(results); err != nil {
+			return err
+		}
+	} else if len(results) == 0 {
+		cmd.PrintErrln("No expired aliases found")
+	} else {
+		tableData, err := output.FormatAliasExpiryResults(results, format)
+		if err != nil {
+			return fmt.Errorf("failed to format output: %v", err)
+		}
+		if err := formatter.Format(tableData); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to %s %d expired alias(es)", action, failed)
+	}
+	return nil
+}
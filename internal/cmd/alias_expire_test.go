@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+func TestParseExpiresIn(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "7d", want: 7 * 24 * time.Hour},
+		{in: "2w", want: 14 * 24 * time.Hour},
+		{in: "90m", want: 90 * time.Minute},
+		{in: "0d", wantErr: true},
+		{in: "-1h", wantErr: true},
+		{in: "soon", wantErr: true},
+		{in: "d", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseExpiresIn(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseExpiresIn(%q) = %v, %v", tt.in, got, err)
+		}
+	}
+}
+
+func TestAliasExpire_CreateAndRun(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    aliases:
+      - name: old-signup
+        recipients: [me@example.org]
+        labels: [expires:2020-01-01T00:00:00Z]
+        is_enabled: true
+      - name: done
+        recipients: [me@example.org]
+        labels: [expires:2020-01-01T00:00:00Z]
+        is_enabled: false
+      - name: info
+        recipients: [me@example.org]
+        is_enabled: true
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetAliasFlags()
+		aliasExpireAction, aliasExpireDryRun = "disable", false
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	})
+
+	run := func(args ...string) string {
+		t.Helper()
+		var buf bytes.Buffer
+		rootCmd.SetOut(&buf)
+		rootCmd.SetErr(&buf)
+		rootCmd.SetArgs(args)
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("%v: %v\n%s", args, err, buf.String())
+		}
+		return buf.String()
+	}
+
+	run("alias", "create", "example.com", "shop", "--recipients", "me@example.org", "--expires-in", "7d")
+	c, err := client.NewAPIClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	created, err := c.Aliases.GetAlias(context.Background(), "example.com", "shop")
+	if err != nil {
+		t.Fatalf("get created alias: %v", err)
+	}
+	expiresAt, ok := aliasExpiry(created.Labels)
+	if !ok || expiresAt.Before(time.Now().Add(6*24*time.Hour)) || expiresAt.After(time.Now().Add(8*24*time.Hour)) {
+		t.Fatalf("expected an expiry ~7 days out, got labels %v", created.Labels)
+	}
+
+	var results []output.AliasExpiryResult
+	out := run("alias", "expire", "run", "example.com", "--dry-run", "-o", "json")
+	if err := json.Unmarshal([]byte(out), &results); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(results) != 1 || results[0].Alias != "old-signup" || results[0].Action != "would disable" {
+		t.Fatalf("unexpected dry-run results: %+v", results)
+	}
+
+	aliasExpireDryRun = false
+	out = run("alias", "expire", "run", "example.com", "--action", "delete", "-o", "table")
+	for _, want := range []string{"old-signup@example.com", "done@example.com", "deleted"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+	if _, err := c.Aliases.GetAlias(context.Background(), "example.com", "old-signup"); err == nil {
+		t.Error("expected expired alias to be deleted")
+	}
+	if _, err := c.Aliases.GetAlias(context.Background(), "example.com", "info"); err != nil {
+		t.Errorf("alias without expiry should be kept: %v", err)
+	}
+}
@@ -0,0 +1,286 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+var aliasGraphAllDomains bool
+
+var aliasGraphCmd = &cobra.Command{
+	Use:   "graph [domain...]",
+	Short: "Render aliases and their recipients as a graph",
+	Long: `Render the forwarding topology of one or more domains: every alias with an
+edge to each recipient, whether an outside address, a webhook, a mail server or
+another alias. Recipients at a graphed domain that has no such alias go to its
+catch-all (*) alias when there is one.
+
+Forwarding loops, where aliases forward to each other in a cycle, are drawn in
+red and listed on stderr.
+
+Output formats:
+  dot       Graphviz (render with: dot -Tsvg)
+  mermaid   Mermaid flowchart, e.g. for Markdown documentation
+  table     one row per edge (default); csv, json and yaml also work`,
+	Example: `  forward-email alias graph example.com -o dot | dot -Tsvg > aliases.svg
+  forward-email alias graph --all-domains -o mermaid`,
+	RunE: runAliasGraph,
+}
+
+func init() {
+	aliasCmd.AddCommand(aliasGraphCmd)
+	aliasGraphCmd.Flags().BoolVar(&aliasGraphAllDomains, "all-domains", false, "Graph the aliases of all available domains")
+}
+
+func runAliasGraph(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	outputFlag := strings.ToLower(viper.GetString("output"))
+	var format output.Format
+	switch output.Format(outputFlag) {
+	case output.FormatDOT, output.FormatMermaid:
+		format = output.Format(outputFlag)
+	default:
+		var err error
+		if format, err = output.ParseFormat(outputFlag); err != nil {
+			return fmt.Errorf("invalid output format: %v (alias graph also supports dot and mermaid)", err)
+		}
+	}
+
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %v", err)
+	}
+	domains, err := resolveAliasDomains(ctx, cmd, apiClient, args, aliasGraphAllDomains)
+	if err != nil {
+		return err
+	}
+	aliases := make([][]api.Alias, len(domains))
+	for i, domain := range domains {
+		if aliases[i], err = listAllAliases(ctx, apiClient, domain); err != nil {
+			return fmt.Errorf("failed to list aliases for %s: %v", domain, err)
+		}
+	}
+
+	graph := buildAliasGraph(domains, aliases)
+	var loops []string
+	for _, loop := range graph.Loops {
+		loops = append(loops, strings.Join(slices.Concat(loop, loop[:1]), " → "))
+	}
+	defer printWarnings(cmd, fmt.Sprintf("%d forwarding loop(s)", len(loops)), loops)
+
+	out := cmd.OutOrStdout()
+	switch format {
+	case output.FormatDOT:
+		return output.WriteAliasGraphDOT(out, graph)
+	case output.FormatMermaid:
+		return output.WriteAliasGraphMermaid(out, graph)
+	case output.FormatJSON, output.FormatYAML:
+		return output.NewFormatter(format, out).Format(graph)
+	}
+	tableData, err := output.FormatAliasGraphEdges(graph, format)
+	if err != nil {
+		return fmt.Errorf("failed to format output: %v", err)
+	}
+	return output.NewFormatter(format, out).Format(tableData)
+}
+
+// buildAliasGraph builds the forwarding graph of the given domains, whose
+// aliases are indexed like domains. Alias nodes come first in domain and name
+// order, followed by the other recipients sorted by name.
+func buildAliasGraph(domains []string, aliases [][]api.Alias) *output.AliasGraph {
+	g := &output.AliasGraph{Nodes: []output.GraphNode{}, Edges: []output.GraphEdge{}, Loops: [][]string{}}
+
+	// Addresses are matched case-insensitively, as mail servers do.
+	aliasIDs := map[string]string{}
+	type source struct {
+		id         string
+		recipients []string
+	}
+	var sources []source
+	for i, domain := range domains {
+		list := append([]api.Alias(nil), aliases[i]...)
+		sort.Slice(list, func(a, b int) bool { return list[a].Name < list[b].Name })
+		for _, a := range list {
+			id := a.Name + "@" + domain
+			aliasIDs[strings.ToLower(id)] = id
+			g.Nodes = append(g.Nodes, output.GraphNode{ID: id, Kind: output.NodeAlias, Disabled: !a.IsEnabled})
+			sources = append(sources, source{id: id, recipients: a.Recipients})
+		}
+	}
+	graphed := make(map[string]bool, len(domains))
+	for _, d := range domains {
+		graphed[strings.ToLower(d)] = true
+	}
+
+	others := map[string]string{}
+	for _, s := range sources {
+		for _, r := range s.recipients {
+			r = strings.TrimSpace(r)
+			if r == "" {
+				continue
+			}
+			target, kind := classifyRecipient(r, aliasIDs, graphed)
+			if kind != output.NodeAlias {
+				others[target] = kind
+			}
+			g.Edges = append(g.Edges, output.GraphEdge{From: s.id, To: target})
+		}
+	}
+	otherIDs := make([]string, 0, len(others))
+	for id := range others {
+		otherIDs = append(otherIDs, id)
+	}
+	sort.Strings(otherIDs)
+	for _, id := range otherIDs {
+		g.Nodes = append(g.Nodes, output.GraphNode{ID: id, Kind: others[id]})
+	}
+
+	markForwardingLoops(g)
+	return g
+}
+
+// classifyRecipient resolves a recipient to a graph node: an alias of a graphed
+// domain (directly or through its catch-all), a webhook, an outside address or
+// a mail server.
+func classifyRecipient(r string, aliasIDs map[string]string, graphed map[string]bool) (id, kind string) {
+	lower := strings.ToLower(r)
+	switch {
+	case strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://"):
+		return r, output.NodeWebhook
+	case !strings.Contains(r, "@"):
+		return r, output.NodeHost
+	}
+	if id, ok := aliasIDs[lower]; ok {
+		return id, output.NodeAlias
+	}
+	if _, domain, _ := strings.Cut(lower, "@"); graphed[domain] {
+		if id, ok := aliasIDs["*@"+domain]; ok {
+			return id, output.NodeAlias
+		}
+	}
+	return r, output.NodeAddress
+}
+
+// markForwardingLoops finds the strongly connected groups of aliases that
+// forward to each other, flags the edges inside them and records one cycle
+// through each group in g.Loops.
+func markForwardingLoops(g *output.AliasGraph) {
+	next := map[string][]string{}
+	isAlias := map[string]bool{}
+	var order []string
+	for _, n := range g.Nodes {
+		if n.Kind == output.NodeAlias {
+			isAlias[n.ID] = true
+			order = append(order, n.ID)
+		}
+	}
+	selfLoop := map[string]bool{}
+	for _, e := range g.Edges {
+		if isAlias[e.To] {
+			next[e.From] = append(next[e.From], e.To)
+			if e.From == e.To {
+				selfLoop[e.From] = true
+			}
+		}
+	}
+
+	// Tarjan's algorithm
+	index, low := map[string]int{}, map[string]int{}
+	onStack := map[string]bool{}
+	var stack []string
+	component := map[string]int{}
+	var components [][]string
+	var visit func(v string)
+	visit = func(v string) {
+		index[v], low[v] = len(index), len(index)
+		stack = append(stack, v)
+		onStack[v] = true
+		for _, w := range next[v] {
+			if _, seen := index[w]; !seen {
+				visit(w)
+				low[v] = min(low[v], low[w])
+			} else if onStack[w] {
+				low[v] = min(low[v], index[w])
+			}
+		}
+		if low[v] != index[v] {
+			return
+		}
+		var members []string
+		for {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[w] = false
+			component[w] = len(components)
+			members = append(members, w)
+			if w == v {
+				break
+			}
+		}
+		components = append(components, members)
+	}
+	for _, v := range order {
+		if _, seen := index[v]; !seen {
+			visit(v)
+		}
+	}
+
+	looping := map[int]bool{}
+	for c, members := range components {
+		looping[c] = len(members) > 1 || selfLoop[members[0]]
+	}
+	for i, e := range g.Edges {
+		if isAlias[e.To] && component[e.From] == component[e.To] && looping[component[e.From]] {
+			g.Edges[i].Loop = true
+		}
+	}
+
+	// Report each loop once, starting from its first alias in node order.
+	reported := map[int]bool{}
+	for _, v := range order {
+		c := component[v]
+		if !looping[c] || reported[c] {
+			continue
+		}
+		reported[c] = true
+		g.Loops = append(g.Loops, shortestCycle(v, next, func(w string) bool { return component[w] == c }))
+	}
+}
+
+// shortestCycle returns the aliases on a shortest forwarding path from start
+// back to itself, staying among aliases accepted by within.
+func shortestCycle(start string, next map[string][]string, within func(string) bool) []string {
+	prev := map[string]string{}
+	queue := []string{start}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for _, w := range next[v] {
+			if !within(w) {
+				continue
+			}
+			if w == start {
+				cycle := []string{v}
+				for cycle[0] != start {
+					cycle = append([]string{prev[cycle[0]]}, cycle...)
+				}
+				return cycle
+			}
+			if _, seen := prev[w]; !seen {
+				prev[w] = v
+				queue = append(queue, w)
+			}
+		}
+	}
+	return []string{start}
+}
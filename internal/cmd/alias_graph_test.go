@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/auth"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+func TestBuildAliasGraph(t *testing.T) {
+	g := buildAliasGraph([]string{"example.com", "example.org"}, [][]api.Alias{
+		{
+			{Name: "sales", IsEnabled: true, Recipients: []string{"Team@Example.org", "https://hooks.example.net/mail"}},
+			{Name: "team", IsEnabled: true, Recipients: []string{"sales@example.com"}},
+			{Name: "echo", IsEnabled: false, Recipients: []string{"echo@example.com"}},
+		},
+		{
+			{Name: "team", IsEnabled: true, Recipients: []string{"jane@corp.com", "nobody@example.com", "mx.corp.com"}},
+			{Name: "*", IsEnabled: true, Recipients: []string{"ops@corp.com"}},
+		},
+	})
+
+	var edges []string
+	for _, e := range g.Edges {
+		edge := e.From + " -> " + e.To
+		if e.Loop {
+			edge += " (loop)"
+		}
+		edges = append(edges, edge)
+	}
+	want := []string{
+		"echo@example.com -> echo@example.com (loop)",
+		"sales@example.com -> team@example.org",
+		"sales@example.com -> https://hooks.example.net/mail",
+		"team@example.com -> sales@example.com",
+		"*@example.org -> ops@corp.com",
+		"team@example.org -> jane@corp.com",
+		"team@example.org -> nobody@example.com",
+		"team@example.org -> mx.corp.com",
+	}
+	if strings.Join(edges, "\n") != strings.Join(want, "\n") {
+		t.Errorf("edges:\n%s\nwant:\n%s", strings.Join(edges, "\n"), strings.Join(want, "\n"))
+	}
+
+	kinds := map[string]string{}
+	for _, n := range g.Nodes {
+		kinds[n.ID] = n.Kind
+	}
+	if kinds["https://hooks.example.net/mail"] != output.NodeWebhook || kinds["mx.corp.com"] != output.NodeHost ||
+		kinds["nobody@example.com"] != output.NodeAddress || kinds["team@example.org"] != output.NodeAlias {
+		t.Errorf("unexpected node kinds %v", kinds)
+	}
+	if len(g.Loops) != 1 || strings.Join(g.Loops[0], ",") != "echo@example.com" {
+		t.Errorf("unexpected loops %v", g.Loops)
+	}
+}
+
+func TestAliasGraphCommand(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    aliases:
+      - name: a
+        recipients: [b@example.com]
+      - name: b
+        recipients: [c@example.com, me@example.org]
+      - name: c
+        recipients: [a@example.com]
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() { _ = rootCmd.PersistentFlags().Set("output", "table") })
+
+	run := func(format string) (string, string) {
+		var stdout, stderr bytes.Buffer
+		rootCmd.SetOut(&stdout)
+		rootCmd.SetErr(&stderr)
+		rootCmd.SetArgs([]string{"alias", "graph", "example.com", "-o", format})
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("alias graph -o %s: %v", format, err)
+		}
+		return stdout.String(), stderr.String()
+	}
+
+	dot, stderr := run("dot")
+	for _, want := range []string{
+		"digraph aliases {",
+		`"a@example.com" -> "b@example.com" [color=red, penwidth=2];`,
+		`"b@example.com" -> "me@example.org";`,
+		`"me@example.org" [label="me@example.org", shape=ellipse];`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("expected %q in DOT output:\n%s", want, dot)
+		}
+	}
+	if !strings.Contains(stderr, "1 forwarding loop(s):\n  - a@example.com → b@example.com → c@example.com → a@example.com") {
+		t.Errorf("expected the loop on stderr, got:\n%s", stderr)
+	}
+
+	mermaid, _ := run("mermaid")
+	for _, want := range []string{"flowchart LR", `n0["a@example.com"]`, "n0 --> n1", "linkStyle 0,1,3 stroke:red"} {
+		if !strings.Contains(mermaid, want) {
+			t.Errorf("expected %q in Mermaid output:\n%s", want, mermaid)
+		}
+	}
+}
@@ -0,0 +1,546 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/output"
+	"github.com/ginsys/forward-email/pkg/planner"
+	"github.com/ginsys/forward-email/pkg/policy"
+	"github.com/ginsys/forward-email/pkg/schema"
+)
+
+// aliasFileVersion is the format version of alias export files. Version 1
+// files (name, recipients, enabled, labels, description) carry no version and
+// are still read; version 2 adds IMAP, PGP and vacation settings.
+const aliasFileVersion = 2
+
+// aliasCSVHeader is the column order of CSV exports. The last column holds
+// the format version on every row, so the file stays a plain CSV table.
+var aliasCSVHeader = []string{
+	"Name", "Recipients", "Enabled", "Labels", "Description", "IMAP", "PGP", "Public Key",
+	"Vacation Enabled", "Vacation Subject", "Vacation Message", "Vacation Start", "Vacation End",
+	"Format Version",
+}
+
+// aliasImportRow is one alias read from an import file. Nil fields were not
+// given and keep their current (or default) values.
+type aliasImportRow struct {
+	Name        string               `yaml:"name" json:"name"`
+	Recipients  []string             `yaml:"recipients" json:"recipients"`
+	Enabled     *bool                `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	Labels      []string             `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Description *string              `yaml:"description,omitempty" json:"description,omitempty"`
+	IMAP        *bool                `yaml:"imap,omitempty" json:"imap,omitempty"`
+	PGP         *bool                `yaml:"pgp,omitempty" json:"pgp,omitempty"`
+	PublicKey   *string              `yaml:"public_key,omitempty" json:"public_key,omitempty"`
+	Vacation    *aliasImportVacation `yaml:"vacation,omitempty" json:"vacation,omitempty"`
+}
+
+// aliasImportVacation is the vacation responder of an import row. Dates are
+// RFC 3339 times or plain YYYY-MM-DD dates.
+type aliasImportVacation struct {
+	Enabled   bool   `yaml:"enabled" json:"enabled"`
+	Subject   string `yaml:"subject,omitempty" json:"subject,omitempty"`
+	Message   string `yaml:"message,omitempty" json:"message,omitempty"`
+	StartDate string `yaml:"start_date,omitempty" json:"start_date,omitempty"`
+	EndDate   string `yaml:"end_date,omitempty" json:"end_date,omitempty"`
+}
+
+// aliasFile is the YAML/JSON import and export document.
+type aliasFile struct {
+	Version int              `yaml:"version,omitempty" json:"version,omitempty"`
+	Aliases []aliasImportRow `yaml:"aliases" json:"aliases"`
+}
+
+// readAliasImportFile reads and validates an import file, choosing YAML/JSON
+// or CSV by extension.
+func readAliasImportFile(path string) ([]aliasImportRow, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open import file: %v", err)
+	}
+	var rows []aliasImportRow
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml", ".json":
+		rows, err = parseAliasImportYAML(data)
+	default:
+		rows, err = parseAliasImportCSV(data)
+	}
+	if err != nil {
+		var errs schema.Errors
+		if errors.As(err, &errs) {
+			return nil, fmt.Errorf("invalid import file %s:\n%v", path, err)
+		}
+		return nil, err
+	}
+	for _, row := range rows {
+		if _, err := row.vacation(); err != nil {
+			return nil, fmt.Errorf("invalid import file %s: alias '%s': %v", path, row.Name, err)
+		}
+	}
+	return rows, nil
+}
+
+func parseAliasImportYAML(data []byte) ([]aliasImportRow, error) {
+	if err := schema.Validate(schema.AliasImport, data); err != nil {
+		return nil, err
+	}
+	var doc aliasFile
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse import file: %v", err)
+	}
+	if err := checkAliasFileVersion(doc.Version); err != nil {
+		return nil, err
+	}
+	return doc.Aliases, nil
+}
+
+func checkAliasFileVersion(v int) error {
+	if v > aliasFileVersion {
+		return fmt.Errorf("import file has format version %d, but this version of forward-email reads up to %d", v, aliasFileVersion)
+	}
+	return nil
+}
+
+// parseAliasImportCSV validates the CSV rows against the alias-import-csv
+// schema, keeping the line and column of every field for error messages.
+func parseAliasImportCSV(data []byte) ([]aliasImportRow, error) {
+	r := csv.NewReader(strings.NewReader(string(data)))
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("empty CSV")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV: %v", err)
+	}
+	for i := range header {
+		header[i] = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(header[i])), " ", "_")
+	}
+
+	doc := &yaml.Node{Kind: yaml.SequenceNode, Line: 1, Column: 1}
+	var records [][]string
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %v", err)
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		line, _ := r.FieldPos(0)
+		row := &yaml.Node{Kind: yaml.MappingNode, Line: line, Column: 1}
+		for i, value := range record {
+			if i >= len(header) || header[i] == "" {
+				continue
+			}
+			l, c := r.FieldPos(i)
+			row.Content = append(row.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: header[i], Line: l, Column: c},
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: strings.TrimSpace(value), Line: l, Column: c})
+		}
+		doc.Content = append(doc.Content, row)
+		records = append(records, record)
+	}
+	if err := checkAliasCSVVersion(header, records); err != nil {
+		return nil, err
+	}
+	if err := schema.ValidateNode(schema.AliasImportCSV, doc); err != nil {
+		return nil, err
+	}
+
+	col := make(map[string]int, len(header))
+	for i, h := range header {
+		col[h] = i
+	}
+	field := func(record []string, name string) (string, bool) {
+		if i, ok := col[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i]), true
+		}
+		return "", false
+	}
+	boolField := func(record []string, name string) *bool {
+		s, _ := field(record, name)
+		if s == "" {
+			return nil
+		}
+		s = strings.ToLower(s)
+		v := s == "true" || s == "1" || s == yesStr
+		return &v
+	}
+	stringField := func(record []string, name string) *string {
+		if s, _ := field(record, name); s != "" {
+			return &s
+		}
+		return nil
+	}
+	rows := make([]aliasImportRow, 0, len(records))
+	for _, record := range records {
+		name, _ := field(record, "name")
+		recipients, _ := field(record, "recipients")
+		labels, _ := field(record, "labels")
+		row := aliasImportRow{
+			Name:        name,
+			Recipients:  splitCSVList(recipients),
+			Labels:      splitCSVList(labels),
+			Enabled:     boolField(record, "enabled"),
+			Description: stringField(record, "description"),
+			IMAP:        boolField(record, "imap"),
+			PGP:         boolField(record, "pgp"),
+			PublicKey:   stringField(record, "public_key"),
+		}
+		vac := aliasImportVacation{}
+		vac.Subject, _ = field(record, "vacation_subject")
+		vac.Message, _ = field(record, "vacation_message")
+		vac.StartDate, _ = field(record, "vacation_start")
+		vac.EndDate, _ = field(record, "vacation_end")
+		enabled := boolField(record, "vacation_enabled")
+		if enabled != nil || vac != (aliasImportVacation{}) {
+			vac.Enabled = derefBool(enabled)
+			row.Vacation = &vac
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// checkAliasCSVVersion rejects CSV files whose Format Version column names a
+// newer format. Files without the column are version 1.
+func checkAliasCSVVersion(header []string, records [][]string) error {
+	i := slices.Index(header, "format_version")
+	if i < 0 {
+		return nil
+	}
+	for _, record := range records {
+		if i >= len(record) {
+			continue
+		}
+		if v, err := strconv.Atoi(strings.TrimSpace(record[i])); err == nil {
+			if err := checkAliasFileVersion(v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// entry returns the row as the desired state of its alias. Rows are
+// validated when read, so the vacation dates parse.
+func (row aliasImportRow) entry() planner.Entry {
+	vacation, _ := row.vacation()
+	return planner.Entry{
+		Name: row.Name, Recipients: row.Recipients, Enabled: row.Enabled, Labels: row.Labels, Description: row.Description,
+		IMAP: row.IMAP, PGP: row.PGP, PublicKey: row.PublicKey, Vacation: vacation,
+	}
+}
+
+// vacation returns the row's vacation responder for the API, or nil when the
+// row has none.
+func (row aliasImportRow) vacation() (*api.VacationResponder, error) {
+	v := row.Vacation
+	if v == nil {
+		return nil, nil
+	}
+	out := &api.VacationResponder{IsEnabled: v.Enabled, Subject: v.Subject, Message: v.Message}
+	var err error
+	if out.StartDate, err = parseVacationDate(v.StartDate); err != nil {
+		return nil, fmt.Errorf("invalid vacation start date: %v", err)
+	}
+	if out.EndDate, err = parseVacationDate(v.EndDate); err != nil {
+		return nil, fmt.Errorf("invalid vacation end date: %v", err)
+	}
+	return out, nil
+}
+
+func parseVacationDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is neither an RFC 3339 time nor YYYY-MM-DD", s)
+	}
+	return t, nil
+}
+
+func formatVacationDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// aliasExportRow converts an alias to its export form, with every setting
+// given so that importing it restores the alias as it was.
+func aliasExportRow(a api.Alias) aliasImportRow {
+	row := aliasImportRow{
+		Name:       a.Name,
+		Recipients: a.Recipients,
+		Enabled:    &a.IsEnabled,
+		Labels:     a.Labels,
+		IMAP:       &a.HasIMAP,
+		PGP:        &a.HasPGP,
+	}
+	if a.Description != "" {
+		row.Description = &a.Description
+	}
+	if a.PublicKey != "" {
+		row.PublicKey = &a.PublicKey
+	}
+	if v := a.Vacation; v != nil {
+		row.Vacation = &aliasImportVacation{
+			Enabled:   v.IsEnabled,
+			Subject:   v.Subject,
+			Message:   v.Message,
+			StartDate: formatVacationDate(v.StartDate),
+			EndDate:   formatVacationDate(v.EndDate),
+		}
+	}
+	return row
+}
+
+// writeAliasesFile exports aliases to path, as YAML or JSON by extension and
+// as CSV otherwise.
+func writeAliasesFile(path string, aliases []api.Alias) error {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext != ".yaml" && ext != ".yml" && ext != ".json" {
+		return writeAliasesCSV(path, aliases)
+	}
+	doc := aliasFile{Version: aliasFileVersion, Aliases: make([]aliasImportRow, 0, len(aliases))}
+	for _, a := range aliases {
+		doc.Aliases = append(doc.Aliases, aliasExportRow(a))
+	}
+	return writeAliasDocument(path, doc)
+}
+
+// writeAliasDocument writes an import document to path, as JSON when it ends
+// in .json and as YAML otherwise.
+func writeAliasDocument(path string, doc aliasFile) error {
+	var data []byte
+	var err error
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		data, err = json.MarshalIndent(doc, "", "  ")
+		data = append(data, '\n')
+	} else {
+		data, err = yaml.Marshal(doc)
+	}
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// aliasExportAddressBook returns the address book format of an alias export,
+// from --format or a .vcf --file, or "" for an import format export.
+func aliasExportAddressBook() (string, error) {
+	format := strings.ToLower(strings.TrimSpace(aliasExportFormat))
+	switch {
+	case format == "":
+		if ext := strings.ToLower(filepath.Ext(aliasExportFile)); ext == ".vcf" || ext == ".vcard" {
+			return output.AddressBookVCard, nil
+		}
+		return "", nil
+	case slices.Contains(output.AddressBookFormats, format):
+		return format, nil
+	}
+	return "", fmt.Errorf("invalid --format %q (supported: %s; use --file with .csv, .yaml or .json for import formats)",
+		aliasExportFormat, strings.Join(output.AddressBookFormats, ", "))
+}
+
+// writeAddressBookExport writes the aliases of domain as address book
+// entries to --file, or to stdout when it is not set.
+func writeAddressBookExport(cmd *cobra.Command, domain, format string, aliases []api.Alias) error {
+	entries := output.NewAddressBookEntries(domain, aliases)
+	if aliasExportFile == "" {
+		return output.WriteAddressBook(cmd.OutOrStdout(), format, entries)
+	}
+	var b bytes.Buffer
+	if err := output.WriteAddressBook(&b, format, entries); err != nil {
+		return err
+	}
+	if err := os.WriteFile(aliasExportFile, b.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("failed to write export file: %v", err)
+	}
+	cmd.PrintErrf("Exported %d addresses from %s to %s\n", len(entries), domain, aliasExportFile)
+	return nil
+}
+
+func runAliasImport(cmd *cobra.Command, args []string) error {
+	domain := strings.TrimSpace(args[0])
+	if domain == "" {
+		return fmt.Errorf("domain is required")
+	}
+	if aliasImportFile == "" {
+		return fmt.Errorf("--file is required")
+	}
+
+	rows, err := readAliasImportFile(aliasImportFile)
+	if err != nil {
+		return err
+	}
+	namingPolicy, err := loadAliasPolicy()
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %v", err)
+	}
+
+	// Fetch existing aliases to decide create/update
+	existing, err := listAllAliases(ctx, apiClient, domain)
+	if err != nil {
+		return fmt.Errorf("failed to list aliases for %s: %v", domain, err)
+	}
+	byName := mapAliasesByName(existing)
+
+	// Check every new alias against the naming policy before changing anything
+	var violations policy.Violations
+	for _, row := range rows {
+		if _, ok := byName[row.Name]; !ok {
+			violations = append(violations, namingPolicy.Check(row.Name, row.Labels)...)
+		}
+	}
+	if err := policyError(violations); err != nil {
+		return err
+	}
+
+	entries := make([]planner.Entry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, row.entry())
+	}
+	plan := planner.PlanImport(domain, existing, entries)
+	if aliasImportDryRun {
+		headers := []string{"ACTION", "ALIAS"}
+		tbl := output.NewTableData(headers)
+		for _, a := range plan.Actions {
+			tbl.AddRow([]string{strings.ToUpper(string(a.Type)), a.Name})
+		}
+		formatter := output.NewFormatter(output.FormatTable, cmd.OutOrStdout())
+		return formatter.Format(tbl)
+	}
+
+	var applied []importChange
+	var journal *opJournal
+	if !aliasImportAtomic {
+		// An atomic import leaves nothing to resume: it either completes or is rolled back
+		if journal, err = startJournal("import", domain, aliasImportResume); err != nil {
+			return err
+		}
+	}
+	prog := newProgress(cmd, "Importing", plan.Len())
+	err = planner.Apply(ctx, plan, planner.APIExecutor{Aliases: apiClient.Aliases}, planner.ApplyHooks{
+		Skip: func(a planner.Action) bool {
+			prog.Item(a.Name)
+			return journal.Done(a.Name)
+		},
+		Done: func(a planner.Action, id string) error {
+			applied = append(applied, importChange{name: a.Name, id: id, prior: a.Current, labelsSet: a.Labels != nil})
+			reportMutation(actionResource(a, id))
+			return journal.Record(a.Name)
+		},
+	})
+	prog.Finish()
+	if err != nil {
+		var actionErr *planner.ActionError
+		if errors.As(err, &actionErr) {
+			// Name only the alias: every action is in domain
+			err = fmt.Errorf("%s %s failed: %v", actionErr.Action.Type, actionErr.Action.Name, actionErr.Err)
+		}
+		if !aliasImportAtomic {
+			journal.Finish(cmd.ErrOrStderr(), err)
+			return err
+		}
+		return rollbackImport(ctx, cmd, apiClient, domain, applied, err)
+	}
+	journal.Finish(cmd.ErrOrStderr(), nil)
+	_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Imported aliases into %s from %s\n", domain, aliasImportFile)
+	return nil
+}
+
+// importChange records one alias changed by an import, so an --atomic import
+// can undo it. prior is nil for aliases the import created.
+type importChange struct {
+	name      string
+	id        string
+	prior     *api.Alias
+	labelsSet bool
+}
+
+// rollbackImport undoes applied in reverse order after cause stopped an
+// --atomic import: created aliases are deleted and updated ones get their
+// previous recipients, labels, description, enabled state, IMAP, PGP and
+// vacation settings back. Every
+// reverted change is reported, and the returned error names cause and any
+// change that could not be reverted.
+func rollbackImport(ctx context.Context, cmd *cobra.Command, apiClient *api.Client, domain string,
+	applied []importChange, cause error,
+) error {
+	out := cmd.ErrOrStderr()
+	_, _ = fmt.Fprintf(out, "❌ %v\n", cause)
+	if len(applied) == 0 {
+		return fmt.Errorf("import aborted, nothing to roll back: %v", cause)
+	}
+	_, _ = fmt.Fprintf(out, "Rolling back %d change(s) to %s:\n", len(applied), domain)
+
+	var failed []string
+	for i := len(applied) - 1; i >= 0; i-- {
+		c := applied[i]
+		if c.prior == nil {
+			if err := apiClient.Aliases.DeleteAlias(ctx, domain, c.id); err != nil {
+				failed = append(failed, c.name)
+				_, _ = fmt.Fprintf(out, "  ⚠️  %s: failed to delete: %v\n", c.name, err)
+				continue
+			}
+			_, _ = fmt.Fprintf(out, "  ↩️  %s: deleted (created by this import)\n", c.name)
+			continue
+		}
+
+		p := c.prior
+		req := &api.UpdateAliasRequest{
+			Recipients: p.Recipients, Labels: p.Labels, Description: &p.Description, IsEnabled: &p.IsEnabled,
+			HasIMAP: &p.HasIMAP, HasPGP: &p.HasPGP, PublicKey: &p.PublicKey, Vacation: p.Vacation,
+		}
+		if _, err := apiClient.Aliases.UpdateAlias(ctx, domain, c.id, req); err != nil {
+			failed = append(failed, c.name)
+			_, _ = fmt.Fprintf(out, "  ⚠️  %s: failed to restore: %v\n", c.name, err)
+			continue
+		}
+		_, _ = fmt.Fprintf(out, "  ↩️  %s: restored previous settings\n", c.name)
+		if c.labelsSet && len(p.Labels) == 0 {
+			// The API ignores an empty label list, so labels added by the import stay
+			_, _ = fmt.Fprintf(out, "  ⚠️  %s: labels added by the import could not be cleared\n", c.name)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("import failed and %d change(s) could not be rolled back (%s): %v",
+			len(failed), strings.Join(failed, ", "), cause)
+	}
+	return fmt.Errorf("import rolled back after error: %v", cause)
+}
@@ -0,0 +1,517 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/auth"
+)
+
+// resetAliasImportFlags restores the import flags, including their Changed
+// state, which mutually exclusive flag groups check.
+func resetAliasImportFlags() {
+	aliasImportCmd.Flags().VisitAll(func(f *pflag.Flag) {
+		_ = f.Value.Set(f.DefValue)
+		f.Changed = false
+	})
+}
+
+func TestParseAliasImportCSV_ReportsPositions(t *testing.T) {
+	data := "Name,Recipients,Enabled\n" +
+		"sales,a@example.org,true\n" +
+		"\n" +
+		",b@example.org,yes\n" +
+		"support,,ture\n"
+	_, err := parseAliasImportCSV([]byte(data))
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	for _, want := range []string{
+		"line 4, column 1 ([1].name): must not be empty",
+		"line 5, column 9 ([2].recipients): must not be empty",
+		`line 5, column 10 ([2].enabled): invalid value "ture"`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in:\n%v", want, err)
+		}
+	}
+
+	rows, err := parseAliasImportCSV([]byte("name,recipients,labels,notes\nsales,\"a@x, b@x\",\"x,y\",ignored\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || len(rows[0].Recipients) != 2 || len(rows[0].Labels) != 2 || rows[0].Enabled != nil {
+		t.Errorf("unexpected rows: %+v", rows)
+	}
+}
+
+func TestAliasImport_YAMLValidatedBeforeAnyChange(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir()) // progress journal
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    aliases:
+      - name: info
+        recipients: [old@example.org]
+        is_enabled: true
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(resetAliasImportFlags)
+
+	dir := t.TempDir()
+	run := func(name, content string) error {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		rootCmd.SetOut(&buf)
+		rootCmd.SetErr(&buf)
+		rootCmd.SetArgs([]string{"alias", "import", "example.com", "--file", path})
+		return rootCmd.Execute()
+	}
+
+	c, err := client.NewAPIClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	// The second entry is invalid, so nothing may be imported
+	err = run("bad.yaml", `aliases:
+  - name: sales
+    recipients: [s@example.org]
+  - name: info
+    recipients: new@example.org
+`)
+	if err == nil || !strings.Contains(err.Error(), "line 5, column 17 (aliases[1].recipients): expected array, got string") {
+		t.Fatalf("expected positioned validation error, got %v", err)
+	}
+	if list, _ := listAllAliases(ctx, c, "example.com"); len(list) != 1 {
+		t.Errorf("invalid file must not change anything, got %d aliases", len(list))
+	}
+
+	if err := run("good.json", `{"aliases": [
+  {"name": "sales", "recipients": ["s@example.org"], "labels": ["team"]},
+  {"name": "info", "recipients": ["new@example.org"], "enabled": false}
+]}`); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	info, err := c.Aliases.GetAlias(ctx, "example.com", "info")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Recipients[0] != "new@example.org" || info.IsEnabled {
+		t.Errorf("info not updated: %+v", info)
+	}
+	if sales, err := c.Aliases.GetAlias(ctx, "example.com", "sales"); err != nil || !sales.IsEnabled || sales.Labels[0] != "team" {
+		t.Errorf("sales not created: %+v, %v", sales, err)
+	}
+}
+
+func TestSchemaPrint(t *testing.T) {
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetErr(&buf)
+	rootCmd.SetArgs([]string{"schema", "print", "alias-import"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"title": "Alias import file"`) {
+		t.Errorf("unexpected schema output:\n%s", buf.String())
+	}
+
+	rootCmd.SetArgs([]string{"schema", "print", "apply"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "unknown schema") {
+		t.Errorf("expected unknown schema error, got %v", err)
+	}
+}
+
+func TestAliasImport_AtomicRollsBack(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    aliases:
+      - name: info
+        recipients: [old@example.org]
+        description: Front desk
+        is_enabled: true
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(resetAliasImportFlags)
+
+	// sales is listed twice, so its second create conflicts after info was
+	// updated and sales created
+	path := filepath.Join(t.TempDir(), "aliases.csv")
+	content := "name,recipients,enabled,description\n" +
+		"info,new@example.org,false,Changed\n" +
+		"sales,s@example.org,,\n" +
+		"sales,t@example.org,,\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetErr(&buf)
+	rootCmd.SetArgs([]string{"alias", "import", "example.com", "--file", path, "--atomic"})
+	err = rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "rolled back") || !strings.Contains(err.Error(), "create sales failed") {
+		t.Fatalf("expected rolled back error, got %v", err)
+	}
+	for _, want := range []string{"Rolling back 2 change(s)", "sales: deleted (created by this import)", "info: restored previous settings"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("expected %q in output:\n%s", want, buf.String())
+		}
+	}
+	if strings.Index(buf.String(), "sales: deleted") > strings.Index(buf.String(), "info: restored") {
+		t.Errorf("changes should be reverted in reverse order:\n%s", buf.String())
+	}
+
+	c, err := client.NewAPIClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	list, err := listAllAliases(ctx, c, "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 {
+		t.Fatalf("created aliases should be deleted, got %+v", list)
+	}
+	if info := list[0]; info.Recipients[0] != "old@example.org" || !info.IsEnabled || info.Description != "Front desk" {
+		t.Errorf("info not restored: %+v", info)
+	}
+}
+
+func TestAliasImport_ResumeFromJournal(t *testing.T) {
+	config := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", config)
+	seed, err := mockserver.ParseSeed([]byte("domains:\n  - name: example.com\n"))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(resetAliasImportFlags)
+
+	dir := t.TempDir()
+	run := func(content string, extra ...string) (string, error) {
+		t.Helper()
+		path := filepath.Join(dir, "aliases.csv")
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		rootCmd.SetOut(&buf)
+		rootCmd.SetErr(&buf)
+		rootCmd.SetArgs(append([]string{"alias", "import", "example.com", "--file", path}, extra...))
+		err := rootCmd.Execute()
+		return buf.String(), err
+	}
+
+	// The duplicate sales row fails after sales and info were created
+	out, err := run("name,recipients\nsales,s@example.org\ninfo,i@example.org\nsales,t@example.org\n")
+	if err == nil {
+		t.Fatal("expected the duplicate row to fail")
+	}
+	_, journal, ok := strings.Cut(out, "resume with --resume ")
+	journal, _, _ = strings.Cut(journal, "\n")
+	if !ok || !strings.HasPrefix(journal, filepath.Join(config, "forwardemail", "journals")) {
+		t.Fatalf("expected a resume hint with the journal path:\n%s", out)
+	}
+
+	// Re-creating sales or info would conflict, so they must be skipped
+	out, err = run("name,recipients\nsales,s@example.org\ninfo,i@example.org\nsupport,x@example.org\n", "--resume", journal)
+	if err != nil {
+		t.Fatalf("resume: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Skipped 2 item(s) completed by the previous run") {
+		t.Errorf("expected skip summary:\n%s", out)
+	}
+	if _, err := os.Stat(journal); !os.IsNotExist(err) {
+		t.Errorf("journal should be removed after a successful run: %v", err)
+	}
+
+	c, err := client.NewAPIClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list, _ := listAllAliases(context.Background(), c, "example.com"); len(list) != 3 {
+		t.Errorf("expected 3 aliases, got %+v", list)
+	}
+}
+
+func TestAliasImport_NamingPolicy(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir()) // progress journal
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    aliases:
+      - name: admin
+        recipients: [it@example.org]
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(resetAliasImportFlags)
+
+	dir := t.TempDir()
+	policyPath := filepath.Join(dir, "policy.yaml")
+	importPath := filepath.Join(dir, "aliases.yaml")
+	if err := os.WriteFile(policyPath, []byte("reserved:\n  admin: [it]\n  hr: [team-hr]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// admin already exists, so only the new hr alias is checked
+	if err := os.WriteFile(importPath, []byte(`aliases:
+  - name: admin
+    recipients: [other@example.org]
+  - name: sales
+    recipients: [s@example.org]
+  - name: hr
+    recipients: [h@example.org]
+    labels: [team-sales]
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetErr(&buf)
+	rootCmd.SetArgs([]string{"alias", "import", "example.com", "--file", importPath, "--policy-file", policyPath})
+	err = rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "alias 'hr': name is reserved for aliases labeled team-hr") ||
+		strings.Contains(err.Error(), "alias 'admin'") {
+		t.Fatalf("expected policy violation for hr only, got %v", err)
+	}
+
+	c, err := client.NewAPIClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list, _ := listAllAliases(context.Background(), c, "example.com"); len(list) != 1 {
+		t.Errorf("a policy violation must not change anything, got %d aliases", len(list))
+	}
+}
+
+func TestAliasExportImport_RoundTrip(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir()) // progress journal
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    aliases:
+      - name: info
+        recipients: [me@example.org, you@example.org]
+        labels: [team]
+        description: Front desk
+        is_enabled: true
+        has_imap: true
+        has_pgp: true
+        public_key: "-----BEGIN PGP PUBLIC KEY BLOCK-----\nmQENBF\n-----END PGP PUBLIC KEY BLOCK-----"
+        vacation:
+          is_enabled: true
+          subject: Away
+          message: "Back on Monday,\nthanks"
+          start_date: 2026-08-01T00:00:00Z
+          end_date: 2026-08-15T00:00:00Z
+      - name: old
+        recipients: [me@example.org]
+        is_enabled: false
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(resetAliasImportFlags)
+
+	c, err := client.NewAPIClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	want, err := listAllAliases(ctx, c, "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, name := range []string{"aliases.csv", "aliases.yaml", "aliases.json"} {
+		t.Run(name, func(t *testing.T) {
+			target := []string{"example.org", "example.net", "example.info"}[i]
+			if _, err := c.Domains.CreateDomain(ctx, &api.CreateDomainRequest{Name: target}); err != nil {
+				t.Fatal(err)
+			}
+			path := filepath.Join(t.TempDir(), name)
+			var out bytes.Buffer
+			rootCmd.SetOut(&out)
+			rootCmd.SetErr(&out)
+			rootCmd.SetArgs([]string{"alias", "export", "example.com", "--file", path})
+			if err := rootCmd.Execute(); err != nil {
+				t.Fatalf("export: %v\n%s", err, out.String())
+			}
+			resetAliasImportFlags()
+			rootCmd.SetArgs([]string{"alias", "import", target, "--file", path})
+			if err := rootCmd.Execute(); err != nil {
+				t.Fatalf("import: %v\n%s", err, out.String())
+			}
+			resetAliasImportFlags()
+
+			got, err := listAllAliases(ctx, c, target)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(want) {
+				t.Fatalf("expected %d aliases, got %d", len(want), len(got))
+			}
+			for j := range want {
+				w, g := want[j], got[j]
+				if g.Name != w.Name || strings.Join(g.Recipients, ",") != strings.Join(w.Recipients, ",") ||
+					strings.Join(g.Labels, ",") != strings.Join(w.Labels, ",") || g.Description != w.Description ||
+					g.IsEnabled != w.IsEnabled || g.HasIMAP != w.HasIMAP || g.HasPGP != w.HasPGP || g.PublicKey != w.PublicKey {
+					t.Errorf("alias %s not restored:\nwant %+v\ngot  %+v", w.Name, w, g)
+				}
+				if (w.Vacation == nil) != (g.Vacation == nil) {
+					t.Errorf("alias %s: vacation %+v, want %+v", w.Name, g.Vacation, w.Vacation)
+				} else if w.Vacation != nil && (g.Vacation.Subject != w.Vacation.Subject || g.Vacation.Message != w.Vacation.Message ||
+					g.Vacation.IsEnabled != w.Vacation.IsEnabled || !g.Vacation.StartDate.Equal(w.Vacation.StartDate) ||
+					!g.Vacation.EndDate.Equal(w.Vacation.EndDate)) {
+					t.Errorf("alias %s: vacation %+v, want %+v", w.Name, g.Vacation, w.Vacation)
+				}
+			}
+		})
+	}
+}
+
+func TestParseAliasImport_Versions(t *testing.T) {
+	rows, err := parseAliasImportCSV([]byte("Name,Recipients,IMAP,Vacation Enabled,Vacation Start,Format Version\n" +
+		"info,me@example.org,yes,true,2026-08-01,2\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || rows[0].IMAP == nil || !*rows[0].IMAP || rows[0].Vacation == nil || !rows[0].Vacation.Enabled {
+		t.Fatalf("unexpected rows: %+v", rows)
+	}
+	if v, err := rows[0].vacation(); err != nil || v.StartDate.Format("2006-01-02") != "2026-08-01" {
+		t.Errorf("vacation = %+v, %v", v, err)
+	}
+
+	_, err = parseAliasImportCSV([]byte("Name,Recipients,Format Version\ninfo,me@example.org,9\n"))
+	if err == nil || !strings.Contains(err.Error(), "format version 9") {
+		t.Errorf("expected a version error for CSV, got %v", err)
+	}
+	_, err = parseAliasImportYAML([]byte("version: 3\naliases:\n  - name: info\n    recipients: [me@example.org]\n"))
+	if err == nil || !strings.Contains(err.Error(), "format version 3") {
+		t.Errorf("expected a version error for YAML, got %v", err)
+	}
+}
+
+func TestAliasExport_AddressBook(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    aliases:
+      - name: sales
+        recipients: [me@example.org]
+        description: Sales team
+        is_enabled: true
+      - name: old
+        recipients: [me@example.org]
+        is_enabled: false
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() { resetCommandFlags(aliasExportCmd) })
+
+	export := func(args ...string) (string, error) {
+		t.Helper()
+		resetCommandFlags(aliasExportCmd)
+		var stdout bytes.Buffer
+		rootCmd.SetOut(&stdout)
+		rootCmd.SetErr(&bytes.Buffer{})
+		rootCmd.SetArgs(append([]string{"alias", "export", "example.com"}, args...))
+		err := rootCmd.Execute()
+		return stdout.String(), err
+	}
+
+	out, err := export("--format", "muttrc")
+	if err != nil {
+		t.Fatalf("export --format muttrc: %v", err)
+	}
+	if out != "alias sales \"Sales team\" <sales@example.com>\n" {
+		t.Errorf("unexpected muttrc export %q", out)
+	}
+
+	path := filepath.Join(t.TempDir(), "aliases.vcf")
+	if _, err := export("--file", path); err != nil {
+		t.Fatalf("export --file %s: %v", path, err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "EMAIL;TYPE=INTERNET:sales@example.com\r\n") || strings.Contains(string(data), "old@") {
+		t.Errorf("unexpected vcf export:\n%s", data)
+	}
+
+	if _, err := export("--format", "ldif"); err == nil || !strings.Contains(err.Error(), "invalid --format") {
+		t.Errorf("expected an invalid format error, got %v", err)
+	}
+	if _, err := export(); err == nil || !strings.Contains(err.Error(), "--file is required") {
+		t.Errorf("expected --file to be required without an address book format, got %v", err)
+	}
+}
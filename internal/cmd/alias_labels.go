@@ -0,0 +1,305 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+var (
+	aliasLabelsAllDomains bool
+	aliasLabelsCounts     bool
+	aliasLabelsFrom       []string
+	aliasLabelsTo         string
+	aliasLabelsDryRun     bool
+)
+
+// aliasLabelsCmd groups the commands that manage labels across aliases
+var aliasLabelsCmd = &cobra.Command{
+	Use:   "labels",
+	Short: "List, rename and merge alias labels",
+	Long: `Manage the labels used across the aliases of one or more domains. Labels
+drift into case and spelling variants such as Marketing, marketing and
+mktg, which break filtering with 'alias list --labels'; list them with
+their counts, then rename or merge the variants into one label.`,
+}
+
+var aliasLabelsListCmd = &cobra.Command{
+	Use:     "list [domain...]",
+	Aliases: []string{"ls"},
+	Short:   "List the labels in use",
+	Long: `List the labels used by the aliases of the given domains, sorted by name.
+With --counts the number of aliases and the domains using each label are
+shown too. Labels that differ only in case are pointed out on stderr.`,
+	Example: `  forward-email alias labels list example.com --counts
+  forward-email alias labels list --all-domains -o json`,
+	RunE: runAliasLabelsList,
+}
+
+var aliasLabelsRenameCmd = &cobra.Command{
+	Use:   "rename [domain...] --from <label> --to <label>",
+	Short: "Rename a label on every alias",
+	Long: `Rename a label on every alias that carries it. The new label must not be
+in use yet on those domains; use 'alias labels merge' to fold a label into
+an existing one. Labels are matched exactly, so --from Marketing leaves
+marketing alone.`,
+	Example: `  forward-email alias labels rename example.com --from Marketing --to marketing
+  forward-email alias labels rename --all-domains --from mktg --to marketing --dry-run`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(aliasLabelsFrom) != 1 {
+			return fmt.Errorf("--from takes exactly one label; use 'alias labels merge' for several")
+		}
+		return runAliasRelabel(cmd, args, false)
+	},
+}
+
+var aliasLabelsMergeCmd = &cobra.Command{
+	Use:   "merge [domain...] --from <label,...> --into <label>",
+	Short: "Merge labels into one",
+	Long: `Replace each of the --from labels with the --into label on every alias that
+carries one of them. An alias that ends up with the label twice keeps it once.
+The --into label may already be in use.`,
+	Example: `  forward-email alias labels merge example.com --from Marketing,mktg --into marketing
+  forward-email alias labels merge --all-domains --from sales-team --from Sales --into sales`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAliasRelabel(cmd, args, true)
+	},
+}
+
+func init() {
+	aliasCmd.AddCommand(aliasLabelsCmd)
+	aliasLabelsCmd.AddCommand(aliasLabelsListCmd, aliasLabelsRenameCmd, aliasLabelsMergeCmd)
+
+	for _, c := range []*cobra.Command{aliasLabelsListCmd, aliasLabelsRenameCmd, aliasLabelsMergeCmd} {
+		c.Flags().BoolVar(&aliasLabelsAllDomains, "all-domains", false, "Use the aliases of all available domains")
+	}
+	aliasLabelsListCmd.Flags().BoolVar(&aliasLabelsCounts, "counts", false, "Show the number of aliases and the domains using each label")
+
+	aliasLabelsRenameCmd.Flags().StringSliceVar(&aliasLabelsFrom, "from", nil, "Label to rename")
+	aliasLabelsRenameCmd.Flags().StringVar(&aliasLabelsTo, "to", "", "New name of the label")
+	aliasLabelsMergeCmd.Flags().StringSliceVar(&aliasLabelsFrom, "from", nil, "Labels to merge (comma-separated or repeated)")
+	aliasLabelsMergeCmd.Flags().StringVar(&aliasLabelsTo, "into", "", "Label to merge them into")
+	for _, c := range []*cobra.Command{aliasLabelsRenameCmd, aliasLabelsMergeCmd} {
+		c.Flags().BoolVar(&aliasLabelsDryRun, "dry-run", false, "Show the aliases that would change without changing them")
+		_ = c.MarkFlagRequired("from")
+	}
+	_ = aliasLabelsRenameCmd.MarkFlagRequired("to")
+	_ = aliasLabelsMergeCmd.MarkFlagRequired("into")
+}
+
+// domainAlias is an alias together with the name of its domain.
+type domainAlias struct {
+	api.Alias
+	Domain string
+}
+
+// labelAliases lists the aliases of the domains named by args, --domain or
+// --all-domains.
+func labelAliases(ctx context.Context, cmd *cobra.Command, c *api.Client, args []string) ([]domainAlias, error) {
+	domains, err := resolveAliasDomains(ctx, cmd, c, args, aliasLabelsAllDomains)
+	if err != nil {
+		return nil, err
+	}
+	var all []domainAlias
+	for _, domain := range domains {
+		aliases, err := listAllAliases(ctx, c, domain)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list aliases for %s: %v", domain, err)
+		}
+		for _, a := range aliases {
+			all = append(all, domainAlias{Alias: a, Domain: domain})
+		}
+	}
+	return all, nil
+}
+
+// labelUsage counts the aliases and domains of every label, sorted by label.
+func labelUsage(aliases []domainAlias) []output.LabelUsage {
+	byLabel := map[string]*output.LabelUsage{}
+	for _, a := range aliases {
+		for _, l := range a.Labels {
+			u := byLabel[l]
+			if u == nil {
+				u = &output.LabelUsage{Label: l, Domains: []string{}}
+				byLabel[l] = u
+			}
+			u.Aliases++
+			if !slices.Contains(u.Domains, a.Domain) {
+				u.Domains = append(u.Domains, a.Domain)
+			}
+		}
+	}
+	usage := make([]output.LabelUsage, 0, len(byLabel))
+	for _, u := range byLabel {
+		sort.Strings(u.Domains)
+		usage = append(usage, *u)
+	}
+	sort.Slice(usage, func(i, j int) bool { return usage[i].Label < usage[j].Label })
+	return usage
+}
+
+// caseVariants returns the groups of labels that differ only in case.
+func caseVariants(usage []output.LabelUsage) [][]string {
+	groups := map[string][]string{}
+	var keys []string
+	for _, u := range usage {
+		key := strings.ToLower(u.Label)
+		if groups[key] == nil {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], u.Label)
+	}
+	var variants [][]string
+	for _, key := range keys {
+		if len(groups[key]) > 1 {
+			variants = append(variants, groups[key])
+		}
+	}
+	return variants
+}
+
+func runAliasLabelsList(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %v", err)
+	}
+	aliases, err := labelAliases(ctx, cmd, apiClient, args)
+	if err != nil {
+		return err
+	}
+	usage := labelUsage(aliases)
+	for _, group := range caseVariants(usage) {
+		cmd.PrintErrf("⚠️  Labels differ only in case: %s (merge them with 'alias labels merge')\n", strings.Join(group, ", "))
+	}
+
+	format, err := output.ParseFormat(viper.GetString("output"))
+	if err != nil {
+		return fmt.Errorf("invalid output format: %v", err)
+	}
+	formatter := output.NewFormatter(format, cmd.OutOrStdout())
+	if format == output.FormatJSON || format == output.FormatYAML {
+		return formatter.Format(usage)
+	}
+	if len(usage) == 0 {
+		cmd.PrintErrln("No labels found")
+		return nil
+	}
+	tableData, err := output.FormatLabelUsage(usage, aliasLabelsCounts, format)
+	if err != nil {
+		return fmt.Errorf("failed to format output: %v", err)
+	}
+	return formatter.Format(tableData)
+}
+
+// relabel returns labels with every label in from replaced by to, keeping
+// the order and dropping duplicates, and whether anything changed.
+func relabel(labels, from []string, to string) ([]string, bool) {
+	out := make([]string, 0, len(labels))
+	changed := false
+	for _, l := range labels {
+		if slices.Contains(from, l) {
+			l, changed = to, true
+		}
+		if !slices.Contains(out, l) {
+			out = append(out, l)
+		}
+	}
+	return out, changed
+}
+
+// runAliasRelabel implements 'alias labels rename' and, with merge set,
+// 'alias labels merge'.
+func runAliasRelabel(cmd *cobra.Command, args []string, merge bool) error {
+	ctx := context.Background()
+
+	to := strings.TrimSpace(aliasLabelsTo)
+	if to == "" || strings.Contains(to, ",") {
+		return fmt.Errorf("invalid target label %q", aliasLabelsTo)
+	}
+	from := make([]string, 0, len(aliasLabelsFrom))
+	for _, l := range aliasLabelsFrom {
+		if l = strings.TrimSpace(l); l != "" && l != to {
+			from = append(from, l)
+		}
+	}
+	if len(from) == 0 {
+		return fmt.Errorf("--from must name a label other than %q", to)
+	}
+
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %v", err)
+	}
+	aliases, err := labelAliases(ctx, cmd, apiClient, args)
+	if err != nil {
+		return err
+	}
+
+	var changes []output.LabelChange
+	var targets []domainAlias
+	for _, a := range aliases {
+		if !merge && slices.Contains(a.Labels, to) {
+			return fmt.Errorf("label %q is already used by %s@%s; use 'alias labels merge' to merge into it", to, a.Name, a.Domain)
+		}
+		if labels, changed := relabel(a.Labels, from, to); changed {
+			changes = append(changes, output.LabelChange{Alias: a.Name, Domain: a.Domain, Before: a.Labels, After: labels})
+			targets = append(targets, a)
+		}
+	}
+
+	if !aliasLabelsDryRun {
+		prog := newProgress(cmd, "Relabeling aliases", len(targets))
+		for i, a := range targets {
+			prog.Item(a.Name + "@" + a.Domain)
+			req := &api.UpdateAliasRequest{Labels: changes[i].After}
+			updated, err := apiClient.Aliases.UpdateAlias(ctx, a.Domain, a.ID, req)
+			if err != nil {
+				prog.Finish()
+				return fmt.Errorf("failed to update alias %s@%s after relabeling %d of %d: %v", a.Name, a.Domain, i, len(targets), err)
+			}
+			reportMutation(hookResource{Type: "alias", Action: "update", Domain: a.Domain, Name: a.Name, ID: a.ID, Data: updated})
+		}
+		prog.Finish()
+		cmd.PrintErrf("✅ Relabeled %d aliases: %s → %s\n", len(changes), strings.Join(from, ", "), to)
+	}
+	return printLabelChanges(cmd, changes)
+}
+
+// printLabelChanges prints the relabeled aliases; with --dry-run they are the
+// command's result, otherwise a record of what was done.
+func printLabelChanges(cmd *cobra.Command, changes []output.LabelChange) error {
+	format, err := output.ParseFormat(viper.GetString("output"))
+	if err != nil {
+		return fmt.Errorf("invalid output format: %v", err)
+	}
+	formatter := output.NewFormatter(format, cmd.OutOrStdout())
+	if format == output.FormatJSON || format == output.FormatYAML {
+		if changes == nil {
+			changes = []output.LabelChange{}
+		}
+		return formatter.Format(changes)
+	}
+	if len(changes) == 0 {
+		cmd.PrintErrln("No aliases carry these labels")
+		return nil
+	}
+	if aliasLabelsDryRun {
+		cmd.PrintErrf("DRY RUN: %d aliases would be relabeled\n", len(changes))
+	}
+	tableData, err := output.FormatLabelChanges(changes, format)
+	if err != nil {
+		return fmt.Errorf("failed to format output: %v", err)
+	}
+	return formatter.Format(tableData)
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+func TestAliasLabels(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    aliases:
+      - name: a
+        recipients: [me@example.org]
+        labels: [Marketing, vip]
+      - name: b
+        recipients: [me@example.org]
+        labels: [marketing]
+      - name: c
+        recipients: [me@example.org]
+        labels: [mktg, Marketing]
+  - name: other.org
+    aliases:
+      - name: d
+        recipients: [me@example.org]
+        labels: [Marketing]
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	commands := []*cobra.Command{aliasLabelsListCmd, aliasLabelsRenameCmd, aliasLabelsMergeCmd}
+	t.Cleanup(func() {
+		for _, c := range commands {
+			resetCommandFlags(c)
+		}
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	})
+
+	run := func(args ...string) (string, string, error) {
+		t.Helper()
+		for _, c := range commands {
+			resetCommandFlags(c)
+		}
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+		var stdout, stderr bytes.Buffer
+		rootCmd.SetOut(&stdout)
+		rootCmd.SetErr(&stderr)
+		rootCmd.SetArgs(append([]string{"alias", "labels"}, args...))
+		err := rootCmd.Execute()
+		return stdout.String(), stderr.String(), err
+	}
+	labelsOf := func(domain, name string) []string {
+		t.Helper()
+		apiClient, err := client.NewAPIClient()
+		if err != nil {
+			t.Fatal(err)
+		}
+		a, err := apiClient.Aliases.GetAlias(context.Background(), domain, name)
+		if err != nil {
+			t.Fatalf("get %s: %v", name, err)
+		}
+		return a.Labels
+	}
+
+	stdout, stderr, err := run("list", "example.com", "--counts")
+	if err != nil {
+		t.Fatalf("labels list: %v", err)
+	}
+	if !strings.Contains(stdout, "ALIASES") || !strings.Contains(stdout, "mktg") {
+		t.Errorf("expected a table with counts, got:\n%s", stdout)
+	}
+	if !strings.Contains(stderr, "Labels differ only in case: Marketing, marketing") {
+		t.Errorf("expected the case variants to be pointed out, got:\n%s", stderr)
+	}
+
+	stdout, _, err = run("list", "--all-domains", "-o", "json")
+	if err != nil {
+		t.Fatalf("labels list --all-domains: %v", err)
+	}
+	var usage []output.LabelUsage
+	if err := json.Unmarshal([]byte(stdout), &usage); err != nil {
+		t.Fatalf("decode %q: %v", stdout, err)
+	}
+	i := slices.IndexFunc(usage, func(u output.LabelUsage) bool { return u.Label == "Marketing" })
+	if len(usage) != 4 || i < 0 || usage[i].Aliases != 3 || strings.Join(usage[i].Domains, ",") != "example.com,other.org" {
+		t.Errorf("unexpected label usage %s", stdout)
+	}
+
+	if _, _, err = run("rename", "example.com", "--from", "Marketing", "--to", "marketing"); err == nil ||
+		!strings.Contains(err.Error(), "alias labels merge") {
+		t.Errorf("expected rename onto a used label to be refused, got %v", err)
+	}
+
+	stdout, _, err = run("rename", "example.com", "--from", "mktg", "--to", "campaigns", "--dry-run", "-o", "json")
+	if err != nil {
+		t.Fatalf("labels rename --dry-run: %v", err)
+	}
+	var changes []output.LabelChange
+	if err := json.Unmarshal([]byte(stdout), &changes); err != nil {
+		t.Fatalf("decode %q: %v", stdout, err)
+	}
+	if len(changes) != 1 || changes[0].Alias != "c" || strings.Join(changes[0].After, ",") != "campaigns,Marketing" {
+		t.Errorf("unexpected dry run %s", stdout)
+	}
+	if got := labelsOf("example.com", "c"); strings.Join(got, ",") != "mktg,Marketing" {
+		t.Errorf("expected the dry run to change nothing, got %v", got)
+	}
+
+	if _, stderr, err = run("merge", "example.com", "--from", "Marketing,mktg", "--into", "marketing"); err != nil {
+		t.Fatalf("labels merge: %v\n%s", err, stderr)
+	}
+	if !strings.Contains(stderr, "Relabeled 2 aliases") {
+		t.Errorf("expected two aliases to be relabeled, got:\n%s", stderr)
+	}
+	for name, want := range map[string]string{"a": "marketing,vip", "b": "marketing", "c": "marketing"} {
+		if got := strings.Join(labelsOf("example.com", name), ","); got != want {
+			t.Errorf("alias %s: expected labels %s, got %s", name, want, got)
+		}
+	}
+	if got := strings.Join(labelsOf("other.org", "d"), ","); got != "Marketing" {
+		t.Errorf("expected other domains to be left alone, got %s", got)
+	}
+}
@@ -0,0 +1,288 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+var aliasLintAllDomains bool
+
+var aliasLintCmd = &cobra.Command{
+	Use:   "lint [domain...]",
+	Short: "Check aliases for forwarding mistakes",
+	Long: `Check the aliases of one or more domains for configuration mistakes:
+
+  missing-alias     (error)   a recipient at one of your domains that is neither
+                              an alias nor covered by a catch-all
+  forwarding-loop   (error)   aliases that forward to themselves or to each other
+  denied-recipient  (error)   a recipient on the domain's denylist
+  duplicate-name    (warning) alias names that differ only by case
+  disabled-target   (warning) a disabled alias that enabled aliases forward to
+  list-conflict     (warning) an entry on both the allowlist and the denylist
+
+Recipients at other domains of the account are checked against those domains'
+aliases. The command exits non-zero when there is an error finding.`,
+	Example: `  forward-email alias lint example.com
+  forward-email alias lint --all-domains -o json`,
+	SilenceUsage: true,
+	RunE:         runAliasLint,
+}
+
+func init() {
+	aliasCmd.AddCommand(aliasLintCmd)
+	aliasLintCmd.Flags().BoolVar(&aliasLintAllDomains, "all-domains", false, "Lint the aliases of all available domains")
+}
+
+func runAliasLint(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	format, err := output.ParseFormat(viper.GetString("output"))
+	if err != nil {
+		return fmt.Errorf("invalid output format: %v", err)
+	}
+
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %v", err)
+	}
+	domains, err := resolveAliasDomains(ctx, cmd, apiClient, args, aliasLintAllDomains)
+	if err != nil {
+		return err
+	}
+	account, err := apiClient.Domains.ListDomains(ctx, &api.ListDomainsOptions{Page: 1, Limit: 1000})
+	if err != nil {
+		return fmt.Errorf("failed to fetch domains: %v", err)
+	}
+	aliases, err := fetchLintAliases(ctx, apiClient, domains, account.Domains)
+	if err != nil {
+		return err
+	}
+
+	findings := lintAliases(domains, account.Domains, aliases)
+
+	formatter := output.NewFormatter(format, cmd.OutOrStdout())
+	if format == output.FormatJSON || format == output.FormatYAML {
+		if err := formatter.Format(findings); err != nil {
+			return err
+		}
+	} else {
+		if len(findings) == 0 {
+			cmd.PrintErrf("✅ No problems found in %d domain(s)\n", len(domains))
+			return nil
+		}
+		tableData, err := output.FormatFindings(findings, format)
+		if err != nil {
+			return fmt.Errorf("failed to format output: %v", err)
+		}
+		if err := formatter.Format(tableData); err != nil {
+			return err
+		}
+	}
+
+	failed := 0
+	for _, f := range findings {
+		if f.Severity == output.SeverityError {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d error(s) in %d finding(s)", failed, len(findings))
+	}
+	return nil
+}
+
+// fetchLintAliases fetches the aliases of the linted domains and of every other
+// account domain their recipients point at, keyed by lower-case domain name.
+func fetchLintAliases(ctx context.Context, c *api.Client, domains []string, account []api.Domain) (map[string][]api.Alias, error) {
+	inAccount := make(map[string]string, len(account))
+	for _, d := range account {
+		inAccount[strings.ToLower(d.Name)] = d.Name
+	}
+
+	aliases := map[string][]api.Alias{}
+	fetch := func(domain string) error {
+		list, err := listAllAliases(ctx, c, domain)
+		if err != nil {
+			return fmt.Errorf("failed to list aliases for %s: %v", domain, err)
+		}
+		aliases[strings.ToLower(domain)] = list
+		return nil
+	}
+	for _, d := range domains {
+		if err := fetch(d); err != nil {
+			return nil, err
+		}
+	}
+	for _, d := range domains {
+		for _, a := range aliases[strings.ToLower(d)] {
+			for _, r := range a.Recipients {
+				_, target, ok := strings.Cut(strings.ToLower(strings.TrimSpace(r)), "@")
+				if _, fetched := aliases[target]; !ok || fetched || inAccount[target] == "" {
+					continue
+				}
+				if err := fetch(inAccount[target]); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return aliases, nil
+}
+
+// lintAliases checks the aliases of domains. aliases holds the aliases of the
+// linted domains and of the account domains they forward to, keyed by
+// lower-case domain name; account is every domain of the account. Findings are
+// ordered by severity, then by domain and alias.
+func lintAliases(domains []string, account []api.Domain, aliases map[string][]api.Alias) []output.Finding {
+	findings := []output.Finding{}
+	add := func(severity, check, subject, message, fix string) {
+		findings = append(findings, output.Finding{Severity: severity, Check: check, Subject: subject, Message: message, Fix: fix})
+	}
+
+	info := make(map[string]api.Domain, len(account))
+	for _, d := range account {
+		info[strings.ToLower(d.Name)] = d
+	}
+	linted := make(map[string]bool, len(domains))
+	for _, d := range domains {
+		linted[strings.ToLower(d)] = true
+	}
+
+	// The forwarding graph covers every fetched domain, so that loops and
+	// references through other domains of the account are seen.
+	graphDomains := append([]string(nil), domains...)
+	var others []string
+	for d := range aliases {
+		if !linted[d] {
+			others = append(others, info[d].Name)
+		}
+	}
+	sort.Strings(others)
+	graphDomains = append(graphDomains, others...)
+	graphAliases := make([][]api.Alias, len(graphDomains))
+	for i, d := range graphDomains {
+		graphAliases[i] = aliases[strings.ToLower(d)]
+	}
+	aliasIDs := map[string]string{}
+	graphed := map[string]bool{}
+	enabled := map[string]bool{}
+	for i, d := range graphDomains {
+		graphed[strings.ToLower(d)] = true
+		for _, a := range graphAliases[i] {
+			id := a.Name + "@" + d
+			aliasIDs[strings.ToLower(id)] = id
+			enabled[id] = a.IsEnabled
+		}
+	}
+	domainOf := func(address string) string {
+		_, d, _ := strings.Cut(strings.ToLower(address), "@")
+		return d
+	}
+
+	for _, domain := range domains {
+		key := strings.ToLower(domain)
+		list := append([]api.Alias(nil), aliases[key]...)
+		sort.Slice(list, func(a, b int) bool { return list[a].Name < list[b].Name })
+
+		byName := map[string][]string{}
+		var names []string
+		for _, a := range list {
+			lower := strings.ToLower(a.Name)
+			if len(byName[lower]) == 0 {
+				names = append(names, lower)
+			}
+			byName[lower] = append(byName[lower], a.Name)
+		}
+		for _, n := range names {
+			if same := byName[n]; len(same) > 1 {
+				add(output.SeverityWarning, "duplicate-name", same[0]+"@"+domain,
+					fmt.Sprintf("aliases %s differ only by case", strings.Join(same, ", ")),
+					"delete or rename all but one; mail servers treat them as the same address")
+			}
+		}
+
+		d := info[key]
+		for _, a := range list {
+			id := a.Name + "@" + domain
+			for _, r := range a.Recipients {
+				r = strings.TrimSpace(r)
+				target, kind := classifyRecipient(r, aliasIDs, graphed)
+				if kind == output.NodeAddress && info[domainOf(r)].Name != "" {
+					var candidates []string
+					for _, other := range aliases[domainOf(r)] {
+						candidates = append(candidates, other.Name+"@"+info[domainOf(r)].Name)
+					}
+					add(output.SeverityError, "missing-alias", id,
+						fmt.Sprintf("forwards to %s, which is not an alias and has no catch-all", r),
+						"create the alias or remove the recipient"+didYouMean(r, candidates))
+				}
+				if kind == output.NodeAddress || kind == output.NodeHost {
+					if entry, ok := matchList(d.Denylist, r); ok {
+						add(output.SeverityError, "denied-recipient", id,
+							fmt.Sprintf("forwards to %s, which the denylist blocks (%s)", r, entry),
+							"remove the recipient or the denylist entry")
+					}
+				}
+				if kind == output.NodeAlias && a.IsEnabled && !enabled[target] && strings.EqualFold(target, r) {
+					add(output.SeverityWarning, "disabled-target", id,
+						fmt.Sprintf("forwards to %s, which is disabled", target),
+						"enable "+target+" or remove it from the recipients")
+				}
+			}
+		}
+
+		for _, entry := range d.Allowlist {
+			if _, ok := matchList(d.Denylist, entry); ok {
+				add(output.SeverityWarning, "list-conflict", domain,
+					fmt.Sprintf("%s is on both the allowlist and the denylist", entry),
+					"remove it from one of the lists")
+			}
+		}
+	}
+
+	graph := buildAliasGraph(graphDomains, graphAliases)
+	for _, loop := range graph.Loops {
+		touches := false
+		for _, id := range loop {
+			touches = touches || linted[domainOf(id)]
+		}
+		if !touches {
+			continue
+		}
+		message := "forwards to itself"
+		if len(loop) > 1 {
+			message = "forwarding loop " + strings.Join(slices.Concat(loop, loop[:1]), " → ")
+		}
+		add(output.SeverityError, "forwarding-loop", loop[0], message,
+			"remove one of the forwards so that mail reaches a mailbox")
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return output.SeverityRank(findings[i].Severity) < output.SeverityRank(findings[j].Severity)
+	})
+	return findings
+}
+
+// matchList reports the entry of list that matches value, an address or host:
+// the same address, or the domain of an address, ignoring case.
+func matchList(list []string, value string) (string, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	_, domain, _ := strings.Cut(value, "@")
+	for _, entry := range list {
+		e := strings.ToLower(strings.TrimSpace(entry))
+		if e != "" && (e == value || e == domain) {
+			return entry, true
+		}
+	}
+	return "", false
+}
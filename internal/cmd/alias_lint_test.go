@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/auth"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+func TestLintAliases(t *testing.T) {
+	account := []api.Domain{
+		{Name: "example.com", Allowlist: []string{"partner.net", "jane@corp.com"}, Denylist: []string{"Partner.net", "spam.biz"}},
+		{Name: "example.org"},
+	}
+	aliases := map[string][]api.Alias{
+		"example.com": {
+			{Name: "info", IsEnabled: true, Recipients: []string{"sales@example.com", "suport@example.com"}},
+			{Name: "Info", IsEnabled: true, Recipients: []string{"jane@corp.com"}},
+			{Name: "sales", IsEnabled: false, Recipients: []string{"team@example.org"}},
+			{Name: "support", IsEnabled: true, Recipients: []string{"x@spam.biz", "support@example.com"}},
+		},
+		"example.org": {
+			{Name: "team", IsEnabled: true, Recipients: []string{"sales@example.com"}},
+		},
+	}
+
+	var got []string
+	for _, f := range lintAliases([]string{"example.com"}, account, aliases) {
+		got = append(got, strings.Join([]string{f.Severity, f.Check, f.Subject, f.Message}, " | "))
+	}
+	want := []string{
+		"error | missing-alias | info@example.com | forwards to suport@example.com, which is not an alias and has no catch-all",
+		"error | denied-recipient | support@example.com | forwards to x@spam.biz, which the denylist blocks (spam.biz)",
+		"error | forwarding-loop | sales@example.com | forwarding loop sales@example.com → team@example.org → sales@example.com",
+		"error | forwarding-loop | support@example.com | forwards to itself",
+		"warning | duplicate-name | Info@example.com | aliases Info, info differ only by case",
+		"warning | disabled-target | info@example.com | forwards to sales@example.com, which is disabled",
+		"warning | list-conflict | example.com | partner.net is on both the allowlist and the denylist",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("findings:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestLintAliases_CatchAll(t *testing.T) {
+	account := []api.Domain{{Name: "example.com"}}
+	aliases := map[string][]api.Alias{
+		"example.com": {
+			{Name: "*", IsEnabled: true, Recipients: []string{"jane@corp.com"}},
+			{Name: "info", IsEnabled: true, Recipients: []string{"anything@example.com"}},
+		},
+	}
+	if findings := lintAliases([]string{"example.com"}, account, aliases); len(findings) != 0 {
+		t.Errorf("expected the catch-all to cover the recipient, got %+v", findings)
+	}
+}
+
+func TestAliasLintCommand(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    aliases:
+      - name: info
+        is_enabled: true
+        recipients: [help@example.org]
+  - name: example.org
+    aliases:
+      - name: helpdesk
+        is_enabled: true
+        recipients: [jane@corp.com]
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() { _ = rootCmd.PersistentFlags().Set("output", "table") })
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"alias", "lint", "example.com", "-o", "json"})
+	err = rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "1 error(s) in 1 finding(s)") {
+		t.Fatalf("expected a lint error, got %v", err)
+	}
+
+	var findings []output.Finding
+	if err := json.Unmarshal(stdout.Bytes(), &findings); err != nil {
+		t.Fatalf("invalid JSON %q: %v", stdout.String(), err)
+	}
+	if len(findings) != 1 || findings[0].Check != "missing-alias" || !strings.Contains(findings[0].Fix, "Did you mean 'helpdesk@example.org'?") {
+		t.Errorf("unexpected findings %+v", findings)
+	}
+}
@@ -0,0 +1,242 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+// Ownership is stored as structured alias labels so it round-trips through the
+// API, CSV export and sync without any extra storage.
+const (
+	ownerLabelPrefix = "owner:"
+	teamLabelPrefix  = "team:"
+)
+
+var (
+	aliasOwnerFlag       string
+	aliasTeamFlag        string
+	aliasOwnerAllDomains bool
+	aliasOwnerReportHTML string
+)
+
+// aliasOwnerCmd groups the alias ownership commands
+var aliasOwnerCmd = &cobra.Command{
+	Use:     "owner",
+	Aliases: []string{"own"},
+	Short:   "Manage alias ownership",
+	Long: `Record who is responsible for an alias using managed owner:<who> and
+team:<name> labels, and report on ownership across domains.`,
+}
+
+var aliasOwnerSetCmd = &cobra.Command{
+	Use:   "set [domain] <alias-id> --owner <who> [--team <name>]",
+	Short: "Set the owner and team of an alias",
+	Long: `Set the owner and/or team of an alias. Existing owner: and team: labels are
+replaced; other labels are kept. Pass an empty value to clear one.`,
+	Example: `  forward-email alias own set example.com info --owner jane@corp.com --team payments
+  forward-email alias owner set example.com info --team ""`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runAliasOwnerSet,
+}
+
+var aliasOwnerReportCmd = &cobra.Command{
+	Use:   "report [domain...]",
+	Short: "Report alias owners by owner and team",
+	Long: `List every alias grouped by owner and team. Aliases without an owner label
+are reported as (unowned) at the end.`,
+	Example: `  forward-email alias owner report example.com
+  forward-email alias owner report --all-domains -o csv
+  forward-email alias owner report --all-domains --report-html owners.html`,
+	RunE: runAliasOwnerReport,
+}
+
+func init() {
+	aliasCmd.AddCommand(aliasOwnerCmd)
+	aliasOwnerCmd.AddCommand(aliasOwnerSetCmd)
+	aliasOwnerCmd.AddCommand(aliasOwnerReportCmd)
+
+	aliasOwnerSetCmd.Flags().StringVar(&aliasOwnerFlag, "owner", "", "Owner of the alias (e.g. jane@corp.com)")
+	aliasOwnerSetCmd.Flags().StringVar(&aliasTeamFlag, "team", "", "Team responsible for the alias")
+
+	aliasOwnerReportCmd.Flags().BoolVar(&aliasOwnerAllDomains, "all-domains", false, "Report on aliases from all available domains")
+	addReportHTMLFlag(aliasOwnerReportCmd, &aliasOwnerReportHTML)
+}
+
+// aliasOwnership returns the owner and team recorded in labels.
+func aliasOwnership(labels []string) (owner, team string) {
+	for _, l := range labels {
+		switch {
+		case strings.HasPrefix(l, ownerLabelPrefix):
+			owner = strings.TrimPrefix(l, ownerLabelPrefix)
+		case strings.HasPrefix(l, teamLabelPrefix):
+			team = strings.TrimPrefix(l, teamLabelPrefix)
+		}
+	}
+	return owner, team
+}
+
+// withManagedLabel returns labels with the managed label for prefix replaced
+// by prefix+value, or removed when value is empty.
+func withManagedLabel(labels []string, prefix, value string) []string {
+	out := make([]string, 0, len(labels)+1)
+	for _, l := range labels {
+		if !strings.HasPrefix(l, prefix) {
+			out = append(out, l)
+		}
+	}
+	if value != "" {
+		out = append(out, prefix+value)
+	}
+	return out
+}
+
+// matchesOwnership reports whether alias carries the requested owner and team;
+// empty filters match anything.
+func matchesOwnership(alias *api.Alias, owner, team string) bool {
+	o, t := aliasOwnership(alias.Labels)
+	return (owner == "" || strings.EqualFold(o, owner)) && (team == "" || strings.EqualFold(t, team))
+}
+
+func runAliasOwnerSet(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	domain := aliasDomain
+	var aliasID string
+	if len(args) == 2 {
+		domain, aliasID = args[0], args[1]
+	} else {
+		aliasID = args[0]
+	}
+	if domain = withDefaultDomain(cmd, domain); domain == "" {
+		return fmt.Errorf("domain is required - specify as first argument or use --domain flag")
+	}
+
+	ownerChanged := cmd.Flags().Changed("owner")
+	teamChanged := cmd.Flags().Changed("team")
+	if !ownerChanged && !teamChanged {
+		return fmt.Errorf("at least one of --owner or --team is required")
+	}
+	owner := strings.TrimSpace(aliasOwnerFlag)
+	team := strings.TrimSpace(aliasTeamFlag)
+	if strings.ContainsAny(owner+team, ",") {
+		return fmt.Errorf("owner and team must not contain commas")
+	}
+
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %v", err)
+	}
+
+	alias, err := apiClient.Aliases.GetAlias(ctx, domain, aliasID)
+	if err != nil {
+		return fmt.Errorf("failed to get alias: %v", err)
+	}
+
+	labels := alias.Labels
+	if ownerChanged {
+		labels = withManagedLabel(labels, ownerLabelPrefix, owner)
+	}
+	if teamChanged {
+		labels = withManagedLabel(labels, teamLabelPrefix, team)
+	}
+
+	updated, err := apiClient.Aliases.UpdateAlias(ctx, domain, aliasID, &api.UpdateAliasRequest{Labels: labels})
+	if err != nil {
+		return fmt.Errorf("failed to update alias: %v", err)
+	}
+	reportMutation(hookResource{Type: "alias", Action: "update", Domain: domain, Name: updated.Name, ID: updated.ID, Data: updated})
+
+	o, t := aliasOwnership(updated.Labels)
+	if o == "" {
+		o = "(none)"
+	}
+	if t == "" {
+		t = "(none)"
+	}
+	cmd.PrintErrf("✅ Alias '%s' owner: %s, team: %s\n", updated.Name, o, t)
+	return nil
+}
+
+func runAliasOwnerReport(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %v", err)
+	}
+
+	domains, err := resolveAliasDomains(ctx, cmd, apiClient, args, aliasOwnerAllDomains)
+	if err != nil {
+		return err
+	}
+
+	entries := []output.AliasOwnership{}
+	for _, domain := range domains {
+		aliases, listErr := listAllAliases(ctx, apiClient, domain)
+		if listErr != nil {
+			return fmt.Errorf("failed to list aliases for %s: %v", domain, listErr)
+		}
+		for i := range aliases {
+			owner, team := aliasOwnership(aliases[i].Labels)
+			entries = append(entries, output.AliasOwnership{
+				Alias: aliases[i].Name, Domain: domain, Owner: owner, Team: team,
+			})
+		}
+	}
+
+	// Group by owner then team, with unowned aliases last
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if (a.Owner == "") != (b.Owner == "") {
+			return b.Owner == ""
+		}
+		if a.Owner != b.Owner {
+			return strings.ToLower(a.Owner) < strings.ToLower(b.Owner)
+		}
+		if a.Team != b.Team {
+			return a.Team < b.Team
+		}
+		if a.Domain != b.Domain {
+			return a.Domain < b.Domain
+		}
+		return a.Alias < b.Alias
+	})
+	if aliasOwnerReportHTML != "" {
+		page, err := output.NewOwnerHTMLReport(entries, time.Now())
+		if err != nil {
+			return err
+		}
+		if err := writeHTMLReportFile(cmd, aliasOwnerReportHTML, page); err != nil {
+			return err
+		}
+	}
+
+	format, err := output.ParseFormat(viper.GetString("output"))
+	if err != nil {
+		return fmt.Errorf("invalid output format: %v", err)
+	}
+	formatter := output.NewFormatter(format, cmd.OutOrStdout())
+	if format == output.FormatJSON || format == output.FormatYAML {
+		return formatter.Format(entries)
+	}
+
+	if len(entries) == 0 {
+		cmd.PrintErrln("No aliases found")
+		return nil
+	}
+	tableData, err := output.FormatAliasOwnerReport(entries, format)
+	if err != nil {
+		return fmt.Errorf("failed to format output: %v", err)
+	}
+	return formatter.Format(tableData)
+}
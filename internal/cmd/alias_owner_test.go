@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/auth"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+func TestWithOwnershipLabels(t *testing.T) {
+	labels := []string{"support", "owner:bob@corp", "team:ops"}
+
+	got := withManagedLabel(labels, ownerLabelPrefix, "jane@corp")
+	if want := []string{"support", "team:ops", "owner:jane@corp"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("replace owner: got %v, want %v", got, want)
+	}
+	got = withManagedLabel(got, teamLabelPrefix, "")
+	if want := []string{"support", "owner:jane@corp"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("clear team: got %v, want %v", got, want)
+	}
+	if owner, team := aliasOwnership(got); owner != "jane@corp" || team != "" {
+		t.Errorf("aliasOwnership = %q, %q", owner, team)
+	}
+}
+
+func TestAliasOwner_SetListReport(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    aliases:
+      - name: info
+        recipients: [me@example.org]
+        labels: [support]
+      - name: billing
+        recipients: [pay@example.org]
+        labels: [owner:jane@corp, team:payments]
+      - name: sales
+        recipients: [sales@example.org]
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetAliasFlags()
+		aliasOwnerFlag, aliasTeamFlag = "", ""
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+		for _, name := range []string{"owner", "team"} {
+			aliasOwnerSetCmd.Flags().Lookup(name).Changed = false
+			aliasListCmd.Flags().Lookup(name).Changed = false
+		}
+	})
+
+	run := func(args ...string) string {
+		t.Helper()
+		var buf bytes.Buffer
+		rootCmd.SetOut(&buf)
+		rootCmd.SetErr(&buf)
+		rootCmd.SetArgs(args)
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("%v: %v\n%s", args, err, buf.String())
+		}
+		return buf.String()
+	}
+
+	run("alias", "own", "set", "example.com", "info", "--owner", "jane@corp", "--team", "support-desk")
+
+	var listed []api.Alias
+	if err := json.Unmarshal([]byte(run("alias", "list", "example.com", "--owner", "jane@corp", "-o", "json")), &listed); err != nil {
+		t.Fatalf("invalid list JSON: %v", err)
+	}
+	if len(listed) != 2 {
+		t.Fatalf("expected 2 aliases owned by jane@corp, got %+v", listed)
+	}
+	for _, a := range listed {
+		if a.Name == "info" && !reflect.DeepEqual(a.Labels, []string{"support", "owner:jane@corp", "team:support-desk"}) {
+			t.Errorf("unexpected labels after set: %v", a.Labels)
+		}
+	}
+
+	var report []output.AliasOwnership
+	if err := json.Unmarshal([]byte(run("alias", "owner", "report", "example.com", "-o", "json")), &report); err != nil {
+		t.Fatalf("invalid report JSON: %v", err)
+	}
+	want := []output.AliasOwnership{
+		{Alias: "billing", Domain: "example.com", Owner: "jane@corp", Team: "payments"},
+		{Alias: "info", Domain: "example.com", Owner: "jane@corp", Team: "support-desk"},
+		{Alias: "sales", Domain: "example.com"},
+	}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("report = %+v, want %+v", report, want)
+	}
+}
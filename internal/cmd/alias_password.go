@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ginsys/forward-email/pkg/api"
+)
+
+// Password policy applied to locally generated and user-supplied passwords.
+const (
+	passwordMinLength     = 12
+	passwordMaxLength     = 128
+	passwordMinClasses    = 3  // character classes required below passphraseLength
+	passphraseLength      = 20 // long passwords only need passwordMinLength and two classes
+	defaultPasswordLength = 24
+)
+
+// passwordClasses maps the --classes names to their character sets.
+var passwordClasses = map[string]string{
+	"lower":   "abcdefghijkmnopqrstuvwxyz",
+	"upper":   "ABCDEFGHJKLMNPQRSTUVWXYZ",
+	"digits":  "23456789",
+	"symbols": "!#$%&*+-=?@^_~",
+}
+
+var (
+	aliasPasswordLength  int
+	aliasPasswordClasses []string
+	aliasPasswordStdin   bool
+)
+
+func init() {
+	aliasPasswordCmd.Flags().IntVar(&aliasPasswordLength, "length", 0,
+		fmt.Sprintf("Generate a password of this length locally (%d-%d)", passwordMinLength, passwordMaxLength))
+	aliasPasswordCmd.Flags().StringSliceVar(&aliasPasswordClasses, "classes", nil,
+		"Character classes for a locally generated password: lower,upper,digits,symbols (default all)")
+	aliasPasswordCmd.Flags().BoolVar(&aliasPasswordStdin, "password-stdin", false, "Read the new password from stdin")
+}
+
+// generatePassword returns a random password of length characters containing
+// at least one character from each class. Look-alike characters are excluded.
+func generatePassword(length int, classes []string) (string, error) {
+	if len(classes) == 0 {
+		classes = []string{"lower", "upper", "digits", "symbols"}
+	}
+	if length < len(classes) {
+		return "", fmt.Errorf("length %d is too short for %d character classes", length, len(classes))
+	}
+
+	var all strings.Builder
+	out := make([]byte, 0, length)
+	for _, name := range classes {
+		set, ok := passwordClasses[name]
+		if !ok {
+			return "", fmt.Errorf("unknown character class %q (valid: lower,upper,digits,symbols)", name)
+		}
+		all.WriteString(set)
+		idx, err := randomIndex(len(set))
+		if err != nil {
+			return "", err
+		}
+		out = append(out, set[idx])
+	}
+	charset := all.String()
+	for len(out) < length {
+		idx, err := randomIndex(len(charset))
+		if err != nil {
+			return "", err
+		}
+		out = append(out, charset[idx])
+	}
+
+	// Shuffle so the guaranteed characters are not always first
+	for i := len(out) - 1; i > 0; i-- {
+		j, err := randomIndex(i + 1)
+		if err != nil {
+			return "", err
+		}
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out), nil
+}
+
+// checkPasswordStrength enforces the password policy. None of names, such as
+// the alias name and address, may appear in the password.
+func checkPasswordStrength(password string, names ...string) error {
+	n := len([]rune(password))
+	if n < passwordMinLength {
+		return fmt.Errorf("password must be at least %d characters", passwordMinLength)
+	}
+	if n > passwordMaxLength {
+		return fmt.Errorf("password must be at most %d characters", passwordMaxLength)
+	}
+
+	var lower, upper, digit, other bool
+	for _, r := range password {
+		switch {
+		case unicode.IsSpace(r) || unicode.IsControl(r):
+			return fmt.Errorf("password must not contain whitespace or control characters")
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			other = true
+		}
+	}
+	classes := 0
+	for _, ok := range []bool{lower, upper, digit, other} {
+		if ok {
+			classes++
+		}
+	}
+	if classes < 2 || (classes < passwordMinClasses && n < passphraseLength) {
+		return fmt.Errorf("password must mix at least %d of lowercase, uppercase, digits and symbols "+
+			"(or be %d+ characters with at least 2)", passwordMinClasses, passphraseLength)
+	}
+
+	for _, name := range names {
+		if len(name) >= 3 && strings.Contains(strings.ToLower(password), strings.ToLower(name)) {
+			return fmt.Errorf("password must not contain the alias name or address")
+		}
+	}
+	return nil
+}
+
+// readPasswordStdin reads a password from the first line of r.
+func readPasswordStdin(r io.Reader) (string, error) {
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("failed to read password from stdin: %v", err)
+	}
+	password := strings.TrimRight(line, "\r\n")
+	if password == "" {
+		return "", fmt.Errorf("no password provided on stdin")
+	}
+	return password, nil
+}
+
+// aliasPasswordRequest builds the generate-password request from the flags.
+// It returns nil when the server should generate the password. The caller
+// checks the password's strength once the alias is known.
+func aliasPasswordRequest(cmd *cobra.Command) (*api.GeneratePasswordRequest, error) {
+	generate := cmd.Flags().Changed("length") || cmd.Flags().Changed("classes")
+	if aliasPasswordStdin && generate {
+		return nil, fmt.Errorf("--password-stdin cannot be combined with --length or --classes")
+	}
+
+	var password string
+	switch {
+	case aliasPasswordStdin:
+		p, err := readPasswordStdin(cmd.InOrStdin())
+		if err != nil {
+			return nil, err
+		}
+		password = p
+	case generate:
+		length := aliasPasswordLength
+		if length == 0 {
+			length = defaultPasswordLength
+		}
+		if length < passwordMinLength || length > passwordMaxLength {
+			return nil, fmt.Errorf("--length must be between %d and %d", passwordMinLength, passwordMaxLength)
+		}
+		p, err := generatePassword(length, aliasPasswordClasses)
+		if err != nil {
+			return nil, err
+		}
+		password = p
+	default:
+		return nil, nil
+	}
+	return &api.GeneratePasswordRequest{NewPassword: password}, nil
+}
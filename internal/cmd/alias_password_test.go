@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+)
+
+func TestGeneratePassword(t *testing.T) {
+	p, err := generatePassword(16, []string{"digits", "symbols"})
+	if err != nil || len(p) != 16 {
+		t.Fatalf("generatePassword: %q, %v", p, err)
+	}
+	if strings.ContainsAny(p, passwordClasses["lower"]+passwordClasses["upper"]) {
+		t.Errorf("password %q has characters outside the requested classes", p)
+	}
+	if !strings.ContainsAny(p, passwordClasses["digits"]) || !strings.ContainsAny(p, passwordClasses["symbols"]) {
+		t.Errorf("password %q is missing a requested class", p)
+	}
+	if _, err := generatePassword(16, []string{"emoji"}); err == nil {
+		t.Error("expected unknown class to fail")
+	}
+}
+
+func TestCheckPasswordStrength(t *testing.T) {
+	tests := []struct {
+		password string
+		wantErr  bool
+	}{
+		{password: "Tr0ub4dor&3x", wantErr: false},
+		{password: "Short1!", wantErr: true},
+		{password: "alllowercaseletters", wantErr: true},
+		{password: "lowercase1234", wantErr: true},
+		{password: "correct-horse-battery-staple", wantErr: false},
+		{password: "has a space Ab1!", wantErr: true},
+		{password: "Support-Desk-2025!", wantErr: true},         // contains the alias name
+		{password: "Mail-support@example.com-1", wantErr: true}, // contains the address
+	}
+	for _, tt := range tests {
+		if err := checkPasswordStrength(tt.password, "support", "support@example.com"); (err != nil) != tt.wantErr {
+			t.Errorf("checkPasswordStrength(%q) = %v, wantErr %v", tt.password, err, tt.wantErr)
+		}
+	}
+}
+
+func TestAliasPassword_GenerationOptions(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    aliases:
+      - name: info
+        recipients: [me@example.org]
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	reset := func() {
+		aliasPasswordLength, aliasPasswordClasses, aliasPasswordStdin = 0, nil, false
+		for _, name := range []string{"length", "classes", "password-stdin"} {
+			aliasPasswordCmd.Flags().Lookup(name).Changed = false
+		}
+	}
+	t.Cleanup(func() {
+		reset()
+		resetAliasFlags()
+	})
+
+	runArgs := func(stdin string, args ...string) (string, error) {
+		reset()
+		var buf bytes.Buffer
+		rootCmd.SetIn(strings.NewReader(stdin))
+		rootCmd.SetOut(&buf)
+		rootCmd.SetErr(&buf)
+		rootCmd.SetArgs(args)
+		err := rootCmd.Execute()
+		return buf.String(), err
+	}
+	run := func(stdin string, args ...string) (string, error) {
+		return runArgs(stdin, append([]string{"alias", "password", "example.com", "info"}, args...)...)
+	}
+
+	out, err := run("", "--length", "16", "--classes", "lower,upper,digits")
+	if err != nil {
+		t.Fatalf("--length: %v\n%s", err, out)
+	}
+	if !regexp.MustCompile(`Password: [a-zA-Z0-9]{16}\n`).MatchString(out) {
+		t.Errorf("expected a 16 character locally generated password, got:\n%s", out)
+	}
+
+	out, err = run("My-Own-Passw0rd\n", "--password-stdin")
+	if err != nil {
+		t.Fatalf("--password-stdin: %v\n%s", err, out)
+	}
+	if strings.Contains(out, "My-Own-Passw0rd") || !strings.Contains(out, "IMAP password updated") {
+		t.Errorf("supplied password must not be echoed:\n%s", out)
+	}
+
+	if _, err := run("weak\n", "--password-stdin"); err == nil || !strings.Contains(err.Error(), "at least 12") {
+		t.Errorf("expected weak password to be rejected, got %v", err)
+	}
+	// The alias argument may be an ID; the password is checked against the
+	// alias name it resolves to.
+	c, err := client.NewAPIClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	alias, err := c.Aliases.GetAlias(context.Background(), "example.com", "info")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err = runArgs("Info-Desk-2026!\n", "alias", "password", "example.com", alias.ID, "--password-stdin")
+	if err == nil || !strings.Contains(err.Error(), "must not contain the alias name") {
+		t.Errorf("expected a password containing the alias name to be rejected, got %v\n%s", err, out)
+	}
+
+	if _, err := run("", "--password-stdin", "--length", "20"); err == nil {
+		t.Error("expected --password-stdin with --length to fail")
+	}
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/ginsys/forward-email/pkg/policy"
+)
+
+// aliasPolicyFile is the --policy-file flag shared by the commands that give
+// aliases new names: alias create, random, import, rename and update.
+var aliasPolicyFile string
+
+func init() {
+	const usage = "Alias naming policy to enforce (default: the profile's alias_policy)"
+	aliasCreateCmd.Flags().StringVar(&aliasPolicyFile, "policy-file", "", usage)
+	aliasRandomCmd.Flags().StringVar(&aliasPolicyFile, "policy-file", "", usage)
+	aliasImportCmd.Flags().StringVar(&aliasPolicyFile, "policy-file", "", usage)
+	aliasRenameCmd.Flags().StringVar(&aliasPolicyFile, "policy-file", "", usage)
+	aliasUpdateCmd.Flags().StringVar(&aliasPolicyFile, "policy-file", "", usage)
+}
+
+// loadAliasPolicy loads the naming policy from --policy-file or the active
+// profile's alias_policy setting. It returns nil when neither is set.
+func loadAliasPolicy() (*policy.Policy, error) {
+	path := aliasPolicyFile
+	if path == "" {
+		p, _ := activeProfile()
+		path = p.AliasPolicy
+	}
+	if path == "" {
+		return nil, nil
+	}
+	return policy.Load(path)
+}
+
+// policyError reports naming policy violations before any alias is created.
+func policyError(violations policy.Violations) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	return fmt.Errorf("alias naming policy violated:\n%v", violations)
+}
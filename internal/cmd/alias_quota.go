@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+var (
+	aliasQuotaAll     bool
+	aliasQuotaOver    string
+	aliasQuotaWorkers int
+)
+
+func init() {
+	aliasQuotaCmd.Flags().BoolVar(&aliasQuotaAll, "all", false, "Report the quota of every IMAP-enabled alias in the domain")
+	aliasQuotaCmd.Flags().StringVar(&aliasQuotaOver, "over", "", "With --all, only show aliases using at least this share of their storage (e.g. 90%)")
+	aliasQuotaCmd.Flags().IntVar(&aliasQuotaWorkers, "concurrency", 4, "With --all, number of quotas to fetch at once")
+}
+
+// parsePercent parses a percentage such as 90% or 90 into a number in (0, 100].
+func parsePercent(flag, s string) (float64, error) {
+	n, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "%"), 64)
+	if err != nil || n <= 0 || n > 100 {
+		return 0, fmt.Errorf("invalid %s %q: percent must be between 0 and 100", flag, s)
+	}
+	return n, nil
+}
+
+// runAliasQuotaReport prints the quotas of every IMAP-enabled alias of a
+// domain, fullest storage first. Quotas are fetched concurrently; aliases
+// whose quota cannot be read are reported after the table.
+func runAliasQuotaReport(cmd *cobra.Command, args []string) (err error) {
+	ctx := cmd.Context()
+
+	domain := aliasDomain
+	switch {
+	case len(args) == 1:
+		domain = args[0]
+	case len(args) > 1:
+		return fmt.Errorf("--all takes only a domain")
+	}
+	if domain = withDefaultDomain(cmd, domain); domain == "" {
+		return fmt.Errorf("domain is required - specify as argument or use --domain flag")
+	}
+	var over float64
+	if aliasQuotaOver != "" {
+		var err error
+		if over, err = parsePercent("--over", aliasQuotaOver); err != nil {
+			return err
+		}
+	}
+	if aliasQuotaWorkers < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+	format, err := output.ParseFormat(viper.GetString("output"))
+	if err != nil {
+		return fmt.Errorf("invalid output format: %v", err)
+	}
+
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %v", err)
+	}
+	aliases, err := listAllAliases(ctx, apiClient, domain)
+	if err != nil {
+		return fmt.Errorf("failed to list aliases for %s: %v", domain, err)
+	}
+	var mailboxes []api.Alias
+	for _, a := range aliases {
+		if a.HasIMAP {
+			mailboxes = append(mailboxes, a)
+		}
+	}
+
+	quotas := make([]*api.AliasQuota, len(mailboxes))
+	errs := make([]error, len(mailboxes))
+	runConcurrently(len(mailboxes), aliasQuotaWorkers, func(i int) {
+		if quotas[i] = mailboxes[i].Quota; quotas[i] == nil {
+			quotas[i], errs[i] = apiClient.Aliases.GetAliasQuota(ctx, domain, mailboxes[i].ID)
+		}
+	})
+
+	usage := []output.AliasQuotaUsage{}
+	var failures []partialFailure
+	for i, a := range mailboxes {
+		if errs[i] != nil {
+			failures = append(failures, newPartialFailure(domain, a.Name+"@"+domain, errs[i]))
+			continue
+		}
+		u := output.NewAliasQuotaUsage(a.Name+"@"+domain, quotas[i])
+		if u.StoragePercent >= over {
+			usage = append(usage, u)
+		}
+	}
+	sort.SliceStable(usage, func(i, j int) bool {
+		if usage[i].StoragePercent != usage[j].StoragePercent {
+			return usage[i].StoragePercent > usage[j].StoragePercent
+		}
+		return usage[i].Alias < usage[j].Alias
+	})
+	defer reportPartial(cmd, fmt.Sprintf("%d alias quota(s) could not be read", len(failures)), failures, &err)
+
+	formatter := output.NewFormatter(format, cmd.OutOrStdout())
+	if format == output.FormatJSON || format == output.FormatYAML {
+		return formatter.Format(usage)
+	}
+	if len(usage) == 0 {
+		if aliasQuotaOver != "" {
+			cmd.PrintErrf("No aliases in %s at or above %g%% storage\n", domain, over)
+		} else {
+			cmd.PrintErrf("No IMAP-enabled aliases in %s\n", domain)
+		}
+		return nil
+	}
+	tableData, err := output.FormatAliasQuotaReport(usage, format)
+	if err != nil {
+		return fmt.Errorf("failed to format output: %v", err)
+	}
+	return formatter.Format(tableData)
+}
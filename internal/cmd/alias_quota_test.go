@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+func TestAliasQuotaAll(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    aliases:
+      - name: half
+        has_imap: true
+        quota: {storage_used: 500, storage_limit: 1000, emails_sent: 1, emails_limit: 300}
+      - name: full
+        has_imap: true
+        quota: {storage_used: 950, storage_limit: 1000}
+      - name: fresh
+        has_imap: true
+      - name: forward-only
+        recipients: [me@example.org]
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetCommandFlags(aliasQuotaCmd)
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	})
+
+	run := func(args ...string) ([]output.AliasQuotaUsage, string, error) {
+		var stdout, stderr bytes.Buffer
+		rootCmd.SetOut(&stdout)
+		rootCmd.SetErr(&stderr)
+		rootCmd.SetArgs(append([]string{"alias", "quota", "--all", "example.com", "-o", "json"}, args...))
+		err := rootCmd.Execute()
+		resetCommandFlags(aliasQuotaCmd)
+		if err != nil {
+			return nil, stderr.String(), err
+		}
+		var usage []output.AliasQuotaUsage
+		if jsonErr := json.Unmarshal(stdout.Bytes(), &usage); jsonErr != nil {
+			t.Fatalf("stdout is not JSON: %v\n%s", jsonErr, stdout.String())
+		}
+		return usage, stderr.String(), nil
+	}
+
+	usage, _, err := run("--concurrency", "2")
+	if err != nil {
+		t.Fatalf("alias quota --all: %v", err)
+	}
+	var got []string
+	for _, u := range usage {
+		got = append(got, u.Alias)
+	}
+	// Fullest first; "fresh" has no quota in the listing and is fetched separately.
+	if strings.Join(got, ",") != "full@example.com,half@example.com,fresh@example.com" {
+		t.Errorf("unexpected order %v", got)
+	}
+	if usage[0].StoragePercent != 95 || usage[1].EmailsSent != 1 {
+		t.Errorf("unexpected usage %+v", usage)
+	}
+
+	usage, _, err = run("--over", "90%")
+	if err != nil {
+		t.Fatalf("alias quota --over: %v", err)
+	}
+	if len(usage) != 1 || usage[0].Alias != "full@example.com" {
+		t.Errorf("expected only full@example.com over 90%%, got %+v", usage)
+	}
+
+	if _, _, err := run("--over", "120%"); err == nil || !strings.Contains(err.Error(), "percent must be between 0 and 100") {
+		t.Errorf("expected an --over error, got %v", err)
+	}
+
+	rootCmd.SetArgs([]string{"alias", "quota", "example.com", "half", "--over", "90%"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "--over requires --all") {
+		t.Errorf("expected --over to require --all, got %v", err)
+	}
+}
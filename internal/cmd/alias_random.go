@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/pkg/api"
+	fe "github.com/ginsys/forward-email/pkg/errors"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+// randomAliasAttempts bounds retries when a generated name is already taken.
+const randomAliasAttempts = 5
+
+var (
+	aliasRandomWords int
+	aliasRandomStyle string
+)
+
+// aliasRandomCmd represents the alias random command
+var aliasRandomCmd = &cobra.Command{
+	Use:   "random [domain] --recipients <email>",
+	Short: "Create an alias with a generated name",
+	Long: `Create a masked alias with a generated name and print its address.
+
+Styles:
+  words  memorable words joined by dashes, e.g. amber-otter-lantern (default)
+  uuid   a random UUID, e.g. 3f0c9a5e-8b1d-4c7e-9f2a-6d5b4e3c2a10
+
+Names are drawn from a cryptographically secure random source. If a name is
+already taken a new one is generated. Like alias create, the name and labels
+must pass the naming policy from --policy-file or the profile's alias_policy.`,
+	Example: `  forward-email alias random example.com --recipients me@corp.com
+  forward-email alias random example.com --recipients me@corp.com --words 4 --copy
+  forward-email alias random example.com --recipients me@corp.com --style uuid --expires-in 30d`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runAliasRandom,
+}
+
+func init() {
+	aliasCmd.AddCommand(aliasRandomCmd)
+
+	aliasRecipientListVar(aliasRandomCmd.Flags(), &aliasRecipients, "recipients", "Recipient email addresses")
+	aliasRandomCmd.Flags().StringSliceVar(&aliasLabelsFlag, "labels", nil, "Labels for the alias")
+	aliasRandomCmd.Flags().StringVar(&aliasDescription, "description", "", "Description for the alias")
+	aliasRandomCmd.Flags().StringVar(&aliasExpiresIn, "expires-in", "", "Expire the alias after this long (e.g. 7d, 2w, 12h)")
+	aliasRandomCmd.Flags().IntVar(&aliasRandomWords, "words", 3, "Number of words in the name (words style)")
+	aliasRandomCmd.Flags().StringVar(&aliasRandomStyle, "style", "words", "Name style: words|uuid")
+	addSecretFlags(aliasRandomCmd, "new address")
+}
+
+// randomWordList is a short list of distinct, easy to spell words for
+// memorable alias names.
+var randomWordList = strings.Fields(`
+	acorn amber anchor apple arrow aspen autumn badger bamboo banjo basil beacon
+	birch bison blossom breeze brook cactus camel canyon cedar cherry cinder clover
+	cobalt comet copper coral cosmos cotton crane cricket crystal cypress dahlia delta
+	desert dolphin dune eagle ember falcon fern fig finch fjord flint forest fossil
+	fox galaxy garnet gecko ginger glacier granite grove harbor hazel heron hickory
+	honey horizon iris island ivory jade jasper juniper kelp kestrel kiwi lagoon
+	lantern lark lava lemon lilac lily linen lotus lunar lynx magnet maple marble
+	meadow mango meteor mint mist moss nectar nickel nova oak oasis ocean olive
+	onyx opal orbit orchid otter owl panda papaya pebble pepper pine planet plum
+	polar poppy prairie quartz quill raven reef ridge river robin ruby saffron sage
+	salmon sapphire sequoia shadow sierra silver sparrow spruce summit sunset swan
+	thistle thunder tiger topaz tulip tundra valley velvet violet walnut willow
+	winter wren yarrow zephyr zinc
+`)
+
+// randomIndex returns a uniformly distributed integer in [0, n).
+func randomIndex(n int) (int, error) {
+	v, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+	return int(v.Int64()), nil
+}
+
+// randomAliasName generates an alias name in the given style.
+func randomAliasName(style string, words int) (string, error) {
+	switch style {
+	case "words":
+		if words < 2 || words > 8 {
+			return "", fmt.Errorf("--words must be between 2 and 8")
+		}
+		parts := make([]string, words)
+		for i := range parts {
+			idx, err := randomIndex(len(randomWordList))
+			if err != nil {
+				return "", err
+			}
+			parts[i] = randomWordList[idx]
+		}
+		return strings.Join(parts, "-"), nil
+	case "uuid":
+		b := make([]byte, 16)
+		if _, err := rand.Read(b); err != nil {
+			return "", err
+		}
+		b[6] = (b[6] & 0x0f) | 0x40 // version 4
+		b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+	default:
+		return "", fmt.Errorf("invalid --style: %s (valid: words|uuid)", style)
+	}
+}
+
+func runAliasRandom(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	domain := aliasDomain
+	if len(args) == 1 {
+		domain = args[0]
+	}
+	if domain = withDefaultDomain(cmd, domain); domain == "" {
+		return fmt.Errorf("domain is required - specify as first argument or use --domain flag")
+	}
+	if len(aliasRecipients) == 0 {
+		return fmt.Errorf("at least one recipient is required")
+	}
+	if err := validateWebhookRecipients(ctx, aliasRecipients); err != nil {
+		return err
+	}
+	if err := checkSecretFlags(cmd); err != nil {
+		return err
+	}
+	style := strings.ToLower(strings.TrimSpace(aliasRandomStyle))
+	// Validate the style and word count before contacting the API
+	_, err := randomAliasName(style, aliasRandomWords)
+	if err != nil {
+		return err
+	}
+
+	labels := aliasLabelsFlag
+	if aliasExpiresIn != "" {
+		if labels, err = expiryLabels(labels, aliasExpiresIn, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	namingPolicy, err := loadAliasPolicy()
+	if err != nil {
+		return err
+	}
+
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %v", err)
+	}
+
+	var alias *api.Alias
+	for attempt := 1; ; attempt++ {
+		name, nameErr := randomAliasName(style, aliasRandomWords)
+		if nameErr != nil {
+			return fmt.Errorf("failed to generate alias name: %v", nameErr)
+		}
+		if err := policyError(namingPolicy.Check(name, labels)); err != nil {
+			return err
+		}
+		alias, err = apiClient.Aliases.CreateAlias(ctx, domain, &api.CreateAliasRequest{
+			Name:        name,
+			Recipients:  aliasRecipients,
+			Labels:      labels,
+			Description: aliasDescription,
+			IsEnabled:   true,
+		})
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, fe.ErrConflict) || attempt == randomAliasAttempts {
+			return fmt.Errorf("failed to create alias: %v", err)
+		}
+	}
+
+	reportMutation(hookResource{Type: "alias", Action: "create", Domain: domain, Name: alias.Name, ID: alias.ID, Data: alias})
+	address := alias.Name + "@" + domain
+	echo, err := shareSecret(cmd, "new address", address)
+	if err != nil {
+		return err
+	}
+	if !echo {
+		cmd.PrintErrln("✅ Alias created")
+		return nil
+	}
+
+	format, err := output.ParseFormat(viper.GetString("output"))
+	if err != nil {
+		return fmt.Errorf("invalid output format: %v", err)
+	}
+	if format == output.FormatJSON || format == output.FormatYAML {
+		return output.NewFormatter(format, cmd.OutOrStdout()).Format(alias)
+	}
+	cmd.PrintErrln("✅ Alias created")
+	_, _ = fmt.Fprintln(cmd.OutOrStdout(), address)
+	return nil
+}
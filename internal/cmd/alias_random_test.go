@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/auth"
+)
+
+func TestRandomAliasName(t *testing.T) {
+	name, err := randomAliasName("words", 4)
+	if err != nil || len(strings.Split(name, "-")) != 4 {
+		t.Errorf("words: %q, %v", name, err)
+	}
+
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	if name, err := randomAliasName("uuid", 0); err != nil || !uuid.MatchString(name) {
+		t.Errorf("uuid: %q, %v", name, err)
+	}
+
+	if _, err := randomAliasName("words", 1); err == nil {
+		t.Error("expected error for too few words")
+	}
+	if _, err := randomAliasName("emoji", 3); err == nil {
+		t.Error("expected error for unknown style")
+	}
+}
+
+func TestAliasRandom_CreatesAlias(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte("domains:\n  - name: example.com\n"))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetAliasFlags()
+		aliasRandomWords, aliasRandomStyle = 3, "words"
+	})
+
+	var stdout, stderr bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetArgs([]string{"alias", "random", "example.com", "--recipients", "me@example.org", "--words", "2"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("alias random: %v\n%s", err, stderr.String())
+	}
+
+	// The address is the command's output; the banner is a status message.
+	m := regexp.MustCompile(`^([a-z]+-[a-z]+)@example\.com\n$`).FindStringSubmatch(stdout.String())
+	if m == nil {
+		t.Fatalf("expected only the new address on stdout, got %q", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "Alias created") {
+		t.Errorf("expected the banner on stderr, got %q", stderr.String())
+	}
+	c, err := client.NewAPIClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	alias, err := c.Aliases.GetAlias(context.Background(), "example.com", m[1])
+	if err != nil || !alias.IsEnabled || len(alias.Recipients) != 1 {
+		t.Errorf("created alias: %+v, %v", alias, err)
+	}
+}
+
+func TestAliasRandom_Policy(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte("domains:\n  - name: example.com\n"))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetAliasFlags()
+		resetCommandFlags(aliasRandomCmd)
+		aliasRandomWords, aliasRandomStyle = 3, "words"
+	})
+
+	policyPath := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(policyPath, []byte("prefixes:\n  team-hr: hr-\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetArgs([]string{"alias", "random", "example.com", "--recipients", "me@example.org",
+		"--labels", "team-hr", "--policy-file", policyPath})
+	err = rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "alias naming policy violated") {
+		t.Fatalf("expected the prefix rule to refuse the name, got %v\n%s", err, stderr.String())
+	}
+
+	c, err := client.NewAPIClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	aliases, err := c.Aliases.ListAliases(context.Background(), &api.ListAliasesOptions{Domain: "example.com"})
+	if err != nil || len(aliases.Aliases) != 0 {
+		t.Errorf("expected no alias to be created, got %+v, %v", aliases, err)
+	}
+}
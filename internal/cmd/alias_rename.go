@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/pkg/api"
+	fe "github.com/ginsys/forward-email/pkg/errors"
+)
+
+var aliasRenameCmd = &cobra.Command{
+	Use:   "rename [domain] <old-name> <new-name>",
+	Short: "Rename an alias, keeping its settings",
+	Long: `Rename an alias. The alias is renamed in place, so it keeps its ID, settings,
+IMAP mailbox and password.
+
+If the API does not rename the alias, it is copied to the new name instead,
+with its recipients, labels, description, enabled state, IMAP, PGP and
+vacation settings, and the old alias is then deleted. A copy starts with an
+empty mailbox and no IMAP password, so for IMAP aliases this asks for
+confirmation first (skip it with --force).
+
+The new name must pass the naming policy from --policy-file or the profile's
+alias_policy, as for alias create.`,
+	Example: `  forward-email alias rename example.com sales orders
+  forward-email alias rename sales orders --domain example.com`,
+	Args: cobra.RangeArgs(2, 3),
+	RunE: runAliasRename,
+}
+
+func init() {
+	aliasCmd.AddCommand(aliasRenameCmd)
+	aliasRenameCmd.Flags().BoolP("force", "f", false, "Copy IMAP aliases without confirmation")
+}
+
+func runAliasRename(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	domain := aliasDomain
+	if len(args) == 3 {
+		domain, args = args[0], args[1:]
+	}
+	if domain = withDefaultDomain(cmd, domain); domain == "" {
+		return fmt.Errorf("domain is required - specify as first argument or use --domain flag")
+	}
+	oldName, newName := strings.TrimSpace(args[0]), strings.TrimSpace(args[1])
+	if newName == "" || strings.Contains(newName, "@") {
+		return fmt.Errorf("invalid new name %q: give the local part only", args[1])
+	}
+	if strings.EqualFold(oldName, newName) {
+		return fmt.Errorf("alias is already named %s", newName)
+	}
+
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %v", err)
+	}
+
+	alias, err := apiClient.Aliases.GetAlias(ctx, domain, oldName)
+	if err != nil {
+		return fmt.Errorf("failed to get alias: %v", err)
+	}
+	namingPolicy, err := loadAliasPolicy()
+	if err != nil {
+		return err
+	}
+	if err := policyError(namingPolicy.Check(newName, alias.Labels)); err != nil {
+		return err
+	}
+	if _, err := apiClient.Aliases.GetAlias(ctx, domain, newName); err == nil {
+		return fmt.Errorf("alias %s@%s already exists", newName, domain)
+	} else if !fe.IsNotFound(err) {
+		return fmt.Errorf("failed to check alias %s: %v", newName, err)
+	}
+
+	renamed, err := apiClient.Aliases.UpdateAlias(ctx, domain, alias.ID, &api.UpdateAliasRequest{Name: &newName})
+	if err != nil {
+		return fmt.Errorf("failed to rename alias: %v", err)
+	}
+	if strings.EqualFold(renamed.Name, newName) {
+		reportMutation(hookResource{Type: "alias", Action: "rename", Domain: domain, Name: renamed.Name, ID: renamed.ID, Data: renamed})
+		cmd.PrintErrf("✅ Alias '%s' renamed to '%s'\n", alias.Name, newName)
+		if alias.HasIMAP {
+			cmd.PrintErrf("ℹ️  The mailbox was kept; IMAP and SMTP clients must now log in as %s@%s\n", newName, domain)
+		}
+		return nil
+	}
+
+	// The API kept the old name, so copy the alias and delete the original
+	return copyRenameAlias(ctx, cmd, apiClient, domain, alias, newName)
+}
+
+// copyRenameAlias renames alias by creating a copy named newName and deleting
+// the original. IMAP aliases lose their mailbox this way, so that needs
+// confirmation.
+func copyRenameAlias(ctx context.Context, cmd *cobra.Command, apiClient *api.Client, domain string, alias *api.Alias, newName string) error {
+	if alias.HasIMAP {
+		cmd.PrintErrf("⚠️  Alias '%s' can only be renamed by copying it. The copy gets a new, empty IMAP mailbox and "+
+			"no password: messages stored for %s@%s are deleted with the old alias.\n", alias.Name, alias.Name, domain)
+		ok, err := confirm(cmd, "Copy the alias and delete the old one?")
+		if err != nil {
+			return err
+		}
+		if !ok {
+			cmd.PrintErrln("❌ Rename canceled")
+			return nil
+		}
+	}
+
+	req := &api.CreateAliasRequest{
+		Name: newName, Recipients: alias.Recipients, Labels: alias.Labels, Description: alias.Description,
+		PublicKey: alias.PublicKey, IsEnabled: alias.IsEnabled, HasIMAP: alias.HasIMAP, HasPGP: alias.HasPGP,
+		Vacation: alias.Vacation,
+	}
+	created, err := apiClient.Aliases.CreateAlias(ctx, domain, req)
+	if err != nil {
+		return fmt.Errorf("failed to create alias %s: %v", newName, err)
+	}
+	if err := apiClient.Aliases.DeleteAlias(ctx, domain, alias.ID); err != nil {
+		return fmt.Errorf("created alias %s, but failed to delete %s; both now exist: %v", newName, alias.Name, err)
+	}
+	reportMutation(hookResource{Type: "alias", Action: "rename", Domain: domain, Name: created.Name, ID: created.ID, Data: created})
+	cmd.PrintErrf("✅ Alias '%s' renamed to '%s' (copied and deleted)\n", alias.Name, newName)
+	if alias.HasIMAP {
+		cmd.PrintErrf("ℹ️  Generate an IMAP password for the new alias with: forward-email alias password %s %s\n", domain, newName)
+	}
+	return nil
+}
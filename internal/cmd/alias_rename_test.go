@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+)
+
+const renameSeed = `
+domains:
+  - name: example.com
+    aliases:
+      - name: info
+        recipients: [me@example.org]
+        labels: [support]
+        has_imap: true
+      - name: sales
+        recipients: [sales@example.org]
+        labels: [team:sales]
+        description: Sales desk
+        has_pgp: true
+        is_enabled: false
+`
+
+// startRenameServer serves renameSeed. With ignoreName it drops the name
+// from alias updates, like an API that cannot rename aliases.
+func startRenameServer(t *testing.T, ignoreName bool) {
+	t.Helper()
+	seed, err := mockserver.ParseSeed([]byte(renameSeed))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	var handler http.Handler = mockserver.New(seed)
+	if ignoreName {
+		next := handler
+		handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPut {
+				var body map[string]any
+				data, _ := io.ReadAll(r.Body)
+				_ = json.Unmarshal(data, &body)
+				delete(body, "name")
+				data, _ = json.Marshal(body)
+				r.Body = io.NopCloser(bytes.NewReader(data))
+				r.ContentLength = int64(len(data))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetAliasFlags()
+		resetCommandFlags(aliasRenameCmd)
+	})
+}
+
+func runRename(t *testing.T, input string, args ...string) (string, error) {
+	t.Helper()
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetErr(&buf)
+	rootCmd.SetIn(strings.NewReader(input))
+	rootCmd.SetArgs(append([]string{"alias", "rename"}, args...))
+	err := rootCmd.Execute()
+	return buf.String(), err
+}
+
+func TestAliasRename_InPlace(t *testing.T) {
+	startRenameServer(t, false)
+	c, err := client.NewAPIClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	before, err := c.Aliases.GetAlias(context.Background(), "example.com", "info")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runRename(t, "", "example.com", "info", "contact")
+	if err != nil {
+		t.Fatalf("rename: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "renamed to 'contact'") || !strings.Contains(out, "log in as contact@example.com") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+	after, err := c.Aliases.GetAlias(context.Background(), "example.com", "contact")
+	if err != nil || after.ID != before.ID || !after.HasIMAP {
+		t.Errorf("expected the same alias under the new name, got %v %+v", err, after)
+	}
+
+	if out, err := runRename(t, "", "example.com", "sales", "contact"); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("expected renaming onto an existing alias to fail, got %v\n%s", err, out)
+	}
+}
+
+func TestAliasRename_Copy(t *testing.T) {
+	startRenameServer(t, true)
+	c, err := client.NewAPIClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runRename(t, "", "sales", "orders", "--domain", "example.com")
+	if err != nil {
+		t.Fatalf("rename: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "(copied and deleted)") {
+		t.Errorf("expected a copy, got:\n%s", out)
+	}
+	copied, err := c.Aliases.GetAlias(context.Background(), "example.com", "orders")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(copied.Labels, []string{"team:sales"}) || copied.Description != "Sales desk" ||
+		!copied.HasPGP || copied.IsEnabled || !reflect.DeepEqual(copied.Recipients, []string{"sales@example.org"}) {
+		t.Errorf("settings not carried over: %+v", copied)
+	}
+	if _, err := c.Aliases.GetAlias(context.Background(), "example.com", "sales"); err == nil {
+		t.Error("expected the old alias to be deleted")
+	}
+
+	// Copying an IMAP alias loses its mailbox, so it needs confirmation
+	out, err = runRename(t, "n\n", "example.com", "info", "contact")
+	if err != nil || !strings.Contains(out, "empty IMAP mailbox") || !strings.Contains(out, "Rename canceled") {
+		t.Fatalf("expected a canceled rename, got %v\n%s", err, out)
+	}
+	if _, err := c.Aliases.GetAlias(context.Background(), "example.com", "info"); err != nil {
+		t.Errorf("expected info to be kept: %v", err)
+	}
+}
+
+func TestAliasRename_Policy(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(renameSeed))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	var changes int
+	next := mockserver.New(seed)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			changes++
+		}
+		next.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetAliasFlags()
+		resetCommandFlags(aliasRenameCmd)
+	})
+
+	policyPath := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(policyPath, []byte("forbidden: [admin]\nreserved:\n  hr: [team-hr]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"admin", "hr"} {
+		out, err := runRename(t, "", "example.com", "sales", name, "--policy-file", policyPath)
+		if err == nil || !strings.Contains(err.Error(), "alias naming policy violated") {
+			t.Errorf("expected renaming to %s to be refused, got %v\n%s", name, err, out)
+		}
+	}
+
+	// alias update -f renames too
+	patchPath := filepath.Join(t.TempDir(), "patch.yaml")
+	if err := os.WriteFile(patchPath, []byte("name: admin\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetErr(&buf)
+	rootCmd.SetArgs([]string{"alias", "update", "example.com", "sales", "-f", patchPath, "--policy-file", policyPath})
+	t.Cleanup(func() { resetCommandFlags(aliasUpdateCmd) })
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "alias naming policy violated") {
+		t.Errorf("expected the patch rename to be refused, got %v\n%s", err, buf.String())
+	}
+
+	if changes != 0 {
+		t.Errorf("expected no changes to be sent, got %d", changes)
+	}
+}
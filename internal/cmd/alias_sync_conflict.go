@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/planner"
+)
+
+// conflictInteractive is the --conflicts value that always prompts per conflict.
+const conflictInteractive = "interactive"
+
+// errSyncAborted is returned when the user quits the conflict prompt; nothing
+// has been changed at that point.
+var errSyncAborted = errors.New("alias sync aborted")
+
+// conflictResolver decides how alias sync resolves an alias that differs
+// between the source and target domains: with a fixed strategy, or by asking
+// the user per conflict. Answers are read from cmd.InOrStdin(), through one
+// buffered reader so that piped answers are not lost between prompts.
+type conflictResolver struct {
+	cmd         *cobra.Command
+	strategy    string // overwrite|skip|merge, or "" to use the mode's default
+	prefer      string // the side that wins an overwrite
+	interactive bool
+	last        string // the user's last choice, applied to all by 'a'
+	in          *bufio.Reader
+}
+
+// newConflictResolver returns the resolver for a --conflicts strategy and
+// --prefer side. Without a strategy it prompts unless the run is a dry run or
+// --yes was given; "interactive" prompts even then.
+func newConflictResolver(cmd *cobra.Command, strategy, prefer string, dryRun, yes bool) *conflictResolver {
+	strategy = strings.ToLower(strings.TrimSpace(strategy))
+	if prefer == "" {
+		prefer = planner.PreferSource
+	}
+	r := &conflictResolver{cmd: cmd, strategy: strategy, prefer: prefer}
+	switch strategy {
+	case conflictInteractive:
+		r.strategy, r.interactive = "", true
+	case "":
+		r.interactive = !dryRun && !yes
+	}
+	return r
+}
+
+// resolve returns the strategy for the conflict on alias: overwrite, skip,
+// merge, or "" for the mode's default.
+func (r *conflictResolver) resolve(alias string, src, dst api.Alias) (string, error) {
+	if !r.interactive {
+		return r.strategy, nil
+	}
+
+	if r.in == nil {
+		r.in = bufio.NewReader(r.cmd.InOrStdin())
+	}
+	out := r.cmd.ErrOrStderr()
+	_, _ = fmt.Fprintf(out, "Conflict for alias '%s' (source → target):\n", alias)
+	for _, line := range aliasConflictDiff(src, dst) {
+		_, _ = fmt.Fprintf(out, "  %s\n", line)
+	}
+	allHint := "apply to all"
+	if r.last != "" {
+		allHint = fmt.Sprintf("%s all remaining", r.last)
+	}
+	_, _ = fmt.Fprintf(out, "Choose: [o] overwrite (%s wins), [s] skip, [m] merge, [a] %s, [q] quit without changes\n", r.prefer, allHint)
+	for {
+		_, _ = fmt.Fprint(out, "Choice [o/s/m/a/q]: ")
+		line, err := r.in.ReadString('\n')
+		choice := strings.ToLower(strings.TrimSpace(line))
+		if err != nil && choice == "" {
+			_, _ = fmt.Fprintln(out)
+			if err == io.EOF {
+				return "", fmt.Errorf("no choice for the conflict on alias '%s'; use --conflicts overwrite|skip|merge or --yes to sync without prompts", alias)
+			}
+			return "", err
+		}
+		switch choice {
+		case "o":
+			r.last = "overwrite"
+		case "s":
+			r.last = "skip"
+		case "m":
+			r.last = "merge"
+		case "a":
+			if r.last == "" {
+				_, _ = fmt.Fprintln(out, "No earlier choice to apply; choose o, s or m for this alias first")
+				continue
+			}
+			r.strategy, r.interactive = r.last, false
+		case "q":
+			return "", errSyncAborted
+		default:
+			continue
+		}
+		return r.last, nil
+	}
+}
+
+// aliasConflictDiff describes the fields that differ between the source and
+// target alias, one line each, listing recipients and labels that only one
+// side has.
+func aliasConflictDiff(src, dst api.Alias) []string {
+	var lines []string
+	for _, f := range []struct {
+		name     string
+		src, dst []string
+	}{
+		{"recipients", src.Recipients, dst.Recipients},
+		{"labels", src.Labels, dst.Labels},
+	} {
+		if planner.EqualSets(f.src, f.dst) {
+			continue
+		}
+		line := fmt.Sprintf("%-10s  %s → %s", f.name, formatConflictList(f.src), formatConflictList(f.dst))
+		if only := missingFrom(f.src, f.dst); len(only) > 0 {
+			line += "  (source only: " + strings.Join(only, ", ") + ")"
+		}
+		if only := missingFrom(f.dst, f.src); len(only) > 0 {
+			line += "  (target only: " + strings.Join(only, ", ") + ")"
+		}
+		lines = append(lines, line)
+	}
+	if src.IsEnabled != dst.IsEnabled {
+		lines = append(lines, fmt.Sprintf("%-10s  %v → %v", "enabled", src.IsEnabled, dst.IsEnabled))
+	}
+	return lines
+}
+
+func formatConflictList(list []string) string {
+	if len(list) == 0 {
+		return "(none)"
+	}
+	return strings.Join(list, ", ")
+}
+
+// missingFrom returns the entries of a that b lacks, ignoring case.
+func missingFrom(a, b []string) []string {
+	var only []string
+	for _, v := range a {
+		if !hasEntry(b, strings.TrimSpace(v)) {
+			only = append(only, v)
+		}
+	}
+	return only
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/auth"
+)
+
+// conflictServer serves three aliases whose recipients differ between
+// src.com and dst.com, and counts requests that would change anything.
+func conflictServer(t *testing.T) *atomic.Int32 {
+	t.Helper()
+	var writes atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/domains/src.com/aliases", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode([]api.Alias{
+			{ID: "1", Name: "info", Recipients: []string{"a@x"}, IsEnabled: true},
+			{ID: "2", Name: "sales", Recipients: []string{"s@x"}, IsEnabled: true, Labels: []string{"crm"}},
+			{ID: "3", Name: "support", Recipients: []string{"h@x"}, IsEnabled: true},
+		})
+	})
+	mux.HandleFunc("/v1/domains/dst.com/aliases", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode([]api.Alias{
+			{ID: "10", Name: "info", Recipients: []string{"b@x"}, IsEnabled: true},
+			{ID: "11", Name: "sales", Recipients: []string{"s@x"}, IsEnabled: false},
+			{ID: "12", Name: "support", Recipients: []string{"h@x", "i@x"}, IsEnabled: true},
+		})
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
+		writes.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	t.Cleanup(func() { resetCommandFlags(aliasSyncCmd) })
+	return &writes
+}
+
+func runSyncWithInput(t *testing.T, input string, args ...string) (string, string, error) {
+	t.Helper()
+	resetCommandFlags(aliasSyncCmd)
+	var stdout, stderr bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetIn(strings.NewReader(input))
+	rootCmd.SetArgs(append([]string{"alias", "sync", "src.com", "dst.com", "--mode", "preserve"}, args...))
+	err := rootCmd.Execute()
+	return stdout.String(), stderr.String(), err
+}
+
+func TestAliasSync_InteractiveApplyLastChoice(t *testing.T) {
+	conflictServer(t)
+
+	stdout, stderr, err := runSyncWithInput(t, "x\nm\na\n", "--conflicts", "interactive", "--dry-run")
+	if err != nil {
+		t.Fatalf("sync: %v\n%s", err, stderr)
+	}
+	if n := strings.Count(stderr, "Conflict for alias"); n != 2 {
+		t.Errorf("expected 2 prompts before 'a' applied to the rest, got %d:\n%s", n, stderr)
+	}
+	if !strings.Contains(stderr, "[a] merge all remaining") {
+		t.Errorf("expected 'a' to name the last choice, got:\n%s", stderr)
+	}
+	// Only info lacks recipients the other side has
+	if !strings.Contains(stdout, "[a@x b@x]") || strings.Count(stdout, "UPDATE") != 1 {
+		t.Errorf("expected one update to the merged recipients, got:\n%s", stdout)
+	}
+}
+
+func TestAliasSync_InteractiveFieldDiff(t *testing.T) {
+	conflictServer(t)
+
+	_, stderr, err := runSyncWithInput(t, "s\ns\ns\n", "--conflicts", "interactive", "--dry-run")
+	if err != nil {
+		t.Fatalf("sync: %v\n%s", err, stderr)
+	}
+	for _, want := range []string{
+		"recipients  a@x → b@x  (source only: a@x)  (target only: b@x)",
+		"labels      crm → (none)  (source only: crm)",
+		"enabled     true → false",
+		"recipients  h@x → h@x, i@x  (target only: i@x)",
+	} {
+		if !strings.Contains(stderr, want) {
+			t.Errorf("expected %q in the prompt:\n%s", want, stderr)
+		}
+	}
+}
+
+func TestAliasSync_InteractiveNoEarlierChoice(t *testing.T) {
+	conflictServer(t)
+
+	stdout, stderr, err := runSyncWithInput(t, "a\ns\na\n", "--conflicts", "interactive", "--dry-run")
+	if err != nil {
+		t.Fatalf("sync: %v\n%s", err, stderr)
+	}
+	if !strings.Contains(stderr, "No earlier choice to apply") {
+		t.Errorf("expected 'a' to need an earlier choice, got:\n%s", stderr)
+	}
+	if strings.Contains(strings.ToUpper(stdout), "UPDATE") {
+		t.Errorf("expected every conflict to be skipped, got:\n%s", stdout)
+	}
+}
+
+func TestAliasSync_InteractiveQuit(t *testing.T) {
+	writes := conflictServer(t)
+
+	_, stderr, err := runSyncWithInput(t, "m\nq\n")
+	if err != nil {
+		t.Fatalf("expected quitting to succeed, got %v", err)
+	}
+	if !strings.Contains(stderr, "Alias sync aborted; nothing was changed") {
+		t.Errorf("expected an abort message, got:\n%s", stderr)
+	}
+	if writes.Load() != 0 {
+		t.Errorf("expected no changes after quitting, got %d request(s)", writes.Load())
+	}
+
+	_, _, err = runSyncWithInput(t, "", "--conflicts", "interactive")
+	if err == nil || !strings.Contains(err.Error(), "no choice for the conflict") {
+		t.Errorf("expected an error without answers, got %v", err)
+	}
+	if _, _, err := runSyncWithInput(t, "", "--conflicts", "interactive", "--yes"); err == nil {
+		t.Error("expected --conflicts interactive to conflict with --yes")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -206,6 +207,18 @@ func TestAliasGetCommand(t *testing.T) {
 			expectError: false,
 			expectOut:   []string{"testuser", "user@company.com"},
 		},
+		{
+			name:        "get by address",
+			args:        []string{"alias", "get", "alias123@example.com"},
+			expectError: false,
+			expectOut:   []string{"testuser@example.com", "imap.forwardemail.net:993", "smtp.forwardemail.net:465"},
+		},
+		{
+			name:        "get by address in another domain",
+			args:        []string{"alias", "get", "alias123@example.com", "--domain", "example.org"},
+			expectError: true,
+			expectOut:   []string{"not in domain example.org"},
+		},
 		{
 			name:        "get without domain",
 			args:        []string{"alias", "get", "alias123"},
@@ -222,7 +235,7 @@ func TestAliasGetCommand(t *testing.T) {
 			name:        "get with JSON output",
 			args:        []string{"alias", "get", "example.com", "alias123", "--output", "json"},
 			expectError: false,
-			expectOut:   []string{`"id": "alias123"`, `"name": "testuser"`},
+			expectOut:   []string{`"id": "alias123"`, `"name": "testuser"`, `"address": "testuser@example.com"`, `"imap": "imap.forwardemail.net:993"`},
 		},
 	}
 
@@ -376,8 +389,9 @@ func TestAliasCreateCommand(t *testing.T) {
 
 func TestAliasUpdateCommand(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != "PUT" {
-			t.Errorf("Expected PUT request, got %s", r.Method)
+		// GET fetches the current alias for the change summary
+		if r.Method != "PUT" && r.Method != "GET" {
+			t.Errorf("Expected GET or PUT request, got %s", r.Method)
 		}
 
 		var req api.UpdateAliasRequest
@@ -1179,6 +1193,9 @@ func resetAliasFlags() {
 	aliasLabels = ""
 	aliasHasIMAP = ""
 	aliasDomain = ""
+	aliasOwner = ""
+	aliasTeam = ""
+	aliasWorkers = 4
 
 	// Create/Update flags
 	aliasRecipients = nil
@@ -1189,6 +1206,11 @@ func resetAliasFlags() {
 	aliasIMAPFlag = false
 	aliasPGPFlag = false
 	aliasPublicKey = ""
+	aliasExpiresIn = ""
+	aliasUpdateDryRun = false
+	aliasUpdateFile = ""
+	aliasPolicyFile = ""
+	aliasAllowPrivate = false
 
 	// Reset viper values
 	viper.Set("output", "table")
@@ -1231,6 +1253,7 @@ func TestAliasExport_CSV_WritesFile(t *testing.T) {
 }
 
 func TestAliasImport_CreatesAndUpdates(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir()) // progress journal
 	created := 0
 	updated := 0
 
@@ -1263,7 +1286,7 @@ func TestAliasImport_CreatesAndUpdates(t *testing.T) {
 	// Create CSV
 	tmp := t.TempDir()
 	inPath := filepath.Join(tmp, "aliases.csv")
-	content := "Name,Recipients,Enabled,Labels,Description\ninfo,a@x,b@x,true,\nsupport,s@x,true,team,Support Alias\n"
+	content := "Name,Recipients,Enabled,Labels,Description\ninfo,\"a@x,b@x\",true,,\nsupport,s@x,true,team,Support Alias\n"
 	if err := os.WriteFile(inPath, []byte(content), 0o600); err != nil {
 		t.Fatalf("write csv: %v", err)
 	}
@@ -1279,3 +1302,181 @@ func TestAliasImport_CreatesAndUpdates(t *testing.T) {
 		t.Fatalf("expected 1 create and 1 update, got created=%d updated=%d", created, updated)
 	}
 }
+
+func TestAliasCreate_Interactive(t *testing.T) {
+	var got api.CreateAliasRequest
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/domains/example.com/aliases", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_ = json.NewEncoder(w).Encode(api.Alias{ID: "1", Name: got.Name, Recipients: got.Recipients, IsEnabled: true})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	stubWebhookNetwork(t)
+	t.Cleanup(func() {
+		resetAliasFlags()
+		aliasInteractive = false
+	})
+	resetAliasFlags()
+
+	// name, invalid recipient, two valid recipients, finish, labels, description, IMAP yes, PGP no, confirm
+	input := "sales\nnot-an-address\na@example.org, https://hooks.example.org/in\n\nteam,crm\nSales inbox\ny\nn\ny\n"
+
+	var out bytes.Buffer
+	rootCmd.SetIn(strings.NewReader(input))
+	rootCmd.SetOut(&out)
+	rootCmd.SetErr(&out)
+	rootCmd.SetArgs([]string{"alias", "create", "example.com", "--interactive", "-o", "json"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("interactive create failed: %v\n%s", err, out.String())
+	}
+
+	s := out.String()
+	if !strings.Contains(s, "invalid email address: not-an-address") || !strings.Contains(s, "Alias: sales@example.com") {
+		t.Errorf("expected validation message and summary, got:\n%s", s)
+	}
+	if got.Name != "sales" || len(got.Recipients) != 2 || !got.HasIMAP || got.HasPGP {
+		t.Errorf("unexpected create request: %+v", got)
+	}
+	if len(got.Labels) != 2 || got.Description != "Sales inbox" {
+		t.Errorf("expected labels and description, got %+v", got)
+	}
+}
+
+// TestAliasCreate_InteractiveFlagDefaults checks that --imap and --pgp only
+// preset the IMAP and PGP questions: the answers decide.
+func TestAliasCreate_InteractiveFlagDefaults(t *testing.T) {
+	var got api.CreateAliasRequest
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/domains/example.com/aliases", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_ = json.NewEncoder(w).Encode(api.Alias{ID: "1", Name: got.Name, Recipients: got.Recipients, IsEnabled: true})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	t.Cleanup(func() {
+		resetAliasFlags()
+		resetCommandFlags(aliasCreateCmd)
+		aliasInteractive = false
+	})
+	resetAliasFlags()
+
+	// recipient, finish, labels, description, IMAP no, PGP default, confirm
+	input := "a@example.org\n\n\n\nn\n\ny\n"
+
+	var out bytes.Buffer
+	rootCmd.SetIn(strings.NewReader(input))
+	rootCmd.SetOut(&out)
+	rootCmd.SetErr(&out)
+	rootCmd.SetArgs([]string{"alias", "create", "example.com", "sales", "-i", "--imap", "--pgp", "--public-key", "KEY", "-o", "json"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("interactive create failed: %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "Enable IMAP access? [Y/n]") {
+		t.Errorf("expected the flag value as the prompt default, got:\n%s", out.String())
+	}
+	if got.HasIMAP || !got.HasPGP {
+		t.Errorf("expected IMAP off by answer and PGP on by default, got %+v", got)
+	}
+}
+
+func TestAliasCreate_InteractiveDeclined(t *testing.T) {
+	client.SetTestMode("http://127.0.0.1:1", auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	t.Cleanup(func() {
+		resetAliasFlags()
+		aliasInteractive = false
+	})
+	resetAliasFlags()
+
+	var out bytes.Buffer
+	rootCmd.SetIn(strings.NewReader("info\na@example.org\n\n\n\nn\nn\nn\n"))
+	rootCmd.SetOut(&out)
+	rootCmd.SetErr(&out)
+	rootCmd.SetArgs([]string{"alias", "create", "example.com", "-i"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("declined create should not fail: %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "Alias creation canceled") {
+		t.Errorf("expected cancel message, got:\n%s", out.String())
+	}
+}
+
+func TestAliasList_ConcurrentDomains(t *testing.T) {
+	var mu sync.Mutex
+	var inFlight, maxInFlight int
+	domains := []string{"a.example", "b.example", "missing.example", "c.example", "d.example"}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+
+		domain := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/domains/"), "/")[0]
+		if domain == "missing.example" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Domain does not exist"}`))
+			return
+		}
+		// Earlier domains answer later, so completion order differs from request order.
+		for i, d := range domains {
+			if d == domain {
+				time.Sleep(time.Duration(len(domains)-i) * 10 * time.Millisecond)
+			}
+		}
+		_ = json.NewEncoder(w).Encode([]api.Alias{{ID: domain, Name: "info", Recipients: []string{"me@example.org"}, IsEnabled: true}})
+	}))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetAliasFlags()
+		resetCommandFlags(aliasListCmd)
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	})
+
+	var stdout, stderr bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetArgs([]string{"alias", "list", strings.Join(domains, ","), "--concurrency", "2", "-o", "json"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("alias list: %v\n%s", err, stderr.String())
+	}
+
+	var aliases []api.Alias
+	if err := json.Unmarshal(stdout.Bytes(), &aliases); err != nil {
+		t.Fatalf("stdout is not a JSON list: %v\n%s", err, stdout.String())
+	}
+	var got []string
+	for _, a := range aliases {
+		got = append(got, a.DomainID)
+	}
+	if want := "a.example,b.example,c.example,d.example"; strings.Join(got, ",") != want {
+		t.Errorf("aliases in order %v, want %s", got, want)
+	}
+	if maxInFlight > 2 {
+		t.Errorf("expected at most 2 concurrent requests, saw %d", maxInFlight)
+	}
+	if want := "1 domain(s) could not be listed:\n  - missing.example: "; !strings.Contains(stderr.String(), want) {
+		t.Errorf("expected an aggregated warning, got:\n%s", stderr.String())
+	}
+
+	rootCmd.SetArgs([]string{"alias", "list", "a.example", "--concurrency", "0"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "--concurrency must be at least 1") {
+		t.Errorf("expected a --concurrency error, got %v", err)
+	}
+}
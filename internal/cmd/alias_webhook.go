@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/pkg/output"
+	"github.com/ginsys/forward-email/pkg/webhook"
+)
+
+var (
+	// aliasAllowPrivate accepts webhook recipients on private networks.
+	aliasAllowPrivate bool
+
+	// webhookHTTPClient delivers test payloads; nil uses a client with
+	// webhook.DefaultTimeout. Tests replace it to trust their TLS server.
+	webhookHTTPClient *http.Client
+
+	// newWebhookValidator checks webhook recipients. Tests replace it to
+	// avoid DNS lookups and connections.
+	newWebhookValidator = func() *webhook.Validator {
+		return &webhook.Validator{AllowPrivate: aliasAllowPrivate}
+	}
+)
+
+// aliasWebhookCmd groups the webhook recipient commands
+var aliasWebhookCmd = &cobra.Command{
+	Use:   "webhook",
+	Short: "Work with the webhook recipients of an alias",
+	Long: `Aliases can forward mail to an https webhook, which receives each message as
+JSON. Webhook recipients are checked when an alias is created or updated: the
+URL must use https, its host must resolve and accept connections, and it must
+not be a private address unless --allow-private is given.`,
+}
+
+var aliasWebhookTestCmd = &cobra.Command{
+	Use:   "test <domain> <alias>",
+	Short: "Send a sample message to the webhook recipients of an alias",
+	Long: `Post a sample message, shaped like the JSON Forward Email sends, to every
+webhook recipient of an alias and report the HTTP status and latency of each.
+The sample is marked with "test": true so receivers can ignore it.
+
+The command exits non-zero when a webhook cannot be reached or does not answer
+with a 2xx status.`,
+	Example: `  forward-email alias webhook test example.com support
+  forward-email alias webhook test example.com support -o json`,
+	Args: cobra.ExactArgs(2),
+	RunE: runAliasWebhookTest,
+}
+
+func init() {
+	aliasCmd.AddCommand(aliasWebhookCmd)
+	aliasWebhookCmd.AddCommand(aliasWebhookTestCmd)
+	aliasWebhookTestCmd.Flags().BoolVar(&aliasAllowPrivate, "allow-private", false, "Send to webhooks on private addresses")
+
+	for _, c := range []*cobra.Command{aliasCreateCmd, aliasUpdateCmd, aliasRecipientsCmd, aliasRandomCmd} {
+		c.Flags().BoolVar(&aliasAllowPrivate, "allow-private", false, "Accept webhook recipients on private addresses")
+	}
+}
+
+// validateWebhookRecipients checks the webhook URLs among recipients; email
+// addresses and mail servers are left to the API.
+func validateWebhookRecipients(ctx context.Context, recipients []string) error {
+	v := newWebhookValidator()
+	for _, r := range recipients {
+		if !webhook.IsWebhook(r) {
+			continue
+		}
+		if err := v.Validate(ctx, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runAliasWebhookTest(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	domain, name := args[0], args[1]
+
+	format, err := output.ParseFormat(viper.GetString("output"))
+	if err != nil {
+		return fmt.Errorf("invalid output format: %v", err)
+	}
+
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %v", err)
+	}
+	alias, err := apiClient.Aliases.GetAlias(ctx, domain, name)
+	if err != nil {
+		return fmt.Errorf("failed to get alias: %v", err)
+	}
+
+	var urls []string
+	for _, r := range alias.Recipients {
+		if webhook.IsWebhook(r) {
+			urls = append(urls, r)
+		}
+	}
+	if len(urls) == 0 {
+		return fmt.Errorf("alias %s@%s has no webhook recipients", alias.Name, domain)
+	}
+
+	address := alias.Name + "@" + domain
+	payload := webhook.SamplePayload(address, time.Now())
+	v := newWebhookValidator()
+	tests := make([]output.WebhookTest, 0, len(urls))
+	failed := 0
+	for _, u := range urls {
+		test := output.WebhookTest{URL: u}
+		if err := v.Validate(ctx, u); err != nil {
+			test.Error = err.Error()
+		} else if res, err := webhook.Send(ctx, webhookHTTPClient, u, payload); err != nil {
+			test.Error = err.Error()
+		} else {
+			test.Status, test.StatusCode = res.Status, res.StatusCode
+			test.LatencyMS = float64(res.Latency.Microseconds()) / 1000
+			if !res.OK() {
+				failed++
+			}
+		}
+		if test.Error != "" {
+			failed++
+		}
+		tests = append(tests, test)
+	}
+
+	formatter := output.NewFormatter(format, cmd.OutOrStdout())
+	if format == output.FormatJSON || format == output.FormatYAML {
+		if err := formatter.Format(tests); err != nil {
+			return err
+		}
+	} else {
+		tableData, err := output.FormatWebhookTests(tests, format)
+		if err != nil {
+			return fmt.Errorf("failed to format output: %v", err)
+		}
+		if err := formatter.Format(tableData); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d webhook(s) of %s failed", failed, len(urls), address)
+	}
+	return nil
+}
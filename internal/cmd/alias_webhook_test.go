@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/auth"
+	"github.com/ginsys/forward-email/pkg/webhook"
+)
+
+// stubWebhookNetwork makes every webhook host resolve to a public address
+// and accept connections, so tests need neither DNS nor the internet.
+func stubWebhookNetwork(t *testing.T) {
+	t.Helper()
+	orig := newWebhookValidator
+	newWebhookValidator = func() *webhook.Validator {
+		return &webhook.Validator{
+			AllowPrivate: aliasAllowPrivate,
+			Lookup: func(context.Context, string, string) ([]netip.Addr, error) {
+				return []netip.Addr{netip.MustParseAddr("203.0.113.10")}, nil
+			},
+			Dial: func(context.Context, string, string) (net.Conn, error) {
+				c1, c2 := net.Pipe()
+				_ = c2.Close()
+				return c1, nil
+			},
+		}
+	}
+	t.Cleanup(func() { newWebhookValidator = orig })
+}
+
+func TestAliasWebhook(t *testing.T) {
+	var received int
+	hook := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received++
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer hook.Close()
+	webhookHTTPClient = hook.Client()
+
+	seed := &mockserver.Seed{Domains: []mockserver.SeedDomain{{
+		Domain: api.Domain{Name: "example.com"},
+		Aliases: []api.Alias{
+			{Name: "hooks", Recipients: []string{"me@example.org", hook.URL + "/in"}},
+			{Name: "broken", Recipients: []string{hook.URL + "/broken"}},
+		},
+	}}}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	resetFlags := func() {
+		for _, c := range []*cobra.Command{aliasCreateCmd, aliasRecipientsCmd, aliasWebhookTestCmd} {
+			resetCommandFlags(c)
+		}
+	}
+	t.Cleanup(func() {
+		webhookHTTPClient = nil
+		resetFlags()
+		resetAliasFlags()
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	})
+
+	run := func(args ...string) (string, error) {
+		resetFlags()
+		var out bytes.Buffer
+		rootCmd.SetOut(&out)
+		rootCmd.SetErr(&out)
+		rootCmd.SetArgs(args)
+		err := rootCmd.Execute()
+		return out.String(), err
+	}
+
+	// The test server listens on loopback, which needs --allow-private.
+	_, err := run("alias", "create", "example.com", "ci", "--recipients", hook.URL+"/ci")
+	if err == nil || !strings.Contains(err.Error(), "private address (127.0.0.1)") {
+		t.Fatalf("expected a private webhook to be rejected, got %v", err)
+	}
+	if _, err := run("alias", "create", "example.com", "ci", "--recipients", hook.URL+"/ci", "--allow-private"); err != nil {
+		t.Fatalf("alias create --allow-private: %v", err)
+	}
+	_, err = run("alias", "recipients", "example.com", "ci", "--recipients", "http://hooks.example.net/ci")
+	if err == nil || !strings.Contains(err.Error(), "must use https") {
+		t.Errorf("expected a plain http webhook to be rejected, got %v", err)
+	}
+
+	out, err := run("alias", "webhook", "test", "example.com", "hooks", "--allow-private")
+	if err != nil {
+		t.Fatalf("alias webhook test: %v\n%s", err, out)
+	}
+	if received != 1 || !strings.Contains(out, hook.URL+"/in") || !strings.Contains(out, "200 OK") || !strings.Contains(out, " ms") {
+		t.Errorf("expected one delivery with its status and latency, got %d:\n%s", received, out)
+	}
+
+	out, err = run("alias", "webhook", "test", "example.com", "broken", "--allow-private", "-o", "json")
+	if err == nil || !strings.Contains(err.Error(), "1 of 1 webhook(s) of broken@example.com failed") {
+		t.Errorf("expected a 500 to fail the test, got %v", err)
+	}
+	if !strings.Contains(out, `"status_code": 500`) {
+		t.Errorf("expected the status code in JSON, got:\n%s", out)
+	}
+
+	out, err = run("alias", "webhook", "test", "example.com", "ci", "-o", "table")
+	if err == nil || !strings.Contains(out, "private address") || received != 2 {
+		t.Errorf("expected the test to refuse a private webhook without --allow-private, got %v:\n%s", err, out)
+	}
+}
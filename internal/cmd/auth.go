@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"time"
@@ -105,7 +106,7 @@ func runAuthVerify(cmd *cobra.Command, _ []string) error {
 	// Use centralized client creation which handles all the auth setup
 	apiClient, err := client.NewAPIClient()
 	if err != nil {
-		fmt.Printf("❌ Authentication failed: %v\n", err)
+		cmd.PrintErrf("❌ Authentication failed: %v\n", err)
 		return fmt.Errorf("authentication verification failed")
 	}
 
@@ -122,16 +123,16 @@ func runAuthVerify(cmd *cobra.Command, _ []string) error {
 		}
 	}
 
-	fmt.Printf("✅ Authentication successful for profile '%s'\n", currentProfile)
+	cmd.PrintErrf("✅ Authentication successful for profile '%s'\n", currentProfile)
 
 	// Make a simple API call to double-check
 	_, err = apiClient.Domains.ListDomains(ctx, nil)
 	if err != nil {
-		fmt.Printf("⚠️  Authentication succeeded but API call failed: %v\n", err)
+		cmd.PrintErrf("⚠️  Authentication succeeded but API call failed: %v\n", err)
 		return fmt.Errorf("API verification failed")
 	}
 
-	fmt.Printf("✅ API access verified\n")
+	cmd.PrintErrf("✅ API access verified\n")
 	return nil
 }
 
@@ -165,8 +166,8 @@ func runAuthLogin(cmd *cobra.Command, _ []string) error {
 	case "auto":
 		krStore, err = keyring.New(keyring.Config{})
 		if err != nil {
-			fmt.Printf("Warning: failed to initialize keyring: %v\n", err)
-			fmt.Println("Credentials will be stored in configuration file.")
+			slog.Warn("failed to initialize keyring", "error", err)
+			cmd.PrintErrln("Credentials will be stored in configuration file.")
 			krStore = nil
 		}
 	case "keyring":
@@ -189,9 +190,9 @@ func runAuthLogin(cmd *cobra.Command, _ []string) error {
 			return fmt.Errorf("failed to create keyring dir: %w", mkErr)
 		}
 		if filePass == "" {
-			fmt.Print("File keyring passphrase (will not echo): ")
+			cmd.PrintErr("File keyring passphrase (will not echo): ")
 			passBytes, perr := term.ReadPassword(int(os.Stdin.Fd()))
-			fmt.Println()
+			cmd.PrintErrln()
 			if perr != nil {
 				return fmt.Errorf("failed to read passphrase: %w", perr)
 			}
@@ -215,18 +216,18 @@ func runAuthLogin(cmd *cobra.Command, _ []string) error {
 	}
 
 	// Prompt for API key
-	fmt.Printf("Forward Email CLI Login\n")
-	fmt.Printf("Profile: %s\n\n", profile)
-	fmt.Printf("Please enter your Forward Email API key.\n")
-	fmt.Printf("You can find this in your Forward Email account under Security settings.\n\n")
-	fmt.Print("API Key: ")
+	cmd.PrintErrf("Forward Email CLI Login\n")
+	cmd.PrintErrf("Profile: %s\n\n", profile)
+	cmd.PrintErrf("Please enter your Forward Email API key.\n")
+	cmd.PrintErrf("You can find this in your Forward Email account under Security settings.\n\n")
+	cmd.PrintErr("API Key: ")
 
 	// Read API key securely
 	apiKeyBytes, err := term.ReadPassword(int(os.Stdin.Fd()))
 	if err != nil {
 		return fmt.Errorf("failed to read API key: %w", err)
 	}
-	fmt.Println() // Add newline after password input
+	cmd.PrintErrln() // Add newline after password input
 
 	apiKey := string(apiKeyBytes)
 	if apiKey == "" {
@@ -258,15 +259,15 @@ func runAuthLogin(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("auth provider does not support credential management")
 	}
 
-	fmt.Printf("✅ Successfully logged in to profile '%s'\n", profile)
+	cmd.PrintErrf("✅ Successfully logged in to profile '%s'\n", profile)
 
 	// Set as current profile if it's not already
 	if cfg.CurrentProfile != profile {
 		cfg.CurrentProfile = profile
 		if err := cfg.Save(); err != nil {
-			fmt.Printf("Warning: failed to set current profile: %v\n", err)
+			slog.Warn("failed to set current profile", "profile", profile, "error", err)
 		} else {
-			fmt.Printf("Set '%s' as the current profile\n", profile)
+			cmd.PrintErrf("Set '%s' as the current profile\n", profile)
 		}
 	}
 
@@ -297,7 +298,7 @@ func runAuthLogout(cmd *cobra.Command, _ []string) error {
 	// Initialize keyring
 	ring, err := keyring.New(keyring.Config{})
 	if err != nil {
-		fmt.Printf("Warning: failed to initialize keyring: %v\n", err)
+		slog.Warn("failed to initialize keyring", "error", err)
 	}
 
 	if logoutAll {
@@ -320,15 +321,15 @@ func runAuthLogout(cmd *cobra.Command, _ []string) error {
 		}
 
 		if len(profiles) == 0 {
-			fmt.Println("No profiles found to log out from")
+			cmd.PrintErrln("No profiles found to log out from")
 			return nil
 		}
 
 		for _, p := range profiles {
 			if err := logoutProfile(cfg, ring, p); err != nil {
-				fmt.Printf("Warning: failed to logout from profile '%s': %v\n", p, err)
+				slog.Warn("failed to log out", "profile", p, "error", err)
 			} else {
-				fmt.Printf("✅ Logged out from profile '%s'\n", p)
+				cmd.PrintErrf("✅ Logged out from profile '%s'\n", p)
 			}
 		}
 	} else {
@@ -336,7 +337,7 @@ func runAuthLogout(cmd *cobra.Command, _ []string) error {
 		if err := logoutProfile(cfg, ring, profile); err != nil {
 			return fmt.Errorf("failed to logout from profile '%s': %w", profile, err)
 		}
-		fmt.Printf("✅ Logged out from profile '%s'\n", profile)
+		cmd.PrintErrf("✅ Logged out from profile '%s'\n", profile)
 	}
 
 	return nil
@@ -379,7 +380,7 @@ func runAuthStatus(_ *cobra.Command, _ []string) error {
 	// Initialize keyring
 	ring, err := keyring.New(keyring.Config{})
 	if err != nil {
-		fmt.Printf("Warning: failed to initialize keyring: %v\n", err)
+		slog.Info("keyring unavailable", "error", err)
 	}
 
 	fmt.Printf("Authentication Status\n")
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+)
+
+// TestCassetteReplay runs a command against a recorded cassette, with no
+// profile or credentials configured.
+func TestCassetteReplay(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	cassette := filepath.Join(dir, "domains.yaml")
+	data := `interactions:
+  - request:
+      method: GET
+      url: /v1/domains?limit=25&order=asc&page=1&sort=name
+    response:
+      status: 200
+      headers:
+        Content-Type: application/json
+      body: '[{"id":"1","name":"recorded.example","plan":"free"}]'
+`
+	if err := os.WriteFile(cassette, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	client.ResetTestMode()
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		_ = rootCmd.PersistentFlags().Set("cassette", "")
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	})
+
+	var stdout, stderr bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetArgs([]string{"domain", "list", "-o", "json", "--cassette", cassette})
+	var err error
+	out := captureStdout(t, func() { err = rootCmd.Execute() })
+	if err != nil {
+		t.Fatalf("domain list: %v\n%s", err, stderr.String())
+	}
+	if !strings.Contains(out, `"name": "recorded.example"`) {
+		t.Errorf("expected the recorded domain, got:\n%s", out)
+	}
+}
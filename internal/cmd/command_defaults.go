@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/pkg/config"
+)
+
+// applyCommandDefaults sets the flags of cmd that the config gives defaults
+// for under defaults.<command path>, e.g. defaults.alias.list.limit, unless
+// they were given on the command line. The value is set as if it had been
+// typed, so it is validated by the flag and the command sees it as given.
+func applyCommandDefaults(cmd *cobra.Command) error {
+	cfg, err := config.Load()
+	if err != nil {
+		// Commands that use the config report why it cannot be read
+		return nil
+	}
+	profile := viper.GetString("profile")
+	if profile == "" {
+		profile = cfg.CurrentProfile
+	}
+	path := commandPath(cmd)
+	values := cfg.CommandDefaults(profile, path)
+	if len(values) == 0 {
+		return nil
+	}
+
+	key := strings.Join(append([]string{"defaults"}, path...), ".")
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil {
+			return fmt.Errorf("invalid config key %s.%s: '%s' has no --%s flag", key, name, cmd.CommandPath(), name)
+		}
+		if flag.Changed {
+			continue
+		}
+		if err := setFlagDefault(cmd.Flags(), flag, values[name]); err != nil {
+			return fmt.Errorf("invalid config key %s.%s: %v", key, name, err)
+		}
+	}
+	return nil
+}
+
+// commandPath returns the names of cmd and its parents below the root, e.g.
+// ["alias", "list"] for 'alias ls'.
+func commandPath(cmd *cobra.Command) []string {
+	var path []string
+	for c := cmd; c.HasParent(); c = c.Parent() {
+		path = append([]string{c.Name()}, path...)
+	}
+	return path
+}
+
+// setFlagDefault sets flag to a value read from the config. Lists set list
+// flags as a whole rather than adding to them.
+func setFlagDefault(flags *pflag.FlagSet, flag *pflag.Flag, value any) error {
+	if list, ok := value.([]any); ok {
+		items := make([]string, len(list))
+		for i, v := range list {
+			items[i] = fmt.Sprint(v)
+		}
+		if sv, ok := flag.Value.(pflag.SliceValue); ok {
+			if err := sv.Replace(items); err != nil {
+				return err
+			}
+			flag.Changed = true
+			return nil
+		}
+		value = strings.Join(items, ",")
+	}
+	return flags.Set(flag.Name, fmt.Sprint(value))
+}
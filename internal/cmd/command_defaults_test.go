@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+)
+
+func TestCommandDefaults(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	if err := os.MkdirAll(filepath.Join(dir, "forwardemail"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	writeConfig := func(cfg string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, "forwardemail", "config.yaml"), []byte(cfg), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    aliases:
+      - name: alpha
+        recipients: [me@example.org]
+      - name: bravo
+        recipients: [me@example.org]
+      - name: charlie
+        recipients: [me@example.org]
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	commands := []*cobra.Command{aliasListCmd}
+	t.Cleanup(func() {
+		for _, c := range commands {
+			resetCommandFlags(c)
+		}
+		resetAliasFlags()
+	})
+
+	run := func(args ...string) ([]string, error) {
+		t.Helper()
+		for _, c := range commands {
+			resetCommandFlags(c)
+		}
+		resetAliasFlags()
+		viper.Reset()
+		bindRootFlags()
+		var stdout, stderr bytes.Buffer
+		rootCmd.SetOut(&stdout)
+		rootCmd.SetErr(&stderr)
+		rootCmd.SetArgs(append(args, "-o", "json"))
+		if err := rootCmd.Execute(); err != nil {
+			return nil, err
+		}
+		var aliases []struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(stdout.Bytes(), &aliases); err != nil {
+			t.Fatalf("decode %q: %v", stdout.String(), err)
+		}
+		names := make([]string, len(aliases))
+		for i, a := range aliases {
+			names[i] = a.Name
+		}
+		return names, nil
+	}
+
+	writeConfig(`
+current_profile: default
+defaults:
+  alias:
+    list:
+      limit: 2
+      order-by: name:desc
+profiles:
+  default:
+    api_key: test
+`)
+	names, err := run("alias", "list", "example.com")
+	if err != nil {
+		t.Fatalf("alias list: %v", err)
+	}
+	if got := strings.Join(names, ","); got != "bravo,alpha" {
+		t.Errorf("expected the config's limit and order, got %s", got)
+	}
+
+	// The command line wins over the config, and the alias 'ls' is the same command
+	names, err = run("alias", "ls", "example.com", "--order-by", "name")
+	if err != nil {
+		t.Fatalf("alias ls: %v", err)
+	}
+	if got := strings.Join(names, ","); got != "alpha,bravo" {
+		t.Errorf("expected --order-by to override the config, got %s", got)
+	}
+
+	// A profile's defaults override the config-wide ones
+	writeConfig(`
+current_profile: default
+defaults:
+  alias:
+    list:
+      limit: 2
+      order-by: name:desc
+profiles:
+  default:
+    api_key: test
+    defaults:
+      alias:
+        list:
+          limit: 1
+`)
+	names, err = run("alias", "list", "example.com")
+	if err != nil {
+		t.Fatalf("alias list: %v", err)
+	}
+	if got := strings.Join(names, ","); got != "alpha" {
+		t.Errorf("expected the profile's limit, got %s", got)
+	}
+
+	writeConfig(`
+defaults:
+  alias:
+    list:
+      limti: 2
+`)
+	if _, err = run("alias", "list", "example.com"); err == nil || !strings.Contains(err.Error(), "defaults.alias.list.limti") {
+		t.Errorf("expected an error naming the unknown config key, got %v", err)
+	}
+
+	writeConfig(`
+defaults:
+  alias:
+    list:
+      limit: many
+`)
+	if _, err = run("alias", "list", "example.com"); err == nil || !strings.Contains(err.Error(), "defaults.alias.list.limit") {
+		t.Errorf("expected an error naming the invalid config value, got %v", err)
+	}
+}
@@ -2,6 +2,9 @@ package cmd
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
 )
@@ -17,19 +20,7 @@ refer to your OS/shell documentation.`,
 	Args:      cobra.ExactArgs(1),
 	ValidArgs: []string{"bash", "zsh", "fish", "powershell"},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		w := cmd.OutOrStdout()
-		switch args[0] {
-		case "bash":
-			return rootCmd.GenBashCompletion(w)
-		case "zsh":
-			return rootCmd.GenZshCompletion(w)
-		case "fish":
-			return rootCmd.GenFishCompletion(w, true)
-		case "powershell":
-			return rootCmd.GenPowerShellCompletionWithDesc(w)
-		default:
-			return fmt.Errorf("unsupported shell: %s", args[0])
-		}
+		return genCompletion(cmd.OutOrStdout(), args[0])
 	},
 	Example: `  forward-email completion bash > /usr/local/etc/bash_completion.d/forward-email
   forward-email completion zsh > /usr/local/share/zsh/site-functions/_forward-email
@@ -40,3 +31,63 @@ refer to your OS/shell documentation.`,
 func init() {
 	rootCmd.AddCommand(completionCmd)
 }
+
+// genCompletion writes the completion script for shell to w.
+func genCompletion(w io.Writer, shell string) error {
+	switch shell {
+	case "bash":
+		return rootCmd.GenBashCompletion(w)
+	case "zsh":
+		return rootCmd.GenZshCompletion(w)
+	case "fish":
+		return rootCmd.GenFishCompletion(w, true)
+	case "powershell":
+		return rootCmd.GenPowerShellCompletionWithDesc(w)
+	default:
+		return fmt.Errorf("unsupported shell: %s", shell)
+	}
+}
+
+// completionInstallPath returns the per-user location a shell loads completions from.
+// PowerShell has no such directory, so it is not supported here.
+func completionInstallPath(shell, home string) (string, error) {
+	switch shell {
+	case "bash":
+		dataDir := os.Getenv("XDG_DATA_HOME")
+		if dataDir == "" {
+			dataDir = filepath.Join(home, ".local", "share")
+		}
+		return filepath.Join(dataDir, "bash-completion", "completions", "forward-email"), nil
+	case "zsh":
+		return filepath.Join(home, ".zsh", "completions", "_forward-email"), nil
+	case "fish":
+		cfgDir := os.Getenv("XDG_CONFIG_HOME")
+		if cfgDir == "" {
+			cfgDir = filepath.Join(home, ".config")
+		}
+		return filepath.Join(cfgDir, "fish", "completions", "forward-email.fish"), nil
+	default:
+		return "", fmt.Errorf("automatic installation is not supported for shell %q", shell)
+	}
+}
+
+// installCompletion writes the completion script for shell into its per-user
+// completion directory and returns the path written.
+func installCompletion(shell, home string) (string, error) {
+	path, err := completionInstallPath(shell, home)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return "", fmt.Errorf("failed to create completion dir: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return "", fmt.Errorf("failed to create completion file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	if err := genCompletion(f, shell); err != nil {
+		return "", err
+	}
+	return path, nil
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/ginsys/forward-email/pkg/i18n"
+)
+
+// errConfirmationRequired is returned when a command needs confirmation but stdin
+// is not a terminal and no answer could be read from it.
+var errConfirmationRequired = errors.New("confirmation required but stdin is not a terminal; re-run with --force or --yes")
+
+// confirm asks the user to approve an action and reports whether it may proceed.
+//
+// The prompt is skipped (and true returned) when the command has a --force or --yes
+// flag set. Answers are read from cmd.InOrStdin(), so piped input such as
+// `echo yes | forward-email ...` works; "y" and "yes", or their translations in the
+// --lang language, approve, anything else declines.
+// When stdin is not a terminal and ends without an answer, errConfirmationRequired is
+// returned instead of silently treating the missing answer as "no".
+func confirm(cmd *cobra.Command, question string) (bool, error) {
+	for _, name := range []string{"force", "yes"} {
+		if f := cmd.Flags().Lookup(name); f != nil && f.Value.String() == "true" {
+			return true, nil
+		}
+	}
+
+	in := cmd.InOrStdin()
+	cmd.PrintErrf("%s %s: ", question, i18n.T("[y/N]"))
+
+	line, err := bufio.NewReader(in).ReadString('\n')
+	answer := strings.ToLower(strings.TrimSpace(line))
+	if err != nil && answer == "" {
+		cmd.PrintErrln()
+		if err == io.EOF && !isTerminal(in) {
+			return false, errConfirmationRequired
+		}
+		return false, nil
+	}
+
+	switch answer {
+	case "y", yesStr, i18n.T("y"), i18n.T(yesStr):
+		return true, nil
+	}
+	return false, nil
+}
+
+// isTerminal reports whether r is an interactive terminal.
+func isTerminal(r io.Reader) bool {
+	f, ok := r.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
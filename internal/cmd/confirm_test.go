@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/pkg/auth"
+	"github.com/ginsys/forward-email/pkg/i18n"
+)
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		flags   []string
+		want    bool
+		wantErr error
+	}{
+		{name: "y approves", input: "y\n", want: true},
+		{name: "yes approves case-insensitively", input: "YES\n", want: true},
+		{name: "answer without trailing newline", input: "yes", want: true},
+		{name: "no declines", input: "no\n", want: false},
+		{name: "blank line declines", input: "\n", want: false},
+		{name: "closed non-terminal stdin errors", input: "", wantErr: errConfirmationRequired},
+		{name: "force skips prompt", input: "", flags: []string{"--force"}, want: true},
+		{name: "yes flag skips prompt", input: "", flags: []string{"--yes"}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got bool
+			var gotErr error
+			c := &cobra.Command{
+				Use: "test",
+				RunE: func(cmd *cobra.Command, _ []string) error {
+					got, gotErr = confirm(cmd, "Proceed?")
+					return nil
+				},
+			}
+			c.Flags().Bool("force", false, "")
+			c.Flags().Bool("yes", false, "")
+			var out bytes.Buffer
+			c.SetIn(strings.NewReader(tt.input))
+			c.SetOut(&out)
+			c.SetErr(&out)
+			c.SetArgs(tt.flags)
+			if err := c.Execute(); err != nil {
+				t.Fatalf("execute: %v", err)
+			}
+
+			if !errors.Is(gotErr, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, gotErr)
+			}
+			if got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+			if len(tt.flags) == 0 && !strings.Contains(out.String(), "Proceed? [y/N]") {
+				t.Errorf("expected prompt in output, got %q", out.String())
+			}
+		})
+	}
+}
+
+func TestDomainDelete_Confirmation(t *testing.T) {
+	deleted := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete && r.URL.Path == "/v1/domains/example.com" {
+			deleted++
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/domains/example.com":
+			_, _ = w.Write([]byte(`{"id":"d1","name":"example.com"}`))
+			return
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/domains/example.com/aliases":
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	t.Cleanup(func() { _ = domainDeleteCmd.Flags().Set("force", "false") })
+
+	run := func(input string, args ...string) (string, error) {
+		var out bytes.Buffer
+		rootCmd.SetIn(strings.NewReader(input))
+		rootCmd.SetOut(&out)
+		rootCmd.SetErr(&out)
+		rootCmd.SetArgs(append([]string{"domain", "delete", "example.com"}, args...))
+		err := rootCmd.Execute()
+		return out.String(), err
+	}
+
+	if out, err := run("no\n"); err != nil || deleted != 0 || !strings.Contains(out, "Domain deletion canceled") {
+		t.Fatalf("expected cancel on 'no', got err=%v deleted=%d out=%s", err, deleted, out)
+	}
+	if _, err := run(""); !errors.Is(err, errConfirmationRequired) || deleted != 0 {
+		t.Fatalf("expected confirmation error on empty piped stdin, got err=%v deleted=%d", err, deleted)
+	}
+	if out, err := run("yes\n"); err != nil || deleted != 1 {
+		t.Fatalf("expected deletion after piped 'yes', got err=%v deleted=%d out=%s", err, deleted, out)
+	}
+	if out, err := run("", "--force"); err != nil || deleted != 2 {
+		t.Fatalf("expected deletion with --force, got err=%v deleted=%d out=%s", err, deleted, out)
+	}
+}
+
+func TestConfirm_Language(t *testing.T) {
+	if err := i18n.SetLanguage("de"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = i18n.SetLanguage(i18n.English) })
+
+	for input, want := range map[string]bool{"j\n": true, "Ja\n": true, "yes\n": true, "nein\n": false} {
+		var got bool
+		c := &cobra.Command{
+			Use: "test",
+			RunE: func(cmd *cobra.Command, _ []string) (err error) {
+				got, err = confirm(cmd, i18n.T("Send this email?"))
+				return err
+			},
+		}
+		var out bytes.Buffer
+		c.SetIn(strings.NewReader(input))
+		c.SetErr(&out)
+		if err := c.Execute(); err != nil {
+			t.Fatalf("execute: %v", err)
+		}
+		if got != want {
+			t.Errorf("answer %q: got %v, want %v", input, got, want)
+		}
+		if prompt := out.String(); prompt != "Diese E-Mail senden? [j/N]: " {
+			t.Errorf("unexpected prompt %q", prompt)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/term"
+
+	"github.com/ginsys/forward-email/pkg/config"
+	"github.com/ginsys/forward-email/pkg/contacts"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+// carddavPasswordEnv holds the CardDAV password for contact sync, so scripts
+// need neither a prompt nor stdin.
+const carddavPasswordEnv = "FORWARDEMAIL_CARDDAV_PASSWORD"
+
+var (
+	contactForce         bool
+	contactSyncURL       string
+	contactSyncUser      string
+	contactSyncAllowHTTP bool
+	contactPasswordStdin bool
+)
+
+// contactCmd represents the contact command group
+var contactCmd = &cobra.Command{
+	Use:     "contact",
+	Aliases: []string{"contacts"},
+	Short:   "Manage the local address book used by email send",
+	Long: `Keep the addresses you send to in a local address book, so that they are
+typed once instead of on every send. A contact is a nickname for an address,
+and a group such as @team is a list of contacts, other groups and addresses.
+
+'email send' expands contacts and groups in --to, --cc and --bcc, as well as
+in the To, Cc and Bcc headers of --edit and the interactive composer:
+--to @team,carol sends to every member of @team and to carol. The book is
+stored in contacts.yaml in the config directory.`,
+}
+
+var contactAddCmd = &cobra.Command{
+	Use:   "add <name> <address> | @<group> <member>...",
+	Short: "Add a contact, or members to a group",
+	Long: `Add a contact named <name> for <address>, which may have a display name.
+With an @group name the remaining arguments are added to that group, which is
+created if needed: contact names, other @groups or addresses.`,
+	Example: `  forward-email contact add alice 'Alice Smith <alice@example.com>'
+  forward-email contact add @team alice bob carol@example.org
+  forward-email email send --to @team --subject "Standup" --text "Moved to 10:00"`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runContactAdd,
+}
+
+var contactListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List contacts and groups",
+	Args:    cobra.NoArgs,
+	RunE:    runContactList,
+}
+
+var contactRemoveCmd = &cobra.Command{
+	Use:     "remove <name|@group>...",
+	Aliases: []string{"rm"},
+	Short:   "Remove contacts or groups",
+	Long:    `Remove contacts or groups. They are also removed from the groups they belong to.`,
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    runContactRemove,
+}
+
+var contactSyncCmd = &cobra.Command{
+	Use:   "sync --carddav-url <url> --user <user>",
+	Short: "Sync contacts from a CardDAV address book",
+	Long: `Download the contacts of a CardDAV address book. Each card with an email
+address becomes a contact named after its nickname, or else the local part of
+its address. Contacts from an earlier sync are replaced; contacts added by
+hand are kept, and cards whose name is already taken by one are skipped.
+
+The password is read from --password-stdin, the
+FORWARDEMAIL_CARDDAV_PASSWORD environment variable, or a prompt. The URL
+must use https; --allow-http accepts plain http, e.g. for a server on
+localhost.`,
+	Example: `  forward-email contact sync --carddav-url https://dav.example.com/addressbooks/me/default/ --user me@example.com
+  echo "$PASSWORD" | forward-email contact sync --carddav-url https://dav.example.com/addressbooks/me/default/ \
+    --user me@example.com --password-stdin`,
+	Args: cobra.NoArgs,
+	RunE: runContactSync,
+}
+
+func init() {
+	rootCmd.AddCommand(contactCmd)
+	contactCmd.AddCommand(contactAddCmd, contactListCmd, contactRemoveCmd, contactSyncCmd)
+
+	contactAddCmd.Flags().BoolVarP(&contactForce, "force", "f", false, "Replace a contact of the same name")
+	contactSyncCmd.Flags().StringVar(&contactSyncURL, "carddav-url", "", "URL of the CardDAV address book")
+	contactSyncCmd.Flags().StringVar(&contactSyncUser, "user", "", "CardDAV user name")
+	contactSyncCmd.Flags().BoolVar(&contactPasswordStdin, "password-stdin", false, "Read the CardDAV password from stdin")
+	contactSyncCmd.Flags().BoolVar(&contactSyncAllowHTTP, "allow-http", false, "Allow a plain http:// CardDAV URL (sends the password unencrypted)")
+	_ = contactSyncCmd.MarkFlagRequired("carddav-url")
+	_ = contactSyncCmd.MarkFlagRequired("user")
+}
+
+// contactsPath returns the path of the address book in the config directory.
+func contactsPath() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate config directory: %v", err)
+	}
+	return filepath.Join(dir, "contacts.yaml"), nil
+}
+
+func loadContacts() (*contacts.Book, string, error) {
+	path, err := contactsPath()
+	if err != nil {
+		return nil, "", err
+	}
+	book, err := contacts.Load(path)
+	if err != nil {
+		return nil, "", err
+	}
+	return book, path, nil
+}
+
+func runContactAdd(cmd *cobra.Command, args []string) error {
+	book, path, err := loadContacts()
+	if err != nil {
+		return err
+	}
+	name := args[0]
+	if strings.HasPrefix(name, "@") {
+		if err := book.AddToGroup(name, args[1:]...); err != nil {
+			return err
+		}
+		if err := book.Save(path); err != nil {
+			return err
+		}
+		g, _ := book.Group(name)
+		cmd.PrintErrf("✅ Group @%s has %d member(s)\n", g.Name, len(g.Members))
+		return nil
+	}
+
+	if len(args) != 2 {
+		return fmt.Errorf("a contact has one address; to add several, make a group: contact add @%s ...", name)
+	}
+	if err := book.AddContact(contacts.Contact{Name: name, Address: args[1]}, contactForce); err != nil {
+		if strings.Contains(err.Error(), "already exists") {
+			return fmt.Errorf("%v; use --force to replace it", err)
+		}
+		return err
+	}
+	if err := book.Save(path); err != nil {
+		return err
+	}
+	c, _ := book.Contact(name)
+	cmd.PrintErrf("✅ Contact %s added: %s\n", c.Name, c.Address)
+	return nil
+}
+
+func runContactList(cmd *cobra.Command, _ []string) error {
+	format, err := output.ParseFormat(viper.GetString("output"))
+	if err != nil {
+		return fmt.Errorf("invalid output format: %v", err)
+	}
+	book, _, err := loadContacts()
+	if err != nil {
+		return err
+	}
+	formatter := output.NewFormatter(format, cmd.OutOrStdout())
+	if format == output.FormatJSON || format == output.FormatYAML {
+		if book.Contacts == nil {
+			book.Contacts = []contacts.Contact{}
+		}
+		if book.Groups == nil {
+			book.Groups = []contacts.Group{}
+		}
+		return formatter.Format(book)
+	}
+	if len(book.Contacts) == 0 && len(book.Groups) == 0 {
+		cmd.PrintErrln("No contacts found; add one with 'forward-email contact add <name> <address>'")
+		return nil
+	}
+	tableData, err := output.FormatContacts(book, format)
+	if err != nil {
+		return fmt.Errorf("failed to format output: %v", err)
+	}
+	return formatter.Format(tableData)
+}
+
+func runContactRemove(cmd *cobra.Command, args []string) error {
+	book, path, err := loadContacts()
+	if err != nil {
+		return err
+	}
+	for _, name := range args {
+		from, err := book.Remove(name)
+		if err != nil {
+			return err
+		}
+		cmd.PrintErrf("✅ Removed %s\n", strings.ToLower(name))
+		if len(from) > 0 {
+			cmd.PrintErrf("   also removed from %s\n", strings.Join(from, ", "))
+		}
+	}
+	return book.Save(path)
+}
+
+func runContactSync(cmd *cobra.Command, _ []string) error {
+	if err := contacts.CheckURL(contactSyncURL, contactSyncAllowHTTP); err != nil {
+		return err
+	}
+	book, path, err := loadContacts()
+	if err != nil {
+		return err
+	}
+	password, err := carddavPassword(cmd)
+	if err != nil {
+		return err
+	}
+
+	stop := startSpinner(cmd, "Fetching address book")
+	synced, err := contacts.FetchCardDAV(cmd.Context(), nil, contactSyncURL, contactSyncUser, password)
+	stop()
+	if err != nil {
+		return err
+	}
+
+	added, skipped := book.Sync(contacts.SourceCardDAV, synced)
+	if err := book.Save(path); err != nil {
+		return err
+	}
+	cmd.PrintErrf("✅ Synced %d contact(s) from CardDAV\n", added)
+	for _, c := range skipped {
+		cmd.PrintErrf("⚠️  Skipped %s (%s): the name is invalid or taken by a local contact\n", c.Name, c.Address)
+	}
+	return nil
+}
+
+// carddavPassword returns the CardDAV password from stdin, the environment or
+// a terminal prompt.
+func carddavPassword(cmd *cobra.Command) (string, error) {
+	if contactPasswordStdin {
+		return readPasswordStdin(cmd.InOrStdin())
+	}
+	if p := os.Getenv(carddavPasswordEnv); p != "" {
+		return p, nil
+	}
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("no CardDAV password: use --password-stdin or set %s", carddavPasswordEnv)
+	}
+	cmd.PrintErrf("CardDAV password for %s (will not echo): ", contactSyncUser)
+	p, err := term.ReadPassword(fd)
+	cmd.PrintErrln()
+	if err != nil {
+		return "", fmt.Errorf("failed to read password: %v", err)
+	}
+	return string(p), nil
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/pkg/contacts"
+)
+
+func TestContacts(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	viper.Reset()
+	bindRootFlags()
+	reset := func() {
+		resetCommandFlags(contactAddCmd)
+		resetCommandFlags(contactRemoveCmd)
+		resetCommandFlags(contactSyncCmd)
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	}
+	t.Cleanup(reset)
+
+	run := func(args ...string) (string, string, error) {
+		t.Helper()
+		reset()
+		var stdout, stderr bytes.Buffer
+		rootCmd.SetOut(&stdout)
+		rootCmd.SetErr(&stderr)
+		rootCmd.SetArgs(append([]string{"contact"}, args...))
+		err := rootCmd.Execute()
+		return stdout.String(), stderr.String(), err
+	}
+
+	for _, args := range [][]string{
+		{"add", "alice", "Alice Smith <alice@example.com>"},
+		{"add", "bob", "bob@example.com"},
+		{"add", "carol", "carol@example.org"},
+		{"add", "@team", "alice", "bob", "ops@example.net"},
+		{"add", "@all", "@team", "carol"},
+	} {
+		if _, stderr, err := run(args...); err != nil {
+			t.Fatalf("%v: %v\n%s", args, err, stderr)
+		}
+	}
+
+	t.Run("add refuses to replace without --force", func(t *testing.T) {
+		if _, _, err := run("add", "bob", "robert@example.com"); err == nil || !strings.Contains(err.Error(), "--force") {
+			t.Fatalf("expected a hint to use --force, got %v", err)
+		}
+		if _, _, err := run("add", "@team", "nobody"); err == nil || !strings.Contains(err.Error(), "unknown contact nobody") {
+			t.Fatalf("expected an unknown member to be rejected, got %v", err)
+		}
+	})
+
+	t.Run("sync refuses plain http without --allow-http", func(t *testing.T) {
+		t.Setenv(carddavPasswordEnv, "secret")
+		_, _, err := run("sync", "--carddav-url", "http://dav.example.com/ab/", "--user", "me")
+		if err == nil || !strings.Contains(err.Error(), "--allow-http") {
+			t.Fatalf("expected plain http to be refused, got %v", err)
+		}
+	})
+
+	t.Run("send flags expand contacts and groups", func(t *testing.T) {
+		got, err := parseEmailAddressList("@all, Bob, dave@example.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := `"Alice Smith" <alice@example.com> | bob@example.com | ops@example.net | carol@example.org | dave@example.com`
+		if strings.Join(got, " | ") != want {
+			t.Errorf("unexpected expansion: %s", strings.Join(got, " | "))
+		}
+
+		_, err = parseEmailAddressList("bob@example.com, @nobody")
+		if err == nil || !strings.Contains(err.Error(), `invalid address "@nobody" at position 18`) ||
+			!strings.Contains(err.Error(), "unknown group @nobody") {
+			t.Errorf("expected the unknown group and its position, got %v", err)
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		stdout, _, err := run("list", "--output", "json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var book contacts.Book
+		if err := json.Unmarshal([]byte(stdout), &book); err != nil {
+			t.Fatalf("invalid JSON: %v\n%s", err, stdout)
+		}
+		if len(book.Contacts) != 3 || len(book.Groups) != 2 {
+			t.Errorf("expected 3 contacts and 2 groups, got %+v", book)
+		}
+
+		stdout, _, err = run("list")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(stdout, "@team") || !strings.Contains(stdout, "alice, bob, ops@example.net") {
+			t.Errorf("expected the groups and their members in the table:\n%s", stdout)
+		}
+	})
+
+	t.Run("remove drops the contact from its groups", func(t *testing.T) {
+		_, stderr, err := run("remove", "bob")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(stderr, "also removed from @team") {
+			t.Errorf("expected the groups bob was removed from, got %q", stderr)
+		}
+		got, err := parseEmailAddressList("@team")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Join(got, " | ") != `"Alice Smith" <alice@example.com> | ops@example.net` {
+			t.Errorf("unexpected expansion after remove: %v", got)
+		}
+	})
+}
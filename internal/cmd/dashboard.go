@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+// dashboardAlertPercent is the usage, in percent of a limit, from which the
+// dashboard lists a quota as an alert.
+const dashboardAlertPercent = 80
+
+// dashboardTimeout bounds the API calls of the dashboard, so that a bare
+// invocation never hangs on an unreachable API.
+const dashboardTimeout = 10 * time.Second
+
+// dashboard is the account summary printed by a bare invocation.
+type dashboard struct {
+	Profile         string   `json:"profile,omitempty" yaml:"profile,omitempty"`
+	Domains         int      `json:"domains" yaml:"domains"`
+	Unverified      []string `json:"unverified_domains" yaml:"unverified_domains"`
+	EmailsSentToday *int     `json:"emails_sent_today,omitempty" yaml:"emails_sent_today,omitempty"`
+	EmailsLimit     int      `json:"emails_limit,omitempty" yaml:"emails_limit,omitempty"`
+	Alerts          []string `json:"alerts" yaml:"alerts"`
+}
+
+func init() {
+	rootCmd.RunE = runDashboard
+	rootCmd.Flags().Bool("no-dashboard", false, "Show help instead of the account summary when no command is given")
+}
+
+// runDashboard runs for a bare invocation. With credentials it prints a
+// summary of the account; without them, with --no-dashboard, or when the API
+// cannot be reached, it shows the help as before.
+func runDashboard(cmd *cobra.Command, _ []string) error {
+	if off, _ := cmd.Flags().GetBool("no-dashboard"); off {
+		return cmd.Help()
+	}
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		return cmd.Help()
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), dashboardTimeout)
+	defer cancel()
+	stop := startSpinner(cmd, "Loading account summary")
+	d, err := loadDashboard(ctx, apiClient)
+	stop()
+	if err != nil {
+		if helpErr := cmd.Help(); helpErr != nil {
+			return helpErr
+		}
+		cmd.PrintErrf("\nAccount summary unavailable: %v\n", err)
+		return nil
+	}
+	_, d.Profile = activeProfile()
+
+	format, err := output.ParseFormat(viper.GetString("output"))
+	if err != nil {
+		return fmt.Errorf("invalid output format: %v", err)
+	}
+	if format == output.FormatJSON || format == output.FormatYAML {
+		return output.NewFormatter(format, cmd.OutOrStdout()).Format(d)
+	}
+	printDashboard(cmd, d)
+	return nil
+}
+
+// loadDashboard gathers the summary: every domain, and the account's sending
+// quota when the API reports one.
+func loadDashboard(ctx context.Context, c *api.Client) (*dashboard, error) {
+	var domains []api.Domain
+	opts := &api.ListDomainsOptions{Page: 1, Limit: 1000}
+	for {
+		resp, err := c.Domains.ListDomains(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		domains = append(domains, resp.Domains...)
+		if !resp.Pagination.HasNext || len(resp.Domains) == 0 {
+			break
+		}
+		opts.Page++
+	}
+
+	d := &dashboard{Domains: len(domains), Unverified: []string{}, Alerts: []string{}}
+	for _, dom := range domains {
+		if !dom.IsVerified {
+			d.Unverified = append(d.Unverified, dom.Name)
+		}
+		if dom.IsSMTPSuspended {
+			d.Alerts = append(d.Alerts, fmt.Sprintf("%s: outbound SMTP suspended", dom.Name))
+		}
+		if pct, ok := usagePercent(int64(dom.AliasCount), int64(dom.MaxForwardedAddresses)); ok {
+			d.Alerts = append(d.Alerts, fmt.Sprintf("%s: %d of %d aliases used (%.0f%%)",
+				dom.Name, dom.AliasCount, dom.MaxForwardedAddresses, pct))
+		}
+	}
+
+	// The sending quota only exists for accounts with outbound SMTP
+	if quota, err := c.Emails.GetEmailQuota(ctx); err == nil {
+		sent := quota.EmailsSent
+		d.EmailsSentToday, d.EmailsLimit = &sent, quota.EmailsLimit
+		if pct, ok := usagePercent(int64(sent), int64(quota.EmailsLimit)); ok {
+			d.Alerts = append(d.Alerts, fmt.Sprintf("%d of %d emails sent today (%.0f%%)", sent, quota.EmailsLimit, pct))
+		}
+	}
+	return d, nil
+}
+
+// usagePercent returns used as a percentage of limit and whether it reaches
+// dashboardAlertPercent. Without a limit nothing is reported.
+func usagePercent(used, limit int64) (float64, bool) {
+	if limit <= 0 {
+		return 0, false
+	}
+	pct := float64(used) * 100 / float64(limit)
+	return pct, pct >= dashboardAlertPercent
+}
+
+func printDashboard(cmd *cobra.Command, d *dashboard) {
+	out := cmd.OutOrStdout()
+	title := "Forward Email account"
+	if d.Profile != "" {
+		title += " (profile " + d.Profile + ")"
+	}
+	_, _ = fmt.Fprintln(out, title)
+
+	domains := fmt.Sprintf("%d", d.Domains)
+	if n := len(d.Unverified); n > 0 {
+		domains += fmt.Sprintf(" (%d unverified: %s)", n, strings.Join(d.Unverified, ", "))
+	}
+	_, _ = fmt.Fprintf(out, "  %-14s %s\n", "Domains", domains)
+	if d.EmailsSentToday != nil {
+		sent := fmt.Sprintf("%d", *d.EmailsSentToday)
+		if d.EmailsLimit > 0 {
+			sent += fmt.Sprintf(" of %d", d.EmailsLimit)
+		}
+		_, _ = fmt.Fprintf(out, "  %-14s %s\n", "Emails today", sent)
+	}
+	if len(d.Alerts) == 0 {
+		_, _ = fmt.Fprintf(out, "  %-14s %s\n", "Alerts", "none")
+	} else {
+		_, _ = fmt.Fprintln(out, "  Alerts")
+		for _, a := range d.Alerts {
+			_, _ = fmt.Fprintf(out, "    ⚠️  %s\n", a)
+		}
+	}
+	cmd.PrintErrln("\nRun 'forward-email --help' for commands, or 'forward-email --no-dashboard' to show help instead.")
+}
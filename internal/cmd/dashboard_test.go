@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+)
+
+func TestDashboard(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    is_verified: true
+    max_forwarded_addresses: 2
+    aliases:
+      - name: info
+        recipients: [me@example.org]
+      - name: sales
+        recipients: [me@example.org]
+  - name: new.example
+    is_verified: false
+quota:
+  emails_sent: 30
+  emails_limit: 300
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	t.Cleanup(srv.Close)
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+		_ = rootCmd.Flags().Set("no-dashboard", "false")
+		rootCmd.Flags().Lookup("no-dashboard").Changed = false
+	})
+
+	run := func(args ...string) (string, string) {
+		t.Helper()
+		var out, errOut bytes.Buffer
+		rootCmd.SetOut(&out)
+		rootCmd.SetErr(&errOut)
+		rootCmd.SetArgs(args)
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("%v: %v\n%s", args, err, errOut.String())
+		}
+		return out.String(), errOut.String()
+	}
+
+	out, errOut := run()
+	for _, want := range []string{
+		"Domains        2 (1 unverified: new.example)",
+		"Emails today   30 of 300",
+		"example.com: 2 of 2 aliases used (100%)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in dashboard:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "emails sent today") {
+		t.Errorf("sending quota below the alert level should not be an alert:\n%s", out)
+	}
+	if !strings.Contains(errOut, "--no-dashboard") {
+		t.Errorf("expected a hint on stderr, got %q", errOut)
+	}
+
+	out, _ = run("-o", "json")
+	var d dashboard
+	if err := json.Unmarshal([]byte(out), &d); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if d.Domains != 2 || !reflect.DeepEqual(d.Unverified, []string{"new.example"}) || d.EmailsSentToday == nil || *d.EmailsSentToday != 30 {
+		t.Errorf("unexpected dashboard %+v", d)
+	}
+
+	out, _ = run("--no-dashboard")
+	if !strings.Contains(out, "Forward Email CLI") || strings.Contains(out, "Domains") {
+		t.Errorf("expected help with --no-dashboard:\n%s", out)
+	}
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// apiDateLayout is the date format used by API date range filters.
+const apiDateLayout = "2006-01-02"
+
+// parseDateSpec resolves an absolute or relative date relative to now.
+// Accepted forms:
+//
+//	2024-05-01, 2024-05-01T12:00:00Z   absolute dates and RFC 3339 times
+//	now, today, yesterday              calendar-relative, in now's location
+//	30m, 12h, 7d, 2w                   that long before now
+func parseDateSpec(spec string, now time.Time) (time.Time, error) {
+	s := strings.ToLower(strings.TrimSpace(spec))
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch s {
+	case "":
+		return time.Time{}, fmt.Errorf("empty date")
+	case "now":
+		return now, nil
+	case "today":
+		return midnight, nil
+	case "yesterday":
+		return midnight.AddDate(0, 0, -1), nil
+	}
+	if t, err := time.ParseInLocation(apiDateLayout, s, now.Location()); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, strings.ToUpper(s)); err == nil {
+		return t, nil
+	}
+	if d, err := parseExpiresIn(s); err == nil {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q (use YYYY-MM-DD, today, yesterday or a span such as 7d)", spec)
+}
+
+// resolveDateRange parses optional --since/--until style values into API
+// dates, checking that the range is not reversed. Empty values stay empty.
+func resolveDateRange(since, until string, now time.Time) (from, to string, err error) {
+	var fromTime, toTime time.Time
+	if since != "" {
+		if fromTime, err = parseDateSpec(since, now); err != nil {
+			return "", "", fmt.Errorf("invalid --since: %v", err)
+		}
+		from = fromTime.Format(apiDateLayout)
+	}
+	if until != "" {
+		if toTime, err = parseDateSpec(until, now); err != nil {
+			return "", "", fmt.Errorf("invalid --until: %v", err)
+		}
+		to = toTime.Format(apiDateLayout)
+	}
+	if from != "" && to != "" && from > to {
+		return "", "", fmt.Errorf("--since (%s) is after --until (%s)", from, to)
+	}
+	return from, to, nil
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDateSpec(t *testing.T) {
+	now := time.Date(2024, 5, 10, 15, 30, 0, 0, time.UTC)
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "2024-05-01", want: "2024-05-01T00:00:00Z"},
+		{in: "2024-05-01T12:00:00Z", want: "2024-05-01T12:00:00Z"},
+		{in: "today", want: "2024-05-10T00:00:00Z"},
+		{in: "Yesterday", want: "2024-05-09T00:00:00Z"},
+		{in: "now", want: "2024-05-10T15:30:00Z"},
+		{in: "7d", want: "2024-05-03T15:30:00Z"},
+		{in: "2w", want: "2024-04-26T15:30:00Z"},
+		{in: "12h", want: "2024-05-10T03:30:00Z"},
+		{in: "05/01/2024", wantErr: true},
+		{in: "last week", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseDateSpec(tt.in, now)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseDateSpec(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if err == nil && got.Format(time.RFC3339) != tt.want {
+			t.Errorf("parseDateSpec(%q) = %s, want %s", tt.in, got.Format(time.RFC3339), tt.want)
+		}
+	}
+}
+
+func TestResolveDateRange(t *testing.T) {
+	now := time.Date(2024, 5, 10, 15, 30, 0, 0, time.UTC)
+	from, to, err := resolveDateRange("7d", "yesterday", now)
+	if err != nil || from != "2024-05-03" || to != "2024-05-09" {
+		t.Errorf("resolveDateRange = %q, %q, %v", from, to, err)
+	}
+	if _, _, err := resolveDateRange("today", "7d", now); err == nil {
+		t.Error("expected error for a reversed range")
+	}
+	if from, to, err := resolveDateRange("", "", now); err != nil || from != "" || to != "" {
+		t.Errorf("empty range = %q, %q, %v", from, to, err)
+	}
+}
@@ -232,11 +232,7 @@ func runDebugAuth(_ *cobra.Command, args []string) error {
 		fmt.Printf("⚠️  API key seems too short (< 10 characters)\n")
 	}
 
-	baseURL := viper.GetString("api_base_url")
-	if baseURL == "" {
-		baseURL = "https://api.forwardemail.net"
-	}
-	fmt.Printf("📡 API Base URL: %s\n", baseURL)
+	fmt.Printf("📡 API Base URL: %s\n", client.ResolveBaseURL(cfg, profile))
 
 	return nil
 }
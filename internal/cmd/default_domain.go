@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// withDefaultDomain returns domain, or the active profile's default_domain
+// when domain is empty. Using the default is reported on stderr, so it is
+// always clear which domain a command acted on.
+func withDefaultDomain(cmd *cobra.Command, domain string) string {
+	if domain != "" {
+		return domain
+	}
+	p, profile := activeProfile()
+	if p.DefaultDomain == "" {
+		return ""
+	}
+	cmd.PrintErrf("Using default domain %s (profile %s)\n", p.DefaultDomain, profile)
+	return p.DefaultDomain
+}
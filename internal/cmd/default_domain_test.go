@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+)
+
+func TestDefaultDomain(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	if err := os.MkdirAll(filepath.Join(dir, "forwardemail"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	cfg := "current_profile: default\nprofiles:\n  default:\n    default_domain: example.com\n"
+	if err := os.WriteFile(filepath.Join(dir, "forwardemail", "config.yaml"), []byte(cfg), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    aliases:
+      - name: info
+        recipients: [me@example.org]
+  - name: other.org
+    aliases:
+      - name: sales
+        recipients: [team@example.org]
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	commands := []*cobra.Command{aliasListCmd, aliasGetCmd, aliasCreateCmd}
+	t.Cleanup(func() {
+		for _, c := range commands {
+			resetCommandFlags(c)
+		}
+		resetAliasFlags()
+	})
+
+	run := func(args ...string) (string, string, error) {
+		t.Helper()
+		for _, c := range commands {
+			resetCommandFlags(c)
+		}
+		var stdout, stderr bytes.Buffer
+		rootCmd.SetOut(&stdout)
+		rootCmd.SetErr(&stderr)
+		rootCmd.SetArgs(append(args, "-o", "json"))
+		err := rootCmd.Execute()
+		return stdout.String(), stderr.String(), err
+	}
+
+	stdout, stderr, err := run("alias", "list")
+	if err != nil {
+		t.Fatalf("alias list: %v", err)
+	}
+	if !strings.Contains(stdout, `"info"`) || !strings.Contains(stderr, "Using default domain example.com (profile default)") {
+		t.Errorf("expected the default domain to be listed and named, got:\n%s\n%s", stdout, stderr)
+	}
+
+	if stdout, _, err = run("alias", "get", "info"); err != nil || !strings.Contains(stdout, "me@example.org") {
+		t.Errorf("expected alias get to use the default domain, got %v:\n%s", err, stdout)
+	}
+	if _, _, err = run("alias", "create", "support", "--recipients", "help@example.org"); err != nil {
+		t.Errorf("expected alias create to use the default domain, got %v", err)
+	}
+	if stdout, _, err = run("alias", "get", "example.com", "support"); err != nil || !strings.Contains(stdout, "help@example.org") {
+		t.Errorf("expected the alias in the default domain, got %v:\n%s", err, stdout)
+	}
+
+	stdout, stderr, err = run("alias", "list", "other.org")
+	if err != nil || !strings.Contains(stdout, `"sales"`) || strings.Contains(stderr, "default domain") {
+		t.Errorf("expected a given domain to win without mentioning the default, got %v:\n%s\n%s", err, stdout, stderr)
+	}
+}
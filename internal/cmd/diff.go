@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+// toJSONMap round-trips v through JSON so values compare the way the API sees them.
+func toJSONMap(v any) (map[string]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	m := map[string]any{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// diffUpdate compares an update request with the current state of the resource
+// and returns the fields whose values would change, sorted by field name.
+// Only fields present in the request's JSON are considered.
+func diffUpdate(current, req any) ([]output.FieldChange, error) {
+	cur, err := toJSONMap(current)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode current state: %v", err)
+	}
+	patch, err := toJSONMap(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode update: %v", err)
+	}
+	changes := diffMaps("", cur, patch)
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes, nil
+}
+
+func diffMaps(prefix string, cur, patch map[string]any) []output.FieldChange {
+	var changes []output.FieldChange
+	for key, newVal := range patch {
+		oldVal := cur[key]
+		if nested, ok := newVal.(map[string]any); ok {
+			oldNested, _ := oldVal.(map[string]any)
+			changes = append(changes, diffMaps(prefix+key+".", oldNested, nested)...)
+			continue
+		}
+		if !reflect.DeepEqual(oldVal, newVal) {
+			changes = append(changes, output.FieldChange{Field: prefix + key, Old: oldVal, New: newVal})
+		}
+	}
+	return changes
+}
+
+// printFieldChanges writes the planned changes to w as "field: old → new" lines.
+func printFieldChanges(w io.Writer, title string, changes []output.FieldChange) {
+	if len(changes) == 0 {
+		_, _ = fmt.Fprintf(w, "%s: no changes\n", title)
+		return
+	}
+	_, _ = fmt.Fprintf(w, "%s:\n", title)
+	for _, c := range changes {
+		_, _ = fmt.Fprintf(w, "  %s: %s → %s\n", c.Field, output.FormatChangeValue(c.Old), output.FormatChangeValue(c.New))
+	}
+}
+
+// showUpdateDiff reports the changes an update would make. With dryRun the
+// changes are the command's result: they are printed to stdout in the
+// requested output format and stop=true is returned. Otherwise they are a
+// status message on stderr, shown only for human-readable formats.
+func showUpdateDiff(cmd *cobra.Command, title string, changes []output.FieldChange, dryRun bool) (bool, error) {
+	format, err := output.ParseFormat(viper.GetString("output"))
+	if err != nil {
+		return false, fmt.Errorf("invalid output format: %v", err)
+	}
+
+	if !dryRun {
+		if format.Tabular() && format != output.FormatCSV {
+			printFieldChanges(cmd.ErrOrStderr(), title, changes)
+		}
+		return false, nil
+	}
+
+	formatter := output.NewFormatter(format, cmd.OutOrStdout())
+	switch format {
+	case output.FormatJSON, output.FormatYAML:
+		if changes == nil {
+			changes = []output.FieldChange{}
+		}
+		return true, formatter.Format(changes)
+	case output.FormatCSV, output.FormatMarkdown:
+		tableData, err := output.FormatFieldChanges(changes, format)
+		if err != nil {
+			return true, err
+		}
+		return true, formatter.Format(tableData)
+	default:
+		printFieldChanges(cmd.OutOrStdout(), "DRY RUN: "+title, changes)
+		return true, nil
+	}
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/auth"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+func TestDiffUpdate(t *testing.T) {
+	current := &api.Domain{
+		Name:          "example.com",
+		RetentionDays: 30,
+		HasCatchall:   true,
+		Allowlist:     []string{"a.com"},
+		Settings:      &api.DomainSettings{SMTPPort: 25, HasVirusProtection: true},
+	}
+	retention, catchall := 90, true
+	settings := *current.Settings
+	settings.HasVirusProtection = false
+	req := &api.UpdateDomainRequest{
+		RetentionDays: &retention,
+		HasCatchall:   &catchall, // unchanged
+		Allowlist:     []string{"a.com", "b.com"},
+		Settings:      &settings,
+	}
+
+	changes, err := diffUpdate(current, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"allowlist: a.com → a.com, b.com",
+		"retention_days: 30 → 90",
+		"settings.has_virus_protection: true → false",
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("got %d changes, want %d: %+v", len(changes), len(want), changes)
+	}
+	for i, c := range changes {
+		if got := c.Field + ": " + output.FormatChangeValue(c.Old) + " → " + output.FormatChangeValue(c.New); got != want[i] {
+			t.Errorf("change %d = %q, want %q", i, got, want[i])
+		}
+	}
+}
+
+func TestUpdateDryRun(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    retention_days: 30
+    settings:
+      smtp_port: 2525
+      has_phishing_protection: true
+    aliases:
+      - name: sales
+        recipients: [a@example.org]
+        is_enabled: true
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetAliasFlags()
+		resetDomainUpdateFlags()
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	})
+
+	run := func(args ...string) string {
+		t.Helper()
+		var buf bytes.Buffer
+		rootCmd.SetOut(&buf)
+		rootCmd.SetErr(&buf)
+		rootCmd.SetArgs(args)
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("%v: %v\n%s", args, err, buf.String())
+		}
+		return buf.String()
+	}
+
+	out := run("domain", "update", "example.com", "--retention-days", "90", "--virus-protection", "--dry-run", "-o", "table")
+	for _, want := range []string{"DRY RUN", "retention_days: 30 → 90", "settings.has_virus_protection: false → true"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+	// Untouched settings are kept rather than reset
+	if strings.Contains(out, "smtp_port") || strings.Contains(out, "has_phishing_protection") {
+		t.Errorf("unchanged settings should not be in the diff:\n%s", out)
+	}
+
+	c, err := client.NewAPIClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d, _ := c.Domains.GetDomain(context.Background(), "example.com"); d.RetentionDays != 30 {
+		t.Errorf("dry run must not update the domain, retention is %d", d.RetentionDays)
+	}
+
+	var changes []output.FieldChange
+	out = run("alias", "update", "example.com", "sales", "--recipients", "b@example.org", "--dry-run", "-o", "json")
+	if err := json.Unmarshal([]byte(out), &changes); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(changes) != 1 || changes[0].Field != "recipients" {
+		t.Errorf("unexpected alias changes: %+v", changes)
+	}
+	if a, _ := c.Aliases.GetAlias(context.Background(), "example.com", "sales"); a.Recipients[0] != "a@example.org" {
+		t.Errorf("dry run must not update the alias: %v", a.Recipients)
+	}
+}
@@ -1,19 +1,26 @@
 package cmd
 
 import (
-	"bufio"
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
 	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/clipboard"
 	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/i18n"
 	"github.com/ginsys/forward-email/pkg/output"
+	"github.com/ginsys/forward-email/pkg/schema"
+	"github.com/ginsys/forward-email/pkg/units"
 )
 
 // Global variables for domain command flags.
@@ -30,36 +37,71 @@ var (
 
 // domainCmd represents the domain command
 var domainCmd = &cobra.Command{
-	Use:   "domain",
-	Short: "Manage Forward Email domains",
+	Use:     "domain",
+	Aliases: []string{"d"},
+	Short:   "Manage Forward Email domains",
 	Long: `Manage Forward Email domains including creating, listing, updating, 
 and configuring domain settings and DNS records.`,
 }
 
 // domainListCmd represents the domain list command
 var domainListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List domains",
-	Long:  `List all domains associated with your Forward Email account.`,
-	RunE:  runDomainList,
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List domains",
+	Long: `List all domains associated with your Forward Email account.
+
+With --with-verification each domain's DNS status is added, with the age of
+the check. Checks are kept as snapshots in the cache directory and reused for
+--verification-ttl (default 15m), so that dashboards refreshing the list do
+not call the verification endpoint on every run; --refresh checks every
+listed domain again. 'domain verify' and 'domain verify-status' update the
+snapshots too.`,
+	Example: `  forward-email domain list --with-verification
+  forward-email domain list --with-verification --verification-ttl 1h -o json
+  forward-email domain list --with-verification --refresh`,
+	RunE: runDomainList,
 }
 
 // domainGetCmd represents the domain get command
 var domainGetCmd = &cobra.Command{
-	Use:   "get <domain-name-or-id>",
+	Use:   "get <domain-name-or-id>...",
 	Short: "Get domain details",
-	Long:  `Get detailed information about a specific domain.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runDomainGet,
+	Long: `Get detailed information about a specific domain.
+
+Given several domains, as arguments or in a file with --file (one per line,
+# starts a comment), they are fetched concurrently and printed together: one
+table row per domain, or a JSON or YAML array in the order given. Domains
+that cannot be fetched are reported after the output; with --fail-on-partial
+the command then exits with status 3.`,
+	Example: `  forward-email domain get example.com
+  forward-email domain get a.com b.com c.com -o json
+  forward-email domain get --file domains.txt --concurrency 8`,
+	SilenceUsage: true,
+	RunE:         runDomainGet,
 }
 
 // domainCreateCmd represents the domain create command
 var domainCreateCmd = &cobra.Command{
 	Use:   "create <domain-name>",
 	Short: "Create a new domain",
-	Long:  `Create a new domain in your Forward Email account.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runDomainCreate,
+	Long: `Create a new domain in your Forward Email account.
+
+The active profile's domain_defaults (plan, protections, webhook, retention and
+any other 'domain update -f' field) are applied right after creation. Use
+--no-defaults to create the domain with the service defaults instead.
+
+With --verify the command then prints the DNS records to add and waits until
+the domain is verified, like 'domain verify --wait', taking a domain from
+nothing to verified in one step.
+
+With --dry-run the command shows the domain and defaults it would send, and
+creates nothing.`,
+	Example: `  forward-email domain create example.com
+  forward-email domain create example.com --dry-run
+  forward-email domain create example.com --verify --wait-timeout 2h`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDomainCreate,
 }
 
 // domainUpdateCmd represents the domain update command
@@ -73,36 +115,110 @@ Note: Some fields shown in 'domain get' are read-only and cannot be updated:
   - DKIM settings (managed automatically by Forward Email)
   - return_path (configured automatically)
   - created_at, updated_at (system timestamps)
-  - id, name (immutable identifiers)`,
+  - id, name (immutable identifiers)
+
+Use -f to apply a partial spec from a YAML or JSON file (or - for stdin) using the
+field names of 'domain get -o json'. Flags given alongside override the file.`,
+	Example: `  forward-email domain update example.com --retention-days 90
+  forward-email domain update example.com -f patch.yaml --dry-run`,
 	Args: cobra.ExactArgs(1),
 	RunE: runDomainUpdate,
 }
 
 // domainDeleteCmd represents the domain delete command
 var domainDeleteCmd = &cobra.Command{
-	Use:   "delete <domain-name-or-id>",
-	Short: "Delete a domain",
-	Long:  `Delete a domain from your Forward Email account.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runDomainDelete,
+	Use:     "delete <domain-name-or-id>",
+	Aliases: []string{"rm"},
+	Short:   "Delete a domain",
+	Long: `Delete a domain from your Forward Email account, along with its aliases.
+
+The command first says how many aliases the domain has. A verified domain
+with enabled aliases still routes mail, so it is only deleted with --cascade,
+which saves the aliases to a backup file that 'alias import' can restore,
+deletes them, and then deletes the domain. --backup chooses the file; by
+default it goes to the backups directory of the config directory.
+
+With --dry-run the command lists the aliases that would be deleted with the
+domain, and deletes nothing.`,
+	Example: `  forward-email domain delete example.com --dry-run
+  forward-email domain delete example.com --force
+  forward-email domain delete example.com --cascade --backup example.com-aliases.yaml`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDomainDelete,
 }
 
 // domainVerifyCmd represents the domain verify command
 var domainVerifyCmd = &cobra.Command{
 	Use:   "verify <domain-name-or-id>",
 	Short: "Verify domain DNS configuration",
-	Long:  `Verify that the DNS records for a domain are correctly configured.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runDomainVerify,
+	Long: `Verify that the DNS records for a domain are correctly configured.
+
+With --wait the check is repeated until the domain is verified or
+--wait-timeout (default 30m) passes, 10s after the first check and twice as
+long after each further one, up to 5m apart.
+
+With --nameserver the records are first looked up at the given nameserver,
+typically the zone's authoritative server, which answers from the zone itself
+rather than from a resolver cache. A missing or wrong required record is
+reported right away, without asking Forward Email to verify.`,
+	Example: `  forward-email domain verify example.com
+  forward-email domain verify example.com --wait --wait-timeout 2h
+  forward-email domain verify example.com --nameserver ns1.myhost.com --wait`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDomainVerify,
+}
+
+// domainVerifyStatusCmd represents the domain verify-status command
+var domainVerifyStatusCmd = &cobra.Command{
+	Use:   "verify-status [domain-name-or-id...]",
+	Short: "Report DNS verification health for dashboards",
+	Long: `Re-check DNS verification for one or more domains and print a compact health
+summary per domain: {domain, verified, missing_records, last_checked}.
+
+Use -o json or -o yaml for a machine-readable document, and --badge-dir to write a
+shields.io-style SVG badge (<domain>.svg) per domain for embedding in wikis.
+
+Examples:
+  forward-email domain verify-status example.com
+  forward-email domain verify-status --all-domains -o json
+  forward-email domain verify-status --all-domains --badge-dir ./badges`,
+	RunE: runDomainVerifyStatus,
 }
 
 // domainDNSCmd represents the domain dns command
 var domainDNSCmd = &cobra.Command{
 	Use:   "dns <domain-name-or-id>",
 	Short: "Get required DNS records for a domain",
-	Long:  `Get the required DNS records that need to be configured for a domain to work with Forward Email.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runDomainDNS,
+	Long: `Get the required DNS records that need to be configured for a domain to work with Forward Email.
+
+Each record has a key: mx1, mx2, verification, spf, dmarc and, when present,
+dkim. --copy puts the value of one record on the clipboard, so long values need
+not be copied out of a wrapped table cell. --format env prints the records as
+DNS_<KEY>=value assignments and --format markdown as a table with full values,
+for pasting into tickets or documentation.`,
+	Example: `  forward-email domain dns example.com --copy verification
+  forward-email domain dns example.com --format env > dns.env
+  forward-email domain dns example.com --format markdown`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDomainDNS,
+}
+
+// domainCloneCmd represents the domain clone command
+var domainCloneCmd = &cobra.Command{
+	Use:   "clone <source-domain> <target-domain>",
+	Short: "Create a domain with the settings of an existing one",
+	Long: `Create the target domain and copy settings from the source domain.
+
+Copied fields: protection, ports, webhook, bounce-webhook, allowlist, denylist,
+retention, catchall, regex, delivery-logs, recipient-verification,
+max-recipients, max-quota. Use --skip to leave fields at their defaults and
+--aliases to also copy the source domain's aliases.
+
+Examples:
+  forward-email domain clone brand-a.com brand-b.com
+  forward-email domain clone brand-a.com brand-b.com --aliases --skip webhook,denylist`,
+	Args: cobra.ExactArgs(2),
+	RunE: runDomainClone,
 }
 
 // domainMembersCmd represents the domain members command group
@@ -114,11 +230,12 @@ var domainMembersCmd = &cobra.Command{
 
 // domainMembersListCmd represents the domain members list command
 var domainMembersListCmd = &cobra.Command{
-	Use:   "list <domain-name-or-id>",
-	Short: "List domain members",
-	Long:  `List all members of a domain.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runDomainMembersList,
+	Use:     "list <domain-name-or-id>",
+	Aliases: []string{"ls"},
+	Short:   "List domain members",
+	Long:    `List all members of a domain.`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runDomainMembersList,
 }
 
 // domainMembersAddCmd represents the domain members add command
@@ -132,11 +249,12 @@ var domainMembersAddCmd = &cobra.Command{
 
 // domainMembersRemoveCmd represents the domain members remove command
 var domainMembersRemoveCmd = &cobra.Command{
-	Use:   "remove <domain-name-or-id> <member-id>",
-	Short: "Remove domain member",
-	Long:  `Remove a member from a domain.`,
-	Args:  cobra.ExactArgs(2),
-	RunE:  runDomainMembersRemove,
+	Use:     "remove <domain-name-or-id> <member-id>",
+	Aliases: []string{"rm"},
+	Short:   "Remove domain member",
+	Long:    `Remove a member from a domain.`,
+	Args:    cobra.ExactArgs(2),
+	RunE:    runDomainMembersRemove,
 }
 
 func init() {
@@ -149,7 +267,9 @@ func init() {
 	domainCmd.AddCommand(domainUpdateCmd)
 	domainCmd.AddCommand(domainDeleteCmd)
 	domainCmd.AddCommand(domainVerifyCmd)
+	domainCmd.AddCommand(domainVerifyStatusCmd)
 	domainCmd.AddCommand(domainDNSCmd)
+	domainCmd.AddCommand(domainCloneCmd)
 	domainCmd.AddCommand(domainMembersCmd)
 
 	// Add members subcommands
@@ -163,7 +283,7 @@ func init() {
 	domainListCmd.Flags().IntVar(&domainPage, "page", 1, "Page number")
 	domainListCmd.Flags().IntVar(&domainLimit, "limit", 25, "Number of results per page")
 	domainListCmd.Flags().StringVar(&domainSort, "sort", "name",
-		"Sort field (name, created_at, updated_at, is_verified, plan)")
+		"Sort field ("+strings.Join(domainSortFields, ", ")+")")
 	domainListCmd.Flags().StringVar(&domainOrder, "order", "asc", "Sort order (asc, desc)")
 	domainListCmd.Flags().StringVar(&domainSearch, "search", "", "Search domains by name")
 	domainListCmd.Flags().StringVar(&domainVerified, "verified", "", "Filter by verification status (true, false)")
@@ -171,10 +291,11 @@ func init() {
 
 	// Create command flags
 	domainCreateCmd.Flags().String("plan", "", "Domain plan (free, enhanced_protection, team)")
+	domainCreateCmd.Flags().Bool("no-defaults", false, "Skip the profile's domain_defaults")
 
 	// Update command flags
 	domainUpdateCmd.Flags().Int("max-forwarded-addresses", 0, "Maximum forwarded addresses")
-	domainUpdateCmd.Flags().Int("retention-days", 0, "Email retention period in days")
+	domainUpdateCmd.Flags().String("retention-days", "", "Email retention period in days (e.g. 90 or 90d, 12w)")
 	domainUpdateCmd.Flags().Bool("adult-content-protection", false, "Enable adult content protection")
 	domainUpdateCmd.Flags().Bool("phishing-protection", false, "Enable phishing protection")
 	domainUpdateCmd.Flags().Bool("executable-protection", false, "Enable executable protection")
@@ -191,31 +312,94 @@ func init() {
 	domainUpdateCmd.Flags().Bool("catchall", false, "Enable catch-all aliases")
 	domainUpdateCmd.Flags().Bool("disable-catchall-regex", false, "Disable catch-all regex on large domains")
 	domainUpdateCmd.Flags().Int("max-recipients", 0, "Max recipients per alias (0-1000)")
-	domainUpdateCmd.Flags().Int64("max-quota", 0, "Max storage quota per alias in bytes")
+	domainUpdateCmd.Flags().String("max-quota", "", "Max storage quota per alias, in bytes or with a unit (e.g. 10GB, 500MB)")
 	domainUpdateCmd.Flags().String("allowlist", "", "Comma-separated list of allowed forwarding destinations")
 	domainUpdateCmd.Flags().String("denylist", "", "Comma-separated list of blocked addresses")
+	domainUpdateCmd.Flags().String("restricted-alias-names", "",
+		"Comma-separated alias names only admins may create")
 	domainUpdateCmd.Flags().Bool("recipient-verification", false, "Enable recipient verification emails")
 	domainUpdateCmd.Flags().Bool("ignore-mx-check", false, "Bypass MX record validation")
+	domainUpdateCmd.Flags().Bool("dry-run", false, "Show the changes without applying them")
+	domainUpdateCmd.Flags().StringP("file", "f", "", "Apply a partial domain spec from a YAML/JSON file (- for stdin)")
+	for _, t := range []struct{ name, what string }{
+		{"adult-content-protection", "adult content protection"},
+		{"phishing-protection", "phishing protection"},
+		{"executable-protection", "executable protection"},
+		{"virus-protection", "virus protection"},
+		{"catchall", "catch-all aliases"},
+		{"regex", "regex alias support"},
+		{"catchall-regex", "catch-all regex on large domains"},
+	} {
+		addToggleFlags(domainUpdateCmd, t.name, t.what)
+	}
+	// Accept the API field names as flag names too, e.g. --max-quota-per-alias
+	domainUpdateCmd.Flags().SetNormalizeFunc(func(_ *pflag.FlagSet, name string) pflag.NormalizedName {
+		if short, ok := domainUpdateFlagAliases[name]; ok {
+			name = short
+		}
+		return pflag.NormalizedName(name)
+	})
 
 	// Delete command flags
 	domainDeleteCmd.Flags().BoolP("force", "f", false, "Force deletion without confirmation")
 
+	// DNS command flags
+	domainDNSCmd.Flags().String("copy", "", "Copy the value of one record to the clipboard (mx1, mx2, verification, spf, dmarc, dkim)")
+	domainDNSCmd.Flags().String("format", "", "Print the records as env or markdown instead of --output")
+
+	// Verify-status command flags
+	domainVerifyStatusCmd.Flags().Bool("all-domains", false, "Check every domain in the account")
+	domainVerifyStatusCmd.Flags().String("badge-dir", "", "Write an SVG status badge per domain to this directory")
+
+	// Clone command flags
+	domainCloneCmd.Flags().String("plan", "", "Plan for the new domain (defaults to the source plan)")
+	domainCloneCmd.Flags().Bool("aliases", false, "Also copy aliases from the source domain")
+	domainCloneCmd.Flags().StringSlice("skip", nil, "Settings to leave uncopied (comma-separated field names)")
+
 	// Members add command flags
 	domainMembersAddCmd.Flags().String("group", "user", "Member group (admin, user)")
+
+	// Members remove command flags
+	domainMembersRemoveCmd.Flags().BoolP("force", "f", false, "Remove without confirmation")
 }
 
 // runDomainList implements the 'domain list' command.
 // It retrieves domains from the API with filtering and pagination options,
 // formats the output according to the user's preference (table/JSON/YAML/CSV),
 // and displays pagination information for non-structured formats.
-func runDomainList(_ *cobra.Command, _ []string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	apiClient, err := client.NewAPIClient()
+func runDomainList(cmd *cobra.Command, _ []string) error {
+	if err := validateSortField(domainSort, domainSortFields); err != nil {
+		return err
+	}
+	allProfiles, err := allProfilesSet(cmd)
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	outputFormat, err := output.ParseFormat(viper.GetString("output"))
+	if err != nil {
+		return fmt.Errorf("invalid output format: %w", err)
+	}
+	envelope, err := envelopeSet(cmd, outputFormat)
 	if err != nil {
 		return err
 	}
+	if envelope && allProfiles {
+		return fmt.Errorf("cannot use --envelope with --all-profiles")
+	}
+	if err := checkVerificationFlags(cmd, allProfiles, envelope); err != nil {
+		return err
+	}
+	if len(domainListTags) > 0 && allProfiles {
+		return fmt.Errorf("cannot use --tag with --all-profiles: tags belong to a profile")
+	}
+	cursor := listCursor(cmd)
+	if cursor != "" && (allProfiles || len(domainListTags) > 0) {
+		return fmt.Errorf("cannot use --cursor with --all-profiles or --tag")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
 
 	// Parse verified filter
 	var verified *bool
@@ -235,22 +419,75 @@ func runDomainList(_ *cobra.Command, _ []string) error {
 		Search:   domainSearch,
 		Verified: verified,
 		Plan:     domainPlan,
+		Cursor:   cursor,
+	}
+
+	// Tags are client-side, so filtering by them needs every domain at once.
+	if len(domainListTags) > 0 {
+		opts.Page, opts.Limit = 1, 1000
+	}
+
+	if allProfiles {
+		results, fanErr := fanOutProfiles(ctx, cmd, func(ctx context.Context, c *api.Client) ([]api.Domain, error) {
+			response, err := c.Domains.ListDomains(ctx, opts)
+			if err != nil {
+				return nil, err
+			}
+			return response.Domains, nil
+		})
+		if err := formatAccountResults(cmd, results, output.FormatDomainList); err != nil {
+			return err
+		}
+		return fanErr
+	}
+
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		return err
 	}
 
 	response, err := apiClient.Domains.ListDomains(ctx, opts)
 	if err != nil {
 		return fmt.Errorf("failed to list domains: %w", err)
 	}
+	profile, _ := activeProfile()
+	if len(domainListTags) > 0 {
+		tagged := []api.Domain{}
+		for _, d := range response.Domains {
+			if hasTags(profile.TagsFor(d.Name), domainListTags) {
+				tagged = append(tagged, d)
+			}
+		}
+		response.Domains = tagged
+		response.Pagination = *listPagination(1, len(tagged), len(tagged), 1)
+	}
 
-	// Handle output formatting
-	outputFormat, err := output.ParseFormat(viper.GetString("output"))
-	if err != nil {
-		return fmt.Errorf("invalid output format: %w", err)
+	if envelope {
+		return writeEnvelope(cmd, start, response.Domains, &response.Pagination, nil)
+	}
+
+	var verifications []domainVerificationStatus
+	if domainListWithVerification {
+		names := make([]string, len(response.Domains))
+		for i := range response.Domains {
+			names[i] = response.Domains[i].Name
+		}
+		verifyCtx, verifyCancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer verifyCancel()
+		verifications = domainVerifications(verifyCtx, cmd, apiClient.Domains, names,
+			time.Duration(domainListVerificationTTL), domainListRefresh)
 	}
 
 	formatter := output.NewFormatter(outputFormat, nil)
 
 	if outputFormat == output.FormatJSON || outputFormat == output.FormatYAML {
+		if domainListWithVerification {
+			entries := make([]domainListEntry, len(response.Domains))
+			for i := range response.Domains {
+				entries[i] = domainListEntry{Domain: response.Domains[i], Verification: verifications[i]}
+			}
+			return formatter.Format(entries)
+		}
 		return formatter.Format(response.Domains)
 	}
 
@@ -259,6 +496,10 @@ func runDomainList(_ *cobra.Command, _ []string) error {
 	if err != nil {
 		return err
 	}
+	addDomainTagColumn(tableData, response.Domains, &profile)
+	if domainListWithVerification {
+		addDomainVerificationColumns(tableData, verifications)
+	}
 
 	err = formatter.Format(tableData)
 	if err != nil {
@@ -267,13 +508,11 @@ func runDomainList(_ *cobra.Command, _ []string) error {
 
 	// Show pagination info for non-JSON/YAML formats
 	if len(response.Domains) > 0 {
-		fmt.Printf("\nShowing %d of %d domains (page %d of %d)\n",
+		cmd.PrintErrf("\nShowing %d of %d domains (page %d of %d)\n",
 			len(response.Domains), response.Pagination.Total, response.Pagination.Page, response.Pagination.TotalPages)
-		if response.Pagination.HasNext {
-			fmt.Printf("Use --page %d to see more results\n", response.Pagination.Page+1)
-		}
+		printNextPageHint(cmd, &response.Pagination)
 	} else {
-		fmt.Println("No domains found")
+		cmd.PrintErrln("No domains found")
 	}
 
 	return nil
@@ -310,15 +549,23 @@ func domainOperationRunner[T any](
 // runDomainGet implements the 'domain get' command.
 // It retrieves detailed information for a specific domain by ID or name
 // using the generic domainOperationRunner helper for consistent error handling and output formatting.
-func runDomainGet(_ *cobra.Command, args []string) error {
+// Several domains are fetched with runDomainGetMany.
+func runDomainGet(cmd *cobra.Command, args []string) error {
+	names, err := domainGetNames(cmd, args)
+	if err != nil {
+		return err
+	}
+	if len(names) > 1 {
+		return runDomainGetMany(cmd, names)
+	}
 	return domainOperationRunner(
-		args,
+		names,
 		func(ctx context.Context, domains *api.DomainService, domainID string) (*api.Domain, error) {
 			return domains.GetDomain(ctx, domainID)
 		},
 		"failed to get domain",
 		func(domain *api.Domain, format output.Format) (interface{}, error) {
-			if format == output.FormatTable || format == output.FormatCSV {
+			if format.Tabular() {
 				return output.FormatDomainDetails(domain, format)
 			}
 			return domain, nil
@@ -341,20 +588,76 @@ func runDomainCreate(cmd *cobra.Command, args []string) error {
 
 	planFlag, _ := cmd.Flags().GetString("plan")
 
+	var defaults map[string]any
+	var profile string
+	if noDefaults, _ := cmd.Flags().GetBool("no-defaults"); !noDefaults {
+		defaults, profile = profileDomainDefaults()
+	}
+	if !cmd.Flags().Changed("plan") {
+		planFlag = domainDefaultsPlan(defaults)
+	}
+	// Check the defaults before creating anything
+	defaultsUpdate, err := buildDefaultsRequest(defaults, profile, nil)
+	if err != nil {
+		return err
+	}
+
 	req := &api.CreateDomainRequest{
 		Name: args[0],
 		Plan: planFlag,
 	}
+	if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+		return previewDomainCreate(ctx, cmd, apiClient, &domainCreatePreview{Create: req, Defaults: defaultsUpdate, Profile: profile})
+	}
 
 	domain, err := apiClient.Domains.CreateDomain(ctx, req)
 	if err != nil {
 		return fmt.Errorf("failed to create domain: %w", err)
 	}
 
-	cmd.Printf("Domain '%s' created successfully\n", domain.Name)
+	cmd.PrintErrf("Domain '%s' created successfully\n", domain.Name)
+
+	update, err := buildDefaultsRequest(defaults, profile, domain)
+	if err != nil {
+		return err
+	}
+	if update != nil {
+		updated, err := apiClient.Domains.UpdateDomain(ctx, domain.Name, update)
+		if err != nil {
+			return fmt.Errorf("domain created but failed to apply defaults from profile '%s': %w", profile, err)
+		}
+		domain = updated
+		cmd.PrintErrf("Applied domain defaults from profile '%s'\n", profile)
+	}
+	reportMutation(hookResource{Type: "domain", Action: "create", Name: domain.Name, ID: domain.ID, Data: domain})
+
+	if verify, _ := cmd.Flags().GetBool("verify"); verify {
+		records, err := apiClient.Domains.GetDomainDNSRecords(ctx, domain.Name)
+		if err != nil {
+			return fmt.Errorf("domain created but failed to get DNS records: %w", err)
+		}
+		// Full values, one per line: a table would wrap the long ones.
+		cmd.PrintErrf("\nAdd these DNS records for %s, then wait for verification (Ctrl-C to stop):\n", domain.Name)
+		for _, r := range records {
+			value := r.Value
+			if r.Priority > 0 {
+				value = fmt.Sprintf("%d %s", r.Priority, value)
+			}
+			cmd.PrintErrf("  %-5s %-8s %s\n", r.Type, r.Name, value)
+		}
+		cmd.PrintErrln()
+		verified, err := waitForVerification(cmd, apiClient.Domains, domain.Name)
+		if err != nil {
+			return fmt.Errorf("domain created but not verified: %w", err)
+		}
+		return printDomainVerification(cmd, verified)
+	}
 
+	if done, err := printIDOnly(cmd, domain.ID); done {
+		return err
+	}
 	return formatOutput(domain, viper.GetString("output"), func(format output.Format) (interface{}, error) {
-		if format == output.FormatTable || format == output.FormatCSV {
+		if format.Tabular() {
 			return output.FormatDomainDetails(domain, format)
 		}
 		return domain, nil
@@ -374,7 +677,27 @@ func runDomainUpdate(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	current, err := apiClient.Domains.GetDomain(ctx, args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get domain: %w", err)
+	}
+
 	req := &api.UpdateDomainRequest{}
+	if file, _ := cmd.Flags().GetString("file"); file != "" {
+		// Settings are sent as a whole, so a partial settings block is applied on top
+		// of the current values
+		if current.Settings != nil {
+			req.Settings = &api.DomainSettings{}
+			*req.Settings = *current.Settings
+		}
+		doc, err := readPatchFile(cmd, file, schema.DomainPatch, req)
+		if err != nil {
+			return err
+		}
+		if _, ok := doc["settings"]; !ok {
+			req.Settings = nil
+		}
+	}
 
 	// Parse flags and build update request
 	if cmd.Flags().Changed("max-forwarded-addresses") {
@@ -383,33 +706,45 @@ func runDomainUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	if cmd.Flags().Changed("retention-days") {
-		retentionDays, _ := cmd.Flags().GetInt("retention-days")
+		value, _ := cmd.Flags().GetString("retention-days")
+		retentionDays, err := units.ParseDays(value)
+		if err != nil {
+			return fmt.Errorf("invalid --retention-days: %v", err)
+		}
 		req.RetentionDays = &retentionDays
 	}
 
 	// Build settings if any protection flags are set
-	if cmd.Flags().Changed("adult-content-protection") ||
-		cmd.Flags().Changed("phishing-protection") ||
-		cmd.Flags().Changed("executable-protection") ||
-		cmd.Flags().Changed("virus-protection") ||
+	adult := toggleValue(cmd.Flags(), "adult-content-protection")
+	phishing := toggleValue(cmd.Flags(), "phishing-protection")
+	executable := toggleValue(cmd.Flags(), "executable-protection")
+	virus := toggleValue(cmd.Flags(), "virus-protection")
+	if adult != nil || phishing != nil || executable != nil || virus != nil ||
 		cmd.Flags().Changed("smtp-port") ||
 		cmd.Flags().Changed("imap-port") ||
 		cmd.Flags().Changed("caldav-port") ||
 		cmd.Flags().Changed("carddav-port") ||
 		cmd.Flags().Changed("webhook-url") {
+		// Settings are sent as a whole, so start from the current values
 		settings := &api.DomainSettings{}
+		switch {
+		case req.Settings != nil:
+			*settings = *req.Settings
+		case current.Settings != nil:
+			*settings = *current.Settings
+		}
 
-		if cmd.Flags().Changed("adult-content-protection") {
-			settings.HasAdultContentProtection, _ = cmd.Flags().GetBool("adult-content-protection")
+		if adult != nil {
+			settings.HasAdultContentProtection = *adult
 		}
-		if cmd.Flags().Changed("phishing-protection") {
-			settings.HasPhishingProtection, _ = cmd.Flags().GetBool("phishing-protection")
+		if phishing != nil {
+			settings.HasPhishingProtection = *phishing
 		}
-		if cmd.Flags().Changed("executable-protection") {
-			settings.HasExecutableProtection, _ = cmd.Flags().GetBool("executable-protection")
+		if executable != nil {
+			settings.HasExecutableProtection = *executable
 		}
-		if cmd.Flags().Changed("virus-protection") {
-			settings.HasVirusProtection, _ = cmd.Flags().GetBool("virus-protection")
+		if virus != nil {
+			settings.HasVirusProtection = *virus
 		}
 		if cmd.Flags().Changed("smtp-port") {
 			settings.SMTPPort, _ = cmd.Flags().GetInt("smtp-port")
@@ -441,19 +776,17 @@ func runDomainUpdate(cmd *cobra.Command, args []string) error {
 		req.BounceWebhook = &bounceWebhook
 	}
 
-	if cmd.Flags().Changed("regex") {
-		hasRegex, _ := cmd.Flags().GetBool("regex")
-		req.HasRegex = &hasRegex
+	if v := toggleValue(cmd.Flags(), "regex"); v != nil {
+		req.HasRegex = v
 	}
 
-	if cmd.Flags().Changed("catchall") {
-		hasCatchall, _ := cmd.Flags().GetBool("catchall")
-		req.HasCatchall = &hasCatchall
+	if v := toggleValue(cmd.Flags(), "catchall"); v != nil {
+		req.HasCatchall = v
 	}
 
-	if cmd.Flags().Changed("disable-catchall-regex") {
-		disableCatchallRegex, _ := cmd.Flags().GetBool("disable-catchall-regex")
-		req.IsCatchallRegexDisabled = &disableCatchallRegex
+	if v := toggleValue(cmd.Flags(), "catchall-regex"); v != nil {
+		disabled := !*v
+		req.IsCatchallRegexDisabled = &disabled
 	}
 
 	if cmd.Flags().Changed("max-recipients") {
@@ -462,7 +795,11 @@ func runDomainUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	if cmd.Flags().Changed("max-quota") {
-		maxQuota, _ := cmd.Flags().GetInt64("max-quota")
+		value, _ := cmd.Flags().GetString("max-quota")
+		maxQuota, err := units.ParseSize(value)
+		if err != nil {
+			return fmt.Errorf("invalid --max-quota: %v", err)
+		}
 		req.MaxQuotaPerAlias = &maxQuota
 	}
 
@@ -488,6 +825,11 @@ func runDomainUpdate(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if cmd.Flags().Changed("restricted-alias-names") {
+		names, _ := cmd.Flags().GetString("restricted-alias-names")
+		req.RestrictedAliasNames = splitCSVList(names)
+	}
+
 	if cmd.Flags().Changed("recipient-verification") {
 		recipientVerification, _ := cmd.Flags().GetBool("recipient-verification")
 		req.HasRecipientVerification = &recipientVerification
@@ -498,15 +840,25 @@ func runDomainUpdate(cmd *cobra.Command, args []string) error {
 		req.IgnoreMXCheck = &ignoreMXCheck
 	}
 
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	changes, err := diffUpdate(current, req)
+	if err != nil {
+		return err
+	}
+	if stop, err := showUpdateDiff(cmd, fmt.Sprintf("Changes to domain '%s'", current.Name), changes, dryRun); stop || err != nil {
+		return err
+	}
+
 	domain, err := apiClient.Domains.UpdateDomain(ctx, args[0], req)
 	if err != nil {
 		return fmt.Errorf("failed to update domain: %w", err)
 	}
 
-	cmd.Printf("Domain '%s' updated successfully\n", domain.Name)
+	reportMutation(hookResource{Type: "domain", Action: "update", Name: domain.Name, ID: domain.ID, Data: domain})
+	cmd.PrintErrf("Domain '%s' updated successfully\n", domain.Name)
 
 	return formatOutput(domain, viper.GetString("output"), func(format output.Format) (interface{}, error) {
-		if format == output.FormatTable || format == output.FormatCSV {
+		if format.Tabular() {
 			return output.FormatDomainDetails(domain, format)
 		}
 		return domain, nil
@@ -514,37 +866,52 @@ func runDomainUpdate(cmd *cobra.Command, args []string) error {
 }
 
 func runDomainDelete(cmd *cobra.Command, args []string) error {
-	force, _ := cmd.Flags().GetBool("force")
+	ctx := context.Background()
 
-	if !force {
-		cmd.Printf("Are you sure you want to delete domain '%s'? This action cannot be undone.\n", args[0])
-		cmd.Print("Type 'yes' to confirm: ")
-		reader := bufio.NewReader(cmd.InOrStdin())
-		line, _ := reader.ReadString('\n')
-		confirmation := strings.TrimSpace(line)
-		if !strings.EqualFold(confirmation, "yes") {
-			cmd.Println("Domain deletion canceled")
-			return nil
-		}
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		return err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+	// Look at what goes with the domain before anything is deleted
+	impact, err := loadDomainDeletePreview(ctx, apiClient, args[0])
+	if err != nil {
+		return err
+	}
+	if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+		return previewDomainDelete(cmd, impact)
+	}
+	cascade, _ := cmd.Flags().GetBool("cascade")
+	if err := checkDomainDelete(cmd, impact, cascade); err != nil {
+		return err
+	}
 
-	apiClient, err := client.NewAPIClient()
+	ok, err := confirm(cmd, i18n.T("Are you sure you want to delete domain '%s'? This action cannot be undone.", args[0]))
 	if err != nil {
 		return err
 	}
+	if !ok {
+		cmd.PrintErrln(i18n.T("Domain deletion canceled"))
+		return nil
+	}
+
+	if cascade && impact.Aliases > 0 {
+		backup, _ := cmd.Flags().GetString("backup")
+		if err := cascadeDeleteAliases(ctx, cmd, apiClient, impact, backup); err != nil {
+			return err
+		}
+	}
 
-	if err := apiClient.Domains.DeleteDomain(ctx, args[0]); err != nil {
+	if err := apiClient.Domains.DeleteDomain(ctx, impact.Domain); err != nil {
 		return fmt.Errorf("failed to delete domain: %w", err)
 	}
+	reportMutation(hookResource{Type: "domain", Action: "delete", Name: impact.Domain})
 
-	cmd.Printf("Domain '%s' deleted successfully\n", args[0])
+	cmd.PrintErrf("Domain '%s' deleted successfully\n", args[0])
 	return nil
 }
 
-func runDomainVerify(_ *cobra.Command, args []string) error {
+func runDomainVerify(cmd *cobra.Command, args []string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -553,28 +920,161 @@ func runDomainVerify(_ *cobra.Command, args []string) error {
 		return err
 	}
 
+	nameserver, _ := cmd.Flags().GetString("nameserver")
+	if nameserver != "" {
+		if err := checkAtNameserver(ctx, cmd, apiClient.Domains, args[0], nameserver); err != nil {
+			return err
+		}
+	}
+
 	// VerifyDomain triggers a DNS record check and returns the updated domain
-	domain, err := apiClient.Domains.VerifyDomain(ctx, args[0])
+	var domain *api.Domain
+	if wait, _ := cmd.Flags().GetBool("wait"); wait {
+		domain, err = waitForVerification(cmd, apiClient.Domains, args[0])
+	} else {
+		domain, err = apiClient.Domains.VerifyDomain(ctx, args[0])
+	}
+	if err != nil && nameserver != "" {
+		return fmt.Errorf("failed to verify domain: %w (the records are published at %s; "+
+			"resolvers may still return cached answers, try --wait)", err, nameserver)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to verify domain: %w", err)
 	}
+	recordVerification(output.NewDomainHealth(domain, time.Now()))
+	return printDomainVerification(cmd, domain)
+}
 
+// printDomainVerification prints the record checks of a verified domain on
+// stderr and the domain itself in the selected output format.
+func printDomainVerification(cmd *cobra.Command, domain *api.Domain) error {
 	// Print verification status summary
-	fmt.Println("DNS records verified")
-	fmt.Printf("   MX Record:    %s\n", formatCheckMark(domain.HasMXRecord))
-	fmt.Printf("   TXT Record:   %s\n", formatCheckMark(domain.HasTXTRecord))
-	fmt.Printf("   DKIM Record:  %s\n", formatCheckMark(domain.HasDKIMRecord))
-	fmt.Printf("   DMARC Record: %s\n", formatCheckMark(domain.HasDMARCRecord))
-	fmt.Printf("   SPF Record:   %s\n", formatCheckMark(domain.HasSPFRecord))
+	cmd.PrintErrln("DNS records verified")
+	cmd.PrintErrf("   MX Record:    %s\n", formatCheckMark(domain.HasMXRecord))
+	cmd.PrintErrf("   TXT Record:   %s\n", formatCheckMark(domain.HasTXTRecord))
+	cmd.PrintErrf("   DKIM Record:  %s\n", formatCheckMark(domain.HasDKIMRecord))
+	cmd.PrintErrf("   DMARC Record: %s\n", formatCheckMark(domain.HasDMARCRecord))
+	cmd.PrintErrf("   SPF Record:   %s\n", formatCheckMark(domain.HasSPFRecord))
 
 	return formatOutput(domain, viper.GetString("output"), func(format output.Format) (interface{}, error) {
-		if format == output.FormatTable || format == output.FormatCSV {
+		if format.Tabular() {
 			return output.FormatDomainDetails(domain, format)
 		}
 		return domain, nil
 	})
 }
 
+// runDomainVerifyStatus implements the 'domain verify-status' command.
+// Each domain is re-verified; failures are reported per domain so one broken
+// domain does not hide the status of the others.
+func runDomainVerifyStatus(cmd *cobra.Command, args []string) error {
+	allDomains, _ := cmd.Flags().GetBool("all-domains")
+	badgeDir, _ := cmd.Flags().GetString("badge-dir")
+	if allDomains == (len(args) > 0) {
+		return fmt.Errorf("specify one or more domains or --all-domains")
+	}
+	allProfiles, err := allProfilesSet(cmd)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	var health []output.DomainHealth
+	var results []accountResult[output.DomainHealth]
+	var fanErr error
+	if allProfiles {
+		results, fanErr = fanOutProfiles(ctx, cmd, func(ctx context.Context, c *api.Client) ([]output.DomainHealth, error) {
+			return checkDomainHealth(ctx, c, args, allDomains)
+		})
+		for _, r := range results {
+			health = append(health, r.items...)
+		}
+	} else {
+		apiClient, err := client.NewAPIClient()
+		if err != nil {
+			return err
+		}
+		if health, err = checkDomainHealth(ctx, apiClient, args, allDomains); err != nil {
+			return err
+		}
+		recordVerification(health...)
+	}
+
+	if badgeDir != "" {
+		if err := writeHealthBadges(badgeDir, health); err != nil {
+			return err
+		}
+	}
+
+	if allProfiles {
+		err = formatAccountResults(cmd, results, output.FormatDomainHealth)
+	} else {
+		err = formatOutput(health, viper.GetString("output"), func(format output.Format) (interface{}, error) {
+			return output.FormatDomainHealth(health, format)
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, h := range health {
+		if h.Error != "" {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to check %d of %d domains", failed, len(health))
+	}
+	return fanErr
+}
+
+// checkDomainHealth re-checks DNS for the named domains, or every domain of the
+// account when allDomains is set. Domains that fail to verify are reported in
+// their entry's Error field.
+func checkDomainHealth(ctx context.Context, c *api.Client, names []string, allDomains bool) ([]output.DomainHealth, error) {
+	if allDomains {
+		resp, err := c.Domains.ListDomains(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list domains: %w", err)
+		}
+		names = nil
+		for i := range resp.Domains {
+			names = append(names, resp.Domains[i].Name)
+		}
+	}
+
+	health := make([]output.DomainHealth, 0, len(names))
+	for _, name := range names {
+		domain, err := c.Domains.VerifyDomain(ctx, name)
+		if err != nil {
+			health = append(health, output.DomainHealth{
+				Domain: name, Error: err.Error(), MissingRecords: []string{}, LastChecked: time.Now().UTC(),
+			})
+			continue
+		}
+		health = append(health, output.NewDomainHealth(domain, time.Now()))
+	}
+	return health, nil
+}
+
+// writeHealthBadges writes one <domain>.svg badge per health entry into dir.
+func writeHealthBadges(dir string, health []output.DomainHealth) error {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create badge directory: %w", err)
+	}
+	for _, h := range health {
+		path := filepath.Join(dir, filepath.Base(h.Domain)+".svg")
+		//nolint:gosec // badges are public status images meant to be served to others
+		if err := os.WriteFile(path, []byte(output.DomainHealthBadge(h)), 0o644); err != nil {
+			return fmt.Errorf("failed to write badge: %w", err)
+		}
+	}
+	return nil
+}
+
 func formatCheckMark(ok bool) string {
 	if ok {
 		return "Yes"
@@ -582,20 +1082,211 @@ func formatCheckMark(ok bool) string {
 	return "No"
 }
 
-func runDomainDNS(_ *cobra.Command, args []string) error {
-	return domainOperationRunner(
-		args,
-		func(ctx context.Context, domains *api.DomainService, domainID string) ([]api.DNSRecord, error) {
-			return domains.GetDomainDNSRecords(ctx, domainID)
-		},
-		"failed to get DNS records",
-		func(records []api.DNSRecord, format output.Format) (interface{}, error) {
-			if format == output.FormatTable || format == output.FormatCSV {
-				return output.FormatDNSRecords(records, format)
-			}
-			return records, nil
-		},
-	)
+func runDomainDNS(cmd *cobra.Command, args []string) error {
+	copyKey, _ := cmd.Flags().GetString("copy")
+	dnsFormat, _ := cmd.Flags().GetString("format")
+	switch dnsFormat {
+	case "", "env", "markdown":
+	default:
+		return fmt.Errorf("invalid --format: %s (supported: env, markdown)", dnsFormat)
+	}
+	if copyKey != "" && !clipboardAvailable() {
+		return fmt.Errorf("--copy: %w", clipboard.ErrUnavailable)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		return err
+	}
+	records, err := apiClient.Domains.GetDomainDNSRecords(ctx, args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get DNS records: %w", err)
+	}
+
+	if copyKey != "" {
+		keys := output.DNSRecordKeys(records)
+		i := slices.Index(keys, strings.ToLower(copyKey))
+		if i < 0 {
+			return fmt.Errorf("no %s record for %s%s Available: %s", copyKey, args[0], didYouMean(copyKey, keys), strings.Join(keys, ", "))
+		}
+		if err := copyToClipboard(records[i].Value); err != nil {
+			return fmt.Errorf("failed to copy the %s record to the clipboard: %v", keys[i], err)
+		}
+		cmd.PrintErrf("📋 Copied the %s record to the clipboard\n", keys[i])
+	}
+
+	switch dnsFormat {
+	case "env":
+		return output.WriteDNSRecordsEnv(cmd.OutOrStdout(), records)
+	case "markdown":
+		return output.WriteDNSRecordsMarkdown(cmd.OutOrStdout(), records)
+	}
+	return formatOutput(records, viper.GetString("output"), func(format output.Format) (interface{}, error) {
+		return output.FormatDNSRecords(records, format)
+	})
+}
+
+// domainSortFields are the fields accepted by 'domain list --sort'.
+var domainSortFields = []string{"name", "created_at", "updated_at", "is_verified", "plan"}
+
+// domainUpdateFlagAliases maps API field names to the matching 'domain update' flags.
+var domainUpdateFlagAliases = map[string]string{
+	"max-recipients-per-alias":   "max-recipients",
+	"max-quota-per-alias":        "max-quota",
+	"has-delivery-logs":          "delivery-logs",
+	"has-recipient-verification": "recipient-verification",
+	"retention":                  "retention-days",
+}
+
+// cloneFields lists the settings copied by 'domain clone', in the order they are applied.
+var cloneFields = []string{
+	"protection", "ports", "webhook", "bounce-webhook", "allowlist", "denylist", "retention",
+	"catchall", "regex", "delivery-logs", "recipient-verification", "max-recipients", "max-quota",
+}
+
+// runDomainClone implements the 'domain clone' command.
+// It creates the target domain, copies the selected settings from the source in a single
+// update request and, with --aliases, replays the source aliases through the sync planner.
+func runDomainClone(cmd *cobra.Command, args []string) error {
+	src := strings.TrimSpace(args[0])
+	dst := strings.TrimSpace(args[1])
+	if strings.EqualFold(src, dst) {
+		return fmt.Errorf("source and target domains must differ")
+	}
+
+	skipList, _ := cmd.Flags().GetStringSlice("skip")
+	skip := make(map[string]bool, len(skipList))
+	for _, f := range skipList {
+		f = strings.ToLower(strings.TrimSpace(f))
+		if !slices.Contains(cloneFields, f) {
+			return fmt.Errorf("invalid --skip field: %s%s Valid fields: %s", f, didYouMean(f, cloneFields), strings.Join(cloneFields, ", "))
+		}
+		skip[f] = true
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	source, err := apiClient.Domains.GetDomain(ctx, src)
+	if err != nil {
+		return fmt.Errorf("failed to get source domain: %w", err)
+	}
+
+	plan := source.Plan
+	if cmd.Flags().Changed("plan") {
+		plan, _ = cmd.Flags().GetString("plan")
+	}
+
+	target, err := apiClient.Domains.CreateDomain(ctx, &api.CreateDomainRequest{Name: dst, Plan: plan})
+	if err != nil {
+		return fmt.Errorf("failed to create domain: %w", err)
+	}
+	cmd.PrintErrf("Domain '%s' created successfully\n", target.Name)
+
+	target, err = apiClient.Domains.UpdateDomain(ctx, dst, buildCloneRequest(source, target, skip))
+	if err != nil {
+		return fmt.Errorf("failed to copy settings from %s: %w", src, err)
+	}
+	cmd.PrintErrf("Settings copied from '%s'\n", source.Name)
+	reportMutation(hookResource{Type: "domain", Action: "create", Name: target.Name, ID: target.ID, Data: target})
+
+	if copyAliases, _ := cmd.Flags().GetBool("aliases"); copyAliases {
+		srcAliases, err := listAllAliases(ctx, apiClient, src)
+		if err != nil {
+			return fmt.Errorf("failed to list aliases for %s: %w", src, err)
+		}
+		// The target is brand new, so a preserve sync only ever plans creates.
+		actions, err := planAliasSync(cmd, "preserve", src, dst, srcAliases, nil)
+		if err != nil {
+			return err
+		}
+		prog := newProgress(cmd, "Copying aliases", actions.Len())
+		err = applySyncPlan(ctx, apiClient, actions, nil, prog)
+		prog.Finish()
+		if err != nil {
+			return err
+		}
+		cmd.PrintErrf("Copied %d aliases from '%s'\n", actions.Len(), source.Name)
+	}
+
+	return formatOutput(target, viper.GetString("output"), func(format output.Format) (interface{}, error) {
+		if format.Tabular() {
+			return output.FormatDomainDetails(target, format)
+		}
+		return target, nil
+	})
+}
+
+// buildCloneRequest builds the update applied to a freshly cloned domain. Settings are
+// sent as a whole object, so the target's own values are kept for skipped groups.
+func buildCloneRequest(source, target *api.Domain, skip map[string]bool) *api.UpdateDomainRequest {
+	req := &api.UpdateDomainRequest{}
+
+	if source.Settings != nil && (!skip["protection"] || !skip["ports"] || !skip["webhook"]) {
+		settings := api.DomainSettings{}
+		if target.Settings != nil {
+			settings = *target.Settings
+		}
+		if !skip["protection"] {
+			settings.HasAdultContentProtection = source.Settings.HasAdultContentProtection
+			settings.HasPhishingProtection = source.Settings.HasPhishingProtection
+			settings.HasExecutableProtection = source.Settings.HasExecutableProtection
+			settings.HasVirusProtection = source.Settings.HasVirusProtection
+		}
+		if !skip["ports"] {
+			settings.SMTPPort = source.Settings.SMTPPort
+			settings.IMAPPort = source.Settings.IMAPPort
+			settings.CalDAVPort = source.Settings.CalDAVPort
+			settings.CardDAVPort = source.Settings.CardDAVPort
+		}
+		if !skip["webhook"] {
+			settings.WebhookURL = source.Settings.WebhookURL
+			settings.WebhookKey = source.Settings.WebhookKey
+		}
+		req.Settings = &settings
+	}
+
+	if !skip["bounce-webhook"] && source.BounceWebhook != "" {
+		req.BounceWebhook = &source.BounceWebhook
+	}
+	if !skip["allowlist"] {
+		req.Allowlist = source.Allowlist
+	}
+	if !skip["denylist"] {
+		req.Denylist = source.Denylist
+	}
+	if !skip["retention"] && source.RetentionDays > 0 {
+		req.RetentionDays = &source.RetentionDays
+	}
+	if !skip["catchall"] {
+		req.HasCatchall = &source.HasCatchall
+		req.IsCatchallRegexDisabled = &source.IsCatchallRegexDisabled
+	}
+	if !skip["regex"] {
+		req.HasRegex = &source.HasRegex
+	}
+	if !skip["delivery-logs"] {
+		req.HasDeliveryLogs = &source.HasDeliveryLogs
+	}
+	if !skip["recipient-verification"] {
+		req.HasRecipientVerification = &source.HasRecipientVerification
+	}
+	if !skip["max-recipients"] && source.MaxRecipientsPerAlias > 0 {
+		req.MaxRecipientsPerAlias = &source.MaxRecipientsPerAlias
+	}
+	if !skip["max-quota"] && source.MaxQuotaPerAlias > 0 {
+		req.MaxQuotaPerAlias = &source.MaxQuotaPerAlias
+	}
+
+	return req
 }
 
 func runDomainMembersList(_ *cobra.Command, args []string) error {
@@ -613,7 +1304,7 @@ func runDomainMembersList(_ *cobra.Command, args []string) error {
 	}
 
 	return formatOutput(domain.Members, viper.GetString("output"), func(format output.Format) (interface{}, error) {
-		if format == output.FormatTable || format == output.FormatCSV {
+		if format.Tabular() {
 			return output.FormatDomainMembers(domain.Members, format)
 		}
 		return domain.Members, nil
@@ -641,14 +1332,24 @@ func runDomainMembersAdd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to add domain member: %w", err)
 	}
 
-	fmt.Printf("Member '%s' added to domain '%s' with group '%s'\n", args[1], args[0], group)
+	reportMutation(hookResource{Type: "member", Action: "add", Domain: args[0], Name: args[1], Data: member})
+	cmd.PrintErrf("Member '%s' added to domain '%s' with group '%s'\n", args[1], args[0], group)
 
 	return formatOutput(member, viper.GetString("output"), func(_ output.Format) (interface{}, error) {
 		return member, nil
 	})
 }
 
-func runDomainMembersRemove(_ *cobra.Command, args []string) error {
+func runDomainMembersRemove(cmd *cobra.Command, args []string) error {
+	ok, err := confirm(cmd, i18n.T("Remove member '%s' from domain '%s'?", args[1], args[0]))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		cmd.PrintErrln(i18n.T("Member removal canceled"))
+		return nil
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -661,7 +1362,8 @@ func runDomainMembersRemove(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to remove domain member: %w", err)
 	}
 
-	fmt.Printf("Member '%s' removed from domain '%s'\n", args[1], args[0])
+	reportMutation(hookResource{Type: "member", Action: "remove", Domain: args[0], ID: args[1]})
+	cmd.PrintErrf("Member '%s' removed from domain '%s'\n", args[1], args[0])
 	return nil
 }
 
@@ -675,7 +1377,7 @@ func formatOutput(data interface{}, format string, tableFormatter func(output.Fo
 
 	formatter := output.NewFormatter(outputFormat, nil)
 
-	if outputFormat == output.FormatTable || outputFormat == output.FormatCSV {
+	if outputFormat.Tabular() {
 		tableData, err := tableFormatter(outputFormat)
 		if err != nil {
 			return err
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	yaml "gopkg.in/yaml.v3"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/pkg/audit"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+var (
+	domainAuditBaseline     string
+	domainAuditMinScore     int
+	domainAuditShowBaseline bool
+	domainAuditReportHTML   string
+)
+
+// domainAuditCmd represents the domain audit command
+var domainAuditCmd = &cobra.Command{
+	Use:   "audit <domain>",
+	Short: "Check domain settings against a best-practice baseline",
+	Long: `Check a domain's settings against a baseline and report pass or fail for every
+rule together with an overall score.
+
+The built-in baseline requires all spam protections, DMARC and SPF records, a
+DKIM key of at least 2048 bits, a retention period of 1-30 days and HTTPS
+webhooks. An organisation can keep its own baseline in a YAML file, given with
+--baseline or the profile's audit_baseline setting; print the active baseline
+with --show-baseline to start one.
+
+The command exits non-zero when the score is below --min-score (default 100,
+i.e. any failed rule). --report-html also writes the result as a standalone
+HTML page to share with people who do not use the CLI.
+
+Examples:
+  forward-email domain audit example.com
+  forward-email domain audit example.com --baseline org-baseline.yaml --min-score 80
+  forward-email domain audit example.com --report-html audit.html
+  forward-email domain audit --show-baseline > org-baseline.yaml`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if domainAuditShowBaseline {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	SilenceUsage: true,
+	RunE:         runDomainAudit,
+}
+
+func init() {
+	domainCmd.AddCommand(domainAuditCmd)
+	domainAuditCmd.Flags().StringVar(&domainAuditBaseline, "baseline", "", "Baseline file to check against (default: the profile's audit_baseline, else built in)")
+	domainAuditCmd.Flags().IntVar(&domainAuditMinScore, "min-score", 100, "Exit non-zero when the score is below this percentage")
+	domainAuditCmd.Flags().BoolVar(&domainAuditShowBaseline, "show-baseline", false, "Print the active baseline as YAML and exit")
+	addReportHTMLFlag(domainAuditCmd, &domainAuditReportHTML)
+}
+
+// loadAuditBaseline loads the baseline from --baseline or the active profile's
+// audit_baseline setting, falling back to the built-in baseline.
+func loadAuditBaseline() (*audit.Baseline, error) {
+	path := domainAuditBaseline
+	if path == "" {
+		p, _ := activeProfile()
+		path = p.AuditBaseline
+	}
+	if path == "" {
+		return audit.Default(), nil
+	}
+	return audit.Load(path)
+}
+
+func runDomainAudit(cmd *cobra.Command, args []string) error {
+	if domainAuditMinScore < 0 || domainAuditMinScore > 100 {
+		return fmt.Errorf("--min-score must be between 0 and 100")
+	}
+	baseline, err := loadAuditBaseline()
+	if err != nil {
+		return err
+	}
+	if domainAuditShowBaseline {
+		enc := yaml.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent(2)
+		if err := enc.Encode(baseline); err != nil {
+			return err
+		}
+		return enc.Close()
+	}
+
+	format, err := output.ParseFormat(viper.GetString("output"))
+	if err != nil {
+		return fmt.Errorf("invalid output format: %v", err)
+	}
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %v", err)
+	}
+	domain, err := apiClient.Domains.GetDomain(cmd.Context(), strings.TrimSpace(args[0]))
+	if err != nil {
+		return fmt.Errorf("failed to get domain: %v", err)
+	}
+
+	report := baseline.Evaluate(domain)
+	if format == output.FormatGHA {
+		if err := startGHASummary("Domain audit: " + report.Domain); err != nil {
+			return err
+		}
+	}
+	formatter := output.NewFormatter(format, cmd.OutOrStdout())
+	if format == output.FormatJSON || format == output.FormatYAML {
+		if err := formatter.Format(report); err != nil {
+			return err
+		}
+	} else {
+		tableData, err := output.FormatDomainAudit(report, format)
+		if err != nil {
+			return fmt.Errorf("failed to format output: %v", err)
+		}
+		if err := formatter.Format(tableData); err != nil {
+			return err
+		}
+		cmd.PrintErrf("\nScore: %d%% (%d of %d rules passed)\n", report.Score, report.Passed, report.Total)
+	}
+	if format == output.FormatGHA {
+		note := fmt.Sprintf("Score: **%d%%** (%d of %d rules passed, minimum %d%%)",
+			report.Score, report.Passed, report.Total, domainAuditMinScore)
+		if err := finishGHASummary(cmd, note, auditAnnotations(report, domainAuditMinScore)); err != nil {
+			return err
+		}
+	}
+	if domainAuditReportHTML != "" {
+		page, err := output.NewAuditHTMLReport(report, domainAuditMinScore, time.Now())
+		if err != nil {
+			return err
+		}
+		if err := writeHTMLReportFile(cmd, domainAuditReportHTML, page); err != nil {
+			return err
+		}
+	}
+
+	if report.Score < domainAuditMinScore {
+		return fmt.Errorf("%s scores %d%%, below the minimum of %d%%", report.Domain, report.Score, domainAuditMinScore)
+	}
+	return nil
+}
+
+// auditAnnotations turns failed rules into annotations for --output gha:
+// errors when the score fails the gate, warnings when it still passes.
+func auditAnnotations(report *audit.Report, minScore int) []output.Annotation {
+	level := output.AnnotationWarning
+	if report.Score < minScore {
+		level = output.AnnotationError
+	}
+	var annotations []output.Annotation
+	for _, r := range report.Results {
+		if r.Passed {
+			continue
+		}
+		annotations = append(annotations, output.Annotation{
+			Level: level, Title: report.Domain + ": " + r.Rule, Message: r.Detail,
+		})
+	}
+	return annotations
+}
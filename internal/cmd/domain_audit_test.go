@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+)
+
+func TestDomainAudit(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    has_dmarc_record: true
+    has_spf_record: true
+    has_dkim_record: true
+    dkim_modulus_length: 2048
+    retention_days: 14
+    settings:
+      has_virus_protection: true
+      has_phishing_protection: true
+      has_executable_protection: true
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetCommandFlags(domainAuditCmd)
+		domainAuditBaseline, domainAuditMinScore, domainAuditShowBaseline = "", 100, false
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	})
+
+	run := func(args ...string) (string, string, error) {
+		var stdout, stderr bytes.Buffer
+		rootCmd.SetOut(&stdout)
+		rootCmd.SetErr(&stderr)
+		rootCmd.SetArgs(args)
+		err := rootCmd.Execute()
+		return stdout.String(), stderr.String(), err
+	}
+
+	// Only the adult content protection is off.
+	stdout, stderr, err := run("domain", "audit", "example.com", "-o", "csv")
+	if err == nil || !strings.Contains(err.Error(), "example.com scores 88%, below the minimum of 100%") {
+		t.Fatalf("expected the audit to fail, got %v", err)
+	}
+	if !strings.Contains(stdout, "protection:adult_content,FAIL,disabled\n") || !strings.Contains(stdout, "dkim,PASS,2048-bit key\n") {
+		t.Errorf("unexpected report:\n%s", stdout)
+	}
+	if !strings.Contains(stderr, "Score: 88% (8 of 9 rules passed)") {
+		t.Errorf("expected the score on stderr, got:\n%s", stderr)
+	}
+
+	if _, _, err := run("domain", "audit", "example.com", "--min-score", "80"); err != nil {
+		t.Errorf("expected 88%% to pass --min-score 80, got %v", err)
+	}
+
+	// A custom baseline replaces the built-in one.
+	path := filepath.Join(t.TempDir(), "baseline.yaml")
+	if err := os.WriteFile(path, []byte("protections: [virus]\nrequire_dmarc: true\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	stdout, _, err = run("domain", "audit", "example.com", "--baseline", path, "--min-score", "100", "-o", "json")
+	if err != nil {
+		t.Fatalf("custom baseline: %v", err)
+	}
+	if !strings.Contains(stdout, `"score": 100`) || !strings.Contains(stdout, `"total": 2`) {
+		t.Errorf("unexpected report:\n%s", stdout)
+	}
+
+	stdout, _, err = run("domain", "audit", "--show-baseline", "--baseline", path)
+	if err != nil {
+		t.Fatalf("--show-baseline: %v", err)
+	}
+	if stdout != "protections:\n  - virus\nrequire_dmarc: true\n" {
+		t.Errorf("unexpected baseline:\n%s", stdout)
+	}
+}
@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/i18n"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+// domainSelection is how a bulk domain command picks its domains: the named
+// ones, or every domain of the account, optionally narrowed to one plan.
+type domainSelection struct {
+	All  bool
+	Plan string
+}
+
+// addDomainSelectionFlags adds --all-domains and --plan to a bulk domain command.
+func addDomainSelectionFlags(cmd *cobra.Command, sel *domainSelection) {
+	cmd.Flags().BoolVar(&sel.All, "all-domains", false, "Apply to all available domains")
+	cmd.Flags().StringVar(&sel.Plan, "plan", "", "Only domains on this plan (free, enhanced_protection, team)")
+}
+
+// selectDomains returns the domains named by args (comma-separated values
+// allowed), or every domain with sel.All, keeping those on sel.Plan.
+func selectDomains(ctx context.Context, c *api.Client, args []string, sel domainSelection) ([]api.Domain, error) {
+	var domains []api.Domain
+	switch {
+	case sel.All && len(args) > 0:
+		return nil, fmt.Errorf("cannot use --all-domains with domain arguments")
+	case sel.All:
+		list, err := c.Domains.ListDomains(ctx, &api.ListDomainsOptions{Page: 1, Limit: 1000})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch domains: %v", err)
+		}
+		domains = list.Domains
+	case len(args) > 0:
+		for _, a := range args {
+			for _, name := range splitCSVList(a) {
+				d, err := c.Domains.GetDomain(ctx, name)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get domain %s: %v", name, err)
+				}
+				domains = append(domains, *d)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("specify domains as arguments or use --all-domains")
+	}
+
+	if sel.Plan == "" {
+		return domains, nil
+	}
+	matching := domains[:0]
+	for _, d := range domains {
+		if strings.EqualFold(d.Plan, sel.Plan) {
+			matching = append(matching, d)
+		}
+	}
+	return matching, nil
+}
+
+// planDomainUpdates works out what build would change on each domain. A nil
+// request from build leaves the domain unchanged.
+func planDomainUpdates(domains []api.Domain, build func(*api.Domain) *api.UpdateDomainRequest) ([]output.DomainBulkResult, []*api.UpdateDomainRequest, error) {
+	results := make([]output.DomainBulkResult, len(domains))
+	reqs := make([]*api.UpdateDomainRequest, len(domains))
+	for i := range domains {
+		results[i] = output.DomainBulkResult{Domain: domains[i].Name, Status: output.DomainBulkUnchanged}
+		req := build(&domains[i])
+		if req == nil {
+			continue
+		}
+		changes, err := diffUpdate(&domains[i], req)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(changes) > 0 {
+			results[i].Status = output.DomainBulkPlanned
+			results[i].Changes = changes
+			reqs[i] = req
+		}
+	}
+	return results, reqs, nil
+}
+
+// runDomainBulkUpdate plans an update of every selected domain, asks for
+// confirmation, applies it with up to workers requests at once and prints one
+// row per domain. With dryRun only the plan is printed. Domains that could not
+// be updated are reported as partial failures.
+func runDomainBulkUpdate(ctx context.Context, cmd *cobra.Command, c *api.Client, domains []api.Domain,
+	build func(*api.Domain) *api.UpdateDomainRequest, what string, dryRun bool, workers int,
+) (err error) {
+	results, reqs, err := planDomainUpdates(domains, build)
+	if err != nil {
+		return err
+	}
+
+	errs := make([]error, len(domains))
+	pending := 0
+	for _, r := range reqs {
+		if r != nil {
+			pending++
+		}
+	}
+	if pending > 0 && !dryRun {
+		ok, confirmErr := confirm(cmd, fmt.Sprintf(i18n.T("Change %s on %d of %d domain(s)?"), what, pending, len(domains)))
+		if confirmErr != nil {
+			return confirmErr
+		}
+		if !ok {
+			cmd.PrintErrln(i18n.T("Update canceled"))
+			return nil
+		}
+
+		runConcurrently(len(domains), workers, func(i int) {
+			if reqs[i] == nil {
+				return
+			}
+			if _, errs[i] = c.Domains.UpdateDomain(ctx, domains[i].Name, reqs[i]); errs[i] != nil {
+				results[i].Status = output.DomainBulkFailed
+				results[i].Error = errs[i].Error()
+				return
+			}
+			results[i].Status = output.DomainBulkChanged
+		})
+		for i, r := range results {
+			if r.Status == output.DomainBulkChanged {
+				reportMutation(hookResource{Type: "domain", Action: "update", Name: domains[i].Name, ID: domains[i].ID})
+			}
+		}
+	}
+
+	var failures []partialFailure
+	for i, e := range errs {
+		if e != nil {
+			failures = append(failures, newPartialFailure(domains[i].Name, "", e))
+		}
+	}
+	defer reportPartial(cmd, "Some domains could not be updated", failures, &err)
+
+	format, err := output.ParseFormat(viper.GetString("output"))
+	if err != nil {
+		return fmt.Errorf("invalid output format: %v", err)
+	}
+	formatter := output.NewFormatter(format, cmd.OutOrStdout())
+	if format == output.FormatJSON || format == output.FormatYAML {
+		return formatter.Format(results)
+	}
+	tableData, err := output.FormatDomainBulkResults(results, format)
+	if err != nil {
+		return fmt.Errorf("failed to format output: %v", err)
+	}
+	if err := formatter.Format(tableData); err != nil {
+		return err
+	}
+	printDomainBulkSummary(cmd, results)
+	return nil
+}
+
+// printDomainBulkSummary prints how many domains changed, were already as
+// wanted and failed.
+func printDomainBulkSummary(cmd *cobra.Command, results []output.DomainBulkResult) {
+	counts := map[string]int{}
+	for _, r := range results {
+		counts[r.Status]++
+	}
+	var parts []string
+	for _, status := range []string{output.DomainBulkPlanned, output.DomainBulkChanged, output.DomainBulkUnchanged, output.DomainBulkFailed} {
+		if n := counts[status]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, status))
+		}
+	}
+	if len(parts) > 0 {
+		cmd.PrintErrf("\n%s\n", strings.Join(parts, ", "))
+	}
+}
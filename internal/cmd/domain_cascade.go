@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/config"
+)
+
+func init() {
+	domainDeleteCmd.Flags().Bool("cascade", false, "Back up and delete the domain's aliases first; required for verified domains with enabled aliases")
+	domainDeleteCmd.Flags().String("backup", "", "Alias backup file for --cascade (.yaml, .json or .csv; default: in the config directory)")
+}
+
+// checkDomainDelete says what deleting the domain takes with it, and refuses
+// a verified domain with enabled aliases without --cascade: it is live, so
+// deleting it would silently drop mail for every one of them.
+func checkDomainDelete(cmd *cobra.Command, p *domainDeletePreview, cascade bool) error {
+	if p.Aliases == 0 {
+		return nil
+	}
+	status := "unverified"
+	if p.Verified {
+		status = "verified"
+	}
+	cmd.PrintErrf("⚠️  Domain '%s' is %s and has %d aliases (%d enabled), which are deleted with it\n",
+		p.Domain, status, p.Aliases, p.EnabledAliases)
+	if p.Verified && p.EnabledAliases > 0 && !cascade {
+		return fmt.Errorf("refusing to delete verified domain %s with %d enabled aliases: "+
+			"re-run with --cascade to back up and delete the aliases first (list them with --dry-run)", p.Domain, p.EnabledAliases)
+	}
+	return nil
+}
+
+// cascadeDeleteAliases saves the domain's aliases to backup, or to a new file
+// in the backups directory when it is empty, and then deletes them. Nothing
+// is deleted unless the backup was written.
+func cascadeDeleteAliases(ctx context.Context, cmd *cobra.Command, c *api.Client, p *domainDeletePreview, backup string) error {
+	if backup == "" {
+		dir, err := config.Dir()
+		if err != nil {
+			return fmt.Errorf("failed to locate backup directory: %v", err)
+		}
+		dir = filepath.Join(dir, "backups")
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return fmt.Errorf("failed to create backup directory: %v", err)
+		}
+		backup = filepath.Join(dir, fmt.Sprintf("%s-%s.yaml", p.Domain, time.Now().UTC().Format("20060102-150405")))
+	}
+	if err := writeAliasesFile(backup, p.aliases); err != nil {
+		return fmt.Errorf("failed to back up aliases: %v", err)
+	}
+	cmd.PrintErrf("Backed up %d aliases to %s (restore with: forward-email alias import %s --file %s)\n",
+		len(p.aliases), backup, p.Domain, backup)
+
+	prog := newProgress(cmd, "Deleting aliases", len(p.aliases))
+	for i, a := range p.aliases {
+		prog.Item(a.Name)
+		if err := c.Aliases.DeleteAlias(ctx, p.Domain, a.ID); err != nil {
+			prog.Finish()
+			return fmt.Errorf("failed to delete alias %s after deleting %d of %d; the domain was kept: %v",
+				a.Name, i, len(p.aliases), err)
+		}
+	}
+	prog.Finish()
+	cmd.PrintErrf("Deleted %d aliases\n", len(p.aliases))
+	return nil
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+	fe "github.com/ginsys/forward-email/pkg/errors"
+)
+
+func TestDomainDelete_Cascade(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: live.example
+    is_verified: true
+    aliases:
+      - name: info
+        recipients: [me@example.org]
+        is_enabled: true
+      - name: sales
+        recipients: [team@example.org]
+        is_enabled: true
+  - name: other.example
+    is_verified: true
+    aliases:
+      - name: info
+        recipients: [me@example.org]
+        is_enabled: true
+  - name: idle.example
+    aliases:
+      - name: info
+        recipients: [me@example.org]
+        is_enabled: true
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() { resetCommandFlags(domainDeleteCmd) })
+
+	run := func(args ...string) (string, error) {
+		t.Helper()
+		resetCommandFlags(domainDeleteCmd)
+		var stderr bytes.Buffer
+		rootCmd.SetOut(&bytes.Buffer{})
+		rootCmd.SetErr(&stderr)
+		rootCmd.SetArgs(append([]string{"domain", "delete"}, append(args, "--force")...))
+		err := rootCmd.Execute()
+		return stderr.String(), err
+	}
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	exists := func(domain string) bool {
+		t.Helper()
+		_, err := apiClient.Domains.GetDomain(context.Background(), domain)
+		if err != nil && !fe.IsNotFound(err) {
+			t.Fatalf("get %s: %v", domain, err)
+		}
+		return err == nil
+	}
+
+	// A live domain is kept without --cascade, even with --force
+	out, err := run("live.example")
+	if err == nil || !strings.Contains(err.Error(), "--cascade") || !exists("live.example") {
+		t.Fatalf("expected the live domain to be refused, got %v", err)
+	}
+	if !strings.Contains(out, "is verified and has 2 aliases (2 enabled)") {
+		t.Errorf("expected the affected aliases to be named, got:\n%s", out)
+	}
+
+	backup := filepath.Join(t.TempDir(), "live.yaml")
+	out, err = run("live.example", "--cascade", "--backup", backup)
+	if err != nil {
+		t.Fatalf("domain delete --cascade: %v\n%s", err, out)
+	}
+	data, err := os.ReadFile(backup)
+	if err != nil {
+		t.Fatalf("backup: %v", err)
+	}
+	if !strings.Contains(string(data), "name: info") || !strings.Contains(string(data), "name: sales") {
+		t.Errorf("expected both aliases in the backup, got:\n%s", data)
+	}
+	if exists("live.example") || !strings.Contains(out, "Deleted 2 aliases") {
+		t.Errorf("expected the aliases and domain to be deleted, got:\n%s", out)
+	}
+
+	// Without --backup the aliases go to the config directory
+	if out, err = run("other.example", "--cascade"); err != nil {
+		t.Fatalf("domain delete --cascade: %v\n%s", err, out)
+	}
+	backups, _ := filepath.Glob(filepath.Join(dir, "forwardemail", "backups", "other.example-*.yaml"))
+	if len(backups) != 1 {
+		t.Errorf("expected a backup in the config directory, got %v", backups)
+	}
+
+	// Unverified domains don't route mail, so they only get a warning
+	out, err = run("idle.example")
+	if err != nil || exists("idle.example") || !strings.Contains(out, "is unverified and has 1 aliases") {
+		t.Errorf("expected the unverified domain to be deleted with a warning, got %v\n%s", err, out)
+	}
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/schema"
+)
+
+// profileDomainDefaults returns the domain_defaults of the active profile
+// together with the profile name.
+func profileDomainDefaults() (defaults map[string]any, profile string) {
+	p, profile := activeProfile()
+	return p.DomainDefaults, profile
+}
+
+// domainDefaultsPlan returns the plan named in a profile's domain defaults.
+func domainDefaultsPlan(defaults map[string]any) string {
+	plan, _ := defaults["plan"].(string)
+	return plan
+}
+
+// buildDefaultsRequest builds the update that applies a profile's domain
+// defaults to domain. Settings are sent as a whole, so a partial settings
+// block is applied on top of the domain's current values. It returns nil when
+// the defaults hold nothing beyond the plan.
+func buildDefaultsRequest(defaults map[string]any, profile string, domain *api.Domain) (*api.UpdateDomainRequest, error) {
+	patch := make(map[string]any, len(defaults))
+	for k, v := range defaults {
+		if k != "plan" {
+			patch[k] = v
+		}
+	}
+	if len(patch) == 0 {
+		return nil, nil
+	}
+
+	data, err := yaml.Marshal(patch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read domain_defaults of profile '%s': %v", profile, err)
+	}
+
+	req := &api.UpdateDomainRequest{}
+	if domain != nil && domain.Settings != nil {
+		req.Settings = &api.DomainSettings{}
+		*req.Settings = *domain.Settings
+	}
+	source := fmt.Sprintf("domain_defaults in profile '%s'", profile)
+	if _, err := decodePatch(data, source, schema.DomainPatch, req); err != nil {
+		return nil, err
+	}
+	if _, ok := patch["settings"]; !ok {
+		req.Settings = nil
+	}
+	return req, nil
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/pkg/api"
+	fe "github.com/ginsys/forward-email/pkg/errors"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+func init() {
+	domainCreateCmd.Flags().Bool("dry-run", false, "Show the domain and defaults that would be sent without creating anything")
+	domainDeleteCmd.Flags().Bool("dry-run", false, "Show the domain and aliases that would be deleted without deleting anything")
+}
+
+// domainCreatePreview is what 'domain create --dry-run' would send: the
+// create request and the update applying the profile's domain_defaults.
+type domainCreatePreview struct {
+	DryRun   bool                     `json:"dry_run" yaml:"dry_run"`
+	Create   *api.CreateDomainRequest `json:"create" yaml:"create"`
+	Defaults *api.UpdateDomainRequest `json:"defaults,omitempty" yaml:"defaults,omitempty"`
+	Profile  string                   `json:"profile,omitempty" yaml:"profile,omitempty"`
+}
+
+// domainDeletePreview is what 'domain delete --dry-run' would remove: the
+// domain and, with it, every alias it holds.
+type domainDeletePreview struct {
+	DryRun         bool     `json:"dry_run" yaml:"dry_run"`
+	Domain         string   `json:"domain" yaml:"domain"`
+	Verified       bool     `json:"verified" yaml:"verified"`
+	Aliases        int      `json:"aliases" yaml:"aliases"`
+	EnabledAliases int      `json:"enabled_aliases" yaml:"enabled_aliases"`
+	AliasNames     []string `json:"alias_names" yaml:"alias_names"`
+
+	aliases []api.Alias
+}
+
+// previewDomainCreate prints the requests 'domain create' would send. The
+// domain must not exist yet, as the create would fail.
+func previewDomainCreate(ctx context.Context, cmd *cobra.Command, c *api.Client, p *domainCreatePreview) error {
+	if _, err := c.Domains.GetDomain(ctx, p.Create.Name); err == nil {
+		return fmt.Errorf("domain %s already exists", p.Create.Name)
+	} else if !fe.IsNotFound(err) {
+		return fmt.Errorf("failed to check domain: %w", err)
+	}
+	p.DryRun = true
+	if p.Defaults == nil {
+		p.Profile = ""
+	}
+
+	return printDryRun(cmd, p, func(w io.Writer) error {
+		if err := printRequestFields(w, fmt.Sprintf("DRY RUN: Would create domain '%s'", p.Create.Name), p.Create); err != nil {
+			return err
+		}
+		if p.Defaults == nil {
+			return nil
+		}
+		return printRequestFields(w, fmt.Sprintf("Then apply domain_defaults of profile '%s'", p.Profile), p.Defaults)
+	})
+}
+
+// loadDomainDeletePreview looks up the domain and the aliases deleted with it.
+func loadDomainDeletePreview(ctx context.Context, c *api.Client, name string) (*domainDeletePreview, error) {
+	domain, err := c.Domains.GetDomain(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	aliases, err := listAllAliases(ctx, c, domain.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list aliases of %s: %w", domain.Name, err)
+	}
+	sort.SliceStable(aliases, func(i, j int) bool { return aliases[i].Name < aliases[j].Name })
+	p := &domainDeletePreview{
+		Domain: domain.Name, Verified: domain.IsVerified, Aliases: len(aliases), AliasNames: []string{}, aliases: aliases,
+	}
+	for _, a := range aliases {
+		if a.IsEnabled {
+			p.EnabledAliases++
+		}
+		p.AliasNames = append(p.AliasNames, a.Name)
+	}
+	return p, nil
+}
+
+// previewDomainDelete prints what 'domain delete' would remove.
+func previewDomainDelete(cmd *cobra.Command, p *domainDeletePreview) error {
+	p.DryRun = true
+	return printDryRun(cmd, p, func(w io.Writer) error {
+		status := "unverified"
+		if p.Verified {
+			status = "verified"
+		}
+		if _, err := fmt.Fprintf(w, "DRY RUN: Would delete %s domain '%s' and its %d aliases (%d enabled)\n",
+			status, p.Domain, p.Aliases, p.EnabledAliases); err != nil {
+			return err
+		}
+		for _, name := range p.AliasNames {
+			if _, err := fmt.Fprintf(w, "  %s@%s\n", name, p.Domain); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// printDryRun prints a dry run's result on stdout: doc for JSON and YAML,
+// and otherwise the lines written by text.
+func printDryRun(cmd *cobra.Command, doc any, text func(io.Writer) error) error {
+	format, err := output.ParseFormat(viper.GetString("output"))
+	if err != nil {
+		return fmt.Errorf("invalid output format: %w", err)
+	}
+	if format == output.FormatJSON || format == output.FormatYAML {
+		return output.NewFormatter(format, cmd.OutOrStdout()).Format(doc)
+	}
+	return text(cmd.OutOrStdout())
+}
+
+// printRequestFields writes the fields an API request would send as
+// "field: value" lines under title.
+func printRequestFields(w io.Writer, title string, req any) error {
+	fields, err := diffUpdate(struct{}{}, req)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "%s:\n", title); err != nil {
+		return err
+	}
+	for _, f := range fields {
+		if _, err := fmt.Fprintf(w, "  %s: %s\n", f.Field, output.FormatChangeValue(f.New)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
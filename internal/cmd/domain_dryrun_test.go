@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+	fe "github.com/ginsys/forward-email/pkg/errors"
+)
+
+func TestDomainDryRun(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	if err := os.MkdirAll(filepath.Join(dir, "forwardemail"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	cfg := "current_profile: default\nprofiles:\n  default:\n    domain_defaults:\n      plan: enhanced_protection\n      retention_days: 30\n"
+	if err := os.WriteFile(filepath.Join(dir, "forwardemail", "config.yaml"), []byte(cfg), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    is_verified: true
+    aliases:
+      - name: info
+        recipients: [me@example.org]
+        is_enabled: true
+      - name: sales
+        recipients: [team@example.org]
+        is_enabled: true
+      - name: old
+        recipients: [me@example.org]
+        is_enabled: false
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	t.Cleanup(func() {
+		resetCommandFlags(domainCreateCmd)
+		resetCommandFlags(domainDeleteCmd)
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	})
+
+	run := func(args ...string) (string, error) {
+		t.Helper()
+		resetCommandFlags(domainCreateCmd)
+		resetCommandFlags(domainDeleteCmd)
+		viper.Reset()
+		bindRootFlags()
+		var stdout, stderr bytes.Buffer
+		rootCmd.SetOut(&stdout)
+		rootCmd.SetErr(&stderr)
+		rootCmd.SetArgs(args)
+		err := rootCmd.Execute()
+		return stdout.String(), err
+	}
+
+	out, err := run("domain", "create", "new.example", "--dry-run", "-o", "json")
+	if err != nil {
+		t.Fatalf("domain create --dry-run: %v", err)
+	}
+	var created domainCreatePreview
+	if err := json.Unmarshal([]byte(out), &created); err != nil {
+		t.Fatalf("decode %q: %v", out, err)
+	}
+	if !created.DryRun || created.Create.Name != "new.example" || created.Create.Plan != "enhanced_protection" ||
+		created.Defaults == nil || created.Defaults.RetentionDays == nil || *created.Defaults.RetentionDays != 30 ||
+		created.Profile != "default" {
+		t.Errorf("unexpected create preview %s", out)
+	}
+
+	out, err = run("domain", "create", "new.example", "--dry-run")
+	if err != nil {
+		t.Fatalf("domain create --dry-run: %v", err)
+	}
+	for _, want := range []string{"DRY RUN: Would create domain 'new.example'", "plan: enhanced_protection",
+		"Then apply domain_defaults of profile 'default'", "retention_days: 30"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in:\n%s", want, out)
+		}
+	}
+
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := apiClient.Domains.GetDomain(context.Background(), "new.example"); !fe.IsNotFound(err) {
+		t.Errorf("expected the dry run to create nothing, got %v", err)
+	}
+
+	if _, err = run("domain", "create", "example.com", "--dry-run"); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("expected an existing domain to be refused, got %v", err)
+	}
+
+	out, err = run("domain", "delete", "example.com", "--dry-run", "-o", "json")
+	if err != nil {
+		t.Fatalf("domain delete --dry-run: %v", err)
+	}
+	var deleted domainDeletePreview
+	if err := json.Unmarshal([]byte(out), &deleted); err != nil {
+		t.Fatalf("decode %q: %v", out, err)
+	}
+	if !deleted.DryRun || !deleted.Verified || deleted.Aliases != 3 || deleted.EnabledAliases != 2 ||
+		strings.Join(deleted.AliasNames, ",") != "info,old,sales" {
+		t.Errorf("unexpected delete preview %s", out)
+	}
+
+	out, err = run("domain", "delete", "example.com", "--dry-run")
+	if err != nil {
+		t.Fatalf("domain delete --dry-run: %v", err)
+	}
+	if !strings.Contains(out, "Would delete verified domain 'example.com' and its 3 aliases (2 enabled)") ||
+		!strings.Contains(out, "  sales@example.com") {
+		t.Errorf("unexpected delete preview:\n%s", out)
+	}
+	if _, err := apiClient.Domains.GetDomain(context.Background(), "example.com"); err != nil {
+		t.Errorf("expected the dry run to delete nothing, got %v", err)
+	}
+}
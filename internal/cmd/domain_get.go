@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+func init() {
+	domainGetCmd.Flags().String("file", "", "Read domain names or IDs from a file, one per line (- for stdin)")
+	domainGetCmd.Flags().Int("concurrency", 4, "Number of domains to fetch at once")
+}
+
+// readDomainNames reads one domain per line from path, or stdin for "-",
+// skipping blank lines and # comments.
+func readDomainNames(cmd *cobra.Command, path string) ([]string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		data, err = os.ReadFile(path) //nolint:gosec // the user names the file to read
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read domains file: %v", err)
+	}
+	var names []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if line = strings.TrimSpace(line); line != "" {
+			names = append(names, line)
+		}
+	}
+	return names, scanner.Err()
+}
+
+// domainGetNames returns the domains named by args and --file, in order and
+// without duplicates.
+func domainGetNames(cmd *cobra.Command, args []string) ([]string, error) {
+	names := append([]string(nil), args...)
+	if path, _ := cmd.Flags().GetString("file"); path != "" {
+		fromFile, err := readDomainNames(cmd, path)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, fromFile...)
+	}
+	seen := map[string]bool{}
+	unique := names[:0]
+	for _, n := range names {
+		if key := strings.ToLower(n); !seen[key] {
+			seen[key] = true
+			unique = append(unique, n)
+		}
+	}
+	if len(unique) == 0 {
+		return nil, fmt.Errorf("specify at least one domain or --file")
+	}
+	return unique, nil
+}
+
+// runDomainGetMany fetches several domains at once and prints them as one
+// list: a table row each, or a JSON or YAML array in the order given.
+// Domains that cannot be fetched are reported as partial failures.
+func runDomainGetMany(cmd *cobra.Command, names []string) (err error) {
+	workers, _ := cmd.Flags().GetInt("concurrency")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	fetched := make([]*api.Domain, len(names))
+	errs := make([]error, len(names))
+	runConcurrently(len(names), workers, func(i int) {
+		fetched[i], errs[i] = apiClient.Domains.GetDomain(ctx, names[i])
+	})
+
+	domains := make([]api.Domain, 0, len(names))
+	var failures []partialFailure
+	for i, d := range fetched {
+		if errs[i] != nil {
+			failures = append(failures, newPartialFailure(names[i], "", errs[i]))
+			continue
+		}
+		domains = append(domains, *d)
+	}
+	if len(domains) == 0 {
+		return fmt.Errorf("failed to get domain %s: %w", names[0], errs[0])
+	}
+	defer reportPartial(cmd, "Some domains could not be fetched", failures, &err)
+
+	format, err := output.ParseFormat(viper.GetString("output"))
+	if err != nil {
+		return fmt.Errorf("invalid output format: %v", err)
+	}
+	formatter := output.NewFormatter(format, cmd.OutOrStdout())
+	if !format.Tabular() {
+		return formatter.Format(domains)
+	}
+	tableData, err := output.FormatDomainList(domains, format)
+	if err != nil {
+		return fmt.Errorf("failed to format output: %v", err)
+	}
+	return formatter.Format(tableData)
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/auth"
+)
+
+func TestDomainGet_Many(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: a.example
+    plan: team
+  - name: b.example
+  - name: c.example
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetCommandFlags(domainGetCmd)
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+		_ = rootCmd.PersistentFlags().Set("fail-on-partial", "false")
+	})
+
+	file := filepath.Join(t.TempDir(), "domains.txt")
+	if err := os.WriteFile(file, []byte("# health check\nc.example\n\nA.example  # duplicate\nmissing.example\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetArgs([]string{"domain", "get", "a.example", "b.example", "--file", file, "--fail-on-partial", "-o", "json"})
+	err = rootCmd.Execute()
+	if ExitCode(err) != exitPartial {
+		t.Fatalf("expected a partial failure, got %v\n%s", err, stderr.String())
+	}
+
+	var domains []api.Domain
+	if err := json.Unmarshal(stdout.Bytes(), &domains); err != nil {
+		t.Fatalf("expected a JSON array, got %q: %v", stdout.String(), err)
+	}
+	var names []string
+	for _, d := range domains {
+		names = append(names, d.Name)
+	}
+	if strings.Join(names, ",") != "a.example,b.example,c.example" {
+		t.Errorf("expected the domains in the order given, got %v", names)
+	}
+	if !strings.Contains(stderr.String(), "missing.example: ") {
+		t.Errorf("expected the missing domain to be reported, got %q", stderr.String())
+	}
+}
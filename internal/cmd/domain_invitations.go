@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+var domainInvitationsExpiredOnly bool
+
+// domainInvitationsCmd represents the domain members invitations command group
+var domainInvitationsCmd = &cobra.Command{
+	Use:   "invitations",
+	Short: "Manage pending member invitations",
+	Long:  `Manage the invitations sent to join a domain that have not been accepted yet.`,
+}
+
+var domainInvitationsListCmd = &cobra.Command{
+	Use:     "list <domain-name-or-id>",
+	Aliases: []string{"ls"},
+	Short:   "List pending member invitations",
+	Long: `List the pending invitations of a domain with the invited address, the group
+they would join, and when the invitation was sent and expires. Expired
+invitations are flagged; they can no longer be accepted and must be sent again
+with 'domain members add'.
+
+Examples:
+  forward-email domain members invitations list example.com
+  forward-email domain members invitations list example.com --expired-only`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDomainInvitationsList,
+}
+
+func init() {
+	domainMembersCmd.AddCommand(domainInvitationsCmd)
+	domainInvitationsCmd.AddCommand(domainInvitationsListCmd)
+	domainInvitationsListCmd.Flags().BoolVar(&domainInvitationsExpiredOnly, "expired-only", false, "Only list expired invitations")
+}
+
+func runDomainInvitationsList(cmd *cobra.Command, args []string) error {
+	format, err := output.ParseFormat(viper.GetString("output"))
+	if err != nil {
+		return fmt.Errorf("invalid output format: %v", err)
+	}
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %v", err)
+	}
+
+	domain, err := apiClient.Domains.GetDomain(cmd.Context(), args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get domain: %v", err)
+	}
+	invitations := output.NewDomainInvitationStatuses(domain.Invitations, time.Now(), domainInvitationsExpiredOnly)
+
+	formatter := output.NewFormatter(format, cmd.OutOrStdout())
+	if format == output.FormatJSON || format == output.FormatYAML {
+		return formatter.Format(invitations)
+	}
+	if len(invitations) == 0 {
+		if domainInvitationsExpiredOnly {
+			cmd.PrintErrf("No expired invitations for %s\n", domain.Name)
+		} else {
+			cmd.PrintErrf("No pending invitations for %s\n", domain.Name)
+		}
+		return nil
+	}
+	tableData, err := output.FormatDomainInvitations(invitations, format)
+	if err != nil {
+		return fmt.Errorf("failed to format output: %v", err)
+	}
+	return formatter.Format(tableData)
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+func TestDomainInvitationsList(t *testing.T) {
+	future := time.Now().Add(72 * time.Hour).UTC().Format(time.RFC3339)
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    invitations:
+      - id: i1
+        email: old@example.org
+        group: admin
+        created_at: 2024-01-01T00:00:00Z
+        expires_at: 2024-01-08T00:00:00Z
+      - id: i2
+        email: new@example.org
+        group: user
+        created_at: 2024-01-01T00:00:00Z
+        expires_at: ` + future + `
+  - name: example.net
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetCommandFlags(domainInvitationsListCmd)
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	})
+
+	run := func(args ...string) (string, string) {
+		t.Helper()
+		resetCommandFlags(domainInvitationsListCmd)
+		var stdout, stderr bytes.Buffer
+		rootCmd.SetOut(&stdout)
+		rootCmd.SetErr(&stderr)
+		rootCmd.SetArgs(append([]string{"domain", "members", "invitations", "list"}, args...))
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("invitations list %v: %v", args, err)
+		}
+		return stdout.String(), stderr.String()
+	}
+
+	out, _ := run("example.com", "-o", "table")
+	for _, want := range []string{"old@example.org", "⚠️ expired", "new@example.org", "pending", "2024-01-08 00:00"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in:\n%s", want, out)
+		}
+	}
+
+	out, _ = run("example.com", "--expired-only", "-o", "json")
+	var statuses []output.DomainInvitationStatus
+	if err := json.Unmarshal([]byte(out), &statuses); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if len(statuses) != 1 || statuses[0].Email != "old@example.org" || !statuses[0].Expired {
+		t.Errorf("unexpected --expired-only result %+v", statuses)
+	}
+
+	out, stderr := run("example.net", "-o", "table")
+	if out != "" || !strings.Contains(stderr, "No pending invitations for example.net") {
+		t.Errorf("unexpected output for a domain without invitations: %q, %q", out, stderr)
+	}
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/dnscheck"
+)
+
+// newNameserverResolver returns the resolver for --nameserver. Tests replace
+// it to answer without network access.
+var newNameserverResolver = func(nameserver string) dnscheck.Resolver {
+	return dnscheck.NewResolver(nameserver)
+}
+
+func init() {
+	domainVerifyCmd.Flags().String("nameserver", "", "Check the records at this nameserver first, e.g. the zone's authoritative server")
+}
+
+// checkAtNameserver looks up the records the domain needs at nameserver and
+// prints one line per record on stderr. It fails when a required record is
+// not published there, since verifying through Forward Email would fail too.
+func checkAtNameserver(ctx context.Context, cmd *cobra.Command, domains *api.DomainService, name, nameserver string) error {
+	records, err := domains.GetDomainDNSRecords(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to get DNS records: %w", err)
+	}
+	// A domain given by ID is looked up by name
+	if !strings.Contains(name, ".") {
+		domain, err := domains.GetDomain(ctx, name)
+		if err != nil {
+			return fmt.Errorf("failed to get domain: %w", err)
+		}
+		name = domain.Name
+	}
+
+	results := dnscheck.Check(ctx, newNameserverResolver(nameserver), name, records)
+	cmd.PrintErrf("Records for %s at %s:\n", name, nameserver)
+	for _, r := range results {
+		mark := "✅"
+		switch {
+		case r.OK:
+		case r.Required:
+			mark = "❌"
+		default:
+			mark = "⚠️ "
+		}
+		line := fmt.Sprintf("   %s %-3s %-8s %s", mark, r.Type, r.Name, r.Expected)
+		switch {
+		case r.Error != "":
+			line += " (lookup failed: " + r.Error + ")"
+		case !r.OK && len(r.Found) > 0:
+			line += " (found: " + strings.Join(r.Found, ", ") + ")"
+		case !r.OK:
+			line += " (not found)"
+		}
+		cmd.PrintErrln(line)
+	}
+
+	if missing := dnscheck.Missing(results); len(missing) > 0 {
+		return fmt.Errorf("%d required record(s) not published at %s: %s", len(missing), nameserver, strings.Join(missing, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/ginsys/forward-email/pkg/dnscheck"
+)
+
+// zoneResolver answers MX and TXT lookups from fixed records.
+type zoneResolver struct {
+	mx  []string
+	txt map[string][]string
+}
+
+func (z zoneResolver) LookupMX(_ context.Context, _ string) ([]*net.MX, error) {
+	var mx []*net.MX
+	for _, h := range z.mx {
+		mx = append(mx, &net.MX{Host: h})
+	}
+	return mx, nil
+}
+
+func (z zoneResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	return z.txt[name], nil
+}
+
+func useZone(t *testing.T, zone zoneResolver) *[]string {
+	t.Helper()
+	var asked []string
+	orig := newNameserverResolver
+	newNameserverResolver = func(ns string) dnscheck.Resolver {
+		asked = append(asked, ns)
+		return zone
+	}
+	t.Cleanup(func() { newNameserverResolver = orig })
+	return &asked
+}
+
+func TestDomainVerify_Nameserver(t *testing.T) {
+	_, checks := verifyingServer(t, 1)
+	asked := useZone(t, zoneResolver{
+		mx: []string{"mx1.forwardemail.net.", "mx2.forwardemail.net."},
+		txt: map[string][]string{"example.com": {
+			"forward-email-site-verification=abc123", "v=spf1 include:spf.forwardemail.net -all",
+		}},
+	})
+
+	var stderr bytes.Buffer
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetArgs([]string{"domain", "verify", "example.com", "--nameserver", "ns1.myhost.com"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("verify --nameserver: %v\n%s", err, stderr.String())
+	}
+	if len(*asked) != 1 || (*asked)[0] != "ns1.myhost.com" {
+		t.Errorf("expected ns1.myhost.com to be asked, got %v", *asked)
+	}
+	errOut := stderr.String()
+	for _, want := range []string{"Records for example.com at ns1.myhost.com", "✅ MX  @        mx2.forwardemail.net",
+		"_dmarc   v=DMARC1; p=quarantine; pct=100 (not found)", "DNS records verified"} {
+		if !strings.Contains(errOut, want) {
+			t.Errorf("expected %q on stderr:\n%s", want, errOut)
+		}
+	}
+	if checks.Load() != 1 {
+		t.Errorf("expected the domain to be verified once, got %d", checks.Load())
+	}
+}
+
+func TestDomainVerify_NameserverMissingRecords(t *testing.T) {
+	_, checks := verifyingServer(t, 1)
+	useZone(t, zoneResolver{
+		mx:  []string{"mx1.forwardemail.net."},
+		txt: map[string][]string{"example.com": {"v=spf1 a mx -all"}},
+	})
+
+	var stderr bytes.Buffer
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetArgs([]string{"domain", "verify", "example.com", "--nameserver", "ns1.myhost.com"})
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "3 required record(s) not published at ns1.myhost.com") {
+		t.Errorf("expected the missing records, got %v", err)
+	}
+	if !strings.Contains(stderr.String(), "(found: v=spf1 a mx -all)") {
+		t.Errorf("expected the published SPF record, got:\n%s", stderr.String())
+	}
+	if checks.Load() != 0 {
+		t.Errorf("expected no verification with missing records, got %d", checks.Load())
+	}
+}
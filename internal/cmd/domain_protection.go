@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/pkg/api"
+)
+
+// protectionOptions are the flags of domain protection enable and disable.
+type protectionOptions struct {
+	AdultContent bool
+	Phishing     bool
+	Executable   bool
+	Virus        bool
+	Select       domainSelection
+	DryRun       bool
+	Workers      int
+}
+
+var protectionEnableOpts, protectionDisableOpts protectionOptions
+
+// domainProtectionCmd groups the bulk protection commands
+var domainProtectionCmd = &cobra.Command{
+	Use:   "protection",
+	Short: "Turn spam and malware protections on or off across domains",
+	Long: `Turn the adult content, phishing, executable and virus protections on or off
+for many domains at once. Domains are updated concurrently; ones that already
+have the wanted settings are left alone, and a summary of changed, unchanged and
+failed domains is printed at the end.`,
+}
+
+var domainProtectionEnableCmd = &cobra.Command{
+	Use:   "enable [domain...]",
+	Short: "Turn protections on for several domains",
+	Example: `  forward-email domain protection enable --virus --phishing --all-domains
+  forward-email domain protection enable --executable --all-domains --plan team --dry-run
+  forward-email domain protection enable --virus example.com example.org --yes`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDomainProtection(cmd, args, &protectionEnableOpts, true)
+	},
+}
+
+var domainProtectionDisableCmd = &cobra.Command{
+	Use:          "disable [domain...]",
+	Short:        "Turn protections off for several domains",
+	Example:      `  forward-email domain protection disable --adult-content --all-domains --plan free`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDomainProtection(cmd, args, &protectionDisableOpts, false)
+	},
+}
+
+func init() {
+	domainCmd.AddCommand(domainProtectionCmd)
+	domainProtectionCmd.AddCommand(domainProtectionEnableCmd)
+	domainProtectionCmd.AddCommand(domainProtectionDisableCmd)
+
+	addProtectionFlags(domainProtectionEnableCmd, &protectionEnableOpts)
+	addProtectionFlags(domainProtectionDisableCmd, &protectionDisableOpts)
+}
+
+func addProtectionFlags(cmd *cobra.Command, opts *protectionOptions) {
+	cmd.Flags().BoolVar(&opts.AdultContent, "adult-content", false, "Adult content protection")
+	cmd.Flags().BoolVar(&opts.Phishing, "phishing", false, "Phishing protection")
+	cmd.Flags().BoolVar(&opts.Executable, "executable", false, "Executable attachment protection")
+	cmd.Flags().BoolVar(&opts.Virus, "virus", false, "Virus protection")
+	addDomainSelectionFlags(cmd, &opts.Select)
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show what would change without changing it")
+	cmd.Flags().Bool("yes", false, "Do not ask for confirmation")
+	cmd.Flags().IntVar(&opts.Workers, "concurrency", 4, "Number of domains to update at once")
+}
+
+// names returns the protections selected in opts, by flag name.
+func (opts *protectionOptions) names() []string {
+	var names []string
+	for _, p := range []struct {
+		name string
+		set  bool
+	}{
+		{"adult-content", opts.AdultContent},
+		{"phishing", opts.Phishing},
+		{"executable", opts.Executable},
+		{"virus", opts.Virus},
+	} {
+		if p.set {
+			names = append(names, p.name)
+		}
+	}
+	return names
+}
+
+func runDomainProtection(cmd *cobra.Command, args []string, opts *protectionOptions, enable bool) error {
+	names := opts.names()
+	if len(names) == 0 {
+		return fmt.Errorf("choose at least one of --adult-content, --phishing, --executable or --virus")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %v", err)
+	}
+
+	domains, err := selectDomains(ctx, apiClient, args, opts.Select)
+	if err != nil {
+		return err
+	}
+	if len(domains) == 0 {
+		cmd.PrintErrln("No matching domains found")
+		return nil
+	}
+
+	state := "off"
+	if enable {
+		state = "on"
+	}
+	what := fmt.Sprintf("%s protection to %s", strings.Join(names, ", "), state)
+	return runDomainBulkUpdate(ctx, cmd, apiClient, domains, func(d *api.Domain) *api.UpdateDomainRequest {
+		// Settings are sent as a whole, so start from the current values
+		if d.Settings == nil {
+			d.Settings = &api.DomainSettings{}
+		}
+		settings := *d.Settings
+		if opts.AdultContent {
+			settings.HasAdultContentProtection = enable
+		}
+		if opts.Phishing {
+			settings.HasPhishingProtection = enable
+		}
+		if opts.Executable {
+			settings.HasExecutableProtection = enable
+		}
+		if opts.Virus {
+			settings.HasVirusProtection = enable
+		}
+		return &api.UpdateDomainRequest{Settings: &settings}
+	}, what, opts.DryRun, opts.Workers)
+}
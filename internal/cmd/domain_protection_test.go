@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+)
+
+func TestDomainProtectionEnable(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: a.example
+    settings: {has_virus_protection: true, has_phishing_protection: true, smtp_port: 25}
+  - name: b.example
+    settings: {has_virus_protection: true, smtp_port: 2525}
+  - name: c.example
+  - name: broken.example
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	mock := mockserver.New(seed)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut && r.URL.Path == "/v1/domains/broken.example" {
+			http.Error(w, `{"message":"Internal Server Error"}`, http.StatusInternalServerError)
+			return
+		}
+		mock.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetCommandFlags(domainProtectionEnableCmd)
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+		_ = rootCmd.PersistentFlags().Set("fail-on-partial", "false")
+	})
+
+	var stdout, stderr bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetArgs([]string{"domain", "protection", "enable", "--virus", "--phishing", "--all-domains", "--yes", "--fail-on-partial", "-o", "csv"})
+	err = rootCmd.Execute()
+	if ExitCode(err) != exitPartial {
+		t.Fatalf("expected a partial failure, got %v\n%s", err, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "2 changed, 1 unchanged, 1 failed") {
+		t.Errorf("expected a summary, got %q", stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "broken.example: ") {
+		t.Errorf("expected the failed domain to be reported, got %q", stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "settings.has_phishing_protection: false → true") {
+		t.Errorf("expected the changes in the table, got:\n%s", stdout.String())
+	}
+
+	c, err := client.NewAPIClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := c.Domains.GetDomain(context.Background(), "b.example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := b.Settings; !s.HasVirusProtection || !s.HasPhishingProtection || s.HasExecutableProtection || s.SMTPPort != 2525 {
+		t.Errorf("expected only the chosen protections to change, got %+v", s)
+	}
+}
+
+func TestDomainProtection_RequiresProtection(t *testing.T) {
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() { resetCommandFlags(domainProtectionDisableCmd) })
+
+	var stderr bytes.Buffer
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetArgs([]string{"domain", "protection", "disable", "--all-domains"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "at least one of") {
+		t.Errorf("expected an error naming the protections, got %v", err)
+	}
+}
@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/output"
+	"github.com/ginsys/forward-email/pkg/units"
+)
+
+var (
+	retentionSetDays           string
+	retentionSetDryRun         bool
+	retentionSetWorkers        int
+	retentionSetSel            domainSelection
+	retentionReportPlan        string
+	retentionReportDays        string
+	retentionReportFailOnDrift bool
+	retentionReportDriftOnly   bool
+)
+
+// domainRetentionCmd groups the retention policy commands
+var domainRetentionCmd = &cobra.Command{
+	Use:   "retention",
+	Short: "Enforce and audit email retention across domains",
+	Long: `Set one retention period on many domains at once and report the periods
+in use, so that every domain keeps mail for the same number of days.`,
+}
+
+var domainRetentionSetCmd = &cobra.Command{
+	Use:   "set [domain...]",
+	Short: "Set the retention period of several domains",
+	Long: `Set the retention period of the named domains, or of every domain with
+--all-domains, optionally only those on one --plan. Domains that already have
+the period are left alone. --dry-run shows what would change without changing
+anything; otherwise the change is confirmed first unless --yes is given.`,
+	Example: `  forward-email domain retention set --days 30 --all-domains --dry-run
+  forward-email domain retention set --days 30 --all-domains --plan team --yes
+  forward-email domain retention set --days 12w example.com example.org`,
+	SilenceUsage: true,
+	RunE:         runDomainRetentionSet,
+}
+
+var domainRetentionReportCmd = &cobra.Command{
+	Use:   "report [domain...]",
+	Short: "Report the retention period of each domain",
+	Long: `Show the retention period of the named domains, or of every domain by
+default, and mark the ones that drift from the expected period: --days when
+given, else the period most domains use. --fail-on-drift exits non-zero when
+any domain drifts, for scheduled compliance checks.`,
+	Example: `  forward-email domain retention report
+  forward-email domain retention report --days 30 --fail-on-drift
+  forward-email domain retention report --plan team --drift-only -o json`,
+	SilenceUsage: true,
+	RunE:         runDomainRetentionReport,
+}
+
+func init() {
+	domainCmd.AddCommand(domainRetentionCmd)
+	domainRetentionCmd.AddCommand(domainRetentionSetCmd)
+	domainRetentionCmd.AddCommand(domainRetentionReportCmd)
+
+	domainRetentionSetCmd.Flags().StringVar(&retentionSetDays, "days", "", "Retention period in days (e.g. 30 or 30d, 12w)")
+	_ = domainRetentionSetCmd.MarkFlagRequired("days")
+	addDomainSelectionFlags(domainRetentionSetCmd, &retentionSetSel)
+	domainRetentionSetCmd.Flags().BoolVar(&retentionSetDryRun, "dry-run", false, "Show what would change without changing it")
+	domainRetentionSetCmd.Flags().Bool("yes", false, "Do not ask for confirmation")
+	domainRetentionSetCmd.Flags().IntVar(&retentionSetWorkers, "concurrency", 4, "Number of domains to update at once")
+
+	domainRetentionReportCmd.Flags().StringVar(&retentionReportDays, "days", "", "Expected retention period (default: the most common one)")
+	domainRetentionReportCmd.Flags().StringVar(&retentionReportPlan, "plan", "",
+		"Only domains on this plan (free, enhanced_protection, team)")
+	domainRetentionReportCmd.Flags().BoolVar(&retentionReportDriftOnly, "drift-only", false, "Only list domains that drift")
+	domainRetentionReportCmd.Flags().BoolVar(&retentionReportFailOnDrift, "fail-on-drift", false, "Exit non-zero when any domain drifts")
+}
+
+func runDomainRetentionSet(cmd *cobra.Command, args []string) error {
+	days, err := units.ParseDays(retentionSetDays)
+	if err != nil {
+		return fmt.Errorf("invalid --days: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %v", err)
+	}
+
+	domains, err := selectDomains(ctx, apiClient, args, retentionSetSel)
+	if err != nil {
+		return err
+	}
+	if len(domains) == 0 {
+		cmd.PrintErrln("No matching domains found")
+		return nil
+	}
+
+	return runDomainBulkUpdate(ctx, cmd, apiClient, domains, func(*api.Domain) *api.UpdateDomainRequest {
+		return &api.UpdateDomainRequest{RetentionDays: &days}
+	}, fmt.Sprintf("retention to %d days", days), retentionSetDryRun, retentionSetWorkers)
+}
+
+func runDomainRetentionReport(cmd *cobra.Command, args []string) error {
+	expected := -1
+	if retentionReportDays != "" {
+		days, err := units.ParseDays(retentionReportDays)
+		if err != nil {
+			return fmt.Errorf("invalid --days: %v", err)
+		}
+		expected = days
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %v", err)
+	}
+
+	sel := domainSelection{All: len(args) == 0, Plan: retentionReportPlan}
+	domains, err := selectDomains(ctx, apiClient, args, sel)
+	if err != nil {
+		return err
+	}
+	if expected < 0 {
+		expected = commonRetention(domains)
+	}
+
+	report := []output.DomainRetention{}
+	drifting := 0
+	for i := range domains {
+		r := output.DomainRetention{
+			Domain:        domains[i].Name,
+			Plan:          domains[i].Plan,
+			RetentionDays: domains[i].RetentionDays,
+			Drift:         domains[i].RetentionDays != expected,
+		}
+		if r.Drift {
+			drifting++
+		} else if retentionReportDriftOnly {
+			continue
+		}
+		report = append(report, r)
+	}
+	sort.Slice(report, func(i, j int) bool { return report[i].Domain < report[j].Domain })
+
+	format, err := output.ParseFormat(viper.GetString("output"))
+	if err != nil {
+		return fmt.Errorf("invalid output format: %v", err)
+	}
+	formatter := output.NewFormatter(format, cmd.OutOrStdout())
+	if format == output.FormatJSON || format == output.FormatYAML {
+		if err := formatter.Format(report); err != nil {
+			return err
+		}
+	} else {
+		tableData, err := output.FormatDomainRetention(report, format)
+		if err != nil {
+			return fmt.Errorf("failed to format output: %v", err)
+		}
+		if err := formatter.Format(tableData); err != nil {
+			return err
+		}
+	}
+
+	if len(domains) > 0 {
+		cmd.PrintErrf("\n%d of %d domain(s) differ from %d days\n", drifting, len(domains), expected)
+	}
+	if drifting > 0 && retentionReportFailOnDrift {
+		return fmt.Errorf("%d domain(s) do not use a retention of %d days", drifting, expected)
+	}
+	return nil
+}
+
+// commonRetention returns the retention period used by most domains, the
+// shortest one on a tie.
+func commonRetention(domains []api.Domain) int {
+	counts := map[int]int{}
+	for i := range domains {
+		counts[domains[i].RetentionDays]++
+	}
+	best, bestCount := 0, 0
+	for days, n := range counts {
+		if n > bestCount || (n == bestCount && days < best) {
+			best, bestCount = days, n
+		}
+	}
+	return best
+}
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+func setupRetentionTest(t *testing.T) {
+	t.Helper()
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: a.example
+    plan: team
+    retention_days: 30
+  - name: b.example
+    plan: team
+    retention_days: 90
+  - name: c.example
+    plan: free
+    retention_days: 90
+  - name: d.example
+    plan: team
+    retention_days: 30
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	t.Cleanup(srv.Close)
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetCommandFlags(domainRetentionSetCmd)
+		resetCommandFlags(domainRetentionReportCmd)
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	})
+}
+
+func runRetention(t *testing.T, args ...string) (string, string, error) {
+	t.Helper()
+	resetCommandFlags(domainRetentionSetCmd)
+	resetCommandFlags(domainRetentionReportCmd)
+	var stdout, stderr bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetIn(strings.NewReader(""))
+	rootCmd.SetArgs(append([]string{"domain", "retention"}, args...))
+	err := rootCmd.Execute()
+	return stdout.String(), stderr.String(), err
+}
+
+func retentionDays(t *testing.T) map[string]int {
+	t.Helper()
+	c, err := client.NewAPIClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	days := map[string]int{}
+	for _, name := range []string{"a.example", "b.example", "c.example", "d.example"} {
+		d, err := c.Domains.GetDomain(context.Background(), name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		days[name] = d.RetentionDays
+	}
+	return days
+}
+
+func TestDomainRetentionSet(t *testing.T) {
+	setupRetentionTest(t)
+
+	stdout, _, err := runRetention(t, "set", "--days", "30d", "--all-domains", "--plan", "team", "--dry-run", "-o", "json")
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	var plan []output.DomainBulkResult
+	if err := json.Unmarshal([]byte(stdout), &plan); err != nil {
+		t.Fatalf("expected JSON, got %q: %v", stdout, err)
+	}
+	if len(plan) != 3 {
+		t.Fatalf("expected the 3 team domains, got %+v", plan)
+	}
+	for _, r := range plan {
+		want := output.DomainBulkUnchanged
+		if r.Domain == "b.example" {
+			want = output.DomainBulkPlanned
+		}
+		if r.Status != want {
+			t.Errorf("%s: expected %q, got %q", r.Domain, want, r.Status)
+		}
+	}
+	if got := retentionDays(t)["b.example"]; got != 90 {
+		t.Fatalf("dry run changed b.example to %d", got)
+	}
+
+	if _, _, err := runRetention(t, "set", "--days", "30", "--all-domains", "--plan", "team"); err != errConfirmationRequired {
+		t.Fatalf("expected a confirmation to be required, got %v", err)
+	}
+
+	stdout, stderr, err := runRetention(t, "set", "--days", "30", "--all-domains", "--plan", "team", "--yes")
+	if err != nil {
+		t.Fatalf("set: %v\n%s", err, stderr)
+	}
+	if !strings.Contains(stdout, "retention_days: 90 → 30") {
+		t.Errorf("expected the change in the table, got:\n%s", stdout)
+	}
+	if !strings.Contains(stderr, "1 changed, 2 unchanged") {
+		t.Errorf("expected a summary, got %q", stderr)
+	}
+	want := map[string]int{"a.example": 30, "b.example": 30, "c.example": 90, "d.example": 30}
+	for name, days := range retentionDays(t) {
+		if days != want[name] {
+			t.Errorf("%s: expected %d days, got %d", name, want[name], days)
+		}
+	}
+}
+
+func TestDomainRetentionReport(t *testing.T) {
+	setupRetentionTest(t)
+
+	stdout, stderr, err := runRetention(t, "report", "--drift-only", "-o", "json")
+	if err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	var report []output.DomainRetention
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil {
+		t.Fatalf("expected JSON, got %q: %v", stdout, err)
+	}
+	// 30 and 90 days are equally common, so the shorter period is expected
+	if len(report) != 2 || report[0].Domain != "b.example" || report[1].Domain != "c.example" {
+		t.Errorf("expected b.example and c.example to drift, got %+v", report)
+	}
+	if !strings.Contains(stderr, "2 of 4 domain(s) differ from 30 days") {
+		t.Errorf("expected a summary, got %q", stderr)
+	}
+
+	if _, _, err := runRetention(t, "report", "--plan", "free", "--days", "90", "--fail-on-drift"); err != nil {
+		t.Errorf("expected no drift on the free plan, got %v", err)
+	}
+	if _, _, err := runRetention(t, "report", "--days", "90", "--fail-on-drift"); err == nil {
+		t.Error("expected --fail-on-drift to fail")
+	}
+}
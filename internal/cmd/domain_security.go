@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/schema"
+)
+
+var (
+	syncListsSource  string
+	syncListsPrune   bool
+	syncListsDryRun  bool
+	syncListsWorkers int
+	syncListsSel     domainSelection
+)
+
+// domainListsSource is the central allowlist and denylist read by sync-lists.
+type domainListsSource struct {
+	Allowlist []string `json:"allowlist"`
+	Denylist  []string `json:"denylist"`
+}
+
+// domainSecurityCmd groups the domain security commands
+var domainSecurityCmd = &cobra.Command{
+	Use:   "security",
+	Short: "Manage security settings across domains",
+}
+
+var domainSecuritySyncListsCmd = &cobra.Command{
+	Use:   "sync-lists [domain...]",
+	Short: "Reconcile domain allowlists and denylists with a central file",
+	Long: `Bring the allowlist and denylist of the named domains, or of every domain
+with --all-domains, in line with a central YAML or JSON file ("-" reads stdin):
+
+  allowlist: [partner.example, 192.0.2.10]
+  denylist:  [spammer.example, bad@example.net]
+
+Entries of the file missing from a domain are added; entries the domain has
+beyond the file are kept unless --prune is given, which removes them. A list
+left out of the file is not touched. Entries compare case-insensitively.
+
+--dry-run shows the changes per domain without making them; otherwise they are
+confirmed first unless --yes is given. The file is checked against the
+domain-lists schema (see 'schema print domain-lists').`,
+	Example: `  forward-email domain security sync-lists --source lists.yaml --all-domains --dry-run
+  forward-email domain security sync-lists --source lists.yaml --all-domains --prune --yes
+  curl -s https://intranet.example/blocklist.yaml | forward-email domain security sync-lists --source - example.com --yes`,
+	SilenceUsage: true,
+	RunE:         runDomainSecuritySyncLists,
+}
+
+func init() {
+	domainCmd.AddCommand(domainSecurityCmd)
+	domainSecurityCmd.AddCommand(domainSecuritySyncListsCmd)
+
+	domainSecuritySyncListsCmd.Flags().StringVar(&syncListsSource, "source", "", "File with the central allowlist and denylist (- for stdin)")
+	_ = domainSecuritySyncListsCmd.MarkFlagRequired("source")
+	domainSecuritySyncListsCmd.Flags().BoolVar(&syncListsPrune, "prune", false, "Remove entries that are not in the source file")
+	addDomainSelectionFlags(domainSecuritySyncListsCmd, &syncListsSel)
+	domainSecuritySyncListsCmd.Flags().BoolVar(&syncListsDryRun, "dry-run", false, "Show what would change without changing it")
+	domainSecuritySyncListsCmd.Flags().Bool("yes", false, "Do not ask for confirmation")
+	domainSecuritySyncListsCmd.Flags().IntVar(&syncListsWorkers, "concurrency", 4, "Number of domains to update at once")
+}
+
+// readDomainListsSource reads and validates the central lists file at path.
+func readDomainListsSource(cmd *cobra.Command, path string) (*domainListsSource, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		data, err = os.ReadFile(path) //nolint:gosec // the user names the file to read
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lists file: %v", err)
+	}
+
+	src := &domainListsSource{}
+	if _, err := decodePatch(data, "lists file "+path, schema.DomainLists, src); err != nil {
+		return nil, err
+	}
+	src.Allowlist = trimListEntries(src.Allowlist)
+	src.Denylist = trimListEntries(src.Denylist)
+	for _, a := range src.Allowlist {
+		if hasEntry(src.Denylist, a) {
+			return nil, fmt.Errorf("invalid lists file %s: %q is in both the allowlist and the denylist", path, a)
+		}
+	}
+	return src, nil
+}
+
+func trimListEntries(list []string) []string {
+	for i := range list {
+		list[i] = strings.TrimSpace(list[i])
+	}
+	return list
+}
+
+// hasEntry reports whether list has s, ignoring case.
+func hasEntry(list []string, s string) bool {
+	return slices.ContainsFunc(list, func(v string) bool { return strings.EqualFold(v, s) })
+}
+
+// syncList returns current with the entries of want it lacks appended, and
+// with prune without the entries that are not in want. The order of current
+// is kept. An empty want leaves the list alone and returns nil.
+func syncList(current, want []string, prune bool) []string {
+	if len(want) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(current)+len(want))
+	for _, v := range current {
+		if !prune || hasEntry(want, v) {
+			out = append(out, v)
+		}
+	}
+	for _, v := range want {
+		if !hasEntry(out, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func runDomainSecuritySyncLists(cmd *cobra.Command, args []string) error {
+	src, err := readDomainListsSource(cmd, syncListsSource)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %v", err)
+	}
+
+	domains, err := selectDomains(ctx, apiClient, args, syncListsSel)
+	if err != nil {
+		return err
+	}
+	if len(domains) == 0 {
+		cmd.PrintErrln("No matching domains found")
+		return nil
+	}
+
+	return runDomainBulkUpdate(ctx, cmd, apiClient, domains, func(d *api.Domain) *api.UpdateDomainRequest {
+		return &api.UpdateDomainRequest{
+			Allowlist: syncList(d.Allowlist, src.Allowlist, syncListsPrune),
+			Denylist:  syncList(d.Denylist, src.Denylist, syncListsPrune),
+		}
+	}, "the allowlist and denylist", syncListsDryRun, syncListsWorkers)
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+)
+
+func TestSyncList(t *testing.T) {
+	tests := []struct {
+		name    string
+		current []string
+		want    []string
+		prune   bool
+		expect  []string
+	}{
+		{"adds missing", []string{"a.example"}, []string{"b.example", "A.example"}, false, []string{"a.example", "b.example"}},
+		{"keeps extra", []string{"x.example", "a.example"}, []string{"a.example"}, false, []string{"x.example", "a.example"}},
+		{"prunes extra", []string{"x.example", "a.example"}, []string{"a.example", "b.example"}, true, []string{"a.example", "b.example"}},
+		{"leaves unset list alone", []string{"x.example"}, nil, true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := syncList(tt.current, tt.want, tt.prune); !slices.Equal(got, tt.expect) {
+				t.Errorf("syncList() = %v, want %v", got, tt.expect)
+			}
+		})
+	}
+}
+
+func TestDomainSecuritySyncLists(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: a.example
+    denylist: [spammer.example, legacy.example]
+  - name: b.example
+    allowlist: [partner.example]
+    denylist: [spammer.example, bad@example.net]
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() { resetCommandFlags(domainSecuritySyncListsCmd) })
+
+	source := filepath.Join(t.TempDir(), "lists.yaml")
+	if err := os.WriteFile(source, []byte("allowlist: [partner.example]\ndenylist: [spammer.example, bad@example.net]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetArgs([]string{"domain", "security", "sync-lists", "--source", source, "--all-domains", "--prune", "--yes"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("sync-lists: %v\n%s", err, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "1 changed, 1 unchanged") {
+		t.Errorf("expected a summary, got %q", stderr.String())
+	}
+
+	c, err := client.NewAPIClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := c.Domains.GetDomain(context.Background(), "a.example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(a.Allowlist, []string{"partner.example"}) || !slices.Equal(a.Denylist, []string{"spammer.example", "bad@example.net"}) {
+		t.Errorf("expected a.example to match the source, got allowlist %v, denylist %v", a.Allowlist, a.Denylist)
+	}
+}
+
+func TestReadDomainListsSource_RejectsConflicts(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "lists.yaml")
+	if err := os.WriteFile(source, []byte("allowlist: [Partner.example]\ndenylist: [partner.example]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readDomainListsSource(domainSecuritySyncListsCmd, source); err == nil || !strings.Contains(err.Error(), "both") {
+		t.Errorf("expected a conflict error, got %v", err)
+	}
+	if err := os.WriteFile(source, []byte("blocklist: [x.example]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readDomainListsSource(domainSecuritySyncListsCmd, source); err == nil {
+		t.Error("expected an unknown key to be rejected")
+	}
+}
@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/config"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+var domainListTags []string
+
+// domainTagCmd represents the domain tag command group
+var domainTagCmd = &cobra.Command{
+	Use:   "tag",
+	Short: "Tag domains to group and filter them",
+	Long: `Attach tags such as customer:acme or env:prod to domains, then filter
+'domain list' by them. Tags are stored in the active profile of the config file,
+not in the account, so they are only seen by this CLI.`,
+}
+
+var domainTagAddCmd = &cobra.Command{
+	Use:   "add <domain> <tag>...",
+	Short: "Add tags to a domain",
+	Example: `  forward-email domain tag add example.com customer:acme env:prod
+  forward-email domain list --tag env=prod`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runDomainTagAdd,
+}
+
+var domainTagRemoveCmd = &cobra.Command{
+	Use:     "remove <domain> <tag>...",
+	Aliases: []string{"rm"},
+	Short:   "Remove tags from a domain",
+	Args:    cobra.MinimumNArgs(2),
+	RunE:    runDomainTagRemove,
+}
+
+var domainTagListCmd = &cobra.Command{
+	Use:     "list [domain...]",
+	Aliases: []string{"ls"},
+	Short:   "List tagged domains and their tags",
+	RunE:    runDomainTagList,
+}
+
+func init() {
+	domainCmd.AddCommand(domainTagCmd)
+	domainTagCmd.AddCommand(domainTagAddCmd)
+	domainTagCmd.AddCommand(domainTagRemoveCmd)
+	domainTagCmd.AddCommand(domainTagListCmd)
+
+	domainListCmd.Flags().StringSliceVar(&domainListTags, "tag", nil,
+		"Only list domains with this tag: key=value, key:value or key (repeatable, all must match)")
+}
+
+// validateTag rejects tags that could not be given back on the command line
+// or in a --tag list.
+func validateTag(tag string) error {
+	if tag == "" || strings.ContainsAny(tag, " \t\n,") {
+		return fmt.Errorf("invalid tag %q: tags cannot be empty or contain spaces or commas", tag)
+	}
+	return nil
+}
+
+// loadTagProfile loads the config together with the active profile and its name.
+func loadTagProfile() (*config.Config, config.Profile, string, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, config.Profile{}, "", fmt.Errorf("failed to load config: %v", err)
+	}
+	name := viper.GetString("profile")
+	if name == "" {
+		name = cfg.CurrentProfile
+	}
+	return cfg, cfg.Profiles[name], name, nil
+}
+
+// tagMatches reports whether tag satisfies a --tag filter. key=value and
+// key:value match that tag exactly; a bare key matches any value of it.
+func tagMatches(tag, filter string) bool {
+	if key, value, ok := strings.Cut(filter, "="); ok {
+		filter = key + ":" + value
+	}
+	if strings.EqualFold(tag, filter) {
+		return true
+	}
+	return !strings.Contains(filter, ":") && strings.HasPrefix(strings.ToLower(tag), strings.ToLower(filter)+":")
+}
+
+// hasTags reports whether tags satisfy every filter.
+func hasTags(tags, filters []string) bool {
+	for _, f := range filters {
+		if !slices.ContainsFunc(tags, func(tag string) bool { return tagMatches(tag, f) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// addDomainTagColumn appends a TAGS column to a domain table whose rows follow
+// domains. Nothing is added when none of the domains are tagged.
+func addDomainTagColumn(table *output.TableData, domains []api.Domain, profile *config.Profile) {
+	tagged := slices.ContainsFunc(domains, func(d api.Domain) bool { return len(profile.TagsFor(d.Name)) > 0 })
+	if !tagged {
+		return
+	}
+	table.Headers = append(table.Headers, "TAGS")
+	for i := range table.Rows {
+		table.Rows[i] = append(table.Rows[i], strings.Join(profile.TagsFor(domains[i].Name), ", "))
+	}
+}
+
+func runDomainTagAdd(cmd *cobra.Command, args []string) error {
+	for _, tag := range args[1:] {
+		if err := validateTag(tag); err != nil {
+			return err
+		}
+	}
+	cfg, profile, name, err := loadTagProfile()
+	if err != nil {
+		return err
+	}
+
+	// Resolve IDs and case to the domain's name, which tags are stored under.
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %v", err)
+	}
+	domain, err := apiClient.Domains.GetDomain(cmd.Context(), args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get domain: %v", err)
+	}
+
+	tags := slices.Clone(profile.TagsFor(domain.Name))
+	for _, tag := range args[1:] {
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+	profile.SetTags(domain.Name, tags)
+	cfg.SetProfile(name, &profile)
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %v", err)
+	}
+
+	cmd.PrintErrf("✅ Tagged %s: %s\n", domain.Name, strings.Join(tags, ", "))
+	return nil
+}
+
+func runDomainTagRemove(cmd *cobra.Command, args []string) error {
+	cfg, profile, name, err := loadTagProfile()
+	if err != nil {
+		return err
+	}
+
+	domain := args[0]
+	tags := profile.TagsFor(domain)
+	var kept []string
+	for _, tag := range tags {
+		if !slices.Contains(args[1:], tag) {
+			kept = append(kept, tag)
+		}
+	}
+	if len(kept) == len(tags) {
+		return fmt.Errorf("domain %s has none of the tags %s", domain, strings.Join(args[1:], ", "))
+	}
+	profile.SetTags(domain, kept)
+	cfg.SetProfile(name, &profile)
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %v", err)
+	}
+
+	if len(kept) == 0 {
+		cmd.PrintErrf("✅ Removed all tags from %s\n", domain)
+	} else {
+		cmd.PrintErrf("✅ Tagged %s: %s\n", domain, strings.Join(kept, ", "))
+	}
+	return nil
+}
+
+func runDomainTagList(cmd *cobra.Command, args []string) error {
+	format, err := output.ParseFormat(viper.GetString("output"))
+	if err != nil {
+		return fmt.Errorf("invalid output format: %v", err)
+	}
+	_, profile, _, err := loadTagProfile()
+	if err != nil {
+		return err
+	}
+
+	entries := []config.DomainTags{}
+	for _, dt := range profile.DomainTags {
+		if len(args) == 0 || slices.ContainsFunc(args, func(a string) bool { return strings.EqualFold(a, dt.Domain) }) {
+			entries = append(entries, dt)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Domain < entries[j].Domain })
+
+	formatter := output.NewFormatter(format, cmd.OutOrStdout())
+	if format == output.FormatJSON || format == output.FormatYAML {
+		return formatter.Format(entries)
+	}
+	if len(entries) == 0 {
+		cmd.PrintErrln("No tagged domains found")
+		return nil
+	}
+	table := output.NewTableData([]string{"DOMAIN", "TAGS"})
+	for _, dt := range entries {
+		table.AddRow([]string{dt.Domain, strings.Join(dt.Tags, ", ")})
+	}
+	return formatter.Format(table)
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/auth"
+)
+
+func TestTagMatches(t *testing.T) {
+	tests := []struct {
+		tag, filter string
+		want        bool
+	}{
+		{"env:prod", "env=prod", true},
+		{"env:prod", "env:prod", true},
+		{"env:prod", "ENV=Prod", true},
+		{"env:prod", "env", true},
+		{"env:prod", "env=dev", false},
+		{"env:prod", "en", false},
+		{"legacy", "legacy", true},
+	}
+	for _, tt := range tests {
+		if got := tagMatches(tt.tag, tt.filter); got != tt.want {
+			t.Errorf("tagMatches(%q, %q) = %v, want %v", tt.tag, tt.filter, got, tt.want)
+		}
+	}
+	if !hasTags([]string{"env:prod", "customer:acme"}, []string{"env=prod", "customer"}) {
+		t.Error("expected all filters to match")
+	}
+	if hasTags([]string{"env:prod"}, []string{"env=prod", "customer"}) {
+		t.Error("expected a missing tag to fail the match")
+	}
+}
+
+func TestDomainTagCommands(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+  - name: example.net
+  - name: example.org
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetCommandFlags(domainListCmd)
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+		viper.Reset()
+		bindRootFlags()
+	})
+
+	run := func(args ...string) (string, string) {
+		t.Helper()
+		resetCommandFlags(domainListCmd)
+		var stdout, stderr bytes.Buffer
+		rootCmd.SetOut(&stdout)
+		rootCmd.SetErr(&stderr)
+		rootCmd.SetArgs(args)
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+		return stdout.String(), stderr.String()
+	}
+
+	_, stderr := run("domain", "tag", "add", "Example.com", "env:prod", "customer:acme")
+	if !strings.Contains(stderr, "Tagged example.com: customer:acme, env:prod") {
+		t.Errorf("unexpected tag add output %q", stderr)
+	}
+	run("domain", "tag", "add", "example.net", "env:dev", "customer:acme")
+
+	out, _ := run("domain", "tag", "list", "-o", "table")
+	if !strings.Contains(out, "example.com") || !strings.Contains(out, "env:dev") {
+		t.Errorf("unexpected tag list:\n%s", out)
+	}
+
+	// domain list writes to os.Stdout.
+	out = captureStdout(t, func() { run("domain", "list", "--tag", "customer=acme", "--tag", "env=prod", "-o", "json") })
+	var domains []api.Domain
+	if err := json.Unmarshal([]byte(out), &domains); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if len(domains) != 1 || domains[0].Name != "example.com" {
+		t.Errorf("expected only example.com, got %+v", domains)
+	}
+
+	out = captureStdout(t, func() { run("domain", "list", "-o", "csv") })
+	if !strings.Contains(out, "TAGS") || !strings.Contains(out, "customer:acme, env:dev") {
+		t.Errorf("expected a tags column in:\n%s", out)
+	}
+
+	run("domain", "tag", "remove", "example.com", "env:prod", "customer:acme")
+	out, _ = run("domain", "tag", "list", "example.com", "-o", "json")
+	if strings.TrimSpace(out) != "[]" {
+		t.Errorf("expected no tags left on example.com, got %s", out)
+	}
+}
@@ -2,12 +2,26 @@ package cmd
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
 	"github.com/ginsys/forward-email/internal/testutil"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/auth"
+	"github.com/ginsys/forward-email/pkg/output"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
 )
 
 // Test variables for domain command flags
@@ -533,3 +547,363 @@ By default, verifies DNS records. Use --smtp to verify SMTP outbound configurati
 		})
 	}
 }
+
+func TestDomainClone_CopiesSettingsAndAliases(t *testing.T) {
+	var update api.UpdateDomainRequest
+	var created []string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/domains/src.com", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(api.Domain{
+			Name:          "src.com",
+			Plan:          "team",
+			RetentionDays: 30,
+			Allowlist:     []string{"good.com"},
+			Denylist:      []string{"bad.com"},
+			Settings:      &api.DomainSettings{WebhookURL: "https://hooks.example.com", HasVirusProtection: true, SMTPPort: 2525},
+		})
+	})
+	mux.HandleFunc("/v1/domains", func(w http.ResponseWriter, r *http.Request) {
+		var req api.CreateDomainRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.Plan != "team" {
+			t.Errorf("expected plan team, got %q", req.Plan)
+		}
+		_ = json.NewEncoder(w).Encode(api.Domain{Name: req.Name, Settings: &api.DomainSettings{SMTPPort: 25}})
+	})
+	mux.HandleFunc("/v1/domains/dst.com", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&update)
+		_ = json.NewEncoder(w).Encode(api.Domain{Name: "dst.com"})
+	})
+	mux.HandleFunc("/v1/domains/src.com/aliases", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode([]api.Alias{{ID: "1", Name: "info", Recipients: []string{"a@x"}, IsEnabled: true}})
+	})
+	mux.HandleFunc("/v1/domains/dst.com/aliases", func(w http.ResponseWriter, r *http.Request) {
+		var req api.CreateAliasRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		created = append(created, req.Name)
+		_ = json.NewEncoder(w).Encode(api.Alias{ID: "2", Name: req.Name})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetErr(&out)
+	rootCmd.SetArgs([]string{"domain", "clone", "src.com", "dst.com", "--aliases", "--skip", "denylist,ports", "-o", "json"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("clone failed: %v\n%s", err, out.String())
+	}
+
+	if update.Settings == nil || update.Settings.WebhookURL != "https://hooks.example.com" || !update.Settings.HasVirusProtection {
+		t.Errorf("expected webhook and protection to be copied, got %+v", update.Settings)
+	}
+	if update.Settings != nil && update.Settings.SMTPPort != 25 {
+		t.Errorf("expected skipped ports to keep target value 25, got %d", update.Settings.SMTPPort)
+	}
+	if len(update.Allowlist) != 1 || len(update.Denylist) != 0 {
+		t.Errorf("expected allowlist copied and denylist skipped, got allow=%v deny=%v", update.Allowlist, update.Denylist)
+	}
+	if update.RetentionDays == nil || *update.RetentionDays != 30 {
+		t.Errorf("expected retention 30 to be copied, got %v", update.RetentionDays)
+	}
+	if len(created) != 1 || created[0] != "info" {
+		t.Errorf("expected alias 'info' to be copied, got %v", created)
+	}
+}
+
+func TestDomainClone_RejectsUnknownSkipField(t *testing.T) {
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetErr(&out)
+	rootCmd.SetArgs([]string{"domain", "clone", "a.com", "b.com", "--skip", "nope"})
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "invalid --skip field") {
+		t.Fatalf("expected invalid --skip error, got %v", err)
+	}
+}
+
+func TestDomainVerifyStatus_AllDomainsWithBadges(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: good.com
+    is_verified: true
+    has_mx_record: true
+    has_txt_record: true
+    has_spf_record: true
+    has_dkim_record: true
+    has_dmarc_record: true
+  - name: pending.org
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	// Other tests override viper keys directly; start from the production bindings.
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+		_ = domainVerifyStatusCmd.Flags().Set("all-domains", "false")
+		_ = domainVerifyStatusCmd.Flags().Set("badge-dir", "")
+	})
+
+	badgeDir := t.TempDir()
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetErr(&buf)
+	rootCmd.SetArgs([]string{"domain", "verify-status", "--all-domains", "--badge-dir", badgeDir, "-o", "json"})
+
+	stdout := captureStdout(t, func() {
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("verify-status: %v\n%s", err, buf.String())
+		}
+	})
+
+	var health []output.DomainHealth
+	if err := json.Unmarshal([]byte(stdout), &health); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+	}
+	if len(health) != 2 || !health[0].Verified || health[1].Verified || len(health[1].MissingRecords) != 5 {
+		t.Errorf("unexpected health document: %+v", health)
+	}
+
+	for name, want := range map[string]string{"good.com.svg": ">verified<", "pending.org.svg": ">unverified<"} {
+		data, err := os.ReadFile(filepath.Join(badgeDir, name))
+		if err != nil || !strings.Contains(string(data), want) {
+			t.Errorf("badge %s: err=%v, want %q", name, err, want)
+		}
+	}
+}
+
+func TestDomainVerifyStatus_RequiresDomainsOrAll(t *testing.T) {
+	rootCmd.SetArgs([]string{"domain", "verify-status"})
+	rootCmd.SetOut(io.Discard)
+	rootCmd.SetErr(io.Discard)
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "--all-domains") {
+		t.Errorf("expected usage error, got %v", err)
+	}
+}
+
+// resetDomainUpdateFlags restores every 'domain update' flag to its default so
+// one test's values and Changed state do not leak into the next.
+func resetDomainUpdateFlags() {
+	domainUpdateCmd.Flags().VisitAll(func(f *pflag.Flag) {
+		_ = f.Value.Set(f.DefValue)
+		f.Changed = false
+	})
+}
+
+func TestDomainUpdate_FieldFlags(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetDomainUpdateFlags()
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	})
+
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetErr(&buf)
+	rootCmd.SetArgs([]string{"domain", "update", "example.com", "-o", "table",
+		"--max-quota-per-alias", "10GB", "--max-recipients-per-alias", "25",
+		"--bounce-webhook", "https://hooks.example.com/bounce", "--has-delivery-logs",
+		"--restricted-alias-names", "admin, postmaster", "--recipient-verification", "--ignore-mx-check",
+		"--retention", "12w"})
+	table := captureStdout(t, func() {
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("domain update: %v\n%s", err, buf.String())
+		}
+	})
+
+	out := buf.String()
+	for _, want := range []string{"max_quota_per_alias: 0 → 10737418240", "restricted_alias_names: - → admin, postmaster"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+	for _, want := range []string{"10.0 GB", "https://hooks.example.com/bounce", "admin, postmaster"} {
+		if !strings.Contains(table, want) {
+			t.Errorf("expected %q in domain details:\n%s", want, table)
+		}
+	}
+
+	c, err := client.NewAPIClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := c.Domains.GetDomain(context.Background(), "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.MaxQuotaPerAlias != 10<<30 || d.MaxRecipientsPerAlias != 25 || !d.HasDeliveryLogs ||
+		!d.HasRecipientVerification || !d.IgnoreMXCheck || len(d.RestrictedAliasNames) != 2 || d.RetentionDays != 84 {
+		t.Errorf("fields not updated: %+v", d)
+	}
+
+	resetDomainUpdateFlags()
+	rootCmd.SetArgs([]string{"domain", "update", "example.com", "--max-quota", "lots", "-o", "table"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "invalid --max-quota") {
+		t.Errorf("expected size parse error, got %v", err)
+	}
+}
+
+func TestDomainCreate_ProfileDefaults(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	if err := os.MkdirAll(filepath.Join(dir, "forwardemail"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	cfg := `current_profile: default
+profiles:
+  default:
+    domain_defaults:
+      plan: enhanced_protection
+      retention_days: 30
+      has_delivery_logs: true
+      settings:
+        has_virus_protection: true
+        webhook_url: https://hooks.example.com/mail
+`
+	if err := os.WriteFile(filepath.Join(dir, "forwardemail", "config.yaml"), []byte(cfg), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(mockserver.New(nil))
+	defer srv.Close()
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	t.Cleanup(func() {
+		domainCreateCmd.Flags().VisitAll(func(f *pflag.Flag) {
+			_ = f.Value.Set(f.DefValue)
+			f.Changed = false
+		})
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	})
+
+	create := func(args ...string) (*api.Domain, string) {
+		t.Helper()
+		viper.Reset()
+		bindRootFlags()
+		var buf bytes.Buffer
+		rootCmd.SetOut(&buf)
+		rootCmd.SetErr(&buf)
+		rootCmd.SetArgs(append([]string{"domain", "create"}, args...))
+		var domain api.Domain
+		out := captureStdout(t, func() {
+			if err := rootCmd.Execute(); err != nil {
+				t.Fatalf("domain create: %v\n%s", err, buf.String())
+			}
+		})
+		if err := json.Unmarshal([]byte(out), &domain); err != nil {
+			t.Fatalf("decode %q: %v", out, err)
+		}
+		return &domain, buf.String()
+	}
+
+	domain, out := create("example.com", "-o", "json")
+	if !strings.Contains(out, "Applied domain defaults from profile 'default'") {
+		t.Errorf("expected defaults notice, got:\n%s", out)
+	}
+	if domain.Plan != "enhanced_protection" || domain.RetentionDays != 30 || !domain.HasDeliveryLogs {
+		t.Errorf("defaults not applied: plan=%q retention=%d logs=%v", domain.Plan, domain.RetentionDays, domain.HasDeliveryLogs)
+	}
+	if domain.Settings == nil || !domain.Settings.HasVirusProtection || domain.Settings.WebhookURL != "https://hooks.example.com/mail" {
+		t.Errorf("settings defaults not applied: %+v", domain.Settings)
+	}
+
+	domain, out = create("example.org", "--no-defaults", "--plan", "free", "-o", "json")
+	if strings.Contains(out, "Applied domain defaults") {
+		t.Errorf("--no-defaults should skip the defaults:\n%s", out)
+	}
+	if domain.Plan != "free" || domain.RetentionDays == 30 || domain.HasDeliveryLogs {
+		t.Errorf("--no-defaults applied defaults: %+v", domain)
+	}
+}
+
+func TestDomainDNS_CopyAndFormats(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    verification_record: abc123
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+
+	var copied string
+	origAvailable, origCopy := clipboardAvailable, copyToClipboard
+	clipboardAvailable = func() bool { return true }
+	copyToClipboard = func(s string) error { copied = s; return nil }
+	t.Cleanup(func() {
+		clipboardAvailable, copyToClipboard = origAvailable, origCopy
+		resetCommandFlags(domainDNSCmd)
+	})
+
+	run := func(args ...string) (string, string, error) {
+		resetCommandFlags(domainDNSCmd)
+		var stdout, stderr bytes.Buffer
+		rootCmd.SetOut(&stdout)
+		rootCmd.SetErr(&stderr)
+		rootCmd.SetArgs(append([]string{"domain", "dns", "example.com"}, args...))
+		err := rootCmd.Execute()
+		return stdout.String(), stderr.String(), err
+	}
+
+	stdout, stderr, err := run("--copy", "verification", "--format", "env")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if copied != "forward-email-site-verification=abc123" || !strings.Contains(stderr, "Copied the verification record") {
+		t.Errorf("unexpected copy %q, stderr %q", copied, stderr)
+	}
+	for _, want := range []string{
+		"DNS_MX1='mx1.forwardemail.net'\n",
+		"DNS_VERIFICATION='forward-email-site-verification=abc123'\n",
+		"DNS_DMARC='v=DMARC1; p=quarantine; pct=100'\nDNS_DMARC_NAME='_dmarc'\n",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected %q in env output:\n%s", want, stdout)
+		}
+	}
+
+	stdout, _, err = run("--format", "markdown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(stdout, "| Type | Name | Value |") || !strings.Contains(stdout, "| TXT | @ | `v=spf1 include:spf.forwardemail.net -all` |") {
+		t.Errorf("unexpected markdown output:\n%s", stdout)
+	}
+
+	if _, _, err = run("--copy", "spff"); err == nil || !strings.Contains(err.Error(), "Did you mean 'spf'?") {
+		t.Errorf("expected an unknown record error, got %v", err)
+	}
+	if _, _, err = run("--format", "html"); err == nil || !strings.Contains(err.Error(), "invalid --format") {
+		t.Errorf("expected an invalid format error, got %v", err)
+	}
+}
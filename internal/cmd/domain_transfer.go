@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/i18n"
+	"github.com/ginsys/forward-email/pkg/output"
+	"github.com/ginsys/forward-email/pkg/planner"
+)
+
+// domainTransferCmd represents the domain transfer command
+var domainTransferCmd = &cobra.Command{
+	Use:   "transfer <domain>",
+	Short: "Move a domain and its aliases to another account",
+	Long: `Re-create a domain with its settings and aliases under the account of another
+configured profile, then print the DNS changes needed for the cutover.
+
+The domain is created in the target account first, so mail keeps flowing through the
+current account until the DNS records are switched. Removing the domain from the current
+account is a separate step: once the target account has verified the domain, run the
+command again with --delete-source. It refuses to delete anything while the target
+account does not report the domain as verified.
+
+Alias passwords, IMAP mailboxes and PGP keys are not transferred.
+
+Examples:
+  forward-email domain transfer example.com --to-profile agency-b --dry-run
+  forward-email domain transfer example.com --to-profile agency-b
+  forward-email domain transfer example.com --to-profile agency-b --delete-source --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDomainTransfer,
+}
+
+func init() {
+	domainCmd.AddCommand(domainTransferCmd)
+	domainTransferCmd.Flags().String("to-profile", "", "Profile of the account receiving the domain")
+	domainTransferCmd.Flags().String("plan", "", "Plan in the target account (defaults to the current plan)")
+	domainTransferCmd.Flags().Bool("delete-source", false, "Delete the domain from the current account once the target account has verified it")
+	domainTransferCmd.Flags().Bool("dry-run", false, "Show what would be transferred without changing anything")
+	domainTransferCmd.Flags().BoolP("force", "f", false, "Skip the confirmation prompt for --delete-source")
+	_ = domainTransferCmd.MarkFlagRequired("to-profile")
+}
+
+func runDomainTransfer(cmd *cobra.Command, args []string) error {
+	name := strings.TrimSpace(args[0])
+	toProfile, _ := cmd.Flags().GetString("to-profile")
+	deleteSource, _ := cmd.Flags().GetBool("delete-source")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	_, fromProfile := activeProfile()
+	if toProfile == fromProfile {
+		return fmt.Errorf("--to-profile must differ from the current profile '%s'", fromProfile)
+	}
+
+	ctx := cmd.Context()
+	sourceClient, err := client.NewAPIClient()
+	if err != nil {
+		return err
+	}
+	targetClient, err := client.NewAPIClientForProfile(toProfile)
+	if err != nil {
+		return fmt.Errorf("failed to create client for profile '%s': %w", toProfile, err)
+	}
+	if deleteSource {
+		return deleteTransferSource(cmd, sourceClient, targetClient, name, toProfile, dryRun)
+	}
+
+	source, err := sourceClient.Domains.GetDomain(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to get domain: %w", err)
+	}
+	aliases, err := listAllAliases(ctx, sourceClient, source.Name)
+	if err != nil {
+		return fmt.Errorf("failed to list aliases for %s: %w", source.Name, err)
+	}
+
+	plan := source.Plan
+	if cmd.Flags().Changed("plan") {
+		plan, _ = cmd.Flags().GetString("plan")
+	}
+
+	cmd.PrintErrf("Transfer '%s' (%s plan, %d aliases) to profile '%s'\n", source.Name, plan, len(aliases), toProfile)
+	if dryRun {
+		cmd.PrintErrln("Dry run: no changes made")
+		return nil
+	}
+
+	target, err := targetClient.Domains.CreateDomain(ctx, &api.CreateDomainRequest{Name: source.Name, Plan: plan})
+	if err != nil {
+		return fmt.Errorf("failed to create domain for profile '%s': %w", toProfile, err)
+	}
+	cmd.PrintErrf("Domain '%s' created for profile '%s'\n", target.Name, toProfile)
+
+	updated, err := targetClient.Domains.UpdateDomain(ctx, target.Name, buildCloneRequest(source, target, nil))
+	if err != nil {
+		return transferCopyFailed(cmd, target.Name, toProfile, nil, fmt.Errorf("failed to copy settings: %w", err))
+	}
+	target = updated
+	cmd.PrintErrln("Settings copied")
+	reportMutation(hookResource{Type: "domain", Action: "create", Name: target.Name, ID: target.ID, Data: target})
+
+	// The target is brand new, so a preserve sync only ever plans creates.
+	actions, err := planAliasSync(cmd, "preserve", source.Name, target.Name, aliases, nil)
+	if err != nil {
+		return transferCopyFailed(cmd, target.Name, toProfile, nil, err)
+	}
+	var copied []string
+	prog := newProgress(cmd, "Copying aliases", actions.Len())
+	err = planner.Apply(ctx, actions, planner.APIExecutor{Aliases: targetClient.Aliases}, planner.ApplyHooks{
+		Skip: func(a planner.Action) bool {
+			prog.Item(string(a.Type) + " " + a.Name + "@" + a.Domain)
+			return false
+		},
+		Done: func(a planner.Action, id string) error {
+			copied = append(copied, a.Name)
+			reportMutation(actionResource(a, id))
+			return nil
+		},
+	})
+	prog.Finish()
+	if err != nil {
+		return transferCopyFailed(cmd, target.Name, toProfile, copied, err)
+	}
+	cmd.PrintErrf("Copied %d aliases\n", len(copied))
+
+	printTransferCutover(cmd, source, target, toProfile, fromProfile)
+
+	return formatOutput(target, viper.GetString("output"), func(format output.Format) (interface{}, error) {
+		if format.Tabular() {
+			return output.FormatDomainDetails(target, format)
+		}
+		return target, nil
+	})
+}
+
+// deleteTransferSource removes a transferred domain from the current account,
+// but only once the target account reports it as verified: until then the
+// current account may still be the one receiving the domain's mail.
+func deleteTransferSource(cmd *cobra.Command, sourceClient, targetClient *api.Client, name, toProfile string, dryRun bool) error {
+	ctx := cmd.Context()
+	target, err := targetClient.Domains.VerifyDomain(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to check '%s' in profile '%s': %w", name, toProfile, err)
+	}
+	if !target.IsVerified {
+		return fmt.Errorf("'%s' is not verified in profile '%s' yet; complete the DNS cutover and run "+
+			"'forward-email domain verify %s --profile %s' before deleting the source", target.Name, toProfile, target.Name, toProfile)
+	}
+
+	cmd.PrintErrf("'%s' is verified in profile '%s'; it will be deleted from the current account\n", target.Name, toProfile)
+	if dryRun {
+		cmd.PrintErrln("Dry run: no changes made")
+		return nil
+	}
+	ok, err := confirm(cmd, i18n.T("Delete '%s' from the current account?", target.Name))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		cmd.PrintErrln(i18n.T("Domain transfer canceled"))
+		return nil
+	}
+	if err := sourceClient.Domains.DeleteDomain(ctx, target.Name); err != nil {
+		return fmt.Errorf("failed to delete domain from the current account: %w", err)
+	}
+	reportMutation(hookResource{Type: "domain", Action: "delete", Name: target.Name})
+	cmd.PrintErrf("Domain '%s' deleted from the current account\n", target.Name)
+	return nil
+}
+
+// transferCopyFailed reports what a failed transfer left behind in the target
+// account, so it can be completed by hand or removed, and returns err.
+func transferCopyFailed(cmd *cobra.Command, domain, toProfile string, copied []string, err error) error {
+	cmd.PrintErrln()
+	cmd.PrintErrf("Transfer incomplete: '%s' exists in profile '%s' with %d copied aliases\n", domain, toProfile, len(copied))
+	for _, name := range copied {
+		cmd.PrintErrf("  %s@%s\n", name, domain)
+	}
+	cmd.PrintErrln("The current account is unchanged. To start over, remove the partial copy with:")
+	cmd.PrintErrf("  forward-email domain delete %s --profile %s\n", domain, toProfile)
+	cmd.PrintErrln()
+	return err
+}
+
+// printTransferCutover prints the DNS and follow-up steps that complete a
+// transfer. MX, SPF and DMARC records are the same for every account; the
+// verification TXT record and the DKIM and return-path records belong to the
+// account and change.
+func printTransferCutover(cmd *cobra.Command, source, target *api.Domain, toProfile, fromProfile string) {
+	cmd.PrintErrln()
+	cmd.PrintErrln("Cutover:")
+	cmd.PrintErrf("  1. In the DNS for %s, replace the TXT record\n", source.Name)
+	cmd.PrintErrf("       forward-email-site-verification=%s\n", source.VerificationRecord)
+	cmd.PrintErrf("     with\n")
+	cmd.PrintErrf("       forward-email-site-verification=%s\n", target.VerificationRecord)
+	cmd.PrintErrln("     Also replace the account-specific records with the values shown for the new account:")
+	cmd.PrintErrf("       DKIM TXT %s\n", transferRecordName(target.DKIMKeySelector, "._domainkey", "<selector>._domainkey"))
+	cmd.PrintErrf("       return-path CNAME %s\n", transferRecordName(target.ReturnPath, "", "<return-path>"))
+	cmd.PrintErrln("     MX, SPF and DMARC records stay unchanged.")
+	cmd.PrintErrf("  2. Once DNS has propagated, verify the domain in the new account:\n")
+	cmd.PrintErrf("       forward-email domain verify %s --profile %s\n", target.Name, toProfile)
+	profileFlag := ""
+	if fromProfile != "" {
+		profileFlag = " --profile " + fromProfile
+	}
+	cmd.PrintErrln("  3. Then remove the domain from the current account:")
+	cmd.PrintErrf("       forward-email domain transfer %s --to-profile %s --delete-source%s\n", source.Name, toProfile, profileFlag)
+	cmd.PrintErrln()
+}
+
+// transferRecordName returns the DNS name of an account-specific record, or
+// placeholder when the API did not report it.
+func transferRecordName(value, suffix, placeholder string) string {
+	if value == "" {
+		return placeholder
+	}
+	return value + suffix
+}
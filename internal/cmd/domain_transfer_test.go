@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+)
+
+func TestDomainTransfer(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	if err := os.MkdirAll(filepath.Join(dir, "forwardemail"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	cfg := "current_profile: agency-a\nprofiles:\n  agency-a: {}\n  agency-b: {}\n"
+	if err := os.WriteFile(filepath.Join(dir, "forwardemail", "config.yaml"), []byte(cfg), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    plan: enhanced_protection
+    retention_days: 14
+    aliases:
+      - name: info
+        recipients: [me@example.org]
+      - name: sales
+        recipients: [team@example.org]
+        labels: [team]
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	source := httptest.NewServer(mockserver.New(seed))
+	defer source.Close()
+	target := httptest.NewServer(mockserver.New(nil))
+	defer target.Close()
+
+	client.SetTestMode(source.URL, auth.MockProvider("test"))
+	client.SetTestProfile("agency-b", target.URL)
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	resetFlags := func() {
+		domainTransferCmd.Flags().VisitAll(func(f *pflag.Flag) {
+			_ = f.Value.Set(f.DefValue)
+			f.Changed = false
+		})
+	}
+	t.Cleanup(func() {
+		resetFlags()
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	})
+
+	run := func(args ...string) string {
+		t.Helper()
+		resetFlags()
+		var buf bytes.Buffer
+		rootCmd.SetOut(&buf)
+		rootCmd.SetErr(&buf)
+		rootCmd.SetArgs(append([]string{"domain", "transfer", "example.com", "-o", "json"}, args...))
+		captureStdout(t, func() {
+			if err := rootCmd.Execute(); err != nil {
+				t.Fatalf("domain transfer: %v\n%s", err, buf.String())
+			}
+		})
+		return buf.String()
+	}
+
+	out := run("--to-profile", "agency-b", "--dry-run")
+	if !strings.Contains(out, "Transfer 'example.com' (enhanced_protection plan, 2 aliases) to profile 'agency-b'") {
+		t.Errorf("unexpected dry-run output:\n%s", out)
+	}
+
+	out = run("--to-profile", "agency-b")
+	for _, want := range []string{"Copied 2 aliases", "DKIM TXT", "return-path CNAME",
+		"domain verify example.com --profile agency-b",
+		"domain transfer example.com --to-profile agency-b --delete-source"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+
+	ctx := context.Background()
+	src, _ := client.NewAPIClient()
+	if _, err := src.Domains.GetDomain(ctx, "example.com"); err != nil {
+		t.Errorf("source domain should be kept until the target is verified: %v", err)
+	}
+	dst, _ := client.NewAPIClientForProfile("agency-b")
+	moved, err := dst.Domains.GetDomain(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("target domain: %v", err)
+	}
+	if moved.Plan != "enhanced_protection" || moved.RetentionDays != 14 {
+		t.Errorf("settings not copied: plan=%q retention=%d", moved.Plan, moved.RetentionDays)
+	}
+	if aliases, _ := listAllAliases(ctx, dst, "example.com"); len(aliases) != 2 {
+		t.Errorf("expected 2 aliases in the target account, got %d", len(aliases))
+	}
+
+	// The target has not verified the domain, so the source must not be deleted.
+	resetFlags()
+	rootCmd.SetArgs([]string{"domain", "transfer", "example.com", "--to-profile", "agency-b", "--delete-source", "--force"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "not verified in profile 'agency-b'") {
+		t.Fatalf("expected unverified target to block deletion, got %v", err)
+	}
+	if _, err := src.Domains.GetDomain(ctx, "example.com"); err != nil {
+		t.Errorf("source domain deleted although the target is unverified: %v", err)
+	}
+
+	verifiedSeed, err := mockserver.ParseSeed([]byte("domains:\n  - name: example.com\n    is_verified: true\n"))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	verified := httptest.NewServer(mockserver.New(verifiedSeed))
+	defer verified.Close()
+	client.SetTestProfile("agency-b", verified.URL)
+	out = run("--to-profile", "agency-b", "--delete-source", "--force")
+	if !strings.Contains(out, "Domain 'example.com' deleted from the current account") {
+		t.Errorf("unexpected delete-source output:\n%s", out)
+	}
+	if _, err := src.Domains.GetDomain(ctx, "example.com"); err == nil {
+		t.Error("source domain should have been deleted")
+	}
+}
+
+func TestTransferCopyFailed_ReportsCopiedAliases(t *testing.T) {
+	var buf bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetErr(&buf)
+	want := errors.New("boom")
+	if err := transferCopyFailed(cmd, "example.com", "agency-b", []string{"info", "sales"}, want); err != want {
+		t.Fatalf("expected the original error, got %v", err)
+	}
+	for _, s := range []string{"2 copied aliases", "info@example.com", "sales@example.com",
+		"forward-email domain delete example.com --profile agency-b"} {
+		if !strings.Contains(buf.String(), s) {
+			t.Errorf("expected %q in report:\n%s", s, buf.String())
+		}
+	}
+}
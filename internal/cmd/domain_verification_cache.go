@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/output"
+	"github.com/ginsys/forward-email/pkg/units"
+)
+
+// defaultVerificationTTL is how long 'domain list --with-verification' shows a
+// domain's last verification before checking its DNS records again.
+const defaultVerificationTTL = 15 * time.Minute
+
+var (
+	domainListWithVerification bool
+	domainListRefresh          bool
+	domainListVerificationTTL  = units.Duration(defaultVerificationTTL)
+)
+
+func init() {
+	domainListCmd.Flags().BoolVar(&domainListWithVerification, "with-verification", false,
+		"Add each domain's DNS verification status, from the snapshot cache while it is fresh")
+	domainListCmd.Flags().BoolVar(&domainListRefresh, "refresh", false,
+		"Verify every listed domain again instead of using cached snapshots (needs --with-verification)")
+	domainListCmd.Flags().Var(&domainListVerificationTTL, "verification-ttl",
+		"How long a verification snapshot is used before the domain is verified again (e.g. 15m, 1h)")
+}
+
+// verificationSnapshots holds the last verify-records result of each domain,
+// per profile, in the user's cache directory. Only successful checks are
+// kept, so that a failed one is retried on the next run.
+type verificationSnapshots struct {
+	path     string
+	Profiles map[string]map[string]output.DomainHealth `json:"profiles"`
+}
+
+// loadVerificationSnapshots reads the snapshot file. A missing or unreadable
+// file gives an empty set, as the snapshots only save API calls. With
+// --no-cache, or without a cache directory, snapshots are neither read nor
+// saved.
+func loadVerificationSnapshots() *verificationSnapshots {
+	s := &verificationSnapshots{Profiles: map[string]map[string]output.DomainHealth{}}
+	if viper.GetBool("no_cache") {
+		return s
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return s
+	}
+	s.path = filepath.Join(dir, "forwardemail", "verification.json")
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return s
+	}
+	if err := json.Unmarshal(data, s); err != nil || s.Profiles == nil {
+		s.Profiles = map[string]map[string]output.DomainHealth{}
+	}
+	return s
+}
+
+func (s *verificationSnapshots) get(profile, domain string) (output.DomainHealth, bool) {
+	h, ok := s.Profiles[profile][strings.ToLower(domain)]
+	return h, ok
+}
+
+func (s *verificationSnapshots) put(profile string, h output.DomainHealth) {
+	if h.Error != "" {
+		return
+	}
+	if s.Profiles[profile] == nil {
+		s.Profiles[profile] = map[string]output.DomainHealth{}
+	}
+	s.Profiles[profile][strings.ToLower(h.Domain)] = h
+}
+
+// save writes the snapshots back, readable by the owner only.
+func (s *verificationSnapshots) save() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// recordVerification stores fresh verification results of the active
+// profile, so that 'domain list --with-verification' can show them. Errors
+// are ignored like those of the response cache.
+func recordVerification(health ...output.DomainHealth) {
+	_, profile := activeProfile()
+	s := loadVerificationSnapshots()
+	for _, h := range health {
+		s.put(profile, h)
+	}
+	_ = s.save()
+}
+
+// domainVerificationStatus is a domain's verification as shown by 'domain
+// list --with-verification': the snapshot, whether it came from the cache,
+// and its age when listed.
+type domainVerificationStatus struct {
+	output.DomainHealth `yaml:",inline"`
+	Cached              bool  `json:"cached" yaml:"cached"`
+	AgeSeconds          int64 `json:"age_seconds" yaml:"age_seconds"`
+}
+
+// domainListEntry is a domain listed together with its verification status.
+type domainListEntry struct {
+	api.Domain   `yaml:",inline"`
+	Verification domainVerificationStatus `json:"verification" yaml:"verification"`
+}
+
+// domainVerifications returns the verification status of each domain, in
+// order. Snapshots younger than ttl are used as they are; other domains, or
+// all of them with refresh, are verified again and their snapshots replaced.
+// A domain that fails to verify is reported in its Error field.
+func domainVerifications(ctx context.Context, cmd *cobra.Command, domains *api.DomainService, names []string,
+	ttl time.Duration, refresh bool) []domainVerificationStatus {
+	_, profile := activeProfile()
+	snapshots := loadVerificationSnapshots()
+	now := time.Now()
+
+	statuses := make([]domainVerificationStatus, len(names))
+	var stale []int
+	for i, name := range names {
+		if h, ok := snapshots.get(profile, name); ok && !refresh && now.Sub(h.LastChecked) < ttl {
+			statuses[i] = domainVerificationStatus{DomainHealth: h, Cached: true, AgeSeconds: int64(now.Sub(h.LastChecked) / time.Second)}
+			continue
+		}
+		stale = append(stale, i)
+	}
+	if len(stale) == 0 {
+		return statuses
+	}
+
+	progress := newProgress(cmd, "Verifying domains", len(stale))
+	for _, i := range stale {
+		domain, err := domains.VerifyDomain(ctx, names[i])
+		progress.Item(names[i])
+		if err != nil {
+			statuses[i] = domainVerificationStatus{DomainHealth: output.DomainHealth{
+				Domain: names[i], Error: err.Error(), MissingRecords: []string{}, LastChecked: time.Now().UTC(),
+			}}
+			continue
+		}
+		statuses[i] = domainVerificationStatus{DomainHealth: output.NewDomainHealth(domain, time.Now())}
+		snapshots.put(profile, statuses[i].DomainHealth)
+	}
+	progress.Finish()
+	if err := snapshots.save(); err != nil {
+		cmd.PrintErrf("⚠️  Failed to save verification snapshots: %v\n", err)
+	}
+	return statuses
+}
+
+// addDomainVerificationColumns appends the DNS status and the age of its
+// check to a domain table whose rows follow statuses.
+func addDomainVerificationColumns(table *output.TableData, statuses []domainVerificationStatus) {
+	table.Headers = append(table.Headers, "DNS", "CHECKED")
+	for i := range table.Rows {
+		s := statuses[i]
+		dns := "ok"
+		switch {
+		case s.Error != "":
+			dns = "error: " + s.Error
+		case len(s.MissingRecords) > 0:
+			dns = "missing " + strings.Join(s.MissingRecords, ", ")
+		}
+		checked := "now"
+		if s.Cached {
+			checked = units.FormatDuration(time.Duration(s.AgeSeconds)*time.Second) + " ago"
+		}
+		table.Rows[i] = append(table.Rows[i], dns, checked)
+	}
+}
+
+// checkVerificationFlags rejects --refresh without --with-verification, and
+// --with-verification where the domains are not listed as a table or document.
+func checkVerificationFlags(cmd *cobra.Command, allProfiles, envelope bool) error {
+	if domainListRefresh && !domainListWithVerification {
+		return errors.New("--refresh needs --with-verification")
+	}
+	if cmd.Flags().Changed("verification-ttl") && !domainListWithVerification {
+		return errors.New("--verification-ttl needs --with-verification")
+	}
+	if domainListWithVerification && (allProfiles || envelope) {
+		return fmt.Errorf("cannot use --with-verification with --all-profiles or --envelope")
+	}
+	return nil
+}
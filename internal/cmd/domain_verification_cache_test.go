@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+)
+
+func TestDomainList_WithVerification(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: good.com
+    is_verified: true
+    has_mx_record: true
+    has_txt_record: true
+    has_spf_record: true
+    has_dkim_record: true
+    has_dmarc_record: true
+  - name: pending.org
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	var verifications atomic.Int32
+	mock := mockserver.New(seed)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/verify-records") {
+			verifications.Add(1)
+		}
+		mock.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetCommandFlags(domainListCmd)
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	})
+
+	run := func(args ...string) (string, string) {
+		t.Helper()
+		resetCommandFlags(domainListCmd)
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+		var stderr bytes.Buffer
+		rootCmd.SetOut(&stderr)
+		rootCmd.SetErr(&stderr)
+		rootCmd.SetArgs(append([]string{"domain", "list"}, args...))
+		stdout := captureStdout(t, func() {
+			if err := rootCmd.Execute(); err != nil {
+				t.Fatalf("domain list %v: %v\n%s", args, err, stderr.String())
+			}
+		})
+		return stdout, stderr.String()
+	}
+
+	stdout, _ := run("--with-verification")
+	if verifications.Load() != 2 {
+		t.Errorf("expected both domains to be verified, got %d calls", verifications.Load())
+	}
+	if !strings.Contains(stdout, "CHECKED") || !strings.Contains(stdout, "missing MX") {
+		t.Errorf("expected the DNS status columns, got:\n%s", stdout)
+	}
+
+	stdout, _ = run("--with-verification", "-o", "json")
+	if verifications.Load() != 2 {
+		t.Errorf("expected fresh snapshots to be used, got %d calls", verifications.Load())
+	}
+	var entries []struct {
+		Name         string                   `json:"name"`
+		Verification domainVerificationStatus `json:"verification"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &entries); err != nil {
+		t.Fatalf("decode %q: %v", stdout, err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected two domains, got %s", stdout)
+	}
+	for _, e := range entries {
+		if !e.Verification.Cached || e.Verification.Verified != (e.Name == "good.com") || e.Verification.Domain != e.Name {
+			t.Errorf("unexpected verification for %s: %+v", e.Name, e.Verification)
+		}
+	}
+
+	run("--with-verification", "--refresh")
+	if verifications.Load() != 4 {
+		t.Errorf("expected --refresh to verify both domains, got %d calls", verifications.Load())
+	}
+	run("--with-verification", "--verification-ttl", "0s")
+	if verifications.Load() != 6 {
+		t.Errorf("expected expired snapshots to be refreshed, got %d calls", verifications.Load())
+	}
+	run()
+	if verifications.Load() != 6 {
+		t.Errorf("expected a plain list not to verify, got %d calls", verifications.Load())
+	}
+
+	resetCommandFlags(domainListCmd)
+	rootCmd.SetArgs([]string{"domain", "list", "--refresh"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "--with-verification") {
+		t.Errorf("expected --refresh alone to be refused, got %v", err)
+	}
+}
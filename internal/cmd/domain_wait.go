@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ginsys/forward-email/pkg/api"
+	fe "github.com/ginsys/forward-email/pkg/errors"
+	"github.com/ginsys/forward-email/pkg/output"
+	"github.com/ginsys/forward-email/pkg/units"
+	"github.com/ginsys/forward-email/pkg/waiter"
+)
+
+// verifyBackoff is the delay before the second verification check, doubled
+// after every further check up to verifyBackoffMax. Tests shorten both.
+var (
+	verifyBackoff    = 10 * time.Second
+	verifyBackoffMax = 5 * time.Minute
+)
+
+const defaultVerifyWait = 30 * time.Minute
+
+func init() {
+	domainVerifyCmd.Flags().Bool("wait", false, "Re-check with exponential backoff until the domain is verified")
+	domainCreateCmd.Flags().Bool("verify", false, "Print the DNS records, then wait until the domain is verified")
+	for _, c := range []*cobra.Command{domainVerifyCmd, domainCreateCmd} {
+		addWaitFlags(c, verifyBackoff, defaultVerifyWait)
+	}
+}
+
+// addWaitFlags adds the flags shared by commands that wait for something
+// outside the CLI: --interval, the delay before the second check, and
+// --wait-timeout. The global --timeout bounds single API requests instead.
+func addWaitFlags(cmd *cobra.Command, interval, timeout time.Duration) {
+	i, t := units.Duration(interval), units.Duration(timeout)
+	cmd.Flags().Var(&i, "interval", "Delay before the second check, doubled after each further one (e.g. 10s, 1m)")
+	cmd.Flags().Var(&t, "wait-timeout", "Give up waiting after this long (e.g. 30m, 2h)")
+}
+
+// waitOptions returns the waiter options for the wait flags of cmd. backoff
+// and backoffMax apply while --interval is left at its default.
+func waitOptions(cmd *cobra.Command, backoff, backoffMax time.Duration) waiter.Options {
+	opts := waiter.Options{Interval: backoff, MaxInterval: backoffMax, Timeout: defaultVerifyWait}
+	if f := cmd.Flags().Lookup("interval"); f != nil && f.Changed {
+		if v, ok := f.Value.(*units.Duration); ok {
+			opts.Interval = time.Duration(*v)
+			opts.MaxInterval = max(opts.Interval, backoffMax)
+		}
+	}
+	if f := cmd.Flags().Lookup("wait-timeout"); f != nil {
+		if v, ok := f.Value.(*units.Duration); ok {
+			opts.Timeout = time.Duration(*v)
+		}
+	}
+	return opts
+}
+
+// waitForVerification re-verifies the domain until the API reports it
+// verified, waiting --interval after the first check and twice as long after
+// each further one, up to verifyBackoffMax. DNS changes take minutes to hours
+// to propagate, so transient API errors are reported and retried too. It gives
+// up once --wait-timeout has passed; when interrupted it reports how far it
+// got.
+func waitForVerification(cmd *cobra.Command, domains *api.DomainService, name string) (*api.Domain, error) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	opts := waitOptions(cmd, verifyBackoff, verifyBackoffMax)
+	if opts.Interval <= 0 {
+		return nil, fmt.Errorf("--interval must be positive")
+	}
+	missing := "unknown"
+	opts.Retryable = fe.IsRetryable
+	opts.OnRetry = func(_ int, err error, _ time.Duration) {
+		cmd.PrintErrf("⚠️  Verification check failed: %v\n", err)
+	}
+	opts.OnPending = func(_ int, next time.Duration) {
+		cmd.PrintErrf("⏳ %s not verified yet (missing: %s); checking again in %s\n", name, missing, units.FormatDuration(next.Round(time.Second)))
+	}
+
+	var verified *api.Domain
+	res, err := waiter.Wait(ctx, opts, func(ctx context.Context, _ int) (bool, error) {
+		stop := startSpinner(cmd, "Checking DNS records for "+name)
+		defer stop()
+		reqCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+		domain, err := domains.VerifyDomain(reqCtx, name)
+		if err != nil {
+			return false, err
+		}
+		if domain.IsVerified {
+			verified = domain
+			return true, nil
+		}
+		if records := output.NewDomainHealth(domain, time.Now()).MissingRecords; len(records) > 0 {
+			missing = strings.Join(records, ", ")
+		}
+		return false, nil
+	})
+
+	var interrupted *waiter.Interrupted
+	switch {
+	case err == nil:
+		return verified, nil
+	case errors.Is(err, waiter.ErrTimeout):
+		return nil, fmt.Errorf("domain %s not verified after %s (missing: %s)", name, units.FormatDuration(opts.Timeout), missing)
+	case errors.As(err, &interrupted):
+		cmd.PrintErrf("Stopped waiting for %s after %d check(s) in %s; still missing: %s\n",
+			name, res.Attempts, units.FormatDuration(res.Elapsed.Round(time.Second)), missing)
+		return nil, interrupted.Err
+	}
+	return nil, err
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/auth"
+)
+
+// verifyingServer serves a domain whose DNS checks pass from the verifiedAt-th
+// verify-records request on; 0 means never.
+func verifyingServer(t *testing.T, verifiedAt int32) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var checks atomic.Int32
+	domain := func() api.Domain {
+		ok := verifiedAt > 0 && checks.Load() >= verifiedAt
+		return api.Domain{ID: "d1", Name: "example.com", VerificationRecord: "abc123",
+			IsVerified: ok, HasMXRecord: ok, HasTXTRecord: true}
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/domains", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(domain())
+	})
+	mux.HandleFunc("GET /v1/domains/example.com", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(domain())
+	})
+	mux.HandleFunc("GET /v1/domains/example.com/verify-records", func(w http.ResponseWriter, _ *http.Request) {
+		checks.Add(1)
+		if !domain().IsVerified {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		_ = json.NewEncoder(w).Encode(domain())
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+
+	origBackoff, origMax := verifyBackoff, verifyBackoffMax
+	verifyBackoff, verifyBackoffMax = time.Millisecond, 4*time.Millisecond
+	t.Cleanup(func() {
+		verifyBackoff, verifyBackoffMax = origBackoff, origMax
+		resetCommandFlags(domainCreateCmd)
+		resetCommandFlags(domainVerifyCmd)
+	})
+	return srv, &checks
+}
+
+func TestDomainCreate_Verify(t *testing.T) {
+	_, checks := verifyingServer(t, 3)
+
+	var stdout, stderr bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetArgs([]string{"domain", "create", "example.com", "--verify"})
+	out := captureStdout(t, func() {
+		if err := rootCmd.Execute(); err != nil {
+			t.Errorf("domain create --verify: %v\n%s", err, stderr.String())
+		}
+	})
+
+	if checks.Load() != 3 {
+		t.Errorf("expected 3 verification checks, got %d", checks.Load())
+	}
+	errOut := stderr.String()
+	for _, want := range []string{"Add these DNS records for example.com", "forward-email-site-verification=abc123",
+		"example.com not verified yet (missing: MX, SPF, DKIM, DMARC)", "DNS records verified"} {
+		if !strings.Contains(errOut, want) {
+			t.Errorf("expected %q on stderr:\n%s", want, errOut)
+		}
+	}
+	if strings.Count(errOut, "not verified yet") != 2 {
+		t.Errorf("expected two waits, got:\n%s", errOut)
+	}
+	if !strings.Contains(out+stdout.String(), "example.com") {
+		t.Errorf("expected the verified domain on stdout, got %q", out+stdout.String())
+	}
+}
+
+func TestDomainVerify_WaitTimesOut(t *testing.T) {
+	verifyingServer(t, 0)
+
+	var stderr bytes.Buffer
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetArgs([]string{"domain", "verify", "example.com", "--wait", "--wait-timeout", "20ms"})
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "example.com not verified after 20ms (missing: MX, SPF, DKIM, DMARC)") {
+		t.Errorf("expected a timeout error, got %v", err)
+	}
+}
+
+func TestDomainVerify_WaitInterrupted(t *testing.T) {
+	_, checks := verifyingServer(t, 0)
+	verifyBackoff, verifyBackoffMax = time.Hour, time.Hour
+
+	// Subcommands keep the context of the first run, so set it directly
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	domainVerifyCmd.SetContext(ctx)
+	t.Cleanup(func() { domainVerifyCmd.SetContext(context.Background()) })
+	go func() {
+		for checks.Load() < 1 {
+			time.Sleep(time.Millisecond)
+		}
+		cancel()
+	}()
+
+	var stderr bytes.Buffer
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetArgs([]string{"domain", "verify", "example.com", "--wait"})
+	err := rootCmd.Execute()
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the wait to be canceled, got %v", err)
+	}
+	if !strings.Contains(stderr.String(), "Stopped waiting for example.com after 1 check(s)") ||
+		!strings.Contains(stderr.String(), "still missing: MX, SPF, DKIM, DMARC") {
+		t.Errorf("expected a progress summary, got:\n%s", stderr.String())
+	}
+}
+
+func TestDomainVerify_WaitInterval(t *testing.T) {
+	verifyingServer(t, 2)
+
+	var stderr bytes.Buffer
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetArgs([]string{"domain", "verify", "example.com", "--wait", "--interval", "2s", "--wait-timeout", "10ms"})
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("verify --wait: %v", err)
+	}
+	// The delay is cut short to fit the timeout
+	if !strings.Contains(stderr.String(), "checking again in 0s") {
+		t.Errorf("expected the delay to fit the timeout, got:\n%s", stderr.String())
+	}
+}
@@ -5,9 +5,13 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"io"
+	"mime"
+	"net/http"
 	"net/mail"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -17,7 +21,9 @@ import (
 
 	"github.com/ginsys/forward-email/internal/client"
 	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/i18n"
 	"github.com/ginsys/forward-email/pkg/output"
+	"github.com/ginsys/forward-email/pkg/units"
 )
 
 var (
@@ -31,7 +37,12 @@ var (
 	emailTo        string
 	emailDateFrom  string
 	emailDateTo    string
+	emailSince     string
+	emailUntil     string
 	emailHasAttach string
+	emailColumns   string
+	emailFullSubj  bool
+	emailMaxWidth  int
 
 	// Send flags
 	emailFromAddr    string
@@ -45,8 +56,12 @@ var (
 	emailHTMLFile    string
 	emailHeaders     []string
 	emailAttachments []string
+	emailAttachTypes []string
+	emailAttachNames []string
 	emailInteractive bool
 	emailDryRun      bool
+	emailAllowDup    bool
+	emailDupWindow   = units.Duration(10 * time.Minute)
 )
 
 // emailCmd represents the email command
@@ -62,42 +77,63 @@ var emailSendCmd = &cobra.Command{
 	Use:   "send",
 	Short: "Send an email",
 	Long: `Send an email through Forward Email. Can be used interactively or with flags.
-If no flags are provided, interactive mode will be used.`,
+If no flags are provided, interactive mode will be used.
+
+An HTML-only email also gets a plain-text part generated from the HTML, with
+link targets kept in parentheses, unless --no-auto-text is given. --markdown
+and --markdown-file render both parts from Markdown instead.`,
+	Example: `  forward-email email send --from me@example.com --to you@example.org --subject Hi --html-file news.html
+  forward-email email send --from me@example.com --to you@example.org --subject Hi --markdown-file notes.md`,
 	RunE: runEmailSend,
 }
 
 // emailListCmd represents the email list command
 var emailListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List sent emails",
-	Long:  `List emails that have been sent through your Forward Email account.`,
-	RunE:  runEmailList,
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List sent emails",
+	Long:    `List emails that have been sent through your Forward Email account.`,
+	RunE:    runEmailList,
 }
 
 // emailGetCmd represents the email get command
 var emailGetCmd = &cobra.Command{
 	Use:   "get <email-id>",
 	Short: "Get email details",
-	Long:  `Get detailed information about a specific sent email.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runEmailGet,
+	Long: `Get detailed information about a specific sent email.
+
+--headers shows the full message headers, for example to inspect DKIM and SPF
+results. --save-eml saves the message source for other tools; when the API
+does not return the source, a message rebuilt from the headers and body is
+saved instead.`,
+	Example: `  forward-email email get 64f1c2 --headers
+  forward-email email get 64f1c2 --headers -o json
+  forward-email email get 64f1c2 --save-eml message.eml
+  forward-email email get 64f1c2 --save-eml - | grep -i '^Authentication-Results'`,
+	Args: cobra.ExactArgs(1),
+	RunE: runEmailGet,
 }
 
 // emailDeleteCmd represents the email delete command
 var emailDeleteCmd = &cobra.Command{
-	Use:   "delete <email-id>",
-	Short: "Delete an email",
-	Long:  `Delete an email from your sent email history.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runEmailDelete,
+	Use:     "delete <email-id>",
+	Aliases: []string{"rm"},
+	Short:   "Delete an email",
+	Long:    `Delete an email from your sent email history.`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runEmailDelete,
 }
 
 // emailQuotaCmd represents the email quota command
 var emailQuotaCmd = &cobra.Command{
 	Use:   "quota",
 	Short: "Show email quota",
-	Long:  `Show your daily email sending quota and usage.`,
-	RunE:  runEmailQuota,
+	Long: `Show your daily email sending quota and usage.
+
+With --forecast, estimate from today's sending rate how many emails will have
+been sent by the next reset, and when the quota runs out if that happens
+first.`,
+	RunE: runEmailQuota,
 }
 
 func init() {
@@ -116,22 +152,29 @@ func init() {
 	// List command flags
 	emailListCmd.Flags().IntVar(&emailPage, "page", 1, "Page number")
 	emailListCmd.Flags().IntVar(&emailLimit, "limit", 25, "Number of emails per page")
-	emailListCmd.Flags().StringVar(&emailSort, "sort", "sent_at", "Sort by (sent_at, subject, from, to)")
+	emailListCmd.Flags().StringVar(&emailSort, "sort", "sent_at", "Sort by ("+strings.Join(emailSortFields, ", ")+")")
 	emailListCmd.Flags().StringVar(&emailOrder, "order", "desc", "Sort order (asc, desc)")
 	emailListCmd.Flags().StringVar(&emailSearch, "search", "", "Search in subject, from, to")
-	emailListCmd.Flags().StringVar(&emailStatus, "status", "", "Filter by status (sent, delivered, bounced, failed)")
+	emailListCmd.Flags().StringVar(&emailStatus, "status", "", "Filter by status ("+emailStatusList()+")")
 	emailListCmd.Flags().StringVar(&emailFrom, "from", "", "Filter by sender")
 	emailListCmd.Flags().StringVar(&emailTo, "to", "", "Filter by recipient")
-	emailListCmd.Flags().StringVar(&emailDateFrom, "date-from", "", "Filter by date from (YYYY-MM-DD)")
-	emailListCmd.Flags().StringVar(&emailDateTo, "date-to", "", "Filter by date to (YYYY-MM-DD)")
+	emailListCmd.Flags().StringVar(&emailSince, "since", "", "Only emails sent since this date (YYYY-MM-DD, today, yesterday, or a span such as 7d)")
+	emailListCmd.Flags().StringVar(&emailUntil, "until", "", "Only emails sent up to this date (same forms as --since)")
+	emailListCmd.Flags().StringVar(&emailDateFrom, "date-from", "", "Alias for --since")
+	emailListCmd.Flags().StringVar(&emailDateTo, "date-to", "", "Alias for --until")
 	emailListCmd.Flags().StringVar(&emailHasAttach, "has-attach", "", "Filter by attachment presence (true/false)")
+	emailListCmd.Flags().StringVar(&emailColumns, "columns", "",
+		"Columns to display ("+strings.Join(output.EmailListColumns, ",")+")")
+	emailListCmd.Flags().BoolVar(&emailFullSubj, "full-subject", false, "Do not shorten subjects in table output")
+	emailListCmd.Flags().IntVar(&emailMaxWidth, "max-col-width", 0,
+		"Maximum table column width; longer values wrap or are shortened (default: automatic)")
 
 	// Send command flags
 	emailSendCmd.Flags().BoolVarP(&emailInteractive, "interactive", "i", false, "Use interactive mode")
 	emailSendCmd.Flags().StringVar(&emailFromAddr, "from", "", "Sender email address")
-	emailSendCmd.Flags().StringSliceVar(&emailToAddrs, "to", nil, "Recipient email addresses")
-	emailSendCmd.Flags().StringSliceVar(&emailCCAddrs, "cc", nil, "CC email addresses")
-	emailSendCmd.Flags().StringSliceVar(&emailBCCAddrs, "bcc", nil, "BCC email addresses")
+	emailAddressListVar(emailSendCmd.Flags(), &emailToAddrs, "to", "Recipient email addresses")
+	emailAddressListVar(emailSendCmd.Flags(), &emailCCAddrs, "cc", "CC email addresses")
+	emailAddressListVar(emailSendCmd.Flags(), &emailBCCAddrs, "bcc", "BCC email addresses")
 	emailSendCmd.Flags().StringVar(&emailSubject, "subject", "", "Email subject")
 	emailSendCmd.Flags().StringVar(&emailText, "text", "", "Plain text content")
 	emailSendCmd.Flags().StringVar(&emailHTML, "html", "", "HTML content")
@@ -139,7 +182,19 @@ func init() {
 	emailSendCmd.Flags().StringVar(&emailHTMLFile, "html-file", "", "File containing HTML content")
 	emailSendCmd.Flags().StringSliceVar(&emailHeaders, "header", nil, "Custom headers (format: 'Name: Value')")
 	emailSendCmd.Flags().StringSliceVar(&emailAttachments, "attach", nil, "Attachment file paths")
+	emailSendCmd.Flags().StringArrayVar(&emailAttachTypes, "attach-type", nil,
+		"Override an attachment's detected MIME type as <file>=<type>, e.g. data.bin=application/x-foo")
+	emailSendCmd.Flags().StringArrayVar(&emailAttachNames, "attach-name", nil,
+		"Send an attachment under another name as <file>=<name>")
 	emailSendCmd.Flags().BoolVar(&emailDryRun, "dry-run", false, "Validate email without sending")
+	emailSendCmd.Flags().BoolP("yes", "y", false, "Send without confirmation")
+	emailSendCmd.Flags().BoolVar(&emailAllowDup, "allow-duplicate", false,
+		"Send even if an identical email was sent within --duplicate-window")
+	emailSendCmd.Flags().Var(&emailDupWindow, "duplicate-window",
+		"Refuse to resend an identical email within this time, e.g. 10m or 1d (0 disables the check)")
+
+	// Delete command flags
+	emailDeleteCmd.Flags().BoolP("force", "f", false, "Delete without confirmation")
 }
 
 func runEmailSend(cmd *cobra.Command, _ []string) error {
@@ -152,9 +207,21 @@ func runEmailSend(cmd *cobra.Command, _ []string) error {
 
 	var req *api.SendEmailRequest
 
-	// Check if we should use interactive mode
-	if emailInteractive || (emailFromAddr == "" && len(emailToAddrs) == 0 && emailSubject == "") {
-		req, err = promptForEmail()
+	// Check if we should use the editor or interactive mode
+	if emailEdit && emailInteractive {
+		return fmt.Errorf("--edit and --interactive cannot be combined")
+	}
+	if emailEdit {
+		req, err = composeEmailInEditor(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to compose email: %v", err)
+		}
+		if req == nil {
+			cmd.PrintErrln("❌ " + i18n.T("Email sending canceled (no recipients)"))
+			return nil
+		}
+	} else if emailInteractive || (emailFromAddr == "" && len(emailToAddrs) == 0 && emailSubject == "") {
+		req, err = promptForEmail(cmd)
 		if err != nil {
 			return fmt.Errorf("failed to get email input: %v", err)
 		}
@@ -163,62 +230,158 @@ func runEmailSend(cmd *cobra.Command, _ []string) error {
 		if err != nil {
 			return fmt.Errorf("failed to build email from flags: %v", err)
 		}
+		if err := markdownBody(cmd, req); err != nil {
+			return err
+		}
 	}
+	addTextAlternative(req)
 
-	// Validate the email
-	if err2 := validateEmailRequest(req); err2 != nil {
+	// Validate the email, including its size against the account's limits
+	limits, err := emailLimits()
+	if err != nil {
+		return err
+	}
+	if err2 := validateEmailRequest(req, limits); err2 != nil {
 		return fmt.Errorf("email validation failed: %v", err2)
 	}
 
 	// Show email preview
-	fmt.Println("📧 Email Preview:")
-	fmt.Printf("From: %s\n", req.From)
-	fmt.Printf("To: %s\n", strings.Join(req.To, ", "))
+	cmd.PrintErrln("📧 Email Preview:")
+	cmd.PrintErrf("From: %s\n", req.From)
+	cmd.PrintErrf("To: %s\n", strings.Join(req.To, ", "))
 	if len(req.CC) > 0 {
-		fmt.Printf("CC: %s\n", strings.Join(req.CC, ", "))
+		cmd.PrintErrf("CC: %s\n", strings.Join(req.CC, ", "))
 	}
 	if len(req.BCC) > 0 {
-		fmt.Printf("BCC: %s\n", strings.Join(req.BCC, ", "))
+		cmd.PrintErrf("BCC: %s\n", strings.Join(req.BCC, ", "))
 	}
-	fmt.Printf("Subject: %s\n", req.Subject)
+	cmd.PrintErrf("Subject: %s\n", req.Subject)
 	if len(req.Attachments) > 0 {
-		fmt.Printf("Attachments: %d files\n", len(req.Attachments))
+		cmd.PrintErrf("Attachments: %d files\n", len(req.Attachments))
+	}
+	cmd.PrintErrln()
+
+	if err := checkSendQuota(ctx, cmd, apiClient.Emails, 1); err != nil {
+		return err
 	}
-	fmt.Println()
 
 	if emailDryRun {
-		fmt.Println("✅ Email validation successful (dry run mode)")
+		if now, window := time.Now(), time.Duration(emailDupWindow); window > 0 {
+			if ledger, err := openSendLedger(now); err == nil {
+				if prev, ok := ledger.lookup(emailFingerprint(req), window, now); ok {
+					cmd.PrintErrf("⚠️  %s\n", duplicateMessage(prev, now))
+				}
+			}
+		}
+		cmd.PrintErrln("✅ Email validation successful (dry run mode)")
 		return nil
 	}
 
-	// Confirm before sending
-	fmt.Print("Send this email? [y/N]: ")
-	reader := bufio.NewReader(cmd.InOrStdin())
-	response, _ := reader.ReadString('\n')
-	response = strings.TrimSpace(strings.ToLower(response))
+	// Claim the email in the send ledger before confirming, so a duplicate
+	// fails without a prompt and a concurrent run of the same job is refused.
+	release, err := claimEmailSend(cmd, req)
+	if err != nil {
+		return err
+	}
 
-	if response != "y" && response != yesStr {
-		fmt.Println("❌ Email sending canceled")
+	// Confirm before sending
+	ok, err := confirm(cmd, i18n.T("Send this email?"))
+	if err != nil {
+		release()
+		return err
+	}
+	if !ok {
+		release()
+		cmd.PrintErrln("❌ " + i18n.T("Email sending canceled"))
 		return nil
 	}
 
-	// Send the email
 	result, err := apiClient.Emails.SendEmail(ctx, req)
 	if err != nil {
-		return fmt.Errorf("failed to send email: %v", err)
+		// Only a rejection by the API proves the email was not sent; after a
+		// timeout or server error it may have gone out, so the claim stays.
+		if sendRejected(err) {
+			release()
+			return fmt.Errorf("failed to send email: %v", err)
+		}
+		return fmt.Errorf("failed to send email: %v (it may have been sent anyway; check 'forward-email email list' "+
+			"before sending it again with --allow-duplicate)", err)
 	}
 
-	cmd.Printf("✅ Email sent successfully!\n")
-	fmt.Printf("Email ID: %s\n", result.ID)
-	fmt.Printf("Message ID: %s\n", result.MessageID)
-	fmt.Printf("Status: %s\n", result.Status)
-	fmt.Printf("Sent at: %s\n", result.SentAt.Format(time.RFC3339))
+	reportMutation(hookResource{Type: "email", Action: "send", Name: req.Subject, ID: result.ID, Data: result})
+	cmd.PrintErrf("✅ Email sent successfully!\n")
+	if done, err := printIDOnly(cmd, result.ID); done {
+		return err
+	}
+
+	format, err := output.ParseFormat(viper.GetString("output"))
+	if err != nil {
+		return fmt.Errorf("invalid output format: %v", err)
+	}
+	if format == output.FormatJSON || format == output.FormatYAML {
+		return output.NewFormatter(format, cmd.OutOrStdout()).Format(result)
+	}
+	out := cmd.OutOrStdout()
+	_, _ = fmt.Fprintf(out, "Email ID: %s\n", result.ID)
+	_, _ = fmt.Fprintf(out, "Message ID: %s\n", result.MessageID)
+	_, _ = fmt.Fprintf(out, "Status: %s\n", result.Status)
+	_, _ = fmt.Fprintf(out, "Sent at: %s\n", output.FormatTime(result.SentAt, time.RFC3339))
 
 	return nil
 }
 
+// emailListOptions validates the email list display flags.
+func emailListOptions() (output.EmailListOptions, error) {
+	opts := output.EmailListOptions{FullSubject: emailFullSubj, MaxWidth: emailMaxWidth}
+	if emailMaxWidth < 0 {
+		return opts, fmt.Errorf("--max-col-width must not be negative")
+	}
+	if emailColumns == "" {
+		return opts, nil
+	}
+	for _, col := range strings.Split(emailColumns, ",") {
+		col = strings.ToLower(strings.TrimSpace(col))
+		if !slices.Contains(output.EmailListColumns, col) {
+			return opts, fmt.Errorf("invalid column '%s'%s Valid columns: %s",
+				col, didYouMean(col, output.EmailListColumns), strings.Join(output.EmailListColumns, ", "))
+		}
+		opts.Columns = append(opts.Columns, col)
+	}
+	return opts, nil
+}
+
 func runEmailList(cmd *cobra.Command, _ []string) error {
 	ctx := context.Background()
+	start := time.Now()
+
+	status, err := parseEmailStatus(emailStatus)
+	if err != nil {
+		return err
+	}
+	if err := validateSortField(emailSort, emailSortFields); err != nil {
+		return err
+	}
+	listOpts, err := emailListOptions()
+	if err != nil {
+		return err
+	}
+	since, until, err := emailListDateFlags()
+	if err != nil {
+		return err
+	}
+	dateFrom, dateTo, err := resolveDateRange(since, until, time.Now())
+	if err != nil {
+		return err
+	}
+
+	format, err := output.ParseFormat(viper.GetString("output"))
+	if err != nil {
+		return fmt.Errorf("invalid output format: %v", err)
+	}
+	envelope, err := envelopeSet(cmd, format)
+	if err != nil {
+		return err
+	}
 
 	apiClient, err := client.NewAPIClient()
 	if err != nil {
@@ -239,12 +402,13 @@ func runEmailList(cmd *cobra.Command, _ []string) error {
 		Sort:      emailSort,
 		Order:     emailOrder,
 		Search:    emailSearch,
-		Status:    emailStatus,
+		Status:    status,
 		From:      emailFrom,
 		To:        emailTo,
-		DateFrom:  emailDateFrom,
-		DateTo:    emailDateTo,
+		DateFrom:  dateFrom,
+		DateTo:    dateTo,
 		HasAttach: hasAttach,
+		Cursor:    listCursor(cmd),
 	}
 
 	response, err := apiClient.Emails.ListEmails(ctx, opts)
@@ -252,9 +416,8 @@ func runEmailList(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to list emails: %v", err)
 	}
 
-	format, err := output.ParseFormat(viper.GetString("output"))
-	if err != nil {
-		return fmt.Errorf("invalid output format: %v", err)
+	if envelope {
+		return writeEnvelope(cmd, start, response.Emails, &response.Pagination, nil)
 	}
 
 	if format == output.FormatJSON || format == output.FormatYAML {
@@ -263,12 +426,12 @@ func runEmailList(cmd *cobra.Command, _ []string) error {
 	}
 
 	// Format as table
-	tableData, err := output.FormatEmailList(response.Emails, format)
+	tableData, err := output.FormatEmailListWithOptions(response.Emails, format, listOpts)
 	if err != nil {
 		return fmt.Errorf("failed to format output: %v", err)
 	}
 
-	formatter := output.NewFormatter(format, cmd.OutOrStdout())
+	formatter := output.NewFormatter(format, cmd.OutOrStdout()).SetMaxColumnWidth(emailMaxWidth)
 	err = formatter.Format(tableData)
 	if err != nil {
 		return err
@@ -276,18 +439,58 @@ func runEmailList(cmd *cobra.Command, _ []string) error {
 
 	// Show pagination info for non-JSON/YAML formats
 	if len(response.Emails) > 0 {
-		fmt.Printf("\nShowing %d of %d emails (page %d of %d)\n",
+		cmd.PrintErrf("\nShowing %d of %d emails (page %d of %d)\n",
 			len(response.Emails), response.TotalCount, response.Page, response.TotalPages)
-		if response.Page < response.TotalPages {
-			fmt.Printf("Use --page %d to see more results\n", response.Page+1)
-		}
+		printNextPageHint(cmd, &response.Pagination)
 	} else {
-		fmt.Println("No emails found")
+		cmd.PrintErrln("No emails found")
 	}
 
 	return nil
 }
 
+// emailStatusNames returns the valid --status values.
+func emailStatusNames() []string {
+	names := make([]string, len(api.EmailStatuses))
+	for i, st := range api.EmailStatuses {
+		names[i] = string(st)
+	}
+	return names
+}
+
+// emailStatusList returns the valid --status values for help and errors.
+func emailStatusList() string {
+	return strings.Join(emailStatusNames(), ", ")
+}
+
+// emailSortFields are the fields accepted by 'email list --sort'.
+var emailSortFields = []string{"sent_at", "subject", "from", "to"}
+
+// parseEmailStatus validates a --status value; empty means no filter.
+func parseEmailStatus(s string) (string, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return "", nil
+	}
+	for _, st := range api.EmailStatuses {
+		if s == string(st) {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf("invalid --status %q%s Valid statuses: %s", s, didYouMean(s, emailStatusNames()), emailStatusList())
+}
+
+// emailListDateFlags merges --since/--until with their --date-from/--date-to aliases.
+func emailListDateFlags() (since, until string, err error) {
+	if emailSince != "" && emailDateFrom != "" {
+		return "", "", fmt.Errorf("use either --since or --date-from, not both")
+	}
+	if emailUntil != "" && emailDateTo != "" {
+		return "", "", fmt.Errorf("use either --until or --date-to, not both")
+	}
+	return emailSince + emailDateFrom, emailUntil + emailDateTo, nil
+}
+
 func runEmailGet(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 	emailID := args[0]
@@ -302,6 +505,19 @@ func runEmailGet(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get email: %v", err)
 	}
 
+	showHeaders, _ := cmd.Flags().GetBool("headers")
+	if path, _ := cmd.Flags().GetString("save-eml"); path != "" {
+		if path == "-" && showHeaders {
+			return fmt.Errorf("--headers cannot be combined with --save-eml -, which writes the message to stdout")
+		}
+		if err := saveEmailEML(cmd, email, path); err != nil || !showHeaders {
+			return err
+		}
+	}
+	if showHeaders {
+		return printEmailHeaders(cmd, email)
+	}
+
 	format, err := output.ParseFormat(viper.GetString("output"))
 	if err != nil {
 		return fmt.Errorf("invalid output format: %v", err)
@@ -337,20 +553,18 @@ func runEmailDelete(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get email: %v", err)
 	}
 
-	fmt.Printf("⚠️  Are you sure you want to delete email '%s'?\n", email.Subject)
 	to := email.Headers["To"]
 	if to == "" {
 		to = "(unknown)"
 	}
-	fmt.Printf("Sent to: %s\n", to)
-	fmt.Printf("Sent at: %s\n", email.SentAt.Format(time.RFC3339))
-	fmt.Print("Type 'yes' to confirm: ")
-	reader := bufio.NewReader(cmd.InOrStdin())
-	line, _ := reader.ReadString('\n')
-	confirmation := strings.TrimSpace(line)
-
-	if !strings.EqualFold(confirmation, "yes") {
-		fmt.Println("❌ Deletion canceled")
+	cmd.PrintErrf("Sent to: %s\n", to)
+	cmd.PrintErrf("Sent at: %s\n", output.FormatTime(email.SentAt, time.RFC3339))
+	ok, err := confirm(cmd, "⚠️  "+i18n.T("Are you sure you want to delete email '%s'?", email.Subject))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		cmd.PrintErrln("❌ " + i18n.T("Deletion canceled"))
 		return nil
 	}
 
@@ -359,13 +573,38 @@ func runEmailDelete(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to delete email: %v", err)
 	}
 
-	cmd.Printf("✅ Email '%s' deleted successfully\n", email.Subject)
+	reportMutation(hookResource{Type: "email", Action: "delete", Name: email.Subject, ID: emailID})
+	cmd.PrintErrf("✅ Email '%s' deleted successfully\n", email.Subject)
 	return nil
 }
 
 func runEmailQuota(cmd *cobra.Command, _ []string) error {
 	ctx := context.Background()
 
+	allProfiles, err := allProfilesSet(cmd)
+	if err != nil {
+		return err
+	}
+	forecast, _ := cmd.Flags().GetBool("forecast")
+	if allProfiles && forecast {
+		return fmt.Errorf("--forecast cannot be combined with --all-profiles")
+	}
+	if allProfiles {
+		results, fanErr := fanOutProfiles(ctx, cmd, func(ctx context.Context, c *api.Client) ([]*api.EmailQuota, error) {
+			quota, err := c.Emails.GetEmailQuota(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return []*api.EmailQuota{quota}, nil
+		})
+		if err := formatAccountResults(cmd, results, func(quotas []*api.EmailQuota, format output.Format) (*output.TableData, error) {
+			return output.FormatEmailQuota(quotas[0], format)
+		}); err != nil {
+			return err
+		}
+		return fanErr
+	}
+
 	apiClient, err := client.NewAPIClient()
 	if err != nil {
 		return fmt.Errorf("failed to create API client: %v", err)
@@ -375,6 +614,9 @@ func runEmailQuota(cmd *cobra.Command, _ []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to get email quota: %v", err)
 	}
+	if forecast {
+		return printQuotaForecast(cmd, quota)
+	}
 
 	format, err := output.ParseFormat(viper.GetString("output"))
 	if err != nil {
@@ -396,57 +638,148 @@ func runEmailQuota(cmd *cobra.Command, _ []string) error {
 	return formatter.Format(tableData)
 }
 
-//nolint:unparam // returns error for future use; currently always nil
-func promptForEmail() (*api.SendEmailRequest, error) {
-	reader := bufio.NewReader(os.Stdin)
+// promptForEmail walks through composing an email: addresses, subject, a text or
+// HTML body, optional custom headers and attachments.
+func promptForEmail(cmd *cobra.Command) (*api.SendEmailRequest, error) {
+	in := bufio.NewReader(cmd.InOrStdin())
+	out := cmd.ErrOrStderr()
 	req := &api.SendEmailRequest{}
 
-	fmt.Println("📧 Interactive Email Composer")
-	fmt.Println()
+	ask := func(label string) string {
+		_, _ = fmt.Fprintf(out, "%s: ", label)
+		answer, _ := readPromptLine(in)
+		return answer
+	}
+
+	_, _ = fmt.Fprintln(out, "📧 Interactive Email Composer")
+	_, _ = fmt.Fprintln(out)
 
-	// From
-	fmt.Print("From: ")
-	from, _ := reader.ReadString('\n')
-	req.From = strings.TrimSpace(from)
+	req.From = ask("From")
+	var err error
+	if req.To, err = parseEmailAddressList(ask("To (comma-separated)")); err != nil {
+		return nil, err
+	}
+	if req.CC, err = parseEmailAddressList(ask("CC (comma-separated, optional)")); err != nil {
+		return nil, err
+	}
+	req.Subject = ask("Subject")
 
-	// To
-	fmt.Print("To (comma-separated): ")
-	to, _ := reader.ReadString('\n')
-	req.To = strings.Split(strings.TrimSpace(to), ",")
-	for i, addr := range req.To {
-		req.To[i] = strings.TrimSpace(addr)
+	format := strings.ToLower(ask("Body format (text/html) [text]"))
+	switch format {
+	case "", "text":
+		_, _ = fmt.Fprintln(out, "Content (enter 'END' on a new line to finish):")
+		req.Text = readUntilEND(in)
+	case "html":
+		_, _ = fmt.Fprintln(out, "HTML content (enter 'END' on a new line to finish):")
+		req.HTML = readUntilEND(in)
+	default:
+		return nil, fmt.Errorf("invalid body format: %s (valid: text|html)", format)
 	}
 
-	// CC (optional)
-	fmt.Print("CC (comma-separated, optional): ")
-	cc, _ := reader.ReadString('\n')
-	ccTrimmed := strings.TrimSpace(cc)
-	if ccTrimmed != "" {
-		req.CC = strings.Split(ccTrimmed, ",")
-		for i, addr := range req.CC {
-			req.CC[i] = strings.TrimSpace(addr)
+	_, _ = fmt.Fprintln(out, "Custom headers as 'Name: Value' (blank line to finish):")
+	for {
+		_, _ = fmt.Fprint(out, "  header: ")
+		line, err := readPromptLine(in)
+		if err != nil || line == "" {
+			break
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			_, _ = fmt.Fprintf(out, "  ✗ invalid header format: %s (expected 'Name: Value')\n", line)
+			continue
+		}
+		if req.Headers == nil {
+			req.Headers = make(map[string]string)
 		}
+		req.Headers[strings.TrimSpace(name)] = strings.TrimSpace(value)
 	}
 
-	// Subject
-	fmt.Print("Subject: ")
-	subject, _ := reader.ReadString('\n')
-	req.Subject = strings.TrimSpace(subject)
+	attachments, err := promptForAttachments(in, out)
+	if err != nil {
+		return nil, err
+	}
+	req.Attachments = attachments
 
-	// Content
-	fmt.Println("Content (enter 'END' on a new line to finish):")
-	var contentLines []string
+	return req, nil
+}
+
+// readUntilEND reads lines up to a line containing only END, or EOF.
+func readUntilEND(in *bufio.Reader) string {
+	var lines []string
 	for {
-		line, _ := reader.ReadString('\n')
-		line = strings.TrimSuffix(line, "\n")
-		if line == "END" {
+		line, err := in.ReadString('\n')
+		line = strings.TrimRight(line, "\r\n")
+		if line == "END" || (err != nil && line == "") {
 			break
 		}
-		contentLines = append(contentLines, line)
+		lines = append(lines, line)
 	}
-	req.Text = strings.Join(contentLines, "\n")
+	return strings.Join(lines, "\n")
+}
 
-	return req, nil
+// promptForAttachments asks for attachment paths until a blank line. A path that
+// does not exist is completed against the file system: a single match is used,
+// several matches are listed so the user can refine the path.
+func promptForAttachments(in *bufio.Reader, out io.Writer) ([]api.AttachmentData, error) {
+	var attachments []api.AttachmentData
+	var total int64
+	_, _ = fmt.Fprintln(out, "Attachments (file path, blank line to finish):")
+	for {
+		_, _ = fmt.Fprint(out, "  attach: ")
+		line, err := readPromptLine(in)
+		if err != nil || line == "" {
+			break
+		}
+		path, matches := completePath(line)
+		if path == "" {
+			if len(matches) == 0 {
+				_, _ = fmt.Fprintf(out, "  ✗ no such file: %s\n", line)
+			} else {
+				_, _ = fmt.Fprintf(out, "  matches: %s\n", strings.Join(matches, "  "))
+			}
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() {
+			_, _ = fmt.Fprintf(out, "  ✗ not a file: %s\n", path)
+			continue
+		}
+		attachment, err := processAttachment(path, "", "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to process attachment %s: %v", path, err)
+		}
+		attachments = append(attachments, *attachment)
+		total += info.Size()
+		_, _ = fmt.Fprintf(out, "  ✓ %s (%s, %s; %d files, %s total)\n", attachment.Filename,
+			output.FormatBytes(info.Size()), attachment.ContentType, len(attachments), output.FormatBytes(total))
+	}
+	return attachments, nil
+}
+
+// completePath resolves a possibly partial path. It returns the path when it
+// exists or is the only completion of the prefix; otherwise it returns the
+// candidate completions (directories with a trailing separator).
+func completePath(p string) (string, []string) {
+	if rest, ok := strings.CutPrefix(p, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			p = filepath.Join(home, rest)
+		}
+	}
+	if _, err := os.Stat(p); err == nil {
+		return p, nil
+	}
+	matches, _ := filepath.Glob(p + "*")
+	if len(matches) == 1 {
+		if info, err := os.Stat(matches[0]); err == nil && !info.IsDir() {
+			return matches[0], nil
+		}
+	}
+	for i, m := range matches {
+		if info, err := os.Stat(m); err == nil && info.IsDir() {
+			matches[i] = m + string(filepath.Separator)
+		}
+	}
+	return "", matches
 }
 
 func buildEmailFromFlags() (*api.SendEmailRequest, error) {
@@ -490,9 +823,17 @@ func buildEmailFromFlags() (*api.SendEmailRequest, error) {
 	}
 
 	// Process attachments
+	types, err := parseAttachOverrides("attach-type", emailAttachTypes, emailAttachments)
+	if err != nil {
+		return nil, err
+	}
+	names, err := parseAttachOverrides("attach-name", emailAttachNames, emailAttachments)
+	if err != nil {
+		return nil, err
+	}
 	if len(emailAttachments) > 0 {
 		for _, attachPath := range emailAttachments {
-			attachment, err := processAttachment(attachPath)
+			attachment, err := processAttachment(attachPath, attachOverride(names, attachPath), attachOverride(types, attachPath))
 			if err != nil {
 				return nil, fmt.Errorf("failed to process attachment %s: %v", attachPath, err)
 			}
@@ -503,43 +844,72 @@ func buildEmailFromFlags() (*api.SendEmailRequest, error) {
 	return req, nil
 }
 
-func processAttachment(filePath string) (*api.AttachmentData, error) {
+// processAttachment reads a file into an attachment. name and contentType
+// override the file's base name and the detected MIME type when set.
+func processAttachment(filePath, name, contentType string) (*api.AttachmentData, error) {
 	content, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %v", err)
 	}
 
+	if name == "" {
+		name = filepath.Base(filePath)
+	}
+	if contentType == "" {
+		contentType = detectContentType(name, content)
+	}
+
 	// Encode content as base64
 	encoded := base64.StdEncoding.EncodeToString(content)
 
-	attachment := &api.AttachmentData{
-		Filename: filepath.Base(filePath),
-		Content:  []byte(encoded),
-	}
-
-	// Try to detect content type based on file extension
-	ext := strings.ToLower(filepath.Ext(filePath))
-	switch ext {
-	case ".txt":
-		attachment.ContentType = "text/plain"
-	case ".pdf":
-		attachment.ContentType = "application/pdf"
-	case ".jpg", ".jpeg":
-		attachment.ContentType = "image/jpeg"
-	case ".png":
-		attachment.ContentType = "image/png"
-	case ".gif":
-		attachment.ContentType = "image/gif"
-	case ".zip":
-		attachment.ContentType = "application/zip"
-	default:
-		attachment.ContentType = "application/octet-stream"
+	return &api.AttachmentData{
+		Filename:    name,
+		Content:     []byte(encoded),
+		ContentType: contentType,
+	}, nil
+}
+
+// detectContentType returns the MIME type registered for the file extension,
+// falling back to sniffing the content when there is none or it is only the
+// generic application/octet-stream, as many mime.types files map .bin and
+// similar extensions to.
+func detectContentType(name string, content []byte) string {
+	t := mime.TypeByExtension(strings.ToLower(filepath.Ext(name)))
+	if t != "" {
+		if base, _, err := mime.ParseMediaType(t); err != nil || base != "application/octet-stream" {
+			return t
+		}
 	}
+	return http.DetectContentType(content)
+}
 
-	return attachment, nil
+// parseAttachOverrides parses --attach-type/--attach-name values of the form
+// <file>=<value>, keyed by the file as given to --attach or its base name.
+func parseAttachOverrides(flag string, values, attachments []string) (map[string]string, error) {
+	overrides := make(map[string]string, len(values))
+	for _, v := range values {
+		file, value, ok := strings.Cut(v, "=")
+		file, value = strings.TrimSpace(file), strings.TrimSpace(value)
+		if !ok || file == "" || value == "" {
+			return nil, fmt.Errorf("invalid --%s %q (expected <file>=<value>)", flag, v)
+		}
+		if !slices.ContainsFunc(attachments, func(a string) bool { return a == file || filepath.Base(a) == file }) {
+			return nil, fmt.Errorf("--%s %q does not match any --attach file", flag, file)
+		}
+		overrides[file] = value
+	}
+	return overrides, nil
 }
 
-func validateEmailRequest(req *api.SendEmailRequest) error {
+// attachOverride looks up the override for an attachment path.
+func attachOverride(overrides map[string]string, path string) string {
+	if v, ok := overrides[path]; ok {
+		return v
+	}
+	return overrides[filepath.Base(path)]
+}
+
+func validateEmailRequest(req *api.SendEmailRequest, limits api.EmailLimits) error {
 	if req.From == "" {
 		return fmt.Errorf("from address is required")
 	}
@@ -567,5 +937,5 @@ func validateEmailRequest(req *api.SendEmailRequest) error {
 		}
 	}
 
-	return nil
+	return limits.Check(req)
 }
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/mailtext"
+)
+
+var (
+	emailMarkdown     string
+	emailMarkdownFile string
+	emailNoAutoText   bool
+)
+
+func init() {
+	emailSendCmd.Flags().StringVar(&emailMarkdown, "markdown", "", "Markdown content, sent as both HTML and plain text")
+	emailSendCmd.Flags().StringVar(&emailMarkdownFile, "markdown-file", "", "File containing Markdown content (- for stdin)")
+	emailSendCmd.Flags().BoolVar(&emailNoAutoText, "no-auto-text", false,
+		"Send an HTML-only email as is, without generating a plain-text part")
+
+	for _, markdown := range []string{"markdown", "markdown-file"} {
+		for _, body := range []string{"text", "html", "text-file", "html-file"} {
+			emailSendCmd.MarkFlagsMutuallyExclusive(markdown, body)
+		}
+	}
+	emailSendCmd.MarkFlagsMutuallyExclusive("markdown", "markdown-file")
+}
+
+// markdownBody sets the HTML and text parts of req from --markdown or
+// --markdown-file, when given.
+func markdownBody(cmd *cobra.Command, req *api.SendEmailRequest) error {
+	src := emailMarkdown
+	if emailMarkdownFile != "" {
+		var content []byte
+		var err error
+		if emailMarkdownFile == "-" {
+			content, err = io.ReadAll(cmd.InOrStdin())
+		} else {
+			content, err = os.ReadFile(emailMarkdownFile) //nolint:gosec // the user names the file to read
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read Markdown file: %v", err)
+		}
+		src = string(content)
+	}
+	if src != "" {
+		req.HTML, req.Text = mailtext.Markdown(src)
+	}
+	return nil
+}
+
+// addTextAlternative generates the plain-text part of an HTML-only email,
+// which spam filters and text-only clients expect, unless --no-auto-text
+// is given.
+func addTextAlternative(req *api.SendEmailRequest) {
+	if !emailNoAutoText && req.HTML != "" && req.Text == "" {
+		req.Text = mailtext.FromHTML(req.HTML)
+	}
+}
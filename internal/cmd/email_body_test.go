@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/auth"
+)
+
+func TestEmailSend_BodyParts(t *testing.T) {
+	var sent []api.SendEmailRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			_ = json.NewEncoder(w).Encode(api.EmailQuota{})
+			return
+		}
+		var req api.SendEmailRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		sent = append(sent, req)
+		_ = json.NewEncoder(w).Encode(api.SendEmailResponse{ID: "e1", Status: "queued"})
+	}))
+	defer srv.Close()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir()) // send ledger
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	reset := func() {
+		resetCommandFlags(emailSendCmd)
+		emailFromAddr, emailToAddrs, emailSubject, emailText, emailHTML = "", nil, "", "", ""
+	}
+	t.Cleanup(reset)
+	send := func(args ...string) error {
+		t.Helper()
+		reset()
+		var out bytes.Buffer
+		rootCmd.SetOut(&out)
+		rootCmd.SetErr(&out)
+		rootCmd.SetArgs(append([]string{"email", "send", "--from", "a@example.com", "--to", "b@example.com",
+			"--subject", "Hi", "--yes", "--allow-duplicate"}, args...))
+		return rootCmd.Execute()
+	}
+
+	require.NoError(t, send("--html", `<p>See <a href="https://example.com/docs">the docs</a>.</p>`))
+	require.NoError(t, send("--html", "<p>Hi</p>", "--no-auto-text"))
+	require.NoError(t, send("--markdown", "Hello **you**, see [docs](https://example.com)."))
+	require.Len(t, sent, 3)
+
+	assert.Equal(t, "See the docs (https://example.com/docs).", sent[0].Text)
+	assert.Empty(t, sent[1].Text, "--no-auto-text sends the HTML alone")
+	assert.Equal(t, "<p>Hello <strong>you</strong>, see <a href=\"https://example.com\">docs</a>.</p>\n", sent[2].HTML)
+	assert.Equal(t, "Hello you, see docs (https://example.com).", sent[2].Text)
+
+	err := send("--markdown", "x", "--text", "y")
+	assert.ErrorContains(t, err, "none of the others can be")
+}
@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ginsys/forward-email/pkg/api"
+)
+
+var emailEdit bool
+
+// emailEditHelp is the comment block at the top of the editor template.
+const emailEditHelp = `# Compose the email below. Lines starting with '#' above the blank line are
+# ignored. Headers come first, one per line; the body follows the first blank
+# line. Set Format to html to send the body as HTML. A line reading
+# ` + emailHTMLMarker + ` starts an HTML part sent along with the text body
+# above it. Other headers are sent as custom headers. Leave To empty to cancel.
+`
+
+// emailHTMLMarker separates the text body from the HTML part of an email
+// that has both.
+const emailHTMLMarker = "=== HTML ==="
+
+func init() {
+	emailSendCmd.Flags().BoolVarP(&emailEdit, "edit", "e", false, "Compose the email in $EDITOR, pre-filled from the other flags")
+}
+
+// editorCommand returns the user's editor: $VISUAL, then $EDITOR, then vi.
+func editorCommand() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			return fields
+		}
+	}
+	return []string{"vi"}
+}
+
+// renderEmailTemplate writes req as headers followed by the body. An email
+// with both a text and an HTML body gets the HTML after emailHTMLMarker.
+func renderEmailTemplate(req *api.SendEmailRequest) string {
+	var b strings.Builder
+	b.WriteString(emailEditHelp)
+	fmt.Fprintf(&b, "From: %s\n", req.From)
+	fmt.Fprintf(&b, "To: %s\n", strings.Join(req.To, ", "))
+	fmt.Fprintf(&b, "Cc: %s\n", strings.Join(req.CC, ", "))
+	fmt.Fprintf(&b, "Bcc: %s\n", strings.Join(req.BCC, ", "))
+	fmt.Fprintf(&b, "Subject: %s\n", req.Subject)
+	body, format := req.Text, "text"
+	if req.Text == "" && req.HTML != "" {
+		body, format = req.HTML, "html"
+	}
+	fmt.Fprintf(&b, "Format: %s\n", format)
+
+	names := make([]string, 0, len(req.Headers))
+	for name := range req.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s: %s\n", name, req.Headers[name])
+	}
+
+	b.WriteString("\n")
+	b.WriteString(body)
+	if req.Text != "" && req.HTML != "" {
+		b.WriteString("\n\n" + emailHTMLMarker + "\n")
+		b.WriteString(req.HTML)
+	}
+	return b.String()
+}
+
+// parseEmailTemplate reads an edited template back into a request.
+func parseEmailTemplate(content string) (*api.SendEmailRequest, error) {
+	req := &api.SendEmailRequest{}
+	format := "text"
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	var body []string
+	inBody := false
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if inBody {
+			body = append(body, line)
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			inBody = true
+			continue
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid header line %q (expected 'Name: Value' before the blank line)", line)
+		}
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		switch strings.ToLower(name) {
+		case "from":
+			req.From = value
+		case "to", "cc", "bcc":
+			addrs, err := parseEmailAddressList(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s header: %v", name, err)
+			}
+			switch strings.ToLower(name) {
+			case "to":
+				req.To = addrs
+			case "cc":
+				req.CC = addrs
+			default:
+				req.BCC = addrs
+			}
+		case "subject":
+			req.Subject = value
+		case "format":
+			format = strings.ToLower(value)
+		default:
+			if value == "" {
+				continue
+			}
+			if req.Headers == nil {
+				req.Headers = make(map[string]string)
+			}
+			req.Headers[name] = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if i := slices.Index(body, emailHTMLMarker); i >= 0 {
+		if format != "text" && format != "" {
+			return nil, fmt.Errorf("format %s cannot be combined with an HTML part (%s)", format, emailHTMLMarker)
+		}
+		req.Text = strings.TrimRight(strings.Join(body[:i], "\n"), "\n")
+		req.HTML = strings.TrimRight(strings.Join(body[i+1:], "\n"), "\n")
+		return req, nil
+	}
+	text := strings.TrimRight(strings.Join(body, "\n"), "\n")
+	switch format {
+	case "text", "":
+		req.Text = text
+	case "html":
+		req.HTML = text
+	default:
+		return nil, fmt.Errorf("invalid Format: %s (valid: text|html)", format)
+	}
+	return req, nil
+}
+
+// composeEmailInEditor opens the user's editor on a template pre-filled from the
+// flags and returns the edited email, or nil when the user cleared the To line.
+// Attachments given with --attach are kept.
+func composeEmailInEditor(cmd *cobra.Command) (*api.SendEmailRequest, error) {
+	draft, err := buildEmailFromFlags()
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.CreateTemp("", "forward-email-*.eml")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create draft file: %v", err)
+	}
+	path := f.Name()
+	defer func() { _ = os.Remove(path) }()
+	if _, err := f.WriteString(renderEmailTemplate(draft)); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to write draft file: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write draft file: %v", err)
+	}
+
+	editor := editorCommand()
+	c := exec.Command(editor[0], append(editor[1:], path)...) //nolint:gosec // the editor is the user's own $VISUAL/$EDITOR
+	c.Stdin, c.Stdout, c.Stderr = cmd.InOrStdin(), cmd.OutOrStdout(), cmd.ErrOrStderr()
+	if err := c.Run(); err != nil {
+		return nil, fmt.Errorf("editor %s failed: %v", editor[0], err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read draft file: %v", err)
+	}
+	req, err := parseEmailTemplate(string(content))
+	if err != nil {
+		return nil, err
+	}
+	if len(req.To) == 0 {
+		return nil, nil
+	}
+	req.Attachments = draft.Attachments
+	return req, nil
+}
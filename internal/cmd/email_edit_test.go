@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ginsys/forward-email/pkg/api"
+)
+
+func TestEmailTemplate_RoundTrip(t *testing.T) {
+	req := &api.SendEmailRequest{
+		From:    "me@example.com",
+		To:      []string{"a@example.com", "b@example.com"},
+		Subject: "Hello: world",
+		HTML:    "<p>Hi</p>\n\n<p>Bye</p>",
+		Headers: map[string]string{"X-Campaign": "q2"},
+	}
+	got, err := parseEmailTemplate(renderEmailTemplate(req))
+	require.NoError(t, err)
+	assert.Equal(t, req, got)
+
+	// Both bodies survive: the HTML part follows the marker
+	req = &api.SendEmailRequest{
+		From:    "me@example.com",
+		To:      []string{"a@example.com"},
+		Subject: "Both",
+		Text:    "Hi\n\nBye",
+		HTML:    "<p>Hi</p>\n\n<p>Bye</p>",
+	}
+	got, err = parseEmailTemplate(renderEmailTemplate(req))
+	require.NoError(t, err)
+	assert.Equal(t, req, got)
+
+	_, err = parseEmailTemplate("To: a@example.com\nFormat: html\n\n<p>x</p>\n" + emailHTMLMarker + "\n<p>y</p>")
+	assert.Error(t, err)
+	_, err = parseEmailTemplate("To: a@example.com\nFormat: rtf\n\nbody")
+	assert.Error(t, err)
+	_, err = parseEmailTemplate("To a@example.com\n\nbody")
+	assert.Error(t, err)
+}
+
+func TestComposeEmailInEditor(t *testing.T) {
+	dir := t.TempDir()
+	editor := filepath.Join(dir, "editor.sh")
+	script := "#!/bin/sh\n" +
+		"sed -i 's/^To: .*/To: a@example.com/' \"$1\"\n" +
+		"printf 'Line one\\nLine two\\n' >> \"$1\"\n"
+	require.NoError(t, os.WriteFile(editor, []byte(script), 0o700)) //nolint:gosec // test editor must be executable
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", editor)
+
+	emailFromAddr, emailToAddrs, emailSubject = "me@example.com", []string{"placeholder@example.com"}, "Draft"
+	t.Cleanup(func() { emailFromAddr, emailToAddrs, emailSubject = "", nil, "" })
+
+	req, err := composeEmailInEditor(&cobra.Command{})
+	require.NoError(t, err)
+	require.NotNil(t, req)
+	assert.Equal(t, "me@example.com", req.From)
+	assert.Equal(t, []string{"a@example.com"}, req.To)
+	assert.Equal(t, "Draft", req.Subject)
+	assert.Equal(t, "Line one\nLine two", req.Text)
+
+	// --text and --html together: both reach the email
+	emailText, emailHTML = "Plain", "<p>Rich</p>"
+	t.Cleanup(func() { emailText, emailHTML = "", "" })
+	require.NoError(t, os.WriteFile(editor, []byte("#!/bin/sh\nsed -i 's/^To: .*/To: a@example.com/' \"$1\"\n"), 0o700)) //nolint:gosec // see above
+	req, err = composeEmailInEditor(&cobra.Command{})
+	require.NoError(t, err)
+	require.NotNil(t, req)
+	assert.Equal(t, "Plain", req.Text)
+	assert.Equal(t, "<p>Rich</p>", req.HTML)
+	emailText, emailHTML = "", ""
+
+	// Clearing To cancels
+	require.NoError(t, os.WriteFile(editor, []byte("#!/bin/sh\nsed -i 's/^To: .*/To: /' \"$1\"\n"), 0o700)) //nolint:gosec // see above
+	req, err = composeEmailInEditor(&cobra.Command{})
+	require.NoError(t, err)
+	assert.Nil(t, req)
+}
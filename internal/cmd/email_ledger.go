@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/config"
+	fe "github.com/ginsys/forward-email/pkg/errors"
+)
+
+// ledgerRetention is how long sent fingerprints are kept, whatever the
+// duplicate window of a single send.
+const ledgerRetention = 24 * time.Hour
+
+// sendLedger remembers the fingerprints of recently sent emails, one file per
+// fingerprint, so the same email is not sent twice by a cron job that fired
+// twice. A send claims its fingerprint by creating the file exclusively,
+// which makes the check safe between processes started at the same moment.
+type sendLedger struct {
+	dir string
+}
+
+// ledgerEntry is the content of a fingerprint file.
+type ledgerEntry struct {
+	SentAt  time.Time `json:"sent_at"`
+	From    string    `json:"from"`
+	To      []string  `json:"to"`
+	Subject string    `json:"subject"`
+}
+
+// openSendLedger opens the ledger in the sent directory of the config
+// directory and drops fingerprints older than ledgerRetention.
+func openSendLedger(now time.Time) (*sendLedger, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate send ledger: %v", err)
+	}
+	l := &sendLedger{dir: filepath.Join(dir, "sent")}
+	if err := os.MkdirAll(l.dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create send ledger: %v", err)
+	}
+	l.prune(now)
+	return l, nil
+}
+
+// emailFingerprint identifies an email by its sender, recipients, subject
+// and a hash of its bodies and attachments. Address case and recipient order
+// do not matter.
+func emailFingerprint(req *api.SendEmailRequest) string {
+	body := sha256.New()
+	for _, part := range []string{req.Text, req.HTML} {
+		body.Write([]byte(part))
+		body.Write([]byte{0})
+	}
+	for _, a := range req.Attachments {
+		body.Write([]byte(a.Filename))
+		body.Write([]byte{0})
+		body.Write(a.Content)
+		body.Write([]byte{0})
+	}
+
+	var recipients []string
+	for _, list := range [][]string{req.To, req.CC, req.BCC} {
+		for _, r := range list {
+			if r = strings.ToLower(strings.TrimSpace(r)); r != "" {
+				recipients = append(recipients, r)
+			}
+		}
+	}
+	slices.Sort(recipients)
+
+	h := sha256.New()
+	for _, part := range []string{
+		strings.ToLower(strings.TrimSpace(req.From)), strings.Join(recipients, ","), req.Subject, hex.EncodeToString(body.Sum(nil)),
+	} {
+		h.Write([]byte(part))
+		h.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// lookup returns the entry of fingerprint when it was sent within window.
+func (l *sendLedger) lookup(fingerprint string, window time.Duration, now time.Time) (*ledgerEntry, bool) {
+	data, err := os.ReadFile(l.path(fingerprint))
+	if err != nil {
+		return nil, false
+	}
+	var e ledgerEntry
+	if json.Unmarshal(data, &e) != nil || now.Sub(e.SentAt) >= window {
+		return nil, false
+	}
+	return &e, true
+}
+
+// claim records fingerprint as sent now. When it was already sent within
+// window, claim returns that entry and records nothing, unless force is set.
+func (l *sendLedger) claim(fingerprint string, e ledgerEntry, window time.Duration, force bool) (*ledgerEntry, error) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	path := l.path(fingerprint)
+	for range 2 {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600) //nolint:gosec // path is a hex fingerprint in the ledger directory
+		if err == nil {
+			if _, err := f.Write(data); err != nil {
+				_ = f.Close()
+				_ = os.Remove(path)
+				return nil, fmt.Errorf("failed to write send ledger: %v", err)
+			}
+			return nil, f.Close()
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, fmt.Errorf("failed to write send ledger: %v", err)
+		}
+		if prev, ok := l.lookup(fingerprint, window, e.SentAt); ok && !force {
+			return prev, nil
+		}
+		// An expired entry, or a duplicate sent on purpose: replace it.
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("failed to update send ledger: %v", err)
+		}
+	}
+	return nil, fmt.Errorf("failed to claim send ledger entry: another send of this email is in progress")
+}
+
+// release forgets fingerprint, after a send that was canceled or rejected.
+func (l *sendLedger) release(fingerprint string) {
+	_ = os.Remove(l.path(fingerprint))
+}
+
+// prune removes fingerprints older than ledgerRetention.
+func (l *sendLedger) prune(now time.Time) {
+	entries, err := os.ReadDir(l.dir)
+	if err != nil {
+		return
+	}
+	for _, de := range entries {
+		if info, err := de.Info(); err == nil && now.Sub(info.ModTime()) > ledgerRetention {
+			_ = os.Remove(filepath.Join(l.dir, de.Name()))
+		}
+	}
+}
+
+func (l *sendLedger) path(fingerprint string) string {
+	return filepath.Join(l.dir, fingerprint)
+}
+
+// duplicateMessage describes an earlier send of the same email.
+func duplicateMessage(prev *ledgerEntry, now time.Time) string {
+	ago := now.Sub(prev.SentAt).Round(time.Second)
+	return fmt.Sprintf("an identical email to %s was sent %s ago, at %s",
+		strings.Join(prev.To, ", "), ago, prev.SentAt.Local().Format("15:04:05"))
+}
+
+// claimEmailSend records req in the send ledger, refusing an email that was
+// already sent within --duplicate-window unless --allow-duplicate is set. The
+// returned func forgets the claim when the send certainly did not happen. A
+// ledger that cannot be opened only warns, as it must not stop mail from going
+// out.
+func claimEmailSend(cmd *cobra.Command, req *api.SendEmailRequest) (release func(), err error) {
+	release = func() {}
+	window := time.Duration(emailDupWindow)
+	if window <= 0 {
+		return release, nil
+	}
+	now := time.Now()
+	ledger, err := openSendLedger(now)
+	if err != nil {
+		cmd.PrintErrf("⚠️  Duplicate check skipped: %v\n", err)
+		return release, nil
+	}
+	fingerprint := emailFingerprint(req)
+	entry := ledgerEntry{SentAt: now, From: req.From, To: req.To, Subject: req.Subject}
+	prev, err := ledger.claim(fingerprint, entry, window, emailAllowDup)
+	if err != nil {
+		return release, err
+	}
+	if prev != nil {
+		return release, fmt.Errorf("%s; use --allow-duplicate to send it again", duplicateMessage(prev, now))
+	}
+	return func() { ledger.release(fingerprint) }, nil
+}
+
+// sendRejected reports whether a send failed because the API refused the
+// request, which means the email was not accepted. Transport errors, timeouts
+// and 5xx responses leave open whether it was.
+func sendRejected(err error) bool {
+	status := fe.GetStatusCode(err)
+	return status >= 400 && status < 500 && status != http.StatusRequestTimeout
+}
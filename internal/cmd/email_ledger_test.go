@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/auth"
+)
+
+func TestEmailFingerprint(t *testing.T) {
+	base := &api.SendEmailRequest{From: "a@example.com", To: []string{"b@example.com", "c@example.com"}, Subject: "Hi", Text: "Hello"}
+	same := &api.SendEmailRequest{From: "A@Example.com", To: []string{"c@example.com", "B@example.com"}, Subject: "Hi", Text: "Hello"}
+	if emailFingerprint(base) != emailFingerprint(same) {
+		t.Error("expected address case and recipient order not to matter")
+	}
+
+	for name, req := range map[string]*api.SendEmailRequest{
+		"subject":    {From: "a@example.com", To: base.To, Subject: "Hi!", Text: "Hello"},
+		"body":       {From: "a@example.com", To: base.To, Subject: "Hi", Text: "Hello again"},
+		"recipients": {From: "a@example.com", To: []string{"b@example.com"}, Subject: "Hi", Text: "Hello"},
+		"attachment": {From: "a@example.com", To: base.To, Subject: "Hi", Text: "Hello",
+			Attachments: []api.AttachmentData{{Filename: "a.txt", Content: []byte("YQ==")}}},
+	} {
+		if emailFingerprint(req) == emailFingerprint(base) {
+			t.Errorf("expected a different %s to change the fingerprint", name)
+		}
+	}
+}
+
+func TestSendLedger_Claim(t *testing.T) {
+	l := &sendLedger{dir: t.TempDir()}
+	now := time.Now()
+	entry := ledgerEntry{SentAt: now, To: []string{"b@example.com"}}
+
+	if prev, err := l.claim("fp", entry, 10*time.Minute, false); err != nil || prev != nil {
+		t.Fatalf("first claim: %v, %v", prev, err)
+	}
+	if prev, err := l.claim("fp", entry, 10*time.Minute, false); err != nil || prev == nil {
+		t.Fatalf("expected the second claim to return the first, got %v, %v", prev, err)
+	}
+	if prev, err := l.claim("fp", entry, 10*time.Minute, true); err != nil || prev != nil {
+		t.Errorf("expected force to replace the entry, got %v, %v", prev, err)
+	}
+
+	later := entry
+	later.SentAt = now.Add(11 * time.Minute)
+	if prev, err := l.claim("fp", later, 10*time.Minute, false); err != nil || prev != nil {
+		t.Errorf("expected an entry outside the window to be replaced, got %v, %v", prev, err)
+	}
+
+	l.release("fp")
+	if _, ok := l.lookup("fp", time.Hour, now); ok {
+		t.Error("expected release to forget the entry")
+	}
+}
+
+func TestEmailSend_Duplicate(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	srv := httptest.NewServer(mockserver.New(&mockserver.Seed{}))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetCommandFlags(emailSendCmd)
+		emailFromAddr, emailToAddrs, emailSubject, emailText = "", nil, "", ""
+	})
+
+	send := func(extra ...string) (string, error) {
+		t.Helper()
+		resetCommandFlags(emailSendCmd)
+		var stderr bytes.Buffer
+		rootCmd.SetOut(&bytes.Buffer{})
+		rootCmd.SetErr(&stderr)
+		rootCmd.SetArgs(append([]string{"email", "send", "--from", "a@example.com", "--to", "b@example.com",
+			"--subject", "Nightly report", "--text", "All good", "--yes"}, extra...))
+		err := rootCmd.Execute()
+		return stderr.String(), err
+	}
+
+	if _, err := send(); err != nil {
+		t.Fatalf("first send: %v", err)
+	}
+
+	stderr, err := send("--dry-run")
+	if err != nil || !strings.Contains(stderr, "an identical email to b@example.com was sent") {
+		t.Errorf("expected --dry-run to warn about the duplicate, got %v: %q", err, stderr)
+	}
+
+	_, err = send()
+	if err == nil || !strings.Contains(err.Error(), "use --allow-duplicate") {
+		t.Fatalf("expected the duplicate to be refused, got %v", err)
+	}
+
+	if _, err := send("--allow-duplicate"); err != nil {
+		t.Errorf("expected --allow-duplicate to send it again, got %v", err)
+	}
+	if _, err := send("--duplicate-window", "0"); err != nil {
+		t.Errorf("expected --duplicate-window 0 to skip the check, got %v", err)
+	}
+	if _, err := send("--text", "All good, again"); err != nil {
+		t.Errorf("expected a different body to be sent, got %v", err)
+	}
+}
+
+func TestEmailSend_FailedSendClaim(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	mock := mockserver.New(&mockserver.Seed{})
+	sendStatus := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/v1/emails" && sendStatus != http.StatusOK {
+			http.Error(w, `{"message":"send failed"}`, sendStatus)
+			return
+		}
+		mock.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetCommandFlags(emailSendCmd)
+		emailFromAddr, emailToAddrs, emailSubject, emailText = "", nil, "", ""
+	})
+
+	send := func(text string, extra ...string) error {
+		t.Helper()
+		resetCommandFlags(emailSendCmd)
+		rootCmd.SetOut(&bytes.Buffer{})
+		rootCmd.SetErr(&bytes.Buffer{})
+		rootCmd.SetArgs(append([]string{"email", "send", "--from", "a@example.com", "--to", "b@example.com",
+			"--subject", "Nightly report", "--text", text, "--yes"}, extra...))
+		return rootCmd.Execute()
+	}
+
+	// A rejected send certainly did not go out, so it may be sent again.
+	sendStatus = http.StatusBadRequest
+	if err := send("rejected"); err == nil {
+		t.Fatal("expected the rejected send to fail")
+	}
+	sendStatus = http.StatusOK
+	if err := send("rejected"); err != nil {
+		t.Errorf("expected a rejected email to be sendable again, got %v", err)
+	}
+
+	// After a server error the email may have gone out, so the claim stays.
+	sendStatus = http.StatusInternalServerError
+	err := send("ambiguous", "--duplicate-window", "1d")
+	if err == nil || !strings.Contains(err.Error(), "may have been sent") {
+		t.Fatalf("expected an ambiguous send failure, got %v", err)
+	}
+	sendStatus = http.StatusOK
+	if err := send("ambiguous", "--duplicate-window", "1d"); err == nil || !strings.Contains(err.Error(), "use --allow-duplicate") {
+		t.Errorf("expected a resend after a server error to be refused, got %v", err)
+	}
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/units"
+)
+
+// emailLimits returns the limits an email is checked against before sending:
+// the active profile's email_limits over the built-in defaults. The API does
+// not report limits, so nothing is fetched.
+func emailLimits() (api.EmailLimits, error) {
+	var limits api.EmailLimits
+	p, _ := activeProfile()
+	if c := p.EmailLimits; c != nil {
+		var err error
+		if c.MaxMessageSize != "" {
+			if limits.MaxMessageSize, err = units.ParseSize(c.MaxMessageSize); err != nil {
+				return limits, fmt.Errorf("invalid email_limits.max_message_size: %v", err)
+			}
+		}
+		if c.MaxAttachmentSize != "" {
+			if limits.MaxAttachmentSize, err = units.ParseSize(c.MaxAttachmentSize); err != nil {
+				return limits, fmt.Errorf("invalid email_limits.max_attachment_size: %v", err)
+			}
+		}
+		limits.MaxRecipients = c.MaxRecipients
+	}
+	return limits.WithDefaults(api.DefaultEmailLimits), nil
+}
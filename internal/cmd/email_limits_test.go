@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+)
+
+func TestEmailSend_Limits(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	big := filepath.Join(dir, "scan.pdf")
+	if err := os.WriteFile(big, bytes.Repeat([]byte("x"), 2048), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, "forwardemail"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	cfg := "current_profile: default\nprofiles:\n  default:\n    email_limits:\n      max_attachment_size: 1KB\n      max_recipients: 2\n"
+	if err := os.WriteFile(filepath.Join(dir, "forwardemail", "config.yaml"), []byte(cfg), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(mockserver.New(nil))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetCommandFlags(emailSendCmd)
+		emailFromAddr, emailToAddrs, emailSubject, emailText, emailAttachments = "", nil, "", "", nil
+	})
+
+	send := func(extra ...string) error {
+		t.Helper()
+		resetCommandFlags(emailSendCmd)
+		rootCmd.SetOut(&bytes.Buffer{})
+		rootCmd.SetErr(&bytes.Buffer{})
+		rootCmd.SetArgs(append([]string{"email", "send", "--from", "a@example.com", "--to", "b@example.com",
+			"--subject", "Hi", "--text", "Hello", "--yes"}, extra...))
+		return rootCmd.Execute()
+	}
+
+	err := send("--attach", big)
+	if err == nil || !strings.Contains(err.Error(), "attachment scan.pdf is 2.0 KB, over the limit of 1.0 KB per attachment") {
+		t.Fatalf("expected the attachment to be refused, got %v", err)
+	}
+
+	err = send("--cc", "c@example.com,d@example.com")
+	if err == nil || !strings.Contains(err.Error(), "recipient d@example.com exceeds the limit of 2 recipients") {
+		t.Fatalf("expected the third recipient to be refused, got %v", err)
+	}
+	if err := send("--cc", "c@example.com"); err != nil {
+		t.Fatalf("expected a send within the limits to succeed, got %v", err)
+	}
+}
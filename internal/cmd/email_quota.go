@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+// quotaLowFraction is the share of the daily limit below which a send warns
+// that the quota is running low.
+const quotaLowFraction = 0.1
+
+func init() {
+	emailSendCmd.Flags().Bool("force", false, "Send even if the daily quota would be exceeded (also skips confirmation)")
+	emailQuotaCmd.Flags().Bool("forecast", false, "Estimate when the quota resets and whether it lasts until then")
+}
+
+// checkSendQuota checks the daily quota before count emails are sent. It
+// refuses a send that would exceed the limit unless overage is allowed or
+// --force is set, and warns when the quota is running low. A quota that
+// cannot be fetched only produces a warning, leaving the API to decide.
+func checkSendQuota(ctx context.Context, cmd *cobra.Command, emails *api.EmailService, count int) error {
+	quota, err := emails.GetEmailQuota(ctx)
+	if err != nil {
+		cmd.PrintErrf("⚠️  Could not check the daily quota: %v\n", err)
+		return nil
+	}
+	if quota.EmailsLimit <= 0 {
+		return nil
+	}
+	remaining := quota.EmailsLimit - quota.EmailsSent
+	force, _ := cmd.Flags().GetBool("force")
+	switch {
+	case count > remaining && quota.OverageAllowed:
+		cmd.PrintErrf("⚠️  This exceeds the daily quota (%d of %d sent); it counts as overage\n", quota.EmailsSent, quota.EmailsLimit)
+	case count > remaining && force:
+		cmd.PrintErrf("⚠️  Sending despite the daily quota (%d of %d sent)\n", quota.EmailsSent, quota.EmailsLimit)
+	case count > remaining:
+		return fmt.Errorf("sending %d email(s) would exceed the daily quota: %d of %d sent, resets at %s (use --force to send anyway)",
+			count, quota.EmailsSent, quota.EmailsLimit, output.FormatTime(quota.ResetTime, time.RFC3339))
+	case float64(remaining-count) < quotaLowFraction*float64(quota.EmailsLimit):
+		cmd.PrintErrf("⚠️  %d of %d daily emails left after this send\n", remaining-count, quota.EmailsLimit)
+	}
+	return nil
+}
+
+// printQuotaForecast prints the forecast of quota in the selected output format.
+func printQuotaForecast(cmd *cobra.Command, quota *api.EmailQuota) error {
+	format, err := output.ParseFormat(viper.GetString("output"))
+	if err != nil {
+		return fmt.Errorf("invalid output format: %v", err)
+	}
+	forecast := output.NewQuotaForecast(quota, time.Now())
+	formatter := output.NewFormatter(format, cmd.OutOrStdout())
+	if format == output.FormatJSON || format == output.FormatYAML {
+		return formatter.Format(forecast)
+	}
+	tableData, err := output.FormatQuotaForecast(forecast, format)
+	if err != nil {
+		return fmt.Errorf("failed to format output: %v", err)
+	}
+	return formatter.Format(tableData)
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+func TestEmailSend_QuotaGate(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+quota:
+  emails_limit: 20
+  emails_sent: 18
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir()) // send ledger
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetCommandFlags(emailSendCmd)
+		emailFromAddr, emailToAddrs, emailSubject, emailText = "", nil, "", ""
+	})
+
+	send := func(extra ...string) (string, error) {
+		t.Helper()
+		resetCommandFlags(emailSendCmd)
+		var stderr bytes.Buffer
+		rootCmd.SetOut(&bytes.Buffer{})
+		rootCmd.SetErr(&stderr)
+		rootCmd.SetArgs(append([]string{"email", "send", "--from", "a@example.com", "--to", "b@example.com",
+			"--subject", "Hi", "--text", "Hello", "--yes", "--allow-duplicate"}, extra...))
+		err := rootCmd.Execute()
+		return stderr.String(), err
+	}
+
+	stderr, err := send()
+	if err != nil {
+		t.Fatalf("first send: %v", err)
+	}
+	if !strings.Contains(stderr, "1 of 20 daily emails left after this send") {
+		t.Errorf("expected a low quota warning, got %q", stderr)
+	}
+	if _, err := send(); err != nil {
+		t.Fatalf("second send: %v", err)
+	}
+
+	_, err = send()
+	if err == nil || !strings.Contains(err.Error(), "would exceed the daily quota: 20 of 20 sent") {
+		t.Fatalf("expected the quota to refuse the send, got %v", err)
+	}
+
+	// --force gets past the check; the mock server then enforces the limit itself.
+	stderr, err = send("--force")
+	if !strings.Contains(stderr, "Sending despite the daily quota") {
+		t.Errorf("expected a --force warning, got %q", stderr)
+	}
+	if err == nil || strings.Contains(err.Error(), "use --force") {
+		t.Errorf("expected the API to reject the forced send, got %v", err)
+	}
+}
+
+func TestEmailQuota_Forecast(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+quota:
+  emails_limit: 300
+  emails_sent: 42
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetCommandFlags(emailQuotaCmd)
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	})
+
+	run := func(args ...string) (string, error) {
+		t.Helper()
+		resetCommandFlags(emailQuotaCmd)
+		var stdout bytes.Buffer
+		rootCmd.SetOut(&stdout)
+		rootCmd.SetErr(&bytes.Buffer{})
+		rootCmd.SetArgs(append([]string{"email", "quota", "--forecast"}, args...))
+		err := rootCmd.Execute()
+		return stdout.String(), err
+	}
+
+	out, err := run("-o", "json")
+	if err != nil {
+		t.Fatalf("email quota --forecast: %v", err)
+	}
+	var f output.QuotaForecast
+	if err := json.Unmarshal([]byte(out), &f); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if f.EmailsSent != 42 || f.Remaining != 258 {
+		t.Errorf("unexpected forecast %+v", f)
+	}
+
+	out, err = run("-o", "table")
+	if err != nil {
+		t.Fatalf("email quota --forecast: %v", err)
+	}
+	if !strings.Contains(out, "Projected At Reset") {
+		t.Errorf("expected the forecast table, got %q", out)
+	}
+
+	if _, err := run("--all-profiles"); err == nil {
+		t.Error("expected --forecast with --all-profiles to fail")
+	}
+}
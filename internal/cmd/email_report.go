@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+// Report groupings understood by email report
+const (
+	reportGroupDay             = "day"
+	reportGroupRecipientDomain = "recipient-domain"
+	reportGroupStatus          = "status"
+)
+
+// emailReportPageSize is the page size used to fetch every email in the range.
+const emailReportPageSize = 100
+
+var (
+	emailReportSince   string
+	emailReportUntil   string
+	emailReportGroupBy string
+	emailReportHTML    string
+)
+
+// emailReportCmd represents the email report command
+var emailReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Summarize delivery outcomes of sent emails",
+	Long: `Aggregate sent emails into counts of delivered, bounced and failed messages
+and the bounce rate, grouped by day, recipient domain or status.
+
+An email to several recipient domains counts once for each domain. Use -o csv
+to export the report to a spreadsheet, or --report-html to write a standalone
+HTML page with charts.`,
+	Example: `  forward-email email report
+  forward-email email report --since 7d --group-by recipient-domain
+  forward-email email report --since 2024-05-01 --until 2024-05-31 -o csv > may.csv
+  forward-email email report --group-by recipient-domain --report-html delivery.html`,
+	Args: cobra.NoArgs,
+	RunE: runEmailReport,
+}
+
+func init() {
+	emailCmd.AddCommand(emailReportCmd)
+
+	emailReportCmd.Flags().StringVar(&emailReportSince, "since", "30d", "Start of the report (YYYY-MM-DD, today, yesterday, or a span such as 30d)")
+	emailReportCmd.Flags().StringVar(&emailReportUntil, "until", "", "End of the report (same forms as --since; default today)")
+	emailReportCmd.Flags().StringVar(&emailReportGroupBy, "group-by", reportGroupDay, "Group by day|recipient-domain|status")
+	addReportHTMLFlag(emailReportCmd, &emailReportHTML)
+}
+
+// listEmailsInRange fetches every page of emails sent within the date range,
+// following the API's next cursor when it pages with cursors.
+func listEmailsInRange(ctx context.Context, c *api.Client, dateFrom, dateTo string) ([]api.Email, error) {
+	opts := &api.ListEmailsOptions{Page: 1, Limit: emailReportPageSize, DateFrom: dateFrom, DateTo: dateTo}
+	var all []api.Email
+	for {
+		resp, err := c.Emails.ListEmails(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, resp.Emails...)
+		switch next := resp.Pagination.NextCursor; {
+		case next != "" && next != opts.Cursor:
+			opts.Cursor = next
+		case opts.Cursor != "" || len(resp.Emails) < emailReportPageSize:
+			return all, nil
+		default:
+			opts.Page++
+		}
+	}
+}
+
+// recipientDomains returns the distinct domains of an email's To and Cc headers.
+func recipientDomains(e *api.Email) []string {
+	seen := map[string]bool{}
+	var domains []string
+	for _, h := range []string{"To", "Cc"} {
+		addrs, err := mail.ParseAddressList(e.Headers[h])
+		if err != nil {
+			continue
+		}
+		for _, a := range addrs {
+			_, d, ok := strings.Cut(a.Address, "@")
+			d = strings.ToLower(d)
+			if ok && d != "" && !seen[d] {
+				seen[d] = true
+				domains = append(domains, d)
+			}
+		}
+	}
+	if len(domains) == 0 {
+		return []string{"(unknown)"}
+	}
+	return domains
+}
+
+// emailReportGroups returns the groups an email counts towards.
+func emailReportGroups(e *api.Email, groupBy string) []string {
+	switch groupBy {
+	case reportGroupRecipientDomain:
+		return recipientDomains(e)
+	case reportGroupStatus:
+		return []string{strings.ToLower(e.Status)}
+	default:
+		if e.SentAt.IsZero() {
+			return []string{"(unknown)"}
+		}
+		return []string{e.SentAt.UTC().Format(apiDateLayout)}
+	}
+}
+
+func countEmail(r *output.EmailReportRow, status string) {
+	r.Sent++
+	switch api.EmailStatus(strings.ToLower(status)) {
+	case api.EmailStatusDelivered:
+		r.Delivered++
+	case api.EmailStatusBounced:
+		r.Bounced++
+	case api.EmailStatusFailed:
+		r.Failed++
+	}
+}
+
+func setBounceRate(r *output.EmailReportRow) {
+	if r.Sent > 0 {
+		r.BounceRate = float64(r.Bounced) * 100 / float64(r.Sent)
+	}
+}
+
+// buildEmailReport aggregates emails by group. Days are listed in order;
+// other groupings by descending volume.
+func buildEmailReport(emails []api.Email, groupBy string) *output.EmailReport {
+	report := &output.EmailReport{GroupBy: groupBy, Total: output.EmailReportRow{Group: "TOTAL"}}
+	rows := map[string]*output.EmailReportRow{}
+	for i := range emails {
+		e := &emails[i]
+		countEmail(&report.Total, e.Status)
+		for _, g := range emailReportGroups(e, groupBy) {
+			r, ok := rows[g]
+			if !ok {
+				r = &output.EmailReportRow{Group: g}
+				rows[g] = r
+			}
+			countEmail(r, e.Status)
+		}
+	}
+
+	report.Groups = make([]output.EmailReportRow, 0, len(rows))
+	for _, r := range rows {
+		setBounceRate(r)
+		report.Groups = append(report.Groups, *r)
+	}
+	setBounceRate(&report.Total)
+	sort.Slice(report.Groups, func(i, j int) bool {
+		a, b := report.Groups[i], report.Groups[j]
+		if groupBy != reportGroupDay && a.Sent != b.Sent {
+			return a.Sent > b.Sent
+		}
+		return a.Group < b.Group
+	})
+	return report
+}
+
+func runEmailReport(cmd *cobra.Command, _ []string) error {
+	ctx := context.Background()
+
+	groupBy := strings.ToLower(strings.TrimSpace(emailReportGroupBy))
+	switch groupBy {
+	case reportGroupDay, reportGroupRecipientDomain, reportGroupStatus:
+	default:
+		return fmt.Errorf("invalid --group-by: %s (valid: day|recipient-domain|status)", emailReportGroupBy)
+	}
+	dateFrom, dateTo, err := resolveDateRange(emailReportSince, emailReportUntil, time.Now())
+	if err != nil {
+		return err
+	}
+
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %v", err)
+	}
+	emails, err := listEmailsInRange(ctx, apiClient, dateFrom, dateTo)
+	if err != nil {
+		return fmt.Errorf("failed to list emails: %v", err)
+	}
+
+	report := buildEmailReport(emails, groupBy)
+	report.Since, report.Until = dateFrom, dateTo
+	if emailReportHTML != "" {
+		page, err := output.NewEmailHTMLReport(report, time.Now())
+		if err != nil {
+			return err
+		}
+		if err := writeHTMLReportFile(cmd, emailReportHTML, page); err != nil {
+			return err
+		}
+	}
+
+	format, err := output.ParseFormat(viper.GetString("output"))
+	if err != nil {
+		return fmt.Errorf("invalid output format: %v", err)
+	}
+	formatter := output.NewFormatter(format, cmd.OutOrStdout())
+	if format == output.FormatJSON || format == output.FormatYAML {
+		return formatter.Format(report)
+	}
+	tableData, err := output.FormatEmailReport(report, format)
+	if err != nil {
+		return fmt.Errorf("failed to format output: %v", err)
+	}
+	return formatter.Format(tableData)
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/auth"
+)
+
+func reportEmails(now time.Time) []api.Email {
+	return []api.Email{
+		{ID: "1", Status: "delivered", SentAt: now.AddDate(0, 0, -1), Headers: map[string]string{"To": "a@gmail.com"}},
+		{ID: "2", Status: "bounced", SentAt: now.AddDate(0, 0, -1), Headers: map[string]string{"To": "Bob <b@Gmail.com>, c@corp.com"}},
+		{ID: "3", Status: "failed", SentAt: now.AddDate(0, 0, -2), Headers: map[string]string{"To": "d@corp.com"}},
+		{ID: "4", Status: "delivered", SentAt: now.AddDate(0, 0, -2)},
+	}
+}
+
+func TestBuildEmailReport(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+
+	byDomain := buildEmailReport(reportEmails(now), reportGroupRecipientDomain)
+	if len(byDomain.Groups) != 3 || byDomain.Groups[0].Group != "corp.com" || byDomain.Groups[1].Group != "gmail.com" {
+		t.Fatalf("unexpected domain groups: %+v", byDomain.Groups)
+	}
+	gmail := byDomain.Groups[1]
+	if gmail.Sent != 2 || gmail.Delivered != 1 || gmail.Bounced != 1 || gmail.BounceRate != 50 {
+		t.Errorf("unexpected gmail.com row: %+v", gmail)
+	}
+	if total := byDomain.Total; total.Sent != 4 || total.Bounced != 1 || total.Failed != 1 || total.BounceRate != 25 {
+		t.Errorf("unexpected total: %+v", total)
+	}
+
+	byDay := buildEmailReport(reportEmails(now), reportGroupDay)
+	if len(byDay.Groups) != 2 || byDay.Groups[0].Group != "2024-05-08" || byDay.Groups[1].Sent != 2 {
+		t.Errorf("unexpected day groups: %+v", byDay.Groups)
+	}
+}
+
+func TestEmailReport_CSV(t *testing.T) {
+	srv := httptest.NewServer(mockserver.New(&mockserver.Seed{Emails: reportEmails(time.Now().UTC())}))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		emailReportSince, emailReportGroupBy = "30d", reportGroupDay
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	})
+
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetErr(&out)
+	rootCmd.SetArgs([]string{"email", "report", "--since", "7d", "--group-by", "status", "-o", "csv"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("email report: %v\n%s", err, out.String())
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 5 || lines[0] != "STATUS,SENT,DELIVERED,BOUNCED,FAILED,BOUNCE RATE" {
+		t.Fatalf("unexpected CSV:\n%s", out.String())
+	}
+	if lines[1] != "delivered,2,2,0,0,0.0%" || lines[4] != "TOTAL,4,2,1,1,25.0%" {
+		t.Errorf("unexpected CSV rows:\n%s", out.String())
+	}
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+func init() {
+	emailGetCmd.Flags().Bool("headers", false, "Show the full message headers instead of the details")
+	emailGetCmd.Flags().String("save-eml", "", "Save the message as an .eml file (- for stdout)")
+}
+
+// mimeHeaders are set by the rebuilt message itself, not copied from the API.
+var mimeHeaders = []string{"Content-Type", "Content-Transfer-Encoding", "Mime-Version"}
+
+// printEmailHeaders prints the message headers: as a name-to-value document
+// for JSON and YAML, and otherwise as they appear in the message, so that
+// they can be read or grepped like the source. The raw source is used when
+// the API sends it, as it keeps the order and repeated headers such as
+// Received.
+func printEmailHeaders(cmd *cobra.Command, email *api.Email) error {
+	format, err := output.ParseFormat(viper.GetString("output"))
+	if err != nil {
+		return fmt.Errorf("invalid output format: %v", err)
+	}
+	if format == output.FormatJSON || format == output.FormatYAML {
+		return output.NewFormatter(format, cmd.OutOrStdout()).Format(email.Headers)
+	}
+	out := cmd.OutOrStdout()
+	if email.Message != "" {
+		header, _, _ := cutHeaderBlock(email.Message)
+		_, err := fmt.Fprintln(out, strings.TrimRight(header, "\r\n"))
+		return err
+	}
+	for _, name := range sortedHeaderNames(email.Headers) {
+		if _, err := fmt.Fprintf(out, "%s: %s\n", name, email.Headers[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveEmailEML writes the message to path, or to stdout for "-". The raw
+// source is saved as is when the API sends it; otherwise a message is
+// rebuilt from the headers and the text and HTML bodies, which is said on
+// stderr since signatures such as DKIM no longer verify against it.
+func saveEmailEML(cmd *cobra.Command, email *api.Email, path string) error {
+	data := []byte(email.Message)
+	if email.Message == "" {
+		var err error
+		if data, err = buildEML(email); err != nil {
+			return fmt.Errorf("failed to build message: %v", err)
+		}
+		cmd.PrintErrln("⚠️  The API did not return the message source; saving a copy rebuilt from its headers and body (DKIM signatures will not verify)")
+	}
+	if path == "-" {
+		_, err := cmd.OutOrStdout().Write(data)
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to save message: %v", err)
+	}
+	cmd.PrintErrf("Saved email %s to %s\n", email.ID, path)
+	return nil
+}
+
+// buildEML rebuilds an RFC 5322 message from the email's headers and bodies:
+// text or HTML alone, or both as multipart/alternative, quoted-printable
+// encoded.
+func buildEML(email *api.Email) ([]byte, error) {
+	var b bytes.Buffer
+	headers := make(map[string]string, len(email.Headers)+1)
+	for name, value := range email.Headers {
+		name = textproto.CanonicalMIMEHeaderKey(name)
+		if !hasEntry(mimeHeaders, name) {
+			headers[name] = value
+		}
+	}
+	if _, ok := headers["Subject"]; !ok && email.Subject != "" {
+		headers["Subject"] = mime.QEncoding.Encode("utf-8", email.Subject)
+	}
+	for _, name := range sortedHeaderNames(headers) {
+		fmt.Fprintf(&b, "%s: %s\r\n", name, headers[name])
+	}
+	b.WriteString("MIME-Version: 1.0\r\n")
+
+	if email.Text == "" || email.HTML == "" {
+		body, contentType := email.Text, "text/plain"
+		if body == "" {
+			body, contentType = email.HTML, "text/html"
+		}
+		fmt.Fprintf(&b, "Content-Type: %s; charset=utf-8\r\nContent-Transfer-Encoding: quoted-printable\r\n\r\n", contentType)
+		if err := writeQuotedPrintable(&b, body); err != nil {
+			return nil, err
+		}
+		return b.Bytes(), nil
+	}
+
+	var parts bytes.Buffer
+	mw := multipart.NewWriter(&parts)
+	for _, p := range []struct{ contentType, body string }{{"text/plain", email.Text}, {"text/html", email.HTML}} {
+		w, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {p.contentType + "; charset=utf-8"},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeQuotedPrintable(w, p.body); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(&b, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", mw.Boundary())
+	b.Write(parts.Bytes())
+	return b.Bytes(), nil
+}
+
+func writeQuotedPrintable(w io.Writer, s string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(s)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+// cutHeaderBlock splits a message source at the blank line that ends the
+// headers, with CRLF or LF line endings.
+func cutHeaderBlock(source string) (header, body string, found bool) {
+	i, sep := strings.Index(source, "\n\n"), "\n\n"
+	if j := strings.Index(source, "\r\n\r\n"); j >= 0 && (i < 0 || j < i) {
+		i, sep = j, "\r\n\r\n"
+	}
+	if i < 0 {
+		return source, "", false
+	}
+	return source[:i], source[i+len(sep):], true
+}
+
+func sortedHeaderNames(headers map[string]string) []string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/http/httptest"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+)
+
+func TestEmailGet_HeadersAndEML(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+emails:
+  - id: raw
+    subject: Report
+    status: delivered
+    headers: {From: a@example.com, Subject: Report}
+    message: "Received: from mx1\r\nReceived: from mx2\r\nAuthentication-Results: mx; dkim=pass\r\nFrom: a@example.com\r\nSubject: Report\r\n\r\nBody\r\n"
+  - id: rebuilt
+    subject: Café
+    status: sent
+    headers: {From: a@example.com, To: b@example.org, Content-Type: text/plain}
+    text: "Hello"
+    html: "<p>Hello</p>"
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	t.Cleanup(srv.Close)
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetCommandFlags(emailGetCmd)
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	})
+
+	run := func(args ...string) (string, string) {
+		t.Helper()
+		resetCommandFlags(emailGetCmd)
+		var out, errOut bytes.Buffer
+		rootCmd.SetOut(&out)
+		rootCmd.SetErr(&errOut)
+		rootCmd.SetArgs(append([]string{"email", "get"}, args...))
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("%v: %v\n%s", args, err, errOut.String())
+		}
+		return out.String(), errOut.String()
+	}
+
+	// The source keeps the order and repeated headers
+	out, _ := run("raw", "--headers")
+	if !strings.HasPrefix(out, "Received: from mx1\r\nReceived: from mx2\r\nAuthentication-Results: mx; dkim=pass") || strings.Contains(out, "Body") {
+		t.Errorf("unexpected headers %q", out)
+	}
+	out, _ = run("rebuilt", "--headers")
+	if out != "Content-Type: text/plain\nFrom: a@example.com\nTo: b@example.org\n" {
+		t.Errorf("unexpected headers %q", out)
+	}
+
+	out, _ = run("raw", "--save-eml", "-")
+	if !strings.HasSuffix(out, "\r\n\r\nBody\r\n") {
+		t.Errorf("expected the source as is, got %q", out)
+	}
+
+	path := filepath.Join(t.TempDir(), "rebuilt.eml")
+	_, errOut := run("rebuilt", "--save-eml", path)
+	if !strings.Contains(errOut, "rebuilt from its headers and body") || !strings.Contains(errOut, "Saved email rebuilt") {
+		t.Errorf("expected a warning and a confirmation, got %q", errOut)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("saved message does not parse: %v\n%s", err, data)
+	}
+	if msg.Header.Get("To") != "b@example.org" || !strings.HasPrefix(msg.Header.Get("Content-Type"), "multipart/alternative") {
+		t.Errorf("unexpected headers %v", msg.Header)
+	}
+	if subject, _ := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject")); subject != "Café" {
+		t.Errorf("unexpected subject %q", subject)
+	}
+	body, _ := io.ReadAll(msg.Body)
+	if !strings.Contains(string(body), "<p>Hello</p>") {
+		t.Errorf("expected both bodies, got %s", body)
+	}
+}
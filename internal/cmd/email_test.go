@@ -1,9 +1,24 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
 	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/auth"
+	"github.com/ginsys/forward-email/pkg/output"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -197,7 +212,7 @@ func TestValidateEmailRequest_SecurityFix(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validateEmailRequest(tt.req)
+			err := validateEmailRequest(tt.req, api.DefaultEmailLimits)
 
 			if tt.wantErr {
 				require.Error(t, err, "Expected validation to fail for test case: %s", tt.name)
@@ -223,7 +238,7 @@ func TestValidateEmailRequest_EmptyAddressHandling(t *testing.T) {
 		Text:    "Test message",
 	}
 
-	err := validateEmailRequest(req)
+	err := validateEmailRequest(req, api.DefaultEmailLimits)
 	assert.NoError(t, err, "Empty strings in address arrays should be ignored")
 }
 
@@ -271,7 +286,7 @@ func TestValidateEmailRequest_RFC5322Compliance(t *testing.T) {
 				Subject: "Test",
 				Text:    "Test",
 			}
-			err := validateEmailRequest(req)
+			err := validateEmailRequest(req, api.DefaultEmailLimits)
 			assert.NoError(t, err, "Email should be valid: %s", email)
 		})
 	}
@@ -285,7 +300,7 @@ func TestValidateEmailRequest_RFC5322Compliance(t *testing.T) {
 				Subject: "Test",
 				Text:    "Test",
 			}
-			err := validateEmailRequest(req)
+			err := validateEmailRequest(req, api.DefaultEmailLimits)
 			assert.Error(t, err, "Email should be invalid: %s", email)
 			assert.Contains(t, err.Error(), "invalid email address",
 				"Error should mention invalid email address for: %s", email)
@@ -300,9 +315,202 @@ func TestValidateEmailRequest_RFC5322Compliance(t *testing.T) {
 			Subject: "Test",
 			Text:    "Test",
 		}
-		err := validateEmailRequest(req)
+		err := validateEmailRequest(req, api.DefaultEmailLimits)
 		assert.Error(t, err, "Empty from should be invalid")
 		assert.Contains(t, err.Error(), "from address is required",
 			"Error should mention from address is required")
 	})
 }
+
+func TestEmailList_RelativeDatesAndStatus(t *testing.T) {
+	now := time.Now().UTC()
+	seed := &mockserver.Seed{Emails: []api.Email{
+		{ID: "recent", Subject: "Recent", Status: "delivered", SentAt: now.Add(-24 * time.Hour)},
+		{ID: "old", Subject: "Old", Status: "delivered", SentAt: now.AddDate(0, 0, -30)},
+		{ID: "bounce", Subject: "Bounce", Status: "bounced", SentAt: now.Add(-48 * time.Hour)},
+	}}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		emailSince, emailStatus = "", ""
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	})
+
+	list := func(args ...string) ([]api.Email, error) {
+		var out, errOut bytes.Buffer
+		rootCmd.SetOut(&out)
+		rootCmd.SetErr(&errOut)
+		rootCmd.SetArgs(append([]string{"email", "list", "-o", "json"}, args...))
+		if err := rootCmd.Execute(); err != nil {
+			return nil, err
+		}
+		var emails []api.Email
+		require.NoError(t, json.Unmarshal(out.Bytes(), &emails), out.String())
+		return emails, nil
+	}
+
+	emails, err := list("--since", "7d")
+	require.NoError(t, err)
+	assert.Len(t, emails, 2)
+
+	emails, err = list("--since", "7d", "--status", "Bounced")
+	require.NoError(t, err)
+	require.Len(t, emails, 1)
+	assert.Equal(t, "bounce", emails[0].ID)
+
+	_, err = list("--status", "queued")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Valid statuses: sent, delivered, bounced, failed")
+}
+
+func TestEmailList_Columns(t *testing.T) {
+	subject := "Quarterly report for the operations team and the full appendix"
+	seed := &mockserver.Seed{Emails: []api.Email{{ID: "e1", Subject: subject, Status: "delivered", SentAt: time.Now()}}}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() { resetCommandFlags(emailListCmd) })
+
+	var out, errOut bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetErr(&errOut)
+	rootCmd.SetArgs([]string{"email", "list", "--columns", "subject,status", "--full-subject", "-o", "csv"})
+	require.NoError(t, rootCmd.Execute(), errOut.String())
+	assert.Equal(t, "SUBJECT,STATUS\n"+subject+",✅ Delivered\n", out.String())
+	resetCommandFlags(emailListCmd)
+
+	rootCmd.SetArgs([]string{"email", "list", "--columns", "subjct"})
+	err := rootCmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid column 'subjct'. Did you mean 'subject'?")
+}
+
+func TestPromptForEmail_AttachmentsHTMLAndHeaders(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "report-2024.pdf"), []byte("%PDF-1.4 test"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("notes"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.md"), []byte("notes"), 0o600))
+
+	input := strings.Join([]string{
+		"me@example.com",
+		"a@example.com, b@example.com",
+		"",
+		"Quarterly report",
+		"html",
+		"<p>Hello</p>",
+		"END",
+		"X-Campaign: q2",
+		"broken header",
+		"",
+		filepath.Join(dir, "report"), // completed to the only match
+		filepath.Join(dir, "notes"),  // ambiguous, lists matches
+		filepath.Join(dir, "notes.txt"),
+		filepath.Join(dir, "missing.bin"),
+		"",
+	}, "\n") + "\n"
+
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetIn(strings.NewReader(input))
+	cmd.SetErr(&out)
+
+	req, err := promptForEmail(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a@example.com", "b@example.com"}, req.To)
+	assert.Empty(t, req.CC)
+	assert.Equal(t, "<p>Hello</p>", req.HTML)
+	assert.Empty(t, req.Text)
+	assert.Equal(t, map[string]string{"X-Campaign": "q2"}, req.Headers)
+	require.Len(t, req.Attachments, 2)
+	assert.Equal(t, "report-2024.pdf", req.Attachments[0].Filename)
+	assert.Equal(t, "notes.txt", req.Attachments[1].Filename)
+
+	text := out.String()
+	assert.Contains(t, text, "invalid header format: broken header")
+	assert.Contains(t, text, "matches: ")
+	assert.Contains(t, text, "no such file")
+	assert.Contains(t, text, "2 files")
+}
+
+func TestBuildEmailFromFlags_AttachmentTypes(t *testing.T) {
+	dir := t.TempDir()
+	png := filepath.Join(dir, "scan") // no extension: type is sniffed
+	require.NoError(t, os.WriteFile(png, []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"), 0o600))
+	pdf := filepath.Join(dir, "invoice.pdf")
+	require.NoError(t, os.WriteFile(pdf, []byte("%PDF-1.4"), 0o600))
+	blob := filepath.Join(dir, "data.bin")
+	require.NoError(t, os.WriteFile(blob, []byte{0, 1, 2}, 0o600))
+
+	emailAttachments = []string{png, pdf, blob}
+	emailAttachTypes = []string{"data.bin=application/x-foo"}
+	emailAttachNames = []string{pdf + "=invoice-2024.pdf"}
+	t.Cleanup(func() { emailAttachments, emailAttachTypes, emailAttachNames = nil, nil, nil })
+
+	req, err := buildEmailFromFlags()
+	require.NoError(t, err)
+	require.Len(t, req.Attachments, 3)
+	assert.Equal(t, "image/png", req.Attachments[0].ContentType)
+	assert.Equal(t, "scan", req.Attachments[0].Filename)
+	assert.Equal(t, "application/pdf", req.Attachments[1].ContentType)
+	assert.Equal(t, "invoice-2024.pdf", req.Attachments[1].Filename)
+	assert.Equal(t, "application/x-foo", req.Attachments[2].ContentType)
+
+	emailAttachTypes = []string{"other.bin=application/x-foo"}
+	_, err = buildEmailFromFlags()
+	assert.ErrorContains(t, err, "does not match any --attach file")
+
+	emailAttachTypes = []string{"data.bin"}
+	_, err = buildEmailFromFlags()
+	assert.ErrorContains(t, err, "expected <file>=<value>")
+}
+
+func TestDetectContentType(t *testing.T) {
+	// Register the generic mapping many mime.types files have for .bin, on an
+	// extension of our own so the result does not depend on the host.
+	require.NoError(t, mime.AddExtensionType(".fe-bin", "application/octet-stream"))
+	pngData := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	assert.Equal(t, "image/png", detectContentType("scan.fe-bin", pngData), "generic type is sniffed")
+	assert.Equal(t, "image/png", detectContentType("scan.fe-unknown", pngData), "unknown extension is sniffed")
+	assert.Equal(t, "application/pdf", detectContentType("invoice.pdf", []byte("not a pdf")), "specific type is kept")
+	assert.Equal(t, "application/octet-stream", detectContentType("blob.fe-bin", []byte{0, 1, 2}))
+}
+
+func TestEmailList_TimeFormat(t *testing.T) {
+	sent := time.Date(2024, 3, 10, 9, 30, 0, 0, time.UTC)
+	seed := &mockserver.Seed{Emails: []api.Email{{ID: "e1", Subject: "Report", Status: "delivered", SentAt: sent}}}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetCommandFlags(emailListCmd)
+		_ = rootCmd.PersistentFlags().Set("time-format", "")
+		_ = rootCmd.PersistentFlags().Set("timezone", "")
+		output.SetTimeFormat(output.TimeDefault, nil)
+	})
+
+	var out, errOut bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetErr(&errOut)
+	rootCmd.SetArgs([]string{"email", "list", "--columns", "id,sent", "-o", "csv", "--time-format", "iso", "--timezone", "Asia/Tokyo"})
+	require.NoError(t, rootCmd.Execute(), errOut.String())
+	assert.Equal(t, "ID,SENT\ne1,2024-03-10T18:30:00+09:00\n", out.String())
+
+	rootCmd.SetArgs([]string{"email", "list", "--time-format", "fuzzy"})
+	err := rootCmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported time format: fuzzy")
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+// listEnvelope wraps the JSON output of a list command with the metadata that
+// is otherwise only shown in the table footer.
+type listEnvelope struct {
+	Data       any              `json:"data"`
+	Pagination *api.Pagination  `json:"pagination,omitempty"`
+	Warnings   []partialFailure `json:"warnings"`
+	Request    envelopeRequest  `json:"request"`
+}
+
+type envelopeRequest struct {
+	DurationMS int64 `json:"duration_ms"`
+}
+
+func init() {
+	addEnvelopeFlag(domainListCmd, aliasListCmd, emailListCmd)
+}
+
+// addEnvelopeFlag registers --envelope on list commands.
+func addEnvelopeFlag(cmds ...*cobra.Command) {
+	for _, c := range cmds {
+		c.Flags().Bool("envelope", false, "Wrap JSON output as {data, pagination, warnings, request}")
+	}
+}
+
+// envelopeSet reports whether --envelope was given, which needs JSON output.
+func envelopeSet(cmd *cobra.Command, format output.Format) (bool, error) {
+	envelope, _ := cmd.Flags().GetBool("envelope")
+	if envelope && format != output.FormatJSON {
+		return false, fmt.Errorf("--envelope requires --output json")
+	}
+	return envelope, nil
+}
+
+// writeEnvelope prints data in a list envelope. start is when the command
+// began, so the duration covers every API request it made.
+func writeEnvelope(cmd *cobra.Command, start time.Time, data any, page *api.Pagination, warnings []partialFailure) error {
+	if warnings == nil {
+		warnings = []partialFailure{}
+	}
+	return output.NewFormatter(output.FormatJSON, cmd.OutOrStdout()).Format(listEnvelope{
+		Data:       data,
+		Pagination: page,
+		Warnings:   warnings,
+		Request:    envelopeRequest{DurationMS: time.Since(start).Milliseconds()},
+	})
+}
+
+// listPagination describes one page of a list whose response only carries
+// totals.
+func listPagination(page, limit, total, totalPages int) *api.Pagination {
+	return &api.Pagination{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
+	}
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+)
+
+func TestListEnvelope(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    aliases:
+      - name: info
+        recipients: [me@example.org]
+      - name: sales
+        recipients: [team@example.org]
+  - name: example.org
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetAliasFlags()
+		resetCommandFlags(domainListCmd)
+		resetCommandFlags(aliasListCmd)
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	})
+
+	run := func(args ...string) (envelope map[string]any, err error) {
+		var stdout, stderr bytes.Buffer
+		rootCmd.SetOut(&stdout)
+		rootCmd.SetErr(&stderr)
+		rootCmd.SetArgs(args)
+		out := captureStdout(t, func() { err = rootCmd.Execute() })
+		resetCommandFlags(domainListCmd)
+		resetCommandFlags(aliasListCmd)
+		if err != nil {
+			return nil, err
+		}
+		stdout.WriteString(out)
+		if jsonErr := json.Unmarshal(stdout.Bytes(), &envelope); jsonErr != nil {
+			t.Fatalf("%v: stdout is not a JSON object: %v\n%s", args, jsonErr, stdout.String())
+		}
+		return envelope, nil
+	}
+
+	env, err := run("domain", "list", "-o", "json", "--envelope")
+	if err != nil {
+		t.Fatalf("domain list: %v", err)
+	}
+	if data, _ := env["data"].([]any); len(data) != 2 {
+		t.Errorf("expected 2 domains in data, got %v", env["data"])
+	}
+	page, _ := env["pagination"].(map[string]any)
+	if page["total"] != float64(2) || page["page"] != float64(1) {
+		t.Errorf("unexpected pagination: %v", env["pagination"])
+	}
+	if _, ok := env["request"].(map[string]any)["duration_ms"]; !ok {
+		t.Errorf("expected request.duration_ms, got %v", env["request"])
+	}
+
+	// A domain that cannot be listed becomes a warning instead of a log line only.
+	env, err = run("alias", "list", "example.com,missing.example", "-o", "json", "--envelope")
+	if err != nil {
+		t.Fatalf("alias list: %v", err)
+	}
+	if data, _ := env["data"].([]any); len(data) != 2 {
+		t.Errorf("expected 2 aliases in data, got %v", env["data"])
+	}
+	warnings, _ := env["warnings"].([]any)
+	if len(warnings) != 1 {
+		t.Fatalf("expected one warning for missing.example, got %v", env["warnings"])
+	}
+	if w, _ := warnings[0].(map[string]any); w["domain"] != "missing.example" || w["error"] == "" || w["retryable"] != false {
+		t.Errorf("expected a structured warning for missing.example, got %v", warnings[0])
+	}
+	if page, _ := env["pagination"].(map[string]any); page["total"] != float64(2) || page["has_next"] != false {
+		t.Errorf("unexpected pagination: %v", env["pagination"])
+	}
+
+	// An empty list is still an envelope, not a "No aliases found" message.
+	env, err = run("alias", "list", "example.org", "-o", "json", "--envelope")
+	if err != nil {
+		t.Fatalf("alias list: %v", err)
+	}
+	if data, ok := env["data"].([]any); !ok || len(data) != 0 {
+		t.Errorf("expected empty data list, got %v", env["data"])
+	}
+
+	if _, err := run("domain", "list", "-o", "table", "--envelope"); err == nil ||
+		!strings.Contains(err.Error(), "--envelope requires --output json") {
+		t.Errorf("expected an error for --envelope without json, got %v", err)
+	}
+}
@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+// startGHASummary opens a command's section of the GitHub Actions step
+// summary with a heading; the table the command prints follows it.
+func startGHASummary(title string) error {
+	return output.AppendStepSummary("### " + title + "\n\n")
+}
+
+// finishGHASummary closes the section with note, if any, and writes the
+// command's findings as annotations to stdout, where the runner picks them up.
+func finishGHASummary(cmd *cobra.Command, note string, annotations []output.Annotation) error {
+	if note != "" {
+		if err := output.AppendStepSummary(note + "\n\n"); err != nil {
+			return err
+		}
+	}
+	return output.WriteAnnotations(cmd.OutOrStdout(), annotations)
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+func TestOutputGHA(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    has_dmarc_record: true
+    has_spf_record: true
+    has_dkim_record: true
+    dkim_modulus_length: 2048
+    retention_days: 14
+    settings:
+      has_virus_protection: true
+      has_phishing_protection: true
+      has_executable_protection: true
+    aliases:
+      - name: info
+        recipients: [me@example.org]
+  - name: example.org
+quota:
+  emails_sent: 95
+  emails_limit: 100
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		alertThresholds = nil
+		resetCommandFlags(alertsCheckCmd)
+		resetCommandFlags(domainAuditCmd)
+		domainAuditMinScore = 100
+		aliasSyncDryRun, aliasSyncMode = false, "merge"
+		resetCommandFlags(aliasSyncCmd)
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	})
+
+	summary := filepath.Join(t.TempDir(), "summary.md")
+	t.Setenv(output.StepSummaryEnv, summary)
+	run := func(args ...string) (string, error) {
+		var stdout, stderr bytes.Buffer
+		rootCmd.SetOut(&stdout)
+		rootCmd.SetErr(&stderr)
+		rootCmd.SetArgs(args)
+		err := rootCmd.Execute()
+		return stdout.String(), err
+	}
+	readSummary := func() string {
+		data, err := os.ReadFile(summary) //nolint:gosec // path is inside the test's temp dir
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = os.Remove(summary)
+		return string(data)
+	}
+
+	stdout, err := run("alerts", "check", "--threshold", "emails=90%", "-o", "gha")
+	if err == nil {
+		t.Fatal("expected the breached quota to fail the check")
+	}
+	if !strings.Contains(stdout, "::error title=Quota alert::account emails at 95.0%25 (threshold 90%25)\n") {
+		t.Errorf("expected an error annotation, got:\n%s", stdout)
+	}
+	got := readSummary()
+	for _, want := range []string{"### Quota alerts\n", "| SCOPE | METRIC |", "❌ 1 quota(s) at or above threshold."} {
+		if !strings.Contains(got, want) {
+			t.Errorf("step summary lacks %q:\n%s", want, got)
+		}
+	}
+
+	// Failed rules are warnings while the score still meets --min-score.
+	stdout, err = run("domain", "audit", "example.com", "--min-score", "80", "-o", "gha")
+	if err != nil {
+		t.Fatalf("domain audit: %v", err)
+	}
+	if !strings.Contains(stdout, "::warning title=example.com%3A protection%3Aadult_content::disabled\n") {
+		t.Errorf("expected a warning annotation, got:\n%s", stdout)
+	}
+	got = readSummary()
+	if !strings.Contains(got, "### Domain audit: example.com\n") || !strings.Contains(got, "Score: **88%**") {
+		t.Errorf("unexpected step summary:\n%s", got)
+	}
+
+	stdout, err = run("alias", "sync", "example.com", "example.org", "--dry-run", "-o", "gha")
+	if err != nil {
+		t.Fatalf("alias sync: %v", err)
+	}
+	if !strings.Contains(stdout, "::notice title=Alias sync::create info@example.org\n") {
+		t.Errorf("expected a notice per planned change, got:\n%s", stdout)
+	}
+	if got := readSummary(); !strings.Contains(got, "### Alias sync plan: example.com → example.org") {
+		t.Errorf("unexpected step summary:\n%s", got)
+	}
+}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"bytes"
+	"flag"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+	outschema "github.com/ginsys/forward-email/pkg/output/schema"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata/golden")
+
+// TestJSONGolden pins the JSON documents of the main read commands to the
+// golden files in testdata/golden/<schema version>. Within a schema version a
+// golden file may only gain fields: a rename, removal or type change needs a
+// new version in pkg/output/schema with a converter for the old shape.
+// Regenerate after an additive change with: go test ./internal/cmd -run TestJSONGolden -update
+func TestJSONGolden(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - id: d1
+    name: example.com
+    plan: enhanced_protection
+    has_mx_record: true
+    has_txt_record: true
+    created_at: 2024-01-02T03:04:05Z
+    updated_at: 2024-02-03T04:05:06Z
+    aliases:
+      - id: a1
+        name: info
+        recipients: [me@example.org]
+        labels: [support]
+        is_enabled: true
+        created_at: 2024-01-02T03:04:05Z
+        updated_at: 2024-01-02T03:04:05Z
+      - id: a2
+        name: sales
+        recipients: [team@example.org, https://hooks.example.org/sales]
+        created_at: 2024-01-03T03:04:05Z
+        updated_at: 2024-01-03T03:04:05Z
+emails:
+  - id: e1
+    subject: Welcome
+    status: delivered
+    created_at: 2024-01-04T03:04:05Z
+    updated_at: 2024-01-04T03:04:05Z
+    sent_at: 2024-01-04T03:04:05Z
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetAliasFlags()
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+		_ = rootCmd.PersistentFlags().Set("schema-version", "")
+	})
+
+	commands := [][]string{
+		{"domain", "list"},
+		{"domain", "get", "example.com"},
+		{"alias", "list", "example.com"},
+		{"alias", "get", "example.com", "info"},
+		{"email", "list"},
+		{"email", "get", "e1"},
+	}
+	for _, version := range outschema.Versions() {
+		for _, args := range commands {
+			name := strings.Join(args[:2], "-")
+			t.Run(version.Name+"/"+name, func(t *testing.T) {
+				var stdout, stderr bytes.Buffer
+				rootCmd.SetOut(&stdout)
+				rootCmd.SetErr(&stderr)
+				rootCmd.SetArgs(append(args, "-o", "json", "--schema-version", version.Name))
+				var err error
+				stdout.WriteString(captureStdout(t, func() { err = rootCmd.Execute() }))
+				if c, _, findErr := rootCmd.Find(args); findErr == nil {
+					resetCommandFlags(c)
+				}
+				if err != nil {
+					t.Fatalf("%v: %v\n%s", args, err, stderr.String())
+				}
+
+				path := filepath.Join("testdata", "golden", version.Name, name+".json")
+				if *updateGolden {
+					if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+						t.Fatal(err)
+					}
+					if err := os.WriteFile(path, stdout.Bytes(), 0o600); err != nil {
+						t.Fatal(err)
+					}
+					return
+				}
+				want, err := os.ReadFile(path) // #nosec G304 -- fixed test data path
+				if err != nil {
+					t.Fatalf("missing golden file (run with -update to create it): %v", err)
+				}
+				if stdout.String() != string(want) {
+					t.Errorf("%v -o json --schema-version %s differs from %s:\n%s\nwant:\n%s",
+						args, version.Name, path, stdout.String(), want)
+				}
+			})
+		}
+	}
+}
+
+func TestSchemaVersionFlag(t *testing.T) {
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+		_ = rootCmd.PersistentFlags().Set("schema-version", "")
+	})
+
+	var stdout, stderr bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetArgs([]string{"schema", "versions", "-o", "json", "--schema-version", "v9"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), `unknown schema version "v9"`) {
+		t.Errorf("expected an unknown version error, got %v", err)
+	}
+
+	stdout.Reset()
+	rootCmd.SetArgs([]string{"schema", "versions", "--schema-version", ""})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if got := stdout.String(); got != outschema.Current()+" (current)\n" {
+		t.Errorf("schema versions printed %q", got)
+	}
+}
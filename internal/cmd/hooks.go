@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/ginsys/forward-email/pkg/planner"
+)
+
+// Hook phases. A hook is named <phase>_<command>, e.g. pre_delete or
+// post_alias_create.
+const (
+	hookPre  = "pre"
+	hookPost = "post"
+)
+
+// hookEvent is the JSON document a hook receives on stdin.
+type hookEvent struct {
+	Hook    string            `json:"hook"`
+	Phase   string            `json:"phase"`
+	Command string            `json:"command"`
+	Args    []string          `json:"args"`
+	Flags   map[string]string `json:"flags,omitempty"`
+	Profile string            `json:"profile,omitempty"`
+	Time    time.Time         `json:"time"`
+	// Resources lists what the command changed; post hooks only.
+	Resources []hookResource `json:"resources,omitempty"`
+}
+
+// hookResource is a resource changed by a command, e.g. the alias created
+// or the domain deleted.
+type hookResource struct {
+	Type   string `json:"type"` // alias, domain, member, email
+	Action string `json:"action"`
+	Domain string `json:"domain,omitempty"`
+	Name   string `json:"name,omitempty"`
+	ID     string `json:"id,omitempty"`
+	Data   any    `json:"data,omitempty"` // the resource as returned by the API, if any
+}
+
+// hookResources collects the resources changed by the current run. Mutating
+// commands add to it with reportMutation once a change has actually been
+// made; post hooks only run when it is not empty, so a declined confirmation
+// or a dry run does not fire them.
+var hookResources []hookResource
+
+// reportMutation records that the running command changed r.
+func reportMutation(r hookResource) {
+	hookResources = append(hookResources, r)
+}
+
+// actionResource describes an applied sync or import action.
+func actionResource(a planner.Action, id string) hookResource {
+	if id == "" {
+		id = a.AliasID
+	}
+	return hookResource{Type: "alias", Action: string(a.Type), Domain: a.Domain, Name: a.Name, ID: id}
+}
+
+func init() {
+	rootCmd.PersistentPostRunE = runPostHooks
+}
+
+// runPreHooks runs the profile's pre_ hooks for cmd. A hook exiting non-zero
+// stops the command, which makes pre hooks usable as approval gates.
+func runPreHooks(cmd *cobra.Command, args []string) error {
+	hookResources = nil
+	return runHooks(cmd, args, hookPre)
+}
+
+// runPostHooks runs the profile's post_ hooks once cmd has succeeded and
+// reported a change. The command has already taken effect, so hook failures
+// are only reported.
+func runPostHooks(cmd *cobra.Command, args []string) error {
+	if len(hookResources) == 0 {
+		return nil
+	}
+	if err := runHooks(cmd, args, hookPost); err != nil {
+		slog.Warn("post hook failed", "error", err)
+	}
+	return nil
+}
+
+func runHooks(cmd *cobra.Command, args []string, phase string) error {
+	p, profile := activeProfile()
+	if len(p.Hooks) == 0 {
+		return nil
+	}
+	for _, name := range hookNames(cmd, phase) {
+		command := p.Hooks[name]
+		if command == "" {
+			continue
+		}
+		event := newHookEvent(cmd, args, name, phase, profile)
+		if phase == hookPost {
+			event.Resources = hookResources
+		}
+		if err := runHook(cmd.Context(), command, event, cmd.ErrOrStderr()); err != nil {
+			if phase == hookPre {
+				return fmt.Errorf("%s hook rejected the command: %v", name, err)
+			}
+			return fmt.Errorf("%s hook failed: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// hookNames returns the hook names that apply to cmd, the generic one first:
+// 'alias delete' runs pre_delete, then pre_alias_delete.
+func hookNames(cmd *cobra.Command, phase string) []string {
+	path := strings.Fields(strings.TrimPrefix(cmd.CommandPath(), rootCmd.Name()))
+	if len(path) == 0 {
+		return nil
+	}
+	name := func(parts ...string) string {
+		return phase + "_" + strings.ReplaceAll(strings.Join(parts, "_"), "-", "_")
+	}
+	names := []string{name(cmd.Name())}
+	if len(path) > 1 {
+		names = append(names, name(path...))
+	}
+	return names
+}
+
+// newHookEvent describes a command run for its hooks. Values of flags that
+// may hold secrets are redacted.
+func newHookEvent(cmd *cobra.Command, args []string, hook, phase, profile string) hookEvent {
+	e := hookEvent{
+		Hook:    hook,
+		Phase:   phase,
+		Command: strings.TrimPrefix(cmd.CommandPath(), rootCmd.Name()+" "),
+		Args:    args,
+		Profile: profile,
+		Time:    time.Now().UTC(),
+	}
+	if e.Args == nil {
+		e.Args = []string{}
+	}
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if e.Flags == nil {
+			e.Flags = map[string]string{}
+		}
+		value := f.Value.String()
+		if isSecretFlag(f.Name) {
+			value = "REDACTED"
+		}
+		e.Flags[f.Name] = value
+	})
+	return e
+}
+
+func isSecretFlag(name string) bool {
+	for _, s := range []string{"pass", "key", "secret", "token"} {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// runHook runs command through the system shell with the event as JSON on
+// stdin and FORWARDEMAIL_HOOK set to the hook name. The hook's output goes to
+// w so it never mixes with the command's own output.
+func runHook(ctx context.Context, command string, event hookEvent, w io.Writer) error {
+	return runHookCommand(ctx, command, event.Hook, event, w)
+}
+
+// runHookCommand runs command like a hook, with v as JSON on stdin and
+// FORWARDEMAIL_HOOK set to name.
+func runHookCommand(ctx context.Context, command, name string, v any, w io.Writer) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+	c := exec.CommandContext(ctx, shell, flag, command) //nolint:gosec // hooks are the user's own configured commands
+	c.Stdin = bytes.NewReader(payload)
+	c.Stdout = w
+	c.Stderr = w
+	c.Env = append(os.Environ(), "FORWARDEMAIL_HOOK="+name)
+	return c.Run()
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+)
+
+func TestHooks_PreGateAndPostEvent(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands in this test use sh")
+	}
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOOK_OUT", filepath.Join(dir, "event.json"))
+	if err := os.MkdirAll(filepath.Join(dir, "forwardemail"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	cfg := `current_profile: default
+profiles:
+  default:
+    hooks:
+      pre_alias_delete: "echo deletes need approval >&2; exit 3"
+      post_create: 'cat > "$HOOK_OUT"'
+      post_update: 'touch "$HOOK_OUT.update"'
+`
+	if err := os.WriteFile(filepath.Join(dir, "forwardemail", "config.yaml"), []byte(cfg), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    aliases:
+      - name: info
+        recipients: [me@example.org]
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(resetAliasFlags)
+
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetErr(&buf)
+	rootCmd.SetArgs([]string{"alias", "delete", "example.com", "info", "--force"})
+	err = rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "pre_alias_delete hook rejected the command: exit status 3") {
+		t.Fatalf("expected the pre hook to stop the delete, got %v\n%s", err, buf.String())
+	}
+	if !strings.Contains(buf.String(), "deletes need approval") {
+		t.Errorf("hook stderr not shown:\n%s", buf.String())
+	}
+
+	rootCmd.SetArgs([]string{"alias", "create", "example.com", "sales", "--recipients", "s@example.org", "-o", "table"})
+	captureStdout(t, func() {
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("alias create: %v\n%s", err, buf.String())
+		}
+	})
+	data, err := os.ReadFile(filepath.Join(dir, "event.json"))
+	if err != nil {
+		t.Fatalf("post hook did not run: %v", err)
+	}
+	var event hookEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		t.Fatalf("decode %s: %v", data, err)
+	}
+	if event.Hook != "post_create" || event.Command != "alias create" || strings.Join(event.Args, " ") != "example.com sales" ||
+		event.Flags["recipients"] != "[s@example.org]" || event.Profile != "default" {
+		t.Errorf("unexpected event: %+v", event)
+	}
+	if len(event.Resources) != 1 || event.Resources[0].Type != "alias" || event.Resources[0].Action != "create" ||
+		event.Resources[0].Domain != "example.com" || event.Resources[0].Name != "sales" || event.Resources[0].ID == "" {
+		t.Errorf("expected the created alias in the event, got %+v", event.Resources)
+	}
+
+	// A dry run changes nothing, so post hooks do not run.
+	rootCmd.SetArgs([]string{"alias", "update", "example.com", "info", "--description", "x", "--dry-run"})
+	captureStdout(t, func() {
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("alias update --dry-run: %v\n%s", err, buf.String())
+		}
+	})
+	if _, err := os.Stat(filepath.Join(dir, "event.json.update")); err == nil {
+		t.Error("post_update hook ran for a dry run")
+	}
+}
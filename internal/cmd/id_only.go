@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	addIDOnlyFlag(domainCreateCmd, "domain")
+	addIDOnlyFlag(aliasCreateCmd, "alias")
+	addIDOnlyFlag(emailSendCmd, "email")
+	domainCreateCmd.MarkFlagsMutuallyExclusive("id-only", "verify")
+}
+
+// addIDOnlyFlag registers --id-only on a command that creates what, for
+// scripts that capture the new ID: ID=$(forward-email alias create ... --id-only).
+func addIDOnlyFlag(cmd *cobra.Command, what string) {
+	cmd.Flags().Bool("id-only", false, "Print only the ID of the new "+what+", for scripts")
+}
+
+// printIDOnly prints id alone on stdout when --id-only is set, and reports
+// whether it did. Status messages still go to stderr.
+func printIDOnly(cmd *cobra.Command, id string) (bool, error) {
+	if on, _ := cmd.Flags().GetBool("id-only"); !on {
+		return false, nil
+	}
+	_, err := fmt.Fprintln(cmd.OutOrStdout(), id)
+	return true, err
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+)
+
+func TestIDOnly(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir()) // send ledger
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    aliases:
+      - name: info
+        recipients: [me@example.org]
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetAliasFlags()
+		resetCommandFlags(domainCreateCmd)
+		resetCommandFlags(emailSendCmd)
+	})
+
+	id := regexp.MustCompile(`^[^\s]+\n$`)
+	for _, args := range [][]string{
+		{"domain", "create", "new.example"},
+		{"alias", "create", "example.com", "hello", "--recipients", "me@example.org"},
+		{"email", "send", "--from", "info@example.com", "--to", "me@example.org", "--subject", "Hi", "--text", "Hello", "--yes"},
+	} {
+		t.Run(strings.Join(args[:2], " "), func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			rootCmd.SetOut(&stdout)
+			rootCmd.SetErr(&stderr)
+			rootCmd.SetArgs(append(args, "--id-only"))
+			err := rootCmd.Execute()
+			if c, _, findErr := rootCmd.Find(args); findErr == nil {
+				resetCommandFlags(c)
+			}
+			if err != nil {
+				t.Fatalf("%v: %v\n%s", args, err, stderr.String())
+			}
+			if !id.MatchString(stdout.String()) {
+				t.Errorf("expected only an ID on stdout, got %q", stdout.String())
+			}
+			if !strings.Contains(stderr.String(), "success") {
+				t.Errorf("expected the status on stderr, got %q", stderr.String())
+			}
+		})
+	}
+
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"domain", "create", "other.example", "--id-only", "--verify"})
+	err = rootCmd.Execute()
+	resetCommandFlags(domainCreateCmd)
+	if err == nil || !strings.Contains(err.Error(), "id-only") {
+		t.Errorf("expected --id-only and --verify to be exclusive, got %v", err)
+	}
+}
@@ -2,26 +2,40 @@ package cmd
 
 import (
 	"bufio"
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	kr "github.com/99designs/keyring"
+	"github.com/ginsys/forward-email/internal/client"
 	ikeyring "github.com/ginsys/forward-email/internal/keyring"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/output"
 )
 
+// initMaxKeyAttempts limits how often the wizard re-prompts for a rejected API key.
+const initMaxKeyAttempts = 3
+
 // initCmd provides an interactive setup wizard for first-time users.
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Interactive setup wizard",
-	Long:  "Guide to configure a profile, store API key securely, and create a config file.",
+	Long: `Guide to configure a profile, store API key securely, and create a config file.
+
+The wizard validates the API key against the API, asks for a default output
+format and default domain, offers to install shell completion, and can add a
+first domain and print the DNS records it needs.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		in := bufio.NewReader(cmd.InOrStdin())
+		out := cmd.OutOrStdout()
 
 		// Ask for profile name
 		_, _ = fmt.Fprint(cmd.OutOrStdout(), "Profile name [default]: ")
@@ -32,16 +46,19 @@ var initCmd = &cobra.Command{
 		}
 
 		// Ask for API key (basic masking not implemented in plain stdin)
-		_, _ = fmt.Fprint(cmd.OutOrStdout(), "API key: ")
-		apiKey, _ := in.ReadString('\n')
-		apiKey = strings.TrimSpace(apiKey)
-		if apiKey == "" {
-			return errors.New("API key is required")
+		noValidate, _ := cmd.Flags().GetBool("no-validate")
+		apiKey, apiClient, domains, err := promptInitAPIKey(in, out, !noValidate)
+		if err != nil {
+			return err
 		}
 
+		format := promptInitOutputFormat(in, out)
+		defaultDomain := promptInitDefaultDomain(in, out, domains)
+
 		// Store API key based on requested backend
 		store := cmd.Flag("store").Value.String()
 		filePass := cmd.Flag("file-pass").Value.String()
+		keyInConfig := false
 		switch store {
 		case "auto", "keyring":
 			kr, err := ikeyring.New(ikeyring.Config{})
@@ -60,6 +77,7 @@ var initCmd = &cobra.Command{
 			fallthrough
 		case "config":
 			// Will be saved to config below
+			keyInConfig = true
 		case "file":
 			// Persistent file keyring under config dir
 			cfgDir := os.Getenv("XDG_CONFIG_HOME")
@@ -120,20 +138,182 @@ var initCmd = &cobra.Command{
 		v.Set("current_profile", profile)
 		v.Set("profiles."+profile+".base_url", "https://api.forwardemail.net")
 		v.Set("profiles."+profile+".timeout", "30s")
-		v.Set("profiles."+profile+".output", "table")
+		v.Set("profiles."+profile+".output", format)
+		if defaultDomain != "" {
+			v.Set("profiles."+profile+".default_domain", defaultDomain)
+		}
+		if keyInConfig {
+			v.Set("profiles."+profile+".api_key", apiKey)
+		}
 
 		if err := v.WriteConfigAs(cfgPath); err != nil {
 			return fmt.Errorf("failed to write config: %w", err)
 		}
 
-		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\n✅ Setup complete. Config: %s (profile: %s)\n", cfgPath, profile)
-		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Tip: run 'forward-email auth verify' to validate credentials.")
+		if promptYesNo(in, out, "Install shell completion?") {
+			shell := filepath.Base(os.Getenv("SHELL"))
+			path, cerr := installCompletion(shell, home)
+			if cerr != nil {
+				_, _ = fmt.Fprintf(out, "⚠️  %v; run 'forward-email completion --help' for manual setup\n", cerr)
+			} else {
+				_, _ = fmt.Fprintf(out, "✅ Installed %s completion: %s\n", shell, path)
+				if shell == "zsh" {
+					_, _ = fmt.Fprintf(out, "   Add 'fpath=(%s $fpath)' to ~/.zshrc before compinit\n", filepath.Dir(path))
+				}
+			}
+		}
+
+		if apiClient != nil && promptYesNo(in, out, "Add your first domain now?") {
+			if err := initAddFirstDomain(in, out, apiClient); err != nil {
+				return err
+			}
+		}
+
+		_, _ = fmt.Fprintf(out, "\n✅ Setup complete. Config: %s (profile: %s)\n", cfgPath, profile)
+		if apiClient == nil {
+			_, _ = fmt.Fprintln(out, "Tip: run 'forward-email auth verify' to validate credentials.")
+		}
 		return nil
 	},
 }
 
+// readPromptLine reads one trimmed line. A final line without a newline is returned
+// as-is; io.EOF is only reported when nothing was read.
+func readPromptLine(in *bufio.Reader) (string, error) {
+	line, err := in.ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// promptYesNo asks a [y/N] question; anything but y/yes (including EOF) means no.
+func promptYesNo(in *bufio.Reader, out io.Writer, question string) bool {
+	return promptYesNoDefault(in, out, question, false)
+}
+
+// promptYesNoDefault asks a yes/no question; y/yes and n/no answer it, and
+// anything else (including EOF) keeps def, e.g. the value given by a flag.
+func promptYesNoDefault(in *bufio.Reader, out io.Writer, question string, def bool) bool {
+	hint := "[y/N]"
+	if def {
+		hint = "[Y/n]"
+	}
+	_, _ = fmt.Fprintf(out, "%s %s: ", question, hint)
+	answer, _ := readPromptLine(in)
+	switch strings.ToLower(answer) {
+	case "y", yesStr:
+		return true
+	case "n", "no":
+		return false
+	}
+	return def
+}
+
+// promptInitAPIKey asks for the API key and, when validate is set, checks it live by
+// listing domains. Rejected keys are re-prompted up to initMaxKeyAttempts times. The
+// validated client and the account's domains are returned for the later wizard steps.
+func promptInitAPIKey(in *bufio.Reader, out io.Writer, validate bool) (string, *api.Client, []api.Domain, error) {
+	for attempt := 1; ; attempt++ {
+		_, _ = fmt.Fprint(out, "API key: ")
+		apiKey, err := readPromptLine(in)
+		if err != nil || apiKey == "" {
+			return "", nil, nil, errors.New("API key is required")
+		}
+		if !validate {
+			return apiKey, nil, nil, nil
+		}
+
+		apiClient, err := client.NewAPIClientWithKey(apiKey)
+		if err != nil {
+			return "", nil, nil, fmt.Errorf("failed to create API client: %w", err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		resp, err := apiClient.Domains.ListDomains(ctx, nil)
+		cancel()
+		if err == nil {
+			_, _ = fmt.Fprintf(out, "✅ API key verified (%d domains)\n", len(resp.Domains))
+			return apiKey, apiClient, resp.Domains, nil
+		}
+
+		_, _ = fmt.Fprintf(out, "❌ API key rejected: %v\n", err)
+		if attempt == initMaxKeyAttempts {
+			return "", nil, nil, fmt.Errorf("API key validation failed after %d attempts", initMaxKeyAttempts)
+		}
+	}
+}
+
+// promptInitOutputFormat asks for the profile's default output format, re-prompting
+// on unknown formats. Empty input or EOF selects table.
+func promptInitOutputFormat(in *bufio.Reader, out io.Writer) string {
+	for {
+		_, _ = fmt.Fprint(out, "Default output format (table|json|yaml|csv|plain) [table]: ")
+		answer, err := readPromptLine(in)
+		if err != nil || answer == "" {
+			return outputTable
+		}
+		if _, perr := output.ParseFormat(answer); perr == nil {
+			return strings.ToLower(answer)
+		}
+		_, _ = fmt.Fprintf(out, "Unknown format %q\n", answer)
+	}
+}
+
+// promptInitDefaultDomain asks for an optional default domain, listing the account's
+// domains when they are known. A single domain is offered as the default answer.
+func promptInitDefaultDomain(in *bufio.Reader, out io.Writer, domains []api.Domain) string {
+	suggestion := ""
+	if len(domains) > 0 {
+		names := make([]string, 0, len(domains))
+		for _, d := range domains {
+			names = append(names, d.Name)
+		}
+		_, _ = fmt.Fprintf(out, "Your domains: %s\n", strings.Join(names, ", "))
+		if len(domains) == 1 {
+			suggestion = domains[0].Name
+		}
+	}
+	_, _ = fmt.Fprintf(out, "Default domain (optional) [%s]: ", suggestion)
+	answer, err := readPromptLine(in)
+	if err != nil || answer == "" {
+		return suggestion
+	}
+	return answer
+}
+
+// initAddFirstDomain creates a domain and prints the DNS records it needs.
+func initAddFirstDomain(in *bufio.Reader, out io.Writer, apiClient *api.Client) error {
+	_, _ = fmt.Fprint(out, "Domain name: ")
+	name, err := readPromptLine(in)
+	if err != nil || name == "" {
+		_, _ = fmt.Fprintln(out, "No domain given, skipping")
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	domain, err := apiClient.Domains.CreateDomain(ctx, &api.CreateDomainRequest{Name: name})
+	if err != nil {
+		return fmt.Errorf("failed to create domain: %w", err)
+	}
+	_, _ = fmt.Fprintf(out, "✅ Domain '%s' created\n\n", domain.Name)
+
+	records, err := apiClient.Domains.GetDomainDNSRecords(ctx, domain.Name)
+	if err != nil {
+		return fmt.Errorf("failed to get DNS records: %w", err)
+	}
+	_, _ = fmt.Fprintln(out, "Add these DNS records at your DNS provider, then run 'forward-email domain verify "+domain.Name+"':")
+	table, err := output.FormatDNSRecords(records, output.FormatTable)
+	if err != nil {
+		return err
+	}
+	return output.NewFormatter(output.FormatTable, out).Format(table)
+}
+
 func init() {
 	rootCmd.AddCommand(initCmd)
 	initCmd.Flags().String("store", "auto", "Credential store: auto|keyring|file|config")
 	initCmd.Flags().String("file-pass", "", "Passphrase for file keyring (used when --store=file)")
+	initCmd.Flags().Bool("no-validate", false, "Skip live validation of the API key")
 }
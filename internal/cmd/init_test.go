@@ -2,16 +2,56 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/auth"
 )
 
+// newInitTestServer serves the endpoints used by the setup wizard. Only goodKey is accepted.
+func newInitTestServer(t *testing.T, goodKey string, domains []api.Domain) *httptest.Server {
+	t.Helper()
+	want := "Basic " + base64.StdEncoding.EncodeToString([]byte(goodKey+":"))
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/domains", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != want {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(map[string]string{"message": "Invalid API token"})
+			return
+		}
+		if r.Method == http.MethodPost {
+			var req api.CreateDomainRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			_ = json.NewEncoder(w).Encode(api.Domain{Name: req.Name, VerificationRecord: "abc123"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(domains)
+	})
+	mux.HandleFunc("/v1/domains/new.com", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(api.Domain{Name: "new.com", VerificationRecord: "abc123"})
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	client.SetTestMode(srv.URL, auth.MockProvider("unused"))
+	t.Cleanup(client.ResetTestMode)
+	return srv
+}
+
 func TestInitCommand_WritesConfigAndStoresKey(t *testing.T) {
 	// Prepare temp HOME and keyring file backend
 	tmp := t.TempDir()
 	t.Setenv("HOME", tmp)
 	// We will use flags to force file keyring, so no env needed
+	newInitTestServer(t, "secret-key", nil)
 
 	// Simulate input: profile name + API key
 	input := bytes.NewBufferString("dev\nsecret-key\n")
@@ -35,3 +75,63 @@ func TestInitCommand_WritesConfigAndStoresKey(t *testing.T) {
 		t.Fatalf("expected config file at %s: %v", cfg, err)
 	}
 }
+
+func TestInitCommand_WizardSteps(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("HOME", tmp)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("SHELL", "/usr/bin/fish")
+	newInitTestServer(t, "good-key", []api.Domain{{Name: "example.com"}})
+
+	// profile, rejected key, accepted key, bad format, json, accept suggested domain,
+	// install completion, add first domain
+	input := bytes.NewBufferString("dev\nbad-key\ngood-key\nxml\njson\n\ny\ny\nnew.com\n")
+
+	c := initCmd
+	c.SetIn(input)
+	var out bytes.Buffer
+	c.SetOut(&out)
+	c.SetErr(&out)
+	_ = c.Flags().Set("store", "config")
+
+	if err := c.RunE(c, nil); err != nil {
+		t.Fatalf("init failed: %v\noutput: %s", err, out.String())
+	}
+
+	s := out.String()
+	for _, want := range []string{"API key rejected", "API key verified", `Unknown format "xml"`, "Domain 'new.com' created", "mx1.forwardemail.net"} {
+		if !strings.Contains(s, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, s)
+		}
+	}
+
+	cfg, err := os.ReadFile(filepath.Join(tmp, ".config", "forwardemail", "config.yaml"))
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	for _, want := range []string{"output: json", "default_domain: example.com", "api_key: good-key"} {
+		if !strings.Contains(string(cfg), want) {
+			t.Errorf("expected config to contain %q, got:\n%s", want, cfg)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(tmp, ".config", "fish", "completions", "forward-email.fish")); err != nil {
+		t.Errorf("expected fish completion to be installed: %v", err)
+	}
+}
+
+func TestInitCommand_GivesUpAfterRejectedKeys(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	newInitTestServer(t, "good-key", nil)
+
+	c := initCmd
+	c.SetIn(bytes.NewBufferString("dev\nk1\nk2\nk3\n"))
+	var out bytes.Buffer
+	c.SetOut(&out)
+	c.SetErr(&out)
+
+	err := c.RunE(c, nil)
+	if err == nil || !strings.Contains(err.Error(), "after 3 attempts") {
+		t.Fatalf("expected validation failure after 3 attempts, got %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+// inviteCmd represents the invite command group
+var inviteCmd = &cobra.Command{
+	Use:   "invite",
+	Short: "Respond to invitations to join a domain",
+	Long: `Respond to invitations addressed to your user, the other side of
+'domain members add'.
+
+Invitations are addressed by domain name: the API neither gives them IDs nor
+lists the invitations waiting for a user, so use the domain named in the
+invitation email. Declining is not available through the API; an invitation
+you ignore expires, or a domain admin can withdraw it.`,
+}
+
+var inviteAcceptCmd = &cobra.Command{
+	Use:   "accept <domain>",
+	Short: "Accept an invitation to join a domain",
+	Long: `Accept your pending invitation to a domain, which makes you a member with the
+group the invitation was sent for.
+
+Examples:
+  forward-email invite accept example.com`,
+	Args: cobra.ExactArgs(1),
+	RunE: runInviteAccept,
+}
+
+func init() {
+	rootCmd.AddCommand(inviteCmd)
+	inviteCmd.AddCommand(inviteAcceptCmd)
+}
+
+func runInviteAccept(cmd *cobra.Command, args []string) error {
+	format, err := output.ParseFormat(viper.GetString("output"))
+	if err != nil {
+		return fmt.Errorf("invalid output format: %v", err)
+	}
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %v", err)
+	}
+
+	name := strings.TrimSpace(args[0])
+	domain, err := apiClient.Domains.AcceptDomainInvite(cmd.Context(), name)
+	if err != nil {
+		return fmt.Errorf("failed to accept the invitation to %s: %v", name, err)
+	}
+	if domain.Name != "" {
+		name = domain.Name
+	}
+	reportMutation(hookResource{Type: "domain", Action: "join", Name: name, ID: domain.ID, Data: domain})
+	cmd.PrintErrf("✅ Joined domain %s\n", name)
+
+	if format == output.FormatJSON || format == output.FormatYAML {
+		return output.NewFormatter(format, cmd.OutOrStdout()).Format(domain)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+)
+
+func TestInviteAccept(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    invitations:
+      - email: new@corp.com
+        group: user
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() { _ = rootCmd.PersistentFlags().Set("output", "table") })
+
+	run := func(args ...string) (string, string, error) {
+		var stdout, stderr bytes.Buffer
+		rootCmd.SetOut(&stdout)
+		rootCmd.SetErr(&stderr)
+		rootCmd.SetArgs(args)
+		err := rootCmd.Execute()
+		return stdout.String(), stderr.String(), err
+	}
+
+	stdout, stderr, err := run("invite", "accept", "example.com", "-o", "json")
+	if err != nil {
+		t.Fatalf("invite accept: %v", err)
+	}
+	if !strings.Contains(stderr, "✅ Joined domain example.com") {
+		t.Errorf("expected a confirmation on stderr, got %q", stderr)
+	}
+	if !strings.Contains(stdout, `"email": "new@corp.com"`) || strings.Contains(stdout, `"invitations"`) {
+		t.Errorf("expected the invitee among the members, got:\n%s", stdout)
+	}
+
+	_, _, err = run("invite", "accept", "example.com")
+	if err == nil || !strings.Contains(err.Error(), "failed to accept the invitation to example.com") {
+		t.Errorf("expected an error without a pending invitation, got %v", err)
+	}
+}
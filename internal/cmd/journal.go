@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ginsys/forward-email/pkg/config"
+)
+
+// opJournal records the completed items of a bulk operation, one JSON line per
+// item after a header line, so an interrupted run can be resumed with
+// --resume without applying those items again. A nil *opJournal records
+// nothing.
+type opJournal struct {
+	path    string
+	file    *os.File
+	done    map[string]bool
+	resumed int
+}
+
+type journalHeader struct {
+	Operation string    `json:"operation"`
+	Target    string    `json:"target"`
+	Started   time.Time `json:"started"`
+}
+
+type journalRecord struct {
+	Item string    `json:"item"`
+	At   time.Time `json:"at"`
+}
+
+// startJournal opens the journal for operation on target. With resumePath it
+// continues that journal, which must belong to the same operation and target;
+// otherwise a new journal is created in the journals directory of the config
+// directory.
+func startJournal(operation, target, resumePath string) (*opJournal, error) {
+	if resumePath != "" {
+		return resumeJournal(operation, target, resumePath)
+	}
+
+	dir, err := config.Dir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate journal directory: %v", err)
+	}
+	dir = filepath.Join(dir, "journals")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create journal directory: %v", err)
+	}
+	now := time.Now().UTC()
+	f, err := os.CreateTemp(dir, fmt.Sprintf("%s-%s-*.jsonl", operation, now.Format("20060102-150405")))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create journal: %v", err)
+	}
+	j := &opJournal{path: f.Name(), file: f, done: map[string]bool{}}
+	if err := j.write(journalHeader{Operation: operation, Target: target, Started: now}); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return j, nil
+}
+
+func resumeJournal(operation, target, path string) (*opJournal, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_APPEND, 0o600) //nolint:gosec // the journal path is given by the user
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal: %v", err)
+	}
+	j := &opJournal{path: path, file: f, done: map[string]bool{}}
+
+	scanner := bufio.NewScanner(f)
+	var header journalHeader
+	if !scanner.Scan() || json.Unmarshal(scanner.Bytes(), &header) != nil || header.Operation == "" {
+		_ = f.Close()
+		return nil, fmt.Errorf("%s is not an operation journal", path)
+	}
+	if header.Operation != operation || header.Target != target {
+		_ = f.Close()
+		return nil, fmt.Errorf("journal %s is for %s %s, not %s %s", path, header.Operation, header.Target, operation, target)
+	}
+	for scanner.Scan() {
+		var r journalRecord
+		// A line cut short by a crash is ignored; that item is simply applied again
+		if json.Unmarshal(scanner.Bytes(), &r) == nil && r.Item != "" {
+			j.done[r.Item] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to read journal: %v", err)
+	}
+	return j, nil
+}
+
+// Done reports whether item was completed by a previous run, counting it as
+// resumed when it was.
+func (j *opJournal) Done(item string) bool {
+	if j == nil || !j.done[item] {
+		return false
+	}
+	j.resumed++
+	return true
+}
+
+// Record marks item as completed. The line is synced to disk before Record
+// returns, so it survives a crash right after the API call.
+func (j *opJournal) Record(item string) error {
+	if j == nil {
+		return nil
+	}
+	return j.write(journalRecord{Item: item, At: time.Now().UTC()})
+}
+
+func (j *opJournal) write(v any) error {
+	line, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if _, err := j.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write journal: %v", err)
+	}
+	if err := j.file.Sync(); err != nil {
+		return fmt.Errorf("failed to write journal: %v", err)
+	}
+	return nil
+}
+
+// Finish closes the journal. After a successful run the journal is removed;
+// after a failure it is kept and w is told how to resume.
+func (j *opJournal) Finish(w io.Writer, runErr error) {
+	if j == nil {
+		return
+	}
+	_ = j.file.Close()
+	if j.resumed > 0 {
+		_, _ = fmt.Fprintf(w, "Skipped %d item(s) completed by the previous run\n", j.resumed)
+	}
+	if runErr == nil {
+		_ = os.Remove(j.path)
+		return
+	}
+	_, _ = fmt.Fprintf(w, "📒 Progress saved; resume with --resume %s\n", j.path)
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestOpJournal_ResumeChecksOperation(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	j, err := startJournal("sync", "a.com -> b.com", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Record("b.com/info"); err != nil {
+		t.Fatal(err)
+	}
+	var out strings.Builder
+	j.Finish(&out, os.ErrDeadlineExceeded)
+	if !strings.Contains(out.String(), "--resume "+j.path) {
+		t.Errorf("expected resume hint, got %q", out.String())
+	}
+	// Simulate a line cut short by a crash
+	f, err := os.OpenFile(j.path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString(`{"item":"b.com/sal`)
+	_ = f.Close()
+
+	if _, err := startJournal("import", "b.com", j.path); err == nil || !strings.Contains(err.Error(), "is for sync a.com -> b.com") {
+		t.Errorf("expected operation mismatch error, got %v", err)
+	}
+	r, err := startJournal("sync", "a.com -> b.com", j.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.Done("b.com/info") || r.Done("b.com/sales") {
+		t.Errorf("unexpected completed items: %v", r.done)
+	}
+
+	var nilJournal *opJournal
+	if nilJournal.Done("x") || nilJournal.Record("x") != nil {
+		t.Error("a nil journal must record nothing")
+	}
+	nilJournal.Finish(&out, nil)
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ginsys/forward-email/pkg/api"
+)
+
+func init() {
+	addCursorFlag(domainListCmd, aliasListCmd, emailListCmd)
+}
+
+// addCursorFlag registers --cursor on list commands, for APIs that page with
+// opaque tokens rather than page numbers.
+func addCursorFlag(cmds ...*cobra.Command) {
+	for _, c := range cmds {
+		c.Flags().String("cursor", "", "Page token from a previous list's footer or next_cursor; replaces --page")
+	}
+}
+
+// listCursor returns the --cursor value of a list command.
+func listCursor(cmd *cobra.Command) string {
+	cursor, _ := cmd.Flags().GetString("cursor")
+	return cursor
+}
+
+// printNextPageHint tells how to fetch the page after p: by cursor when the
+// API pages with cursors, otherwise by page number.
+func printNextPageHint(cmd *cobra.Command, p *api.Pagination) {
+	switch {
+	case p.NextCursor != "":
+		cmd.PrintErrf("Use --cursor %s to see more results\n", p.NextCursor)
+	case p.HasNext:
+		cmd.PrintErrf("Use --page %d to see more results\n", p.Page+1)
+	}
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/auth"
+)
+
+// cursorAliasServer pages the aliases of example.com with opaque cursors, as
+// an API that moved away from page numbers would.
+func cursorAliasServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	pages := map[string][]api.Alias{
+		"":   {{Name: "info", IsEnabled: true}, {Name: "sales", IsEnabled: true}},
+		"c2": {{Name: "support", IsEnabled: true}},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/domains/example.com/aliases" {
+			http.NotFound(w, r)
+			return
+		}
+		cursor := r.URL.Query().Get("cursor")
+		if cursor == "" {
+			w.Header().Set("Link", `<`+r.URL.Path+`?cursor=c2>; rel="next"`)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(pages[cursor])
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestListCursor(t *testing.T) {
+	srv := cursorAliasServer(t)
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetAliasFlags()
+		resetCommandFlags(aliasListCmd)
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	})
+
+	run := func(args ...string) (string, string, error) {
+		var stdout, stderr bytes.Buffer
+		rootCmd.SetOut(&stdout)
+		rootCmd.SetErr(&stderr)
+		rootCmd.SetArgs(args)
+		var err error
+		out := captureStdout(t, func() { err = rootCmd.Execute() })
+		resetCommandFlags(aliasListCmd)
+		return stdout.String() + out, stderr.String(), err
+	}
+
+	_, stderr, err := run("alias", "list", "example.com", "-o", "table")
+	if err != nil {
+		t.Fatalf("alias list: %v", err)
+	}
+	if !strings.Contains(stderr, "Use --cursor c2 to see more results") {
+		t.Errorf("expected a --cursor hint, got stderr:\n%s", stderr)
+	}
+
+	stdout, _, err := run("alias", "list", "example.com", "--cursor", "c2", "-o", "json", "--envelope")
+	if err != nil {
+		t.Fatalf("alias list --cursor: %v", err)
+	}
+	var env struct {
+		Data       []api.Alias    `json:"data"`
+		Pagination api.Pagination `json:"pagination"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &env); err != nil {
+		t.Fatalf("stdout is not an envelope: %v\n%s", err, stdout)
+	}
+	if len(env.Data) != 1 || env.Data[0].Name != "support" {
+		t.Errorf("expected the second page, got %+v", env.Data)
+	}
+	if env.Pagination.HasNext || env.Pagination.NextCursor != "" {
+		t.Errorf("expected the last page, got %+v", env.Pagination)
+	}
+
+	if _, _, err := run("alias", "list", "example.com,example.org", "--cursor", "c2"); err == nil ||
+		!strings.Contains(err.Error(), "single domain") {
+		t.Errorf("expected --cursor to be refused for several domains, got %v", err)
+	}
+
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	all, err := listAllAliases(t.Context(), apiClient, "example.com")
+	if err != nil {
+		t.Fatalf("listAllAliases: %v", err)
+	}
+	if len(all) != 3 {
+		t.Errorf("expected listAllAliases to follow the cursor to 3 aliases, got %d", len(all))
+	}
+}
+
+func TestListPageHeaders(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: a.example
+  - name: b.example
+  - name: c.example
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetCommandFlags(domainListCmd)
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	})
+
+	var stderr bytes.Buffer
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetArgs([]string{"domain", "list", "--limit", "2", "-o", "table"})
+	captureStdout(t, func() { err = rootCmd.Execute() })
+	resetCommandFlags(domainListCmd)
+	if err != nil {
+		t.Fatalf("domain list: %v", err)
+	}
+	if !strings.Contains(stderr.String(), "Showing 2 of 3 domains (page 1 of 2)") ||
+		!strings.Contains(stderr.String(), "Use --page 2 to see more results") {
+		t.Errorf("expected the totals from the pagination headers, got:\n%s", stderr.String())
+	}
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ginsys/forward-email/internal/mockserver"
+)
+
+var (
+	mockServerPort int
+	mockServerHost string
+	mockServerSeed string
+)
+
+// mockServerCmd represents the mock-server command
+var mockServerCmd = &cobra.Command{
+	Use:   "mock-server",
+	Short: "Run a local mock of the Forward Email API",
+	Long: `Run an in-memory HTTP server implementing the parts of the Forward Email API
+used by this CLI (domains, aliases and emails).
+
+State is kept in memory and starts from the optional --seed file, a YAML document
+using the API's JSON field names:
+
+  domains:
+    - name: example.com
+      plan: enhanced_protection
+      is_verified: true
+      aliases:
+        - name: info
+          recipients: [me@example.org]
+          is_enabled: true
+  emails:
+    - subject: Welcome
+      status: delivered
+  quota:
+    emails_limit: 300
+
+Any non-empty API key is accepted. Point the CLI at the server with --api-url;
+without a profile, the key is taken from FORWARDEMAIL_API_KEY:
+
+  FORWARDEMAIL_API_KEY=demo forward-email --api-url http://127.0.0.1:8080 domain list`,
+	Args: cobra.NoArgs,
+	RunE: runMockServer,
+}
+
+func init() {
+	rootCmd.AddCommand(mockServerCmd)
+
+	mockServerCmd.Flags().IntVar(&mockServerPort, "port", 8080, "Port to listen on")
+	mockServerCmd.Flags().StringVar(&mockServerHost, "host", "127.0.0.1", "Address to bind to")
+	mockServerCmd.Flags().StringVar(&mockServerSeed, "seed", "", "YAML file with the initial domains, aliases and emails")
+}
+
+func runMockServer(cmd *cobra.Command, _ []string) error {
+	var seed *mockserver.Seed
+	if mockServerSeed != "" {
+		var err error
+		if seed, err = mockserver.LoadSeed(mockServerSeed); err != nil {
+			return err
+		}
+	}
+
+	ln, err := net.Listen("tcp", net.JoinHostPort(mockServerHost, fmt.Sprint(mockServerPort)))
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	srv := &http.Server{
+		Handler:           mockserver.New(seed),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	cmd.PrintErrf("Mock Forward Email API listening on http://%s\n", ln.Addr())
+	cmd.PrintErrf("Use it with: FORWARDEMAIL_API_KEY=demo forward-email --api-url http://%s <command>\n", ln.Addr())
+
+	if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("mock server failed: %w", err)
+	}
+	return nil
+}
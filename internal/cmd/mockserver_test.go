@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+)
+
+// TestMockServer_QuickStart runs the documented quick start on a machine
+// without a profile: FORWARDEMAIL_API_KEY=demo forward-email --api-url <url> domain list.
+func TestMockServer_QuickStart(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("FORWARDEMAIL_API_KEY", "demo")
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    is_verified: true
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+	client.ResetTestMode()
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		_ = rootCmd.PersistentFlags().Set("api-url", "")
+		viper.Reset()
+		bindRootFlags()
+	})
+
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetErr(&buf)
+	rootCmd.SetArgs([]string{"--api-url", srv.URL, "domain", "list"})
+	out := captureStdout(t, func() {
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("domain list: %v\n%s", err, buf.String())
+		}
+	})
+	if !strings.Contains(out+buf.String(), "example.com") {
+		t.Errorf("expected the seeded domain, got:\n%s%s", out, buf.String())
+	}
+}
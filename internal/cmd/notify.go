@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/pkg/config"
+	"github.com/ginsys/forward-email/pkg/notify"
+)
+
+// notifyAnnotation marks commands whose results are posted to --notify URLs.
+const (
+	notifyAnnotation = "notify"
+	notifyAlways     = "always"  // every run, e.g. commands that change mail routing
+	notifyOnFailure  = "failure" // only failed runs, e.g. alert checks
+)
+
+// notifyDetails carries command-specific data into the notification of the
+// current run, such as the breaches found by alerts check.
+var notifyDetails any
+
+func init() {
+	markNotify(notifyAlways,
+		domainCreateCmd, domainUpdateCmd, domainDeleteCmd, domainCloneCmd, domainTransferCmd,
+		domainMembersAddCmd, domainMembersRemoveCmd,
+		aliasCreateCmd, aliasUpdateCmd, aliasDeleteCmd, aliasEnableCmd, aliasDisableCmd,
+		aliasRecipientsCmd, aliasPasswordCmd, aliasImportCmd, aliasSyncCmd,
+		aliasExpireRunCmd, aliasOwnerSetCmd, aliasRandomCmd,
+		emailSendCmd, emailDeleteCmd,
+	)
+	markNotify(notifyOnFailure, alertsCheckCmd)
+}
+
+func markNotify(mode string, cmds ...*cobra.Command) {
+	for _, c := range cmds {
+		if c.Annotations == nil {
+			c.Annotations = map[string]string{}
+		}
+		c.Annotations[notifyAnnotation] = mode
+	}
+}
+
+// notifyTargets returns the notification URLs from --notify,
+// FORWARDEMAIL_NOTIFY or the config file, falling back to the profile's
+// notify list, together with the active profile name.
+func notifyTargets() (urls []string, profile string) {
+	urls = viper.GetStringSlice("notify")
+	profile = viper.GetString("profile")
+
+	cfg, err := config.Load()
+	if err != nil {
+		return urls, profile
+	}
+	if profile == "" {
+		profile = cfg.CurrentProfile
+	}
+	if len(urls) == 0 {
+		if p, ok := cfg.Profiles[profile]; ok {
+			urls = p.Notify
+		}
+	}
+	return urls, profile
+}
+
+// notifyCommandResult posts a summary of a marked command's run to the
+// configured notification URLs. A successful run is only posted when it
+// reported a change (see reportMutation), so dry runs and declined
+// confirmations stay quiet. Delivery failures are reported on stderr and
+// never change the command's exit status.
+func notifyCommandResult(ctx context.Context, cmd *cobra.Command, runErr error) {
+	if cmd == nil {
+		return
+	}
+	mode := cmd.Annotations[notifyAnnotation]
+	if mode == "" || (mode == notifyOnFailure && runErr == nil) {
+		return
+	}
+	if mode == notifyAlways && runErr == nil && len(hookResources) == 0 {
+		return
+	}
+	urls, profile := notifyTargets()
+	if len(urls) == 0 {
+		return
+	}
+
+	if err := postNotification(ctx, urls, newNotifyEvent(cmd, profile, runErr)); err != nil {
+		slog.Warn("failed to send notification", "error", err)
+	}
+}
+
+// newNotifyEvent summarizes a command run and the changes it reported. Only
+// positional arguments are included; flag values may hold secrets such as
+// passwords.
+func newNotifyEvent(cmd *cobra.Command, profile string, runErr error) notify.Event {
+	command := strings.TrimPrefix(cmd.CommandPath(), rootCmd.Name()+" ")
+	summary := strings.TrimSpace(command + " " + strings.Join(cmd.Flags().Args(), " "))
+
+	e := notify.Event{
+		Time:    time.Now().UTC(),
+		Command: command,
+		Profile: profile,
+		Success: runErr == nil,
+		Details: notifyDetails,
+	}
+	for _, r := range hookResources {
+		e.Changes = append(e.Changes, notify.Change{Type: r.Type, Action: r.Action, Domain: r.Domain, Name: r.Name})
+	}
+	if runErr != nil {
+		e.Error = runErr.Error()
+		e.Text = fmt.Sprintf("❌ forward-email %s failed: %v", summary, runErr)
+	} else {
+		e.Text = "✅ forward-email " + summary
+	}
+	if changed := describeChanges(hookResources); changed != "" {
+		e.Text += " — " + changed
+	}
+	if profile != "" {
+		e.Text += fmt.Sprintf(" (profile: %s)", profile)
+	}
+	return e
+}
+
+// maxListedChanges is the number of changes named in a notification's text;
+// longer runs are counted instead.
+const maxListedChanges = 3
+
+// describeChanges names the changed resources, e.g. "alias create
+// info@example.com", or counts them when there are many.
+func describeChanges(resources []hookResource) string {
+	if len(resources) > maxListedChanges {
+		return fmt.Sprintf("%d changes", len(resources))
+	}
+	parts := make([]string, 0, len(resources))
+	for _, r := range resources {
+		name := r.Name
+		if name == "" {
+			name = r.ID
+		}
+		switch {
+		case r.Type == "alias" && r.Domain != "" && name != "":
+			name += "@" + r.Domain
+		case r.Domain != "":
+			name = strings.TrimSpace(name + " on " + r.Domain)
+		}
+		parts = append(parts, strings.TrimSpace(r.Type+" "+r.Action+" "+name))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func postNotification(ctx context.Context, urls []string, e notify.Event) error {
+	sink, err := notify.NewMulti(urls, nil)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), notify.DefaultTimeout)
+	defer cancel()
+	return sink.Notify(ctx, e)
+}
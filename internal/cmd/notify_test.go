@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+)
+
+func TestNotifyCommandResult(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	var posted []map[string]any
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		posted = append(posted, body)
+	}))
+	defer hook.Close()
+
+	viper.Reset()
+	bindRootFlags()
+	viper.Set("notify", []string{hook.URL})
+	viper.Set("profile", "ops")
+	t.Cleanup(func() { viper.Reset(); bindRootFlags() })
+
+	ctx := context.Background()
+	_ = aliasCreateCmd.Flags().Set("recipients", "secret@example.org")
+	_ = aliasCreateCmd.Flags().Parse([]string{"example.com", "info"})
+	t.Cleanup(resetAliasFlags)
+
+	notifyCommandResult(ctx, aliasCreateCmd, nil) // nothing reported, nothing posted
+	hookResources = []hookResource{{Type: "alias", Action: "create", Domain: "example.com", Name: "info"}}
+	t.Cleanup(func() { hookResources = nil })
+	notifyCommandResult(ctx, aliasCreateCmd, nil)
+	hookResources = nil
+	notifyCommandResult(ctx, alertsCheckCmd, nil) // alert checks only notify on failure
+	notifyCommandResult(ctx, aliasListCmd, errors.New("boom"))
+	notifyCommandResult(ctx, alertsCheckCmd, errors.New("1 quota(s) at or above threshold"))
+
+	if len(posted) != 2 {
+		t.Fatalf("expected 2 notifications, got %d: %v", len(posted), posted)
+	}
+	text, _ := posted[0]["text"].(string)
+	if posted[0]["command"] != "alias create" || posted[0]["success"] != true ||
+		!strings.Contains(text, "alias create example.com info") || !strings.Contains(text, "alias create info@example.com") ||
+		!strings.Contains(text, "profile: ops") {
+		t.Errorf("unexpected notification: %v", posted[0])
+	}
+	if strings.Contains(text, "secret@example.org") {
+		t.Errorf("flag values must not be posted: %q", text)
+	}
+	if changes, _ := posted[0]["changes"].([]any); len(changes) != 1 {
+		t.Errorf("expected the created alias in changes, got %v", posted[0]["changes"])
+	}
+	if posted[1]["command"] != "alerts check" || posted[1]["success"] != false || posted[1]["error"] == "" {
+		t.Errorf("unexpected failure notification: %v", posted[1])
+	}
+}
+
+// TestNotifyCommandResult_OnlyChanges runs commands end to end: a dry run and
+// a declined confirmation change nothing and post nothing.
+func TestNotifyCommandResult_OnlyChanges(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	var posted []map[string]any
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		posted = append(posted, body)
+	}))
+	defer hook.Close()
+
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    aliases:
+      - name: info
+        recipients: [me@example.org]
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	viper.Set("notify", []string{hook.URL})
+	t.Cleanup(func() {
+		viper.Reset()
+		bindRootFlags()
+		resetAliasFlags()
+		resetCommandFlags(aliasDeleteCmd)
+		resetCommandFlags(domainUpdateCmd)
+		rootCmd.SetIn(nil)
+	})
+
+	run := func(stdin string, args ...string) {
+		t.Helper()
+		resetAliasFlags()
+		resetCommandFlags(aliasDeleteCmd)
+		resetCommandFlags(domainUpdateCmd)
+		var buf bytes.Buffer
+		rootCmd.SetIn(strings.NewReader(stdin))
+		rootCmd.SetOut(&buf)
+		rootCmd.SetErr(&buf)
+		rootCmd.SetArgs(args)
+		captureStdout(t, func() {
+			if err := Execute(context.Background()); err != nil {
+				t.Fatalf("%v: %v\n%s", args, err, buf.String())
+			}
+		})
+	}
+
+	run("", "domain", "update", "example.com", "--catchall", "--dry-run")
+	run("n\n", "alias", "delete", "example.com", "info")
+	if len(posted) != 0 {
+		t.Fatalf("expected no notification for a dry run or a declined delete, got %v", posted)
+	}
+
+	run("", "alias", "delete", "example.com", "info", "--force")
+	if len(posted) != 1 || posted[0]["success"] != true {
+		t.Fatalf("expected one notification for the delete, got %v", posted)
+	}
+	if text, _ := posted[0]["text"].(string); !strings.Contains(text, "alias delete info@example.com") {
+		t.Errorf("expected the deleted alias in the text, got %q", text)
+	}
+}
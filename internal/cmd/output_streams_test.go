@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+)
+
+// resetCommandFlags restores the flags of cmd to their defaults so that the
+// next Execute starts clean.
+func resetCommandFlags(cmd *cobra.Command) {
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if s, ok := f.Value.(pflag.SliceValue); ok {
+			_ = s.Replace(nil)
+		} else {
+			_ = f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	})
+}
+
+// TestJSONOutputStreams runs commands with -o json and checks that stdout only
+// ever holds the JSON document, with banners, footers and prompts on stderr.
+func TestJSONOutputStreams(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir()) // send ledger
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    plan: enhanced_protection
+    aliases:
+      - name: info
+        recipients: [me@example.org]
+      - name: sales
+        recipients: [team@example.org]
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetAliasFlags()
+		resetDomainUpdateFlags()
+		resetCommandFlags(emailSendCmd)
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	})
+
+	tests := []struct {
+		args []string
+		// data is false for commands that only report success; their stdout
+		// must then stay empty.
+		data bool
+	}{
+		{args: []string{"domain", "list"}, data: true},
+		{args: []string{"domain", "get", "example.com"}, data: true},
+		{args: []string{"domain", "create", "new.example"}, data: true},
+		{args: []string{"domain", "update", "example.com", "--retention-days", "30"}, data: true},
+		{args: []string{"domain", "update", "example.com", "--retention-days", "60", "--dry-run"}, data: true},
+		{args: []string{"domain", "verify", "example.com"}, data: true},
+		{args: []string{"domain", "delete", "new.example", "--force"}},
+		{args: []string{"alias", "list", "example.com"}, data: true},
+		{args: []string{"alias", "get", "example.com", "info"}, data: true},
+		{args: []string{"alias", "create", "example.com", "hello", "--recipients", "me@example.org"}, data: true},
+		{args: []string{"alias", "update", "example.com", "hello", "--description", "greeting"}, data: true},
+		{args: []string{"alias", "disable", "example.com", "hello"}},
+		{args: []string{"alias", "enable", "example.com", "hello"}},
+		{args: []string{"alias", "recipients", "example.com", "hello", "--recipients", "you@example.org"}},
+		{args: []string{"alias", "delete", "example.com", "hello", "--force"}},
+		{args: []string{"email", "send", "--from", "info@example.com", "--to", "me@example.org",
+			"--subject", "Hi", "--text", "Hello", "--yes"}, data: true},
+		{args: []string{"email", "list"}, data: true},
+		{args: []string{"email", "quota"}, data: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args[:2], " "), func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			rootCmd.SetOut(&stdout)
+			rootCmd.SetErr(&stderr)
+			rootCmd.SetArgs(append(tt.args, "-o", "json"))
+			var err error
+			// Some commands format straight to os.Stdout rather than cmd's writer.
+			stdout.WriteString(captureStdout(t, func() { err = rootCmd.Execute() }))
+			if c, _, findErr := rootCmd.Find(tt.args); findErr == nil {
+				resetCommandFlags(c)
+			}
+			if err != nil {
+				t.Fatalf("%v: %v\n%s", tt.args, err, stderr.String())
+			}
+
+			if !tt.data {
+				if stdout.Len() != 0 {
+					t.Errorf("%v: expected no stdout, got %q", tt.args, stdout.String())
+				}
+				return
+			}
+			if !json.Valid(stdout.Bytes()) {
+				t.Errorf("%v: stdout is not clean JSON:\n%s", tt.args, stdout.String())
+			}
+		})
+	}
+}
+
+// TestMarkdownOutput checks that commands printing tables render them as
+// Markdown with -o markdown.
+func TestMarkdownOutput(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    aliases:
+      - name: info
+        recipients: [me@example.org]
+emails:
+  - id: e1
+    subject: Welcome
+    status: delivered
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetAliasFlags()
+		resetDomainUpdateFlags()
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	})
+
+	for _, args := range [][]string{
+		{"domain", "list"},
+		{"domain", "get", "example.com"},
+		{"domain", "update", "example.com", "--retention-days", "60", "--dry-run"},
+		{"alias", "list", "example.com"},
+		{"alias", "get", "example.com", "info"},
+		{"email", "list"},
+		{"email", "quota"},
+	} {
+		t.Run(strings.Join(args[:2], " "), func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			rootCmd.SetOut(&stdout)
+			rootCmd.SetErr(&stderr)
+			rootCmd.SetArgs(append(args, "-o", "markdown"))
+			var err error
+			stdout.WriteString(captureStdout(t, func() { err = rootCmd.Execute() }))
+			if c, _, findErr := rootCmd.Find(args); findErr == nil {
+				resetCommandFlags(c)
+			}
+			if err != nil {
+				t.Fatalf("%v: %v\n%s", args, err, stderr.String())
+			}
+			lines := strings.Split(stdout.String(), "\n")
+			if len(lines) < 3 || !strings.HasPrefix(lines[0], "| ") || !strings.HasPrefix(lines[1], "| --- |") {
+				t.Errorf("%v: stdout is not a Markdown table:\n%s", args, stdout.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	fe "github.com/ginsys/forward-email/pkg/errors"
+)
+
+// exitPartial is the exit status of a command that, with --fail-on-partial,
+// completed for only some of its domains or items. Other errors exit with 1.
+const exitPartial = 3
+
+// partialFailure is one part of an aggregated command, such as one domain of
+// alias list --all-domains, that failed while the rest succeeded.
+type partialFailure struct {
+	Domain    string `json:"domain,omitempty" yaml:"domain,omitempty"`
+	Item      string `json:"item,omitempty" yaml:"item,omitempty"` // what failed, when narrower than a domain
+	Error     string `json:"error" yaml:"error"`
+	Retryable bool   `json:"retryable" yaml:"retryable"` // rate limits, 5xx and unavailability
+}
+
+func newPartialFailure(domain, item string, err error) partialFailure {
+	return partialFailure{Domain: domain, Item: item, Error: err.Error(), Retryable: fe.IsRetryable(err)}
+}
+
+func (f partialFailure) String() string {
+	label := f.Item
+	if label == "" {
+		label = f.Domain
+	}
+	s := label + ": " + f.Error
+	if f.Retryable {
+		s += " (retryable)"
+	}
+	return s
+}
+
+// partialError is returned with --fail-on-partial when part of a command
+// failed; ExitCode maps it to exitPartial.
+type partialError struct {
+	failed int
+}
+
+func (e *partialError) Error() string {
+	return fmt.Sprintf("%d part(s) of the command failed", e.failed)
+}
+
+// ExitCode returns the process exit status for an error returned by Execute.
+func ExitCode(err error) int {
+	var partial *partialError
+	if errors.As(err, &partial) {
+		return exitPartial
+	}
+	return 1
+}
+
+// reportPartial prints failures on stderr under heading, after the command's
+// output, and with --fail-on-partial turns them into the command's error.
+// Defer it with a pointer to the command's named error result.
+func reportPartial(cmd *cobra.Command, heading string, failures []partialFailure, err *error) {
+	if len(failures) == 0 {
+		return
+	}
+	lines := make([]string, len(failures))
+	for i, f := range failures {
+		lines[i] = f.String()
+	}
+	printWarnings(cmd, heading, lines)
+	if *err == nil {
+		*err = partialResult(failures)
+	}
+}
+
+// partialResult is the error for failures with --fail-on-partial, or nil.
+func partialResult(failures []partialFailure) error {
+	if len(failures) == 0 || !viper.GetBool("fail_on_partial") {
+		return nil
+	}
+	return &partialError{failed: len(failures)}
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+	fe "github.com/ginsys/forward-email/pkg/errors"
+)
+
+func TestPartialFailure(t *testing.T) {
+	f := newPartialFailure("example.com", "", fe.NewServiceUnavailableError("maintenance"))
+	if !f.Retryable || !strings.HasPrefix(f.String(), "example.com: ") || !strings.HasSuffix(f.String(), " (retryable)") {
+		t.Errorf("unexpected failure %+v rendered as %q", f, f)
+	}
+	f = newPartialFailure("example.com", "info@example.com", fe.NewNotFoundError("alias"))
+	if f.Retryable || !strings.HasPrefix(f.String(), "info@example.com: ") {
+		t.Errorf("unexpected failure %+v rendered as %q", f, f)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	if got := ExitCode(fmt.Errorf("wrapped: %w", &partialError{failed: 2})); got != exitPartial {
+		t.Errorf("ExitCode(partial) = %d, want %d", got, exitPartial)
+	}
+	if got := ExitCode(errors.New("boom")); got != 1 {
+		t.Errorf("ExitCode(other) = %d, want 1", got)
+	}
+}
+
+func TestFailOnPartial(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    aliases:
+      - name: info
+        recipients: [me@example.org]
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetAliasFlags()
+		resetCommandFlags(aliasListCmd)
+		_ = rootCmd.PersistentFlags().Set("fail-on-partial", "false")
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	})
+
+	run := func(args ...string) (string, string, error) {
+		var stdout, stderr bytes.Buffer
+		rootCmd.SetOut(&stdout)
+		rootCmd.SetErr(&stderr)
+		rootCmd.SetArgs(args)
+		err := rootCmd.Execute()
+		return stdout.String(), stderr.String(), err
+	}
+
+	// Without the flag a partial failure is only a warning.
+	stdout, stderr, err := run("alias", "list", "example.com,missing.example")
+	if err != nil {
+		t.Fatalf("alias list: %v", err)
+	}
+	if !strings.Contains(stdout, "info") || !strings.Contains(stderr, "1 domain(s) could not be listed") {
+		t.Errorf("expected the list and a warning, got stdout:\n%s\nstderr:\n%s", stdout, stderr)
+	}
+
+	stdout, stderr, err = run("alias", "list", "example.com,missing.example", "--fail-on-partial")
+	if ExitCode(err) != exitPartial {
+		t.Fatalf("expected a partial failure, got %v", err)
+	}
+	if !strings.Contains(stdout, "info") || !strings.Contains(stderr, "missing.example: ") {
+		t.Errorf("expected the list and a warning, got stdout:\n%s\nstderr:\n%s", stdout, stderr)
+	}
+
+	// The envelope carries the failures and the exit status still reports them.
+	stdout, _, err = run("alias", "list", "example.com,missing.example", "-o", "json", "--envelope", "--fail-on-partial")
+	if ExitCode(err) != exitPartial || !strings.Contains(stdout, `"domain": "missing.example"`) {
+		t.Errorf("expected a partial failure in the envelope, got %v:\n%s", err, stdout)
+	}
+
+	if _, _, err := run("alias", "list", "example.com", "--fail-on-partial"); err != nil {
+		t.Errorf("expected no error without failures, got %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ginsys/forward-email/pkg/schema"
+)
+
+// readPatchFile decodes a YAML or JSON partial spec from path ("-" for stdin)
+// into req, whose json tags define the accepted field names. The file is first
+// checked against the named schema, so typos and wrong types are reported with
+// their line and column. Fields already set in req and absent from the file
+// are kept. It returns the top-level keys of the file.
+func readPatchFile(cmd *cobra.Command, path, schemaName string, req any) (map[string]any, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read patch file: %v", err)
+	}
+
+	return decodePatch(data, "patch file "+path, schemaName, req)
+}
+
+// decodePatch validates data against the named schema and decodes it into
+// req as readPatchFile does. source names the document in error messages.
+func decodePatch(data []byte, source, schemaName string, req any) (map[string]any, error) {
+	if err := schema.Validate(schemaName, data); err != nil {
+		return nil, fmt.Errorf("invalid %s:\n%v", source, err)
+	}
+
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", source, err)
+	}
+	if len(doc) == 0 {
+		return nil, fmt.Errorf("%s is empty", source)
+	}
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", source, err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(req); err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", source, err)
+	}
+	return doc, nil
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+)
+
+func TestUpdateFromPatchFile(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    retention_days: 30
+    settings:
+      smtp_port: 2525
+      has_phishing_protection: true
+    aliases:
+      - name: sales
+        recipients: [a@example.org]
+        is_enabled: true
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetAliasFlags()
+		resetDomainUpdateFlags()
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	})
+
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	run := func(args ...string) (string, error) {
+		t.Helper()
+		var buf bytes.Buffer
+		rootCmd.SetOut(&buf)
+		rootCmd.SetErr(&buf)
+		rootCmd.SetArgs(args)
+		err := rootCmd.Execute()
+		return buf.String(), err
+	}
+
+	domainPatch := write("domain.yaml", `
+retention_days: 60
+allowlist: [partner.com, vendor.com]
+settings:
+  has_virus_protection: true
+`)
+	// Flags override the file
+	if out, err := run("domain", "update", "example.com", "-f", domainPatch, "--retention-days", "90", "-o", "table"); err != nil {
+		t.Fatalf("domain update: %v\n%s", err, out)
+	}
+
+	c, err := client.NewAPIClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	d, err := c.Domains.GetDomain(ctx, "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.RetentionDays != 90 || len(d.Allowlist) != 2 {
+		t.Errorf("patch not applied: retention %d, allowlist %v", d.RetentionDays, d.Allowlist)
+	}
+	if d.Settings == nil || !d.Settings.HasVirusProtection || !d.Settings.HasPhishingProtection || d.Settings.SMTPPort != 2525 {
+		t.Errorf("partial settings patch should keep other settings: %+v", d.Settings)
+	}
+
+	typo := write("typo.yaml", "retention_dayz: 10\n")
+	if _, err := run("domain", "update", "example.com", "-f", typo, "-o", "table"); err == nil || !strings.Contains(err.Error(), "retention_dayz") {
+		t.Errorf("expected unknown field error, got %v", err)
+	}
+
+	aliasPatch := write("alias.json", `{"recipients": ["b@example.org", "c@example.org"], "description": "Sales team"}`)
+	if out, err := run("alias", "update", "example.com", "sales", "-f", aliasPatch, "-o", "table"); err != nil {
+		t.Fatalf("alias update: %v\n%s", err, out)
+	}
+	a, err := c.Aliases.GetAlias(ctx, "example.com", "sales")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(a.Recipients) != 2 || a.Recipients[0] != "b@example.org" || a.Description != "Sales team" || !a.IsEnabled {
+		t.Errorf("alias patch not applied: %+v", a)
+	}
+}
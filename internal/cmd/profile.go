@@ -1,14 +1,17 @@
 package cmd
 
 import (
-	"bufio"
 	"fmt"
-	"strings"
+	"log/slog"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
+	"github.com/ginsys/forward-email/internal/client"
 	"github.com/ginsys/forward-email/internal/keyring"
+	"github.com/ginsys/forward-email/pkg/api"
 	"github.com/ginsys/forward-email/pkg/config"
+	"github.com/ginsys/forward-email/pkg/i18n"
 	"github.com/ginsys/forward-email/pkg/output"
 )
 
@@ -25,10 +28,11 @@ and configuration settings.`,
 
 // profileListCmd represents the profile list command
 var profileListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List all profiles",
-	Long:  `List all available configuration profiles and show the current active profile.`,
-	RunE:  runProfileList,
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List all profiles",
+	Long:    `List all available configuration profiles and show the current active profile.`,
+	RunE:    runProfileList,
 }
 
 // profileShowCmd represents the profile show command
@@ -51,25 +55,31 @@ var profileSwitchCmd = &cobra.Command{
 
 // profileDeleteCmd represents the profile delete command
 var profileDeleteCmd = &cobra.Command{
-	Use:   "delete <profile-name>",
-	Short: "Delete a profile",
-	Long:  `Delete a profile and its associated credentials from both the config file and keyring.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runProfileDelete,
+	Use:     "delete <profile-name>",
+	Aliases: []string{"rm"},
+	Short:   "Delete a profile",
+	Long:    `Delete a profile and its associated credentials from both the config file and keyring.`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runProfileDelete,
 }
 
 // profileCreateCmd represents the profile create command
 var profileCreateCmd = &cobra.Command{
 	Use:   "create <profile-name>",
 	Short: "Create a new profile",
-	Long:  `Create a new empty profile with default settings.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runProfileCreate,
+	Long: `Create a new empty profile with default settings.
+
+Use --base-url to point the profile at a self-hosted Forward Email instance or a
+staging environment. The URL may include a path prefix, e.g.
+https://mail.example.com/api.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProfileCreate,
 }
 
 var (
-	profileOutputFormat string
-	profileForce        bool
+	profileForce         bool
+	profileBaseURL       string
+	profileDefaultDomain string
 )
 
 func init() {
@@ -80,12 +90,12 @@ func init() {
 	profileCmd.AddCommand(profileDeleteCmd)
 	profileCmd.AddCommand(profileCreateCmd)
 
-	// Global flags for profile commands
-	profileCmd.PersistentFlags().StringVarP(&profileOutputFormat, "output", "o",
-		"table", "Output format (table, json, yaml)")
-
 	// Delete command flags
 	profileDeleteCmd.Flags().BoolVarP(&profileForce, "force", "f", false, "Force deletion without confirmation")
+
+	// Create command flags
+	profileCreateCmd.Flags().StringVar(&profileBaseURL, "base-url", client.DefaultBaseURL, "API base URL for this profile")
+	profileCreateCmd.Flags().StringVar(&profileDefaultDomain, "default-domain", "", "Domain used by alias commands that are not given one")
 }
 
 func runProfileList(_ *cobra.Command, _ []string) error {
@@ -95,7 +105,7 @@ func runProfileList(_ *cobra.Command, _ []string) error {
 	}
 
 	// Create table data for profiles
-	if profileOutputFormat == outputTable {
+	if viper.GetString("output") == outputTable {
 		headers := []string{"PROFILE", "CURRENT", "BASE_URL", "HAS_API_KEY", "OUTPUT", "TIMEOUT"}
 		table := output.NewTableData(headers)
 
@@ -143,7 +153,7 @@ func runProfileList(_ *cobra.Command, _ []string) error {
 		Profiles:       cfg.Profiles,
 	}
 
-	outputFormat, err := output.ParseFormat(profileOutputFormat)
+	outputFormat, err := output.ParseFormat(viper.GetString("output"))
 	if err != nil {
 		return fmt.Errorf("invalid output format: %w", err)
 	}
@@ -185,7 +195,7 @@ func runProfileShow(_ *cobra.Command, args []string) error {
 		}
 	}
 
-	if profileOutputFormat == outputTable {
+	if viper.GetString("output") == outputTable {
 		headers := []string{"PROPERTY", "VALUE"}
 		table := output.NewTableData(headers)
 
@@ -201,6 +211,7 @@ func runProfileShow(_ *cobra.Command, args []string) error {
 		table.AddRow([]string{"Username", output.FormatValue(profile.Username)})
 		table.AddRow([]string{"Output Format", profile.Output})
 		table.AddRow([]string{"Timeout", profile.Timeout})
+		table.AddRow([]string{"Default Domain", output.FormatValue(profile.DefaultDomain)})
 
 		formatter := output.NewFormatter(output.FormatTable, nil)
 		return formatter.Format(table)
@@ -215,6 +226,7 @@ func runProfileShow(_ *cobra.Command, args []string) error {
 		Username       string `json:"username" yaml:"username"`
 		Output         string `json:"output" yaml:"output"`
 		Timeout        string `json:"timeout" yaml:"timeout"`
+		DefaultDomain  string `json:"default_domain,omitempty" yaml:"default_domain,omitempty"`
 	}{
 		Name:           profileName,
 		IsCurrent:      profileName == cfg.CurrentProfile,
@@ -223,9 +235,10 @@ func runProfileShow(_ *cobra.Command, args []string) error {
 		Username:       profile.Username,
 		Output:         profile.Output,
 		Timeout:        profile.Timeout,
+		DefaultDomain:  profile.DefaultDomain,
 	}
 
-	outputFormat, err := output.ParseFormat(profileOutputFormat)
+	outputFormat, err := output.ParseFormat(viper.GetString("output"))
 	if err != nil {
 		return fmt.Errorf("invalid output format: %w", err)
 	}
@@ -234,7 +247,7 @@ func runProfileShow(_ *cobra.Command, args []string) error {
 	return formatter.Format(profileData)
 }
 
-func runProfileSwitch(_ *cobra.Command, args []string) error {
+func runProfileSwitch(cmd *cobra.Command, args []string) error {
 	profileName := args[0]
 
 	cfg, err := config.Load()
@@ -251,7 +264,7 @@ func runProfileSwitch(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to save config: %w", err)
 	}
 
-	fmt.Printf("Switched to profile '%s'\n", profileName)
+	cmd.PrintErrf("Switched to profile '%s'\n", profileName)
 	return nil
 }
 
@@ -267,23 +280,20 @@ func runProfileDelete(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("profile '%s' does not exist", profileName)
 	}
 
-	if !profileForce {
-		prompt := "Are you sure you want to delete profile '%s'? This will remove all associated credentials. [y/N]: "
-		fmt.Printf(prompt, profileName)
-		reader := bufio.NewReader(cmd.InOrStdin())
-		line, _ := reader.ReadString('\n')
-		response := strings.TrimSpace(line)
-		if !strings.EqualFold(response, "y") && !strings.EqualFold(response, yesStr) {
-			fmt.Println("Profile deletion canceled")
-			return nil
-		}
+	ok, err := confirm(cmd, i18n.T("Are you sure you want to delete profile '%s'? This will remove all associated credentials.", profileName))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		cmd.PrintErrln(i18n.T("Profile deletion canceled"))
+		return nil
 	}
 
 	// Remove from keyring if it exists
 	kr, err := keyring.New(keyring.Config{})
 	if err == nil {
 		if err := kr.DeleteAPIKey(profileName); err != nil {
-			fmt.Printf("Warning: failed to delete credentials from keyring: %v\n", err)
+			slog.Warn("failed to delete credentials from keyring", "profile", profileName, "error", err)
 		}
 	}
 
@@ -293,14 +303,14 @@ func runProfileDelete(cmd *cobra.Command, args []string) error {
 	// If this was the current profile, unset it
 	if cfg.CurrentProfile == profileName {
 		cfg.CurrentProfile = ""
-		fmt.Printf("Current profile unset. Use 'forward-email profile switch <name>' to set a new current profile\n")
+		cmd.PrintErrf("Current profile unset. Use 'forward-email profile switch <name>' to set a new current profile\n")
 	}
 
 	if err := cfg.Save(); err != nil {
 		return fmt.Errorf("failed to save config: %w", err)
 	}
 
-	cmd.Printf("Profile '%s' deleted successfully\n", profileName)
+	cmd.PrintErrf("Profile '%s' deleted successfully\n", profileName)
 	return nil
 }
 
@@ -316,14 +326,21 @@ func runProfileCreate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("profile '%s' already exists", profileName)
 	}
 
+	baseURL, err := api.ParseBaseURL(profileBaseURL)
+	if err != nil {
+		return err
+	}
+
 	// Create new profile with default settings
 	newProfile := config.Profile{
-		BaseURL:  "https://api.forwardemail.net",
+		BaseURL:  baseURL.String(),
 		APIKey:   "",
 		Username: "",
 		Password: "",
 		Timeout:  "30s",
 		Output:   "table",
+
+		DefaultDomain: profileDefaultDomain,
 	}
 
 	if cfg.Profiles == nil {
@@ -340,10 +357,26 @@ func runProfileCreate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to save config: %w", err)
 	}
 
-	cmd.Printf("Profile '%s' created successfully\n", profileName)
+	cmd.PrintErrf("Profile '%s' created successfully\n", profileName)
 	if cfg.CurrentProfile == profileName {
-		fmt.Printf("Set as current profile\n")
+		cmd.PrintErrf("Set as current profile\n")
 	}
-	fmt.Printf("Use 'forward-email auth login --profile %s' to add API credentials\n", profileName)
+	cmd.PrintErrf("Use 'forward-email auth login --profile %s' to add API credentials\n", profileName)
 	return nil
 }
+
+// activeProfile returns the profile selected by --profile or the config's
+// current profile, together with its name. A missing config yields an empty
+// profile.
+func activeProfile() (config.Profile, string) {
+	name := viper.GetString("profile")
+
+	cfg, err := config.Load()
+	if err != nil {
+		return config.Profile{}, name
+	}
+	if name == "" {
+		name = cfg.CurrentProfile
+	}
+	return cfg.Profiles[name], name
+}
@@ -306,8 +306,7 @@ profiles: {}
 
 			// Add flags
 			profileDeleteCmd.Flags().BoolVarP(&profileForce, "force", "f", false, "Force deletion without confirmation")
-			profileCmd.PersistentFlags().StringVarP(&profileOutputFormat, "output", "o",
-				"table", "Output format (table, json, yaml)")
+			rootCmd.PersistentFlags().StringP("output", "o", "table", "Output format (table|json|yaml|csv|plain)")
 
 			// Build command hierarchy
 			profileCmd.AddCommand(profileListCmd, profileShowCmd, profileSwitchCmd, profileDeleteCmd, profileCreateCmd)
@@ -452,9 +451,8 @@ func TestProfileInit(t *testing.T) {
 	testProfileCmd.AddCommand(testProfileListCmd)
 	testRootCmd.AddCommand(testProfileCmd)
 
-	// Add flags similar to init function
-	testProfileCmd.PersistentFlags().StringVarP(&profileOutputFormat, "output", "o",
-		"table", "Output format (table, json, yaml)")
+	// Output is a root persistent flag inherited by profile commands
+	testRootCmd.PersistentFlags().StringP("output", "o", "table", "Output format (table|json|yaml|csv|plain)")
 
 	// Test that the command tree is properly constructed
 	if testRootCmd.Commands()[0] != testProfileCmd {
@@ -466,7 +464,7 @@ func TestProfileInit(t *testing.T) {
 	}
 
 	// Test flag access
-	flag := testProfileCmd.PersistentFlags().Lookup("output")
+	flag := testProfileCmd.Flag("output")
 	if flag == nil {
 		t.Error("Output flag not found")
 		return
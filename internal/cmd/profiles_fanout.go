@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/config"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+// accountResult is what one profile's account returned during a fan-out.
+type accountResult[T any] struct {
+	account string
+	items   []T
+}
+
+func init() {
+	addAllProfilesFlag(domainListCmd, domainVerifyStatusCmd, emailQuotaCmd, alertsCheckCmd)
+}
+
+// addAllProfilesFlag registers --all-profiles on read-only commands that can
+// merge results from every configured account.
+func addAllProfilesFlag(cmds ...*cobra.Command) {
+	for _, c := range cmds {
+		c.Flags().Bool("all-profiles", false, "Run for every configured profile and merge the results with an ACCOUNT column")
+	}
+}
+
+// allProfilesSet reports whether --all-profiles was given, rejecting --profile
+// alongside it.
+func allProfilesSet(cmd *cobra.Command) (bool, error) {
+	all, _ := cmd.Flags().GetBool("all-profiles")
+	if all && viper.GetString("profile") != "" {
+		return false, fmt.Errorf("cannot use --all-profiles with --profile")
+	}
+	return all, nil
+}
+
+// configuredProfiles returns the names of all configured profiles, sorted.
+func configuredProfiles() ([]string, error) {
+	cfg, err := config.LoadWithoutDefaults()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	names := make([]string, 0, len(cfg.Profiles))
+	for name := range cfg.Profiles {
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no profiles configured")
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// fanOutProfiles calls collect with a client for every configured profile, in
+// name order. A failing profile is reported on stderr and skipped, so the other
+// accounts are still shown; the returned error then counts the failures.
+func fanOutProfiles[T any](
+	ctx context.Context, cmd *cobra.Command, collect func(context.Context, *api.Client) ([]T, error),
+) ([]accountResult[T], error) {
+	profiles, err := configuredProfiles()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]accountResult[T], 0, len(profiles))
+	failed := 0
+	for _, profile := range profiles {
+		c, err := client.NewAPIClientForProfile(profile)
+		if err == nil {
+			var items []T
+			if items, err = collect(ctx, c); err == nil {
+				results = append(results, accountResult[T]{account: profile, items: items})
+				continue
+			}
+		}
+		failed++
+		slog.Warn("profile failed", "profile", profile, "error", err)
+	}
+
+	if failed > 0 {
+		return results, fmt.Errorf("failed for %d of %d profiles", failed, len(profiles))
+	}
+	return results, nil
+}
+
+// formatAccountResults prints fan-out results. JSON and YAML get one list whose
+// items carry an "account" field; other formats get a leading ACCOUNT column.
+func formatAccountResults[T any](
+	cmd *cobra.Command, results []accountResult[T], table func([]T, output.Format) (*output.TableData, error),
+) error {
+	format, err := output.ParseFormat(viper.GetString("output"))
+	if err != nil {
+		return fmt.Errorf("invalid output format: %w", err)
+	}
+	formatter := output.NewFormatter(format, cmd.OutOrStdout())
+
+	if format == output.FormatJSON || format == output.FormatYAML {
+		items := []map[string]any{}
+		for _, r := range results {
+			for _, item := range r.items {
+				tagged, err := withAccount(r.account, item)
+				if err != nil {
+					return err
+				}
+				items = append(items, tagged)
+			}
+		}
+		return formatter.Format(items)
+	}
+
+	var merged *output.TableData
+	for _, r := range results {
+		t, err := table(r.items, format)
+		if err != nil {
+			return err
+		}
+		if merged == nil {
+			merged = output.NewTableData(append([]string{"ACCOUNT"}, t.Headers...))
+		}
+		for _, row := range t.Rows {
+			merged.AddRow(append([]string{r.account}, row...))
+		}
+	}
+	if merged == nil {
+		cmd.PrintErrln("No results")
+		return nil
+	}
+	return formatter.Format(merged)
+}
+
+// withAccount returns item's JSON fields plus an "account" field.
+func withAccount(account string, item any) (map[string]any, error) {
+	raw, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]any{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	fields["account"] = account
+	return fields, nil
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+)
+
+func TestAllProfiles_MergesAccounts(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	if err := os.MkdirAll(filepath.Join(dir, "forwardemail"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	cfg := "current_profile: acme\nprofiles:\n  acme: {output: table}\n  globex: {output: table}\n"
+	if err := os.WriteFile(filepath.Join(dir, "forwardemail", "config.yaml"), []byte(cfg), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for profile, domain := range map[string]string{"acme": "acme.com", "globex": "globex.com"} {
+		seed, err := mockserver.ParseSeed([]byte("domains:\n  - name: " + domain + "\n"))
+		if err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+		srv := httptest.NewServer(mockserver.New(seed))
+		t.Cleanup(srv.Close)
+		client.SetTestProfile(profile, srv.URL)
+	}
+	client.SetTestMode("http://127.0.0.1:0", auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		for _, c := range []*pflag.FlagSet{domainListCmd.Flags(), emailQuotaCmd.Flags()} {
+			c.VisitAll(func(f *pflag.Flag) {
+				_ = f.Value.Set(f.DefValue)
+				f.Changed = false
+			})
+		}
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	})
+
+	run := func(args ...string) string {
+		t.Helper()
+		var buf bytes.Buffer
+		rootCmd.SetOut(&buf)
+		rootCmd.SetErr(&buf)
+		rootCmd.SetArgs(args)
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("%v: %v\n%s", args, err, buf.String())
+		}
+		return buf.String()
+	}
+
+	var domains []map[string]any
+	out := run("domain", "list", "--all-profiles", "-o", "json")
+	if err := json.Unmarshal([]byte(out), &domains); err != nil {
+		t.Fatalf("decode %q: %v", out, err)
+	}
+	if len(domains) != 2 || domains[0]["account"] != "acme" || domains[0]["name"] != "acme.com" ||
+		domains[1]["account"] != "globex" || domains[1]["name"] != "globex.com" {
+		t.Errorf("unexpected merged domains: %v", domains)
+	}
+
+	out = run("email", "quota", "--all-profiles", "-o", "table")
+	if !strings.Contains(out, "ACCOUNT") || !strings.Contains(out, "acme") || !strings.Contains(out, "globex") {
+		t.Errorf("expected an ACCOUNT column with both profiles:\n%s", out)
+	}
+}
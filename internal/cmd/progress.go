@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/term"
+)
+
+// progressTerminal reports whether progress can be drawn on w. Tests replace
+// it to draw into a buffer.
+var progressTerminal = func(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+const (
+	progressBarWidth = 20
+	progressItemMax  = 40
+	clearLine        = "\r\033[K"
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// progress draws a one-line progress bar for an operation over a known number
+// of items on stderr: items done, the current item and the estimated time
+// left. Nothing is drawn when stderr is not a terminal or with --quiet, so
+// pipes and logs only see the usual status messages. A nil *progress is
+// valid and does nothing.
+type progress struct {
+	w       io.Writer
+	label   string
+	total   int
+	started int
+	start   time.Time
+}
+
+// newProgress returns a progress for total items, or nil when progress should
+// not be shown.
+func newProgress(cmd *cobra.Command, label string, total int) *progress {
+	w := cmd.ErrOrStderr()
+	if total < 1 || viper.GetBool("quiet") || !progressTerminal(w) {
+		return nil
+	}
+	return &progress{w: w, label: label, total: total, start: time.Now()}
+}
+
+// Item reports that work on item has begun, which means every item before it
+// is done.
+func (p *progress) Item(item string) {
+	if p == nil {
+		return
+	}
+	done := p.started
+	p.started++
+	_, _ = fmt.Fprint(p.w, clearLine+p.line(done, item, time.Since(p.start)))
+}
+
+// Finish removes the bar, so the messages that follow start on a clean line.
+// It may be called more than once.
+func (p *progress) Finish() {
+	if p == nil {
+		return
+	}
+	_, _ = fmt.Fprint(p.w, clearLine)
+}
+
+// line renders the bar after done items took elapsed.
+func (p *progress) line(done int, item string, elapsed time.Duration) string {
+	filled := done * progressBarWidth / p.total
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", progressBarWidth-filled)
+	s := fmt.Sprintf("%s %s %d/%d", p.label, bar, done, p.total)
+	if done > 0 {
+		eta := elapsed / time.Duration(done) * time.Duration(p.total-done)
+		s += " ETA " + eta.Round(time.Second).String()
+	}
+	if r := []rune(item); len(r) > progressItemMax {
+		item = string(r[:progressItemMax-1]) + "…"
+	}
+	return s + "  " + item
+}
+
+// startSpinner shows label with a spinner on stderr until the returned stop
+// function is called, for steps whose length is unknown, such as a single
+// large API request. Like progress it is silent off a terminal or with
+// --quiet.
+func startSpinner(cmd *cobra.Command, label string) (stop func()) {
+	w := cmd.ErrOrStderr()
+	if viper.GetBool("quiet") || !progressTerminal(w) {
+		return func() {}
+	}
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+		for frame := 0; ; frame++ {
+			_, _ = fmt.Fprintf(w, "%s%s %s", clearLine, spinnerFrames[frame%len(spinnerFrames)], label)
+			select {
+			case <-done:
+				_, _ = fmt.Fprint(w, clearLine)
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			wg.Wait()
+		})
+	}
+}
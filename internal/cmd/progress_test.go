@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// drawProgress makes progress render into buffers as if they were terminals.
+func drawProgress(t *testing.T) {
+	t.Helper()
+	orig := progressTerminal
+	progressTerminal = func(io.Writer) bool { return true }
+	t.Cleanup(func() { progressTerminal = orig })
+}
+
+func TestProgress(t *testing.T) {
+	drawProgress(t)
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	var stderr bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetErr(&stderr)
+
+	p := newProgress(cmd, "Importing", 4)
+	p.Item("info")
+	p.Item("sales")
+	p.Finish()
+	out := stderr.String()
+	for _, want := range []string{"Importing ░░░░░░░░░░░░░░░░░░░░ 0/4  info", "1/4 ETA ", "sales", clearLine} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in %q", want, out)
+		}
+	}
+	if !strings.HasSuffix(out, clearLine) {
+		t.Errorf("expected Finish to clear the bar, got %q", out)
+	}
+
+	viper.Set("quiet", true)
+	if p := newProgress(cmd, "Importing", 4); p != nil {
+		t.Error("expected no progress with --quiet")
+	}
+	viper.Set("quiet", false)
+	progressTerminal = func(io.Writer) bool { return false }
+	if p := newProgress(cmd, "Importing", 4); p != nil {
+		t.Error("expected no progress off a terminal")
+	}
+
+	// A nil progress is a no-op.
+	var none *progress
+	none.Item("x")
+	none.Finish()
+}
+
+func TestProgressLine(t *testing.T) {
+	p := &progress{label: "Syncing", total: 10}
+	got := p.line(5, strings.Repeat("x", 50), 10*time.Second)
+	want := "Syncing ██████████░░░░░░░░░░ 5/10 ETA 10s  " + strings.Repeat("x", progressItemMax-1) + "…"
+	if got != want {
+		t.Errorf("line() = %q, want %q", got, want)
+	}
+}
+
+func TestSpinner(t *testing.T) {
+	drawProgress(t)
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	var stderr bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetErr(&stderr)
+
+	stop := startSpinner(cmd, "Fetching aliases from example.com")
+	stop()
+	stop()
+	out := stderr.String()
+	if !strings.Contains(out, spinnerFrames[0]+" Fetching aliases from example.com") || !strings.HasSuffix(out, clearLine) {
+		t.Errorf("unexpected spinner output %q", out)
+	}
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ginsys/forward-email/pkg/addrlist"
+	"github.com/ginsys/forward-email/pkg/contacts"
+)
+
+// addressListValue is a repeatable flag whose values are RFC 5322 address
+// lists. Each value is parsed as it is set, so a bad entry fails the command
+// with its position before anything is sent. It replaces pflag's string
+// slice, whose CSV parsing rejects quoted display names.
+type addressListValue struct {
+	list    *[]string
+	parse   func(string) ([]string, error)
+	changed bool
+}
+
+var _ pflag.SliceValue = (*addressListValue)(nil)
+
+// emailAddressListVar defines a flag of email addresses, kept with their
+// display names, e.g. "Smith, Bob" <bob@example.com>.
+func emailAddressListVar(fs *pflag.FlagSet, p *[]string, name, usage string) {
+	fs.Var(&addressListValue{list: p, parse: parseEmailAddressList}, name, usage)
+}
+
+// aliasRecipientListVar defines a flag of alias recipients.
+func aliasRecipientListVar(fs *pflag.FlagSet, p *[]string, name, usage string) {
+	fs.Var(&addressListValue{list: p, parse: parseAliasRecipientList}, name, usage)
+}
+
+func (v *addressListValue) Set(s string) error {
+	values, err := v.parse(s)
+	if err != nil {
+		return err
+	}
+	if !v.changed {
+		*v.list = nil
+		v.changed = true
+	}
+	*v.list = append(*v.list, values...)
+	return nil
+}
+
+func (v *addressListValue) Type() string { return "addresses" }
+
+func (v *addressListValue) String() string { return "[" + strings.Join(*v.list, ",") + "]" }
+
+func (v *addressListValue) Append(s string) error {
+	*v.list = append(*v.list, s)
+	return nil
+}
+
+func (v *addressListValue) Replace(values []string) error {
+	*v.list = values
+	v.changed = false
+	return nil
+}
+
+func (v *addressListValue) GetSlice() []string { return *v.list }
+
+// parseEmailAddressList parses an address list for an email header, keeping
+// display names. Contact nicknames and @groups are expanded from the address
+// book, and an address is kept once however many groups list it.
+func parseEmailAddressList(s string) ([]string, error) {
+	entries, err := addrlist.Split(s)
+	if err != nil || len(entries) == 0 {
+		return nil, err
+	}
+	var (
+		book *contacts.Book
+		out  []string
+	)
+	seen := map[string]bool{}
+	add := func(addr *mail.Address) {
+		if key := strings.ToLower(addr.Address); !seen[key] {
+			seen[key] = true
+			out = append(out, addrlist.Format(addr))
+		}
+	}
+	for _, e := range entries {
+		if !contacts.IsReference(e.Raw) {
+			addr, err := addrlist.ParseEntry(s, e)
+			if err != nil {
+				return nil, err
+			}
+			add(addr)
+			continue
+		}
+		if book == nil {
+			if book, _, err = loadContacts(); err != nil {
+				return nil, err
+			}
+		}
+		expanded, err := book.Expand(e.Raw)
+		if err != nil {
+			return nil, &addrlist.Error{List: s, Entry: e.Raw, Offset: e.Offset, Reason: err.Error()}
+		}
+		for _, a := range expanded {
+			addr, err := mail.ParseAddress(a)
+			if err != nil {
+				return nil, &addrlist.Error{List: s, Entry: e.Raw, Offset: e.Offset, Reason: fmt.Sprintf("invalid address %q in the address book", a)}
+			}
+			add(addr)
+		}
+	}
+	return out, nil
+}
+
+// parseAliasRecipientList parses a list of alias recipients. Email addresses
+// may have display names, which are dropped since forwarding has no use for
+// them; webhook URLs, domain names and IP addresses are kept as written.
+func parseAliasRecipientList(s string) ([]string, error) {
+	entries, err := addrlist.Split(s)
+	if err != nil || len(entries) == 0 {
+		return nil, err
+	}
+	out := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if !strings.Contains(e.Raw, "@") || strings.Contains(e.Raw, "://") {
+			out = append(out, e.Raw)
+			continue
+		}
+		addr, err := addrlist.ParseEntry(s, e)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, addr.Address)
+	}
+	return out, nil
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestAddressListFlags(t *testing.T) {
+	var to, recipients []string
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	emailAddressListVar(fs, &to, "to", "")
+	aliasRecipientListVar(fs, &recipients, "recipients", "")
+
+	err := fs.Parse([]string{
+		"--to", `"Smith, Bob" <bob@example.com>, carol@example.com`, "--to", "dave@example.com,",
+		"--recipients", `"Team" <team@example.org>, https://hooks.example.net/in?a=1, mx.example.net`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(to, " | "); got != `"Smith, Bob" <bob@example.com> | carol@example.com | dave@example.com` {
+		t.Errorf("unexpected --to values: %s", got)
+	}
+	if got := strings.Join(recipients, " | "); got != "team@example.org | https://hooks.example.net/in?a=1 | mx.example.net" {
+		t.Errorf("unexpected --recipients values: %s", got)
+	}
+
+	err = fs.Parse([]string{"--to", "a@example.com, b@@example.com"})
+	if err == nil || !strings.Contains(err.Error(), `invalid address "b@@example.com" at position 16`) {
+		t.Errorf("expected the bad address and its position, got %v", err)
+	}
+}
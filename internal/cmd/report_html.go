@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+// addReportHTMLFlag adds --report-html to a report command.
+func addReportHTMLFlag(cmd *cobra.Command, path *string) {
+	cmd.Flags().StringVar(path, "report-html", "", "Also write the report as a standalone HTML page to this file")
+}
+
+// writeHTMLReportFile renders r into path. The report is built in memory
+// first so a template error does not leave a truncated file behind.
+func writeHTMLReportFile(cmd *cobra.Command, path string, r *output.HTMLReport) error {
+	var b bytes.Buffer
+	if err := output.WriteHTMLReport(&b, r); err != nil {
+		return fmt.Errorf("failed to render HTML report: %v", err)
+	}
+	if err := os.WriteFile(path, b.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("failed to write HTML report: %v", err)
+	}
+	cmd.PrintErrf("Wrote HTML report to %s\n", path)
+	return nil
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+)
+
+func TestReportHTML(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    has_dmarc_record: true
+    has_spf_record: true
+    aliases:
+      - name: info
+        recipients: [me@example.org]
+        labels: ["owner:jane", "team:ops"]
+      - name: old
+        recipients: [me@example.org]
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	seed.Emails = reportEmails(time.Now().UTC())
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		domainAuditMinScore, domainAuditReportHTML = 100, ""
+		resetCommandFlags(domainAuditCmd)
+		emailReportSince, emailReportGroupBy, emailReportHTML = "30d", reportGroupDay, ""
+		resetCommandFlags(emailReportCmd)
+		aliasOwnerReportHTML = ""
+		resetCommandFlags(aliasOwnerReportCmd)
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	})
+
+	dir := t.TempDir()
+	run := func(args ...string) (string, string, error) {
+		var stdout, stderr bytes.Buffer
+		rootCmd.SetOut(&stdout)
+		rootCmd.SetErr(&stderr)
+		rootCmd.SetArgs(args)
+		err := rootCmd.Execute()
+		return stdout.String(), stderr.String(), err
+	}
+	readReport := func(name string) string {
+		data, err := os.ReadFile(filepath.Join(dir, name)) //nolint:gosec // path is inside the test's temp dir
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	// The HTML file is written alongside the normal output, and even when
+	// the score fails the gate.
+	audit := filepath.Join(dir, "audit.html")
+	stdout, stderr, err := run("domain", "audit", "example.com", "--report-html", audit)
+	if err == nil {
+		t.Fatal("expected the audit to fail the default minimum score")
+	}
+	if !strings.Contains(stdout, "dmarc") || !strings.Contains(stderr, "Wrote HTML report to "+audit) {
+		t.Errorf("unexpected output:\n%s\n%s", stdout, stderr)
+	}
+	if html := readReport("audit.html"); !strings.Contains(html, "<title>Domain audit: example.com</title>") ||
+		!strings.Contains(html, "<svg") {
+		t.Errorf("unexpected audit report:\n%s", html)
+	}
+
+	if _, _, err := run("email", "report", "--group-by", "recipient-domain", "--report-html", filepath.Join(dir, "email.html")); err != nil {
+		t.Fatalf("email report: %v", err)
+	}
+	html := readReport("email.html")
+	for _, want := range []string{"<h2>Emails sent by recipient domain</h2>", ">gmail.com</text>", "<td>TOTAL</td>"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("email report lacks %q", want)
+		}
+	}
+
+	if _, _, err := run("alias", "owner", "report", "example.com", "--report-html", filepath.Join(dir, "owners.html")); err != nil {
+		t.Fatalf("alias owner report: %v", err)
+	}
+	html = readReport("owners.html")
+	for _, want := range []string{"<h2>Aliases by owner</h2>", ">jane</text>", ">(unowned)</text>"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("owner report lacks %q", want)
+		}
+	}
+}
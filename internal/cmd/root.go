@@ -5,8 +5,15 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/ginsys/forward-email/internal/logging"
 	buildversion "github.com/ginsys/forward-email/internal/version"
+	"github.com/ginsys/forward-email/pkg/i18n"
+	"github.com/ginsys/forward-email/pkg/output"
+	outschema "github.com/ginsys/forward-email/pkg/output/schema"
+	"github.com/ginsys/forward-email/pkg/units"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -35,7 +42,127 @@ Features:
 // start the CLI application and handle all command parsing and execution.
 func Execute(ctx context.Context) error {
 	rootCmd.SetContext(ctx)
-	return rootCmd.Execute()
+	start := time.Now()
+	executed, err := rootCmd.ExecuteC()
+	reportTelemetry(ctx, executed, time.Since(start))
+	notifyCommandResult(ctx, executed, err)
+	closeLogFile()
+	return err
+}
+
+// closeLog closes the --log-file opened by setupLogging.
+var closeLog func() error
+
+// persistentPreRun runs before every command: it applies the config's flag
+// defaults for the command, configures logging, timestamps, the message
+// language, accessibility mode, the output schema version and key order from
+// the global flags, then runs the profile's pre hooks.
+func persistentPreRun(cmd *cobra.Command, args []string) error {
+	if err := applyCommandDefaults(cmd); err != nil {
+		return err
+	}
+	if err := setupLogging(cmd); err != nil {
+		return err
+	}
+	if err := setupTimeFormat(); err != nil {
+		return err
+	}
+	if err := i18n.SetLanguage(viper.GetString("lang")); err != nil {
+		return err
+	}
+	setupAccessibility(cmd)
+	if err := setupSchemaVersion(cmd); err != nil {
+		return err
+	}
+	output.SetSortKeys(viper.GetBool("sort_keys"))
+	return runPreHooks(cmd, args)
+}
+
+// setupTimeFormat applies --time-format and --timezone to every timestamp the
+// output package renders.
+func setupTimeFormat() error {
+	format, err := output.ParseTimeFormat(viper.GetString("time_format"))
+	if err != nil {
+		return err
+	}
+	var loc *time.Location
+	if tz := viper.GetString("timezone"); tz != "" {
+		if loc, err = time.LoadLocation(tz); err != nil {
+			return fmt.Errorf("invalid time zone %q: use an IANA name such as Europe/Brussels, UTC or Local", tz)
+		}
+	}
+	output.SetTimeFormat(format, loc)
+	return nil
+}
+
+// setupAccessibility applies --ascii: tables get ASCII borders and everything
+// the command prints passes through output.ToASCII. Progress bars and spinners
+// are not drawn, as the wrapped stderr is no longer a terminal.
+func setupAccessibility(cmd *cobra.Command) {
+	on := viper.GetBool("accessibility")
+	output.SetASCII(on)
+	if on {
+		cmd.SetOut(output.NewASCIIWriter(cmd.OutOrStdout()))
+		cmd.SetErr(output.NewASCIIWriter(cmd.ErrOrStderr()))
+	}
+}
+
+// setupSchemaVersion applies --schema-version to JSON and YAML output: an
+// older version converts the command's documents into its shape, and a
+// deprecated one prints a warning.
+func setupSchemaVersion(cmd *cobra.Command) error {
+	output.SetDocumentConverter(nil)
+	name := viper.GetString("schema_version")
+	if name == "" {
+		return nil
+	}
+	version, err := outschema.Lookup(name)
+	if err != nil {
+		return err
+	}
+	switch output.Format(strings.ToLower(viper.GetString("output"))) {
+	case output.FormatJSON, output.FormatYAML:
+	default:
+		return nil
+	}
+	if version.Deprecated != "" {
+		cmd.PrintErrf("⚠️  Output schema %s is deprecated: %s\n", version.Name, version.Deprecated)
+	}
+	command := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	if version.Converts(command) {
+		output.SetDocumentConverter(func(doc any) (any, error) {
+			return version.Convert(command, doc)
+		})
+	}
+	return nil
+}
+
+// setupLogging installs the slog logger selected by --log-level, --log-format
+// and --log-file. --debug implies --log-level debug.
+func setupLogging(cmd *cobra.Command) error {
+	closeLogFile()
+	level := viper.GetString("log_level")
+	if level == "" && viper.GetBool("debug") {
+		level = "debug"
+	}
+	closeFn, err := logging.Setup(logging.Options{
+		Level:  level,
+		Format: viper.GetString("log_format"),
+		File:   viper.GetString("log_file"),
+		Stderr: cmd.ErrOrStderr(),
+	})
+	if err != nil {
+		return err
+	}
+	closeLog = closeFn
+	return nil
+}
+
+func closeLogFile() {
+	if closeLog != nil {
+		_ = closeLog()
+		closeLog = nil
+	}
 }
 
 // initFlags initializes all persistent flags for the root command and binds them to viper.
@@ -45,17 +172,28 @@ func Execute(ctx context.Context) error {
 func initFlags() {
 	// Global flags with short options
 	rootCmd.PersistentFlags().StringP("profile", "p", "", "Configuration profile to use")
-	rootCmd.PersistentFlags().StringP("output", "o", "table", "Output format (table|json|yaml|csv|plain)")
+	rootCmd.PersistentFlags().StringP("output", "o", "table", "Output format (table|json|yaml|csv|plain|markdown|gha)")
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose output")
 	rootCmd.PersistentFlags().Bool("debug", false, "Enable debug output")
-	rootCmd.PersistentFlags().Duration("timeout", 0, "Request timeout duration")
+	rootCmd.PersistentFlags().Var(new(units.Duration), "timeout", "Request timeout duration (e.g. 30s, 2m)")
+	rootCmd.PersistentFlags().String("api-url", "", "API base URL, overriding the profile's base_url")
+	rootCmd.PersistentFlags().StringSlice("notify", nil, "Post a summary of changes to this webhook URL (Slack, Matrix or generic JSON)")
+	rootCmd.PersistentFlags().String("log-level", "", "Log level for diagnostics on stderr (debug|info|warn|error, default warn)")
+	rootCmd.PersistentFlags().String("log-format", "text", "Log format (text|json)")
+	rootCmd.PersistentFlags().String("log-file", "", "Append diagnostics to this file instead of stderr")
+	rootCmd.PersistentFlags().String("cassette", "", "Record API traffic to, or replay it from, this cassette file")
+	rootCmd.PersistentFlags().String("cassette-mode", "replay", "Cassette mode (replay|record)")
+	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Do not show progress bars or spinners")
+	rootCmd.PersistentFlags().Bool("no-cache", false, "Do not cache API responses or send conditional requests")
+	rootCmd.PersistentFlags().Bool("fail-on-partial", false, "Exit with status 3 when a multi-domain command fails for some domains or items")
+	rootCmd.PersistentFlags().String("time-format", "", "Timestamp format in tables and CSV (relative|local|iso|epoch)")
+	rootCmd.PersistentFlags().String("timezone", "", "Time zone for timestamps, e.g. Europe/Brussels or UTC")
+	rootCmd.PersistentFlags().Bool("ascii", false, "Accessibility mode: ASCII tables and words instead of emoji and symbols")
+	rootCmd.PersistentFlags().String("schema-version", "", "Schema version of JSON and YAML output, e.g. v1 (default: current)")
+	rootCmd.PersistentFlags().Bool("sort-keys", false, "Print JSON and YAML object keys in alphabetical order")
+	rootCmd.PersistentFlags().String("lang", "", "Language of prompts and table headers (en|de, default en)")
 
-	// Bind flags to viper
-	_ = viper.BindPFlag("profile", rootCmd.PersistentFlags().Lookup("profile"))
-	_ = viper.BindPFlag("output", rootCmd.PersistentFlags().Lookup("output"))
-	_ = viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
-	_ = viper.BindPFlag("debug", rootCmd.PersistentFlags().Lookup("debug"))
-	_ = viper.BindPFlag("timeout", rootCmd.PersistentFlags().Lookup("timeout"))
+	bindRootFlags()
 
 	// Version template using internal/version package
 	v := buildversion.Get()
@@ -63,9 +201,50 @@ func initFlags() {
 	rootCmd.SetVersionTemplate(fmt.Sprintf(vt, v.Version, v.Commit, v.Date))
 }
 
+// bindRootFlags binds the global flags to their viper keys.
+func bindRootFlags() {
+	_ = viper.BindPFlag("profile", rootCmd.PersistentFlags().Lookup("profile"))
+	_ = viper.BindPFlag("output", rootCmd.PersistentFlags().Lookup("output"))
+	_ = viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
+	_ = viper.BindPFlag("debug", rootCmd.PersistentFlags().Lookup("debug"))
+	_ = viper.BindPFlag("timeout", rootCmd.PersistentFlags().Lookup("timeout"))
+	_ = viper.BindPFlag("api_base_url", rootCmd.PersistentFlags().Lookup("api-url"))
+	_ = viper.BindPFlag("notify", rootCmd.PersistentFlags().Lookup("notify"))
+	_ = viper.BindPFlag("log_level", rootCmd.PersistentFlags().Lookup("log-level"))
+	_ = viper.BindPFlag("log_format", rootCmd.PersistentFlags().Lookup("log-format"))
+	_ = viper.BindPFlag("log_file", rootCmd.PersistentFlags().Lookup("log-file"))
+	_ = viper.BindPFlag("cassette", rootCmd.PersistentFlags().Lookup("cassette"))
+	_ = viper.BindPFlag("cassette_mode", rootCmd.PersistentFlags().Lookup("cassette-mode"))
+	_ = viper.BindPFlag("quiet", rootCmd.PersistentFlags().Lookup("quiet"))
+	_ = viper.BindPFlag("no_cache", rootCmd.PersistentFlags().Lookup("no-cache"))
+	_ = viper.BindPFlag("fail_on_partial", rootCmd.PersistentFlags().Lookup("fail-on-partial"))
+	_ = viper.BindPFlag("time_format", rootCmd.PersistentFlags().Lookup("time-format"))
+	_ = viper.BindPFlag("timezone", rootCmd.PersistentFlags().Lookup("timezone"))
+	_ = viper.BindPFlag("lang", rootCmd.PersistentFlags().Lookup("lang"))
+	_ = viper.BindPFlag("accessibility", rootCmd.PersistentFlags().Lookup("ascii"))
+	_ = viper.BindPFlag("schema_version", rootCmd.PersistentFlags().Lookup("schema-version"))
+	_ = viper.BindPFlag("sort_keys", rootCmd.PersistentFlags().Lookup("sort-keys"))
+}
+
 func init() {
 	cobra.OnInitialize(initConfig)
 	initFlags()
+	initUsageTemplate()
+	rootCmd.PersistentPreRunE = persistentPreRun
+}
+
+// initUsageTemplate lists each command's aliases, such as ls and rm, next to
+// its description in the help's command list. Cobra only shows them in the
+// help of the command itself.
+func initUsageTemplate() {
+	cobra.AddTemplateFunc("shortWithAliases", func(c *cobra.Command) string {
+		if len(c.Aliases) == 0 {
+			return c.Short
+		}
+		return fmt.Sprintf("%s (alias: %s)", c.Short, strings.Join(c.Aliases, ", "))
+	})
+	rootCmd.SetUsageTemplate(strings.ReplaceAll(rootCmd.UsageTemplate(),
+		"{{rpad .Name .NamePadding }} {{.Short}}", "{{rpad .Name .NamePadding }} {{shortWithAliases .}}"))
 }
 
 // initConfig initializes the configuration system using viper.
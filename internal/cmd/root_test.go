@@ -3,12 +3,23 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/internal/testutil"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/auth"
+	"github.com/ginsys/forward-email/pkg/output"
 )
 
 func TestRootCommand(t *testing.T) {
@@ -266,3 +277,168 @@ func TestVersionTemplate(t *testing.T) {
 		}
 	}
 }
+
+func TestOutputFlag_DefinedOnlyOnRoot(t *testing.T) {
+	var walk func(c *cobra.Command)
+	walk = func(c *cobra.Command) {
+		for _, sub := range c.Commands() {
+			if sub.PersistentFlags().Lookup("output") != nil || sub.LocalNonPersistentFlags().Lookup("output") != nil {
+				t.Errorf("command %q defines its own --output flag; use the root persistent flag", sub.CommandPath())
+			}
+			if sub.Flag("output") == nil && sub.Name() != "help" {
+				t.Errorf("command %q does not inherit the root --output flag", sub.CommandPath())
+			}
+			walk(sub)
+		}
+	}
+	walk(rootCmd)
+}
+
+func TestOutputFlag_AppliesAcrossSubcommands(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/domains/example.com", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(api.Domain{ID: "d1", Name: "example.com"})
+	})
+	mux.HandleFunc("/v1/domains/example.com/aliases/a1", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(api.Alias{ID: "a1", Name: "info"})
+	})
+	mux.HandleFunc("/v1/emails/limit", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(api.EmailQuota{EmailsSent: 3, EmailsLimit: 300})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+
+	tempDir := testutil.SetupTempConfig(t)
+	testutil.WriteTestConfig(t, tempDir, "current_profile: main\nprofiles:\n  main:\n    base_url: https://api.forwardemail.net\n")
+
+	// Other tests reset viper; make sure the root flag is bound as in production.
+	_ = viper.BindPFlag("output", rootCmd.PersistentFlags().Lookup("output"))
+	t.Cleanup(func() { _ = rootCmd.PersistentFlags().Set("output", "table") })
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"domain short flag json", []string{"domain", "get", "example.com", "-o", "json"}, `"name": "example.com"`},
+		{"domain long flag yaml", []string{"domain", "get", "example.com", "--output", "yaml"}, "name: example.com"},
+		{"alias json", []string{"alias", "get", "example.com", "a1", "-o", "json"}, `"name": "info"`},
+		{"email json", []string{"email", "quota", "-o", "json"}, `"emails_limit": 300`},
+		{"profile json", []string{"profile", "show", "main", "-o", "json"}, `"name": "main"`},
+		{"flag before subcommand", []string{"-o", "json", "profile", "show", "main"}, `"name": "main"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			rootCmd.SetOut(&buf)
+			rootCmd.SetErr(&buf)
+			rootCmd.SetArgs(tt.args)
+
+			stdout := captureStdout(t, func() {
+				if err := rootCmd.Execute(); err != nil {
+					t.Fatalf("execute %v: %v\n%s", tt.args, err, buf.String())
+				}
+			})
+
+			if got := stdout + buf.String(); !strings.Contains(got, tt.want) {
+				t.Errorf("expected output to contain %q, got:\n%s", tt.want, got)
+			}
+		})
+	}
+}
+
+// captureStdout returns everything written to os.Stdout while fn runs.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	orig := os.Stdout
+	os.Stdout = w
+	done := make(chan string)
+	go func() {
+		var b bytes.Buffer
+		_, _ = io.Copy(&b, r)
+		done <- b.String()
+	}()
+	defer func() { os.Stdout = orig }()
+	fn()
+	_ = w.Close()
+	return <-done
+}
+
+func TestAccessibilityMode(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    aliases:
+      - name: info
+        recipients: [me@example.org]
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetAliasFlags()
+		resetCommandFlags(aliasListCmd)
+		aliasListCmd.SetOut(nil)
+		aliasListCmd.SetErr(nil)
+		_ = rootCmd.PersistentFlags().Set("ascii", "false")
+		output.SetASCII(false)
+	})
+
+	var stdout, stderr bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetArgs([]string{"alias", "list", "example.com,missing.example", "--ascii"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("alias list: %v", err)
+	}
+
+	if !strings.Contains(stdout.String(), "info") || !strings.Contains(stdout.String(), "+--") {
+		t.Errorf("expected an ASCII table, got:\n%s", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "Warning: 1 domain(s) could not be listed") {
+		t.Errorf("expected a plain warning, got:\n%s", stderr.String())
+	}
+	for _, s := range []string{stdout.String(), stderr.String()} {
+		for _, r := range s {
+			if r > 127 {
+				t.Fatalf("unexpected non-ASCII %q in:\n%s", r, s)
+			}
+		}
+	}
+}
+
+func TestCommandAliases(t *testing.T) {
+	for _, tc := range []struct {
+		args []string
+		want *cobra.Command
+	}{
+		{[]string{"d", "ls"}, domainListCmd},
+		{[]string{"a", "rm"}, aliasDeleteCmd},
+		{[]string{"email", "ls"}, emailListCmd},
+		{[]string{"profile", "rm"}, profileDeleteCmd},
+	} {
+		got, _, err := rootCmd.Find(tc.args)
+		if err != nil || got != tc.want {
+			t.Errorf("%v: got %v (%v), want %s", tc.args, got.CommandPath(), err, tc.want.CommandPath())
+		}
+	}
+
+	if usage := aliasCmd.UsageString(); !strings.Contains(usage, "List aliases (alias: ls)") {
+		t.Errorf("expected aliases in the command list:\n%s", usage)
+	}
+}
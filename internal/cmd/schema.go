@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	outschema "github.com/ginsys/forward-email/pkg/output/schema"
+	"github.com/ginsys/forward-email/pkg/schema"
+)
+
+// schemaCmd groups the JSON Schema commands
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "JSON Schemas for import and patch files",
+	Long: `Print the JSON Schemas of the files forward-email reads, for editor
+validation and CI checks. The same schemas are enforced before any API call.
+
+  alias-import       alias import --file with a .yaml/.yml/.json file
+  alias-import-csv   alias import --file with a CSV file (one object per row)
+  alias-patch        alias update -f
+  domain-patch       domain update -f
+
+'schema versions' lists the versions of the JSON and YAML output itself, for
+--schema-version.`,
+}
+
+var schemaListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List the available schemas",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		for _, name := range schema.Names() {
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), name)
+		}
+		return nil
+	},
+}
+
+var schemaPrintCmd = &cobra.Command{
+	Use:       "print <type>",
+	Short:     "Print a JSON Schema",
+	Example:   `  forward-email schema print alias-import > alias-import.schema.json`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: schema.Names(),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := schema.Get(args[0])
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprint(cmd.OutOrStdout(), string(data))
+		return err
+	},
+}
+
+var schemaVersionsCmd = &cobra.Command{
+	Use:   "versions",
+	Short: "List the output schema versions accepted by --schema-version",
+	Long: `List the versions of the JSON and YAML documents commands print. Within a
+version fields are never renamed, removed or retyped, only added; pin one with
+--schema-version to keep scripts working across releases. Deprecated versions
+still work but warn on stderr.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		for _, v := range outschema.Versions() {
+			switch {
+			case v.Name == outschema.Current():
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s (current)\n", v.Name)
+			case v.Deprecated != "":
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s (deprecated: %s)\n", v.Name, v.Deprecated)
+			default:
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), v.Name)
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+	schemaCmd.AddCommand(schemaListCmd)
+	schemaCmd.AddCommand(schemaPrintCmd)
+	schemaCmd.AddCommand(schemaVersionsCmd)
+}
@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+// Resource types searched by the search command, in result order
+const (
+	searchTypeDomain = "domain"
+	searchTypeAlias  = "alias"
+	searchTypeEmail  = "email"
+)
+
+var searchTypes = []string{searchTypeDomain, searchTypeAlias, searchTypeEmail}
+
+var (
+	searchTypeFilter string
+	searchEmailLimit int
+	searchWorkers    int
+)
+
+// searchCmd represents the search command
+var searchCmd = &cobra.Command{
+	Use:   "search <term>",
+	Short: "Search domains, aliases and sent emails",
+	Long: `Search every resource of the account for a term, case-insensitively:
+
+  domain  domain names
+  alias   alias names, recipients and labels, in every domain
+  email   subjects, senders and recipients of sent emails
+
+Results are grouped by type. Domains' aliases and emails are searched
+concurrently; resources that cannot be searched are reported after the results.`,
+	Example: `  forward-email search acme
+  forward-email search acme --type alias,email -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSearch,
+}
+
+func init() {
+	rootCmd.AddCommand(searchCmd)
+
+	searchCmd.Flags().StringVar(&searchTypeFilter, "type", "", "Only search these types ("+strings.Join(searchTypes, ",")+")")
+	searchCmd.Flags().IntVar(&searchEmailLimit, "email-limit", 100, "Maximum number of emails to return")
+	searchCmd.Flags().IntVar(&searchWorkers, "concurrency", 4, "Number of domains to search at once")
+}
+
+// parseSearchTypes returns the types selected by --type, all when empty.
+func parseSearchTypes(s string) (map[string]bool, error) {
+	selected := map[string]bool{}
+	if s == "" {
+		for _, t := range searchTypes {
+			selected[t] = true
+		}
+		return selected, nil
+	}
+	for _, t := range splitCSVList(s) {
+		t = strings.ToLower(t)
+		if !slices.Contains(searchTypes, t) {
+			return nil, fmt.Errorf("invalid --type %q%s Valid types: %s", t, didYouMean(t, searchTypes), strings.Join(searchTypes, ", "))
+		}
+		selected[t] = true
+	}
+	return selected, nil
+}
+
+func runSearch(cmd *cobra.Command, args []string) (err error) {
+	ctx := cmd.Context()
+	term := strings.TrimSpace(args[0])
+	if term == "" {
+		return fmt.Errorf("search term is required")
+	}
+	types, err := parseSearchTypes(searchTypeFilter)
+	if err != nil {
+		return err
+	}
+	if searchWorkers < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+	format, err := output.ParseFormat(viper.GetString("output"))
+	if err != nil {
+		return fmt.Errorf("invalid output format: %v", err)
+	}
+
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %v", err)
+	}
+
+	var domainResults, aliasResults, emailResults []output.SearchResult
+	var domainFailures, aliasFailures, emailFailures []partialFailure
+	var wg sync.WaitGroup
+	if types[searchTypeDomain] || types[searchTypeAlias] {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			domainResults, aliasResults, domainFailures, aliasFailures = searchDomainsAndAliases(ctx, apiClient, term, types)
+		}()
+	}
+	if types[searchTypeEmail] {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			emailResults, emailFailures = searchEmails(ctx, apiClient, term)
+		}()
+	}
+	wg.Wait()
+
+	results := slices.Concat(domainResults, aliasResults, emailResults)
+	if results == nil {
+		results = []output.SearchResult{}
+	}
+	failures := slices.Concat(domainFailures, aliasFailures, emailFailures)
+	defer reportPartial(cmd, fmt.Sprintf("%d search(es) failed", len(failures)), failures, &err)
+
+	formatter := output.NewFormatter(format, cmd.OutOrStdout())
+	if format == output.FormatJSON || format == output.FormatYAML {
+		return formatter.Format(results)
+	}
+	if len(results) == 0 {
+		cmd.PrintErrf("Nothing found for %q\n", term)
+		return nil
+	}
+	tableData, err := output.FormatSearchResults(results, format)
+	if err != nil {
+		return fmt.Errorf("failed to format output: %v", err)
+	}
+	if err := formatter.Format(tableData); err != nil {
+		return err
+	}
+	cmd.PrintErrf("\n%d domain(s), %d alias(es), %d email(s) match %q\n",
+		len(domainResults), len(aliasResults), len(emailResults), term)
+	return nil
+}
+
+// searchDomainsAndAliases matches term against domain names and, with the
+// alias type selected, the aliases of every domain, searched concurrently.
+func searchDomainsAndAliases(
+	ctx context.Context, c *api.Client, term string, types map[string]bool,
+) (domainResults, aliasResults []output.SearchResult, domainFailures, aliasFailures []partialFailure) {
+	list, err := c.Domains.ListDomains(ctx, &api.ListDomainsOptions{Page: 1, Limit: 1000})
+	if err != nil {
+		return nil, nil, []partialFailure{newPartialFailure("", "domains", err)}, nil
+	}
+	domains := list.Domains
+	if types[searchTypeDomain] {
+		for _, d := range domains {
+			if containsFold(d.Name, term) {
+				domainResults = append(domainResults, output.SearchResult{
+					Type: searchTypeDomain, ID: d.ID, Name: d.Name, Field: "name", Match: d.Name, Detail: d.Plan,
+				})
+			}
+		}
+	}
+	if !types[searchTypeAlias] {
+		return domainResults, nil, nil, nil
+	}
+
+	perDomain := make([][]output.SearchResult, len(domains))
+	errs := make([]error, len(domains))
+	runConcurrently(len(domains), searchWorkers, func(i int) {
+		aliases, err := listAllAliases(ctx, c, domains[i].Name)
+		if err != nil {
+			errs[i] = err
+			return
+		}
+		for _, a := range aliases {
+			if r, ok := matchAlias(&a, domains[i].Name, term); ok {
+				perDomain[i] = append(perDomain[i], r)
+			}
+		}
+	})
+	for i, d := range domains {
+		if errs[i] != nil {
+			aliasFailures = append(aliasFailures, newPartialFailure(d.Name, "aliases of "+d.Name, errs[i]))
+			continue
+		}
+		aliasResults = append(aliasResults, perDomain[i]...)
+	}
+	return domainResults, aliasResults, nil, aliasFailures
+}
+
+// matchAlias reports the first of an alias's name, recipients and labels that
+// contains term.
+func matchAlias(a *api.Alias, domain, term string) (output.SearchResult, bool) {
+	r := output.SearchResult{Type: searchTypeAlias, ID: a.ID, Name: a.Name + "@" + domain}
+	switch {
+	case containsFold(a.Name, term):
+		r.Field, r.Match = "name", a.Name
+	default:
+		if i := slices.IndexFunc(a.Recipients, func(s string) bool { return containsFold(s, term) }); i >= 0 {
+			r.Field, r.Match = "recipients", a.Recipients[i]
+		} else if i := slices.IndexFunc(a.Labels, func(s string) bool { return containsFold(s, term) }); i >= 0 {
+			r.Field, r.Match = "labels", a.Labels[i]
+		} else {
+			return r, false
+		}
+	}
+	return r, true
+}
+
+// searchEmails asks the API for sent emails matching term in their subject,
+// sender or recipients.
+func searchEmails(ctx context.Context, c *api.Client, term string) ([]output.SearchResult, []partialFailure) {
+	resp, err := c.Emails.ListEmails(ctx, &api.ListEmailsOptions{Search: term, Limit: searchEmailLimit, Sort: "sent_at", Order: "desc"})
+	if err != nil {
+		return nil, []partialFailure{newPartialFailure("", "emails", err)}
+	}
+	var results []output.SearchResult
+	for _, e := range resp.Emails {
+		r := output.SearchResult{Type: searchTypeEmail, ID: e.ID, Name: e.Subject, Field: "subject", Match: e.Subject}
+		for _, h := range []string{"From", "To"} {
+			if !containsFold(e.Subject, term) && containsFold(e.Headers[h], term) {
+				r.Field, r.Match = strings.ToLower(h), e.Headers[h]
+				break
+			}
+		}
+		if !e.SentAt.IsZero() {
+			r.Detail = output.FormatTime(e.SentAt, "2006-01-02 15:04")
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// containsFold reports whether s contains substr, ignoring case.
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
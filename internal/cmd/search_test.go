@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+func TestSearch(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: acme.com
+    aliases:
+      - name: info
+        recipients: [me@example.org]
+  - name: example.com
+    aliases:
+      - name: billing
+        recipients: [finance@ACME.com]
+      - name: partners
+        recipients: [me@example.org]
+        labels: [acme-deal]
+      - name: sales
+        recipients: [me@example.org]
+emails:
+  - id: e1
+    subject: Your Acme invoice
+    status: delivered
+  - id: e2
+    subject: Hello
+    status: delivered
+    headers: {From: billing@example.com, To: ceo@acme.com}
+  - id: e3
+    subject: Unrelated
+    status: delivered
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetCommandFlags(searchCmd)
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	})
+
+	search := func(args ...string) ([]output.SearchResult, error) {
+		var stdout, stderr bytes.Buffer
+		rootCmd.SetOut(&stdout)
+		rootCmd.SetErr(&stderr)
+		rootCmd.SetArgs(append([]string{"search", "-o", "json"}, args...))
+		err := rootCmd.Execute()
+		resetCommandFlags(searchCmd)
+		if err != nil {
+			return nil, err
+		}
+		var results []output.SearchResult
+		if jsonErr := json.Unmarshal(stdout.Bytes(), &results); jsonErr != nil {
+			t.Fatalf("stdout is not JSON: %v\n%s", jsonErr, stdout.String())
+		}
+		return results, nil
+	}
+
+	results, err := search("acme")
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	var got []string
+	for _, r := range results {
+		got = append(got, r.Type+":"+r.Name+":"+r.Field)
+	}
+	want := []string{
+		"domain:acme.com:name",
+		"alias:billing@example.com:recipients",
+		"alias:partners@example.com:labels",
+		"email:Your Acme invoice:subject",
+		"email:Hello:to",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("results:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	results, err = search("acme", "--type", "email")
+	if err != nil {
+		t.Fatalf("search --type: %v", err)
+	}
+	if len(results) != 2 || results[0].Type != "email" {
+		t.Errorf("expected only emails, got %+v", results)
+	}
+
+	if _, err := search("acme", "--type", "aliases"); err == nil || !strings.Contains(err.Error(), "Did you mean 'alias'?") {
+		t.Errorf("expected an invalid type error, got %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ginsys/forward-email/internal/clipboard"
+)
+
+// Clipboard hooks, replaced in tests.
+var (
+	clipboardAvailable = clipboard.Available
+	copyToClipboard    = clipboard.Write
+)
+
+// addSecretFlags registers --copy and --no-echo for a command that produces a
+// secret; what names the secret in help text.
+func addSecretFlags(cmd *cobra.Command, what string) {
+	cmd.Flags().Bool("copy", false, fmt.Sprintf("Copy the %s to the clipboard", what))
+	cmd.Flags().Bool("no-echo", false, fmt.Sprintf("Do not print the %s (requires --copy)", what))
+}
+
+// checkSecretFlags validates --copy and --no-echo before a secret is produced,
+// so it is never generated only to be lost.
+func checkSecretFlags(cmd *cobra.Command) error {
+	copyFlag, _ := cmd.Flags().GetBool("copy")
+	noEcho, _ := cmd.Flags().GetBool("no-echo")
+	if noEcho && !copyFlag {
+		return fmt.Errorf("--no-echo requires --copy")
+	}
+	if copyFlag && !clipboardAvailable() {
+		return fmt.Errorf("--copy: %w", clipboard.ErrUnavailable)
+	}
+	return nil
+}
+
+// shareSecret copies secret to the clipboard when --copy is set and reports
+// whether it may also be printed.
+func shareSecret(cmd *cobra.Command, what, secret string) (echo bool, err error) {
+	copyFlag, _ := cmd.Flags().GetBool("copy")
+	noEcho, _ := cmd.Flags().GetBool("no-echo")
+	if !copyFlag {
+		return true, nil
+	}
+	if err := copyToClipboard(secret); err != nil {
+		if noEcho {
+			return false, fmt.Errorf("failed to copy %s to the clipboard: %v", what, err)
+		}
+		cmd.PrintErrf("⚠️  Failed to copy %s to the clipboard: %v\n", what, err)
+		return true, nil
+	}
+	cmd.PrintErrf("📋 Copied %s to the clipboard\n", what)
+	return !noEcho, nil
+}
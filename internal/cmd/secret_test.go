@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/auth"
+)
+
+func TestAliasPassword_CopyNoEcho(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    aliases:
+      - name: info
+        recipients: [me@example.org]
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+
+	var copied string
+	available := true
+	origAvailable, origCopy := clipboardAvailable, copyToClipboard
+	clipboardAvailable = func() bool { return available }
+	copyToClipboard = func(s string) error { copied = s; return nil }
+	t.Cleanup(func() {
+		clipboardAvailable, copyToClipboard = origAvailable, origCopy
+		resetAliasFlags()
+		for _, name := range []string{"copy", "no-echo"} {
+			_ = aliasPasswordCmd.Flags().Set(name, "false")
+		}
+	})
+
+	run := func(args ...string) (string, error) {
+		var buf bytes.Buffer
+		rootCmd.SetOut(&buf)
+		rootCmd.SetErr(&buf)
+		rootCmd.SetArgs(append([]string{"alias", "password", "example.com", "info"}, args...))
+		err := rootCmd.Execute()
+		return buf.String(), err
+	}
+
+	out, err := run("--copy", "--no-echo")
+	if err != nil {
+		t.Fatalf("alias password: %v\n%s", err, out)
+	}
+	if !strings.HasPrefix(copied, "mock-") {
+		t.Fatalf("expected the password on the clipboard, got %q", copied)
+	}
+	if strings.Contains(out, copied) || !strings.Contains(out, "Copied password to the clipboard") {
+		t.Errorf("password must not be echoed with --no-echo:\n%s", out)
+	}
+
+	_ = aliasPasswordCmd.Flags().Set("copy", "false")
+	if _, err := run("--no-echo"); err == nil || !strings.Contains(err.Error(), "--no-echo requires --copy") {
+		t.Errorf("expected --no-echo without --copy to fail, got %v", err)
+	}
+
+	available = false
+	_ = aliasPasswordCmd.Flags().Set("no-echo", "false")
+	if _, err := run("--copy"); err == nil || !strings.Contains(err.Error(), "no clipboard tool") {
+		t.Errorf("expected a missing clipboard tool to fail before generating, got %v", err)
+	}
+}
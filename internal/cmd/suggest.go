@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ginsys/forward-email/pkg/suggest"
+)
+
+// didYouMean ends an "invalid ..." sentence and suggests the candidate
+// closest to input: ". Did you mean 'x'?", or just "." when no candidate is
+// close enough to be a likely typo. Callers put it right after the invalid
+// value and follow it with the list of valid values:
+//
+//	fmt.Errorf("invalid column '%s'%s Valid columns: %s", col, didYouMean(col, valid), ...)
+func didYouMean(input string, candidates []string) string {
+	if match, ok := suggest.Closest(input, candidates); ok {
+		return ". Did you mean '" + match + "'?"
+	}
+	return "."
+}
+
+// validateSortField checks a --sort value against the fields a list command
+// accepts; empty means the API default.
+func validateSortField(value string, valid []string) error {
+	if value == "" {
+		return nil
+	}
+	for _, f := range valid {
+		if strings.EqualFold(value, f) {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid --sort field '%s'%s Valid fields: %s", value, didYouMean(value, valid), strings.Join(valid, ", "))
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDidYouMean(t *testing.T) {
+	columns := strings.Split(validColumnsList, ", ")
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"RECIPENTS", ". Did you mean 'recipients'?"},
+		{"enabeld", ". Did you mean 'enabled'?"},
+		{"descripton", ". Did you mean 'description'?"},
+		{"colour", "."},
+		{"", "."},
+	}
+	for _, tt := range tests {
+		if got := didYouMean(tt.input, columns); got != tt.want {
+			t.Errorf("didYouMean(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestListFlagValidationSuggestions(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"alias column", func() error {
+			_, err := formatAliasListWithCustomColumns(nil, "table", nil, "name,recipents")
+			return err
+		}(), "invalid column 'recipents'. Did you mean 'recipients'?"},
+		{"alias order-by", func() error { _, err := parseSortCriteria("domian:desc"); return err }(),
+			"invalid sort field 'domian'. Did you mean 'domain'?"},
+		{"domain sort", validateSortField("created", domainSortFields), "Did you mean 'created_at'?"},
+		{"email sort", validateSortField("subjct", emailSortFields), "Did you mean 'subject'?"},
+		{"email status", func() error { _, err := parseEmailStatus("bouncd"); return err }(), `invalid --status "bouncd". Did you mean 'bounced'? Valid statuses:`},
+		{"no suggestion", validateSortField("colour", emailSortFields), "invalid --sort field 'colour'. Valid fields:"},
+		{"no suggestion in parentheses", func() error { _, err := parseEmailStatus("xyzzy"); return err }(), `invalid --status "xyzzy". Valid statuses:`},
+	}
+	for _, tt := range tests {
+		if tt.err == nil || !strings.Contains(tt.err.Error(), tt.want) {
+			t.Errorf("%s: error = %v, want it to contain %q", tt.name, tt.err, tt.want)
+		}
+	}
+	if err := validateSortField("Name", aliasSortFields); err != nil {
+		t.Errorf("valid sort field rejected: %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestTelemetry(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("DO_NOT_TRACK", "")
+	var events []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		var e map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&e)
+		events = append(events, e)
+	}))
+	defer srv.Close()
+	orig := telemetryEndpoint
+	telemetryEndpoint = srv.URL
+	t.Cleanup(func() { telemetryEndpoint = orig })
+	viper.Reset()
+	bindRootFlags()
+
+	run := func(args ...string) string {
+		t.Helper()
+		var out bytes.Buffer
+		rootCmd.SetOut(&out)
+		rootCmd.SetErr(&out)
+		rootCmd.SetArgs(args)
+		if err := Execute(context.Background()); err != nil {
+			t.Fatalf("%v: %v\n%s", args, err, out.String())
+		}
+		return out.String()
+	}
+
+	if out := run("telemetry", "status"); !strings.Contains(out, "disabled (default)") {
+		t.Errorf("expected telemetry to be off by default, got %q", out)
+	}
+	if len(events) != 0 {
+		t.Fatalf("expected no events while disabled, got %v", events)
+	}
+
+	run("telemetry", "enable")
+	if out := run("telemetry", "status"); !strings.Contains(out, "Telemetry: enabled") {
+		t.Errorf("expected telemetry to be enabled, got %q", out)
+	}
+	// The run that enabled telemetry is the first one reported.
+	if len(events) != 2 || events[0]["command"] != "telemetry enable" || events[1]["command"] != "telemetry status" {
+		t.Fatalf("expected an event per run once enabled, got %v", events)
+	}
+	if _, ok := events[1]["duration_ms"]; !ok || len(events[1]) != 2 {
+		t.Errorf("expected only the command and its duration, got %v", events[1])
+	}
+
+	t.Setenv("DO_NOT_TRACK", "1")
+	if out := run("telemetry", "status"); !strings.Contains(out, "disabled by DO_NOT_TRACK") || len(events) != 2 {
+		t.Errorf("expected DO_NOT_TRACK to stop events, got %q and %d events", out, len(events))
+	}
+	t.Setenv("DO_NOT_TRACK", "")
+
+	run("telemetry", "disable")
+	run("telemetry", "status")
+	if len(events) != 2 {
+		t.Errorf("expected no events once disabled, got %v", events)
+	}
+}
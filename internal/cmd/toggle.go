@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// addToggleFlags registers an explicit --enable-<name>/--disable-<name> pair
+// for an on/off setting. A flag of either name that already exists is reused,
+// and the older --<name>[=true|false] form, if defined, is hidden but kept
+// working. All forms are mutually exclusive.
+func addToggleFlags(cmd *cobra.Command, name, what string) {
+	flags := cmd.Flags()
+	names := make([]string, 0, 3)
+	for _, n := range []string{"enable-" + name, "disable-" + name} {
+		if flags.Lookup(n) == nil {
+			verb := "Enable"
+			if n[0] == 'd' {
+				verb = "Disable"
+			}
+			flags.Bool(n, false, verb+" "+what)
+		}
+		names = append(names, n)
+	}
+	if flags.Lookup(name) != nil {
+		_ = flags.MarkHidden(name)
+		names = append(names, name)
+	}
+	cmd.MarkFlagsMutuallyExclusive(names...)
+}
+
+// toggleValue resolves the flags registered by addToggleFlags into a
+// tri-state: nil when none was given, otherwise whether the setting should be
+// on. --enable-x=false and --disable-x=false invert as expected.
+func toggleValue(flags *pflag.FlagSet, name string) *bool {
+	for _, n := range []string{name, "enable-" + name, "disable-" + name} {
+		f := flags.Lookup(n)
+		if f == nil || !f.Changed {
+			continue
+		}
+		v, _ := flags.GetBool(n)
+		if n == "disable-"+name {
+			v = !v
+		}
+		return &v
+	}
+	return nil
+}
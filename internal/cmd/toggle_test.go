@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestToggleFlags(t *testing.T) {
+	newCmd := func() *cobra.Command {
+		c := &cobra.Command{Use: "update", RunE: func(*cobra.Command, []string) error { return nil }}
+		c.Flags().Bool("catchall", false, "Enable catch-all aliases")
+		c.Flags().Bool("disable-catchall-regex", false, "Disable catch-all regex")
+		addToggleFlags(c, "catchall", "catch-all aliases")
+		addToggleFlags(c, "catchall-regex", "catch-all regex")
+		addToggleFlags(c, "virus-protection", "virus protection")
+		return c
+	}
+
+	tests := []struct {
+		args    []string
+		name    string
+		want    string // "", "on" or "off"
+		wantErr bool
+	}{
+		{args: nil, name: "catchall", want: ""},
+		{args: []string{"--enable-catchall"}, name: "catchall", want: "on"},
+		{args: []string{"--disable-catchall"}, name: "catchall", want: "off"},
+		{args: []string{"--catchall=false"}, name: "catchall", want: "off"},
+		{args: []string{"--catchall"}, name: "catchall", want: "on"},
+		{args: []string{"--disable-catchall=false"}, name: "catchall", want: "on"},
+		{args: []string{"--disable-catchall-regex"}, name: "catchall-regex", want: "off"},
+		{args: []string{"--enable-catchall-regex"}, name: "catchall-regex", want: "on"},
+		{args: []string{"--disable-virus-protection"}, name: "virus-protection", want: "off"},
+		{args: []string{"--enable-catchall"}, name: "virus-protection", want: ""},
+		{args: []string{"--enable-catchall", "--disable-catchall"}, wantErr: true},
+		{args: []string{"--catchall", "--disable-catchall"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		c := newCmd()
+		c.SetArgs(tt.args)
+		err := c.Execute()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%v: error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		got := ""
+		if v := toggleValue(c.Flags(), tt.name); v != nil {
+			got = map[bool]string{true: "on", false: "off"}[*v]
+		}
+		if got != tt.want {
+			t.Errorf("%v: toggleValue(%s) = %q, want %q", tt.args, tt.name, got, tt.want)
+		}
+	}
+
+	if f := newCmd().Flags().Lookup("catchall"); !f.Hidden {
+		t.Error("legacy --catchall flag should be hidden")
+	}
+}
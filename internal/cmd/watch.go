@@ -0,0 +1,271 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/pkg/api"
+	fe "github.com/ginsys/forward-email/pkg/errors"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+var (
+	watchInterval time.Duration
+	watchCount    int
+	watchHook     string
+)
+
+// watchIgnored are fields that change without anyone changing the domain or
+// alias, and would only add noise to the feed.
+var watchIgnored = map[string]bool{
+	"created_at":  true,
+	"updated_at":  true,
+	"alias_count": true, // follows from the aliases added and removed
+	"quota":       true, // alias storage and sending usage
+}
+
+// watchVerificationFields are domain fields reported as verification flips.
+var watchVerificationFields = map[string]bool{
+	"is_verified":            true,
+	"has_mx_record":          true,
+	"has_txt_record":         true,
+	"has_spf_record":         true,
+	"has_dkim_record":        true,
+	"has_dmarc_record":       true,
+	"has_return_path_record": true,
+	"is_smtp_suspended":      true,
+	"smtp_verified_at":       true,
+}
+
+// watchCmd groups the commands that follow changes
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Follow changes to domains and aliases",
+	Long:  `Poll the API and report changes as they happen, such as edits made by teammates in the web UI.`,
+}
+
+var watchDomainCmd = &cobra.Command{
+	Use:   "domain <domain>",
+	Short: "Print a feed of changes to a domain and its aliases",
+	Long: `Poll a domain and its aliases every --interval and print each change:
+settings that changed, aliases added, removed or edited, and verification
+flips such as an MX record that stopped resolving.
+
+With -o json every change is printed as one JSON object per line. --hook runs a
+command for each change with the change as JSON on stdin, the same way profile
+hooks run; a failing hook is reported and watching continues.
+
+Watching stops on Ctrl-C, or after --count checks.`,
+	Example: `  forward-email watch domain example.com
+  forward-email watch domain example.com --interval 5m -o json
+  forward-email watch domain example.com --hook 'jq -r .kind >> changes.log'`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWatchDomain,
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+	watchCmd.AddCommand(watchDomainCmd)
+
+	watchDomainCmd.Flags().DurationVar(&watchInterval, "interval", time.Minute, "Time between checks")
+	watchDomainCmd.Flags().IntVar(&watchCount, "count", 0, "Stop after this many checks (default: until interrupted)")
+	watchDomainCmd.Flags().StringVar(&watchHook, "hook", "", "Run this command for each change, with the change as JSON on stdin")
+}
+
+// watchSnapshot is the state of a domain and its aliases, by alias name, as
+// JSON maps without the ignored fields.
+type watchSnapshot struct {
+	domain  map[string]any
+	aliases map[string]map[string]any
+}
+
+func takeWatchSnapshot(ctx context.Context, c *api.Client, name string) (*watchSnapshot, error) {
+	domain, err := c.Domains.GetDomain(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	aliases, err := listAllAliases(ctx, c, name)
+	if err != nil {
+		return nil, err
+	}
+	return newWatchSnapshot(domain, aliases)
+}
+
+func newWatchSnapshot(domain *api.Domain, aliases []api.Alias) (*watchSnapshot, error) {
+	s := &watchSnapshot{aliases: make(map[string]map[string]any, len(aliases))}
+	var err error
+	if s.domain, err = watchState(domain); err != nil {
+		return nil, err
+	}
+	for i := range aliases {
+		if s.aliases[aliases[i].Name], err = watchState(&aliases[i]); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func watchState(v any) (map[string]any, error) {
+	m, err := toJSONMap(v)
+	if err != nil {
+		return nil, err
+	}
+	for key := range watchIgnored {
+		delete(m, key)
+	}
+	return m, nil
+}
+
+// diffWatchSnapshots lists the changes from prev to cur: domain fields first,
+// then aliases by name.
+func diffWatchSnapshots(domain string, prev, cur *watchSnapshot, now time.Time) []output.WatchChange {
+	var changes []output.WatchChange
+	for _, fc := range diffState("", prev.domain, cur.domain) {
+		kind := output.WatchSetting
+		if watchVerificationFields[fc.Field] {
+			kind = output.WatchVerification
+		}
+		changes = append(changes, output.WatchChange{Time: now, Domain: domain, Kind: kind, Field: fc.Field, Old: fc.Old, New: fc.New})
+	}
+
+	names := make([]string, 0, len(prev.aliases)+len(cur.aliases))
+	for name := range prev.aliases {
+		names = append(names, name)
+	}
+	for name := range cur.aliases {
+		if _, ok := prev.aliases[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		before, existed := prev.aliases[name]
+		after, exists := cur.aliases[name]
+		switch {
+		case !existed:
+			changes = append(changes, output.WatchChange{Time: now, Domain: domain, Kind: output.WatchAliasAdded, Alias: name, New: after["recipients"]})
+		case !exists:
+			changes = append(changes, output.WatchChange{Time: now, Domain: domain, Kind: output.WatchAliasRemoved, Alias: name})
+		default:
+			for _, fc := range diffState("", before, after) {
+				changes = append(changes, output.WatchChange{
+					Time: now, Domain: domain, Kind: output.WatchAliasModified, Alias: name, Field: fc.Field, Old: fc.Old, New: fc.New,
+				})
+			}
+		}
+	}
+	return changes
+}
+
+// diffState compares two JSON maps in both directions, so fields that were
+// dropped show up too, and returns the changed fields sorted by name.
+func diffState(prefix string, prev, cur map[string]any) []output.FieldChange {
+	keys := map[string]bool{}
+	for key := range prev {
+		keys[key] = true
+	}
+	for key := range cur {
+		keys[key] = true
+	}
+	var changes []output.FieldChange
+	for key := range keys {
+		oldVal, newVal := prev[key], cur[key]
+		oldNested, oldIsMap := oldVal.(map[string]any)
+		newNested, newIsMap := newVal.(map[string]any)
+		if oldIsMap || newIsMap {
+			changes = append(changes, diffState(prefix+key+".", oldNested, newNested)...)
+			continue
+		}
+		if !reflect.DeepEqual(oldVal, newVal) {
+			changes = append(changes, output.FieldChange{Field: prefix + key, Old: oldVal, New: newVal})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
+
+func runWatchDomain(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	name := args[0]
+	if watchInterval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+
+	format, err := output.ParseFormat(viper.GetString("output"))
+	if err != nil {
+		return fmt.Errorf("invalid output format: %v", err)
+	}
+	apiClient, err := client.NewAPIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %v", err)
+	}
+
+	prev, err := takeWatchSnapshot(ctx, apiClient, name)
+	if err != nil {
+		return fmt.Errorf("failed to get domain %s: %v", name, err)
+	}
+	cmd.PrintErrf("👀 Watching %s (%d aliases) every %s; press Ctrl-C to stop\n", name, len(prev.aliases), watchInterval)
+
+	for checks := 1; watchCount == 0 || checks < watchCount; checks++ {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(watchInterval):
+		}
+
+		cur, err := takeWatchSnapshot(ctx, apiClient, name)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if !fe.IsRetryable(err) {
+				return fmt.Errorf("failed to get domain %s: %v", name, err)
+			}
+			cmd.PrintErrf("⚠️  Check failed, retrying in %s: %v\n", watchInterval, err)
+			continue
+		}
+
+		for _, change := range diffWatchSnapshots(name, prev, cur, time.Now()) {
+			if err := printWatchChange(cmd, format, change); err != nil {
+				return err
+			}
+			if watchHook != "" {
+				if err := runHookCommand(ctx, watchHook, "watch", change, cmd.ErrOrStderr()); err != nil {
+					cmd.PrintErrf("⚠️  Watch hook failed: %v\n", err)
+				}
+			}
+		}
+		prev = cur
+	}
+	return nil
+}
+
+// printWatchChange prints one change as a JSON line, a YAML document or a
+// line of text.
+func printWatchChange(cmd *cobra.Command, format output.Format, change output.WatchChange) error {
+	w := cmd.OutOrStdout()
+	switch format {
+	case output.FormatJSON:
+		return json.NewEncoder(w).Encode(change)
+	case output.FormatYAML:
+		if _, err := fmt.Fprintln(w, "---"); err != nil {
+			return err
+		}
+		return output.NewFormatter(format, w).Format(change)
+	default:
+		_, err := fmt.Fprintln(w, output.FormatWatchChange(change))
+		return err
+	}
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/ginsys/forward-email/internal/client"
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/auth"
+	"github.com/ginsys/forward-email/pkg/output"
+)
+
+func TestDiffWatchSnapshots(t *testing.T) {
+	now := time.Now()
+	snapshot := func(d api.Domain, aliases ...api.Alias) *watchSnapshot {
+		t.Helper()
+		s, err := newWatchSnapshot(&d, aliases)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	prev := snapshot(api.Domain{Name: "example.com", HasMXRecord: true, IsVerified: true, AliasCount: 2},
+		api.Alias{Name: "info", Recipients: []string{"me@example.org"}, Description: "front desk"},
+		api.Alias{Name: "old", Recipients: []string{"me@example.org"}})
+	cur := snapshot(api.Domain{Name: "example.com", HasMXRecord: false, IsVerified: true, AliasCount: 2, UpdatedAt: now},
+		api.Alias{Name: "info", Recipients: []string{"me@example.org"}, UpdatedAt: now, Quota: &api.AliasQuota{StorageUsed: 10}},
+		api.Alias{Name: "new", Recipients: []string{"you@example.org"}})
+
+	var got []string
+	for _, c := range diffWatchSnapshots("example.com", prev, cur, now) {
+		got = append(got, c.Kind+" "+c.Alias+" "+c.Field)
+	}
+	want := []string{
+		"verification  has_mx_record",
+		"alias_changed info description",
+		"alias_added new ",
+		"alias_removed old ",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected changes:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestWatchDomain(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    retention_days: 30
+    aliases:
+      - name: info
+        recipients: [me@example.org]
+      - name: old
+        recipients: [me@example.org]
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	mock := mockserver.New(seed)
+	// A teammate edits the domain in the web UI between the first and second check.
+	change := func(method, path, body string) {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.SetBasicAuth("test", "")
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		mock.ServeHTTP(rec, req)
+		if rec.Code >= 300 {
+			t.Errorf("%s %s: %d %s", method, path, rec.Code, rec.Body.String())
+		}
+	}
+	var checks int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && r.URL.Path == "/v1/domains/example.com" {
+			if checks++; checks == 2 {
+				change(http.MethodPut, "/v1/domains/example.com", `{"retention_days":60}`)
+				change(http.MethodPost, "/v1/domains/example.com/aliases", `{"name":"sales","recipients":["team@example.org"]}`)
+				change(http.MethodDelete, "/v1/domains/example.com/aliases/old", "")
+			}
+		}
+		mock.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	client.SetTestMode(srv.URL, auth.MockProvider("test"))
+	t.Cleanup(client.ResetTestMode)
+	viper.Reset()
+	bindRootFlags()
+	t.Cleanup(func() {
+		resetCommandFlags(watchDomainCmd)
+		_ = rootCmd.PersistentFlags().Set("output", "table")
+	})
+
+	hookLog := filepath.Join(t.TempDir(), "changes.jsonl")
+	var stdout, stderr bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetArgs([]string{"watch", "domain", "example.com", "--interval", "1ms", "--count", "3",
+		"--hook", "cat >> " + hookLog + "; echo >> " + hookLog, "-o", "json"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("watch domain: %v\n%s", err, stderr.String())
+	}
+	if checks != 3 {
+		t.Errorf("expected 3 checks, got %d", checks)
+	}
+	if !strings.Contains(stderr.String(), "Watching example.com (2 aliases)") {
+		t.Errorf("expected a start message, got %q", stderr.String())
+	}
+
+	var kinds []string
+	for _, line := range strings.Split(strings.TrimSpace(stdout.String()), "\n") {
+		var c output.WatchChange
+		if err := json.Unmarshal([]byte(line), &c); err != nil {
+			t.Fatalf("expected one JSON change per line, got %q: %v", line, err)
+		}
+		kinds = append(kinds, c.Kind+" "+c.Alias+c.Field)
+	}
+	if got := strings.Join(kinds, ", "); got != "setting retention_days, alias_removed old, alias_added sales" {
+		t.Errorf("unexpected change feed: %s", got)
+	}
+
+	data, err := os.ReadFile(hookLog) //nolint:gosec // test file
+	if err != nil {
+		t.Fatalf("hook did not run: %v", err)
+	}
+	if n := strings.Count(string(data), `"kind"`); n != 3 {
+		t.Errorf("expected the hook to run once per change, got %d:\n%s", n, data)
+	}
+}
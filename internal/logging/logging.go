@@ -0,0 +1,89 @@
+// Package logging configures the process-wide slog logger from the CLI's
+// --log-level, --log-format and --log-file options. Diagnostics always go to
+// stderr or the log file, never to stdout, so they cannot corrupt command output.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// Formats accepted by Setup.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Options selects the level, format and destination of log output.
+type Options struct {
+	Level  string    // debug, info, warn or error; empty means warn
+	Format string    // text or json; empty means text
+	File   string    // append to this file instead of writing to Stderr
+	Stderr io.Writer // destination when File is empty
+}
+
+// ParseLevel parses a log level name.
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "", "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return 0, fmt.Errorf("invalid log level %q (valid: debug, info, warn, error)", name)
+}
+
+// Setup builds a logger from opts and installs it as the slog default. The
+// returned function closes the log file, if one was opened.
+func Setup(opts Options) (func() error, error) {
+	level, err := ParseLevel(opts.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	w := opts.Stderr
+	if w == nil {
+		w = os.Stderr
+	}
+	closeFn := func() error { return nil }
+	if opts.File != "" {
+		f, err := os.OpenFile(opts.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600) //nolint:gosec // the log path is given by the user
+		if err != nil {
+			return nil, fmt.Errorf("failed to open log file: %w", err)
+		}
+		w, closeFn = f, f.Close
+	}
+
+	handlerOpts := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	switch strings.ToLower(opts.Format) {
+	case "", FormatText:
+		if opts.File == "" {
+			// Timestamps only add noise to messages read on a terminal
+			handlerOpts.ReplaceAttr = dropTime
+		}
+		handler = slog.NewTextHandler(w, handlerOpts)
+	case FormatJSON:
+		handler = slog.NewJSONHandler(w, handlerOpts)
+	default:
+		_ = closeFn()
+		return nil, fmt.Errorf("invalid log format %q (valid: text, json)", opts.Format)
+	}
+
+	slog.SetDefault(slog.New(handler))
+	return closeFn, nil
+}
+
+func dropTime(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) == 0 && a.Key == slog.TimeKey {
+		return slog.Attr{}
+	}
+	return a
+}
@@ -0,0 +1,64 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSetup(t *testing.T) {
+	prev := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(prev) })
+
+	var buf bytes.Buffer
+	closeFn, err := Setup(Options{Stderr: &buf})
+	if err != nil {
+		t.Fatal(err)
+	}
+	slog.Info("hidden")
+	slog.Warn("failed to list aliases", "domain", "example.com")
+	_ = closeFn()
+	if got := buf.String(); got != "level=WARN msg=\"failed to list aliases\" domain=example.com\n" {
+		t.Errorf("unexpected text output %q", got)
+	}
+
+	buf.Reset()
+	if _, err := Setup(Options{Level: "debug", Format: "json", Stderr: &buf}); err != nil {
+		t.Fatal(err)
+	}
+	slog.Debug("api request", "status", 200)
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("decode %q: %v", buf.String(), err)
+	}
+	if record["level"] != "DEBUG" || record["msg"] != "api request" || record["status"] != float64(200) || record["time"] == nil {
+		t.Errorf("unexpected JSON record %v", record)
+	}
+
+	path := filepath.Join(t.TempDir(), "cli.log")
+	closeFn, err = Setup(Options{File: path, Stderr: &buf})
+	if err != nil {
+		t.Fatal(err)
+	}
+	slog.Error("boom")
+	if err := closeFn(); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "level=ERROR msg=boom") || !strings.HasPrefix(string(data), "time=") {
+		t.Errorf("unexpected log file %q", data)
+	}
+}
+
+func TestSetup_Invalid(t *testing.T) {
+	if _, err := Setup(Options{Level: "loud"}); err == nil || !strings.Contains(err.Error(), "invalid log level") {
+		t.Errorf("expected level error, got %v", err)
+	}
+	if _, err := Setup(Options{Format: "xml"}); err == nil || !strings.Contains(err.Error(), "invalid log format") {
+		t.Errorf("expected format error, got %v", err)
+	}
+}
@@ -0,0 +1,694 @@
+// Package mockserver implements an in-memory stand-in for the subset of the
+// Forward Email REST API used by the CLI (domains, aliases and emails).
+//
+// It is meant for demos, offline development and integration tests of user
+// scripts. Request and response bodies use the types from pkg/api, so the
+// regular API client works against it unchanged. Any non-empty API key is accepted.
+package mockserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ginsys/forward-email/pkg/api"
+)
+
+// Seed is the initial state of a mock server. Seed files are YAML documents that
+// use the same field names as the API's JSON responses.
+type Seed struct {
+	Quota   *api.EmailQuota `json:"quota,omitempty"`
+	Domains []SeedDomain    `json:"domains"`
+	Emails  []api.Email     `json:"emails,omitempty"`
+}
+
+// SeedDomain is a domain together with the aliases that belong to it.
+type SeedDomain struct {
+	api.Domain
+	Aliases []api.Alias `json:"aliases,omitempty"`
+}
+
+// LoadSeed reads a YAML (or JSON) seed file.
+func LoadSeed(path string) (*Seed, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read seed file: %w", err)
+	}
+	return ParseSeed(data)
+}
+
+// ParseSeed decodes seed data. The document is converted to JSON first so that the
+// json tags of the pkg/api types define the accepted field names.
+func ParseSeed(data []byte) (*Seed, error) {
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse seed file: %w", err)
+	}
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse seed file: %w", err)
+	}
+	var seed Seed
+	if err := json.Unmarshal(raw, &seed); err != nil {
+		return nil, fmt.Errorf("invalid seed file: %w", err)
+	}
+	return &seed, nil
+}
+
+// domainState holds a domain and its aliases.
+type domainState struct {
+	domain  api.Domain
+	aliases []api.Alias
+}
+
+// Server is an http.Handler serving the mock API. It is safe for concurrent use.
+type Server struct {
+	mux     *http.ServeMux
+	now     func() time.Time
+	domains []*domainState
+	emails  []api.Email
+	quota   api.EmailQuota
+	nextID  int
+	mu      sync.Mutex
+}
+
+// New creates a mock server initialized from seed, which may be nil.
+func New(seed *Seed) *Server {
+	s := &Server{
+		mux:   http.NewServeMux(),
+		now:   time.Now,
+		quota: api.EmailQuota{EmailsLimit: 300},
+	}
+	if seed != nil {
+		for _, d := range seed.Domains {
+			ds := &domainState{domain: d.Domain, aliases: slices.Clone(d.Aliases)}
+			if ds.domain.ID == "" {
+				ds.domain.ID = s.newID()
+			}
+			for i := range ds.aliases {
+				if ds.aliases[i].ID == "" {
+					ds.aliases[i].ID = s.newID()
+				}
+				ds.aliases[i].DomainID = ds.domain.ID
+			}
+			ds.domain.AliasCount = len(ds.aliases)
+			s.domains = append(s.domains, ds)
+		}
+		for _, e := range seed.Emails {
+			if e.ID == "" {
+				e.ID = s.newID()
+			}
+			s.emails = append(s.emails, e)
+		}
+		if seed.Quota != nil {
+			s.quota = *seed.Quota
+		}
+	}
+	s.routes()
+	return s
+}
+
+func (s *Server) routes() {
+	s.mux.HandleFunc("GET /v1/domains", s.listDomains)
+	s.mux.HandleFunc("POST /v1/domains", s.createDomain)
+	s.mux.HandleFunc("GET /v1/domains/{domain}", s.getDomain)
+	s.mux.HandleFunc("PUT /v1/domains/{domain}", s.updateDomain)
+	s.mux.HandleFunc("DELETE /v1/domains/{domain}", s.deleteDomain)
+	s.mux.HandleFunc("GET /v1/domains/{domain}/verify-records", s.verifyRecords)
+	s.mux.HandleFunc("GET /v1/domains/{domain}/verify-smtp", s.verifySMTP)
+	s.mux.HandleFunc("POST /v1/domains/{domain}/members", s.addMember)
+	s.mux.HandleFunc("DELETE /v1/domains/{domain}/members/{member}", s.removeMember)
+	s.mux.HandleFunc("GET /v1/domains/{domain}/invites", s.acceptInvite)
+
+	s.mux.HandleFunc("GET /v1/domains/{domain}/aliases", s.listAliases)
+	s.mux.HandleFunc("POST /v1/domains/{domain}/aliases", s.createAlias)
+	s.mux.HandleFunc("GET /v1/domains/{domain}/aliases/{alias}", s.getAlias)
+	s.mux.HandleFunc("PUT /v1/domains/{domain}/aliases/{alias}", s.updateAlias)
+	s.mux.HandleFunc("DELETE /v1/domains/{domain}/aliases/{alias}", s.deleteAlias)
+	s.mux.HandleFunc("POST /v1/domains/{domain}/aliases/{alias}/generate-password", s.generatePassword)
+	s.mux.HandleFunc("GET /v1/domains/{domain}/aliases/{alias}/quota", s.aliasQuota)
+	s.mux.HandleFunc("GET /v1/domains/{domain}/aliases/{alias}/stats", s.aliasStats)
+
+	s.mux.HandleFunc("GET /v1/emails", s.listEmails)
+	s.mux.HandleFunc("POST /v1/emails", s.sendEmail)
+	s.mux.HandleFunc("GET /v1/emails/limit", s.emailQuota)
+	s.mux.HandleFunc("GET /v1/emails/{id}", s.getEmail)
+	s.mux.HandleFunc("DELETE /v1/emails/{id}", s.deleteEmail)
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if user, _, ok := r.BasicAuth(); !ok || user == "" {
+		writeError(w, http.StatusUnauthorized, "Invalid API token")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mux.ServeHTTP(w, r)
+}
+
+// newID returns a unique, stable identifier. Callers must hold s.mu or be in New.
+func (s *Server) newID() string {
+	s.nextID++
+	return fmt.Sprintf("mock%020d", s.nextID)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"message": message})
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// findDomain looks a domain up by ID or name and writes a 404 if it is missing.
+func (s *Server) findDomain(w http.ResponseWriter, r *http.Request) (*domainState, int) {
+	key := r.PathValue("domain")
+	for i, d := range s.domains {
+		if d.domain.ID == key || strings.EqualFold(d.domain.Name, key) {
+			return d, i
+		}
+	}
+	writeError(w, http.StatusNotFound, "Domain does not exist")
+	return nil, -1
+}
+
+// findAlias looks an alias up by ID or name and writes a 404 if it is missing.
+func (s *Server) findAlias(w http.ResponseWriter, r *http.Request) (*domainState, int) {
+	d, _ := s.findDomain(w, r)
+	if d == nil {
+		return nil, -1
+	}
+	key := r.PathValue("alias")
+	for i, a := range d.aliases {
+		if a.ID == key || strings.EqualFold(a.Name, key) {
+			return d, i
+		}
+	}
+	writeError(w, http.StatusNotFound, "Alias does not exist")
+	return nil, -1
+}
+
+// paginate applies the page and limit query parameters to n items and sets
+// the pagination headers the API sends with each page.
+func paginate(w http.ResponseWriter, r *http.Request, n int) (start, end int) {
+	q := r.URL.Query()
+	page, _ := strconv.Atoi(q.Get("page"))
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	if page < 1 || limit < 1 {
+		setPageHeaders(w, r, 1, max(n, 1), n)
+		return 0, n
+	}
+	setPageHeaders(w, r, page, limit, n)
+	start = min((page-1)*limit, n)
+	return start, min(start+limit, n)
+}
+
+// setPageHeaders sets the X-Page-* and X-Item-Count headers and a Link header
+// naming the adjacent pages.
+func setPageHeaders(w http.ResponseWriter, r *http.Request, page, limit, n int) {
+	pages := max((n+limit-1)/limit, 1)
+	h := w.Header()
+	h.Set(api.HeaderPageCurrent, strconv.Itoa(page))
+	h.Set(api.HeaderPageSize, strconv.Itoa(limit))
+	h.Set(api.HeaderPageCount, strconv.Itoa(pages))
+	h.Set(api.HeaderItemCount, strconv.Itoa(n))
+	link := func(rel string, p int) string {
+		u := *r.URL
+		q := u.Query()
+		q.Set("page", strconv.Itoa(p))
+		q.Set("limit", strconv.Itoa(limit))
+		u.RawQuery = q.Encode()
+		return fmt.Sprintf("<%s>; rel=%q", u.RequestURI(), rel)
+	}
+	var links []string
+	if page < pages {
+		links = append(links, link("next", page+1))
+	}
+	if page > 1 {
+		links = append(links, link("prev", page-1))
+	}
+	if len(links) > 0 {
+		h.Set("Link", strings.Join(links, ", "))
+	}
+}
+
+func (s *Server) listDomains(w http.ResponseWriter, r *http.Request) {
+	search := strings.ToLower(r.URL.Query().Get("search"))
+	domains := []api.Domain{}
+	for _, d := range s.domains {
+		if search != "" && !strings.Contains(strings.ToLower(d.domain.Name), search) {
+			continue
+		}
+		domains = append(domains, d.domain)
+	}
+	start, end := paginate(w, r, len(domains))
+	writeJSON(w, http.StatusOK, domains[start:end])
+}
+
+func (s *Server) createDomain(w http.ResponseWriter, r *http.Request) {
+	var req api.CreateDomainRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, "Domain name is required")
+		return
+	}
+	for _, d := range s.domains {
+		if strings.EqualFold(d.domain.Name, req.Name) {
+			writeError(w, http.StatusConflict, "Domain already exists")
+			return
+		}
+	}
+	plan := req.Plan
+	if plan == "" {
+		plan = "free"
+	}
+	now := s.now()
+	d := api.Domain{
+		ID:                    s.newID(),
+		Name:                  strings.ToLower(req.Name),
+		Plan:                  plan,
+		VerificationRecord:    fmt.Sprintf("mock%d", s.nextID),
+		MaxForwardedAddresses: 10,
+		Settings:              &api.DomainSettings{},
+		CreatedAt:             now,
+		UpdatedAt:             now,
+	}
+	s.domains = append(s.domains, &domainState{domain: d})
+	writeJSON(w, http.StatusOK, d)
+}
+
+func (s *Server) getDomain(w http.ResponseWriter, r *http.Request) {
+	if d, _ := s.findDomain(w, r); d != nil {
+		writeJSON(w, http.StatusOK, d.domain)
+	}
+}
+
+func (s *Server) updateDomain(w http.ResponseWriter, r *http.Request) {
+	d, _ := s.findDomain(w, r)
+	if d == nil {
+		return
+	}
+	var req api.UpdateDomainRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	applyDomainUpdate(&d.domain, &req)
+	d.domain.UpdatedAt = s.now()
+	writeJSON(w, http.StatusOK, d.domain)
+}
+
+// applyDomainUpdate copies every field set in req onto d.
+func applyDomainUpdate(d *api.Domain, req *api.UpdateDomainRequest) {
+	setIf(&d.MaxForwardedAddresses, req.MaxForwardedAddresses)
+	setIf(&d.RetentionDays, req.RetentionDays)
+	setIf(&d.HasDeliveryLogs, req.HasDeliveryLogs)
+	setIf(&d.BounceWebhook, req.BounceWebhook)
+	setIf(&d.HasRegex, req.HasRegex)
+	setIf(&d.HasCatchall, req.HasCatchall)
+	setIf(&d.IsCatchallRegexDisabled, req.IsCatchallRegexDisabled)
+	setIf(&d.MaxRecipientsPerAlias, req.MaxRecipientsPerAlias)
+	setIf(&d.MaxQuotaPerAlias, req.MaxQuotaPerAlias)
+	setIf(&d.HasRecipientVerification, req.HasRecipientVerification)
+	setIf(&d.IgnoreMXCheck, req.IgnoreMXCheck)
+	if req.Allowlist != nil {
+		d.Allowlist = req.Allowlist
+	}
+	if req.Denylist != nil {
+		d.Denylist = req.Denylist
+	}
+	if req.RestrictedAliasNames != nil {
+		d.RestrictedAliasNames = req.RestrictedAliasNames
+	}
+	if req.Settings != nil {
+		settings := *req.Settings
+		d.Settings = &settings
+	}
+}
+
+func setIf[T any](dst *T, src *T) {
+	if src != nil {
+		*dst = *src
+	}
+}
+
+func (s *Server) deleteDomain(w http.ResponseWriter, r *http.Request) {
+	if _, i := s.findDomain(w, r); i >= 0 {
+		s.domains = slices.Delete(s.domains, i, i+1)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// verifyRecords mirrors the real endpoint: 200 when verified, 400 otherwise.
+func (s *Server) verifyRecords(w http.ResponseWriter, r *http.Request) {
+	d, _ := s.findDomain(w, r)
+	if d == nil {
+		return
+	}
+	if !d.domain.IsVerified {
+		writeError(w, http.StatusBadRequest, "DNS records are not configured")
+		return
+	}
+	writeJSON(w, http.StatusOK, d.domain)
+}
+
+func (s *Server) verifySMTP(w http.ResponseWriter, r *http.Request) {
+	if d, _ := s.findDomain(w, r); d != nil {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("OK"))
+	}
+}
+
+func (s *Server) addMember(w http.ResponseWriter, r *http.Request) {
+	d, _ := s.findDomain(w, r)
+	if d == nil {
+		return
+	}
+	var req struct {
+		Email string `json:"email"`
+		Group string `json:"group"`
+	}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	m := api.DomainMember{
+		User:     api.User{ID: s.newID(), Email: req.Email},
+		Group:    req.Group,
+		JoinedAt: s.now(),
+	}
+	d.domain.Members = append(d.domain.Members, m)
+	writeJSON(w, http.StatusOK, m)
+}
+
+func (s *Server) removeMember(w http.ResponseWriter, r *http.Request) {
+	d, _ := s.findDomain(w, r)
+	if d == nil {
+		return
+	}
+	key := r.PathValue("member")
+	i := slices.IndexFunc(d.domain.Members, func(m api.DomainMember) bool {
+		return m.User.ID == key || strings.EqualFold(m.User.Email, key)
+	})
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "Member does not exist")
+		return
+	}
+	d.domain.Members = slices.Delete(d.domain.Members, i, i+1)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// acceptInvite turns the oldest pending invitation into a member. The mock has
+// no user accounts, so whoever calls it is taken to be the invitee.
+func (s *Server) acceptInvite(w http.ResponseWriter, r *http.Request) {
+	d, _ := s.findDomain(w, r)
+	if d == nil {
+		return
+	}
+	if len(d.domain.Invitations) == 0 {
+		writeError(w, http.StatusNotFound, "Invite does not exist")
+		return
+	}
+	inv := d.domain.Invitations[0]
+	d.domain.Invitations = d.domain.Invitations[1:]
+	d.domain.Members = append(d.domain.Members, api.DomainMember{
+		User:     api.User{ID: s.newID(), Email: inv.Email},
+		Group:    inv.Group,
+		JoinedAt: s.now(),
+	})
+	writeJSON(w, http.StatusOK, d.domain)
+}
+
+func (s *Server) listAliases(w http.ResponseWriter, r *http.Request) {
+	d, _ := s.findDomain(w, r)
+	if d == nil {
+		return
+	}
+	q := r.URL.Query()
+	search := strings.ToLower(q.Get("search"))
+	var labels []string
+	if l := q.Get("labels"); l != "" {
+		labels = strings.Split(l, ",")
+	}
+	aliases := []api.Alias{}
+	for _, a := range d.aliases {
+		if search != "" && !strings.Contains(strings.ToLower(a.Name), search) {
+			continue
+		}
+		if v := q.Get("enabled"); v != "" && strconv.FormatBool(a.IsEnabled) != v {
+			continue
+		}
+		if v := q.Get("has_imap"); v != "" && strconv.FormatBool(a.HasIMAP) != v {
+			continue
+		}
+		if len(labels) > 0 && !slices.ContainsFunc(labels, func(l string) bool { return slices.Contains(a.Labels, l) }) {
+			continue
+		}
+		aliases = append(aliases, a)
+	}
+	start, end := paginate(w, r, len(aliases))
+	writeJSON(w, http.StatusOK, aliases[start:end])
+}
+
+func (s *Server) createAlias(w http.ResponseWriter, r *http.Request) {
+	d, _ := s.findDomain(w, r)
+	if d == nil {
+		return
+	}
+	var req api.CreateAliasRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, "Alias name is required")
+		return
+	}
+	if slices.ContainsFunc(d.aliases, func(a api.Alias) bool { return strings.EqualFold(a.Name, req.Name) }) {
+		writeError(w, http.StatusConflict, "Alias already exists")
+		return
+	}
+	now := s.now()
+	a := api.Alias{
+		ID:          s.newID(),
+		DomainID:    d.domain.ID,
+		Name:        req.Name,
+		Recipients:  req.Recipients,
+		Labels:      req.Labels,
+		Description: req.Description,
+		PublicKey:   req.PublicKey,
+		IsEnabled:   req.IsEnabled,
+		HasIMAP:     req.HasIMAP,
+		HasPGP:      req.HasPGP,
+		Vacation:    req.Vacation,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	d.aliases = append(d.aliases, a)
+	d.domain.AliasCount = len(d.aliases)
+	writeJSON(w, http.StatusOK, a)
+}
+
+func (s *Server) getAlias(w http.ResponseWriter, r *http.Request) {
+	if d, i := s.findAlias(w, r); d != nil {
+		writeJSON(w, http.StatusOK, d.aliases[i])
+	}
+}
+
+func (s *Server) updateAlias(w http.ResponseWriter, r *http.Request) {
+	d, i := s.findAlias(w, r)
+	if d == nil {
+		return
+	}
+	var req api.UpdateAliasRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	a := &d.aliases[i]
+	if req.Name != nil && !strings.EqualFold(*req.Name, a.Name) {
+		if slices.ContainsFunc(d.aliases, func(o api.Alias) bool { return strings.EqualFold(o.Name, *req.Name) }) {
+			writeError(w, http.StatusConflict, "Alias already exists")
+			return
+		}
+		a.Name = *req.Name
+	}
+	if req.Recipients != nil {
+		a.Recipients = req.Recipients
+	}
+	if req.Labels != nil {
+		a.Labels = req.Labels
+	}
+	setIf(&a.Description, req.Description)
+	setIf(&a.PublicKey, req.PublicKey)
+	setIf(&a.IsEnabled, req.IsEnabled)
+	setIf(&a.HasIMAP, req.HasIMAP)
+	setIf(&a.HasPGP, req.HasPGP)
+	if req.Vacation != nil {
+		a.Vacation = req.Vacation
+	}
+	a.UpdatedAt = s.now()
+	writeJSON(w, http.StatusOK, a)
+}
+
+func (s *Server) deleteAlias(w http.ResponseWriter, r *http.Request) {
+	if d, i := s.findAlias(w, r); d != nil {
+		d.aliases = slices.Delete(d.aliases, i, i+1)
+		d.domain.AliasCount = len(d.aliases)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) generatePassword(w http.ResponseWriter, r *http.Request) {
+	var req api.GeneratePasswordRequest
+	if r.ContentLength != 0 && !decodeBody(w, r, &req) {
+		return
+	}
+	if d, i := s.findAlias(w, r); d != nil {
+		d.aliases[i].HasPassword = true
+		if req.NewPassword != "" {
+			// Only server-generated passwords are returned
+			writeJSON(w, http.StatusOK, api.GeneratePasswordResponse{})
+			return
+		}
+		writeJSON(w, http.StatusOK, api.GeneratePasswordResponse{Password: "mock-" + s.newID()})
+	}
+}
+
+func (s *Server) aliasQuota(w http.ResponseWriter, r *http.Request) {
+	if d, i := s.findAlias(w, r); d != nil {
+		quota := api.AliasQuota{StorageLimit: 10 << 30, EmailsLimit: s.quota.EmailsLimit}
+		if q := d.aliases[i].Quota; q != nil {
+			quota = *q
+		}
+		writeJSON(w, http.StatusOK, quota)
+	}
+}
+
+func (s *Server) aliasStats(w http.ResponseWriter, r *http.Request) {
+	if d, i := s.findAlias(w, r); d != nil {
+		writeJSON(w, http.StatusOK, api.AliasStats{LastActivity: d.aliases[i].UpdatedAt})
+	}
+}
+
+func (s *Server) listEmails(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	search := strings.ToLower(q.Get("search"))
+	emails := []api.Email{}
+	for _, e := range s.emails {
+		if v := q.Get("status"); v != "" && e.Status != v {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(e.Subject+"\n"+e.Headers["From"]+"\n"+e.Headers["To"]), search) {
+			continue
+		}
+		// Date filters are inclusive YYYY-MM-DD bounds on the UTC send date
+		day := e.SentAt.UTC().Format("2006-01-02")
+		if v := q.Get("date_from"); v != "" && day < v {
+			continue
+		}
+		if v := q.Get("date_to"); v != "" && day > v {
+			continue
+		}
+		emails = append(emails, e)
+	}
+	start, end := paginate(w, r, len(emails))
+	writeJSON(w, http.StatusOK, emails[start:end])
+}
+
+func (s *Server) sendEmail(w http.ResponseWriter, r *http.Request) {
+	var req api.SendEmailRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.From == "" || len(req.To) == 0 {
+		writeError(w, http.StatusBadRequest, "From and To are required")
+		return
+	}
+	if s.quota.EmailsLimit > 0 && s.quota.EmailsSent >= s.quota.EmailsLimit && !s.quota.OverageAllowed {
+		writeError(w, http.StatusForbidden, "Daily sending limit exceeded")
+		return
+	}
+	s.quota.EmailsSent++
+
+	now := s.now()
+	id := s.newID()
+	headers := map[string]string{
+		"From":       req.From,
+		"To":         strings.Join(req.To, ", "),
+		"Message-ID": fmt.Sprintf("<%s@mock.forwardemail.net>", id),
+	}
+	for k, v := range req.Headers {
+		headers[k] = v
+	}
+	attachments := make([]api.EmailAttachment, 0, len(req.Attachments))
+	for _, a := range req.Attachments {
+		attachments = append(attachments, api.EmailAttachment{
+			ID: s.newID(), Filename: a.Filename, ContentType: a.ContentType, Size: int64(len(a.Content)), CID: a.CID,
+		})
+	}
+	s.emails = append(s.emails, api.Email{
+		ID:          id,
+		Subject:     req.Subject,
+		Text:        req.Text,
+		HTML:        req.HTML,
+		Headers:     headers,
+		Attachments: attachments,
+		Status:      "delivered",
+		SentAt:      now,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		DeliveredAt: &now,
+	})
+	writeJSON(w, http.StatusOK, api.SendEmailResponse{
+		ID: id, MessageID: headers["Message-ID"], Status: "queued", SentAt: now,
+	})
+}
+
+func (s *Server) emailQuota(w http.ResponseWriter, _ *http.Request) {
+	quota := s.quota
+	if quota.ResetTime.IsZero() {
+		y, m, d := s.now().Date()
+		quota.ResetTime = time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC)
+	}
+	writeJSON(w, http.StatusOK, quota)
+}
+
+func (s *Server) findEmail(w http.ResponseWriter, r *http.Request) int {
+	id := r.PathValue("id")
+	i := slices.IndexFunc(s.emails, func(e api.Email) bool { return e.ID == id })
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "Email does not exist")
+	}
+	return i
+}
+
+func (s *Server) getEmail(w http.ResponseWriter, r *http.Request) {
+	if i := s.findEmail(w, r); i >= 0 {
+		writeJSON(w, http.StatusOK, s.emails[i])
+	}
+}
+
+func (s *Server) deleteEmail(w http.ResponseWriter, r *http.Request) {
+	if i := s.findEmail(w, r); i >= 0 {
+		s.emails = slices.Delete(s.emails, i, i+1)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
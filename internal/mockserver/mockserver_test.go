@@ -0,0 +1,193 @@
+package mockserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ginsys/forward-email/pkg/api"
+)
+
+const testSeed = `
+domains:
+  - name: example.com
+    plan: enhanced_protection
+    is_verified: true
+    aliases:
+      - name: info
+        recipients: [me@example.org]
+        labels: [support]
+        is_enabled: true
+      - name: sales
+        recipients: [sales@example.org]
+        is_enabled: false
+  - name: pending.org
+emails:
+  - id: e1
+    subject: Welcome
+    status: delivered
+quota:
+  emails_limit: 2
+`
+
+func newTestClient(t *testing.T) *api.Client {
+	t.Helper()
+	seed, err := ParseSeed([]byte(testSeed))
+	if err != nil {
+		t.Fatalf("ParseSeed: %v", err)
+	}
+	srv := httptest.NewServer(New(seed))
+	t.Cleanup(srv.Close)
+
+	c, err := api.NewClient(api.WithBaseURL(srv.URL), api.WithAPIKey("demo"))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return c
+}
+
+func TestServer_Domains(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t)
+
+	list, err := c.Domains.ListDomains(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListDomains: %v", err)
+	}
+	if len(list.Domains) != 2 || list.Domains[0].AliasCount != 2 {
+		t.Fatalf("unexpected seeded domains: %+v", list.Domains)
+	}
+
+	created, err := c.Domains.CreateDomain(ctx, &api.CreateDomainRequest{Name: "new.com"})
+	if err != nil {
+		t.Fatalf("CreateDomain: %v", err)
+	}
+	if created.ID == "" || created.Plan != "free" || created.VerificationRecord == "" {
+		t.Errorf("unexpected created domain: %+v", created)
+	}
+	if _, err := c.Domains.CreateDomain(ctx, &api.CreateDomainRequest{Name: "new.com"}); err == nil {
+		t.Error("expected duplicate domain to be rejected")
+	}
+
+	days := 30
+	updated, err := c.Domains.UpdateDomain(ctx, "new.com", &api.UpdateDomainRequest{RetentionDays: &days})
+	if err != nil || updated.RetentionDays != 30 {
+		t.Fatalf("UpdateDomain: %v %+v", err, updated)
+	}
+
+	verified, err := c.Domains.VerifyDomain(ctx, "pending.org")
+	if err != nil || verified.IsVerified {
+		t.Fatalf("expected unverified domain without error, got %v %+v", err, verified)
+	}
+
+	if err := c.Domains.DeleteDomain(ctx, created.ID); err != nil {
+		t.Fatalf("DeleteDomain: %v", err)
+	}
+	if _, err := c.Domains.GetDomain(ctx, "new.com"); err == nil {
+		t.Error("expected deleted domain to be gone")
+	}
+}
+
+func TestServer_Aliases(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t)
+
+	enabled := true
+	list, err := c.Aliases.ListAliases(ctx, &api.ListAliasesOptions{Domain: "example.com", Enabled: &enabled})
+	if err != nil {
+		t.Fatalf("ListAliases: %v", err)
+	}
+	if len(list.Aliases) != 1 || list.Aliases[0].Name != "info" {
+		t.Fatalf("expected only the enabled alias, got %+v", list.Aliases)
+	}
+
+	a, err := c.Aliases.CreateAlias(ctx, "example.com", &api.CreateAliasRequest{
+		Name: "hello", Recipients: []string{"me@example.org"}, IsEnabled: true,
+	})
+	if err != nil {
+		t.Fatalf("CreateAlias: %v", err)
+	}
+
+	desc := "greetings"
+	if _, err := c.Aliases.UpdateAlias(ctx, "example.com", a.ID, &api.UpdateAliasRequest{Description: &desc}); err != nil {
+		t.Fatalf("UpdateAlias: %v", err)
+	}
+	got, err := c.Aliases.GetAlias(ctx, "example.com", a.ID)
+	if err != nil || got.Description != desc || got.DomainID == "" {
+		t.Fatalf("GetAlias: %v %+v", err, got)
+	}
+
+	taken, renamed := "info", "hi"
+	if _, err := c.Aliases.UpdateAlias(ctx, "example.com", a.ID, &api.UpdateAliasRequest{Name: &taken}); err == nil {
+		t.Error("expected renaming to an existing alias to fail")
+	}
+	if got, err := c.Aliases.UpdateAlias(ctx, "example.com", a.ID, &api.UpdateAliasRequest{Name: &renamed}); err != nil || got.Name != renamed {
+		t.Fatalf("UpdateAlias rename: %v %+v", err, got)
+	}
+
+	if err := c.Aliases.DeleteAlias(ctx, "example.com", a.ID); err != nil {
+		t.Fatalf("DeleteAlias: %v", err)
+	}
+	if _, err := c.Aliases.GetAlias(ctx, "example.com", a.ID); err == nil {
+		t.Error("expected deleted alias to be gone")
+	}
+}
+
+func TestServer_PaginationHeaders(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t)
+
+	first, err := c.Aliases.ListAliases(ctx, &api.ListAliasesOptions{Domain: "example.com", Page: 1, Limit: 1})
+	if err != nil {
+		t.Fatalf("ListAliases: %v", err)
+	}
+	if p := first.Pagination; p.Total != 2 || p.TotalPages != 2 || !p.HasNext || p.HasPrev {
+		t.Fatalf("unexpected first page: %+v", p)
+	}
+	last, err := c.Aliases.ListAliases(ctx, &api.ListAliasesOptions{Domain: "example.com", Page: 2, Limit: 1})
+	if err != nil {
+		t.Fatalf("ListAliases: %v", err)
+	}
+	if p := last.Pagination; len(last.Aliases) != 1 || p.HasNext || !p.HasPrev {
+		t.Fatalf("unexpected last page: %+v", p)
+	}
+}
+
+func TestServer_EmailsEnforceQuota(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t)
+
+	req := &api.SendEmailRequest{From: "info@example.com", To: []string{"a@example.org"}, Subject: "Hi", Text: "x"}
+	for i := 0; i < 2; i++ {
+		if _, err := c.Emails.SendEmail(ctx, req); err != nil {
+			t.Fatalf("SendEmail #%d: %v", i+1, err)
+		}
+	}
+	if _, err := c.Emails.SendEmail(ctx, req); err == nil {
+		t.Error("expected the daily limit to be enforced")
+	}
+
+	list, err := c.Emails.ListEmails(ctx, nil)
+	if err != nil || len(list.Emails) != 3 {
+		t.Fatalf("expected seeded + sent emails, got %v %d", err, len(list.Emails))
+	}
+	quota, err := c.Emails.GetEmailQuota(ctx)
+	if err != nil || quota.EmailsSent != 2 {
+		t.Fatalf("GetEmailQuota: %v %+v", err, quota)
+	}
+}
+
+func TestServer_RequiresAPIKey(t *testing.T) {
+	srv := httptest.NewServer(New(nil))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/v1/domains")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401 without credentials, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,96 @@
+package testutil
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var imapCommand = regexp.MustCompile(`^(\S+) (?:UID )?(\S+)(?: (.*))?$`)
+
+// IMAPServer starts a plain-text IMAP server on a loopback port that serves
+// messages, given as raw headers, from a single mailbox to user with
+// password. It supports just what an address scan needs: LOGIN, EXAMINE,
+// UID SEARCH (which ignores its criteria), UID FETCH and LOGOUT. The server
+// stops when the test ends; its address is returned.
+func IMAPServer(t *testing.T, user, password string, messages []string) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to start IMAP server: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveIMAP(conn, fmt.Sprintf("%q %q", user, password), messages)
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func serveIMAP(conn net.Conn, credentials string, messages []string) {
+	defer func() { _ = conn.Close() }()
+	w := bufio.NewWriter(conn)
+	reply := func(format string, args ...any) {
+		_, _ = fmt.Fprintf(w, format+"\r\n", args...)
+		_ = w.Flush()
+	}
+	reply("* OK test IMAP server ready")
+
+	r := bufio.NewScanner(conn)
+	loggedIn := false
+	for r.Scan() {
+		m := imapCommand.FindStringSubmatch(r.Text())
+		if m == nil {
+			reply("* BAD unparsable command")
+			continue
+		}
+		tag, cmd, args := m[1], strings.ToUpper(m[2]), m[3]
+		switch {
+		case cmd == "LOGIN":
+			if args != credentials {
+				reply("%s NO [AUTHENTICATIONFAILED] invalid credentials", tag)
+				continue
+			}
+			loggedIn = true
+			reply("%s OK logged in", tag)
+		case cmd == "LOGOUT":
+			reply("* BYE logging out")
+			reply("%s OK bye", tag)
+			return
+		case !loggedIn:
+			reply("%s NO not logged in", tag)
+		case cmd == "EXAMINE":
+			reply("* %d EXISTS", len(messages))
+			reply("%s OK [READ-ONLY] examined", tag)
+		case cmd == "SEARCH":
+			uids := make([]string, len(messages))
+			for i := range messages {
+				uids[i] = fmt.Sprint(i + 1)
+			}
+			reply("* SEARCH %s", strings.Join(uids, " "))
+			reply("%s OK search done", tag)
+		case cmd == "FETCH":
+			set, _, _ := strings.Cut(args, " ")
+			for _, id := range strings.Split(set, ",") {
+				var uid int
+				if _, err := fmt.Sscan(id, &uid); err != nil || uid < 1 || uid > len(messages) {
+					continue
+				}
+				header := strings.ReplaceAll(strings.TrimRight(messages[uid-1], "\n"), "\n", "\r\n") + "\r\n\r\n"
+				reply("* %d FETCH (UID %d BODY[HEADER] {%d}\r\n%s)", uid, uid, len(header), header)
+			}
+			reply("%s OK fetch done", tag)
+		default:
+			reply("%s BAD unsupported command", tag)
+		}
+	}
+}
@@ -44,3 +44,11 @@ func (i Info) String() string {
 	return fmt.Sprintf("forward-email version %s\ncommit: %s\nbuilt: %s\ngo: %s\nos/arch: %s/%s",
 		i.Version, i.Commit, i.Date, i.GoVersion, i.OS, i.Arch)
 }
+
+// UserAgent returns the User-Agent header sent to the API, naming the
+// version, platform and Go release, e.g.
+// forward-email/1.4.0 (linux; amd64; go1.25.1).
+func UserAgent() string {
+	i := Get()
+	return fmt.Sprintf("forward-email/%s (%s; %s; %s)", i.Version, i.OS, i.Arch, i.GoVersion)
+}
@@ -0,0 +1,129 @@
+// Package addrlist splits and parses RFC 5322 address lists, such as the
+// values of --to or --recipients, reporting where in the list a bad entry is.
+//
+// Commas separate entries only outside quoted strings, angle brackets and
+// comments, so display names such as "Smith, Bob" <bob@example.com> survive.
+// Empty entries, from a trailing comma or ", ,", are dropped.
+package addrlist
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+)
+
+// Entry is one element of an address list.
+type Entry struct {
+	Raw    string // the element as written, without surrounding space
+	Offset int    // byte offset of Raw in the list
+}
+
+// Error reports an entry of List that is not valid, at byte Offset.
+type Error struct {
+	List   string
+	Entry  string
+	Offset int
+	Reason string
+}
+
+func (e *Error) Error() string {
+	if e.Entry == "" {
+		return fmt.Sprintf("%s at position %d of %q", e.Reason, e.Offset+1, e.List)
+	}
+	return fmt.Sprintf("invalid address %q at position %d of %q: %s", e.Entry, e.Offset+1, e.List, e.Reason)
+}
+
+// Split splits s into its entries. It fails on an unterminated quoted
+// string, angle bracket or comment.
+func Split(s string) ([]Entry, error) {
+	var entries []Entry
+	start := 0
+	add := func(end int) {
+		raw := s[start:end]
+		trimmed := strings.TrimLeft(raw, " \t")
+		offset := start + len(raw) - len(trimmed)
+		if trimmed = strings.TrimRight(trimmed, " \t"); trimmed != "" {
+			entries = append(entries, Entry{Raw: trimmed, Offset: offset})
+		}
+	}
+
+	quote, angle, comment := -1, -1, -1 // offsets of the open quote, bracket and comment
+	depth := 0                          // comments nest
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\' && (quote >= 0 || comment >= 0):
+			i++ // the next character is escaped
+		case quote >= 0:
+			if c == '"' {
+				quote = -1
+			}
+		case comment >= 0:
+			switch c {
+			case '(':
+				depth++
+			case ')':
+				if depth--; depth == 0 {
+					comment = -1
+				}
+			}
+		case c == '"':
+			quote = i
+		case c == '(':
+			comment, depth = i, 1
+		case c == '<':
+			angle = i
+		case c == '>':
+			angle = -1
+		case c == ',' && angle < 0:
+			add(i)
+			start = i + 1
+		}
+	}
+
+	switch {
+	case quote >= 0:
+		return nil, &Error{List: s, Offset: quote, Reason: "unterminated quoted string"}
+	case comment >= 0:
+		return nil, &Error{List: s, Offset: comment, Reason: "unterminated comment"}
+	case angle >= 0:
+		return nil, &Error{List: s, Offset: angle, Reason: "missing closing >"}
+	}
+	add(len(s))
+	return entries, nil
+}
+
+// Parse parses s as an address list. Entries may carry display names.
+func Parse(s string) ([]*mail.Address, error) {
+	entries, err := Split(s)
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]*mail.Address, 0, len(entries))
+	for _, e := range entries {
+		addr, err := ParseEntry(s, e)
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
+
+// ParseEntry parses one entry of list as an address.
+func ParseEntry(list string, e Entry) (*mail.Address, error) {
+	addr, err := mail.ParseAddress(e.Raw)
+	if err != nil {
+		return nil, &Error{List: list, Entry: e.Raw, Offset: e.Offset, Reason: strings.TrimPrefix(err.Error(), "mail: ")}
+	}
+	return addr, nil
+}
+
+// Format renders addr for a header: the bare address when it has no display
+// name, else the quoted name and the address in angle brackets.
+func Format(addr *mail.Address) string {
+	if addr.Name == "" {
+		return addr.Address
+	}
+	return addr.String()
+}
@@ -0,0 +1,57 @@
+package addrlist
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	entries, err := Split(`a@example.com, "Smith, Bob" <bob@example.com>,,  carol@example.com (Carol, Sales) ,`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Entry{
+		{Raw: "a@example.com", Offset: 0},
+		{Raw: `"Smith, Bob" <bob@example.com>`, Offset: 15},
+		{Raw: "carol@example.com (Carol, Sales)", Offset: 49},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("got %d entries %v, want %v", len(entries), entries, want)
+	}
+	for i := range want {
+		if entries[i] != want[i] {
+			t.Errorf("entry %d = %+v, want %+v", i, entries[i], want[i])
+		}
+	}
+
+	for list, reason := range map[string]string{
+		`a@example.com, "Bob <bob@example.com>`: "unterminated quoted string at position 16",
+		`a@example.com, Bob <bob@example.com`:   "missing closing > at position 20",
+		`a@example.com (note`:                   "unterminated comment at position 15",
+	} {
+		if _, err := Split(list); err == nil || !strings.Contains(err.Error(), reason) {
+			t.Errorf("Split(%s) = %v, want %q", list, err, reason)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	addrs, err := Parse(`"Smith, Bob" <bob@example.com>, a@example.com`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(addrs) != 2 || addrs[0].Name != "Smith, Bob" || addrs[0].Address != "bob@example.com" || addrs[1].Address != "a@example.com" {
+		t.Fatalf("unexpected addresses %v", addrs)
+	}
+	if got := Format(addrs[0]); got != `"Smith, Bob" <bob@example.com>` {
+		t.Errorf("Format = %s", got)
+	}
+	if got := Format(addrs[1]); got != "a@example.com" {
+		t.Errorf("Format = %s", got)
+	}
+
+	_, err = Parse("a@example.com, b@@example.com")
+	if err == nil || !strings.Contains(err.Error(), `invalid address "b@@example.com" at position 16`) {
+		t.Errorf("expected the bad entry and its position, got %v", err)
+	}
+}
@@ -52,6 +52,7 @@ type ListAliasesOptions struct {
 	Labels  string `json:"labels,omitempty"`   // Filter by labels (comma-separated)
 	Page    int    `json:"page,omitempty"`     // Page number (1-based)
 	Limit   int    `json:"limit,omitempty"`    // Items per page
+	Cursor  string `json:"cursor,omitempty"`   // Opaque page token; replaces Page
 }
 
 // ListAliasesResponse represents the response from listing aliases
@@ -61,6 +62,9 @@ type ListAliasesResponse struct {
 	Page       int     `json:"page"`
 	Limit      int     `json:"limit"`
 	TotalPages int     `json:"total_pages"`
+	// Pagination carries the same totals plus the API's next and previous
+	// page links, when it sends them.
+	Pagination Pagination `json:"pagination"`
 }
 
 // CreateAliasRequest represents a request to create an alias
@@ -73,10 +77,13 @@ type CreateAliasRequest struct {
 	IsEnabled   bool     `json:"is_enabled"`            // Default enabled status
 	HasIMAP     bool     `json:"has_imap,omitempty"`    // Enable IMAP access
 	HasPGP      bool     `json:"has_pgp,omitempty"`     // Enable PGP encryption
+
+	Vacation *VacationResponder `json:"vacation,omitempty"` // Vacation auto-responder
 }
 
 // UpdateAliasRequest represents a request to update an alias
 type UpdateAliasRequest struct {
+	Name        *string  `json:"name,omitempty"`        // Rename the alias
 	Recipients  []string `json:"recipients,omitempty"`  // Update recipients
 	Labels      []string `json:"labels,omitempty"`      // Update labels
 	Description *string  `json:"description,omitempty"` // Update description (nil to clear)
@@ -84,6 +91,15 @@ type UpdateAliasRequest struct {
 	IsEnabled   *bool    `json:"is_enabled,omitempty"`  // Update enabled status
 	HasIMAP     *bool    `json:"has_imap,omitempty"`    // Update IMAP access
 	HasPGP      *bool    `json:"has_pgp,omitempty"`     // Update PGP encryption
+
+	Vacation *VacationResponder `json:"vacation,omitempty"` // Update vacation auto-responder
+}
+
+// GeneratePasswordRequest represents the body of a generate-password call
+type GeneratePasswordRequest struct {
+	NewPassword string `json:"new_password,omitempty"` // Custom password; the server generates one when empty
+	Password    string `json:"password,omitempty"`     // Current password, keeps the existing mailbox readable
+	IsOverride  bool   `json:"is_override,omitempty"`  // Reset the mailbox when the current password is unknown
 }
 
 // GeneratePasswordResponse represents the response from generating an IMAP password
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -19,13 +20,11 @@ func (s *AliasService) ListAliases(ctx context.Context, opts *ListAliasesOptions
 		return nil, fmt.Errorf("domain is required")
 	}
 
-	u := s.client.BaseURL.ResolveReference(&url.URL{Path: fmt.Sprintf("/v1/domains/%s/aliases", opts.Domain)})
+	u := s.client.resolve(fmt.Sprintf("/v1/domains/%s/aliases", opts.Domain))
 
 	// Add query parameters
 	params := url.Values{}
-	if opts.Page > 0 {
-		params.Set("page", strconv.Itoa(opts.Page))
-	}
+	setPageParams(params, opts.Page, opts.Cursor)
 	if opts.Limit > 0 {
 		params.Set("limit", strconv.Itoa(opts.Limit))
 	}
@@ -55,11 +54,13 @@ func (s *AliasService) ListAliases(ctx context.Context, opts *ListAliasesOptions
 	}
 
 	var aliases []Alias
-	if err := s.client.Do(ctx, req, &aliases); err != nil {
+	header, err := s.client.doWithHeader(ctx, req, &aliases)
+	if err != nil {
 		return nil, fmt.Errorf("failed to list aliases: %w", err)
 	}
 
-	// Calculate pagination info (since API returns array directly)
+	// Calculate pagination info from the returned page, then prefer the
+	// API's pagination headers when it sends them
 	totalCount := len(aliases)
 	page := 1
 	if opts.Page > 0 {
@@ -69,14 +70,16 @@ func (s *AliasService) ListAliases(ctx context.Context, opts *ListAliasesOptions
 	if opts.Limit > 0 {
 		limit = opts.Limit
 	}
-	totalPages := (totalCount + limit - 1) / limit
+	pagination := Pagination{Page: page, Limit: limit, Total: totalCount, TotalPages: (totalCount + limit - 1) / limit}
+	applyPaginationHeaders(&pagination, header)
 
 	return &ListAliasesResponse{
 		Aliases:    aliases,
-		TotalCount: totalCount,
-		Page:       page,
-		Limit:      limit,
-		TotalPages: totalPages,
+		TotalCount: pagination.Total,
+		Page:       pagination.Page,
+		Limit:      pagination.Limit,
+		TotalPages: pagination.TotalPages,
+		Pagination: pagination,
 	}, nil
 }
 
@@ -91,7 +94,7 @@ func (s *AliasService) GetAlias(ctx context.Context, domain, aliasID string) (*A
 		return nil, fmt.Errorf("alias ID is required")
 	}
 
-	u := s.client.BaseURL.ResolveReference(&url.URL{Path: fmt.Sprintf("/v1/domains/%s/aliases/%s", domain, aliasID)})
+	u := s.client.resolve(fmt.Sprintf("/v1/domains/%s/aliases/%s", domain, aliasID))
 
 	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), http.NoBody)
 	if err != nil {
@@ -110,7 +113,9 @@ func (s *AliasService) GetAlias(ctx context.Context, domain, aliasID string) (*A
 // Requires a name and at least one recipient. Optional settings include labels,
 // description, IMAP configuration, PGP encryption, and vacation responder.
 // Recipients can be email addresses, webhooks, or FQDN forwarding targets.
-func (s *AliasService) CreateAlias(ctx context.Context, domain string, req *CreateAliasRequest) (*Alias, error) {
+func (s *AliasService) CreateAlias(
+	ctx context.Context, domain string, req *CreateAliasRequest, opts ...RequestOption,
+) (*Alias, error) {
 	if domain == "" {
 		return nil, fmt.Errorf("domain is required")
 	}
@@ -131,7 +136,7 @@ func (s *AliasService) CreateAlias(ctx context.Context, domain string, req *Crea
 		}
 	}
 
-	u := s.client.BaseURL.ResolveReference(&url.URL{Path: fmt.Sprintf("/v1/domains/%s/aliases", domain)})
+	u := s.client.resolve(fmt.Sprintf("/v1/domains/%s/aliases", domain))
 
 	body, err := json.Marshal(req)
 	if err != nil {
@@ -143,6 +148,8 @@ func (s *AliasService) CreateAlias(ctx context.Context, domain string, req *Crea
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
+	ctx, cancel := applyRequestOptions(ctx, httpReq, opts)
+	defer cancel()
 
 	var alias Alias
 	if err := s.client.Do(ctx, httpReq, &alias); err != nil {
@@ -178,7 +185,7 @@ func (s *AliasService) UpdateAlias(
 		}
 	}
 
-	u := s.client.BaseURL.ResolveReference(&url.URL{Path: fmt.Sprintf("/v1/domains/%s/aliases/%s", domain, aliasID)})
+	u := s.client.resolve(fmt.Sprintf("/v1/domains/%s/aliases/%s", domain, aliasID))
 
 	body, err := json.Marshal(req)
 	if err != nil {
@@ -210,7 +217,7 @@ func (s *AliasService) DeleteAlias(ctx context.Context, domain, aliasID string)
 		return fmt.Errorf("alias ID is required")
 	}
 
-	u := s.client.BaseURL.ResolveReference(&url.URL{Path: fmt.Sprintf("/v1/domains/%s/aliases/%s", domain, aliasID)})
+	u := s.client.resolve(fmt.Sprintf("/v1/domains/%s/aliases/%s", domain, aliasID))
 
 	req, err := http.NewRequestWithContext(ctx, "DELETE", u.String(), http.NoBody)
 	if err != nil {
@@ -230,6 +237,24 @@ func (s *AliasService) DeleteAlias(ctx context.Context, domain, aliasID string)
 // Returns the new password which should be stored securely by the client.
 func (s *AliasService) GeneratePassword(
 	ctx context.Context, domain, aliasID string,
+) (*GeneratePasswordResponse, error) {
+	return s.generatePassword(ctx, domain, aliasID, nil)
+}
+
+// SetPassword sets the IMAP password of an alias through the generate-password
+// endpoint. With an empty NewPassword the server generates one, as with
+// GeneratePassword. The returned password is the one now in effect.
+func (s *AliasService) SetPassword(
+	ctx context.Context, domain, aliasID string, req *GeneratePasswordRequest,
+) (*GeneratePasswordResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request is required")
+	}
+	return s.generatePassword(ctx, domain, aliasID, req)
+}
+
+func (s *AliasService) generatePassword(
+	ctx context.Context, domain, aliasID string, body *GeneratePasswordRequest,
 ) (*GeneratePasswordResponse, error) {
 	if domain == "" {
 		return nil, fmt.Errorf("domain is required")
@@ -239,17 +264,33 @@ func (s *AliasService) GeneratePassword(
 	}
 
 	path := fmt.Sprintf("/v1/domains/%s/aliases/%s/generate-password", domain, aliasID)
-	u := s.client.BaseURL.ResolveReference(&url.URL{Path: path})
+	u := s.client.resolve(path)
+
+	var reqBody io.Reader = http.NoBody
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", u.String(), http.NoBody)
+	req, err := http.NewRequestWithContext(ctx, "POST", u.String(), reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
 
 	var resp GeneratePasswordResponse
 	if err := s.client.Do(ctx, req, &resp); err != nil {
 		return nil, fmt.Errorf("failed to generate password: %w", err)
 	}
+	// A custom password is not echoed back by the API
+	if resp.Password == "" && body != nil {
+		resp.Password = body.NewPassword
+	}
 
 	return &resp, nil
 }
@@ -304,7 +345,7 @@ func (s *AliasService) GetAliasQuota(ctx context.Context, domain, aliasID string
 		return nil, fmt.Errorf("alias ID is required")
 	}
 
-	u := s.client.BaseURL.ResolveReference(&url.URL{Path: fmt.Sprintf("/v1/domains/%s/aliases/%s/quota", domain, aliasID)})
+	u := s.client.resolve(fmt.Sprintf("/v1/domains/%s/aliases/%s/quota", domain, aliasID))
 
 	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), http.NoBody)
 	if err != nil {
@@ -330,7 +371,7 @@ func (s *AliasService) GetAliasStats(ctx context.Context, domain, aliasID string
 		return nil, fmt.Errorf("alias ID is required")
 	}
 
-	u := s.client.BaseURL.ResolveReference(&url.URL{Path: fmt.Sprintf("/v1/domains/%s/aliases/%s/stats", domain, aliasID)})
+	u := s.client.resolve(fmt.Sprintf("/v1/domains/%s/aliases/%s/stats", domain, aliasID))
 
 	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), http.NoBody)
 	if err != nil {
@@ -636,6 +636,42 @@ func TestAliasService_GeneratePassword(t *testing.T) {
 	}
 }
 
+func TestAliasService_SetPassword(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req GeneratePasswordRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		if req.NewPassword != "My-Own-Passw0rd" || req.IsOverride {
+			t.Errorf("Unexpected request body: %+v", req)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Expected JSON content type, got %q", ct)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := createTestAliasClient(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	result, err := client.Aliases.SetPassword(context.Background(), "example.com", "alias-id",
+		&GeneratePasswordRequest{NewPassword: "My-Own-Passw0rd"})
+	if err != nil {
+		t.Fatalf("SetPassword failed: %v", err)
+	}
+	if result.Password != "My-Own-Passw0rd" {
+		t.Errorf("Expected the custom password to be returned, got %q", result.Password)
+	}
+
+	if _, err := client.Aliases.SetPassword(context.Background(), "example.com", "alias-id", nil); err == nil {
+		t.Error("Expected error for nil request")
+	}
+}
+
 func TestAliasService_EnableDisableAlias(t *testing.T) {
 	domain := "example.com"
 	aliasID := "toggle-alias-id"
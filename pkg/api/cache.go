@@ -0,0 +1,214 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// CachedResponse is a GET response body kept together with the validators the
+// server sent for it.
+type CachedResponse struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+	ContentType  string `json:"content_type,omitempty"`
+	Body         []byte `json:"body"`
+}
+
+// ResponseCache stores GET responses for conditional requests. Keys identify
+// the URL and the credentials used, so accounts never see each other's data.
+// Implementations must be safe for concurrent use.
+type ResponseCache interface {
+	Get(key string) (CachedResponse, bool)
+	Set(key string, r CachedResponse)
+}
+
+// WithCache makes GET requests conditional: a response that carried an ETag or
+// Last-Modified header is cached, later requests for the same URL send
+// If-None-Match or If-Modified-Since, and a 304 answer is served from the cache
+// as if the server had returned the body again.
+func WithCache(cache ResponseCache) ClientOption {
+	return func(c *Client) error {
+		c.Cache = cache
+		return nil
+	}
+}
+
+// CacheMiddleware makes GET requests conditional on the responses kept in
+// cache, as described for WithCache. Requests must carry their credentials,
+// which are part of the cache key.
+func CacheMiddleware(cache ResponseCache, logger *slog.Logger) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Method != http.MethodGet {
+				return next.RoundTrip(req)
+			}
+			key := cacheKey(req)
+			cached, hit := cache.Get(key)
+			if hit {
+				req = req.Clone(req.Context())
+				if cached.ETag != "" {
+					req.Header.Set("If-None-Match", cached.ETag)
+				}
+				if cached.LastModified != "" {
+					req.Header.Set("If-Modified-Since", cached.LastModified)
+				}
+			}
+
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				return nil, err
+			}
+
+			switch {
+			case resp.StatusCode == http.StatusNotModified && hit:
+				_, _ = io.Copy(io.Discard, resp.Body)
+				_ = resp.Body.Close()
+				logger.DebugContext(req.Context(), "api response not modified, served from cache", "path", req.URL.Path)
+				header := resp.Header.Clone()
+				if cached.ContentType != "" {
+					header.Set("Content-Type", cached.ContentType)
+				}
+				resp.StatusCode = http.StatusOK
+				resp.Status = "200 OK"
+				resp.Header = header
+				resp.Body = io.NopCloser(bytes.NewReader(cached.Body))
+				resp.ContentLength = int64(len(cached.Body))
+				return resp, nil
+
+			case resp.StatusCode == http.StatusOK:
+				etag, modified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+				if etag == "" && modified == "" {
+					return resp, nil
+				}
+				body, err := io.ReadAll(resp.Body)
+				_ = resp.Body.Close()
+				if err != nil {
+					return nil, err
+				}
+				cache.Set(key, CachedResponse{
+					ETag: etag, LastModified: modified, ContentType: resp.Header.Get("Content-Type"), Body: body,
+				})
+				resp.Body = io.NopCloser(bytes.NewReader(body))
+				return resp, nil
+			}
+			return resp, nil
+		})
+	}
+}
+
+// cacheKey identifies a request by its URL and a digest of its credentials.
+func cacheKey(req *http.Request) string {
+	sum := sha256.Sum256([]byte(req.Header.Get("Authorization")))
+	return hex.EncodeToString(sum[:8]) + " " + req.URL.String()
+}
+
+// MemoryCache is a ResponseCache held in memory, for long-running programs
+// such as watch loops. It keeps at most a fixed number of responses, dropping
+// the oldest first.
+type MemoryCache struct {
+	mu      sync.Mutex
+	max     int
+	entries map[string]CachedResponse
+	order   []string
+}
+
+// NewMemoryCache returns a MemoryCache holding up to maxEntries responses;
+// maxEntries <= 0 means no limit.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{max: maxEntries, entries: map[string]CachedResponse{}}
+}
+
+// Get implements ResponseCache.
+func (m *MemoryCache) Get(key string) (CachedResponse, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r, ok := m.entries[key]
+	return r, ok
+}
+
+// Set implements ResponseCache.
+func (m *MemoryCache) Set(key string, r CachedResponse) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.entries[key]; !ok {
+		m.order = append(m.order, key)
+	}
+	m.entries[key] = r
+	for m.max > 0 && len(m.order) > m.max {
+		delete(m.entries, m.order[0])
+		m.order = m.order[1:]
+	}
+}
+
+// FileCache is a ResponseCache kept as one file per response in a directory,
+// so that separate runs of a program, such as shell completions, share it.
+// Files are readable by the owner only.
+type FileCache struct {
+	dir string
+}
+
+// NewFileCache returns a FileCache in dir, which is created on first use.
+func NewFileCache(dir string) *FileCache {
+	return &FileCache{dir: dir}
+}
+
+func (f *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(f.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get implements ResponseCache. Unreadable entries are treated as misses.
+func (f *FileCache) Get(key string) (CachedResponse, bool) {
+	data, err := os.ReadFile(f.path(key))
+	if err != nil {
+		return CachedResponse{}, false
+	}
+	var r CachedResponse
+	if err := json.Unmarshal(data, &r); err != nil {
+		return CachedResponse{}, false
+	}
+	return r, true
+}
+
+// Set implements ResponseCache. The cache is an optimization, so write errors
+// are ignored; the next request simply fetches the full response again.
+func (f *FileCache) Set(key string, r CachedResponse) {
+	_ = f.write(key, r)
+}
+
+func (f *FileCache) write(key string, r CachedResponse) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(f.dir, 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(f.dir, "entry-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), f.path(key)); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to store cache entry: %w", err)
+	}
+	return nil
+}
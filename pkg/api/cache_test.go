@@ -0,0 +1,125 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestClient_WithCache(t *testing.T) {
+	var full, notModified int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		full++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`{"id":"d1","name":"example.com"}`))
+	}))
+	defer server.Close()
+
+	cache := NewMemoryCache(0)
+	client, err := NewClient(WithBaseURL(server.URL), WithAPIKey("key"), WithCache(cache))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		d, err := client.Domains.GetDomain(ctx, "example.com")
+		if err != nil {
+			t.Fatalf("GetDomain #%d: %v", i+1, err)
+		}
+		if d.Name != "example.com" {
+			t.Errorf("GetDomain #%d returned %+v", i+1, d)
+		}
+	}
+	if full != 1 || notModified != 2 {
+		t.Errorf("expected 1 full response and 2 revalidations, got %d and %d", full, notModified)
+	}
+
+	// Another account shares the cache but never its entries.
+	other, err := NewClient(WithBaseURL(server.URL), WithAPIKey("other-key"), WithCache(cache))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if _, err := other.Domains.GetDomain(ctx, "example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if full != 2 {
+		t.Errorf("expected a full response for a different API key, got %d", full)
+	}
+}
+
+func TestClient_WithCache_NoValidators(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("If-None-Match") != "" || r.Header.Get("If-Modified-Since") != "" {
+			t.Errorf("unexpected conditional request: %v", r.Header)
+		}
+		_, _ = w.Write([]byte(`{"id":"d1","name":"example.com"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithBaseURL(server.URL), WithAPIKey("key"), WithCache(NewMemoryCache(0)))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := client.Domains.GetDomain(context.Background(), "example.com"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+}
+
+func TestMemoryCache_Evicts(t *testing.T) {
+	c := NewMemoryCache(2)
+	c.Set("a", CachedResponse{ETag: "1"})
+	c.Set("b", CachedResponse{ETag: "2"})
+	c.Set("a", CachedResponse{ETag: "3"})
+	c.Set("c", CachedResponse{ETag: "4"})
+
+	if _, ok := c.Get("a"); ok {
+		t.Error("expected the oldest entry to be evicted")
+	}
+	if r, ok := c.Get("c"); !ok || r.ETag != "4" {
+		t.Errorf("Get(c) = %+v, %v", r, ok)
+	}
+}
+
+func TestFileCache(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "http")
+	c := NewFileCache(dir)
+	if _, ok := c.Get("k"); ok {
+		t.Fatal("expected a miss on an empty cache")
+	}
+
+	want := CachedResponse{ETag: `"v1"`, LastModified: "Mon, 02 Jan 2006 15:04:05 GMT", Body: []byte(`{"ok":true}`)}
+	c.Set("k", want)
+	got, ok := NewFileCache(dir).Get("k")
+	if !ok || got.ETag != want.ETag || got.LastModified != want.LastModified || string(got.Body) != string(want.Body) {
+		t.Errorf("Get(k) = %+v, %v", got, ok)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected one cache file, got %v, %v", entries, err)
+	}
+	info, err := entries[0].Info()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("cache file mode = %v, want 0600", perm)
+	}
+}
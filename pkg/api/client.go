@@ -1,18 +1,35 @@
 // Package api provides the Forward Email REST API client and data structures.
+//
+// The package can be used on its own as a Go SDK:
+//
+//	client, err := api.NewClient(
+//		api.WithAPIKey(os.Getenv("FORWARDEMAIL_API_KEY")),
+//		api.WithRetryPolicy(api.DefaultRetryPolicy),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//	domains, err := client.Domains.ListDomains(ctx, nil)
 package api
 
 import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ginsys/forward-email/pkg/auth"
 	"github.com/ginsys/forward-email/pkg/errors"
 )
 
+// DefaultBaseURL is the public Forward Email API endpoint.
+const DefaultBaseURL = "https://api.forwardemail.net"
+
 // Client represents the Forward Email API client
 type Client struct {
 	HTTPClient *http.Client
@@ -25,16 +42,39 @@ type Client struct {
 	Logs       *LogService
 	Crypto     *CryptoService
 	UserAgent  string
+	Retry      RetryPolicy
+	Logger     *slog.Logger    // Request diagnostics; nil uses slog.Default()
+	Cache      ResponseCache   // Conditional GET cache; nil disables caching
+	DNS        DNSExpectations // Records expected by GetDomainDNSRecords
+	Middleware []Middleware    // Wrappers around every API request, outermost first
 }
 
 // ClientOption defines options for configuring the client
 type ClientOption func(*Client) error
 
-// NewClient creates a new Forward Email API client
-func NewClient(baseURL string, authProvider auth.Provider, opts ...ClientOption) (*Client, error) {
-	u, err := url.Parse(baseURL)
+// RetryPolicy controls how failed requests are retried. Only idempotent requests
+// (GET, HEAD, PUT, DELETE, OPTIONS) are retried, and only after network errors, 429 or 5xx responses.
+// The zero value disables retries.
+type RetryPolicy struct {
+	MaxAttempts    int           // Total attempts including the first; <= 1 disables retries
+	InitialBackoff time.Duration // Delay before the first retry, doubled after each attempt
+	MaxBackoff     time.Duration // Upper bound for a single delay; 0 means unbounded
+}
+
+// DefaultRetryPolicy retries a request up to two more times with a short backoff.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+}
+
+// NewClient creates a new Forward Email API client configured by opts.
+// Without WithBaseURL the client talks to DefaultBaseURL. An auth provider must be
+// supplied with WithAuth or WithAPIKey.
+func NewClient(opts ...ClientOption) (*Client, error) {
+	u, err := ParseBaseURL(DefaultBaseURL)
 	if err != nil {
-		return nil, fmt.Errorf("invalid base URL: %w", err)
+		return nil, err
 	}
 
 	client := &Client{
@@ -42,7 +82,6 @@ func NewClient(baseURL string, authProvider auth.Provider, opts ...ClientOption)
 			Timeout: 30 * time.Second,
 		},
 		BaseURL:   u,
-		Auth:      authProvider,
 		UserAgent: "forward-email/dev",
 	}
 
@@ -53,6 +92,10 @@ func NewClient(baseURL string, authProvider auth.Provider, opts ...ClientOption)
 		}
 	}
 
+	if client.Auth == nil {
+		return nil, fmt.Errorf("no authentication provider configured: use WithAuth or WithAPIKey")
+	}
+
 	// Initialize services
 	client.Account = &AccountService{client: client}
 	client.Domains = &DomainService{client: client}
@@ -64,6 +107,22 @@ func NewClient(baseURL string, authProvider auth.Provider, opts ...ClientOption)
 	return client, nil
 }
 
+// ParseBaseURL parses and validates an API base URL. The URL must be an absolute
+// http(s) URL; it may carry a path prefix (e.g. for a self-hosted instance behind
+// a reverse proxy), whose trailing slash is stripped.
+func ParseBaseURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return nil, fmt.Errorf("invalid base URL %q: must be an absolute http(s) URL", raw)
+	}
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = ""
+	return u, nil
+}
+
 // ValidateAuth validates the authentication credentials
 func (c *Client) ValidateAuth(ctx context.Context) error {
 	if c.Auth == nil {
@@ -73,6 +132,57 @@ func (c *Client) ValidateAuth(ctx context.Context) error {
 	return c.Auth.Validate(ctx)
 }
 
+// resolve returns the absolute URL for an API path such as "/v1/domains".
+// Any path prefix of BaseURL is preserved, so a client for
+// "https://mail.example.com/api" requests "https://mail.example.com/api/v1/domains".
+// Escaped segments in path (e.g. from url.PathEscape) are kept as-is.
+func (c *Client) resolve(path string) *url.URL {
+	return c.BaseURL.JoinPath(path)
+}
+
+// WithBaseURL sets the API base URL, e.g. for a self-hosted instance or a mock server.
+// The URL may include a path prefix.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		u, err := ParseBaseURL(baseURL)
+		if err != nil {
+			return err
+		}
+		c.BaseURL = u
+		return nil
+	}
+}
+
+// WithAuth sets the authentication provider
+func WithAuth(provider auth.Provider) ClientOption {
+	return func(c *Client) error {
+		c.Auth = provider
+		return nil
+	}
+}
+
+// WithAPIKey authenticates every request with the given API key
+func WithAPIKey(apiKey string) ClientOption {
+	return func(c *Client) error {
+		if apiKey == "" {
+			return fmt.Errorf("API key is empty")
+		}
+		c.Auth = auth.StaticProvider(apiKey)
+		return nil
+	}
+}
+
+// WithRetryPolicy sets the retry policy for failed requests
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) error {
+		if policy.MaxAttempts < 0 || policy.InitialBackoff < 0 || policy.MaxBackoff < 0 {
+			return fmt.Errorf("invalid retry policy: values must not be negative")
+		}
+		c.Retry = policy
+		return nil
+	}
+}
+
 // WithHTTPClient sets a custom HTTP client
 func WithHTTPClient(httpClient *http.Client) ClientOption {
 	return func(c *Client) error {
@@ -89,23 +199,39 @@ func WithUserAgent(userAgent string) ClientOption {
 	}
 }
 
+// WithDNSExpectations sets the mail servers and SPF include that
+// GetDomainDNSRecords expects, for self-hosted deployments
+func WithDNSExpectations(dns DNSExpectations) ClientOption {
+	return func(c *Client) error {
+		c.DNS = dns
+		return nil
+	}
+}
+
+// WithLogger sets the logger that receives request diagnostics at debug level
+// and retries at info level
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) error {
+		c.Logger = logger
+		return nil
+	}
+}
+
+// logger returns the client's logger, falling back to the default logger.
+func (c *Client) logger() *slog.Logger {
+	if c.Logger != nil {
+		return c.Logger
+	}
+	return slog.Default()
+}
+
 // DoWithStatus performs an HTTP request and returns the status code along with any error.
 // Unlike Do, this method does NOT treat 4xx status codes as errors - it returns the
 // status code for the caller to interpret. This is useful for endpoints where
 // different status codes represent different valid states (e.g., verify-records
 // returns 400 for "not verified" and 200 for "verified").
 func (c *Client) DoWithStatus(ctx context.Context, req *http.Request) (int, error) {
-	// Apply authentication using the configured auth provider
-	if err := c.Auth.Apply(req); err != nil {
-		return 0, fmt.Errorf("authentication failed: %w", err)
-	}
-
-	// Set standard headers expected by the Forward Email API
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", c.UserAgent)
-
-	// Execute request with context for cancellation support
-	resp, err := c.HTTPClient.Do(req.WithContext(ctx))
+	resp, err := c.send(ctx, req)
 	if err != nil {
 		return 0, err
 	}
@@ -119,33 +245,94 @@ func (c *Client) DoWithStatus(ctx context.Context, req *http.Request) (int, erro
 // If v is provided, the response body will be JSON decoded into it.
 // API errors are automatically parsed and returned as typed errors.
 func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) error {
-	// Apply authentication using the configured auth provider
-	if err := c.Auth.Apply(req); err != nil {
-		return fmt.Errorf("authentication failed: %w", err)
-	}
-
-	// Set standard headers expected by the Forward Email API
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", c.UserAgent)
+	_, err := c.doWithHeader(ctx, req, v)
+	return err
+}
 
-	// Execute request with context for cancellation support
-	resp, err := c.HTTPClient.Do(req.WithContext(ctx))
+// doWithHeader is Do, also returning the headers of a successful response,
+// such as the pagination headers of list endpoints.
+func (c *Client) doWithHeader(ctx context.Context, req *http.Request, v interface{}) (http.Header, error) {
+	resp, err := c.send(ctx, req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	// Handle HTTP error status codes (4xx, 5xx)
 	if resp.StatusCode >= 400 {
-		return c.handleErrorResponse(resp)
+		return nil, c.handleErrorResponse(resp)
 	}
 
 	// Decode successful response body if destination provided
 	if v != nil {
-		return json.NewDecoder(resp.Body).Decode(v)
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			return nil, err
+		}
 	}
 
-	return nil
+	return resp.Header, nil
+}
+
+// send authenticates and executes req through the client's middleware, the
+// response cache and retries according to c.Retry.
+// The caller must close the returned response body.
+func (c *Client) send(ctx context.Context, req *http.Request) (*http.Response, error) {
+	if c.Auth == nil {
+		return nil, fmt.Errorf("no authentication provider configured")
+	}
+
+	// Apply authentication using the configured auth provider
+	if err := c.Auth.Apply(req); err != nil {
+		return nil, fmt.Errorf("authentication failed: %w", err)
+	}
+
+	// Set standard headers expected by the Forward Email API
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.UserAgent)
+
+	return c.roundTrip(ctx, req)
+}
+
+// roundTrip sends an authenticated req through the client's transport chain.
+func (c *Client) roundTrip(ctx context.Context, req *http.Request) (*http.Response, error) {
+	return c.transport().RoundTrip(req.WithContext(ctx))
+}
+
+// isIdempotent reports whether req may be sent more than once without side effects.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// shouldRetry reports whether a request that produced resp and err is worth retrying.
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoff returns the delay before retry number attempt. A Retry-After header given
+// in seconds takes precedence over the exponential delay.
+func (p RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	delay := p.InitialBackoff << (attempt - 1)
+	if resp != nil {
+		if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs >= 0 {
+			delay = time.Duration(secs) * time.Second
+		}
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	return delay
 }
 
 // handleErrorResponse parses HTTP error responses and returns typed errors.
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -36,11 +37,22 @@ func TestNewClient(t *testing.T) {
 			baseURL: "http://localhost:8080",
 			wantErr: false,
 		},
+		{
+			name:    "URL with path prefix",
+			baseURL: "https://mail.example.com/forwardemail/",
+			wantErr: false,
+		},
+		{
+			name:       "relative URL",
+			baseURL:    "api.forwardemail.net",
+			wantErr:    true,
+			wantErrMsg: "must be an absolute http(s) URL",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			client, err := NewClient(tt.baseURL, authProvider)
+			client, err := NewClient(WithBaseURL(tt.baseURL), WithAuth(authProvider))
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("NewClient() error = %v, wantErr %v", err, tt.wantErr)
@@ -95,6 +107,29 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
+func TestClient_BaseURLPathPrefix(t *testing.T) {
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.EscapedPath()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"d1","name":"example.com"}`))
+	}))
+	defer server.Close()
+
+	for _, base := range []string{server.URL + "/api", server.URL + "/api/"} {
+		client, err := NewClient(WithBaseURL(base), WithAuth(auth.MockProvider("test-key")))
+		if err != nil {
+			t.Fatalf("NewClient(%q): %v", base, err)
+		}
+		if _, err := client.Domains.GetDomain(context.Background(), "example.com"); err != nil {
+			t.Fatalf("GetDomain via %q: %v", base, err)
+		}
+		if gotPath != "/api/v1/domains/example.com" {
+			t.Errorf("base %q: expected request path /api/v1/domains/example.com, got %s", base, gotPath)
+		}
+	}
+}
+
 func TestClient_ValidateAuth(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -205,7 +240,7 @@ func TestClient_Do(t *testing.T) {
 
 			// Create client
 			authProvider := auth.MockProvider(tt.authKey)
-			client, err := NewClient(server.URL, authProvider)
+			client, err := NewClient(WithBaseURL(server.URL), WithAuth(authProvider))
 			if err != nil {
 				t.Fatalf("failed to create client: %v", err)
 			}
@@ -249,7 +284,7 @@ func TestClient_WithOptions(t *testing.T) {
 	customClient := &http.Client{Timeout: 60 * time.Second}
 	customUserAgent := "custom-cli/1.0.0"
 
-	client, err := NewClient("https://api.forwardemail.net", authProvider,
+	client, err := NewClient(WithAuth(authProvider),
 		WithHTTPClient(customClient),
 		WithUserAgent(customUserAgent),
 	)
@@ -265,3 +300,104 @@ func TestClient_WithOptions(t *testing.T) {
 		t.Errorf("WithUserAgent() option did not set custom user agent, got %v", client.UserAgent)
 	}
 }
+
+func TestNewClient_Options(t *testing.T) {
+	if _, err := NewClient(); err == nil || !strings.Contains(err.Error(), "no authentication provider") {
+		t.Errorf("expected missing auth to be rejected, got %v", err)
+	}
+	if _, err := NewClient(WithAPIKey("")); err == nil {
+		t.Error("expected empty API key to be rejected")
+	}
+
+	client, err := NewClient(WithAPIKey("key"))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if client.BaseURL.String() != DefaultBaseURL {
+		t.Errorf("expected default base URL %s, got %s", DefaultBaseURL, client.BaseURL)
+	}
+	if client.Retry.MaxAttempts != 0 {
+		t.Errorf("expected retries to be disabled by default, got %+v", client.Retry)
+	}
+}
+
+func TestClient_RetryPolicy(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"d1","name":"example.com"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(
+		WithBaseURL(server.URL),
+		WithAPIKey("key"),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if _, err := client.Domains.GetDomain(context.Background(), "example.com"); err != nil {
+		t.Fatalf("expected GET to succeed after retries, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls)
+	}
+
+	// POST is sent only once
+	calls = 0
+	if _, err := client.Domains.CreateDomain(context.Background(), &CreateDomainRequest{Name: "example.com"}); err == nil {
+		t.Error("expected POST to fail without retrying")
+	}
+	if calls != 1 {
+		t.Errorf("expected a single POST attempt, got %d", calls)
+	}
+}
+
+func TestClient_WithLogger(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"d1","name":"example.com"}`))
+	}))
+	defer server.Close()
+
+	var buf strings.Builder
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client, err := NewClient(
+		WithBaseURL(server.URL),
+		WithAPIKey("key"),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}),
+		WithLogger(logger),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if _, err := client.Domains.GetDomain(context.Background(), "example.com"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		`level=DEBUG msg="api request" method=GET path=/v1/domains/example.com attempt=1`, "status=503",
+		`level=INFO msg="retrying api request" method=GET path=/v1/domains/example.com attempt=2`,
+		"status=200",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in log:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "key") {
+		t.Errorf("log must not contain credentials:\n%s", out)
+	}
+}
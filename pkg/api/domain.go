@@ -126,6 +126,31 @@ type DNSRecord struct {
 	Required bool   `json:"required"`
 }
 
+// DNSExpectations are the mail servers and SPF include that the DNS records
+// of a domain point to. Self-hosted Forward Email deployments use their own;
+// empty fields fall back to DefaultDNSExpectations.
+type DNSExpectations struct {
+	MXHosts    []string // MX hosts in order of preference
+	SPFInclude string   // Domain named by the include: mechanism of the SPF record
+}
+
+// DefaultDNSExpectations are the records of the hosted Forward Email service.
+var DefaultDNSExpectations = DNSExpectations{
+	MXHosts:    []string{"mx1.forwardemail.net", "mx2.forwardemail.net"},
+	SPFInclude: "spf.forwardemail.net",
+}
+
+// withDefaults returns e with empty fields taken from DefaultDNSExpectations.
+func (e DNSExpectations) withDefaults() DNSExpectations {
+	if len(e.MXHosts) == 0 {
+		e.MXHosts = DefaultDNSExpectations.MXHosts
+	}
+	if e.SPFInclude == "" {
+		e.SPFInclude = DefaultDNSExpectations.SPFInclude
+	}
+	return e
+}
+
 // DomainVerification represents the verification status of a domain
 type DomainVerification struct {
 	LastCheckedAt   time.Time   `json:"last_checked_at"`
@@ -161,6 +186,7 @@ type UpdateDomainRequest struct {
 	Denylist                 []string `json:"denylist,omitempty"`
 	HasRecipientVerification *bool    `json:"has_recipient_verification,omitempty"`
 	IgnoreMXCheck            *bool    `json:"ignore_mx_check,omitempty"`
+	RestrictedAliasNames     []string `json:"restricted_alias_names,omitempty"`
 }
 
 // ListDomainsOptions represents options for listing domains
@@ -172,6 +198,7 @@ type ListDomainsOptions struct {
 	Plan     string `json:"plan,omitempty"`
 	Page     int    `json:"page,omitempty"`
 	Limit    int    `json:"limit,omitempty"`
+	Cursor   string `json:"cursor,omitempty"` // Opaque page token; replaces Page
 }
 
 // ListDomainsResponse represents the response from listing domains
@@ -188,6 +215,10 @@ type Pagination struct {
 	TotalPages int  `json:"total_pages"`
 	HasNext    bool `json:"has_next"`
 	HasPrev    bool `json:"has_prev"`
+	// NextCursor and PrevCursor are the opaque tokens of the adjacent pages
+	// when the API pages with cursors; pass them as the Cursor list option.
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
 
 // DomainGroup represents domain permission groups
@@ -15,14 +15,12 @@ import (
 // Supported filters include verification status, plan type, and search by name.
 // Results can be sorted and paginated using the provided options.
 func (s *DomainService) ListDomains(ctx context.Context, opts *ListDomainsOptions) (*ListDomainsResponse, error) {
-	u := s.client.BaseURL.ResolveReference(&url.URL{Path: "/v1/domains"})
+	u := s.client.resolve("/v1/domains")
 
 	// Add query parameters
 	if opts != nil {
 		params := url.Values{}
-		if opts.Page > 0 {
-			params.Set("page", strconv.Itoa(opts.Page))
-		}
+		setPageParams(params, opts.Page, opts.Cursor)
 		if opts.Limit > 0 {
 			params.Set("limit", strconv.Itoa(opts.Limit))
 		}
@@ -50,11 +48,13 @@ func (s *DomainService) ListDomains(ctx context.Context, opts *ListDomainsOption
 	}
 
 	var domains []Domain
-	if err := s.client.Do(ctx, req, &domains); err != nil {
+	header, err := s.client.doWithHeader(ctx, req, &domains)
+	if err != nil {
 		return nil, fmt.Errorf("failed to list domains: %w", err)
 	}
 
-	// Create response with basic pagination (since API returns array directly)
+	// Create response with basic pagination (since API returns array directly),
+	// then prefer the API's pagination headers when it sends them
 	response := &ListDomainsResponse{
 		Domains: domains,
 		Pagination: Pagination{
@@ -66,6 +66,7 @@ func (s *DomainService) ListDomains(ctx context.Context, opts *ListDomainsOption
 			HasPrev:    false,
 		},
 	}
+	applyPaginationHeaders(&response.Pagination, header)
 
 	return response, nil
 }
@@ -78,9 +79,7 @@ func domainGetHelper[T any](
 	ctx context.Context, s *DomainService, pathTemplate string,
 	domainIDOrName, errorPrefix string,
 ) (*T, error) {
-	u := s.client.BaseURL.ResolveReference(&url.URL{
-		Path: fmt.Sprintf(pathTemplate, url.PathEscape(domainIDOrName)),
-	})
+	u := s.client.resolve(fmt.Sprintf(pathTemplate, url.PathEscape(domainIDOrName)))
 
 	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), http.NoBody)
 	if err != nil {
@@ -106,12 +105,14 @@ func (s *DomainService) GetDomain(ctx context.Context, domainIDOrName string) (*
 // The request must contain at minimum the domain name. Optional settings include
 // SMTP configuration, webhook URLs, and custom plan settings.
 // Returns the created domain with initial verification status and required DNS records.
-func (s *DomainService) CreateDomain(ctx context.Context, req *CreateDomainRequest) (*Domain, error) {
+func (s *DomainService) CreateDomain(
+	ctx context.Context, req *CreateDomainRequest, opts ...RequestOption,
+) (*Domain, error) {
 	if req == nil {
 		return nil, fmt.Errorf("create domain request cannot be nil")
 	}
 
-	u := s.client.BaseURL.ResolveReference(&url.URL{Path: "/v1/domains"})
+	u := s.client.resolve("/v1/domains")
 
 	body, err := json.Marshal(req)
 	if err != nil {
@@ -123,6 +124,8 @@ func (s *DomainService) CreateDomain(ctx context.Context, req *CreateDomainReque
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
+	ctx, cancel := applyRequestOptions(ctx, httpReq, opts)
+	defer cancel()
 
 	var domain Domain
 	if err := s.client.Do(ctx, httpReq, &domain); err != nil {
@@ -143,9 +146,7 @@ func (s *DomainService) UpdateDomain(
 		return nil, fmt.Errorf("update domain request cannot be nil")
 	}
 
-	u := s.client.BaseURL.ResolveReference(&url.URL{
-		Path: fmt.Sprintf("/v1/domains/%s", url.PathEscape(domainIDOrName)),
-	})
+	u := s.client.resolve(fmt.Sprintf("/v1/domains/%s", url.PathEscape(domainIDOrName)))
 
 	body, err := json.Marshal(req)
 	if err != nil {
@@ -171,9 +172,7 @@ func (s *DomainService) UpdateDomain(
 // associated with the domain. The domainIDOrName parameter identifies the domain (UUID or FQDN).
 // Returns an error if the domain is not found or deletion fails.
 func (s *DomainService) DeleteDomain(ctx context.Context, domainIDOrName string) error {
-	u := s.client.BaseURL.ResolveReference(&url.URL{
-		Path: fmt.Sprintf("/v1/domains/%s", url.PathEscape(domainIDOrName)),
-	})
+	u := s.client.resolve(fmt.Sprintf("/v1/domains/%s", url.PathEscape(domainIDOrName)))
 
 	req, err := http.NewRequestWithContext(ctx, "DELETE", u.String(), http.NoBody)
 	if err != nil {
@@ -198,9 +197,7 @@ func (s *DomainService) DeleteDomain(ctx context.Context, domainIDOrName string)
 //
 // Both are valid states; 400 is NOT treated as an error.
 func (s *DomainService) VerifyDomain(ctx context.Context, domainIDOrName string) (*Domain, error) {
-	u := s.client.BaseURL.ResolveReference(&url.URL{
-		Path: fmt.Sprintf("/v1/domains/%s/verify-records", url.PathEscape(domainIDOrName)),
-	})
+	u := s.client.resolve(fmt.Sprintf("/v1/domains/%s/verify-records", url.PathEscape(domainIDOrName)))
 
 	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), http.NoBody)
 	if err != nil {
@@ -226,7 +223,7 @@ func (s *DomainService) VerifyDomain(ctx context.Context, domainIDOrName string)
 
 // GetDomainDNSRecords returns the required DNS records for a domain.
 // These records are generated locally based on Forward Email's standard requirements,
-// as there is no API endpoint for this. Each record includes the type, name, value,
+// or on Client.DNS for self-hosted deployments, as there is no API endpoint for this. Each record includes the type, name, value,
 // and purpose for proper email forwarding functionality.
 func (s *DomainService) GetDomainDNSRecords(ctx context.Context, domainIDOrName string) ([]DNSRecord, error) {
 	// First, get the domain to retrieve the verification record
@@ -235,27 +232,26 @@ func (s *DomainService) GetDomainDNSRecords(ctx context.Context, domainIDOrName
 		return nil, fmt.Errorf("failed to get domain: %w", err)
 	}
 
-	// Generate standard Forward Email DNS records
-	records := []DNSRecord{
-		{
-			Type:     "MX",
-			Name:     "@",
-			Value:    "mx1.forwardemail.net",
-			Priority: 10,
-			TTL:      3600,
-			Purpose:  "Primary mail server",
-			Required: true,
-		},
-		{
+	// Generate the records from the client's expected mail servers
+	dns := s.client.DNS.withDefaults()
+	records := make([]DNSRecord, 0, len(dns.MXHosts)+3)
+	for i, host := range dns.MXHosts {
+		purpose := "Backup mail server"
+		if i == 0 {
+			purpose = "Primary mail server"
+		}
+		records = append(records, DNSRecord{
 			Type:     "MX",
 			Name:     "@",
-			Value:    "mx2.forwardemail.net",
-			Priority: 20,
+			Value:    host,
+			Priority: 10 * (i + 1),
 			TTL:      3600,
-			Purpose:  "Backup mail server",
+			Purpose:  purpose,
 			Required: true,
-		},
-		{
+		})
+	}
+	records = append(records,
+		DNSRecord{
 			Type:     "TXT",
 			Name:     "@",
 			Value:    fmt.Sprintf("forward-email-site-verification=%s", domain.VerificationRecord),
@@ -263,15 +259,15 @@ func (s *DomainService) GetDomainDNSRecords(ctx context.Context, domainIDOrName
 			Purpose:  "Domain ownership verification",
 			Required: true,
 		},
-		{
+		DNSRecord{
 			Type:     "TXT",
 			Name:     "@",
-			Value:    "v=spf1 include:spf.forwardemail.net -all",
+			Value:    fmt.Sprintf("v=spf1 include:%s -all", dns.SPFInclude),
 			TTL:      3600,
 			Purpose:  "SPF record for email authentication",
 			Required: true,
 		},
-		{
+		DNSRecord{
 			Type:     "TXT",
 			Name:     "_dmarc",
 			Value:    "v=DMARC1; p=quarantine; pct=100",
@@ -279,7 +275,7 @@ func (s *DomainService) GetDomainDNSRecords(ctx context.Context, domainIDOrName
 			Purpose:  "DMARC policy for email security",
 			Required: false,
 		},
-	}
+	)
 
 	return records, nil
 }
@@ -290,9 +286,7 @@ func (s *DomainService) GetDomainDNSRecords(ctx context.Context, domainIDOrName
 // Returns verification results with status for the SMTP configuration.
 // Note: The API returns plain text, not JSON.
 func (s *DomainService) VerifySMTP(ctx context.Context, domainIDOrName string) (*DomainVerification, error) {
-	u := s.client.BaseURL.ResolveReference(&url.URL{
-		Path: fmt.Sprintf("/v1/domains/%s/verify-smtp", url.PathEscape(domainIDOrName)),
-	})
+	u := s.client.resolve(fmt.Sprintf("/v1/domains/%s/verify-smtp", url.PathEscape(domainIDOrName)))
 
 	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), http.NoBody)
 	if err != nil {
@@ -318,9 +312,7 @@ func (s *DomainService) VerifySMTP(ctx context.Context, domainIDOrName string) (
 func (s *DomainService) AddDomainMember(
 	ctx context.Context, domainIDOrName, email, group string,
 ) (*DomainMember, error) {
-	u := s.client.BaseURL.ResolveReference(&url.URL{
-		Path: fmt.Sprintf("/v1/domains/%s/members", url.PathEscape(domainIDOrName)),
-	})
+	u := s.client.resolve(fmt.Sprintf("/v1/domains/%s/members", url.PathEscape(domainIDOrName)))
 
 	reqBody := map[string]string{
 		"email": email,
@@ -346,14 +338,19 @@ func (s *DomainService) AddDomainMember(
 	return &member, nil
 }
 
+// AcceptDomainInvite accepts the pending invitation of the authenticated user
+// to join a domain and returns the domain. Invitations are addressed by domain:
+// the API has no invitation IDs and no endpoint listing a user's invitations.
+func (s *DomainService) AcceptDomainInvite(ctx context.Context, domainIDOrName string) (*Domain, error) {
+	return domainGetHelper[Domain](ctx, s, "/v1/domains/%s/invites", domainIDOrName, "failed to accept domain invite")
+}
+
 // RemoveDomainMember removes a member from a domain's access list.
 // The memberID parameter identifies the member to remove (UUID from domain member list).
 // This operation immediately revokes the member's access to the domain management interface
 // and any associated permissions.
 func (s *DomainService) RemoveDomainMember(ctx context.Context, domainIDOrName, memberID string) error {
-	u := s.client.BaseURL.ResolveReference(&url.URL{
-		Path: fmt.Sprintf("/v1/domains/%s/members/%s", url.PathEscape(domainIDOrName), url.PathEscape(memberID)),
-	})
+	u := s.client.resolve(fmt.Sprintf("/v1/domains/%s/members/%s", url.PathEscape(domainIDOrName), url.PathEscape(memberID)))
 
 	req, err := http.NewRequestWithContext(ctx, "DELETE", u.String(), http.NoBody)
 	if err != nil {
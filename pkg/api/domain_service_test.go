@@ -508,6 +508,41 @@ func TestDomainService_GetDomainDNSRecords(t *testing.T) {
 	}
 }
 
+// TestDomainService_GetDomainDNSRecords_Expectations tests that a self-hosted
+// deployment's mail servers and SPF include replace the defaults.
+func TestDomainService_GetDomainDNSRecords_Expectations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Domain{ID: "d1", Name: "example.com", VerificationRecord: "abc"})
+	}))
+	defer server.Close()
+
+	client, err := createTestClient(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if err := WithDNSExpectations(DNSExpectations{
+		MXHosts:    []string{"mx-a.mail.example.net", "mx-b.mail.example.net", "mx-c.mail.example.net"},
+		SPFInclude: "spf.mail.example.net",
+	})(client); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := client.Domains.GetDomainDNSRecords(context.Background(), "d1")
+	if err != nil {
+		t.Fatalf("GetDomainDNSRecords failed: %v", err)
+	}
+	if len(result) != 6 {
+		t.Fatalf("Expected 6 DNS records, got %d", len(result))
+	}
+	if result[2].Value != "mx-c.mail.example.net" || result[2].Priority != 30 || result[2].Purpose != "Backup mail server" {
+		t.Errorf("Expected the third MX host with priority 30, got %+v", result[2])
+	}
+	if result[4].Value != "v=spf1 include:spf.mail.example.net -all" {
+		t.Errorf("Expected the custom SPF include, got %s", result[4].Value)
+	}
+}
+
 func TestDomainService_AddDomainMember(t *testing.T) {
 	domainID := "member-domain-id"
 
@@ -599,6 +634,33 @@ func TestDomainService_RemoveDomainMember(t *testing.T) {
 	}
 }
 
+func TestDomainService_AcceptDomainInvite(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected GET request, got %s", r.Method)
+		}
+		if r.URL.Path != "/v1/domains/example.com/invites" {
+			t.Errorf("Expected path /v1/domains/example.com/invites, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Domain{ID: "d1", Name: "example.com"})
+	}))
+	defer server.Close()
+
+	client, err := createTestClient(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	domain, err := client.Domains.AcceptDomainInvite(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("AcceptDomainInvite failed: %v", err)
+	}
+	if domain.Name != "example.com" {
+		t.Errorf("Expected domain example.com, got %q", domain.Name)
+	}
+}
+
 func TestDomainService_ErrorHandling(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
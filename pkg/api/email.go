@@ -17,10 +17,28 @@ type Email struct {
 	Subject     string            `json:"subject"`
 	Text        string            `json:"text,omitempty"`
 	HTML        string            `json:"html,omitempty"`
-	Status      string            `json:"status"` // sent, delivered, bounced, failed
+	Message     string            `json:"message,omitempty"` // raw RFC 5322 source, when the API includes it
+	Status      string            `json:"status"`            // sent, delivered, bounced, failed
 	StatusInfo  string            `json:"status_info,omitempty"`
 }
 
+// EmailStatus represents the delivery status of a sent email
+type EmailStatus string
+
+const (
+	// EmailStatusSent means the email was accepted for delivery
+	EmailStatusSent EmailStatus = "sent"
+	// EmailStatusDelivered means the recipient server accepted the email
+	EmailStatusDelivered EmailStatus = "delivered"
+	// EmailStatusBounced means the recipient server rejected the email
+	EmailStatusBounced EmailStatus = "bounced"
+	// EmailStatusFailed means delivery was given up
+	EmailStatusFailed EmailStatus = "failed"
+)
+
+// EmailStatuses lists the statuses accepted by the email list status filter.
+var EmailStatuses = []EmailStatus{EmailStatusSent, EmailStatusDelivered, EmailStatusBounced, EmailStatusFailed}
+
 // EmailAttachment represents an email attachment
 type EmailAttachment struct {
 	Size        int64  `json:"size"` // bytes
@@ -83,6 +101,7 @@ type ListEmailsOptions struct {
 	DateTo    string `json:"date_to,omitempty"`    // Filter by date range (YYYY-MM-DD)
 	Page      int    `json:"page,omitempty"`       // Page number (1-based)
 	Limit     int    `json:"limit,omitempty"`      // Items per page
+	Cursor    string `json:"cursor,omitempty"`     // Opaque page token; replaces Page
 }
 
 // ListEmailsResponse represents the response from listing emails
@@ -92,6 +111,9 @@ type ListEmailsResponse struct {
 	Page       int     `json:"page"`
 	Limit      int     `json:"limit"`
 	TotalPages int     `json:"total_pages"`
+	// Pagination carries the same totals plus the API's next and previous
+	// page links, when it sends them.
+	Pagination Pagination `json:"pagination"`
 }
 
 // BulkEmailRequest represents a request to send multiple emails
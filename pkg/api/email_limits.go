@@ -0,0 +1,109 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ginsys/forward-email/pkg/units"
+)
+
+// EmailLimits are the size and recipient limits of one outbound email. A
+// zero field is not limited.
+type EmailLimits struct {
+	MaxMessageSize    int64 `json:"max_message_size" yaml:"max_message_size"`       // bytes, bodies and encoded attachments together
+	MaxAttachmentSize int64 `json:"max_attachment_size" yaml:"max_attachment_size"` // bytes, per decoded attachment
+	MaxRecipients     int   `json:"max_recipients" yaml:"max_recipients"`           // To, CC and BCC together
+}
+
+// DefaultEmailLimits are the limits checked where none are configured. The
+// API has no endpoint reporting them; the Forward Email FAQ
+// (https://forwardemail.net/en/faq, "What is the maximum email size limit")
+// documents 50 MB per email, headers and attachments included. It publishes no
+// per-attachment or recipient limit, so those are only checked when configured.
+var DefaultEmailLimits = EmailLimits{
+	MaxMessageSize: 50 << 20,
+}
+
+// WithDefaults fills the fields of l that are not set from defaults.
+func (l EmailLimits) WithDefaults(defaults EmailLimits) EmailLimits {
+	if l.MaxMessageSize == 0 {
+		l.MaxMessageSize = defaults.MaxMessageSize
+	}
+	if l.MaxAttachmentSize == 0 {
+		l.MaxAttachmentSize = defaults.MaxAttachmentSize
+	}
+	if l.MaxRecipients == 0 {
+		l.MaxRecipients = defaults.MaxRecipients
+	}
+	return l
+}
+
+// Check reports the first part of req that exceeds the limits, naming the
+// recipient or attachment, so a send fails before it is uploaded rather than
+// with a 413 from the server.
+func (l EmailLimits) Check(req *SendEmailRequest) error {
+	if l.MaxRecipients > 0 {
+		n := 0
+		for _, list := range [][]string{req.To, req.CC, req.BCC} {
+			for _, r := range list {
+				n++
+				if n > l.MaxRecipients {
+					return fmt.Errorf("recipient %s exceeds the limit of %d recipients per email (To, CC and BCC together)",
+						r, l.MaxRecipients)
+				}
+			}
+		}
+	}
+
+	for _, a := range req.Attachments {
+		if size := a.Size(); l.MaxAttachmentSize > 0 && size > l.MaxAttachmentSize {
+			return fmt.Errorf("attachment %s is %s, over the limit of %s per attachment",
+				a.Filename, units.FormatBytes(size), units.FormatBytes(l.MaxAttachmentSize))
+		}
+	}
+
+	if l.MaxMessageSize > 0 {
+		if size := req.EstimatedSize(); size > l.MaxMessageSize {
+			msg := fmt.Sprintf("email is about %s, over the limit of %s per email", units.FormatBytes(size), units.FormatBytes(l.MaxMessageSize))
+			if largest := largestAttachment(req.Attachments); largest != nil {
+				msg += fmt.Sprintf("; the largest attachment is %s (%s)", largest.Filename, units.FormatBytes(largest.Size()))
+			}
+			return fmt.Errorf("%s", msg)
+		}
+	}
+	return nil
+}
+
+// EstimatedSize is the approximate size of req as a MIME message: bodies,
+// headers and attachments in their base64 encoding.
+func (req *SendEmailRequest) EstimatedSize() int64 {
+	size := int64(len(req.Subject) + len(req.Text) + len(req.HTML))
+	for k, v := range req.Headers {
+		size += int64(len(k) + len(v) + 4)
+	}
+	for _, list := range [][]string{req.To, req.CC, req.BCC, {req.From}} {
+		for _, r := range list {
+			size += int64(len(r) + 2)
+		}
+	}
+	for _, a := range req.Attachments {
+		size += int64(len(a.Content))
+	}
+	return size
+}
+
+// Size is the decoded size of the attachment's base64 content.
+func (a *AttachmentData) Size() int64 {
+	n := len(a.Content)
+	return int64(n/4*3 - bytes.Count(a.Content[max(n-2, 0):], []byte("=")))
+}
+
+func largestAttachment(attachments []AttachmentData) *AttachmentData {
+	var largest *AttachmentData
+	for i := range attachments {
+		if largest == nil || attachments[i].Size() > largest.Size() {
+			largest = &attachments[i]
+		}
+	}
+	return largest
+}
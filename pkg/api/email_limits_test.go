@@ -0,0 +1,60 @@
+package api
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+// attachment is an attachment of n bytes, base64-encoded as email send does.
+func attachment(name string, n int) AttachmentData {
+	return AttachmentData{Filename: name, Content: []byte(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte("x"), n)))}
+}
+
+func TestEmailLimits_Check(t *testing.T) {
+	limits := EmailLimits{MaxMessageSize: 3000, MaxAttachmentSize: 2000, MaxRecipients: 3}
+	base := func() *SendEmailRequest {
+		return &SendEmailRequest{From: "a@example.com", To: []string{"b@example.com"}, Subject: "Hi", Text: "Hello"}
+	}
+
+	if err := limits.Check(base()); err != nil {
+		t.Fatalf("expected a small email to pass, got %v", err)
+	}
+
+	req := base()
+	req.CC = []string{"c@example.com", "d@example.com"}
+	req.BCC = []string{"e@example.com"}
+	if err := limits.Check(req); err == nil || !strings.Contains(err.Error(), "recipient e@example.com exceeds the limit of 3") {
+		t.Errorf("expected the fourth recipient to be named, got %v", err)
+	}
+
+	req = base()
+	req.Attachments = []AttachmentData{attachment("notes.txt", 100), attachment("scan.pdf", 2500)}
+	if err := limits.Check(req); err == nil || !strings.Contains(err.Error(), "attachment scan.pdf is 2.4 KB, over the limit of 2.0 KB") {
+		t.Errorf("expected the oversized attachment to be named, got %v", err)
+	}
+
+	// Each attachment fits, but base64 encoding takes the email over the limit.
+	req.Attachments = []AttachmentData{attachment("a.bin", 1500), attachment("b.bin", 1000)}
+	if err := limits.Check(req); err == nil || !strings.Contains(err.Error(), "the largest attachment is a.bin") {
+		t.Errorf("expected the total size to be refused, got %v", err)
+	}
+
+	for n := 0; n < 5; n++ {
+		if a := attachment("x", n); a.Size() != int64(n) {
+			t.Errorf("Size of %d bytes = %d", n, a.Size())
+		}
+	}
+
+	if err := (EmailLimits{}).Check(req); err != nil {
+		t.Errorf("expected zero limits not to limit, got %v", err)
+	}
+}
+
+func TestEmailLimits_WithDefaults(t *testing.T) {
+	got := EmailLimits{MaxRecipients: 10}.WithDefaults(DefaultEmailLimits)
+	if got.MaxRecipients != 10 || got.MaxMessageSize != DefaultEmailLimits.MaxMessageSize || got.MaxAttachmentSize != 0 {
+		t.Errorf("unexpected limits %+v", got)
+	}
+}
@@ -15,7 +15,9 @@ import (
 // Requires from address, at least one recipient, subject, and either text or HTML content.
 // Supports CC/BCC recipients, custom headers, and file attachments. The from address
 // must be a verified alias or catch-all for a domain under your account.
-func (s *EmailService) SendEmail(ctx context.Context, req *SendEmailRequest) (*SendEmailResponse, error) {
+func (s *EmailService) SendEmail(
+	ctx context.Context, req *SendEmailRequest, opts ...RequestOption,
+) (*SendEmailResponse, error) {
 	if req == nil {
 		return nil, fmt.Errorf("send request is required")
 	}
@@ -45,7 +47,7 @@ func (s *EmailService) SendEmail(ctx context.Context, req *SendEmailRequest) (*S
 		}
 	}
 
-	u := s.client.BaseURL.ResolveReference(&url.URL{Path: "/v1/emails"})
+	u := s.client.resolve("/v1/emails")
 
 	body, err := json.Marshal(req)
 	if err != nil {
@@ -57,6 +59,8 @@ func (s *EmailService) SendEmail(ctx context.Context, req *SendEmailRequest) (*S
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
+	ctx, cancel := applyRequestOptions(ctx, httpReq, opts)
+	defer cancel()
 
 	var response SendEmailResponse
 	if err := s.client.Do(ctx, httpReq, &response); err != nil {
@@ -70,7 +74,9 @@ func (s *EmailService) SendEmail(ctx context.Context, req *SendEmailRequest) (*S
 // More efficient than individual SendEmail calls for large volumes. Each email
 // in the batch is validated independently. Returns a job ID for tracking progress
 // and detailed results for each email sent, including any failures.
-func (s *EmailService) SendBulkEmails(ctx context.Context, req *BulkEmailRequest) (*BulkEmailResponse, error) {
+func (s *EmailService) SendBulkEmails(
+	ctx context.Context, req *BulkEmailRequest, opts ...RequestOption,
+) (*BulkEmailResponse, error) {
 	if req == nil {
 		return nil, fmt.Errorf("bulk request is required")
 	}
@@ -94,7 +100,7 @@ func (s *EmailService) SendBulkEmails(ctx context.Context, req *BulkEmailRequest
 		}
 	}
 
-	u := s.client.BaseURL.ResolveReference(&url.URL{Path: "/v1/emails/bulk"})
+	u := s.client.resolve("/v1/emails/bulk")
 
 	body, err := json.Marshal(req)
 	if err != nil {
@@ -106,6 +112,8 @@ func (s *EmailService) SendBulkEmails(ctx context.Context, req *BulkEmailRequest
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
+	ctx, cancel := applyRequestOptions(ctx, httpReq, opts)
+	defer cancel()
 
 	var response BulkEmailResponse
 	if err := s.client.Do(ctx, httpReq, &response); err != nil {
@@ -120,14 +128,12 @@ func (s *EmailService) SendBulkEmails(ctx context.Context, req *BulkEmailRequest
 // Results can be searched by subject/content and sorted by various criteria.
 // Useful for tracking email delivery status and auditing sent messages.
 func (s *EmailService) ListEmails(ctx context.Context, opts *ListEmailsOptions) (*ListEmailsResponse, error) {
-	u := s.client.BaseURL.ResolveReference(&url.URL{Path: "/v1/emails"})
+	u := s.client.resolve("/v1/emails")
 
 	// Add query parameters
 	if opts != nil {
 		params := url.Values{}
-		if opts.Page > 0 {
-			params.Set("page", strconv.Itoa(opts.Page))
-		}
+		setPageParams(params, opts.Page, opts.Cursor)
 		if opts.Limit > 0 {
 			params.Set("limit", strconv.Itoa(opts.Limit))
 		}
@@ -167,11 +173,13 @@ func (s *EmailService) ListEmails(ctx context.Context, opts *ListEmailsOptions)
 	}
 
 	var emails []Email
-	if err := s.client.Do(ctx, req, &emails); err != nil {
+	header, err := s.client.doWithHeader(ctx, req, &emails)
+	if err != nil {
 		return nil, fmt.Errorf("failed to list emails: %w", err)
 	}
 
-	// Calculate pagination info (since API returns array directly)
+	// Calculate pagination info from the returned page, then prefer the
+	// API's pagination headers when it sends them
 	totalCount := len(emails)
 	page := 1
 	if opts != nil && opts.Page > 0 {
@@ -181,14 +189,16 @@ func (s *EmailService) ListEmails(ctx context.Context, opts *ListEmailsOptions)
 	if opts != nil && opts.Limit > 0 {
 		limit = opts.Limit
 	}
-	totalPages := (totalCount + limit - 1) / limit
+	pagination := Pagination{Page: page, Limit: limit, Total: totalCount, TotalPages: (totalCount + limit - 1) / limit}
+	applyPaginationHeaders(&pagination, header)
 
 	return &ListEmailsResponse{
 		Emails:     emails,
-		TotalCount: totalCount,
-		Page:       page,
-		Limit:      limit,
-		TotalPages: totalPages,
+		TotalCount: pagination.Total,
+		Page:       pagination.Page,
+		Limit:      pagination.Limit,
+		TotalPages: pagination.TotalPages,
+		Pagination: pagination,
 	}, nil
 }
 
@@ -201,7 +211,7 @@ func (s *EmailService) GetEmail(ctx context.Context, emailID string) (*Email, er
 		return nil, fmt.Errorf("email ID is required")
 	}
 
-	u := s.client.BaseURL.ResolveReference(&url.URL{Path: fmt.Sprintf("/v1/emails/%s", emailID)})
+	u := s.client.resolve(fmt.Sprintf("/v1/emails/%s", emailID))
 
 	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), http.NoBody)
 	if err != nil {
@@ -225,7 +235,7 @@ func (s *EmailService) DeleteEmail(ctx context.Context, emailID string) error {
 		return fmt.Errorf("email ID is required")
 	}
 
-	u := s.client.BaseURL.ResolveReference(&url.URL{Path: fmt.Sprintf("/v1/emails/%s", emailID)})
+	u := s.client.resolve(fmt.Sprintf("/v1/emails/%s", emailID))
 
 	req, err := http.NewRequestWithContext(ctx, "DELETE", u.String(), http.NoBody)
 	if err != nil {
@@ -244,7 +254,7 @@ func (s *EmailService) DeleteEmail(ctx context.Context, emailID string) error {
 // and any temporary restrictions. Quotas are enforced per account and
 // may vary based on subscription plan and sending reputation.
 func (s *EmailService) GetEmailQuota(ctx context.Context) (*EmailQuota, error) {
-	u := s.client.BaseURL.ResolveReference(&url.URL{Path: "/v1/emails/limit"})
+	u := s.client.resolve("/v1/emails/limit")
 
 	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), http.NoBody)
 	if err != nil {
@@ -295,7 +305,7 @@ func (s *EmailService) GetBulkJobStatus(ctx context.Context, jobID string) (*Bul
 		return nil, fmt.Errorf("job ID is required")
 	}
 
-	u := s.client.BaseURL.ResolveReference(&url.URL{Path: fmt.Sprintf("/v1/emails/bulk/%s", jobID)})
+	u := s.client.resolve(fmt.Sprintf("/v1/emails/bulk/%s", jobID))
 
 	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), http.NoBody)
 	if err != nil {
@@ -323,7 +333,7 @@ func (s *EmailService) GetAttachment(ctx context.Context, emailID, attachmentID
 	}
 
 	path := fmt.Sprintf("/v1/emails/%s/attachments/%s", emailID, attachmentID)
-	u := s.client.BaseURL.ResolveReference(&url.URL{Path: path})
+	u := s.client.resolve(path)
 
 	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), http.NoBody)
 	if err != nil {
@@ -351,7 +361,7 @@ func (s *EmailService) DownloadAttachment(ctx context.Context, emailID, attachme
 	}
 
 	path := fmt.Sprintf("/v1/emails/%s/attachments/%s/download", emailID, attachmentID)
-	u := s.client.BaseURL.ResolveReference(&url.URL{Path: path})
+	u := s.client.resolve(path)
 
 	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), http.NoBody)
 	if err != nil {
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Middleware wraps the transport that sends API requests, to observe or change
+// requests and responses on their way, e.g. for logging, metrics, caching or
+// extra headers:
+//
+//	func tagRequests(next http.RoundTripper) http.RoundTripper {
+//		return api.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+//			req = req.Clone(req.Context())
+//			req.Header.Set("X-Request-Source", "billing-sync")
+//			return next.RoundTrip(req)
+//		})
+//	}
+//
+// Like any http.RoundTripper, a middleware must not modify the request it is
+// given; it clones it instead.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to an http.RoundTripper.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper.
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// WithMiddleware adds middleware to the client, the first given outermost.
+// Middleware sees each API call once, authenticated, before the response cache
+// and retries; the client's own cache, retry and logging middleware run inside
+// it.
+func WithMiddleware(mw ...Middleware) ClientOption {
+	return func(c *Client) error {
+		c.Middleware = append(c.Middleware, mw...)
+		return nil
+	}
+}
+
+// Chain wraps rt in mw, the first outermost, so that a request passes through
+// mw[0], then mw[1], and so on before reaching rt.
+func Chain(rt http.RoundTripper, mw ...Middleware) http.RoundTripper {
+	for i := len(mw) - 1; i >= 0; i-- {
+		rt = mw[i](rt)
+	}
+	return rt
+}
+
+// transport returns the chain an authenticated request goes through: the
+// client's middleware, the response cache, retries and request logging, and
+// finally HTTPClient. It is built per request, so changes to the client's
+// fields apply to the next request.
+func (c *Client) transport() http.RoundTripper {
+	mw := append([]Middleware{}, c.Middleware...)
+	if c.Cache != nil {
+		mw = append(mw, CacheMiddleware(c.Cache, c.logger()))
+	}
+	mw = append(mw, RetryMiddleware(c.Retry, c.logger()), LoggingMiddleware(c.logger()))
+	return Chain(RoundTripperFunc(c.HTTPClient.Do), mw...)
+}
+
+// attemptKey is the context key holding the attempt number of a request.
+type attemptKey struct{}
+
+// RetryMiddleware retries requests according to policy: idempotent requests
+// are sent again after network errors, 429 and 5xx responses, waiting for the
+// policy's backoff or the response's Retry-After. Retries are logged to
+// logger at info level.
+func RetryMiddleware(policy RetryPolicy, logger *slog.Logger) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			ctx := req.Context()
+			attempts := 1
+			if isIdempotent(req) {
+				attempts = max(policy.MaxAttempts, 1)
+			}
+
+			for attempt := 1; ; attempt++ {
+				resp, err := next.RoundTrip(req.WithContext(context.WithValue(ctx, attemptKey{}, attempt)))
+				if attempt >= attempts || ctx.Err() != nil || !shouldRetry(resp, err) {
+					return resp, err
+				}
+
+				delay := policy.backoff(attempt, resp)
+				logger.InfoContext(ctx, "retrying api request", "method", req.Method, "path", req.URL.Path, "attempt", attempt+1, "delay", delay)
+				if resp != nil {
+					_, _ = io.Copy(io.Discard, resp.Body)
+					_ = resp.Body.Close()
+				}
+
+				timer := time.NewTimer(delay)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return nil, ctx.Err()
+				case <-timer.C:
+				}
+
+				// Rewind the body for the next attempt
+				if req.GetBody != nil {
+					body, err := req.GetBody()
+					if err != nil {
+						return nil, err
+					}
+					req = req.Clone(ctx)
+					req.Body = body
+				}
+			}
+		})
+	}
+}
+
+// LoggingMiddleware logs every request sent, with its attempt number, status
+// and duration, to logger at debug level.
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			attempt, ok := req.Context().Value(attemptKey{}).(int)
+			if !ok {
+				attempt = 1
+			}
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			attrs := []any{"method", req.Method, "path", req.URL.Path, "attempt", attempt, "duration", time.Since(start)}
+			if err != nil {
+				logger.DebugContext(req.Context(), "api request failed", append(attrs, "error", err)...)
+			} else {
+				logger.DebugContext(req.Context(), "api request", append(attrs, "status", resp.StatusCode)...)
+			}
+			return resp, err
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_WithMiddleware(t *testing.T) {
+	var calls int
+	var gotHeader, gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		gotHeader, gotAuth = r.Header.Get("X-Request-Source"), r.Header.Get("Authorization")
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"d1","name":"example.com"}`))
+	}))
+	defer server.Close()
+
+	var order []string
+	var statuses []int
+	trace := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				resp, err := next.RoundTrip(req)
+				if err == nil && name == "outer" {
+					statuses = append(statuses, resp.StatusCode)
+				}
+				return resp, err
+			})
+		}
+	}
+	tag := func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			req.Header.Set("X-Request-Source", "test")
+			return next.RoundTrip(req)
+		})
+	}
+
+	client, err := NewClient(
+		WithBaseURL(server.URL),
+		WithAPIKey("key"),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}),
+		WithMiddleware(trace("outer"), trace("inner")),
+		WithMiddleware(tag),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if _, err := client.Domains.GetDomain(context.Background(), "example.com"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := strings.Join(order, ","); got != "outer,inner" {
+		t.Errorf("expected each middleware to run once, outermost first, got %s", got)
+	}
+	if calls != 2 || len(statuses) != 1 || statuses[0] != http.StatusOK {
+		t.Errorf("expected the retry inside the middleware: calls=%d statuses=%v", calls, statuses)
+	}
+	if gotHeader != "test" || !strings.HasPrefix(gotAuth, "Basic ") {
+		t.Errorf("expected the added header on an authenticated request, got %q and %q", gotHeader, gotAuth)
+	}
+}
+
+func TestChain(t *testing.T) {
+	var order []string
+	mw := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+	rt := Chain(RoundTripperFunc(func(*http.Request) (*http.Response, error) {
+		order = append(order, "transport")
+		return &http.Response{StatusCode: http.StatusNoContent, Body: http.NoBody}, nil
+	}), mw("a"), mw("b"))
+
+	req := httptest.NewRequest(http.MethodGet, "https://example.com", nil)
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if got := strings.Join(order, ","); got != "a,b,transport" {
+		t.Errorf("expected a,b,transport, got %s", got)
+	}
+}
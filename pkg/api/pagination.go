@@ -0,0 +1,111 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Pagination headers sent by the Forward Email API on list endpoints.
+const (
+	HeaderPageCount   = "X-Page-Count"
+	HeaderPageCurrent = "X-Page-Current"
+	HeaderPageSize    = "X-Page-Size"
+	HeaderItemCount   = "X-Item-Count"
+)
+
+// applyPaginationHeaders overlays the pagination headers of a list response
+// on p, which holds the values computed from the request and the returned
+// items. Absent or malformed headers leave their field as computed, so
+// servers that send none keep the previous behavior.
+//
+// A Link header (RFC 8288) decides HasNext and HasPrev when present. A cursor
+// query parameter in its next or prev link is surfaced as NextCursor or
+// PrevCursor, for APIs that page with opaque tokens instead of page numbers.
+func applyPaginationHeaders(p *Pagination, h http.Header) {
+	if v, ok := headerInt(h, HeaderPageCurrent); ok {
+		p.Page = v
+	}
+	if v, ok := headerInt(h, HeaderPageSize); ok {
+		p.Limit = v
+	}
+	if v, ok := headerInt(h, HeaderPageCount); ok {
+		p.TotalPages = v
+	}
+	if v, ok := headerInt(h, HeaderItemCount); ok {
+		p.Total = v
+	}
+	p.HasNext = p.Page < p.TotalPages
+	p.HasPrev = p.Page > 1
+
+	links := parseLinkHeader(h.Values("Link"))
+	if len(links) == 0 {
+		return
+	}
+	next, hasNext := links["next"]
+	prev, hasPrev := links["prev"]
+	if !hasPrev {
+		prev, hasPrev = links["previous"]
+	}
+	p.HasNext, p.HasPrev = hasNext, hasPrev
+	if hasNext {
+		p.NextCursor = next.Query().Get("cursor")
+	}
+	if hasPrev {
+		p.PrevCursor = prev.Query().Get("cursor")
+	}
+}
+
+// headerInt returns the non-negative integer value of header name.
+func headerInt(h http.Header, name string) (int, bool) {
+	v, err := strconv.Atoi(strings.TrimSpace(h.Get(name)))
+	if err != nil || v < 0 {
+		return 0, false
+	}
+	return v, true
+}
+
+// parseLinkHeader returns the target of each relation in the given Link
+// header values, keyed by lowercase relation type. Malformed links are
+// skipped.
+func parseLinkHeader(values []string) map[string]*url.URL {
+	links := map[string]*url.URL{}
+	for _, v := range values {
+		for {
+			start := strings.IndexByte(v, '<')
+			end := strings.IndexByte(v, '>')
+			if start < 0 || end < start {
+				break
+			}
+			target, err := url.Parse(v[start+1 : end])
+			params, rest, _ := strings.Cut(v[end+1:], ",")
+			v = rest
+			if err != nil {
+				continue
+			}
+			for _, param := range strings.Split(params, ";") {
+				key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+				if !ok || !strings.EqualFold(strings.TrimSpace(key), "rel") {
+					continue
+				}
+				for _, rel := range strings.Fields(strings.Trim(strings.TrimSpace(value), `"`)) {
+					links[strings.ToLower(rel)] = target
+				}
+			}
+		}
+	}
+	return links
+}
+
+// setPageParams sets the page and cursor query parameters of a list request.
+// A cursor replaces the page number, which cursor-paged APIs ignore.
+func setPageParams(params url.Values, page int, cursor string) {
+	if cursor != "" {
+		params.Set("cursor", cursor)
+		return
+	}
+	if page > 0 {
+		params.Set("page", strconv.Itoa(page))
+	}
+}
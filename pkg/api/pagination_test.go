@@ -0,0 +1,176 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestApplyPaginationHeaders(t *testing.T) {
+	computed := Pagination{Page: 1, Limit: 25, Total: 3, TotalPages: 1}
+	tests := []struct {
+		name   string
+		header http.Header
+		want   Pagination
+	}{
+		{
+			name:   "no headers keeps the computed values",
+			header: http.Header{},
+			want:   computed,
+		},
+		{
+			name: "page number headers",
+			header: http.Header{
+				HeaderPageCurrent: {"2"}, HeaderPageSize: {"25"}, HeaderPageCount: {"4"}, HeaderItemCount: {"80"},
+			},
+			want: Pagination{Page: 2, Limit: 25, Total: 80, TotalPages: 4, HasNext: true, HasPrev: true},
+		},
+		{
+			name:   "malformed headers are ignored",
+			header: http.Header{HeaderPageCount: {"many"}, HeaderItemCount: {"-1"}},
+			want:   computed,
+		},
+		{
+			name: "link header with page numbers",
+			header: http.Header{
+				HeaderPageCurrent: {"1"}, HeaderPageCount: {"1"},
+				"Link": {`</v1/domains?page=2&limit=25>; rel="next", </v1/domains?page=9&limit=25>; rel="last"`},
+			},
+			want: Pagination{Page: 1, Limit: 25, Total: 3, TotalPages: 1, HasNext: true},
+		},
+		{
+			name: "link header with cursors",
+			header: http.Header{"Link": {
+				`<https://api.forwardemail.net/v1/domains?cursor=b2Zmc2V0OjUw&limit=25>; rel="next"`,
+				`<https://api.forwardemail.net/v1/domains?cursor=b2Zmc2V0OjA>; rel="prev first"`,
+			}},
+			want: Pagination{
+				Page: 1, Limit: 25, Total: 3, TotalPages: 1, HasNext: true, HasPrev: true,
+				NextCursor: "b2Zmc2V0OjUw", PrevCursor: "b2Zmc2V0OjA",
+			},
+		},
+		{
+			name: "link header on the last page",
+			header: http.Header{
+				HeaderPageCurrent: {"1"}, HeaderPageCount: {"3"},
+				"Link": {`</v1/domains?page=1>; rel="first"`},
+			},
+			want: Pagination{Page: 1, Limit: 25, Total: 3, TotalPages: 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := computed
+			applyPaginationHeaders(&got, tt.header)
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLinkHeader(t *testing.T) {
+	links := parseLinkHeader([]string{
+		`<https://x.test/a?page=2>; rel="next"; title="Next, please", <https://x.test/a?page=1>; rel=prev`,
+		`not a link, <%zz>; rel="last"`,
+	})
+	if len(links) != 2 {
+		t.Fatalf("got %d links, want 2: %v", len(links), links)
+	}
+	if got := links["next"].Query().Get("page"); got != "2" {
+		t.Errorf("next page = %q, want 2", got)
+	}
+	if got := links["prev"].Query().Get("page"); got != "1" {
+		t.Errorf("prev page = %q, want 1", got)
+	}
+}
+
+// TestListPaginationStyles checks that list calls work against servers that
+// send no pagination headers, page numbers, or cursors.
+func TestListPaginationStyles(t *testing.T) {
+	pages := map[string][]Alias{"": {{Name: "a"}, {Name: "b"}}, "c2": {{Name: "c"}}}
+	tests := []struct {
+		name   string
+		style  string
+		cursor string
+		check  func(t *testing.T, r *http.Request, resp *ListAliasesResponse)
+	}{
+		{
+			name:  "headerless",
+			style: "none",
+			check: func(t *testing.T, _ *http.Request, resp *ListAliasesResponse) {
+				if resp.TotalCount != 2 || resp.Pagination.HasNext || resp.Pagination.NextCursor != "" {
+					t.Errorf("unexpected pagination %+v", resp.Pagination)
+				}
+			},
+		},
+		{
+			name:  "page numbers",
+			style: "pages",
+			check: func(t *testing.T, _ *http.Request, resp *ListAliasesResponse) {
+				if resp.TotalCount != 3 || resp.TotalPages != 2 || !resp.Pagination.HasNext {
+					t.Errorf("unexpected pagination %+v", resp.Pagination)
+				}
+			},
+		},
+		{
+			name:  "cursor first page",
+			style: "cursor",
+			check: func(t *testing.T, _ *http.Request, resp *ListAliasesResponse) {
+				if resp.Pagination.NextCursor != "c2" {
+					t.Errorf("NextCursor = %q, want c2", resp.Pagination.NextCursor)
+				}
+			},
+		},
+		{
+			name:   "cursor next page",
+			style:  "cursor",
+			cursor: "c2",
+			check: func(t *testing.T, r *http.Request, resp *ListAliasesResponse) {
+				if r.URL.Query().Has("page") {
+					t.Errorf("page sent with cursor: %s", r.URL.RawQuery)
+				}
+				if len(resp.Aliases) != 1 || resp.Pagination.HasNext || resp.Pagination.NextCursor != "" {
+					t.Errorf("unexpected last page %+v", resp.Pagination)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *http.Request
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r
+				cursor := r.URL.Query().Get("cursor")
+				switch tt.style {
+				case "pages":
+					w.Header().Set(HeaderPageCurrent, "1")
+					w.Header().Set(HeaderPageSize, "2")
+					w.Header().Set(HeaderPageCount, "2")
+					w.Header().Set(HeaderItemCount, "3")
+				case "cursor":
+					if cursor == "" {
+						w.Header().Set("Link", `</v1/domains/example.com/aliases?cursor=c2&limit=2>; rel="next"`)
+					}
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(pages[cursor])
+			}))
+			defer server.Close()
+
+			client, err := createTestAliasClient(server.URL)
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+			resp, err := client.Aliases.ListAliases(context.Background(), &ListAliasesOptions{
+				Domain: "example.com", Page: 1, Limit: 2, Cursor: tt.cursor,
+			})
+			if err != nil {
+				t.Fatalf("ListAliases failed: %v", err)
+			}
+			tt.check(t, got, resp)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+)
+
+// IdempotencyKeyHeader is the request header carrying an idempotency key.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// RequestOption customizes a single API call, e.g. SendEmail or CreateAlias.
+type RequestOption func(*requestConfig)
+
+// requestConfig collects the per-call settings applied by RequestOptions.
+type requestConfig struct {
+	headers http.Header
+	timeout time.Duration
+}
+
+// WithIdempotencyKey sends key as the Idempotency-Key header, for servers or
+// proxies in front of them that honor it. The Forward Email API does not
+// document support for the header, so it does not by itself prevent a request
+// from being applied twice, and it does not make the request retryable.
+func WithIdempotencyKey(key string) RequestOption {
+	return func(rc *requestConfig) {
+		if key != "" {
+			rc.headers.Set(IdempotencyKeyHeader, key)
+		}
+	}
+}
+
+// WithRequestTimeout bounds the duration of a single call, including retries,
+// independently of the HTTP client's timeout.
+func WithRequestTimeout(d time.Duration) RequestOption {
+	return func(rc *requestConfig) {
+		rc.timeout = d
+	}
+}
+
+// NewIdempotencyKey returns a random key suitable for WithIdempotencyKey.
+func NewIdempotencyKey() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// applyRequestOptions applies opts to req and returns the context to execute it with.
+// The returned cancel function must always be called.
+func applyRequestOptions(
+	ctx context.Context, req *http.Request, opts []RequestOption,
+) (context.Context, context.CancelFunc) {
+	rc := requestConfig{headers: http.Header{}}
+	for _, opt := range opts {
+		opt(&rc)
+	}
+	for name, values := range rc.headers {
+		req.Header[name] = values
+	}
+	if rc.timeout > 0 {
+		return context.WithTimeout(ctx, rc.timeout)
+	}
+	return ctx, func() {}
+}
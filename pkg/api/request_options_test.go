@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRequestOptions_IdempotencyKeyDoesNotEnableRetry(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(
+		WithBaseURL(server.URL),
+		WithAPIKey("key"),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	// A POST that may have been applied must not be sent again, key or not.
+	req := &SendEmailRequest{From: "a@example.com", To: []string{"b@example.com"}, Subject: "s", Text: "t"}
+	if _, err := client.Emails.SendEmail(context.Background(), req, WithIdempotencyKey("abc")); err == nil {
+		t.Fatal("expected SendEmail to fail")
+	}
+	if len(keys) != 1 || keys[0] != "abc" {
+		t.Errorf("expected a single attempt carrying the key, got %q", keys)
+	}
+}
+
+func TestRequestOptions_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(200 * time.Millisecond):
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithBaseURL(server.URL), WithAPIKey("key"))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	_, err = client.Domains.CreateDomain(context.Background(), &CreateDomainRequest{Name: "example.com"},
+		WithRequestTimeout(20*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestNewIdempotencyKey(t *testing.T) {
+	a, b := NewIdempotencyKey(), NewIdempotencyKey()
+	if len(a) != 32 || a == b {
+		t.Errorf("expected distinct 32-char keys, got %q and %q", a, b)
+	}
+}
@@ -0,0 +1,353 @@
+// Package apitest records Forward Email API traffic to cassette files and
+// replays it, so code built on the api package (or scripts driving the CLI)
+// can be tested against realistic API behavior without live credentials.
+//
+// Record a cassette once against the real API:
+//
+//	rec, err := apitest.New("testdata/domains.yaml", apitest.ModeRecord, nil)
+//	client, err := api.NewClient(api.WithAPIKey(key), api.WithHTTPClient(rec.Client()))
+//
+// and replay it in tests, where any API key will do:
+//
+//	rec, err := apitest.New("testdata/domains.yaml", apitest.ModeReplay, nil)
+//	client, err := api.NewClient(api.WithAPIKey("test"), api.WithHTTPClient(rec.Client()))
+//
+// Cassettes are YAML. Credentials are scrubbed before anything is written: only
+// a fixed set of harmless headers is kept, and JSON body fields whose names
+// suggest a secret (password, token, api_key, ...) are replaced by REDACTED.
+package apitest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Mode selects whether a Recorder talks to the API or to its cassette.
+type Mode string
+
+const (
+	// ModeReplay answers requests from the cassette and never touches the network.
+	ModeReplay Mode = "replay"
+	// ModeRecord forwards requests to the API and appends them to the cassette.
+	ModeRecord Mode = "record"
+)
+
+// Redacted replaces scrubbed secret values.
+const Redacted = "REDACTED"
+
+// ParseMode parses "replay" or "record".
+func ParseMode(s string) (Mode, error) {
+	switch m := Mode(strings.ToLower(s)); m {
+	case ModeReplay, ModeRecord:
+		return m, nil
+	default:
+		return "", fmt.Errorf("invalid cassette mode %q: must be replay or record", s)
+	}
+}
+
+// Cassette is a recorded sequence of API requests and their responses.
+type Cassette struct {
+	Interactions []Interaction `yaml:"interactions" json:"interactions"`
+}
+
+// Interaction is one request and the response the API gave to it.
+type Interaction struct {
+	Request  Request  `yaml:"request" json:"request"`
+	Response Response `yaml:"response" json:"response"`
+}
+
+// Request is a recorded request. URL holds the path and query only, so a
+// cassette replays against any base URL host.
+type Request struct {
+	Method  string            `yaml:"method" json:"method"`
+	URL     string            `yaml:"url" json:"url"`
+	Headers map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+	Body    string            `yaml:"body,omitempty" json:"body,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	Status  int               `yaml:"status" json:"status"`
+	Headers map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+	Body    string            `yaml:"body,omitempty" json:"body,omitempty"`
+}
+
+// Scrubber edits an interaction before it is written to a cassette.
+type Scrubber func(*Interaction)
+
+// keptHeaders are the headers worth recording; everything else, including
+// Authorization and cookies, is dropped.
+var keptHeaders = []string{
+	"Content-Type", "Idempotency-Key", "If-Match", "If-None-Match", "ETag", "Link", "Location", "Retry-After",
+	"X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset",
+}
+
+// secretFields are substrings of JSON field names whose values are redacted.
+var secretFields = []string{"password", "secret", "token", "api_key", "apikey", "private_key"}
+
+// Load reads a cassette file.
+func Load(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // the cassette path is chosen by the caller
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+	var c Cassette
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("invalid cassette %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// Save writes the cassette to path, creating parent directories as needed.
+func (c *Cassette) Save(path string) error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+// Recorder is an http.RoundTripper that records to or replays from a
+// cassette file.
+type Recorder struct {
+	// Scrubbers run, in order, on every interaction before it is saved, after
+	// the built-in secret scrubbing.
+	Scrubbers []Scrubber
+
+	path     string
+	mode     Mode
+	next     http.RoundTripper
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// New returns a Recorder for the cassette at path. In ModeReplay the cassette
+// must exist. In ModeRecord it is started afresh and saved after every
+// request, so nothing is lost if the program exits early; requests go to next,
+// or to http.DefaultTransport when next is nil.
+func New(path string, mode Mode, next http.RoundTripper) (*Recorder, error) {
+	r := &Recorder{path: path, mode: mode, next: next}
+	switch mode {
+	case ModeReplay:
+		c, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		r.cassette = *c
+		r.used = make([]bool, len(c.Interactions))
+	case ModeRecord:
+		if r.next == nil {
+			r.next = http.DefaultTransport
+		}
+	default:
+		return nil, fmt.Errorf("invalid cassette mode %q", mode)
+	}
+	return r, nil
+}
+
+// Mode returns whether r records or replays.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Client returns an HTTP client that sends its requests through r.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.mode == ModeReplay {
+		return r.replay(req)
+	}
+	return r.record(req)
+}
+
+// replay answers req with the first unused interaction that has the same
+// method and URL, so repeated requests get their responses in recorded order.
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, in := range r.cassette.Interactions {
+		if r.used[i] || in.Request.Method != req.Method || in.Request.URL != req.URL.RequestURI() {
+			continue
+		}
+		r.used[i] = true
+		return newResponse(req, in.Response), nil
+	}
+	return nil, fmt.Errorf("apitest: no recorded response for %s %s in %s", req.Method, req.URL.RequestURI(), r.path)
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		if reqBody, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	in := Interaction{
+		Request: Request{
+			Method:  req.Method,
+			URL:     req.URL.RequestURI(),
+			Headers: keepHeaders(req.Header),
+			Body:    string(reqBody),
+		},
+		Response: Response{
+			Status:  resp.StatusCode,
+			Headers: keepHeaders(resp.Header),
+			Body:    string(respBody),
+		},
+	}
+	ScrubSecrets(&in)
+	for _, s := range r.Scrubbers {
+		s(&in)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, in)
+	if err := r.cassette.Save(r.path); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Unused returns the interactions of a replayed cassette that no request has
+// asked for yet, which helps tests assert that every recorded call was made.
+func (r *Recorder) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []Interaction
+	for i, in := range r.cassette.Interactions {
+		if i < len(r.used) && !r.used[i] {
+			out = append(out, in)
+		}
+	}
+	return out
+}
+
+// ScrubSecrets redacts secret-looking JSON fields in both bodies of in.
+// Recorders always apply it; it is exported for tools that edit cassettes.
+func ScrubSecrets(in *Interaction) {
+	in.Request.Body = scrubBody(in.Request.Body)
+	in.Response.Body = scrubBody(in.Response.Body)
+}
+
+func scrubBody(body string) string {
+	if body == "" {
+		return body
+	}
+	var v any
+	dec := json.NewDecoder(strings.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return body // not JSON; recorded as is
+	}
+	if !scrubValue(v) {
+		return body
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return string(data)
+}
+
+// scrubValue redacts secret string fields in place and reports whether it changed v.
+func scrubValue(v any) bool {
+	changed := false
+	switch t := v.(type) {
+	case map[string]any:
+		for k, val := range t {
+			// Only strings hold secrets; flags such as has_password stay usable.
+			if s, ok := val.(string); ok && isSecretField(k) {
+				if s != "" && s != Redacted {
+					t[k] = Redacted
+					changed = true
+				}
+				continue
+			}
+			changed = scrubValue(val) || changed
+		}
+	case []any:
+		for _, val := range t {
+			changed = scrubValue(val) || changed
+		}
+	}
+	return changed
+}
+
+func isSecretField(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range secretFields {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func keepHeaders(h http.Header) map[string]string {
+	var out map[string]string
+	for _, name := range keptHeaders {
+		if v := h.Get(name); v != "" {
+			if out == nil {
+				out = map[string]string{}
+			}
+			out[name] = v
+		}
+	}
+	return out
+}
+
+func newResponse(req *http.Request, r Response) *http.Response {
+	header := http.Header{}
+	for k, v := range r.Headers {
+		header.Set(k, v)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.Status, http.StatusText(r.Status)),
+		StatusCode:    r.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
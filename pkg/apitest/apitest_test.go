@@ -0,0 +1,139 @@
+package apitest
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ginsys/forward-email/internal/mockserver"
+	"github.com/ginsys/forward-email/pkg/api"
+	fe "github.com/ginsys/forward-email/pkg/errors"
+)
+
+func TestReplayGoldenCassette(t *testing.T) {
+	rec, err := New("testdata/domains.yaml", ModeReplay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := api.NewClient(api.WithAPIKey("test"), api.WithHTTPClient(rec.Client()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	resp, err := c.Domains.ListDomains(ctx, &api.ListDomainsOptions{Page: 1, Limit: 25})
+	if err != nil {
+		t.Fatalf("ListDomains: %v", err)
+	}
+	if len(resp.Domains) != 1 || resp.Domains[0].Name != "example.com" || !resp.Domains[0].IsVerified {
+		t.Errorf("unexpected domains: %+v", resp.Domains)
+	}
+
+	_, err = c.Domains.GetDomain(ctx, "missing.example")
+	var apiErr *fe.ForwardEmailError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected a recorded 404, got %v", err)
+	}
+	if unused := rec.Unused(); len(unused) != 0 {
+		t.Errorf("expected every interaction to be used, %d left", len(unused))
+	}
+
+	// Each interaction answers once; nothing else falls through to the network.
+	if _, err := c.Domains.GetDomain(ctx, "missing.example"); err == nil ||
+		!strings.Contains(err.Error(), "no recorded response for GET /v1/domains/missing.example") {
+		t.Errorf("expected an unmatched request error, got %v", err)
+	}
+}
+
+func TestRecordThenReplay(t *testing.T) {
+	seed, err := mockserver.ParseSeed([]byte(`
+domains:
+  - name: example.com
+    aliases:
+      - name: info
+        recipients: [me@example.org]
+`))
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	srv := httptest.NewServer(mockserver.New(seed))
+	path := filepath.Join(t.TempDir(), "cassettes", "aliases.yaml")
+	ctx := context.Background()
+
+	rec, err := New(path, ModeRecord, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	live, err := api.NewClient(api.WithBaseURL(srv.URL), api.WithAPIKey("live-secret-key"), api.WithHTTPClient(rec.Client()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorded, err := live.Aliases.ListAliases(ctx, &api.ListAliasesOptions{Domain: "example.com"})
+	if err != nil {
+		t.Fatalf("ListAliases: %v", err)
+	}
+	srv.Close()
+
+	data, err := os.ReadFile(path) //nolint:gosec // test file
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"live-secret-key", base64.StdEncoding.EncodeToString([]byte("live-secret-key:")), "Authorization"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette leaks %q:\n%s", secret, data)
+		}
+	}
+
+	// The server is gone; the replay answers from the cassette alone.
+	rec, err = New(path, ModeReplay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replay, err := api.NewClient(api.WithAPIKey("test"), api.WithHTTPClient(rec.Client()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayed, err := replay.Aliases.ListAliases(ctx, &api.ListAliasesOptions{Domain: "example.com"})
+	if err != nil {
+		t.Fatalf("replayed ListAliases: %v", err)
+	}
+	if len(replayed.Aliases) != len(recorded.Aliases) || replayed.Aliases[0].Name != "info" {
+		t.Errorf("replay differs from recording: %+v vs %+v", replayed.Aliases, recorded.Aliases)
+	}
+}
+
+func TestScrubSecrets(t *testing.T) {
+	in := Interaction{
+		Request:  Request{Body: `{"name":"info","new_password":"hunter2"}`},
+		Response: Response{Body: `{"items":[{"api_token":"abc","has_password":true}],"note":"plain"}`},
+	}
+	ScrubSecrets(&in)
+	if in.Request.Body != `{"name":"info","new_password":"REDACTED"}` {
+		t.Errorf("request body not scrubbed: %s", in.Request.Body)
+	}
+	if in.Response.Body != `{"items":[{"api_token":"REDACTED","has_password":true}],"note":"plain"}` {
+		t.Errorf("response body not scrubbed: %s", in.Response.Body)
+	}
+
+	// Bodies without secrets, or that are not JSON, are kept byte for byte.
+	in = Interaction{Request: Request{Body: `{ "name": "info" }`}, Response: Response{Body: "not json"}}
+	ScrubSecrets(&in)
+	if in.Request.Body != `{ "name": "info" }` || in.Response.Body != "not json" {
+		t.Errorf("unexpected rewrite: %+v", in)
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	if m, err := ParseMode("Record"); err != nil || m != ModeRecord {
+		t.Errorf("ParseMode(Record) = %q, %v", m, err)
+	}
+	if _, err := ParseMode("rewind"); err == nil {
+		t.Error("expected an error for an unknown mode")
+	}
+}
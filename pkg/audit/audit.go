@@ -0,0 +1,204 @@
+// Package audit checks a domain's settings against a best-practice baseline
+// and scores the result, so that an organisation can keep every domain it
+// manages configured the same way.
+//
+// A baseline file is YAML (or JSON); rules that are left out are not checked:
+//
+//	protections:           # spam filters that must be on
+//	  - phishing
+//	  - executable
+//	  - virus
+//	  - adult_content
+//	require_dmarc: true    # a DMARC record must be published
+//	require_spf: true      # an SPF record must be published
+//	min_dkim_bits: 2048    # DKIM key length
+//	retention_days:        # bounds for the retention period
+//	  min: 7
+//	  max: 30
+//	https_webhooks: true   # webhook URLs must use HTTPS
+package audit
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ginsys/forward-email/pkg/api"
+)
+
+// Protections lists the names accepted in a baseline's protections.
+var Protections = []string{"adult_content", "phishing", "executable", "virus"}
+
+// Baseline is a set of rules a domain should satisfy.
+type Baseline struct {
+	Protections   []string `yaml:"protections,omitempty" json:"protections,omitempty"`
+	RequireDMARC  bool     `yaml:"require_dmarc,omitempty" json:"require_dmarc,omitempty"`
+	RequireSPF    bool     `yaml:"require_spf,omitempty" json:"require_spf,omitempty"`
+	MinDKIMBits   int      `yaml:"min_dkim_bits,omitempty" json:"min_dkim_bits,omitempty"`
+	Retention     *Range   `yaml:"retention_days,omitempty" json:"retention_days,omitempty"`
+	HTTPSWebhooks bool     `yaml:"https_webhooks,omitempty" json:"https_webhooks,omitempty"`
+}
+
+// Range bounds a number of days; a zero Max means no upper bound.
+type Range struct {
+	Min int `yaml:"min,omitempty" json:"min,omitempty"`
+	Max int `yaml:"max,omitempty" json:"max,omitempty"`
+}
+
+// Default returns the built-in best-practice baseline.
+func Default() *Baseline {
+	return &Baseline{
+		Protections:   append([]string(nil), Protections...),
+		RequireDMARC:  true,
+		RequireSPF:    true,
+		MinDKIMBits:   2048,
+		Retention:     &Range{Min: 1, Max: 30},
+		HTTPSWebhooks: true,
+	}
+}
+
+// Load reads and parses the baseline file at path.
+func Load(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // the baseline path is given by the user
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline file: %w", err)
+	}
+	b, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid baseline file %s: %w", path, err)
+	}
+	return b, nil
+}
+
+// Parse parses a YAML or JSON baseline.
+func Parse(data []byte) (*Baseline, error) {
+	var b Baseline
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&b); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	for i, p := range b.Protections {
+		p = strings.ToLower(strings.TrimSpace(p))
+		if !slices.Contains(Protections, p) {
+			return nil, fmt.Errorf("unknown protection %q (supported: %s)", p, strings.Join(Protections, ", "))
+		}
+		b.Protections[i] = p
+	}
+	if r := b.Retention; r != nil && r.Max > 0 && r.Min > r.Max {
+		return nil, fmt.Errorf("retention_days: min %d is above max %d", r.Min, r.Max)
+	}
+	return &b, nil
+}
+
+// Result is the outcome of one baseline rule.
+type Result struct {
+	Rule   string `json:"rule" yaml:"rule"`
+	Passed bool   `json:"passed" yaml:"passed"`
+	Detail string `json:"detail" yaml:"detail"` // what was found
+}
+
+// Report is a domain's compliance with a baseline. Score is the percentage of
+// rules passed; a baseline without rules scores 100.
+type Report struct {
+	Domain  string   `json:"domain" yaml:"domain"`
+	Score   int      `json:"score" yaml:"score"`
+	Passed  int      `json:"passed" yaml:"passed"`
+	Total   int      `json:"total" yaml:"total"`
+	Results []Result `json:"results" yaml:"results"`
+}
+
+// Evaluate checks d against the baseline.
+func (b *Baseline) Evaluate(d *api.Domain) *Report {
+	r := &Report{Domain: d.Name, Results: []Result{}}
+	add := func(rule string, passed bool, detail string) {
+		r.Results = append(r.Results, Result{Rule: rule, Passed: passed, Detail: detail})
+	}
+	settings := d.Settings
+	if settings == nil {
+		settings = &api.DomainSettings{}
+	}
+
+	for _, p := range b.Protections {
+		on := map[string]bool{
+			"adult_content": settings.HasAdultContentProtection,
+			"phishing":      settings.HasPhishingProtection,
+			"executable":    settings.HasExecutableProtection,
+			"virus":         settings.HasVirusProtection,
+		}[p]
+		add("protection:"+p, on, onOff(on))
+	}
+	if b.RequireDMARC {
+		add("dmarc", d.HasDMARCRecord, found(d.HasDMARCRecord, "DMARC record"))
+	}
+	if b.RequireSPF {
+		add("spf", d.HasSPFRecord, found(d.HasSPFRecord, "SPF record"))
+	}
+	if b.MinDKIMBits > 0 {
+		switch {
+		case !d.HasDKIMRecord:
+			add("dkim", false, "no DKIM record")
+		case d.DKIMModulusLength < b.MinDKIMBits:
+			add("dkim", false, fmt.Sprintf("%d-bit key, below %d", d.DKIMModulusLength, b.MinDKIMBits))
+		default:
+			add("dkim", true, fmt.Sprintf("%d-bit key", d.DKIMModulusLength))
+		}
+	}
+	if rng := b.Retention; rng != nil {
+		days := d.RetentionDays
+		passed := days >= rng.Min && (rng.Max == 0 || days <= rng.Max)
+		bounds := fmt.Sprintf("at least %d", rng.Min)
+		if rng.Max > 0 {
+			bounds = fmt.Sprintf("%d-%d", rng.Min, rng.Max)
+		}
+		add("retention", passed, fmt.Sprintf("%d days (allowed: %s)", days, bounds))
+	}
+	if b.HTTPSWebhooks {
+		var insecure []string
+		for _, u := range []string{settings.WebhookURL, d.BounceWebhook} {
+			if u != "" && !strings.HasPrefix(strings.ToLower(u), "https://") {
+				insecure = append(insecure, u)
+			}
+		}
+		switch {
+		case len(insecure) > 0:
+			add("webhook-https", false, "not HTTPS: "+strings.Join(insecure, ", "))
+		case settings.WebhookURL == "" && d.BounceWebhook == "":
+			add("webhook-https", true, "no webhooks")
+		default:
+			add("webhook-https", true, "HTTPS")
+		}
+	}
+
+	r.Total = len(r.Results)
+	for _, res := range r.Results {
+		if res.Passed {
+			r.Passed++
+		}
+	}
+	r.Score = 100
+	if r.Total > 0 {
+		r.Score = r.Passed * 100 / r.Total
+	}
+	return r
+}
+
+func onOff(on bool) string {
+	if on {
+		return "enabled"
+	}
+	return "disabled"
+}
+
+func found(ok bool, what string) string {
+	if ok {
+		return what + " found"
+	}
+	return "no " + what
+}
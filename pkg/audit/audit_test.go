@@ -0,0 +1,94 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ginsys/forward-email/pkg/api"
+)
+
+func TestDefault_Evaluate(t *testing.T) {
+	d := &api.Domain{
+		Name:              "example.com",
+		HasDMARCRecord:    true,
+		HasDKIMRecord:     true,
+		DKIMModulusLength: 1024,
+		RetentionDays:     90,
+		BounceWebhook:     "http://hooks.example.com/bounce",
+		Settings: &api.DomainSettings{
+			HasPhishingProtection: true, HasExecutableProtection: true, HasVirusProtection: true,
+			WebhookURL: "https://hooks.example.com/mail",
+		},
+	}
+	r := Default().Evaluate(d)
+
+	var got []string
+	for _, res := range r.Results {
+		status := "FAIL"
+		if res.Passed {
+			status = "PASS"
+		}
+		got = append(got, res.Rule+" "+status+" "+res.Detail)
+	}
+	want := []string{
+		"protection:adult_content FAIL disabled",
+		"protection:phishing PASS enabled",
+		"protection:executable PASS enabled",
+		"protection:virus PASS enabled",
+		"dmarc PASS DMARC record found",
+		"spf FAIL no SPF record",
+		"dkim FAIL 1024-bit key, below 2048",
+		"retention FAIL 90 days (allowed: 1-30)",
+		"webhook-https FAIL not HTTPS: http://hooks.example.com/bounce",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("results:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if r.Passed != 4 || r.Total != 9 || r.Score != 44 {
+		t.Errorf("score = %d (%d/%d), want 44 (4/9)", r.Score, r.Passed, r.Total)
+	}
+}
+
+func TestParse(t *testing.T) {
+	b, err := Parse([]byte("protections: [Virus]\nmin_dkim_bits: 2048\nretention_days: {min: 7}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := b.Evaluate(&api.Domain{Name: "example.com", HasDKIMRecord: true, DKIMModulusLength: 2048, RetentionDays: 365})
+	if r.Total != 3 || r.Score != 66 {
+		t.Errorf("expected 2 of 3 rules to pass, got %+v", r)
+	}
+	if r.Results[2].Detail != "365 days (allowed: at least 7)" {
+		t.Errorf("unexpected retention detail %q", r.Results[2].Detail)
+	}
+
+	if r := (&Baseline{}).Evaluate(&api.Domain{}); r.Score != 100 || r.Total != 0 {
+		t.Errorf("expected an empty baseline to score 100, got %+v", r)
+	}
+
+	for _, bad := range []string{
+		"protections: [spam]\n",
+		"retention_days: {min: 30, max: 7}\n",
+		"require_dkim: true\n",
+	} {
+		if _, err := Parse([]byte(bad)); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.yaml")
+	if err := os.WriteFile(path, []byte("require_spf: true\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	b, err := Load(path)
+	if err != nil || !b.RequireSPF {
+		t.Fatalf("Load() = %+v, %v", b, err)
+	}
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
 	"strings"
@@ -219,7 +220,7 @@ func (f *ForwardEmailAuth) DeleteAPIKey() error {
 	if f.keyring != nil {
 		if err := f.keyring.DeleteAPIKey(f.profile); err != nil {
 			// Don't fail if key doesn't exist in keyring
-			fmt.Printf("Warning: failed to delete API key from keyring: %v\n", err)
+			slog.Warn("failed to delete API key from keyring", "profile", f.profile, "error", err)
 		}
 	}
 
@@ -273,3 +274,35 @@ func (m *mockAuth) GetAPIKey() (string, error) {
 	}
 	return m.apiKey, nil
 }
+
+// StaticProvider returns a provider that authenticates every request with a fixed
+// API key. It is the simplest way to authenticate when using pkg/api as a library.
+func StaticProvider(apiKey string) Provider {
+	return &staticAuth{apiKey: apiKey}
+}
+
+type staticAuth struct {
+	apiKey string
+}
+
+func (s *staticAuth) Apply(req *http.Request) error {
+	if s.apiKey == "" {
+		return fmt.Errorf("API key is empty")
+	}
+	req.SetBasicAuth(s.apiKey, "")
+	return nil
+}
+
+func (s *staticAuth) Validate(_ context.Context) error {
+	if s.apiKey == "" {
+		return fmt.Errorf("API key is empty")
+	}
+	return nil
+}
+
+func (s *staticAuth) GetAPIKey() (string, error) {
+	if s.apiKey == "" {
+		return "", fmt.Errorf("API key is empty")
+	}
+	return s.apiKey, nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/viper"
 )
@@ -17,6 +18,12 @@ type Config struct {
 	Profiles map[string]Profile `yaml:"profiles" mapstructure:"profiles"`
 	// Name of the currently active profile
 	CurrentProfile string `yaml:"current_profile" mapstructure:"current_profile"`
+	// Telemetry opts in to sending anonymous usage events: the command name
+	// and its duration. It is off unless enabled.
+	Telemetry bool `yaml:"telemetry,omitempty" mapstructure:"telemetry"`
+	// Defaults holds flag values per command, keyed by the command path, e.g.
+	// defaults.alias.list.limit. Flags given on the command line win.
+	Defaults map[string]any `yaml:"defaults,omitempty" mapstructure:"defaults"`
 }
 
 // Profile represents a configuration profile for a specific Forward Email account or environment.
@@ -29,6 +36,83 @@ type Profile struct {
 	Password string `yaml:"password" mapstructure:"password"` // Password (legacy, not used)
 	Timeout  string `yaml:"timeout" mapstructure:"timeout"`   // Request timeout duration
 	Output   string `yaml:"output" mapstructure:"output"`     // Default output format (table/json/yaml/csv)
+
+	DefaultDomain string   `yaml:"default_domain,omitempty" mapstructure:"default_domain"` // Domain used when a command omits one
+	Notify        []string `yaml:"notify,omitempty" mapstructure:"notify"`                 // Notification URLs for mutating commands
+
+	// DomainDefaults is applied to every domain created with this profile. It takes
+	// the fields of a 'domain update -f' patch file plus an optional plan.
+	DomainDefaults map[string]any `yaml:"domain_defaults,omitempty" mapstructure:"domain_defaults"`
+
+	AliasPolicy   string            `yaml:"alias_policy,omitempty" mapstructure:"alias_policy"`     // Naming policy file checked by alias create/import
+	AuditBaseline string            `yaml:"audit_baseline,omitempty" mapstructure:"audit_baseline"` // Baseline file checked by domain audit
+	Hooks         map[string]string `yaml:"hooks,omitempty" mapstructure:"hooks"`                   // Commands run before/after commands, e.g. pre_delete
+
+	// Defaults holds flag values per command like Config.Defaults, and
+	// overrides them for this profile.
+	Defaults map[string]any `yaml:"defaults,omitempty" mapstructure:"defaults"`
+
+	// EmailLimits replaces the built-in outbound email limits checked before
+	// sending, where the API does not report its own.
+	EmailLimits *EmailLimits `yaml:"email_limits,omitempty" mapstructure:"email_limits"`
+
+	// DNSRecords replaces the mail servers and SPF include that 'domain dns'
+	// and 'domain verify --nameserver' expect, for self-hosted deployments.
+	DNSRecords *DNSRecords `yaml:"dns_records,omitempty" mapstructure:"dns_records"`
+
+	// DomainTags holds client-side tags such as customer:acme, used to group
+	// and filter domains. It is a list rather than a map keyed by domain
+	// because viper splits map keys on dots.
+	DomainTags []DomainTags `yaml:"domain_tags,omitempty" mapstructure:"domain_tags"`
+}
+
+// EmailLimits are outbound email limits. Sizes take units, e.g. 25MB.
+type EmailLimits struct {
+	MaxMessageSize    string `yaml:"max_message_size,omitempty" mapstructure:"max_message_size"`
+	MaxAttachmentSize string `yaml:"max_attachment_size,omitempty" mapstructure:"max_attachment_size"`
+	MaxRecipients     int    `yaml:"max_recipients,omitempty" mapstructure:"max_recipients"`
+}
+
+// DNSRecords are the expected MX hosts, in order of preference, and the
+// domain named by the SPF include. Empty fields keep the hosted service's.
+type DNSRecords struct {
+	MXHosts    []string `yaml:"mx_hosts,omitempty" mapstructure:"mx_hosts"`
+	SPFInclude string   `yaml:"spf_include,omitempty" mapstructure:"spf_include"`
+}
+
+// DomainTags are the tags of one domain.
+type DomainTags struct {
+	Domain string   `json:"domain" yaml:"domain" mapstructure:"domain"` // lowercase domain name
+	Tags   []string `json:"tags" yaml:"tags" mapstructure:"tags"`
+}
+
+// TagsFor returns the tags of domain, matched case-insensitively.
+func (p *Profile) TagsFor(domain string) []string {
+	for _, dt := range p.DomainTags {
+		if strings.EqualFold(dt.Domain, domain) {
+			return dt.Tags
+		}
+	}
+	return nil
+}
+
+// SetTags replaces the tags of domain, removing its entry when tags is empty.
+func (p *Profile) SetTags(domain string, tags []string) {
+	domain = strings.ToLower(domain)
+	for i, dt := range p.DomainTags {
+		if dt.Domain != domain {
+			continue
+		}
+		if len(tags) == 0 {
+			p.DomainTags = append(p.DomainTags[:i], p.DomainTags[i+1:]...)
+		} else {
+			p.DomainTags[i].Tags = tags
+		}
+		return
+	}
+	if len(tags) > 0 {
+		p.DomainTags = append(p.DomainTags, DomainTags{Domain: domain, Tags: tags})
+	}
 }
 
 // Load loads the complete application configuration from file and environment variables.
@@ -102,6 +186,7 @@ func (c *Config) Save() error {
 	// Set the values in viper
 	viper.Set("current_profile", c.CurrentProfile)
 	viper.Set("profiles", c.Profiles)
+	viper.Set("telemetry", c.Telemetry)
 
 	// Write config file
 	if err := viper.WriteConfigAs(configPath); err != nil {
@@ -156,6 +241,45 @@ func (c *Config) ListProfiles() []string {
 	return profiles
 }
 
+// CommandDefaults returns the default flag values of the command at path,
+// e.g. ["alias", "list"], for profile: the config-wide ones overridden by the
+// profile's. Keys are flag names.
+func (c *Config) CommandDefaults(profile string, path []string) map[string]any {
+	values := map[string]any{}
+	for _, defaults := range []map[string]any{c.Defaults, c.Profiles[profile].Defaults} {
+		for name, value := range commandDefaults(defaults, path) {
+			values[name] = value
+		}
+	}
+	return values
+}
+
+// commandDefaults walks defaults down path and returns the flag values found
+// there. Nested maps are subcommands, not flags, and are left out.
+func commandDefaults(defaults map[string]any, path []string) map[string]any {
+	node := defaults
+	for _, name := range path {
+		next, ok := node[name].(map[string]any)
+		if !ok {
+			return nil
+		}
+		node = next
+	}
+	values := make(map[string]any, len(node))
+	for name, value := range node {
+		if _, sub := value.(map[string]any); !sub {
+			values[name] = value
+		}
+	}
+	return values
+}
+
+// Dir returns the directory holding the configuration file and other
+// per-user state such as operation journals.
+func Dir() (string, error) {
+	return getConfigDir()
+}
+
 // getConfigDir returns the configuration directory
 func getConfigDir() (string, error) {
 	configDir := os.Getenv("XDG_CONFIG_HOME")
@@ -561,3 +561,74 @@ func containsString(haystack, needle string) bool {
 		(haystack[0:len(needle)] == needle ||
 			(len(haystack) > len(needle) && containsString(haystack[1:], needle)))
 }
+
+func TestConfig_SaveDomainTags(t *testing.T) {
+	testutil.ResetViper()
+	testutil.SetupTempConfig(t)
+
+	config := &Config{
+		CurrentProfile: "test",
+		Profiles: map[string]Profile{
+			"test": {DomainTags: []DomainTags{{Domain: "example.com", Tags: []string{"customer:acme", "env:prod"}}}},
+		},
+	}
+	if err := config.Save(); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+
+	testutil.ResetViper()
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Failed to load saved config: %v", err)
+	}
+	profile := loaded.Profiles["test"]
+	tags := profile.TagsFor("Example.com")
+	if len(tags) != 2 || tags[0] != "customer:acme" || tags[1] != "env:prod" {
+		t.Errorf("domain tags did not round-trip: %v", loaded.Profiles["test"].DomainTags)
+	}
+}
+
+func TestProfile_SetTags(t *testing.T) {
+	var p Profile
+	p.SetTags("Example.com", []string{"env:prod"})
+	p.SetTags("example.org", []string{"env:dev"})
+	p.SetTags("example.com", []string{"env:prod", "customer:acme"})
+	if len(p.DomainTags) != 2 || p.DomainTags[0].Domain != "example.com" || len(p.DomainTags[0].Tags) != 2 {
+		t.Errorf("unexpected domain tags %+v", p.DomainTags)
+	}
+	p.SetTags("EXAMPLE.COM", nil)
+	if len(p.DomainTags) != 1 || p.TagsFor("example.com") != nil {
+		t.Errorf("expected example.com to be removed, got %+v", p.DomainTags)
+	}
+}
+
+func TestConfig_CommandDefaults(t *testing.T) {
+	cfg := &Config{
+		Defaults: map[string]any{
+			"alias": map[string]any{
+				"limit": 5,
+				"list":  map[string]any{"limit": 100, "order": "desc"},
+			},
+		},
+		Profiles: map[string]Profile{
+			"prod": {Defaults: map[string]any{
+				"alias": map[string]any{"list": map[string]any{"limit": 10}},
+			}},
+		},
+	}
+
+	got := cfg.CommandDefaults("", []string{"alias", "list"})
+	if len(got) != 2 || got["limit"] != 100 || got["order"] != "desc" {
+		t.Errorf("unexpected config-wide defaults %v", got)
+	}
+	got = cfg.CommandDefaults("prod", []string{"alias", "list"})
+	if len(got) != 2 || got["limit"] != 10 || got["order"] != "desc" {
+		t.Errorf("expected the profile to override limit only, got %v", got)
+	}
+	if got = cfg.CommandDefaults("prod", []string{"alias"}); len(got) != 1 || got["limit"] != 5 {
+		t.Errorf("expected subcommands to be left out, got %v", got)
+	}
+	if got = cfg.CommandDefaults("prod", []string{"domain", "list"}); len(got) != 0 {
+		t.Errorf("expected no defaults, got %v", got)
+	}
+}
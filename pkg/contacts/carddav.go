@@ -0,0 +1,186 @@
+package contacts
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	neturl "net/url"
+	"strings"
+	"time"
+)
+
+// SourceCardDAV marks the contacts synced from a CardDAV address book.
+const SourceCardDAV = "carddav"
+
+// DefaultTimeout bounds a CardDAV download when FetchCardDAV is given no
+// client.
+const DefaultTimeout = 2 * time.Minute
+
+// addressbookQuery asks a CardDAV server for every vCard in an address book
+// (RFC 6352, section 8.6).
+const addressbookQuery = `<?xml version="1.0" encoding="utf-8"?>
+<C:addressbook-query xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:carddav">
+  <D:prop><D:getetag/><C:address-data/></D:prop>
+</C:addressbook-query>`
+
+type multistatus struct {
+	Responses []struct {
+		Propstats []struct {
+			Status      string `xml:"DAV: status"`
+			AddressData string `xml:"prop>address-data"` // in the CardDAV namespace
+		} `xml:"DAV: propstat"`
+	} `xml:"DAV: response"`
+}
+
+// CheckURL rejects address book URLs that the password must not be sent to:
+// anything but https, and plain http unless allowHTTP is set.
+func CheckURL(rawURL string, allowHTTP bool) error {
+	u, err := neturl.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("invalid CardDAV URL %q", rawURL)
+	}
+	switch u.Scheme {
+	case "https":
+		return nil
+	case "http":
+		if allowHTTP {
+			return nil
+		}
+		return fmt.Errorf("refusing to send the CardDAV password over plain http to %s; use https or --allow-http", u.Host)
+	default:
+		return fmt.Errorf("invalid CardDAV URL %q: scheme must be https", rawURL)
+	}
+}
+
+// FetchCardDAV downloads the vCards of the address book at url, logging in
+// with user and password, and returns them as contacts. A nil client uses
+// one bounded by DefaultTimeout.
+func FetchCardDAV(ctx context.Context, client *http.Client, url, user, password string) ([]Contact, error) {
+	if client == nil {
+		client = &http.Client{Timeout: DefaultTimeout}
+	}
+	req, err := http.NewRequestWithContext(ctx, "REPORT", url, strings.NewReader(addressbookQuery))
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(user, password)
+	req.Header.Set("Depth", "1")
+	req.Header.Set("Content-Type", "application/xml; charset=utf-8")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query address book: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusMultiStatus {
+		return nil, fmt.Errorf("failed to query address book: %s", resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read address book: %w", err)
+	}
+
+	var ms multistatus
+	if err := xml.Unmarshal(body, &ms); err != nil {
+		return nil, fmt.Errorf("invalid address book response: %w", err)
+	}
+	var cards []string
+	for _, r := range ms.Responses {
+		for _, ps := range r.Propstats {
+			if strings.Contains(ps.Status, " 200 ") && strings.TrimSpace(ps.AddressData) != "" {
+				cards = append(cards, ps.AddressData)
+			}
+		}
+	}
+	return ParseVCards(strings.Join(cards, "\n")), nil
+}
+
+// ParseVCards returns a contact for each vCard in data that has an email
+// address. The nickname is the card's NICKNAME, else the local part of the
+// address; the display name is its FN. Of several addresses the preferred
+// one is used.
+func ParseVCards(data string) []Contact {
+	var out []Contact
+	var card map[string][]vcardProperty
+	for _, line := range unfoldLines(data) {
+		name, params, value := parseVCardLine(line)
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VCARD"):
+			card = map[string][]vcardProperty{}
+		case name == "END" && strings.EqualFold(value, "VCARD"):
+			if c, ok := cardContact(card); ok {
+				out = append(out, c)
+			}
+			card = nil
+		case card != nil:
+			card[name] = append(card[name], vcardProperty{params: params, value: value})
+		}
+	}
+	return out
+}
+
+type vcardProperty struct {
+	params string
+	value  string
+}
+
+func cardContact(card map[string][]vcardProperty) (Contact, bool) {
+	emails := card["EMAIL"]
+	if len(emails) == 0 {
+		return Contact{}, false
+	}
+	email := emails[0]
+	for _, e := range emails {
+		if p := strings.ToUpper(e.params); strings.Contains(p, "PREF") {
+			email = e
+			break
+		}
+	}
+	addr := &mail.Address{Address: strings.TrimPrefix(email.value, "mailto:")}
+	if fn := card["FN"]; len(fn) > 0 {
+		addr.Name = fn[0].value
+	}
+
+	nickname := ""
+	if n := card["NICKNAME"]; len(n) > 0 {
+		nickname, _, _ = strings.Cut(n[0].value, ",")
+	}
+	if nickname == "" {
+		nickname, _, _ = strings.Cut(addr.Address, "@")
+	}
+	nickname = strings.ToLower(strings.Join(strings.Fields(nickname), "-"))
+	return Contact{Name: nickname, Address: formatAddress(addr)}, true
+}
+
+// unfoldLines splits a vCard stream into logical lines, joining the
+// continuation lines that start with a space or tab.
+func unfoldLines(data string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n") {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// parseVCardLine splits "item1.EMAIL;TYPE=work:bob@example.com" into the
+// upper-case property name without its group, its parameters and its
+// unescaped value.
+func parseVCardLine(line string) (name, params, value string) {
+	head, value, ok := strings.Cut(line, ":")
+	if !ok {
+		return "", "", ""
+	}
+	name, params, _ = strings.Cut(head, ";")
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		name = name[i+1:]
+	}
+	value = strings.NewReplacer(`\,`, ",", `\;`, ";", `\n`, " ", `\N`, " ", `\\`, `\`).Replace(strings.TrimSpace(value))
+	return strings.ToUpper(strings.TrimSpace(name)), params, value
+}
@@ -0,0 +1,298 @@
+// Package contacts is a local address book for the recipients of outgoing
+// email, so that addresses are typed once instead of on every send.
+//
+// A contact is a nickname for one address, which may carry a display name.
+// A group, written with a leading @ such as @team, is a list of contacts,
+// other groups and plain addresses. The book is stored as YAML; contacts
+// synced from a CardDAV address book are marked with their source, so that a
+// later sync replaces them without touching contacts added by hand.
+package contacts
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Contact is a nickname for an address.
+type Contact struct {
+	Name    string `json:"name" yaml:"name"`
+	Address string `json:"address" yaml:"address"`                   // with an optional display name
+	Source  string `json:"source,omitempty" yaml:"source,omitempty"` // where a synced contact came from, e.g. carddav
+}
+
+// Group is a named list of recipients. Members are contact nicknames,
+// @groups or addresses.
+type Group struct {
+	Name    string   `json:"name" yaml:"name"` // without the leading @
+	Members []string `json:"members" yaml:"members"`
+}
+
+// Book holds the contacts and groups, each sorted by name.
+type Book struct {
+	Contacts []Contact `json:"contacts" yaml:"contacts"`
+	Groups   []Group   `json:"groups" yaml:"groups"`
+}
+
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._+-]*$`)
+
+// ValidName reports whether name, without a leading @ for groups, can name
+// a contact or group: lowercase letters, digits and . _ + -, so that it can
+// never be mistaken for an address.
+func ValidName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid name %q: use lowercase letters, digits, '.', '_', '+' and '-'", name)
+	}
+	return nil
+}
+
+// IsReference reports whether an entry of an address list refers to the
+// book, as @group or as a contact nickname, rather than being an address.
+func IsReference(entry string) bool {
+	if group, ok := strings.CutPrefix(entry, "@"); ok {
+		return ValidName(strings.ToLower(group)) == nil
+	}
+	return ValidName(strings.ToLower(entry)) == nil
+}
+
+// Load reads the book at path. A missing file is an empty book.
+func Load(path string) (*Book, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // the book lives in the config directory
+	if errors.Is(err, os.ErrNotExist) {
+		return &Book{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read contacts: %w", err)
+	}
+	var b Book
+	if err := yaml.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("failed to parse contacts %s: %w", path, err)
+	}
+	return &b, nil
+}
+
+// Save writes the book to path, readable by the owner only.
+func (b *Book) Save(path string) error {
+	b.sort()
+	data, err := yaml.Marshal(b)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create contacts directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to save contacts: %w", err)
+	}
+	return nil
+}
+
+func (b *Book) sort() {
+	sort.Slice(b.Contacts, func(i, j int) bool { return b.Contacts[i].Name < b.Contacts[j].Name })
+	sort.Slice(b.Groups, func(i, j int) bool { return b.Groups[i].Name < b.Groups[j].Name })
+}
+
+// Contact returns the contact named name.
+func (b *Book) Contact(name string) (*Contact, bool) {
+	i := slices.IndexFunc(b.Contacts, func(c Contact) bool { return c.Name == strings.ToLower(name) })
+	if i < 0 {
+		return nil, false
+	}
+	return &b.Contacts[i], true
+}
+
+// Group returns the group named name, with or without its leading @.
+func (b *Book) Group(name string) (*Group, bool) {
+	name = strings.ToLower(strings.TrimPrefix(name, "@"))
+	i := slices.IndexFunc(b.Groups, func(g Group) bool { return g.Name == name })
+	if i < 0 {
+		return nil, false
+	}
+	return &b.Groups[i], true
+}
+
+// AddContact adds c, or replaces the contact of the same name when replace
+// is set.
+func (b *Book) AddContact(c Contact, replace bool) error {
+	c.Name = strings.ToLower(c.Name)
+	if err := ValidName(c.Name); err != nil {
+		return err
+	}
+	addr, err := mail.ParseAddress(c.Address)
+	if err != nil {
+		return fmt.Errorf("invalid address %q: %s", c.Address, strings.TrimPrefix(err.Error(), "mail: "))
+	}
+	c.Address = formatAddress(addr)
+	if existing, ok := b.Contact(c.Name); ok {
+		if !replace {
+			return fmt.Errorf("contact %s already exists (%s)", c.Name, existing.Address)
+		}
+		*existing = c
+		return nil
+	}
+	b.Contacts = append(b.Contacts, c)
+	b.sort()
+	return nil
+}
+
+// AddToGroup adds members to the group named name, creating it. Members
+// must be addresses or name existing contacts and groups; ones already in
+// the group are skipped. Nothing changes when a member is invalid.
+func (b *Book) AddToGroup(name string, members ...string) error {
+	name = strings.ToLower(strings.TrimPrefix(name, "@"))
+	if err := ValidName(name); err != nil {
+		return err
+	}
+	g, ok := b.Group(name)
+	if !ok {
+		g = &Group{Name: name}
+	}
+	updated := *g
+	updated.Members = slices.Clone(g.Members)
+	for _, m := range members {
+		if IsReference(m) {
+			m = strings.ToLower(m)
+			if m == "@"+name {
+				return fmt.Errorf("group @%s cannot contain itself", name)
+			}
+			if _, err := b.Expand(m); err != nil {
+				return err
+			}
+		} else {
+			addr, err := mail.ParseAddress(m)
+			if err != nil {
+				return fmt.Errorf("invalid member %q: not an address, contact or @group", m)
+			}
+			m = formatAddress(addr)
+		}
+		if !slices.Contains(updated.Members, m) {
+			updated.Members = append(updated.Members, m)
+		}
+	}
+
+	// A group may not end up containing itself through another group
+	check := &Book{Contacts: b.Contacts, Groups: slices.DeleteFunc(slices.Clone(b.Groups), func(o Group) bool { return o.Name == name })}
+	check.Groups = append(check.Groups, updated)
+	if _, err := check.Expand("@" + name); err != nil {
+		return err
+	}
+	if ok {
+		*g = updated
+		return nil
+	}
+	b.Groups = append(b.Groups, updated)
+	b.sort()
+	return nil
+}
+
+// Remove deletes the contact or @group named name, and drops it from the
+// groups it was a member of, which are returned.
+func (b *Book) Remove(name string) ([]string, error) {
+	name = strings.ToLower(name)
+	if group, ok := strings.CutPrefix(name, "@"); ok {
+		if _, found := b.Group(group); !found {
+			return nil, fmt.Errorf("group @%s not found", group)
+		}
+		b.Groups = slices.DeleteFunc(b.Groups, func(g Group) bool { return g.Name == group })
+	} else {
+		if _, found := b.Contact(name); !found {
+			return nil, fmt.Errorf("contact %s not found", name)
+		}
+		b.Contacts = slices.DeleteFunc(b.Contacts, func(c Contact) bool { return c.Name == name })
+	}
+
+	var from []string
+	for i := range b.Groups {
+		g := &b.Groups[i]
+		if slices.Contains(g.Members, name) {
+			g.Members = slices.DeleteFunc(g.Members, func(m string) bool { return m == name })
+			from = append(from, "@"+g.Name)
+		}
+	}
+	return from, nil
+}
+
+// Expand resolves a contact nickname or @group to its addresses, in order
+// and without duplicates. Groups are expanded recursively.
+func (b *Book) Expand(ref string) ([]string, error) {
+	var out []string
+	seen := map[string]bool{}
+	err := b.expand(strings.ToLower(ref), nil, func(addr string) {
+		if key := addressKey(addr); !seen[key] {
+			seen[key] = true
+			out = append(out, addr)
+		}
+	})
+	return out, err
+}
+
+func (b *Book) expand(ref string, path []string, emit func(string)) error {
+	if !IsReference(ref) {
+		emit(ref)
+		return nil
+	}
+	if slices.Contains(path, ref) {
+		return fmt.Errorf("group %s contains itself (%s)", ref, strings.Join(append(path, ref), " → "))
+	}
+	group, isGroup := strings.CutPrefix(ref, "@")
+	if !isGroup {
+		c, ok := b.Contact(ref)
+		if !ok {
+			return fmt.Errorf("unknown contact %s", ref)
+		}
+		emit(c.Address)
+		return nil
+	}
+	g, ok := b.Group(group)
+	if !ok {
+		return fmt.Errorf("unknown group %s", ref)
+	}
+	for _, m := range g.Members {
+		if err := b.expand(m, append(path, ref), emit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Sync replaces the contacts from source with synced. Contacts added by
+// hand keep their names, so synced contacts that clash with them are
+// skipped and returned.
+func (b *Book) Sync(source string, synced []Contact) (added int, skipped []Contact) {
+	b.Contacts = slices.DeleteFunc(b.Contacts, func(c Contact) bool { return c.Source == source })
+	for _, c := range synced {
+		c.Source = source
+		if err := b.AddContact(c, false); err != nil {
+			skipped = append(skipped, c)
+			continue
+		}
+		added++
+	}
+	return added, skipped
+}
+
+// formatAddress renders addr like an address list entry: the bare address
+// without a display name, else the quoted name and the address.
+func formatAddress(addr *mail.Address) string {
+	if addr.Name == "" {
+		return addr.Address
+	}
+	return addr.String()
+}
+
+// addressKey identifies the mailbox of an address entry, ignoring its
+// display name and case.
+func addressKey(entry string) string {
+	if addr, err := mail.ParseAddress(entry); err == nil {
+		return strings.ToLower(addr.Address)
+	}
+	return strings.ToLower(entry)
+}
@@ -0,0 +1,175 @@
+package contacts
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBook(t *testing.T) {
+	b := &Book{}
+	for name, addr := range map[string]string{
+		"alice": "Alice Smith <alice@example.com>",
+		"Bob":   "bob@example.com",
+	} {
+		if err := b.AddContact(Contact{Name: name, Address: addr}, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := b.AddContact(Contact{Name: "bob", Address: "bobby@example.com"}, false); err == nil ||
+		!strings.Contains(err.Error(), "already exists") {
+		t.Errorf("expected a duplicate to be refused, got %v", err)
+	}
+	for _, name := range []string{"a@b", "Has Space", ""} {
+		if err := b.AddContact(Contact{Name: name, Address: "x@example.com"}, false); err == nil {
+			t.Errorf("expected %q to be refused as a name", name)
+		}
+	}
+
+	if err := b.AddToGroup("@dev", "alice", "carol@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.AddToGroup("team", "@dev", "bob", "ALICE@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	got, err := b.Expand("@team")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `"Alice Smith" <alice@example.com> | carol@example.com | bob@example.com`; strings.Join(got, " | ") != want {
+		t.Errorf("Expand(@team) = %s, want %s", strings.Join(got, " | "), want)
+	}
+
+	if err := b.AddToGroup("dev", "@team"); err == nil || !strings.Contains(err.Error(), "contains itself") {
+		t.Errorf("expected a cycle to be refused, got %v", err)
+	}
+	if g, _ := b.Group("dev"); len(g.Members) != 2 {
+		t.Errorf("a refused member changed the group: %v", g.Members)
+	}
+	if err := b.AddToGroup("ops", "nobody"); err == nil || !strings.Contains(err.Error(), "unknown contact nobody") {
+		t.Errorf("expected an unknown contact to be refused, got %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "contacts.yaml")
+	if err := b.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	from, err := loaded.Remove("alice")
+	if err != nil || strings.Join(from, ",") != "@dev" {
+		t.Errorf("Remove(alice) = %v, %v", from, err)
+	}
+	if got, _ := loaded.Expand("@team"); strings.Join(got, ",") != "carol@example.com,bob@example.com,ALICE@example.com" {
+		t.Errorf("expected alice to leave @dev, got %v", got)
+	}
+	if _, err := loaded.Remove("@nope"); err == nil {
+		t.Error("expected an unknown group to fail")
+	}
+}
+
+func TestSync(t *testing.T) {
+	b := &Book{}
+	_ = b.AddContact(Contact{Name: "alice", Address: "alice@example.org"}, false)
+	_ = b.AddContact(Contact{Name: "old", Address: "old@example.com", Source: SourceCardDAV}, false)
+
+	added, skipped := b.Sync(SourceCardDAV, []Contact{
+		{Name: "alice", Address: "alice@example.com"},
+		{Name: "carol", Address: "carol@example.com"},
+	})
+	if added != 1 || len(skipped) != 1 || skipped[0].Name != "alice" {
+		t.Errorf("Sync = %d, %v", added, skipped)
+	}
+	if _, ok := b.Contact("old"); ok {
+		t.Error("expected the previous sync to be replaced")
+	}
+	if c, _ := b.Contact("alice"); c.Address != "alice@example.org" || c.Source != "" {
+		t.Errorf("expected the local contact to win, got %+v", c)
+	}
+}
+
+func TestCheckURL(t *testing.T) {
+	tests := []struct {
+		url       string
+		allowHTTP bool
+		wantErr   string
+	}{
+		{"https://dav.example.com/ab/", false, ""},
+		{"http://dav.example.com/ab/", false, "--allow-http"},
+		{"http://localhost:5232/ab/", true, ""},
+		{"ftp://dav.example.com/ab/", true, "scheme must be https"},
+		{"dav.example.com/ab/", false, "invalid CardDAV URL"},
+	}
+	for _, tt := range tests {
+		err := CheckURL(tt.url, tt.allowHTTP)
+		if tt.wantErr == "" && err != nil {
+			t.Errorf("CheckURL(%q, %v) = %v", tt.url, tt.allowHTTP, err)
+		}
+		if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("CheckURL(%q, %v) = %v, want %q", tt.url, tt.allowHTTP, err, tt.wantErr)
+		}
+	}
+}
+
+func TestFetchCardDAV(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if user, pass, _ := r.BasicAuth(); r.Method != "REPORT" || user != "me" || pass != "secret" ||
+			r.Header.Get("Depth") != "1" || !strings.Contains(string(body), "addressbook-query") {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusMultiStatus)
+		_, _ = io.WriteString(w, `<?xml version="1.0"?>
+<d:multistatus xmlns:d="DAV:" xmlns:card="urn:ietf:params:xml:ns:carddav">
+  <d:response><d:href>/ab/1.vcf</d:href><d:propstat><d:prop>
+    <card:address-data>BEGIN:VCARD&#13;
+VERSION:3.0&#13;
+FN:Bob\, Jr.&#13;
+NICKNAME:Bobby&#13;
+EMAIL;TYPE=work:bob@work.example&#13;
+item1.EMAIL;TYPE=home,pref:bob@&#13;
+ home.example&#13;
+END:VCARD&#13;
+</card:address-data></d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat></d:response>
+  <d:response><d:href>/ab/2.vcf</d:href><d:propstat><d:prop>
+    <card:address-data>BEGIN:VCARD
+FN:Carol
+EMAIL:carol@example.com
+END:VCARD
+BEGIN:VCARD
+FN:No Mail
+END:VCARD
+</card:address-data></d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat></d:response>
+</d:multistatus>`)
+	}))
+	defer srv.Close()
+
+	got, err := FetchCardDAV(context.Background(), srv.Client(), srv.URL+"/ab/", "me", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Contact{
+		{Name: "bobby", Address: `"Bob, Jr." <bob@home.example>`},
+		{Name: "carol", Address: `"Carol" <carol@example.com>`},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("contact %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	if _, err := FetchCardDAV(context.Background(), srv.Client(), srv.URL, "me", "wrong"); err == nil ||
+		!strings.Contains(err.Error(), "400") {
+		t.Errorf("expected the server error, got %v", err)
+	}
+}
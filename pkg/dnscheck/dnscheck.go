@@ -0,0 +1,180 @@
+// Package dnscheck looks up the DNS records Forward Email needs for a domain
+// and compares them with the expected ones.
+//
+// Forward Email verifies domains through recursive resolvers, which may keep
+// serving old answers for the TTL of a record after the zone was edited.
+// Asking the authoritative nameserver directly shows whether the zone itself
+// is right, so mistakes are caught before waiting for caches to expire.
+package dnscheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ginsys/forward-email/pkg/api"
+)
+
+// Resolver looks up MX and TXT records. *net.Resolver satisfies it.
+type Resolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// NewResolver returns a resolver that sends every query to nameserver, a host
+// name or address with an optional port (53 by default), bypassing the
+// system resolver and its caches.
+func NewResolver(nameserver string) *net.Resolver {
+	addr := nameserver
+	if _, _, err := net.SplitHostPort(nameserver); err != nil {
+		addr = net.JoinHostPort(strings.Trim(nameserver, "[]"), "53")
+	}
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			d := net.Dialer{Timeout: 5 * time.Second}
+			return d.DialContext(ctx, network, addr)
+		},
+	}
+}
+
+// Result is the outcome of checking one expected record.
+type Result struct {
+	Type     string   `json:"type"`
+	Name     string   `json:"name"`
+	Expected string   `json:"expected"`
+	Found    []string `json:"found"`
+	Required bool     `json:"required"`
+	OK       bool     `json:"ok"`
+	Error    string   `json:"error,omitempty"`
+}
+
+// Check looks up every record in records for domain with r and reports
+// whether it is published. MX records match on the host name; TXT records
+// match exactly, except SPF and DMARC, where a policy that differs from the
+// suggested one is accepted as long as an SPF record includes the expected
+// mechanisms and a DMARC record exists. Lookups are shared between records
+// of the same name and type.
+func Check(ctx context.Context, r Resolver, domain string, records []api.DNSRecord) []Result {
+	type key struct{ typ, name string }
+	type answer struct {
+		values []string
+		err    error
+	}
+	answers := map[key]answer{}
+	lookup := func(typ, name string) ([]string, error) {
+		k := key{typ, name}
+		if a, ok := answers[k]; ok {
+			return a.values, a.err
+		}
+		var a answer
+		switch typ {
+		case "MX":
+			var mx []*net.MX
+			mx, a.err = r.LookupMX(ctx, name)
+			for _, m := range mx {
+				a.values = append(a.values, strings.TrimSuffix(m.Host, "."))
+			}
+		default:
+			a.values, a.err = r.LookupTXT(ctx, name)
+		}
+		var dnsErr *net.DNSError
+		if errors.As(a.err, &dnsErr) && dnsErr.IsNotFound {
+			a.err = nil
+		}
+		answers[k] = a
+		return a.values, a.err
+	}
+
+	results := make([]Result, 0, len(records))
+	for _, rec := range records {
+		typ := strings.ToUpper(rec.Type)
+		res := Result{Type: typ, Name: rec.Name, Expected: rec.Value, Required: rec.Required}
+		found, err := lookup(typ, FQDN(rec.Name, domain))
+		if err != nil {
+			res.Error = err.Error()
+		}
+		for _, v := range found {
+			if typ == "TXT" && !sameKind(v, rec.Value) {
+				continue
+			}
+			res.Found = append(res.Found, v)
+			if Matches(typ, rec.Value, v) {
+				res.OK = true
+			}
+		}
+		results = append(results, res)
+	}
+	return results
+}
+
+// FQDN returns the full name of a record named name ("@" for the apex) in
+// domain.
+func FQDN(name, domain string) string {
+	name = strings.TrimSuffix(name, ".")
+	if name == "" || name == "@" {
+		return domain
+	}
+	if strings.HasSuffix(strings.ToLower(name), "."+strings.ToLower(domain)) {
+		return name
+	}
+	return name + "." + domain
+}
+
+// Matches reports whether the published value found satisfies the expected
+// value of a record of type typ.
+func Matches(typ, expected, found string) bool {
+	expected, found = strings.TrimSpace(expected), strings.TrimSpace(found)
+	switch {
+	case strings.EqualFold(typ, "MX"):
+		return strings.EqualFold(strings.TrimSuffix(expected, "."), strings.TrimSuffix(found, "."))
+	case hasPrefixFold(expected, "v=spf1"):
+		if !hasPrefixFold(found, "v=spf1") {
+			return false
+		}
+		terms := strings.Fields(strings.ToLower(found))
+		for _, t := range strings.Fields(strings.ToLower(expected))[1:] {
+			if strings.HasPrefix(t, "include:") && !slices.Contains(terms, t) {
+				return false
+			}
+		}
+		return true
+	case hasPrefixFold(expected, "v=dmarc1"):
+		return hasPrefixFold(found, "v=dmarc1")
+	}
+	return expected == found
+}
+
+// sameKind reports whether the TXT value found is of the same kind as
+// expected, so that unrelated TXT records at the same name are not listed.
+func sameKind(found, expected string) bool {
+	for _, prefix := range []string{"v=spf1", "v=dmarc1", "v=dkim1"} {
+		if hasPrefixFold(expected, prefix) {
+			return hasPrefixFold(strings.TrimSpace(found), prefix)
+		}
+	}
+	if i := strings.Index(expected, "="); i > 0 {
+		return hasPrefixFold(found, expected[:i+1])
+	}
+	return true
+}
+
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+// Missing returns the required records among results that are not
+// published, as "TYPE name value" strings.
+func Missing(results []Result) []string {
+	var missing []string
+	for _, r := range results {
+		if r.Required && !r.OK {
+			missing = append(missing, fmt.Sprintf("%s %s %s", r.Type, r.Name, r.Expected))
+		}
+	}
+	return missing
+}
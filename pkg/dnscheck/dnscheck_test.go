@@ -0,0 +1,97 @@
+package dnscheck
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/ginsys/forward-email/pkg/api"
+)
+
+type fakeResolver struct {
+	mx  map[string][]string
+	txt map[string][]string
+}
+
+func (f fakeResolver) LookupMX(_ context.Context, name string) ([]*net.MX, error) {
+	hosts, ok := f.mx[name]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+	var mx []*net.MX
+	for _, h := range hosts {
+		mx = append(mx, &net.MX{Host: h + "."})
+	}
+	return mx, nil
+}
+
+func (f fakeResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	txt, ok := f.txt[name]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+	return txt, nil
+}
+
+func TestCheck(t *testing.T) {
+	r := fakeResolver{
+		mx: map[string][]string{"example.com": {"MX1.forwardemail.net"}},
+		txt: map[string][]string{"example.com": {
+			"google-site-verification=xyz",
+			"forward-email-site-verification=abc",
+			"v=spf1 include:_spf.google.com include:spf.forwardemail.net ~all",
+		}},
+	}
+	records := []api.DNSRecord{
+		{Type: "MX", Name: "@", Value: "mx1.forwardemail.net", Required: true},
+		{Type: "MX", Name: "@", Value: "mx2.forwardemail.net", Required: true},
+		{Type: "TXT", Name: "@", Value: "forward-email-site-verification=abc", Required: true},
+		{Type: "TXT", Name: "@", Value: "v=spf1 include:spf.forwardemail.net -all", Required: true},
+		{Type: "TXT", Name: "_dmarc", Value: "v=DMARC1; p=quarantine; pct=100"},
+	}
+	results := Check(context.Background(), r, "example.com", records)
+	want := []bool{true, false, true, true, false}
+	for i, res := range results {
+		if res.OK != want[i] || res.Error != "" {
+			t.Errorf("%s %s: ok = %v, error %q; want %v", res.Type, res.Expected, res.OK, res.Error, want[i])
+		}
+	}
+	if got := results[2].Found; len(got) != 1 {
+		t.Errorf("expected only the verification record to be listed, got %v", got)
+	}
+	missing := Missing(results)
+	if len(missing) != 1 || missing[0] != "MX @ mx2.forwardemail.net" {
+		t.Errorf("Missing = %v", missing)
+	}
+}
+
+func TestMatches(t *testing.T) {
+	tests := []struct {
+		typ, expected, found string
+		want                 bool
+	}{
+		{"MX", "mx1.forwardemail.net", "mx1.forwardemail.net.", true},
+		{"TXT", "v=spf1 include:spf.forwardemail.net -all", "v=spf1 include:spf.forwardemail.net -all", true},
+		{"TXT", "v=spf1 include:spf.forwardemail.net -all", "v=spf1 a mx -all", false},
+		{"TXT", "v=DMARC1; p=quarantine", "v=DMARC1; p=reject", true},
+		{"TXT", "forward-email-site-verification=abc", "forward-email-site-verification=abd", false},
+	}
+	for _, tt := range tests {
+		if got := Matches(tt.typ, tt.expected, tt.found); got != tt.want {
+			t.Errorf("Matches(%q, %q, %q) = %v; want %v", tt.typ, tt.expected, tt.found, got, tt.want)
+		}
+	}
+}
+
+func TestFQDN(t *testing.T) {
+	for name, want := range map[string]string{
+		"@":                   "example.com",
+		"_dmarc":              "_dmarc.example.com",
+		"_dmarc.example.com.": "_dmarc.example.com",
+		"fe._domainkey":       "fe._domainkey.example.com",
+	} {
+		if got := FQDN(name, "example.com"); got != want {
+			t.Errorf("FQDN(%q) = %q; want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+// Package i18n translates the CLI's user-facing strings: confirmation
+// prompts, cancellation notices and table headers. It is a thin layer over
+// go-i18n that keeps the call sites gettext style.
+//
+// Messages are looked up by their English text, so a string without a
+// translation is simply printed in English. Each additional language is a
+// YAML catalog embedded from locales/<lang>.yaml that maps the English format
+// strings to translated ones:
+//
+//	"Send this email?": "Diese E-Mail senden?"
+//	"NAME": "NAME"
+//
+// The translated format keeps the English verbs, in the same order. The
+// catalogs are added to the go-i18n bundle message by message rather than
+// through its file loader, which would read a header such as "ID" or
+// "DESCRIPTION" as a message field.
+//
+// English is used unless a language is selected explicitly; the locale of the
+// environment is not consulted, so output does not change under a user's LANG.
+package i18n
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	goi18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+// English is the source language, used when no other language is selected.
+const English = "en"
+
+//go:embed locales/*.yaml
+var locales embed.FS
+
+var (
+	mu        sync.RWMutex
+	current   = English
+	localizer *goi18n.Localizer
+
+	bundleOnce sync.Once
+	bundle     *goi18n.Bundle
+	bundleErr  error
+)
+
+// Languages returns the supported language codes, English first.
+func Languages() []string {
+	entries, _ := locales.ReadDir("locales")
+	langs := make([]string, 0, len(entries))
+	for _, e := range entries {
+		langs = append(langs, strings.TrimSuffix(e.Name(), ".yaml"))
+	}
+	sort.Strings(langs)
+	return append([]string{English}, langs...)
+}
+
+// Normalize reduces a locale such as de_DE.UTF-8 or de-AT to its language
+// code. C and POSIX, the locales without a language, normalize to English.
+func Normalize(locale string) string {
+	lang := strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(lang, "_-.@"); i >= 0 {
+		lang = lang[:i]
+	}
+	if lang == "c" || lang == "posix" {
+		return English
+	}
+	return lang
+}
+
+// SetLanguage selects the language T translates into. An empty lang selects
+// English.
+func SetLanguage(lang string) error {
+	if lang == "" {
+		lang = English
+	}
+	lang = Normalize(lang)
+	if !supported(lang) {
+		return fmt.Errorf("unsupported language %q (supported: %s)", lang, strings.Join(Languages(), ", "))
+	}
+	var loc *goi18n.Localizer
+	if lang != English {
+		b, err := load()
+		if err != nil {
+			return err
+		}
+		loc = goi18n.NewLocalizer(b, lang)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	current, localizer = lang, loc
+	return nil
+}
+
+// Language returns the selected language code.
+func Language() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// T translates the English format string into the selected language and
+// formats it with args like fmt.Sprintf. Without args the translation is
+// returned as is, so messages containing % need no escaping.
+func T(format string, args ...any) string {
+	mu.RLock()
+	loc := localizer
+	mu.RUnlock()
+	if loc != nil {
+		if s, err := loc.Localize(&goi18n.LocalizeConfig{MessageID: format}); err == nil {
+			format = s
+		}
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+func supported(lang string) bool {
+	for _, l := range Languages() {
+		if l == lang {
+			return true
+		}
+	}
+	return false
+}
+
+// load builds the bundle from the embedded catalogs once.
+func load() (*goi18n.Bundle, error) {
+	bundleOnce.Do(func() {
+		b := goi18n.NewBundle(language.English)
+		for _, lang := range Languages()[1:] {
+			data, err := locales.ReadFile(path.Join("locales", lang+".yaml"))
+			if err != nil {
+				bundleErr = fmt.Errorf("failed to read %s catalog: %w", lang, err)
+				return
+			}
+			var cat map[string]string
+			if err := yaml.Unmarshal(data, &cat); err != nil {
+				bundleErr = fmt.Errorf("invalid %s catalog: %w", lang, err)
+				return
+			}
+			msgs := make([]*goi18n.Message, 0, len(cat))
+			for id, other := range cat {
+				msgs = append(msgs, &goi18n.Message{ID: id, Other: other})
+			}
+			if err := b.AddMessages(language.Make(lang), msgs...); err != nil {
+				bundleErr = fmt.Errorf("invalid %s catalog: %w", lang, err)
+				return
+			}
+		}
+		bundle = b
+	})
+	return bundle, bundleErr
+}
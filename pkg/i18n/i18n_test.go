@@ -0,0 +1,102 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestNormalize(t *testing.T) {
+	for in, want := range map[string]string{
+		"de_DE.UTF-8": "de",
+		"de-AT":       "de",
+		"EN":          "en",
+		"C":           "en",
+		"POSIX":       "en",
+		"fr_FR@euro":  "fr",
+	} {
+		if got := Normalize(in); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestSetLanguageIgnoresLocale(t *testing.T) {
+	t.Cleanup(func() { _ = SetLanguage(English) })
+	t.Setenv("LC_ALL", "de_DE.UTF-8")
+	t.Setenv("LANG", "de_DE.UTF-8")
+
+	if err := SetLanguage(""); err != nil {
+		t.Fatal(err)
+	}
+	if Language() != English {
+		t.Errorf("Language() = %q, want English without an explicit language", Language())
+	}
+	if got := T("Send this email?"); got != "Send this email?" {
+		t.Errorf("T translated to %q without an explicit language", got)
+	}
+}
+
+func TestT(t *testing.T) {
+	t.Cleanup(func() { _ = SetLanguage(English) })
+
+	if err := SetLanguage("de_DE"); err != nil {
+		t.Fatal(err)
+	}
+	if got := T("Remove member '%s' from domain '%s'?", "bob", "example.com"); got != "Mitglied 'bob' aus der Domain 'example.com' entfernen?" {
+		t.Errorf("unexpected translation %q", got)
+	}
+	if got := T("No translation for %d", 3); got != "No translation for 3" {
+		t.Errorf("expected the English fallback, got %q", got)
+	}
+	if got := T("STORAGE %"); got != "SPEICHER %" {
+		t.Errorf("expected no formatting without args, got %q", got)
+	}
+
+	if err := SetLanguage("en"); err != nil {
+		t.Fatal(err)
+	}
+	if got := T("NAME"); got != "NAME" {
+		t.Errorf("T(NAME) in English = %q", got)
+	}
+
+	err := SetLanguage("xx")
+	if err == nil || !strings.Contains(err.Error(), "supported: en, de") {
+		t.Errorf("expected an unsupported language error, got %v", err)
+	}
+	if Language() != English {
+		t.Errorf("a failed SetLanguage changed the language to %q", Language())
+	}
+}
+
+// TestCatalogs checks that every translation keeps the verbs of its English
+// message in order, so T never formats a translation with mismatched args.
+func TestCatalogs(t *testing.T) {
+	for _, lang := range Languages()[1:] {
+		data, err := locales.ReadFile("locales/" + lang + ".yaml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var cat map[string]string
+		if err := yaml.Unmarshal(data, &cat); err != nil {
+			t.Fatalf("%s: %v", lang, err)
+		}
+		for en, tr := range cat {
+			if verbs(en) != verbs(tr) {
+				t.Errorf("%s: %q translates to %q with different verbs", lang, en, tr)
+			}
+		}
+	}
+}
+
+func verbs(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s)-1; i++ {
+		if s[i] == '%' {
+			b.WriteString(s[i : i+2])
+			i++
+		}
+	}
+	return b.String()
+}
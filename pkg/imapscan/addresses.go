@@ -0,0 +1,74 @@
+package imapscan
+
+import (
+	"net/mail"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AddressUse is an address at the scanned domain and how often mail was
+// delivered to it.
+type AddressUse struct {
+	LastSeen time.Time // date of the newest message, zero when none had a valid Date
+	Address  string    // lowercase
+	Messages int
+}
+
+// Addresses returns the addresses at domain that the messages were delivered
+// to, by AddressHeaders, most used first. Each message counts once per
+// address however many headers name it.
+func Addresses(messages []Message, domain string) []AddressUse {
+	suffix := "@" + strings.ToLower(domain)
+	uses := map[string]*AddressUse{}
+	for _, m := range messages {
+		date, _ := m.Header.Date()
+		seen := map[string]bool{}
+		for _, addr := range headerAddresses(m.Header) {
+			addr = strings.ToLower(addr)
+			if !strings.HasSuffix(addr, suffix) || seen[addr] {
+				continue
+			}
+			seen[addr] = true
+			u := uses[addr]
+			if u == nil {
+				u = &AddressUse{Address: addr}
+				uses[addr] = u
+			}
+			u.Messages++
+			if date.After(u.LastSeen) {
+				u.LastSeen = date
+			}
+		}
+	}
+
+	list := make([]AddressUse, 0, len(uses))
+	for _, u := range uses {
+		list = append(list, *u)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Messages != list[j].Messages {
+			return list[i].Messages > list[j].Messages
+		}
+		return list[i].Address < list[j].Address
+	})
+	return list
+}
+
+// headerAddresses returns every address in the AddressHeaders of h, skipping
+// values that do not parse.
+func headerAddresses(h mail.Header) []string {
+	var addrs []string
+	for _, name := range AddressHeaders {
+		for _, v := range h[name] {
+			list, err := mail.ParseAddressList(v)
+			if err != nil {
+				continue
+			}
+			for _, a := range list {
+				addrs = append(addrs, a.Address)
+			}
+		}
+	}
+	return addrs
+}
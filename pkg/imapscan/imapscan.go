@@ -0,0 +1,271 @@
+// Package imapscan reads the address headers of the messages in an IMAP
+// mailbox, to discover the addresses a domain receives mail at before it
+// moves to Forward Email.
+//
+// Only the read-only subset of IMAP4rev1 (RFC 3501) needed for that is
+// implemented: LOGIN, EXAMINE, UID SEARCH and UID FETCH of header fields.
+// Mailboxes are opened with EXAMINE and headers fetched with BODY.PEEK, so a
+// scan never changes flags such as \Seen.
+package imapscan
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/mail"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AddressHeaders are the headers that name the addresses a message was
+// delivered to.
+var AddressHeaders = []string{"To", "Cc", "Delivered-To", "X-Original-To"}
+
+// fetchBatch is the number of messages fetched per UID FETCH command.
+const fetchBatch = 500
+
+// Client is a connection to an IMAP server.
+type Client struct {
+	conn net.Conn
+	r    *bufio.Reader
+	tag  int
+	stop func() bool
+}
+
+// Message is the fetched header of one message.
+type Message struct {
+	UID    uint32
+	Header mail.Header
+}
+
+// Dial connects to the IMAP server at addr (host:port) and reads its
+// greeting. tlsConfig enables implicit TLS, as on port 993; nil connects in
+// plain text, which is only suitable for local servers. The connection is
+// closed when ctx is done.
+func Dial(ctx context.Context, addr string, tlsConfig *tls.Config) (*Client, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	if tlsConfig != nil {
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("TLS handshake with %s failed: %w", addr, err)
+		}
+		conn = tlsConn
+	}
+
+	c := &Client{conn: conn, r: bufio.NewReader(conn)}
+	c.stop = context.AfterFunc(ctx, func() { _ = conn.Close() })
+	line, _, err := c.readResponse()
+	if err != nil {
+		_ = c.Close()
+		return nil, fmt.Errorf("failed to read the greeting of %s: %w", addr, err)
+	}
+	if !strings.HasPrefix(line, "* OK") && !strings.HasPrefix(line, "* PREAUTH") {
+		_ = c.Close()
+		return nil, fmt.Errorf("unexpected greeting from %s: %s", addr, line)
+	}
+	return c, nil
+}
+
+// Close closes the connection without logging out.
+func (c *Client) Close() error {
+	c.stop()
+	return c.conn.Close()
+}
+
+// Logout ends the session and closes the connection.
+func (c *Client) Logout() error {
+	_, err := c.command("LOGOUT")
+	if cerr := c.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// Login authenticates with a user name and password.
+func (c *Client) Login(user, password string) error {
+	u, err := quote(user)
+	if err != nil {
+		return err
+	}
+	p, err := quote(password)
+	if err != nil {
+		return err
+	}
+	if _, err := c.command("LOGIN " + u + " " + p); err != nil {
+		return fmt.Errorf("login failed: %w", err)
+	}
+	return nil
+}
+
+// Examine opens mailbox read-only.
+func (c *Client) Examine(mailbox string) error {
+	m, err := quote(mailbox)
+	if err != nil {
+		return err
+	}
+	if _, err := c.command("EXAMINE " + m); err != nil {
+		return fmt.Errorf("failed to open mailbox %s: %w", mailbox, err)
+	}
+	return nil
+}
+
+// SearchSince returns the UIDs of the messages in the open mailbox received
+// on or after since's date, or of all messages when since is zero.
+func (c *Client) SearchSince(since time.Time) ([]uint32, error) {
+	criteria := "ALL"
+	if !since.IsZero() {
+		criteria = "SINCE " + since.Format("2-Jan-2006")
+	}
+	untagged, err := c.command("UID SEARCH " + criteria)
+	if err != nil {
+		return nil, fmt.Errorf("search failed: %w", err)
+	}
+	var uids []uint32
+	for _, resp := range untagged {
+		rest, ok := strings.CutPrefix(resp.line, "* SEARCH")
+		if !ok {
+			continue
+		}
+		for _, f := range strings.Fields(rest) {
+			uid, err := strconv.ParseUint(f, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid search result %q", f)
+			}
+			uids = append(uids, uint32(uid))
+		}
+	}
+	sort.Slice(uids, func(i, j int) bool { return uids[i] < uids[j] })
+	return uids, nil
+}
+
+var fetchUID = regexp.MustCompile(`\bUID (\d+)`)
+
+// FetchHeaders fetches the given header fields of the messages with the
+// given UIDs.
+func (c *Client) FetchHeaders(uids []uint32, fields []string) ([]Message, error) {
+	var messages []Message
+	for start := 0; start < len(uids); start += fetchBatch {
+		batch := uids[start:min(start+fetchBatch, len(uids))]
+		set := make([]string, len(batch))
+		for i, uid := range batch {
+			set[i] = strconv.FormatUint(uint64(uid), 10)
+		}
+		untagged, err := c.command(fmt.Sprintf("UID FETCH %s (UID BODY.PEEK[HEADER.FIELDS (%s)])",
+			strings.Join(set, ","), strings.ToUpper(strings.Join(fields, " "))))
+		if err != nil {
+			return nil, fmt.Errorf("fetch failed: %w", err)
+		}
+		for _, resp := range untagged {
+			if !strings.Contains(resp.line, " FETCH ") {
+				continue
+			}
+			m := fetchUID.FindStringSubmatch(resp.line)
+			if m == nil {
+				continue
+			}
+			uid, _ := strconv.ParseUint(m[1], 10, 32)
+			msg := Message{UID: uint32(uid), Header: mail.Header{}}
+			if len(resp.literals) > 0 {
+				msg.Header = parseHeader(resp.literals[0])
+			}
+			messages = append(messages, msg)
+		}
+	}
+	return messages, nil
+}
+
+// parseHeader parses a fetched header block, or returns an empty header when
+// it is malformed.
+func parseHeader(data []byte) mail.Header {
+	data = bytes.TrimRight(data, "\r\n")
+	msg, err := mail.ReadMessage(bytes.NewReader(append(data, "\r\n\r\n"...)))
+	if err != nil {
+		return mail.Header{}
+	}
+	return msg.Header
+}
+
+// response is one untagged or tagged server response line, with the
+// literals it carried replaced by {n} in line.
+type response struct {
+	line     string
+	literals [][]byte
+}
+
+// command sends cmd and returns its untagged responses, or an error when the
+// server does not answer OK.
+func (c *Client) command(cmd string) ([]response, error) {
+	c.tag++
+	tag := "a" + strconv.Itoa(c.tag)
+	if _, err := io.WriteString(c.conn, tag+" "+cmd+"\r\n"); err != nil {
+		return nil, err
+	}
+	var untagged []response
+	for {
+		line, literals, err := c.readResponse()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case strings.HasPrefix(line, tag+" "):
+			status := strings.TrimPrefix(line, tag+" ")
+			if strings.HasPrefix(status, "OK") {
+				return untagged, nil
+			}
+			return nil, fmt.Errorf("server said: %s", status)
+		case strings.HasPrefix(line, "* "):
+			untagged = append(untagged, response{line: line, literals: literals})
+		default:
+			return nil, fmt.Errorf("unexpected server response: %s", line)
+		}
+	}
+}
+
+var literalSuffix = regexp.MustCompile(`\{(\d+)\+?\}$`)
+
+// readResponse reads one response line, following any literals in it.
+func (c *Client) readResponse() (string, [][]byte, error) {
+	var line strings.Builder
+	var literals [][]byte
+	for {
+		part, err := c.r.ReadString('\n')
+		if err != nil {
+			return "", nil, err
+		}
+		part = strings.TrimRight(part, "\r\n")
+		line.WriteString(part)
+		m := literalSuffix.FindStringSubmatch(part)
+		if m == nil {
+			return line.String(), literals, nil
+		}
+		n, err := strconv.Atoi(m[1])
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid literal size in %q", part)
+		}
+		literal := make([]byte, n)
+		if _, err := io.ReadFull(c.r, literal); err != nil {
+			return "", nil, err
+		}
+		literals = append(literals, literal)
+	}
+}
+
+// quote returns s as an IMAP quoted string.
+func quote(s string) (string, error) {
+	if strings.ContainsAny(s, "\r\n\x00") {
+		return "", fmt.Errorf("%q cannot be sent as an IMAP string", s)
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`, nil
+}
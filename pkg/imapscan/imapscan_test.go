@@ -0,0 +1,90 @@
+package imapscan
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ginsys/forward-email/internal/testutil"
+)
+
+var testMessages = []string{
+	"Date: Mon, 02 Mar 2026 10:00:00 +0000\nTo: Sales <Sales@Example.com>, partner@corp.com\nCc: billing@example.com\n",
+	"Date: Tue, 03 Mar 2026 10:00:00 +0000\nTo: undisclosed-recipients:;\nDelivered-To: sales@example.com\nX-Original-To: sales@example.com\n",
+	"Date: not a date\nTo: old@example.com\n",
+	"To: <<broken\nDelivered-To: ops@example.org\n",
+}
+
+func TestScan(t *testing.T) {
+	addr := testutil.IMAPServer(t, "me@example.com", `pa"ss`, testMessages)
+	ctx := context.Background()
+
+	c, err := Dial(ctx, addr, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Login("me@example.com", "wrong"); err == nil || !strings.Contains(err.Error(), "AUTHENTICATIONFAILED") {
+		t.Errorf("expected a failed login, got %v", err)
+	}
+	if err := c.Login("me@example.com", `pa"ss`); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Examine("INBOX"); err != nil {
+		t.Fatal(err)
+	}
+	uids, err := c.SearchSince(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(uids) != len(testMessages) {
+		t.Fatalf("expected %d UIDs, got %v", len(testMessages), uids)
+	}
+	messages, err := c.FetchHeaders(uids, append(AddressHeaders, "Date"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != len(testMessages) || messages[1].UID != 2 {
+		t.Fatalf("unexpected fetch result %+v", messages)
+	}
+	if err := c.Logout(); err != nil {
+		t.Errorf("Logout: %v", err)
+	}
+
+	uses := Addresses(messages, "EXAMPLE.com")
+	var got []string
+	for _, u := range uses {
+		got = append(got, u.Address)
+	}
+	if strings.Join(got, ",") != "sales@example.com,billing@example.com,old@example.com" {
+		t.Errorf("addresses = %v", got)
+	}
+	if uses[0].Messages != 2 || !uses[0].LastSeen.Equal(time.Date(2026, 3, 3, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected use of sales@: %+v", uses[0])
+	}
+	if !uses[2].LastSeen.IsZero() {
+		t.Errorf("expected no last seen date for a message with an invalid Date, got %v", uses[2].LastSeen)
+	}
+}
+
+func TestDial_Canceled(t *testing.T) {
+	addr := testutil.IMAPServer(t, "u", "p", nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	c, err := Dial(ctx, addr, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	if err := c.Login("u", "p"); err == nil {
+		t.Error("expected a canceled context to close the connection")
+	}
+}
+
+func TestQuote(t *testing.T) {
+	if q, err := quote(`a"b\c`); err != nil || q != `"a\"b\\c"` {
+		t.Errorf("quote = %s, %v", q, err)
+	}
+	if _, err := quote("a\r\nb"); err == nil {
+		t.Error("expected an error for a line break")
+	}
+}
@@ -0,0 +1,281 @@
+// Package mailtext renders the bodies of outgoing email: a plain-text
+// alternative for an HTML body, and HTML and text parts from Markdown.
+//
+// It handles the markup people write in messages, not arbitrary web pages.
+// Scripts, styles and the document head are dropped, blocks and list items
+// start new lines, and links keep their target in parentheses after the link
+// text, so the text part stays usable without the HTML one.
+package mailtext
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// skippedElements have content that is not part of the message text.
+var skippedElements = map[string]bool{"head": true, "script": true, "style": true, "title": true, "template": true}
+
+// paragraphElements are separated from their surroundings by a blank line,
+// lineElements by a line break.
+var (
+	paragraphElements = map[string]bool{
+		"p": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+		"ul": true, "ol": true, "table": true, "blockquote": true, "pre": true, "hr": true,
+	}
+	lineElements = map[string]bool{
+		"br": true, "div": true, "tr": true, "li": true, "dt": true, "dd": true, "dl": true,
+		"section": true, "article": true, "header": true, "footer": true, "address": true,
+	}
+)
+
+var attrPattern = regexp.MustCompile(`(?is)\b([a-z-]+)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+
+// FromHTML returns a plain-text version of an HTML body.
+func FromHTML(src string) string {
+	var (
+		w     textWriter
+		skip  string   // element whose content is being skipped
+		pre   int      // depth of <pre> elements
+		links []string // targets of the open <a> elements
+		lists []int    // item counters of the open lists, -1 for <ul>
+	)
+	for len(src) > 0 {
+		i := strings.IndexByte(src, '<')
+		if i < 0 {
+			i = len(src)
+		}
+		if skip == "" {
+			w.text(html.UnescapeString(src[:i]), pre > 0)
+		}
+		src = src[i:]
+		if src == "" {
+			break
+		}
+
+		if strings.HasPrefix(src, "<!--") {
+			end := strings.Index(src, "-->")
+			if end < 0 {
+				break
+			}
+			src = src[end+3:]
+			continue
+		}
+		end := tagEnd(src)
+		if end < 0 || len(src) < 2 || !isTagStart(src[1]) {
+			if skip == "" {
+				w.text("<", pre > 0)
+			}
+			src = src[1:]
+			continue
+		}
+		tag := src[1:end]
+		src = src[end+1:]
+
+		closing := strings.HasPrefix(tag, "/")
+		name, attrs := tagName(strings.TrimPrefix(tag, "/"))
+		if skip != "" {
+			if closing && name == skip {
+				skip = ""
+			}
+			continue
+		}
+		if !closing && skippedElements[name] && !strings.HasSuffix(tag, "/") {
+			skip = name
+			continue
+		}
+
+		switch {
+		case paragraphElements[name]:
+			w.breakLines(2)
+		case lineElements[name]:
+			w.breakLines(1)
+		case name == "td" || name == "th":
+			if !closing {
+				w.space = true
+			}
+		}
+
+		switch name {
+		case "pre":
+			if closing {
+				pre = max(pre-1, 0)
+			} else {
+				pre++
+			}
+		case "ul", "ol":
+			if closing {
+				if len(lists) > 0 {
+					lists = lists[:len(lists)-1]
+				}
+			} else if name == "ol" {
+				lists = append(lists, 0)
+			} else {
+				lists = append(lists, -1)
+			}
+		case "li":
+			if closing {
+				continue
+			}
+			bullet := "- "
+			if n := len(lists); n > 0 && lists[n-1] >= 0 {
+				lists[n-1]++
+				bullet = fmt.Sprintf("%d. ", lists[n-1])
+			}
+			w.write(strings.Repeat("  ", max(len(lists)-1, 0)) + bullet)
+		case "hr":
+			if !closing {
+				w.write("---")
+				w.breakLines(2)
+			}
+		case "img":
+			if alt := attr(attrs, "alt"); alt != "" {
+				w.text(alt, false)
+			}
+		case "a":
+			if !closing {
+				links = append(links, attr(attrs, "href"))
+				w.mark()
+				continue
+			}
+			if len(links) == 0 {
+				continue
+			}
+			href := links[len(links)-1]
+			links = links[:len(links)-1]
+			if target := linkTarget(href); target != "" && target != strings.TrimSpace(w.sinceMark()) {
+				w.write(" (" + target + ")")
+			}
+		}
+	}
+	return w.String()
+}
+
+// linkTarget returns the part of href worth keeping in text: the address of
+// a mailto link, or the URL, but nothing for page anchors and scripts.
+func linkTarget(href string) string {
+	href = strings.TrimSpace(html.UnescapeString(href))
+	lower := strings.ToLower(href)
+	switch {
+	case href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(lower, "javascript:"):
+		return ""
+	case strings.HasPrefix(lower, "mailto:"):
+		return href[len("mailto:"):]
+	}
+	return href
+}
+
+// tagEnd returns the index of the '>' closing the tag at the start of s,
+// skipping quoted attribute values, or -1.
+func tagEnd(s string) int {
+	var quote byte
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '>':
+			return i
+		}
+	}
+	return -1
+}
+
+func isTagStart(c byte) bool {
+	return c == '/' || c == '!' || c == '?' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// tagName splits the inside of a tag into its lowercase name and attributes.
+func tagName(tag string) (name, attrs string) {
+	i := strings.IndexAny(tag, " \t\r\n/")
+	if i < 0 {
+		return strings.ToLower(tag), ""
+	}
+	return strings.ToLower(tag[:i]), tag[i:]
+}
+
+func attr(attrs, name string) string {
+	for _, m := range attrPattern.FindAllStringSubmatch(attrs, -1) {
+		if strings.EqualFold(m[1], name) {
+			return m[2] + m[3] + m[4]
+		}
+	}
+	return ""
+}
+
+// textWriter collects text, collapsing white space and holding back line
+// breaks until more text follows, so that nested blocks do not pile up
+// blank lines.
+type textWriter struct {
+	b      strings.Builder
+	breaks int  // line breaks due before the next text
+	space  bool // a space is due before the next text
+	marked int  // offset of the text since mark
+}
+
+// text writes s, collapsing its white space unless it is preformatted.
+func (w *textWriter) text(s string, preformatted bool) {
+	if preformatted {
+		w.write(s)
+		return
+	}
+	words := strings.Fields(s)
+	if len(words) == 0 {
+		if s != "" {
+			w.space = true
+		}
+		return
+	}
+	if isSpace(s[0]) {
+		w.space = true
+	}
+	w.write(strings.Join(words, " "))
+	w.space = isSpace(s[len(s)-1])
+}
+
+func (w *textWriter) write(s string) {
+	if s == "" {
+		return
+	}
+	if w.b.Len() > 0 {
+		if w.breaks > 0 {
+			w.b.WriteString(strings.Repeat("\n", w.breaks))
+		} else if w.space && !w.endsWithSpace() {
+			w.b.WriteByte(' ')
+		}
+	}
+	w.breaks, w.space = 0, false
+	w.b.WriteString(s)
+}
+
+// breakLines asks for n line breaks before the next text.
+func (w *textWriter) breakLines(n int) {
+	w.breaks = max(w.breaks, n)
+	w.space = false
+}
+
+func (w *textWriter) mark() { w.marked = w.b.Len() }
+
+func (w *textWriter) sinceMark() string { return w.b.String()[min(w.marked, w.b.Len()):] }
+
+func (w *textWriter) endsWithSpace() bool {
+	s := w.b.String()
+	return s != "" && isSpace(s[len(s)-1])
+}
+
+// String returns the text without trailing white space on its lines.
+func (w *textWriter) String() string {
+	lines := strings.Split(w.b.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
@@ -0,0 +1,77 @@
+package mailtext
+
+import "testing"
+
+func TestFromHTML(t *testing.T) {
+	for _, tc := range []struct{ name, html, want string }{
+		{
+			name: "document",
+			html: `<html><head><title>News</title><style>p { color: red }</style></head>
+<body><h1>Welcome,   friend</h1><p>Thanks &amp; bye<br>The team</p><script>track()</script></body></html>`,
+			want: "Welcome, friend\n\nThanks & bye\nThe team",
+		},
+		{
+			name: "links",
+			html: `<p>Read <a href="https://example.com/docs?a=1&amp;b=2">the docs</a>, mail ` +
+				`<a href="mailto:help@example.com">help@example.com</a> or <a href="#top">go up</a>.</p>` +
+				`<p><a href='https://example.org'>https://example.org</a></p>`,
+			want: "Read the docs (https://example.com/docs?a=1&b=2), mail help@example.com or go up.\n\nhttps://example.org",
+		},
+		{
+			name: "lists",
+			html: `<ul><li>One</li><li>Two <b>bold</b></li></ul><ol><li>first</li><li>second</li></ol>`,
+			want: "- One\n- Two bold\n\n1. first\n2. second",
+		},
+		{
+			name: "preformatted and tables",
+			html: "<pre>  x := 1\n  y := 2</pre><table><tr><td>a</td><td>b</td></tr><tr><th>c</th><td>d</td></tr></table>",
+			want: "  x := 1\n  y := 2\n\na b\nc d",
+		},
+		{
+			name: "images, rules and stray brackets",
+			html: `<p>x < y <!-- hidden --></p><img src="logo.png" alt="Logo"><hr><p>end</p>`,
+			want: "x < y\n\nLogo\n\n---\n\nend",
+		},
+	} {
+		if got := FromHTML(tc.html); got != tc.want {
+			t.Errorf("%s: FromHTML() =\n%q\nwant\n%q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	src := "# Hello *world*\n\n" +
+		"Some **bold** and _em_ text, `a<b>` and snake_case.\nSee [the docs](https://example.com/a_b \"Docs\") or <https://example.org>.  \nBye\n\n" +
+		"- one\n- two\n  continued\n\n" +
+		"1. first\n2. second\n\n" +
+		"> quoted\n\n" +
+		"```\nx := <1>\n```\n\n" +
+		"---\n"
+	wantHTML := "<h1>Hello <em>world</em></h1>\n" +
+		"<p>Some <strong>bold</strong> and <em>em</em> text, <code>a&lt;b&gt;</code> and snake_case.\n" +
+		"See <a href=\"https://example.com/a_b\">the docs</a> or <a href=\"https://example.org\">https://example.org</a>.<br>\nBye</p>\n" +
+		"<ul>\n<li>one</li>\n<li>two\ncontinued</li>\n</ul>\n" +
+		"<ol>\n<li>first</li>\n<li>second</li>\n</ol>\n" +
+		"<blockquote>\n<p>quoted</p>\n</blockquote>\n" +
+		"<pre><code>x := &lt;1&gt;</code></pre>\n" +
+		"<hr>\n"
+	wantText := "Hello world\n\n" +
+		"Some bold and em text, a<b> and snake_case. See the docs (https://example.com/a_b) or https://example.org.\nBye\n\n" +
+		"- one\n- two continued\n\n" +
+		"1. first\n2. second\n\n" +
+		"quoted\n\n" +
+		"x := <1>\n\n" +
+		"---"
+
+	html, text := Markdown(src)
+	if html != wantHTML {
+		t.Errorf("HTML =\n%s\nwant\n%s", html, wantHTML)
+	}
+	if text != wantText {
+		t.Errorf("text =\n%q\nwant\n%q", text, wantText)
+	}
+
+	if html, _ := Markdown("<script>alert(1)</script>"); html != "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>\n" {
+		t.Errorf("expected raw HTML to be escaped, got %q", html)
+	}
+}
@@ -0,0 +1,159 @@
+package mailtext
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Markdown renders a Markdown message as an HTML body and its plain-text
+// alternative. It covers the Markdown used in email: paragraphs, headings,
+// emphasis, inline and fenced code, links, images, block quotes, rules, and
+// flat bulleted and numbered lists. Raw HTML is escaped, not passed through.
+func Markdown(src string) (htmlBody, text string) {
+	htmlBody = renderBlocks(strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n"))
+	return htmlBody, FromHTML(htmlBody)
+}
+
+var (
+	headingPattern  = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	rulePattern     = regexp.MustCompile(`^ {0,3}(?:(?:- *){3,}|(?:\* *){3,}|(?:_ *){3,})$`)
+	bulletPattern   = regexp.MustCompile(`^ {0,3}[-*+]\s+(.*)$`)
+	numberedPattern = regexp.MustCompile(`^ {0,3}\d{1,9}[.)]\s+(.*)$`)
+	quotePattern    = regexp.MustCompile(`^ {0,3}> ?(.*)$`)
+	fencePattern    = regexp.MustCompile("^ {0,3}(```|~~~)")
+)
+
+// renderBlocks renders lines as a sequence of blocks.
+func renderBlocks(lines []string) string {
+	var b strings.Builder
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case strings.TrimSpace(line) == "":
+			i++
+
+		case fencePattern.MatchString(line):
+			fence := fencePattern.FindStringSubmatch(line)[1]
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				code = append(code, lines[i])
+			}
+			i++ // the closing fence
+			fmt.Fprintf(&b, "<pre><code>%s</code></pre>\n", html.EscapeString(strings.Join(code, "\n")))
+
+		case headingPattern.MatchString(line):
+			m := headingPattern.FindStringSubmatch(line)
+			fmt.Fprintf(&b, "<h%d>%s</h%d>\n", len(m[1]), renderInline(m[2]), len(m[1]))
+			i++
+
+		case rulePattern.MatchString(line):
+			b.WriteString("<hr>\n")
+			i++
+
+		case quotePattern.MatchString(line):
+			var quoted []string
+			for ; i < len(lines) && quotePattern.MatchString(lines[i]); i++ {
+				quoted = append(quoted, quotePattern.FindStringSubmatch(lines[i])[1])
+			}
+			fmt.Fprintf(&b, "<blockquote>\n%s</blockquote>\n", renderBlocks(quoted))
+
+		case bulletPattern.MatchString(line), numberedPattern.MatchString(line):
+			tag, item := "ul", bulletPattern
+			if !bulletPattern.MatchString(line) {
+				tag, item = "ol", numberedPattern
+			}
+			fmt.Fprintf(&b, "<%s>\n", tag)
+			for i < len(lines) && item.MatchString(lines[i]) {
+				text := []string{item.FindStringSubmatch(lines[i])[1]}
+				// Indented lines continue the item
+				for i++; i < len(lines) && strings.TrimSpace(lines[i]) != "" && isIndented(lines[i]); i++ {
+					text = append(text, strings.TrimSpace(lines[i]))
+				}
+				fmt.Fprintf(&b, "<li>%s</li>\n", renderInline(strings.Join(text, "\n")))
+			}
+			fmt.Fprintf(&b, "</%s>\n", tag)
+
+		default:
+			var para []string
+			for ; i < len(lines) && strings.TrimSpace(lines[i]) != "" && !startsBlock(lines[i]); i++ {
+				para = append(para, lines[i])
+			}
+			fmt.Fprintf(&b, "<p>%s</p>\n", renderParagraph(para))
+		}
+	}
+	return b.String()
+}
+
+func isIndented(line string) bool {
+	return strings.HasPrefix(line, "  ") || strings.HasPrefix(line, "\t")
+}
+
+// startsBlock reports whether line ends a paragraph by starting another block.
+func startsBlock(line string) bool {
+	return fencePattern.MatchString(line) || headingPattern.MatchString(line) || rulePattern.MatchString(line) ||
+		quotePattern.MatchString(line) || bulletPattern.MatchString(line) || numberedPattern.MatchString(line)
+}
+
+// renderParagraph joins the lines of a paragraph; a line ending in two
+// spaces or a backslash ends with a hard line break.
+func renderParagraph(lines []string) string {
+	var b strings.Builder
+	for i, line := range lines {
+		hard := strings.HasSuffix(line, "  ") || strings.HasSuffix(line, "\\")
+		line = strings.TrimSuffix(strings.TrimSpace(line), "\\")
+		b.WriteString(renderInline(line))
+		if i < len(lines)-1 {
+			if hard {
+				b.WriteString("<br>")
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+var (
+	imagePattern    = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)(?:\s+&#34;[^)]*&#34;)?\)`)
+	linkPattern     = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)(?:\s+&#34;[^)]*&#34;)?\)`)
+	autolinkPattern = regexp.MustCompile(`&lt;((?:https?://|mailto:)[^\s&]+)&gt;`)
+	strongPattern   = regexp.MustCompile(`\*\*(\S(?:.*?\S)?)\*\*|__(\S(?:.*?\S)?)__`)
+	emPattern       = regexp.MustCompile(`\*(\S(?:.*?\S)?)\*|(^|[^\w])_(\S(?:.*?\S)?)_([^\w]|$)`)
+)
+
+// renderInline renders the spans of a line: code spans are taken as they
+// are, and the rest is escaped before links and emphasis are marked up.
+func renderInline(s string) string {
+	var b strings.Builder
+	parts := strings.Split(s, "`")
+	for i, part := range parts {
+		// Odd parts are code spans, unless the last backtick is unmatched
+		if i%2 == 1 && i < len(parts)-1 {
+			fmt.Fprintf(&b, "<code>%s</code>", html.EscapeString(part))
+			continue
+		}
+		if i%2 == 1 {
+			b.WriteString("`")
+		}
+		b.WriteString(renderSpans(html.EscapeString(part)))
+	}
+	return b.String()
+}
+
+func renderSpans(s string) string {
+	s = imagePattern.ReplaceAllString(s, `<img src="$2" alt="$1">`)
+	s = linkPattern.ReplaceAllString(s, `<a href="$2">$1</a>`)
+	s = autolinkPattern.ReplaceAllStringFunc(s, func(m string) string {
+		url := autolinkPattern.FindStringSubmatch(m)[1]
+		return fmt.Sprintf(`<a href="%s">%s</a>`, url, strings.TrimPrefix(url, "mailto:"))
+	})
+	s = strongPattern.ReplaceAllString(s, `<strong>$1$2</strong>`)
+	return emPattern.ReplaceAllStringFunc(s, func(m string) string {
+		g := emPattern.FindStringSubmatch(m)
+		if g[1] != "" {
+			return "<em>" + g[1] + "</em>"
+		}
+		return g[2] + "<em>" + g[3] + "</em>" + g[4]
+	})
+}
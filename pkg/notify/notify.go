@@ -0,0 +1,137 @@
+// Package notify delivers short summaries of CLI activity to chat and webhook
+// endpoints.
+//
+// A Sink is chosen from the destination URL. Plain http(s) URLs receive a
+// generic JSON payload whose "text" field also makes it a valid Slack incoming
+// webhook message. Scheme prefixes select other sinks:
+//
+//	slack+https://hooks.slack.com/services/...        Slack (text only)
+//	matrix+https://matrix.example.org/_matrix/...     Matrix room message
+//
+// Further sinks can be added with Register.
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultTimeout bounds each delivery so a slow endpoint cannot stall a command.
+const DefaultTimeout = 10 * time.Second
+
+// Event is a summary of one command run.
+type Event struct {
+	Time    time.Time `json:"time"`
+	Details any       `json:"details,omitempty"` // command-specific data, e.g. quota breaches
+	Command string    `json:"command"`           // e.g. "alias create"
+	Text    string    `json:"text"`              // human-readable one-line summary
+	Profile string    `json:"profile,omitempty"`
+	Error   string    `json:"error,omitempty"`
+	Changes []Change  `json:"changes,omitempty"`
+	Success bool      `json:"success"`
+}
+
+// Change is a resource the command changed, e.g. the alias it created.
+type Change struct {
+	Type   string `json:"type"` // alias, domain, member, email
+	Action string `json:"action"`
+	Domain string `json:"domain,omitempty"`
+	Name   string `json:"name,omitempty"`
+}
+
+// Sink delivers events to one destination.
+type Sink interface {
+	Notify(ctx context.Context, e Event) error
+}
+
+// Factory creates a Sink for a destination URL whose scheme prefix has
+// already been stripped, e.g. https://hooks.slack.com/... for slack+https.
+type Factory func(target *url.URL, client *http.Client) (Sink, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Factory{}
+)
+
+// Register makes a sink available under the URL scheme prefix name, used as
+// name+https://... in destination URLs.
+func Register(name string, f Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = f
+}
+
+func init() {
+	Register("slack", newSlackSink)
+	Register("matrix", newMatrixSink)
+	Register("webhook", newWebhookSink)
+}
+
+// New returns the Sink for a destination URL.
+func New(rawURL string, client *http.Client) (Sink, error) {
+	if client == nil {
+		client = &http.Client{Timeout: DefaultTimeout}
+	}
+
+	kind := "webhook"
+	target := rawURL
+	if scheme, rest, ok := strings.Cut(rawURL, "://"); ok {
+		if name, transport, ok := strings.Cut(scheme, "+"); ok {
+			kind, target = name, transport+"://"+rest
+		}
+	}
+
+	u, err := url.Parse(target)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, fmt.Errorf("invalid notification URL %q: must be an absolute http(s) URL", redact(rawURL))
+	}
+
+	registryMu.RLock()
+	f, ok := registry[kind]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown notification sink %q", kind)
+	}
+	return f(u, client)
+}
+
+// Multi fans an event out to several sinks.
+type Multi []Sink
+
+// Notify delivers e to every sink and joins their errors.
+func (m Multi) Notify(ctx context.Context, e Event) error {
+	var errs []error
+	for _, s := range m {
+		if err := s.Notify(ctx, e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// NewMulti builds a Multi sink for each destination URL.
+func NewMulti(urls []string, client *http.Client) (Multi, error) {
+	m := make(Multi, 0, len(urls))
+	for _, u := range urls {
+		s, err := New(u, client)
+		if err != nil {
+			return nil, err
+		}
+		m = append(m, s)
+	}
+	return m, nil
+}
+
+// redact hides credentials that webhook URLs often carry in their path or query.
+func redact(rawURL string) string {
+	if u, err := url.Parse(rawURL); err == nil && u.Host != "" {
+		return u.Scheme + "://" + u.Host + "/..."
+	}
+	return "..."
+}
@@ -0,0 +1,135 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type recorded struct {
+	method string
+	path   string
+	auth   string
+	query  string
+	body   map[string]any
+}
+
+func recorder(t *testing.T, status int) (*httptest.Server, *[]recorded) {
+	t.Helper()
+	var got []recorded
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := recorded{method: r.Method, path: r.URL.Path, auth: r.Header.Get("Authorization"), query: r.URL.RawQuery}
+		if err := json.NewDecoder(r.Body).Decode(&rec.body); err != nil {
+			t.Errorf("invalid JSON body: %v", err)
+		}
+		got = append(got, rec)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &got
+}
+
+func TestNew_SelectsSink(t *testing.T) {
+	tests := []struct {
+		url     string
+		want    string
+		wantErr bool
+	}{
+		{url: "https://hooks.example.com/x", want: "webhook"},
+		{url: "slack+https://hooks.slack.com/services/x", want: "slack"},
+		{url: "matrix+https://m.example.org/_matrix/client/v3/rooms/r/send/m.room.message?access_token=t", want: "matrix"},
+		{url: "matrix+https://m.example.org/_matrix/client/v3/rooms/r/send/m.room.message", wantErr: true},
+		{url: "teams+https://example.com/x", wantErr: true},
+		{url: "ftp://example.com/x", wantErr: true},
+		{url: "hooks.example.com/x", wantErr: true},
+	}
+	for _, tt := range tests {
+		s, err := New(tt.url, nil)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("New(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+			continue
+		}
+		if err == nil && typeName(s) != tt.want {
+			t.Errorf("New(%q) = %s sink, want %s", tt.url, typeName(s), tt.want)
+		}
+	}
+}
+
+func typeName(v any) string {
+	switch v.(type) {
+	case *webhookSink:
+		return "webhook"
+	case *slackSink:
+		return "slack"
+	case *matrixSink:
+		return "matrix"
+	}
+	return "unknown"
+}
+
+func TestSinks_Payloads(t *testing.T) {
+	srv, got := recorder(t, http.StatusOK)
+	e := Event{Command: "alias create", Text: "✅ alias create example.com info", Success: true}
+
+	urls := []string{
+		srv.URL + "/hook",
+		"slack+" + srv.URL + "/slack",
+		"matrix+" + srv.URL + "/_matrix/client/v3/rooms/r/send/m.room.message?access_token=secret",
+	}
+	m, err := NewMulti(urls, srv.Client())
+	if err != nil {
+		t.Fatalf("NewMulti: %v", err)
+	}
+	if err := m.Notify(context.Background(), e); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if len(*got) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(*got))
+	}
+
+	hook, slack, matrix := (*got)[0], (*got)[1], (*got)[2]
+	if hook.method != http.MethodPost || hook.body["command"] != "alias create" || hook.body["text"] != e.Text || hook.body["success"] != true {
+		t.Errorf("unexpected webhook request: %+v", hook)
+	}
+	if slack.method != http.MethodPost || len(slack.body) != 1 || slack.body["text"] != e.Text {
+		t.Errorf("unexpected slack request: %+v", slack)
+	}
+	if matrix.method != http.MethodPut || matrix.auth != "Bearer secret" || matrix.query != "" ||
+		!strings.HasPrefix(matrix.path, "/_matrix/client/v3/rooms/r/send/m.room.message/") ||
+		matrix.body["msgtype"] != "m.text" || matrix.body["body"] != e.Text {
+		t.Errorf("unexpected matrix request: %+v", matrix)
+	}
+}
+
+func TestNotify_ErrorStatusRedactsURL(t *testing.T) {
+	srv, _ := recorder(t, http.StatusForbidden)
+	s, err := New(srv.URL+"/services/SECRET", srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.Notify(context.Background(), Event{Text: "x"})
+	if err == nil || !strings.Contains(err.Error(), "403") || strings.Contains(err.Error(), "SECRET") {
+		t.Errorf("expected redacted 403 error, got %v", err)
+	}
+}
+
+func TestNotify_TransportErrorRedactsURL(t *testing.T) {
+	srv, _ := recorder(t, http.StatusOK)
+	target := srv.URL + "/services/T000/B000/SECRET"
+	srv.Close()
+
+	s, err := New("slack+"+target, srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.Notify(context.Background(), Event{Text: "x"})
+	if err == nil {
+		t.Fatal("expected error from closed server")
+	}
+	if strings.Contains(err.Error(), "SECRET") || strings.Contains(err.Error(), "/services/") {
+		t.Errorf("error leaks the webhook path: %v", err)
+	}
+}
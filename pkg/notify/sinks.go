@@ -0,0 +1,105 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// webhookSink posts the whole event as JSON.
+type webhookSink struct {
+	client *http.Client
+	url    string
+}
+
+func newWebhookSink(target *url.URL, client *http.Client) (Sink, error) {
+	return &webhookSink{client: client, url: target.String()}, nil
+}
+
+func (s *webhookSink) Notify(ctx context.Context, e Event) error {
+	return send(ctx, s.client, http.MethodPost, s.url, e, nil)
+}
+
+// slackSink posts only the text, as Slack incoming webhooks reject unknown fields
+// in some workspaces.
+type slackSink struct {
+	client *http.Client
+	url    string
+}
+
+func newSlackSink(target *url.URL, client *http.Client) (Sink, error) {
+	return &slackSink{client: client, url: target.String()}, nil
+}
+
+func (s *slackSink) Notify(ctx context.Context, e Event) error {
+	return send(ctx, s.client, http.MethodPost, s.url, map[string]string{"text": e.Text}, nil)
+}
+
+// matrixSink sends an m.text message through the client-server API. The
+// target is the room's send endpoint,
+// https://<server>/_matrix/client/v3/rooms/<room>/send/m.room.message, with the
+// access token given as the access_token query parameter.
+type matrixSink struct {
+	client *http.Client
+	url    *url.URL
+	token  string
+}
+
+func newMatrixSink(target *url.URL, client *http.Client) (Sink, error) {
+	u := *target
+	q := u.Query()
+	token := q.Get("access_token")
+	if token == "" {
+		return nil, fmt.Errorf("matrix notification URL needs an access_token query parameter")
+	}
+	q.Del("access_token")
+	u.RawQuery = q.Encode()
+	return &matrixSink{client: client, url: &u, token: token}, nil
+}
+
+func (s *matrixSink) Notify(ctx context.Context, e Event) error {
+	txn := make([]byte, 8)
+	if _, err := rand.Read(txn); err != nil {
+		return err
+	}
+	// Sending uses PUT with a client transaction ID so retries are idempotent
+	u := s.url.JoinPath(hex.EncodeToString(txn))
+	headers := map[string]string{"Authorization": "Bearer " + s.token}
+	return send(ctx, s.client, http.MethodPut, u.String(), map[string]string{"msgtype": "m.text", "body": e.Text}, headers)
+}
+
+func send(ctx context.Context, client *http.Client, method, target string, payload any, headers map[string]string) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create notification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		// *url.Error embeds the full URL, which carries the webhook secret
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			urlErr.URL = redact(urlErr.URL)
+		}
+		return fmt.Errorf("failed to post notification to %s: %w", redact(target), err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("notification endpoint %s returned %s", redact(target), resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"net/mail"
+	"strings"
+
+	"github.com/ginsys/forward-email/pkg/api"
+)
+
+// Address book export formats
+const (
+	AddressBookVCard  = "vcf"     // vCard 3.0, for desktop and phone contact apps
+	AddressBookMuttrc = "muttrc"  // mutt/neomutt alias lines
+	AddressBookMailrc = "aliases" // mailx/.mailrc alias lines
+)
+
+// AddressBookFormats lists the address book export formats.
+var AddressBookFormats = []string{AddressBookVCard, AddressBookMuttrc, AddressBookMailrc}
+
+// vCardLineMax is the longest vCard line, in octets, before it is folded.
+const vCardLineMax = 75
+
+// AddressBookEntry is an alias address with the name a mail client shows.
+type AddressBookEntry struct {
+	Nickname    string // the alias name, used as the mutt and mailx alias key
+	Address     string
+	Description string
+	Labels      []string
+}
+
+// NewAddressBookEntries returns the address book entries for the aliases of
+// domain. Disabled aliases, the catch-all and regex aliases are left out, as
+// they are not addresses to write to.
+func NewAddressBookEntries(domain string, aliases []api.Alias) []AddressBookEntry {
+	entries := []AddressBookEntry{}
+	for _, a := range aliases {
+		if !a.IsEnabled || a.Name == "*" || strings.HasPrefix(a.Name, "/") {
+			continue
+		}
+		entries = append(entries, AddressBookEntry{
+			Nickname:    a.Name,
+			Address:     a.Name + "@" + domain,
+			Description: strings.TrimSpace(a.Description),
+			Labels:      a.Labels,
+		})
+	}
+	return entries
+}
+
+// WriteAddressBook writes entries in the given address book format.
+func WriteAddressBook(w io.Writer, format string, entries []AddressBookEntry) error {
+	var b strings.Builder
+	switch format {
+	case AddressBookVCard:
+		for _, e := range entries {
+			writeVCard(&b, e)
+		}
+	case AddressBookMuttrc:
+		for _, e := range entries {
+			addr := mail.Address{Name: e.Description, Address: e.Address}
+			fmt.Fprintf(&b, "alias %s %s\n", e.Nickname, addr.String())
+		}
+	case AddressBookMailrc:
+		for _, e := range entries {
+			if e.Description != "" {
+				fmt.Fprintf(&b, "# %s\n", strings.ReplaceAll(e.Description, "\n", " "))
+			}
+			fmt.Fprintf(&b, "alias %s %s\n", e.Nickname, e.Address)
+		}
+	default:
+		return fmt.Errorf("unsupported address book format %q (supported: %s)", format, strings.Join(AddressBookFormats, ", "))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeVCard writes one contact as a vCard 3.0 (RFC 2426) card.
+func writeVCard(b *strings.Builder, e AddressBookEntry) {
+	name := e.Description
+	if name == "" {
+		name = e.Address
+	}
+	lines := []string{
+		"BEGIN:VCARD",
+		"VERSION:3.0",
+		"FN:" + vCardEscape(name),
+		"N:;" + vCardEscape(name) + ";;;",
+		"EMAIL;TYPE=INTERNET:" + vCardEscape(e.Address),
+	}
+	if e.Description != "" {
+		lines = append(lines, "NOTE:"+vCardEscape(e.Description))
+	}
+	if len(e.Labels) > 0 {
+		categories := make([]string, len(e.Labels))
+		for i, l := range e.Labels {
+			categories[i] = vCardEscape(l)
+		}
+		lines = append(lines, "CATEGORIES:"+strings.Join(categories, ","))
+	}
+	lines = append(lines, "END:VCARD")
+	for _, l := range lines {
+		b.WriteString(vCardFold(l))
+		b.WriteString("\r\n")
+	}
+}
+
+// vCardEscape escapes a vCard text value.
+func vCardEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ",", `\,`, ";", `\;`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// vCardFold folds a content line longer than vCardLineMax octets, continuing
+// it on lines that start with a space, without splitting a UTF-8 sequence.
+func vCardFold(line string) string {
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		n := len(string(r))
+		if width+n > vCardLineMax {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += n
+	}
+	return b.String()
+}
@@ -0,0 +1,66 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ginsys/forward-email/pkg/api"
+)
+
+func TestWriteAddressBook(t *testing.T) {
+	entries := NewAddressBookEntries("example.com", []api.Alias{
+		{Name: "sales", IsEnabled: true, Description: "Sales, EMEA", Labels: []string{"team"}},
+		{Name: "info", IsEnabled: true},
+		{Name: "old", IsEnabled: false},
+		{Name: "*", IsEnabled: true},
+		{Name: "/^ticket-\\d+$/", IsEnabled: true},
+	})
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %+v", entries)
+	}
+
+	var b strings.Builder
+	if err := WriteAddressBook(&b, AddressBookVCard, entries); err != nil {
+		t.Fatal(err)
+	}
+	want := "BEGIN:VCARD\r\nVERSION:3.0\r\nFN:Sales\\, EMEA\r\nN:;Sales\\, EMEA;;;\r\n" +
+		"EMAIL;TYPE=INTERNET:sales@example.com\r\nNOTE:Sales\\, EMEA\r\nCATEGORIES:team\r\nEND:VCARD\r\n" +
+		"BEGIN:VCARD\r\nVERSION:3.0\r\nFN:info@example.com\r\nN:;info@example.com;;;\r\n" +
+		"EMAIL;TYPE=INTERNET:info@example.com\r\nEND:VCARD\r\n"
+	if b.String() != want {
+		t.Errorf("vcf:\n%q\nwant:\n%q", b.String(), want)
+	}
+
+	b.Reset()
+	if err := WriteAddressBook(&b, AddressBookMuttrc, entries); err != nil {
+		t.Fatal(err)
+	}
+	if want := "alias sales \"Sales, EMEA\" <sales@example.com>\nalias info <info@example.com>\n"; b.String() != want {
+		t.Errorf("muttrc:\n%q\nwant:\n%q", b.String(), want)
+	}
+
+	b.Reset()
+	if err := WriteAddressBook(&b, AddressBookMailrc, entries); err != nil {
+		t.Fatal(err)
+	}
+	if want := "# Sales, EMEA\nalias sales sales@example.com\nalias info info@example.com\n"; b.String() != want {
+		t.Errorf("aliases:\n%q\nwant:\n%q", b.String(), want)
+	}
+
+	if err := WriteAddressBook(&b, "ldif", entries); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestVCardFold(t *testing.T) {
+	line := "NOTE:" + strings.Repeat("é", 40)
+	folded := vCardFold(line)
+	for _, l := range strings.Split(folded, "\r\n") {
+		if len(l) > vCardLineMax {
+			t.Errorf("line of %d octets: %q", len(l), l)
+		}
+	}
+	if strings.ReplaceAll(folded, "\r\n ", "") != line {
+		t.Errorf("unfolding %q does not give back the line", folded)
+	}
+}
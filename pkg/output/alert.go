@@ -0,0 +1,42 @@
+package output
+
+import (
+	"fmt"
+)
+
+// QuotaUsage is one quota measurement checked against an alert threshold.
+type QuotaUsage struct {
+	Account   string  `json:"account,omitempty" yaml:"account,omitempty"` // profile, with --all-profiles
+	Scope     string  `json:"scope" yaml:"scope"`                         // "account" or an alias address
+	Metric    string  `json:"metric" yaml:"metric"`                       // "emails" or "storage"
+	Used      int64   `json:"used" yaml:"used"`
+	Limit     int64   `json:"limit" yaml:"limit"`
+	Percent   float64 `json:"percent" yaml:"percent"`
+	Threshold float64 `json:"threshold" yaml:"threshold"`
+	Breached  bool    `json:"breached" yaml:"breached"`
+}
+
+// FormatQuotaUsage formats quota measurements as a table
+func FormatQuotaUsage(usage []QuotaUsage, format Format) (*TableData, error) {
+	if !format.Tabular() {
+		return nil, fmt.Errorf("use direct JSON/YAML encoding for quota usage")
+	}
+
+	table := NewTableData([]string{"SCOPE", "METRIC", "USED", "LIMIT", "USAGE", "THRESHOLD", "STATUS"})
+	for _, u := range usage {
+		used, limit := FormatValue(u.Used), FormatValue(u.Limit)
+		if u.Metric == "storage" {
+			used, limit = FormatBytes(u.Used), FormatBytes(u.Limit)
+		}
+		status := "OK"
+		if u.Breached {
+			status = "ALERT"
+		}
+		table.AddRow([]string{
+			u.Scope, u.Metric, used, limit,
+			fmt.Sprintf("%.1f%%", u.Percent), fmt.Sprintf("%g%%", u.Threshold), status,
+		})
+	}
+
+	return table, nil
+}
@@ -2,6 +2,7 @@ package output
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -10,7 +11,7 @@ import (
 
 // FormatAliasList formats a list of aliases for display
 func FormatAliasList(aliases []api.Alias, format Format, domain string) (*TableData, error) {
-	if format != FormatTable && format != FormatCSV {
+	if !format.Tabular() {
 		// For JSON/YAML, return the aliases directly
 		return nil, fmt.Errorf("use direct JSON/YAML encoding for aliases")
 	}
@@ -32,7 +33,7 @@ func FormatAliasList(aliases []api.Alias, format Format, domain string) (*TableD
 		if alias.CreatedAt.IsZero() {
 			created = "-"
 		} else {
-			created = alias.CreatedAt.Format("2006-01-02")
+			created = FormatTime(alias.CreatedAt, "2006-01-02")
 		}
 
 		row := []string{
@@ -52,7 +53,7 @@ func FormatAliasList(aliases []api.Alias, format Format, domain string) (*TableD
 
 // FormatAliasDetails formats detailed alias information
 func FormatAliasDetails(alias *api.Alias, format Format) (*TableData, error) {
-	if format != FormatTable && format != FormatCSV {
+	if !format.Tabular() {
 		return nil, fmt.Errorf("use direct JSON/YAML encoding for alias details")
 	}
 
@@ -64,8 +65,8 @@ func FormatAliasDetails(alias *api.Alias, format Format) (*TableData, error) {
 	table.AddRow([]string{"Name", alias.Name})
 	table.AddRow([]string{"Domain ID", alias.DomainID})
 	table.AddRow([]string{"Enabled", FormatValue(alias.IsEnabled)})
-	table.AddRow([]string{"Created", alias.CreatedAt.Format(time.RFC3339)})
-	table.AddRow([]string{"Updated", alias.UpdatedAt.Format(time.RFC3339)})
+	table.AddRow([]string{"Created", FormatTime(alias.CreatedAt, time.RFC3339)})
+	table.AddRow([]string{"Updated", FormatTime(alias.UpdatedAt, time.RFC3339)})
 
 	// Recipients
 	if len(alias.Recipients) > 0 {
@@ -109,19 +110,81 @@ func FormatAliasDetails(alias *api.Alias, format Format) (*TableData, error) {
 			table.AddRow([]string{"Vacation Subject", alias.Vacation.Subject})
 		}
 		if !alias.Vacation.StartDate.IsZero() {
-			table.AddRow([]string{"Vacation Start", alias.Vacation.StartDate.Format("2006-01-02")})
+			table.AddRow([]string{"Vacation Start", FormatTime(alias.Vacation.StartDate, "2006-01-02")})
 		}
 		if !alias.Vacation.EndDate.IsZero() {
-			table.AddRow([]string{"Vacation End", alias.Vacation.EndDate.Format("2006-01-02")})
+			table.AddRow([]string{"Vacation End", FormatTime(alias.Vacation.EndDate, "2006-01-02")})
 		}
 	}
 
 	return table, nil
 }
 
+// AliasDetails is an alias as shown by alias get: the API fields together
+// with the address, the recipients that are webhooks and, for IMAP aliases,
+// the settings a mail client needs.
+type AliasDetails struct {
+	api.Alias         `yaml:",inline"`
+	Address           string              `json:"address" yaml:"address"`
+	WebhookRecipients []string            `json:"webhook_recipients,omitempty" yaml:"webhook_recipients,omitempty"`
+	MailClient        *MailClientSettings `json:"mail_client,omitempty" yaml:"mail_client,omitempty"`
+}
+
+// MailClientSettings are the server settings for reading and sending the mail
+// of an IMAP alias.
+type MailClientSettings struct {
+	Username string `json:"username" yaml:"username"`
+	IMAP     string `json:"imap" yaml:"imap"` // host:port, implicit TLS
+	SMTP     string `json:"smtp" yaml:"smtp"` // host:port, implicit TLS
+	POP3     string `json:"pop3" yaml:"pop3"` // host:port, implicit TLS
+}
+
+// FormatAliasFullDetails formats alias details together with the address,
+// webhook recipients and mail client settings
+func FormatAliasFullDetails(d *AliasDetails, format Format) (*TableData, error) {
+	table, err := FormatAliasDetails(&d.Alias, format)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0, len(table.Rows)+6)
+	for _, row := range table.Rows {
+		switch row[0] {
+		case "Recipients":
+			recipients := make([]string, len(d.Recipients))
+			for i, r := range d.Recipients {
+				recipients[i] = r
+				if slices.Contains(d.WebhookRecipients, r) {
+					recipients[i] += " (webhook)"
+				}
+			}
+			row = []string{row[0], strings.Join(recipients, ", ")}
+		case "Domain ID":
+			rows = append(rows, []string{"Address", d.Address})
+		}
+		rows = append(rows, row)
+	}
+
+	if c := d.MailClient; c != nil {
+		password := "not set (see 'alias password')"
+		if d.HasPassword {
+			password = "set"
+		}
+		rows = append(rows,
+			[]string{"Mail Client Username", c.Username},
+			[]string{"Mail Client Password", password},
+			[]string{"IMAP Server", c.IMAP + " (SSL/TLS)"},
+			[]string{"POP3 Server", c.POP3 + " (SSL/TLS)"},
+			[]string{"SMTP Server", c.SMTP + " (SSL/TLS)"},
+		)
+	}
+	table.Rows = rows
+	return table, nil
+}
+
 // FormatAliasQuota formats alias quota information
 func FormatAliasQuota(quota *api.AliasQuota, format Format) (*TableData, error) {
-	if format != FormatTable && format != FormatCSV {
+	if !format.Tabular() {
 		return nil, fmt.Errorf("use direct JSON/YAML encoding for alias quota")
 	}
 
@@ -143,9 +206,54 @@ func FormatAliasQuota(quota *api.AliasQuota, format Format) (*TableData, error)
 	return table, nil
 }
 
+// AliasQuotaUsage is one alias's quota in a domain-wide quota report.
+type AliasQuotaUsage struct {
+	Alias          string  `json:"alias" yaml:"alias"`
+	StorageUsed    int64   `json:"storage_used" yaml:"storage_used"`
+	StorageLimit   int64   `json:"storage_limit" yaml:"storage_limit"`
+	StoragePercent float64 `json:"storage_percent" yaml:"storage_percent"`
+	EmailsSent     int     `json:"emails_sent" yaml:"emails_sent"`
+	EmailsLimit    int     `json:"emails_limit" yaml:"emails_limit"`
+	EmailsPercent  float64 `json:"emails_percent" yaml:"emails_percent"`
+}
+
+// NewAliasQuotaUsage computes the usage percentages of quota; quotas without a
+// limit are reported at 0%.
+func NewAliasQuotaUsage(alias string, quota *api.AliasQuota) AliasQuotaUsage {
+	u := AliasQuotaUsage{
+		Alias:       alias,
+		StorageUsed: quota.StorageUsed, StorageLimit: quota.StorageLimit,
+		EmailsSent: quota.EmailsSent, EmailsLimit: quota.EmailsLimit,
+	}
+	if quota.StorageLimit > 0 {
+		u.StoragePercent = float64(quota.StorageUsed) * 100 / float64(quota.StorageLimit)
+	}
+	if quota.EmailsLimit > 0 {
+		u.EmailsPercent = float64(quota.EmailsSent) * 100 / float64(quota.EmailsLimit)
+	}
+	return u
+}
+
+// FormatAliasQuotaReport formats the quotas of several aliases, one row each
+func FormatAliasQuotaReport(usage []AliasQuotaUsage, format Format) (*TableData, error) {
+	if !format.Tabular() {
+		return nil, fmt.Errorf("use direct JSON/YAML encoding for alias quotas")
+	}
+
+	table := NewTableData([]string{"ALIAS", "STORAGE USED", "STORAGE LIMIT", "STORAGE %", "EMAILS", "EMAIL LIMIT", "EMAILS %"})
+	for _, u := range usage {
+		table.AddRow([]string{
+			u.Alias,
+			FormatBytes(u.StorageUsed), FormatBytes(u.StorageLimit), fmt.Sprintf("%.1f%%", u.StoragePercent),
+			fmt.Sprintf("%d", u.EmailsSent), fmt.Sprintf("%d", u.EmailsLimit), fmt.Sprintf("%.1f%%", u.EmailsPercent),
+		})
+	}
+	return table, nil
+}
+
 // FormatAliasStats formats alias usage statistics
 func FormatAliasStats(stats *api.AliasStats, format Format) (*TableData, error) {
-	if format != FormatTable && format != FormatCSV {
+	if !format.Tabular() {
 		return nil, fmt.Errorf("use direct JSON/YAML encoding for alias stats")
 	}
 
@@ -157,7 +265,7 @@ func FormatAliasStats(stats *api.AliasStats, format Format) (*TableData, error)
 	table.AddRow([]string{"Storage Used", FormatBytes(stats.StorageUsed)})
 
 	if !stats.LastActivity.IsZero() {
-		table.AddRow([]string{"Last Activity", stats.LastActivity.Format(time.RFC3339)})
+		table.AddRow([]string{"Last Activity", FormatTime(stats.LastActivity, time.RFC3339)})
 	}
 
 	if len(stats.RecentSenders) > 0 {
@@ -173,7 +281,7 @@ func FormatAliasStats(stats *api.AliasStats, format Format) (*TableData, error)
 
 // FormatAliasRecipients formats alias recipients for display
 func FormatAliasRecipients(recipients []string, format Format) (*TableData, error) {
-	if format != FormatTable && format != FormatCSV {
+	if !format.Tabular() {
 		return nil, fmt.Errorf("use direct JSON/YAML encoding for recipients")
 	}
 
@@ -193,3 +301,174 @@ func FormatAliasRecipients(recipients []string, format Format) (*TableData, erro
 
 	return table, nil
 }
+
+// AliasOwnership records who is responsible for an alias, as derived from its
+// owner: and team: labels.
+type AliasOwnership struct {
+	Alias  string `json:"alias" yaml:"alias"`
+	Domain string `json:"domain" yaml:"domain"`
+	Owner  string `json:"owner" yaml:"owner"`
+	Team   string `json:"team" yaml:"team"`
+}
+
+// FormatAliasOwnerReport formats alias ownership entries as a table
+func FormatAliasOwnerReport(entries []AliasOwnership, format Format) (*TableData, error) {
+	if !format.Tabular() {
+		return nil, fmt.Errorf("use direct JSON/YAML encoding for owner report")
+	}
+
+	table := NewTableData([]string{"OWNER", "TEAM", "ALIAS"})
+	for _, e := range entries {
+		owner, team := e.Owner, e.Team
+		if owner == "" {
+			owner = "(unowned)"
+		}
+		if team == "" {
+			team = "-"
+		}
+		table.AddRow([]string{owner, team, e.Alias + "@" + e.Domain})
+	}
+
+	return table, nil
+}
+
+// LabelUsage is a label and the aliases that carry it, as listed by
+// `alias labels list`.
+type LabelUsage struct {
+	Label   string   `json:"label" yaml:"label"`
+	Aliases int      `json:"aliases" yaml:"aliases"`
+	Domains []string `json:"domains" yaml:"domains"`
+}
+
+// FormatLabelUsage formats labels as a table, with the number of aliases and
+// the domains using each label when counts is set
+func FormatLabelUsage(labels []LabelUsage, counts bool, format Format) (*TableData, error) {
+	if !format.Tabular() {
+		return nil, fmt.Errorf("use direct JSON/YAML encoding for labels")
+	}
+
+	headers := []string{"LABEL"}
+	if counts {
+		headers = append(headers, "ALIASES", "DOMAINS")
+	}
+	table := NewTableData(headers)
+	for _, l := range labels {
+		row := []string{l.Label}
+		if counts {
+			row = append(row, fmt.Sprintf("%d", l.Aliases), strings.Join(l.Domains, ", "))
+		}
+		table.AddRow(row)
+	}
+
+	return table, nil
+}
+
+// LabelChange is the relabeling of one alias by `alias labels rename` or
+// `alias labels merge`.
+type LabelChange struct {
+	Alias  string   `json:"alias" yaml:"alias"`
+	Domain string   `json:"domain" yaml:"domain"`
+	Before []string `json:"before" yaml:"before"`
+	After  []string `json:"after" yaml:"after"`
+}
+
+// FormatLabelChanges formats relabeled aliases as a table
+func FormatLabelChanges(changes []LabelChange, format Format) (*TableData, error) {
+	if !format.Tabular() {
+		return nil, fmt.Errorf("use direct JSON/YAML encoding for label changes")
+	}
+
+	table := NewTableData([]string{"ALIAS", "BEFORE", "AFTER"})
+	for _, c := range changes {
+		table.AddRow([]string{c.Alias + "@" + c.Domain, strings.Join(c.Before, ", "), strings.Join(c.After, ", ")})
+	}
+
+	return table, nil
+}
+
+// AliasExpiryResult describes what `alias expire run` did with an expired alias.
+type AliasExpiryResult struct {
+	ExpiresAt time.Time `json:"expires_at" yaml:"expires_at"`
+	Alias     string    `json:"alias" yaml:"alias"`
+	Domain    string    `json:"domain" yaml:"domain"`
+	Action    string    `json:"action" yaml:"action"`
+	Error     string    `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// FormatAliasExpiryResults formats expired alias actions as a table
+func FormatAliasExpiryResults(results []AliasExpiryResult, format Format) (*TableData, error) {
+	if !format.Tabular() {
+		return nil, fmt.Errorf("use direct JSON/YAML encoding for expiry results")
+	}
+
+	table := NewTableData([]string{"ALIAS", "EXPIRED", "ACTION"})
+	for _, r := range results {
+		action := r.Action
+		if r.Error != "" {
+			action = "failed: " + r.Error
+		}
+		table.AddRow([]string{r.Alias + "@" + r.Domain, FormatTime(r.ExpiresAt, time.RFC3339), action})
+	}
+
+	return table, nil
+}
+
+// Statuses of a discovered address
+const (
+	DiscoveredExists   = "alias exists"
+	DiscoveredCatchAll = "catch-all"
+	DiscoveredNew      = "new"
+)
+
+// DiscoveredAddress is an address found in existing mail by `alias discover`.
+type DiscoveredAddress struct {
+	LastSeen *time.Time `json:"last_seen,omitempty" yaml:"last_seen,omitempty"`
+	Address  string     `json:"address" yaml:"address"`
+	Status   string     `json:"status" yaml:"status"` // DiscoveredExists, DiscoveredCatchAll or DiscoveredNew
+	Messages int        `json:"messages" yaml:"messages"`
+}
+
+// FormatDiscoveredAddresses formats discovered addresses as a table
+func FormatDiscoveredAddresses(addresses []DiscoveredAddress, format Format) (*TableData, error) {
+	if !format.Tabular() {
+		return nil, fmt.Errorf("use direct JSON/YAML encoding for discovered addresses")
+	}
+
+	table := NewTableData([]string{"ADDRESS", "MESSAGES", "LAST SEEN", "STATUS"})
+	for _, a := range addresses {
+		lastSeen := "-"
+		if a.LastSeen != nil {
+			lastSeen = FormatTime(*a.LastSeen, "2006-01-02")
+		}
+		table.AddRow([]string{a.Address, fmt.Sprintf("%d", a.Messages), lastSeen, a.Status})
+	}
+
+	return table, nil
+}
+
+// WebhookTest is the outcome of `alias webhook test` for one webhook recipient.
+type WebhookTest struct {
+	URL        string  `json:"url" yaml:"url"`
+	Status     string  `json:"status,omitempty" yaml:"status,omitempty"` // e.g. "200 OK"
+	Error      string  `json:"error,omitempty" yaml:"error,omitempty"`
+	StatusCode int     `json:"status_code,omitempty" yaml:"status_code,omitempty"`
+	LatencyMS  float64 `json:"latency_ms" yaml:"latency_ms"`
+}
+
+// FormatWebhookTests formats webhook test deliveries as a table
+func FormatWebhookTests(tests []WebhookTest, format Format) (*TableData, error) {
+	if !format.Tabular() {
+		return nil, fmt.Errorf("use direct JSON/YAML encoding for webhook tests")
+	}
+
+	table := NewTableData([]string{"WEBHOOK", "STATUS", "LATENCY"})
+	for _, t := range tests {
+		status, latency := t.Status, fmt.Sprintf("%.0f ms", t.LatencyMS)
+		if t.Error != "" {
+			status, latency = "failed: "+t.Error, "-"
+		}
+		table.AddRow([]string{t.URL, status, latency})
+	}
+
+	return table, nil
+}
@@ -346,6 +346,45 @@ func TestFormatAliasDetails(t *testing.T) {
 	}
 }
 
+func TestFormatAliasFullDetails(t *testing.T) {
+	d := &AliasDetails{
+		Alias: api.Alias{
+			ID:          "a1",
+			DomainID:    "d1",
+			Name:        "sales",
+			Recipients:  []string{"team@example.org", "https://hooks.example.org/sales"},
+			HasIMAP:     true,
+			HasPassword: false,
+		},
+		Address:           "sales@example.com",
+		WebhookRecipients: []string{"https://hooks.example.org/sales"},
+		MailClient:        &MailClientSettings{Username: "sales@example.com", IMAP: "imap.example:993", POP3: "pop3.example:995", SMTP: "smtp.example:465"},
+	}
+
+	result, err := FormatAliasFullDetails(d, FormatTable)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	rows := map[string]string{}
+	var order []string
+	for _, row := range result.Rows {
+		rows[row[0]] = row[1]
+		order = append(order, row[0])
+	}
+	if order[2] != "Address" || rows["Address"] != "sales@example.com" {
+		t.Errorf("expected the address after the name, got rows %v", order)
+	}
+	if want := "team@example.org, https://hooks.example.org/sales (webhook)"; rows["Recipients"] != want {
+		t.Errorf("Recipients = %q, want %q", rows["Recipients"], want)
+	}
+	if rows["IMAP Server"] != "imap.example:993 (SSL/TLS)" || rows["Mail Client Username"] != "sales@example.com" {
+		t.Errorf("unexpected mail client rows: %v", rows)
+	}
+	if !strings.Contains(rows["Mail Client Password"], "alias password") {
+		t.Errorf("expected a hint to set a password, got %q", rows["Mail Client Password"])
+	}
+}
+
 func TestFormatAliasDetails_MinimalData(t *testing.T) {
 	// Test with minimal alias data (no optional fields)
 	alias := &api.Alias{
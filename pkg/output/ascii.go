@@ -0,0 +1,73 @@
+package output
+
+import (
+	"io"
+	"strings"
+)
+
+// asciiMode is set by SetASCII for --ascii (accessibility mode).
+var asciiMode bool
+
+// asciiReplacer maps the symbols the CLI prints to plain ASCII words and
+// characters. Longer keys come first, so that the more specific replacement
+// wins where several start at the same position.
+var asciiReplacer = strings.NewReplacer(
+	// email delivery states, whose words already say it all
+	"✓ Sent", "Sent",
+	"↩️ Bounced", "Bounced",
+	"⏳ Queued", "Queued",
+
+	// status markers
+	"✅ ", "OK: ", "✅", "OK",
+	"❌ ", "Error: ", "❌", "Error",
+	"⚠️  ", "Warning: ", "⚠️ ", "Warning: ", "⚠️", "Warning",
+	"✓ (", "yes (", "✓ ", "OK: ", "✓", "yes",
+	"✗ ", "Error: ", "✗", "no",
+	"↩️  ", "Undo: ", "↩️", "Undo:",
+
+	// decorations without meaning of their own
+	"📧 ", "", "📋 ", "", "📒 ", "", "🏷️  ", "", "🔍 ", "", "🔐 ", "",
+	"📡 ", "", "🌐 ", "", "📞 ", "", "⏳ ", "",
+
+	// punctuation, arrows and drawing characters
+	"→", "->", "…", "...", "•", "*", "┗", ">", "█", "#", "░", "-",
+)
+
+// SetASCII turns accessibility mode on or off. In accessibility mode tables
+// are drawn with ASCII borders and symbols are replaced by ASCII words, see
+// ToASCII.
+func SetASCII(on bool) {
+	asciiMode = on
+}
+
+// ASCII reports whether accessibility mode is on.
+func ASCII() bool {
+	return asciiMode
+}
+
+// ToASCII replaces the emoji, check marks, arrows and box-drawing characters
+// the CLI prints with ASCII equivalents: "✅ Done" becomes "OK: Done", a lone
+// "✓" in a table cell "yes". Other text, such as names from the API, is
+// returned unchanged.
+func ToASCII(s string) string {
+	return asciiReplacer.Replace(s)
+}
+
+// asciiWriter applies ToASCII to everything written through it.
+type asciiWriter struct {
+	w io.Writer
+}
+
+// NewASCIIWriter returns a writer that passes what is written to w through
+// ToASCII. Each Write is converted on its own, which suits the line and
+// message sized writes of the CLI.
+func NewASCIIWriter(w io.Writer) io.Writer {
+	return asciiWriter{w: w}
+}
+
+func (a asciiWriter) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(a.w, ToASCII(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,59 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestToASCII(t *testing.T) {
+	for in, want := range map[string]string{
+		"✅ Alias 'info' created successfully": "OK: Alias 'info' created successfully",
+		"⚠️  2 warning(s):":                   "Warning: 2 warning(s):",
+		"❌ Deletion canceled":                 "Error: Deletion canceled",
+		"✓ (keyring)":                         "yes (keyring)",
+		"✓":                                   "yes",
+		"✗":                                   "no",
+		"✓ Sent":                              "Sent",
+		"↩️ Bounced":                          "Bounced",
+		"a → b → a":                           "a -> b -> a",
+		"📧 Email Preview:":                    "Email Preview:",
+		"café":                                "café",
+	} {
+		if got := ToASCII(in); got != want {
+			t.Errorf("ToASCII(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestASCIIMode_Formatter(t *testing.T) {
+	SetASCII(true)
+	t.Cleanup(func() { SetASCII(false) })
+
+	data := &TableData{Headers: []string{"NAME", "CURRENT"}, Rows: [][]string{{"default", "✓"}}}
+	var table bytes.Buffer
+	if err := NewFormatter(FormatTable, NewASCIIWriter(&table)).Format(data); err != nil {
+		t.Fatal(err)
+	}
+	if out := table.String(); !strings.Contains(out, "+-") || !strings.Contains(out, "| default | yes") {
+		t.Errorf("expected an ASCII table, got:\n%s", out)
+	}
+
+	// Data formats bypass the conversion.
+	var js bytes.Buffer
+	if err := NewFormatter(FormatJSON, NewASCIIWriter(&js)).Format(map[string]string{"status": "✓ Sent"}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(js.String(), "✓ Sent") {
+		t.Errorf("expected JSON unchanged, got %s", js.String())
+	}
+
+	var dot bytes.Buffer
+	g := &AliasGraph{Nodes: []GraphNode{{ID: "a@x", Kind: NodeAlias}}, Edges: []GraphEdge{{From: "a@x", To: "a@x", Loop: true}}}
+	if err := WriteAliasGraphDOT(&dot, g); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(dot.String(), `label="loop"`) {
+		t.Errorf("expected loop edges to be labeled, got:\n%s", dot.String())
+	}
+}
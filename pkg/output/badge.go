@@ -0,0 +1,56 @@
+package output
+
+import (
+	"fmt"
+	"html"
+	"unicode/utf8"
+)
+
+// Badge colors matching the shields.io palette
+const (
+	BadgeGreen  = "#4c1"
+	BadgeYellow = "#dfb317"
+	BadgeRed    = "#e05d44"
+	BadgeGrey   = "#9f9f9f"
+)
+
+// badgeCharWidth approximates the width in pixels of one character in the
+// 11px Verdana used by shields.io flat badges.
+const badgeCharWidth = 7
+
+// RenderBadge returns a shields.io-style flat SVG badge with a grey label on the
+// left and message on a colored background on the right.
+func RenderBadge(label, message, color string) string {
+	lw := utf8.RuneCountInString(label)*badgeCharWidth + 10
+	mw := utf8.RuneCountInString(message)*badgeCharWidth + 10
+	w := lw + mw
+	label, message = html.EscapeString(label), html.EscapeString(message)
+
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%[1]d" height="20" role="img" aria-label="%[4]s: %[5]s">
+<title>%[4]s: %[5]s</title>
+<linearGradient id="s" x2="0" y2="100%%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>
+<clipPath id="r"><rect width="%[1]d" height="20" rx="3" fill="#fff"/></clipPath>
+<g clip-path="url(#r)"><rect width="%[2]d" height="20" fill="#555"/><rect x="%[2]d" width="%[3]d" height="20" fill="%[6]s"/><rect width="%[1]d" height="20" fill="url(#s)"/></g>
+<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">
+<text x="%[7]d" y="15" fill="#010101" fill-opacity=".3">%[4]s</text><text x="%[7]d" y="14">%[4]s</text>
+<text x="%[8]d" y="15" fill="#010101" fill-opacity=".3">%[5]s</text><text x="%[8]d" y="14">%[5]s</text>
+</g>
+</svg>
+`, w, lw, mw, label, message, color, lw/2, lw+mw/2)
+}
+
+// DomainHealthBadge renders the badge for a domain health summary: green
+// when verified, yellow when records are missing, red when unverified and
+// grey when the check itself failed.
+func DomainHealthBadge(h DomainHealth) string {
+	switch {
+	case h.Error != "":
+		return RenderBadge(h.Domain, "error", BadgeGrey)
+	case h.Verified && len(h.MissingRecords) == 0:
+		return RenderBadge(h.Domain, "verified", BadgeGreen)
+	case h.Verified:
+		return RenderBadge(h.Domain, fmt.Sprintf("%d missing", len(h.MissingRecords)), BadgeYellow)
+	default:
+		return RenderBadge(h.Domain, "unverified", BadgeRed)
+	}
+}
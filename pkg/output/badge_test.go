@@ -0,0 +1,49 @@
+package output
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestRenderBadge(t *testing.T) {
+	svg := RenderBadge("a&b.com", "verified", BadgeGreen)
+
+	if err := xml.Unmarshal([]byte(svg), new(struct{})); err != nil {
+		t.Fatalf("badge is not well-formed XML: %v\n%s", err, svg)
+	}
+	for _, want := range []string{`fill="#4c1"`, "a&amp;b.com", ">verified<"} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("expected badge to contain %q", want)
+		}
+	}
+}
+
+func TestDomainHealthBadge(t *testing.T) {
+	tests := []struct {
+		name   string
+		health DomainHealth
+		want   string
+		color  string
+	}{
+		{"verified", DomainHealth{Domain: "a.com", Verified: true}, ">verified<", BadgeGreen},
+		{"missing records", DomainHealth{Domain: "a.com", Verified: true, MissingRecords: []string{"DMARC"}}, ">1 missing<", BadgeYellow},
+		{"unverified", DomainHealth{Domain: "a.com"}, ">unverified<", BadgeRed},
+		{"error", DomainHealth{Domain: "a.com", Error: "boom"}, ">error<", BadgeGrey},
+		{"error on verified domain", DomainHealth{Domain: "a.com", Verified: true, Error: "boom"}, ">error<", BadgeGrey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svg := DomainHealthBadge(tt.health)
+			if !strings.Contains(svg, tt.want) || !strings.Contains(svg, `fill="`+tt.color+`"`) {
+				t.Errorf("expected %q in %s, got:\n%s", tt.want, tt.color, svg)
+			}
+			for _, other := range []string{BadgeGreen, BadgeYellow, BadgeRed, BadgeGrey} {
+				if other != tt.color && strings.Contains(svg, `fill="`+other+`"`) {
+					t.Errorf("expected only %s, but the badge is also filled %s:\n%s", tt.color, other, svg)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ginsys/forward-email/pkg/contacts"
+)
+
+// FormatContacts formats the contacts and groups of an address book as a
+// table, groups last with their members
+func FormatContacts(book *contacts.Book, format Format) (*TableData, error) {
+	if !format.Tabular() {
+		return nil, fmt.Errorf("use direct JSON/YAML encoding for contacts")
+	}
+
+	table := NewTableData([]string{"NAME", "ADDRESS", "SOURCE"})
+	for _, c := range book.Contacts {
+		source := c.Source
+		if source == "" {
+			source = "local"
+		}
+		table.AddRow([]string{c.Name, c.Address, source})
+	}
+	for _, g := range book.Groups {
+		table.AddRow([]string{"@" + g.Name, strings.Join(g.Members, ", "), "group"})
+	}
+
+	return table, nil
+}
@@ -0,0 +1,55 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FieldChange is one field an update would change.
+type FieldChange struct {
+	Field string `json:"field" yaml:"field"` // JSON field name; nested fields are dotted, e.g. settings.smtp_port
+	Old   any    `json:"old" yaml:"old"`
+	New   any    `json:"new" yaml:"new"`
+}
+
+// FormatFieldChanges formats field changes as a table
+func FormatFieldChanges(changes []FieldChange, format Format) (*TableData, error) {
+	if !format.Tabular() {
+		return nil, fmt.Errorf("use direct JSON/YAML encoding for field changes")
+	}
+
+	table := NewTableData([]string{"FIELD", "OLD", "NEW"})
+	for _, c := range changes {
+		table.AddRow([]string{c.Field, FormatChangeValue(c.Old), FormatChangeValue(c.New)})
+	}
+
+	return table, nil
+}
+
+// FormatChangeValue renders a JSON-decoded value for a diff: lists are joined
+// with commas and unset values show as "-".
+func FormatChangeValue(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return "-"
+	case string:
+		if val == "" {
+			return `""`
+		}
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case []any:
+		if len(val) == 0 {
+			return "[]"
+		}
+		parts := make([]string, len(val))
+		for i, item := range val {
+			parts[i] = FormatChangeValue(item)
+		}
+		return strings.Join(parts, ", ")
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
@@ -2,14 +2,17 @@ package output
 
 import (
 	"fmt"
+	"io"
+	"strings"
 	"time"
 
 	"github.com/ginsys/forward-email/pkg/api"
+	"github.com/ginsys/forward-email/pkg/audit"
 )
 
 // FormatDomainList formats a list of domains for display
 func FormatDomainList(domains []api.Domain, format Format) (*TableData, error) {
-	if format != FormatTable && format != FormatCSV {
+	if !format.Tabular() {
 		// For JSON/YAML, return the domains directly
 		return nil, fmt.Errorf("use direct JSON/YAML encoding for domains")
 	}
@@ -23,7 +26,7 @@ func FormatDomainList(domains []api.Domain, format Format) (*TableData, error) {
 		plan := FormatValue(domain.Plan)
 		aliasCount := "-"
 		memberCount := FormatValue(len(domain.Members))
-		created := domain.CreatedAt.Format("2006-01-02")
+		created := FormatTime(domain.CreatedAt, "2006-01-02")
 
 		row := []string{
 			domain.Name,
@@ -41,7 +44,7 @@ func FormatDomainList(domains []api.Domain, format Format) (*TableData, error) {
 
 // FormatDomainDetails formats detailed domain information
 func FormatDomainDetails(domain *api.Domain, format Format) (*TableData, error) {
-	if format != FormatTable && format != FormatCSV {
+	if !format.Tabular() {
 		return nil, fmt.Errorf("use direct JSON/YAML encoding for domain details")
 	}
 
@@ -71,7 +74,7 @@ func FormatDomainDetails(domain *api.Domain, format Format) (*TableData, error)
 	if domain.HasSMTP {
 		table.AddRow([]string{"SMTP Suspended", FormatValue(domain.IsSMTPSuspended)})
 		if !domain.SMTPVerifiedAt.IsZero() {
-			table.AddRow([]string{"SMTP Verified", domain.SMTPVerifiedAt.Format(time.RFC3339)})
+			table.AddRow([]string{"SMTP Verified", FormatTime(domain.SMTPVerifiedAt, time.RFC3339)})
 		}
 	}
 
@@ -109,6 +112,9 @@ func FormatDomainDetails(domain *api.Domain, format Format) (*TableData, error)
 	if len(domain.Denylist) > 0 {
 		table.AddRow([]string{"Denylist", FormatValue(len(domain.Denylist)) + " addresses"})
 	}
+	if len(domain.RestrictedAliasNames) > 0 {
+		table.AddRow([]string{"Restricted Alias Names", TruncateString(strings.Join(domain.RestrictedAliasNames, ", "), 50)})
+	}
 
 	// === Verification Settings ===
 	table.AddRow([]string{"Recipient Verification", FormatValue(domain.HasRecipientVerification)})
@@ -156,15 +162,15 @@ func FormatDomainDetails(domain *api.Domain, format Format) (*TableData, error)
 	table.AddRow([]string{"Pending Invitations", FormatValue(len(domain.Invitations))})
 
 	// === Timestamps ===
-	table.AddRow([]string{"Created", domain.CreatedAt.Format(time.RFC3339)})
-	table.AddRow([]string{"Updated", domain.UpdatedAt.Format(time.RFC3339)})
+	table.AddRow([]string{"Created", FormatTime(domain.CreatedAt, time.RFC3339)})
+	table.AddRow([]string{"Updated", FormatTime(domain.UpdatedAt, time.RFC3339)})
 
 	return table, nil
 }
 
 // FormatDNSRecords formats DNS records for display
 func FormatDNSRecords(records []api.DNSRecord, format Format) (*TableData, error) {
-	if format != FormatTable && format != FormatCSV {
+	if !format.Tabular() {
 		return nil, fmt.Errorf("use direct JSON/YAML encoding for DNS records")
 	}
 
@@ -201,9 +207,80 @@ func FormatDNSRecords(records []api.DNSRecord, format Format) (*TableData, error
 	return table, nil
 }
 
+// DNSRecordKeys returns a short key for each record, such as "mx1",
+// "verification", "spf", "dmarc" or "dkim", for picking a record by name and
+// naming its environment variable. Records of no known kind are keyed by
+// type and position, e.g. "txt6".
+func DNSRecordKeys(records []api.DNSRecord) []string {
+	keys := make([]string, len(records))
+	mx := 0
+	for i, r := range records {
+		value := strings.ToLower(r.Value)
+		switch {
+		case strings.EqualFold(r.Type, "MX"):
+			mx++
+			keys[i] = fmt.Sprintf("mx%d", mx)
+		case strings.HasPrefix(value, "forward-email-site-verification="):
+			keys[i] = "verification"
+		case strings.HasPrefix(value, "v=spf1"):
+			keys[i] = "spf"
+		case strings.HasPrefix(value, "v=dmarc1"):
+			keys[i] = "dmarc"
+		case strings.HasPrefix(value, "v=dkim1"):
+			keys[i] = "dkim"
+		default:
+			keys[i] = fmt.Sprintf("%s%d", strings.ToLower(r.Type), i+1)
+		}
+	}
+	return keys
+}
+
+// WriteDNSRecordsEnv writes records as shell variable assignments,
+// DNS_<KEY>=value, plus DNS_<KEY>_NAME for records not at the zone apex,
+// quoted for sh and for .env files.
+func WriteDNSRecordsEnv(w io.Writer, records []api.DNSRecord) error {
+	var b strings.Builder
+	for i, key := range DNSRecordKeys(records) {
+		name := "DNS_" + strings.ToUpper(key)
+		fmt.Fprintf(&b, "%s=%s\n", name, shellQuote(records[i].Value))
+		if records[i].Name != "" && records[i].Name != "@" {
+			fmt.Fprintf(&b, "%s_NAME=%s\n", name, shellQuote(records[i].Name))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteDNSRecordsMarkdown writes records as a Markdown table with untruncated
+// values, for tickets and documentation.
+func WriteDNSRecordsMarkdown(w io.Writer, records []api.DNSRecord) error {
+	var b strings.Builder
+	b.WriteString("| Type | Name | Value | Priority | TTL | Required | Purpose |\n")
+	b.WriteString("|------|------|-------|----------|-----|----------|---------|\n")
+	cell := strings.NewReplacer("|", `\|`, "\n", " ")
+	for _, r := range records {
+		priority, ttl := "-", "-"
+		if r.Priority > 0 {
+			priority = FormatValue(r.Priority)
+		}
+		if r.TTL > 0 {
+			ttl = FormatValue(r.TTL)
+		}
+		fmt.Fprintf(&b, "| %s | %s | `%s` | %s | %s | %s | %s |\n", cell.Replace(r.Type), cell.Replace(r.Name),
+			cell.Replace(r.Value), priority, ttl, FormatValue(r.Required), cell.Replace(r.Purpose))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// shellQuote single-quotes s for POSIX shells.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
 // FormatDomainVerification formats domain verification status
 func FormatDomainVerification(verification *api.DomainVerification, format Format) (*TableData, error) {
-	if format != FormatTable && format != FormatCSV {
+	if !format.Tabular() {
 		return nil, fmt.Errorf("use direct JSON/YAML encoding for domain verification")
 	}
 
@@ -213,7 +290,7 @@ func FormatDomainVerification(verification *api.DomainVerification, format Forma
 	table.AddRow([]string{"Verified", FormatValue(verification.IsVerified)})
 	table.AddRow([]string{"DNS Records Found", FormatValue(len(verification.DNSRecords))})
 	table.AddRow([]string{"Missing Records", FormatValue(len(verification.MissingRecords))})
-	table.AddRow([]string{"Last Checked", verification.LastCheckedAt.Format(time.RFC3339)})
+	table.AddRow([]string{"Last Checked", FormatTime(verification.LastCheckedAt, time.RFC3339)})
 
 	if verification.VerificationURL != "" {
 		table.AddRow([]string{"Verification URL", verification.VerificationURL})
@@ -231,7 +308,7 @@ func FormatDomainVerification(verification *api.DomainVerification, format Forma
 
 // FormatDomainMembers formats domain members list
 func FormatDomainMembers(members []api.DomainMember, format Format) (*TableData, error) {
-	if format != FormatTable && format != FormatCSV {
+	if !format.Tabular() {
 		return nil, fmt.Errorf("use direct JSON/YAML encoding for domain members")
 	}
 
@@ -253,10 +330,206 @@ func FormatDomainMembers(members []api.DomainMember, format Format) (*TableData,
 			member.User.Email,
 			displayName,
 			member.Group,
-			member.JoinedAt.Format("2006-01-02"),
+			FormatTime(member.JoinedAt, "2006-01-02"),
 		}
 		table.AddRow(row)
 	}
 
 	return table, nil
 }
+
+// DomainInvitationStatus is a pending domain invitation and whether it has
+// expired.
+type DomainInvitationStatus struct {
+	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+	ExpiresAt time.Time `json:"expires_at" yaml:"expires_at"`
+	ID        string    `json:"id" yaml:"id"`
+	Email     string    `json:"email" yaml:"email"`
+	Group     string    `json:"group" yaml:"group"`
+	Expired   bool      `json:"expired" yaml:"expired"`
+}
+
+// NewDomainInvitationStatuses returns the status of each invitation as of now,
+// keeping only the expired ones when expiredOnly is set.
+func NewDomainInvitationStatuses(invitations []api.DomainInvitation, now time.Time, expiredOnly bool) []DomainInvitationStatus {
+	statuses := []DomainInvitationStatus{}
+	for _, inv := range invitations {
+		expired := !inv.ExpiresAt.IsZero() && !inv.ExpiresAt.After(now)
+		if expiredOnly && !expired {
+			continue
+		}
+		statuses = append(statuses, DomainInvitationStatus{
+			CreatedAt: inv.CreatedAt,
+			ExpiresAt: inv.ExpiresAt,
+			ID:        inv.ID,
+			Email:     inv.Email,
+			Group:     inv.Group,
+			Expired:   expired,
+		})
+	}
+	return statuses
+}
+
+// FormatDomainInvitations formats domain invitations for display, flagging
+// the expired ones
+func FormatDomainInvitations(invitations []DomainInvitationStatus, format Format) (*TableData, error) {
+	if !format.Tabular() {
+		return nil, fmt.Errorf("use direct JSON/YAML encoding for domain invitations")
+	}
+
+	table := NewTableData([]string{"EMAIL", "GROUP", "CREATED", "EXPIRES", "STATUS"})
+	for _, inv := range invitations {
+		status := "pending"
+		if inv.Expired {
+			status = "⚠️ expired"
+		}
+		table.AddRow([]string{
+			inv.Email,
+			inv.Group,
+			FormatTime(inv.CreatedAt, "2006-01-02"),
+			FormatTime(inv.ExpiresAt, "2006-01-02 15:04"),
+			status,
+		})
+	}
+
+	return table, nil
+}
+
+// DomainHealth is a compact, machine-readable verification summary for a domain,
+// intended for dashboards and monitoring.
+type DomainHealth struct {
+	LastChecked    time.Time `json:"last_checked" yaml:"last_checked"`
+	Domain         string    `json:"domain" yaml:"domain"`
+	Error          string    `json:"error,omitempty" yaml:"error,omitempty"`
+	MissingRecords []string  `json:"missing_records" yaml:"missing_records"`
+	Verified       bool      `json:"verified" yaml:"verified"`
+}
+
+// NewDomainHealth summarizes the DNS verification state of domain as of checkedAt.
+func NewDomainHealth(domain *api.Domain, checkedAt time.Time) DomainHealth {
+	missing := []string{}
+	for _, r := range []struct {
+		name string
+		ok   bool
+	}{
+		{"MX", domain.HasMXRecord},
+		{"TXT", domain.HasTXTRecord},
+		{"SPF", domain.HasSPFRecord},
+		{"DKIM", domain.HasDKIMRecord},
+		{"DMARC", domain.HasDMARCRecord},
+	} {
+		if !r.ok {
+			missing = append(missing, r.name)
+		}
+	}
+	return DomainHealth{
+		Domain:         domain.Name,
+		Verified:       domain.IsVerified,
+		MissingRecords: missing,
+		LastChecked:    checkedAt.UTC(),
+	}
+}
+
+// FormatDomainHealth formats domain health summaries as a table
+func FormatDomainHealth(health []DomainHealth, format Format) (*TableData, error) {
+	if !format.Tabular() {
+		return nil, fmt.Errorf("use direct JSON/YAML encoding for domain health")
+	}
+
+	table := NewTableData([]string{"DOMAIN", "VERIFIED", "MISSING", "LAST CHECKED"})
+	for _, h := range health {
+		missing := strings.Join(h.MissingRecords, ", ")
+		if h.Error != "" {
+			missing = "error: " + h.Error
+		} else if missing == "" {
+			missing = "-"
+		}
+		table.AddRow([]string{h.Domain, FormatValue(h.Verified), missing, FormatTime(h.LastChecked, time.RFC3339)})
+	}
+
+	return table, nil
+}
+
+// FormatDomainAudit formats a baseline audit report, one row per rule
+func FormatDomainAudit(report *audit.Report, format Format) (*TableData, error) {
+	if !format.Tabular() {
+		return nil, fmt.Errorf("use direct JSON/YAML encoding for audit reports")
+	}
+
+	table := NewTableData([]string{"RULE", "STATUS", "DETAIL"})
+	for _, r := range report.Results {
+		status := "PASS"
+		if !r.Passed {
+			status = "FAIL"
+		}
+		table.AddRow([]string{r.Rule, status, r.Detail})
+	}
+	return table, nil
+}
+
+// Outcomes of a bulk domain update for one domain
+const (
+	DomainBulkChanged   = "changed"
+	DomainBulkUnchanged = "unchanged"
+	DomainBulkPlanned   = "would change" // --dry-run
+	DomainBulkFailed    = "failed"
+)
+
+// DomainBulkResult is what a bulk domain update did, or with --dry-run would
+// do, to one domain.
+type DomainBulkResult struct {
+	Domain  string        `json:"domain" yaml:"domain"`
+	Status  string        `json:"status" yaml:"status"`
+	Changes []FieldChange `json:"changes,omitempty" yaml:"changes,omitempty"`
+	Error   string        `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// FormatDomainBulkResults formats bulk domain update results as a table
+func FormatDomainBulkResults(results []DomainBulkResult, format Format) (*TableData, error) {
+	if !format.Tabular() {
+		return nil, fmt.Errorf("use direct JSON/YAML encoding for bulk update results")
+	}
+
+	table := NewTableData([]string{"DOMAIN", "STATUS", "CHANGES"})
+	for _, r := range results {
+		changes := make([]string, len(r.Changes))
+		for i, c := range r.Changes {
+			changes[i] = fmt.Sprintf("%s: %s → %s", c.Field, FormatChangeValue(c.Old), FormatChangeValue(c.New))
+		}
+		detail := strings.Join(changes, "; ")
+		if r.Error != "" {
+			detail = "error: " + r.Error
+		} else if detail == "" {
+			detail = "-"
+		}
+		table.AddRow([]string{r.Domain, r.Status, detail})
+	}
+
+	return table, nil
+}
+
+// DomainRetention is the retention period of one domain, for `domain retention report`.
+type DomainRetention struct {
+	Domain        string `json:"domain" yaml:"domain"`
+	Plan          string `json:"plan" yaml:"plan"`
+	RetentionDays int    `json:"retention_days" yaml:"retention_days"`
+	Drift         bool   `json:"drift" yaml:"drift"` // differs from the expected period
+}
+
+// FormatDomainRetention formats domain retention periods as a table
+func FormatDomainRetention(retention []DomainRetention, format Format) (*TableData, error) {
+	if !format.Tabular() {
+		return nil, fmt.Errorf("use direct JSON/YAML encoding for retention reports")
+	}
+
+	table := NewTableData([]string{"DOMAIN", "PLAN", "RETENTION", "STATUS"})
+	for _, r := range retention {
+		status := "ok"
+		if r.Drift {
+			status = "drift"
+		}
+		table.AddRow([]string{r.Domain, r.Plan, fmt.Sprintf("%d days", r.RetentionDays), status})
+	}
+
+	return table, nil
+}
@@ -1,6 +1,7 @@
 package output
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -293,6 +294,37 @@ func TestFormatDomainVerification(t *testing.T) {
 	}
 }
 
+func TestNewDomainHealth(t *testing.T) {
+	checked := time.Date(2024, 5, 1, 12, 0, 0, 0, time.FixedZone("CEST", 2*3600))
+	domain := &api.Domain{
+		Name:          "example.com",
+		IsVerified:    true,
+		HasMXRecord:   true,
+		HasTXTRecord:  true,
+		HasSPFRecord:  true,
+		HasDKIMRecord: false,
+	}
+
+	h := NewDomainHealth(domain, checked)
+	if !h.Verified || h.Domain != "example.com" {
+		t.Errorf("unexpected health: %+v", h)
+	}
+	if got := strings.Join(h.MissingRecords, ","); got != "DKIM,DMARC" {
+		t.Errorf("expected missing DKIM,DMARC, got %s", got)
+	}
+	if h.LastChecked.Location() != time.UTC || !h.LastChecked.Equal(checked) {
+		t.Errorf("expected last_checked in UTC, got %v", h.LastChecked)
+	}
+
+	table, err := FormatDomainHealth([]DomainHealth{h, {Domain: "broken.org", Error: "not found"}}, FormatTable)
+	if err != nil {
+		t.Fatalf("FormatDomainHealth failed: %v", err)
+	}
+	if len(table.Rows) != 2 || table.Rows[0][2] != "DKIM, DMARC" || table.Rows[1][2] != "error: not found" {
+		t.Errorf("unexpected rows: %v", table.Rows)
+	}
+}
+
 func TestFormatDomainMembers(t *testing.T) {
 	members := []api.DomainMember{
 		{
@@ -465,3 +497,53 @@ func TestFormatPercentage(t *testing.T) {
 		}
 	}
 }
+
+func TestDNSRecordKeys(t *testing.T) {
+	records := []api.DNSRecord{
+		{Type: "MX", Value: "mx1.forwardemail.net"},
+		{Type: "MX", Value: "mx2.forwardemail.net"},
+		{Type: "TXT", Value: "forward-email-site-verification=abc"},
+		{Type: "TXT", Value: "v=spf1 include:spf.forwardemail.net -all"},
+		{Type: "TXT", Name: "_dmarc", Value: "v=DMARC1; p=none"},
+		{Type: "TXT", Name: "fe._domainkey", Value: "v=DKIM1; k=rsa; p=MIIB"},
+		{Type: "CNAME", Name: "fe-bounces", Value: "forwardemail.net"},
+	}
+	got := strings.Join(DNSRecordKeys(records), ",")
+	if want := "mx1,mx2,verification,spf,dmarc,dkim,cname7"; got != want {
+		t.Errorf("DNSRecordKeys() = %s, want %s", got, want)
+	}
+
+	var b strings.Builder
+	if err := WriteDNSRecordsEnv(&b, []api.DNSRecord{{Type: "TXT", Name: "@", Value: "it's"}}); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != "DNS_TXT1='it'\\''s'\n" {
+		t.Errorf("unexpected env output %q", b.String())
+	}
+}
+
+func TestNewDomainInvitationStatuses(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	invitations := []api.DomainInvitation{
+		{Email: "old@example.org", ExpiresAt: now.Add(-time.Hour)},
+		{Email: "due@example.org", ExpiresAt: now},
+		{Email: "new@example.org", ExpiresAt: now.Add(time.Hour)},
+		{Email: "open@example.org"},
+	}
+
+	var expired []string
+	for _, s := range NewDomainInvitationStatuses(invitations, now, false) {
+		if s.Expired {
+			expired = append(expired, s.Email)
+		}
+	}
+	if strings.Join(expired, ",") != "old@example.org,due@example.org" {
+		t.Errorf("expired = %v", expired)
+	}
+	if got := NewDomainInvitationStatuses(invitations, now, true); len(got) != 2 {
+		t.Errorf("expected 2 expired invitations, got %+v", got)
+	}
+	if got := NewDomainInvitationStatuses(nil, now, false); got == nil {
+		t.Error("expected an empty, non-nil slice")
+	}
+}
@@ -2,22 +2,57 @@ package output
 
 import (
 	"fmt"
+	"math"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/ginsys/forward-email/pkg/api"
 )
 
+// EmailListColumns are the columns of an email list, in default order.
+var EmailListColumns = []string{"id", "from", "to", "subject", "status", "attachments", "sent"}
+
+// EmailListOptions adjusts how an email list is rendered.
+type EmailListOptions struct {
+	Columns     []string // Columns to show, from EmailListColumns; all when empty
+	FullSubject bool     // Never shorten the subject in tables
+	MaxWidth    int      // Shorten from, to and subject to this width in tables instead of the defaults
+}
+
 // FormatEmailList formats a list of emails for display
 func FormatEmailList(emails []api.Email, format Format) (*TableData, error) {
-	if format != FormatTable && format != FormatCSV {
+	return FormatEmailListWithOptions(emails, format, EmailListOptions{})
+}
+
+// FormatEmailListWithOptions formats a list of emails for display with the
+// given column selection and truncation limits. Unknown columns are an error.
+func FormatEmailListWithOptions(emails []api.Email, format Format, opts EmailListOptions) (*TableData, error) {
+	if !format.Tabular() {
 		// For JSON/YAML, return the emails directly
 		return nil, fmt.Errorf("use direct JSON/YAML encoding for emails")
 	}
 
-	headers := []string{"ID", "FROM", "TO", "SUBJECT", "STATUS", "ATTACHMENTS", "SENT"}
+	columns := slices.Clone(opts.Columns)
+	if len(columns) == 0 {
+		columns = slices.Clone(EmailListColumns)
+	}
+	headers := make([]string, len(columns))
+	for i, col := range columns {
+		col = strings.ToLower(strings.TrimSpace(col))
+		if !slices.Contains(EmailListColumns, col) {
+			return nil, fmt.Errorf("invalid column '%s'. Valid columns: %s", col, strings.Join(EmailListColumns, ", "))
+		}
+		columns[i] = col
+		headers[i] = strings.ToUpper(col)
+	}
 	table := NewTableData(headers)
 
+	fromWidth, toWidth, subjectWidth := 25, 30, 40
+	if opts.MaxWidth > 0 {
+		fromWidth, toWidth, subjectWidth = opts.MaxWidth, opts.MaxWidth, opts.MaxWidth
+	}
+
 	for _, email := range emails {
 		var id, from, to, subject string
 
@@ -40,12 +75,12 @@ func FormatEmailList(emails []api.Email, format Format) (*TableData, error) {
 		} else {
 			// For table, truncate for readability
 			id = TruncateString(email.ID, 8)
-			from = TruncateString(fromHeader, 25)
-			to = toHeader
-			if len(to) > 30 {
-				to = TruncateString(to, 27) + "..."
+			from = TruncateString(fromHeader, fromWidth)
+			to = TruncateString(toHeader, toWidth)
+			subject = email.Subject
+			if !opts.FullSubject {
+				subject = TruncateString(subject, subjectWidth)
 			}
-			subject = TruncateString(email.Subject, 40)
 		}
 
 		status := FormatEmailStatus(email.Status)
@@ -59,17 +94,21 @@ func FormatEmailList(emails []api.Email, format Format) (*TableData, error) {
 		if email.SentAt.IsZero() {
 			sent = "-"
 		} else {
-			sent = email.SentAt.Format("2006-01-02 15:04")
+			sent = FormatTime(email.SentAt, "2006-01-02 15:04")
 		}
 
-		row := []string{
-			id,
-			from,
-			to,
-			subject,
-			status,
-			attachments,
-			sent,
+		values := map[string]string{
+			"id":          id,
+			"from":        from,
+			"to":          to,
+			"subject":     subject,
+			"status":      status,
+			"attachments": attachments,
+			"sent":        sent,
+		}
+		row := make([]string, len(columns))
+		for i, col := range columns {
+			row[i] = values[col]
 		}
 		table.AddRow(row)
 	}
@@ -79,7 +118,7 @@ func FormatEmailList(emails []api.Email, format Format) (*TableData, error) {
 
 // FormatEmailDetails formats detailed email information
 func FormatEmailDetails(email *api.Email, format Format) (*TableData, error) {
-	if format != FormatTable && format != FormatCSV {
+	if !format.Tabular() {
 		return nil, fmt.Errorf("use direct JSON/YAML encoding for email details")
 	}
 
@@ -125,11 +164,11 @@ func FormatEmailDetails(email *api.Email, format Format) (*TableData, error) {
 		table.AddRow([]string{"Status Info", email.StatusInfo})
 	}
 
-	table.AddRow([]string{"Sent At", email.SentAt.Format(time.RFC3339)})
+	table.AddRow([]string{"Sent At", FormatTime(email.SentAt, time.RFC3339)})
 	if email.DeliveredAt != nil {
-		table.AddRow([]string{"Delivered At", email.DeliveredAt.Format(time.RFC3339)})
+		table.AddRow([]string{"Delivered At", FormatTime(*email.DeliveredAt, time.RFC3339)})
 	}
-	table.AddRow([]string{"Created At", email.CreatedAt.Format(time.RFC3339)})
+	table.AddRow([]string{"Created At", FormatTime(email.CreatedAt, time.RFC3339)})
 
 	// Content preview
 	if email.Text != "" {
@@ -184,7 +223,7 @@ func FormatEmailDetails(email *api.Email, format Format) (*TableData, error) {
 
 // FormatEmailQuota formats email quota information
 func FormatEmailQuota(quota *api.EmailQuota, format Format) (*TableData, error) {
-	if format != FormatTable && format != FormatCSV {
+	if !format.Tabular() {
 		return nil, fmt.Errorf("use direct JSON/YAML encoding for email quota")
 	}
 
@@ -208,9 +247,72 @@ func FormatEmailQuota(quota *api.EmailQuota, format Format) (*TableData, error)
 	return table, nil
 }
 
+// QuotaForecast projects the daily email quota to its next reset from the
+// sending rate since the previous one.
+type QuotaForecast struct {
+	ResetTime     time.Time  `json:"reset_time" yaml:"reset_time"`
+	ExhaustedAt   *time.Time `json:"exhausted_at,omitempty" yaml:"exhausted_at,omitempty"` // when the quota runs out before the reset at the current rate
+	EmailsSent    int        `json:"emails_sent" yaml:"emails_sent"`
+	EmailsLimit   int        `json:"emails_limit" yaml:"emails_limit"`
+	Remaining     int        `json:"remaining" yaml:"remaining"`
+	ResetInSecs   int64      `json:"reset_in_seconds" yaml:"reset_in_seconds"`
+	RatePerHour   float64    `json:"rate_per_hour" yaml:"rate_per_hour"`
+	ProjectedSent int        `json:"projected_sent" yaml:"projected_sent"` // emails sent by the reset at the current rate
+}
+
+// NewQuotaForecast forecasts quota as of now. The quota window is the 24
+// hours before its reset time.
+func NewQuotaForecast(quota *api.EmailQuota, now time.Time) QuotaForecast {
+	f := QuotaForecast{
+		ResetTime:     quota.ResetTime,
+		EmailsSent:    quota.EmailsSent,
+		EmailsLimit:   quota.EmailsLimit,
+		Remaining:     max(0, quota.EmailsLimit-quota.EmailsSent),
+		ProjectedSent: quota.EmailsSent,
+	}
+	if quota.ResetTime.IsZero() {
+		return f
+	}
+	untilReset := max(0, quota.ResetTime.Sub(now))
+	f.ResetInSecs = int64(untilReset.Seconds())
+	elapsed := now.Sub(quota.ResetTime.Add(-24 * time.Hour))
+	if elapsed <= 0 || quota.EmailsSent == 0 {
+		return f
+	}
+	f.RatePerHour = float64(quota.EmailsSent) / elapsed.Hours()
+	f.ProjectedSent = quota.EmailsSent + int(math.Round(f.RatePerHour*untilReset.Hours()))
+	if f.ProjectedSent > quota.EmailsLimit && quota.EmailsLimit > 0 {
+		at := now.Add(time.Duration(float64(f.Remaining) / f.RatePerHour * float64(time.Hour))).Truncate(time.Second)
+		f.ExhaustedAt = &at
+	}
+	return f
+}
+
+// FormatQuotaForecast formats a quota forecast for display
+func FormatQuotaForecast(f QuotaForecast, format Format) (*TableData, error) {
+	if !format.Tabular() {
+		return nil, fmt.Errorf("use direct JSON/YAML encoding for quota forecasts")
+	}
+
+	table := NewTableData([]string{"PROPERTY", "VALUE"})
+	table.AddRow([]string{"Emails Sent", fmt.Sprintf("%d of %d", f.EmailsSent, f.EmailsLimit)})
+	table.AddRow([]string{"Remaining", fmt.Sprintf("%d", f.Remaining)})
+	table.AddRow([]string{"Resets At", FormatTime(f.ResetTime, time.RFC3339)})
+	table.AddRow([]string{"Resets In", (time.Duration(f.ResetInSecs) * time.Second).String()})
+	table.AddRow([]string{"Sending Rate", fmt.Sprintf("%.1f/hour", f.RatePerHour)})
+	table.AddRow([]string{"Projected At Reset", fmt.Sprintf("%d (%s)", f.ProjectedSent,
+		FormatPercentage(int64(f.ProjectedSent), int64(f.EmailsLimit)))})
+	runsOut := "Not at the current rate"
+	if f.ExhaustedAt != nil {
+		runsOut = FormatTime(*f.ExhaustedAt, time.RFC3339)
+	}
+	table.AddRow([]string{"Runs Out", runsOut})
+	return table, nil
+}
+
 // FormatEmailAttachments formats email attachments for display
 func FormatEmailAttachments(attachments []api.EmailAttachment, format Format) (*TableData, error) {
-	if format != FormatTable && format != FormatCSV {
+	if !format.Tabular() {
 		return nil, fmt.Errorf("use direct JSON/YAML encoding for attachments")
 	}
 
@@ -252,3 +354,44 @@ func FormatEmailStatus(status string) string {
 		return status
 	}
 }
+
+// EmailReportRow aggregates the outcomes of the emails in one report group.
+type EmailReportRow struct {
+	Group      string  `json:"group" yaml:"group"`
+	Sent       int     `json:"sent" yaml:"sent"` // all emails in the group, whatever their status
+	Delivered  int     `json:"delivered" yaml:"delivered"`
+	Bounced    int     `json:"bounced" yaml:"bounced"`
+	Failed     int     `json:"failed" yaml:"failed"`
+	BounceRate float64 `json:"bounce_rate" yaml:"bounce_rate"` // percentage of Sent
+}
+
+// EmailReport is a delivery report over a date range.
+type EmailReport struct {
+	Since   string           `json:"since,omitempty" yaml:"since,omitempty"` // YYYY-MM-DD
+	Until   string           `json:"until,omitempty" yaml:"until,omitempty"` // YYYY-MM-DD
+	GroupBy string           `json:"group_by" yaml:"group_by"`
+	Groups  []EmailReportRow `json:"groups" yaml:"groups"`
+	Total   EmailReportRow   `json:"total" yaml:"total"`
+}
+
+// FormatEmailReport formats an email delivery report as a table, ending with a total row
+func FormatEmailReport(report *EmailReport, format Format) (*TableData, error) {
+	if !format.Tabular() {
+		return nil, fmt.Errorf("use direct JSON/YAML encoding for email reports")
+	}
+
+	header := strings.ToUpper(strings.ReplaceAll(report.GroupBy, "-", " "))
+	table := NewTableData([]string{header, "SENT", "DELIVERED", "BOUNCED", "FAILED", "BOUNCE RATE"})
+	for _, r := range append(report.Groups, report.Total) {
+		table.AddRow([]string{
+			r.Group,
+			fmt.Sprintf("%d", r.Sent),
+			fmt.Sprintf("%d", r.Delivered),
+			fmt.Sprintf("%d", r.Bounced),
+			fmt.Sprintf("%d", r.Failed),
+			fmt.Sprintf("%.1f%%", r.BounceRate),
+		})
+	}
+
+	return table, nil
+}
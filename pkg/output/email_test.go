@@ -0,0 +1,79 @@
+package output
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ginsys/forward-email/pkg/api"
+)
+
+func TestFormatEmailListWithOptions(t *testing.T) {
+	subject := "Quarterly report for the operations team, including the appendix"
+	emails := []api.Email{{
+		ID:      "0123456789abcdef",
+		Subject: subject,
+		Status:  "delivered",
+		Headers: map[string]string{"From": "reports@example.com", "To": "operations-team@example.org"},
+	}}
+
+	table, err := FormatEmailList(emails, FormatTable)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(table.Headers, ","); got != "ID,FROM,TO,SUBJECT,STATUS,ATTACHMENTS,SENT" {
+		t.Errorf("default headers = %s", got)
+	}
+	if got := table.Rows[0][3]; got != subject[:37]+"..." {
+		t.Errorf("default subject = %q", got)
+	}
+
+	table, err = FormatEmailListWithOptions(emails, FormatTable, EmailListOptions{
+		Columns: []string{"subject", " To "}, FullSubject: true, MaxWidth: 12,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(table.Headers, ","); got != "SUBJECT,TO" {
+		t.Errorf("selected headers = %s", got)
+	}
+	if table.Rows[0][0] != subject || table.Rows[0][1] != "operation..." {
+		t.Errorf("unexpected row %q", table.Rows[0])
+	}
+	if EmailListColumns[0] != "id" {
+		t.Error("column selection must not modify EmailListColumns")
+	}
+
+	if _, err := FormatEmailListWithOptions(emails, FormatTable, EmailListOptions{Columns: []string{"cc"}}); err == nil {
+		t.Error("expected an error for an unknown column")
+	}
+}
+
+func TestNewQuotaForecast(t *testing.T) {
+	reset := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	now := reset.Add(-12 * time.Hour)
+
+	f := NewQuotaForecast(&api.EmailQuota{ResetTime: reset, EmailsSent: 120, EmailsLimit: 200}, now)
+	if f.RatePerHour != 10 || f.ProjectedSent != 240 || f.Remaining != 80 || f.ResetInSecs != 12*3600 {
+		t.Errorf("unexpected forecast %+v", f)
+	}
+	if f.ExhaustedAt == nil || !f.ExhaustedAt.Equal(now.Add(8*time.Hour)) {
+		t.Errorf("ExhaustedAt = %v, want %v", f.ExhaustedAt, now.Add(8*time.Hour))
+	}
+
+	f = NewQuotaForecast(&api.EmailQuota{ResetTime: reset, EmailsSent: 60, EmailsLimit: 200}, now)
+	if f.ProjectedSent != 120 || f.ExhaustedAt != nil {
+		t.Errorf("unexpected forecast %+v", f)
+	}
+
+	table, err := FormatQuotaForecast(f, FormatTable)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := table.Rows[len(table.Rows)-1]; got[0] != "Runs Out" || got[1] != "Not at the current rate" {
+		t.Errorf("unexpected last row %q", got)
+	}
+	if _, err := FormatQuotaForecast(f, FormatJSON); err == nil {
+		t.Error("expected an error for JSON")
+	}
+}
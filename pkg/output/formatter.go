@@ -9,10 +9,15 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/tw"
 	"golang.org/x/term"
 	yaml "gopkg.in/yaml.v3"
+
+	"github.com/ginsys/forward-email/pkg/i18n"
+	"github.com/ginsys/forward-email/pkg/units"
 )
 
 // Standard labels for boolean value formatting across all output formats.
@@ -28,19 +33,33 @@ type Format string
 
 // Output format constants for different display types
 const (
-	FormatTable Format = "table" // Human-readable table with proper column alignment
-	FormatJSON  Format = "json"  // Machine-readable JSON for API integration
-	FormatYAML  Format = "yaml"  // Human-readable YAML for configuration
-	FormatCSV   Format = "csv"   // Comma-separated values for spreadsheet import
-	FormatPlain Format = "plain" // Borderless, fixed-width columns without truncation
+	FormatTable    Format = "table"    // Human-readable table with proper column alignment
+	FormatJSON     Format = "json"     // Machine-readable JSON for API integration
+	FormatYAML     Format = "yaml"     // Human-readable YAML for configuration
+	FormatCSV      Format = "csv"      // Comma-separated values for spreadsheet import
+	FormatPlain    Format = "plain"    // Borderless, fixed-width columns without truncation
+	FormatGHA      Format = "gha"      // Plain columns plus a GitHub Actions step summary
+	FormatMarkdown Format = "markdown" // GitHub-flavored Markdown tables for issues and wikis
 )
 
+// Tabular reports whether f renders TableData, as opposed to JSON and YAML,
+// which encode the data itself.
+func (f Format) Tabular() bool {
+	switch f {
+	case FormatTable, FormatCSV, FormatPlain, FormatGHA, FormatMarkdown:
+		return true
+	default:
+		return false
+	}
+}
+
 // Formatter handles output formatting for CLI responses.
 // It supports multiple output formats and provides consistent formatting
 // across all CLI commands with proper terminal width detection and alignment.
 type Formatter struct {
-	format Format    // The output format to use for rendering
-	writer io.Writer // The output destination (typically os.Stdout)
+	format         Format    // The output format to use for rendering
+	writer         io.Writer // The output destination (typically os.Stdout)
+	maxColumnWidth int       // Widest a table column may be; 0 sizes columns automatically
 }
 
 // NewFormatter creates a new output formatter with the specified format and writer.
@@ -50,12 +69,24 @@ func NewFormatter(format Format, writer io.Writer) *Formatter {
 	if writer == nil {
 		writer = os.Stdout
 	}
+	// Data formats carry the data as is, also in accessibility mode.
+	if a, ok := writer.(asciiWriter); ok && (format == FormatJSON || format == FormatYAML || format == FormatCSV) {
+		writer = a.w
+	}
 	return &Formatter{
 		format: format,
 		writer: writer,
 	}
 }
 
+// SetMaxColumnWidth caps every table column at n characters, wrapping longer
+// cells, and replaces the automatic per-column caps used when a table is wider
+// than the terminal. n <= 0 restores automatic sizing. It returns f.
+func (f *Formatter) SetMaxColumnWidth(n int) *Formatter {
+	f.maxColumnWidth = n
+	return f
+}
+
 // Format renders the provided data in the formatter's configured output format.
 // It handles type detection, proper formatting, and output generation for tables,
 // JSON, YAML, and CSV formats. The data structure determines the specific formatting logic.
@@ -71,13 +102,84 @@ func (f *Formatter) Format(data interface{}) error {
 		return f.formatCSV(data)
 	case FormatPlain:
 		return f.formatPlain(data)
+	case FormatGHA:
+		return f.formatGHA(data)
+	case FormatMarkdown:
+		return f.formatMarkdown(data)
 	default:
 		return fmt.Errorf("unsupported format: %s", f.format)
 	}
 }
 
+// documentConverter is set by SetDocumentConverter.
+var documentConverter func(any) (any, error)
+
+// SetDocumentConverter makes the JSON and YAML formatters pass every document
+// through convert first, decoded into generic JSON values, to print it in an
+// older schema version (see package output/schema). nil turns conversion off.
+func SetDocumentConverter(convert func(any) (any, error)) {
+	documentConverter = convert
+}
+
+// sortKeys is set by SetSortKeys.
+var sortKeys bool
+
+// SetSortKeys makes the JSON and YAML formatters print object keys in
+// alphabetical order instead of the order of the Go struct fields, so that
+// documents compare line by line whatever version of the CLI wrote them.
+func SetSortKeys(on bool) {
+	sortKeys = on
+}
+
+// convertDocument applies the document converter, if any, to data. With
+// sorted keys, data is decoded into generic JSON values, whose object keys
+// both encoders sort.
+func convertDocument(data interface{}) (interface{}, error) {
+	if documentConverter == nil && !sortKeys {
+		return data, nil
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var doc any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	if documentConverter != nil {
+		if doc, err = documentConverter(doc); err != nil {
+			return nil, err
+		}
+	}
+	return wholeNumbers(doc), nil
+}
+
+// wholeNumbers replaces the float64 values of a generic document that are
+// whole numbers with int64, which YAML prints as 1024 rather than 1.024e+03.
+func wholeNumbers(v any) any {
+	switch val := v.(type) {
+	case float64:
+		if val == math.Trunc(val) && math.Abs(val) < 1<<53 {
+			return int64(val)
+		}
+	case map[string]any:
+		for k, item := range val {
+			val[k] = wholeNumbers(item)
+		}
+	case []any:
+		for i, item := range val {
+			val[i] = wholeNumbers(item)
+		}
+	}
+	return v
+}
+
 // formatJSON outputs data as JSON
 func (f *Formatter) formatJSON(data interface{}) error {
+	data, err := convertDocument(data)
+	if err != nil {
+		return err
+	}
 	encoder := json.NewEncoder(f.writer)
 	encoder.SetIndent("", "  ")
 	return encoder.Encode(data)
@@ -85,6 +187,10 @@ func (f *Formatter) formatJSON(data interface{}) error {
 
 // formatYAML outputs data as YAML
 func (f *Formatter) formatYAML(data interface{}) error {
+	data, err := convertDocument(data)
+	if err != nil {
+		return err
+	}
 	encoder := yaml.NewEncoder(f.writer)
 	defer func() { _ = encoder.Close() }()
 	return encoder.Encode(data)
@@ -93,22 +199,25 @@ func (f *Formatter) formatYAML(data interface{}) error {
 // formatTable outputs data as a table using tablewriter
 func (f *Formatter) formatTable(data interface{}) error {
 	table := tablewriter.NewWriter(f.writer)
+	if asciiMode {
+		table = tablewriter.NewTable(f.writer, tablewriter.WithSymbols(tw.NewSymbols(tw.StyleASCII)))
+	}
 
 	// Get terminal width for column sizing
 	terminalWidth := getTerminalWidth()
 
 	switch v := data.(type) {
 	case TableData:
-		table.Header(convertToInterface(v.Headers)...)
+		table.Header(convertToInterface(translateHeaders(v.Headers))...)
 		// Apply intelligent text wrapping for long content using current terminal width
-		wrappedRows := f.wrapTableContentWithWidth(v.Rows, v.Headers, terminalWidth)
+		wrappedRows := f.wrapTableContentWithWidth(asciiRows(v.Rows), v.Headers, terminalWidth)
 		for _, row := range wrappedRows {
 			_ = table.Append(convertToInterface(row)...)
 		}
 	case *TableData:
-		table.Header(convertToInterface(v.Headers)...)
+		table.Header(convertToInterface(translateHeaders(v.Headers))...)
 		// Apply intelligent text wrapping for long content using current terminal width
-		wrappedRows := f.wrapTableContentWithWidth(v.Rows, v.Headers, terminalWidth)
+		wrappedRows := f.wrapTableContentWithWidth(asciiRows(v.Rows), v.Headers, terminalWidth)
 		for _, row := range wrappedRows {
 			_ = table.Append(convertToInterface(row)...)
 		}
@@ -119,6 +228,23 @@ func (f *Formatter) formatTable(data interface{}) error {
 	return table.Render()
 }
 
+// asciiRows returns rows with every cell passed through ToASCII in
+// accessibility mode, before column widths are measured, and rows unchanged
+// otherwise.
+func asciiRows(rows [][]string) [][]string {
+	if !asciiMode {
+		return rows
+	}
+	converted := make([][]string, len(rows))
+	for i, row := range rows {
+		converted[i] = make([]string, len(row))
+		for j, cell := range row {
+			converted[i][j] = ToASCII(cell)
+		}
+	}
+	return converted
+}
+
 // wrapTableContent intelligently wraps long content in table cells (deprecated, use wrapTableContentWithWidth)
 // Removed: wrapTableContent (deprecated)
 
@@ -189,13 +315,16 @@ func (f *Formatter) calculateColumnWidthsForTerminal(headers []string, rows [][]
 		totalContentWidth += width
 	}
 
-	if totalContentWidth <= availableWidth {
-		// Content fits naturally, no need to truncate
-		return colWidths
+	if totalContentWidth > availableWidth {
+		// Content doesn't fit, use intelligent width distribution
+		colWidths = f.distributeWidthIntelligently(headers, colWidths, availableWidth)
 	}
 
-	// Content doesn't fit, use intelligent width distribution
-	colWidths = f.distributeWidthIntelligently(headers, colWidths, availableWidth)
+	if f.maxColumnWidth > 0 {
+		for i := range colWidths {
+			colWidths[i] = minInt(colWidths[i], f.maxColumnWidth)
+		}
+	}
 
 	return colWidths
 }
@@ -217,7 +346,7 @@ func (f *Formatter) distributeWidthIntelligently(headers []string, originalWidth
 			priorityColumns[i] = true
 		}
 		// Content columns: potentially long text that can be wrapped effectively
-		if headerLower == "name" || headerLower == "description" || headerLower == "labels" {
+		if headerLower == "name" || headerLower == "description" || headerLower == "labels" || headerLower == "subject" {
 			contentColumns[i] = true
 		}
 	}
@@ -255,8 +384,10 @@ func (f *Formatter) distributeWidthIntelligently(headers []string, originalWidth
 		reasonableWidth := originalWidths[i]
 		// Cap at sensible maximums based on column type
 		headerLower := strings.ToLower(headers[i])
-		switch headerLower {
-		case "recipients":
+		switch {
+		case f.maxColumnWidth > 0:
+			reasonableWidth = minInt(reasonableWidth, f.maxColumnWidth)
+		case headerLower == "recipients":
 			// Recipients need enough space for email addresses
 			reasonableWidth = minInt(reasonableWidth, 30)
 		default:
@@ -353,6 +484,9 @@ func wrapText(text string, width int) string {
 
 	// Reserve space for continuation indicator on wrapped lines
 	continuationIndicator := "┗ "
+	if asciiMode {
+		continuationIndicator = "> "
+	}
 	continuationSpace := len(continuationIndicator)
 
 	for _, word := range words {
@@ -510,28 +644,31 @@ func (f *Formatter) formatPlainTable(td *TableData) error {
 		return nil
 	}
 
+	headers := td.Headers
+	rows := asciiRows(td.Rows)
+
 	// Calculate column widths based on actual content (no truncation)
-	colWidths := make([]int, len(td.Headers))
+	colWidths := make([]int, len(headers))
 
 	// Initialize with header widths
-	for i, header := range td.Headers {
-		colWidths[i] = len(header)
+	for i, header := range headers {
+		colWidths[i] = utf8.RuneCountInString(header)
 	}
 
 	// Update with row content widths
-	for _, row := range td.Rows {
+	for _, row := range rows {
 		for i, cell := range row {
-			if i < len(colWidths) && len(cell) > colWidths[i] {
-				colWidths[i] = len(cell)
+			if i < len(colWidths) && utf8.RuneCountInString(cell) > colWidths[i] {
+				colWidths[i] = utf8.RuneCountInString(cell)
 			}
 		}
 	}
 
 	// Format and write headers
-	headerParts := make([]string, len(td.Headers))
-	for i, header := range td.Headers {
+	headerParts := make([]string, len(headers))
+	for i, header := range headers {
 		// Don't pad the last column to avoid trailing spaces
-		if i == len(td.Headers)-1 {
+		if i == len(headers)-1 {
 			headerParts[i] = header
 		} else {
 			headerParts[i] = padRight(header, colWidths[i])
@@ -540,7 +677,7 @@ func (f *Formatter) formatPlainTable(td *TableData) error {
 	_, _ = fmt.Fprintln(f.writer, strings.Join(headerParts, "  "))
 
 	// Format and write rows
-	for _, row := range td.Rows {
+	for _, row := range rows {
 		rowParts := make([]string, len(row))
 		for i, cell := range row {
 			// Don't pad the last column to avoid trailing spaces
@@ -560,10 +697,22 @@ func (f *Formatter) formatPlainTable(td *TableData) error {
 
 // padRight pads a string with spaces on the right to reach the desired width
 func padRight(s string, width int) string {
-	if len(s) >= width {
+	n := utf8.RuneCountInString(s)
+	if n >= width {
 		return s
 	}
-	return s + strings.Repeat(" ", width-len(s))
+	return s + strings.Repeat(" ", width-n)
+}
+
+// translateHeaders returns the column headers in the selected language, for
+// the formats people read. Plain and CSV keep the English headers that
+// scripts match.
+func translateHeaders(headers []string) []string {
+	translated := make([]string, len(headers))
+	for i, h := range headers {
+		translated[i] = i18n.T(h)
+	}
+	return translated
 }
 
 // TableData represents tabular data
@@ -624,23 +773,7 @@ func TruncateString(s string, maxLen int) string {
 
 // FormatBytes formats bytes as human-readable string
 func FormatBytes(bytes int64) string {
-	const unit = 1024 // Use 1024 for binary units (KiB, MiB, GiB, etc.)
-	if bytes < unit {
-		return fmt.Sprintf("%d B", bytes)
-	}
-	div, exp := int64(unit), 0
-	units := []string{"K", "M", "G", "T", "P", "E"}
-	for n := bytes / unit; n >= unit; n /= unit {
-		div *= unit
-		exp++
-		if exp >= len(units) {
-			break
-		}
-	}
-	if exp >= len(units) {
-		exp = len(units) - 1
-	}
-	return fmt.Sprintf("%.1f %sB", float64(bytes)/float64(div), units[exp])
+	return units.FormatBytes(bytes)
 }
 
 // FormatPercentage formats a percentage value
@@ -665,6 +798,10 @@ func ParseFormat(s string) (Format, error) {
 		return FormatCSV, nil
 	case "plain":
 		return FormatPlain, nil
+	case "gha":
+		return FormatGHA, nil
+	case "markdown", "md":
+		return FormatMarkdown, nil
 	default:
 		return "", fmt.Errorf("unsupported format: %s", s)
 	}
@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	yaml "gopkg.in/yaml.v3"
+
+	"github.com/ginsys/forward-email/pkg/i18n"
 )
 
 func TestNewFormatter(t *testing.T) {
@@ -512,3 +514,86 @@ func Test_convertToInterface_Empty(t *testing.T) {
 		t.Errorf("Expected empty slice, got length %d", len(result))
 	}
 }
+
+func TestFormatter_SetMaxColumnWidth(t *testing.T) {
+	headers := []string{"ID", "SUBJECT"}
+	rows := [][]string{{"1", "a subject that is much longer than the cap"}}
+
+	f := NewFormatter(FormatTable, nil)
+	if got := f.calculateColumnWidthsForTerminal(headers, rows, 200); got[1] != len(rows[0][1]) {
+		t.Errorf("automatic width = %d, want the natural %d", got[1], len(rows[0][1]))
+	}
+	if got := f.SetMaxColumnWidth(15).calculateColumnWidthsForTerminal(headers, rows, 200); got[0] != 2 || got[1] != 15 {
+		t.Errorf("capped widths = %v, want [2 15]", got)
+	}
+
+	var buf bytes.Buffer
+	if err := NewFormatter(FormatTable, &buf).SetMaxColumnWidth(15).Format(&TableData{Headers: headers, Rows: rows}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "┗ ") {
+		t.Errorf("expected the long cell to wrap:\n%s", buf.String())
+	}
+}
+
+func TestFormatter_TranslatedHeaders(t *testing.T) {
+	if err := i18n.SetLanguage("de"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = i18n.SetLanguage(i18n.English) })
+
+	data := &TableData{Headers: []string{"SIZE", "SUBJECT"}, Rows: [][]string{{"1 KB", "Hello"}}}
+	var table bytes.Buffer
+	if err := NewFormatter(FormatTable, &table).Format(data); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(table.String(), "GRÖSSE") || !strings.Contains(table.String(), "BETREFF") {
+		t.Errorf("expected German table headers, got:\n%s", table.String())
+	}
+
+	var plain bytes.Buffer
+	if err := NewFormatter(FormatPlain, &plain).Format(data); err != nil {
+		t.Fatal(err)
+	}
+	if want := "SIZE  SUBJECT\n1 KB  Hello\n"; plain.String() != want {
+		t.Errorf("plain output = %q, want %q", plain.String(), want)
+	}
+
+	var csv bytes.Buffer
+	if err := NewFormatter(FormatCSV, &csv).Format(data); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(csv.String(), "SIZE,SUBJECT\n") {
+		t.Errorf("expected English CSV headers, got %q", csv.String())
+	}
+}
+
+func TestFormatter_SortKeys(t *testing.T) {
+	SetSortKeys(true)
+	t.Cleanup(func() { SetSortKeys(false) })
+
+	doc := struct {
+		Name    string         `json:"name"`
+		Quota   int64          `json:"quota"`
+		Enabled bool           `json:"enabled"`
+		Extra   map[string]int `json:"extra"`
+	}{Name: "info", Quota: 107374182400, Enabled: true, Extra: map[string]int{"b": 2, "a": 1}}
+
+	var buf bytes.Buffer
+	if err := NewFormatter(FormatJSON, &buf).Format(doc); err != nil {
+		t.Fatal(err)
+	}
+	want := "{\n  \"enabled\": true,\n  \"extra\": {\n    \"a\": 1,\n    \"b\": 2\n  },\n  \"name\": \"info\",\n  \"quota\": 107374182400\n}\n"
+	if buf.String() != want {
+		t.Errorf("JSON:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := NewFormatter(FormatYAML, &buf).Format(doc); err != nil {
+		t.Fatal(err)
+	}
+	want = "enabled: true\nextra:\n    a: 1\n    b: 2\nname: info\nquota: 107374182400\n"
+	if buf.String() != want {
+		t.Errorf("YAML:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,87 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// StepSummaryEnv names the file GitHub Actions renders as the step summary.
+const StepSummaryEnv = "GITHUB_STEP_SUMMARY"
+
+// Annotation levels of GitHub Actions workflow commands.
+const (
+	AnnotationError   = "error"
+	AnnotationWarning = "warning"
+	AnnotationNotice  = "notice"
+)
+
+// Annotation is a GitHub Actions annotation, shown on the workflow run and
+// in pull request checks.
+type Annotation struct {
+	Level   string // AnnotationError, AnnotationWarning or AnnotationNotice
+	Title   string
+	Message string
+}
+
+// WriteAnnotations writes annotations as workflow commands such as
+// "::error title=Quota alert::emails at 95.0%".
+func WriteAnnotations(w io.Writer, annotations []Annotation) error {
+	for _, a := range annotations {
+		props := ""
+		if a.Title != "" {
+			props = " title=" + escapeProperty(a.Title)
+		}
+		if _, err := fmt.Fprintf(w, "::%s%s::%s\n", a.Level, props, escapeData(a.Message)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// escapeData escapes the message of a workflow command.
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeProperty escapes a property value of a workflow command.
+func escapeProperty(s string) string {
+	return strings.NewReplacer(":", "%3A", ",", "%2C").Replace(escapeData(s))
+}
+
+// AppendStepSummary appends markdown to the step summary file named by
+// $GITHUB_STEP_SUMMARY. It does nothing outside GitHub Actions.
+func AppendStepSummary(markdown string) error {
+	path := os.Getenv(StepSummaryEnv)
+	if path == "" {
+		return nil
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600) //nolint:gosec // path is set by the Actions runner
+	if err != nil {
+		return fmt.Errorf("failed to open step summary: %w", err)
+	}
+	if _, err := io.WriteString(f, markdown); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write step summary: %w", err)
+	}
+	return f.Close()
+}
+
+// formatGHA prints a table as plain columns for the job log and appends it
+// as a Markdown table to the step summary.
+func (f *Formatter) formatGHA(data interface{}) error {
+	var td *TableData
+	switch v := data.(type) {
+	case TableData:
+		td = &v
+	case *TableData:
+		td = v
+	default:
+		return fmt.Errorf("gha format requires TableData struct")
+	}
+	if err := f.formatPlainTable(td); err != nil {
+		return err
+	}
+	return AppendStepSummary(markdownTable(td) + "\n")
+}
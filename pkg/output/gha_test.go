@@ -0,0 +1,87 @@
+package output
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteAnnotations(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteAnnotations(&buf, []Annotation{
+		{Level: AnnotationError, Title: "example.com: dkim", Message: "1024-bit key"},
+		{Level: AnnotationWarning, Message: "100% used\nsecond line"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "::error title=example.com%3A dkim::1024-bit key\n" +
+		"::warning::100%25 used%0Asecond line\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestFormatGHA(t *testing.T) {
+	summary := filepath.Join(t.TempDir(), "summary.md")
+	t.Setenv(StepSummaryEnv, summary)
+
+	td := NewTableData([]string{"RULE", "STATUS"})
+	td.AddRow([]string{"a|b", "PASS"})
+	td.AddRow([]string{"multi\nline"})
+
+	var buf bytes.Buffer
+	if err := NewFormatter(FormatGHA, &buf).Format(td); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(buf.String(), "RULE  ") {
+		t.Errorf("expected plain columns in the job log, got:\n%s", buf.String())
+	}
+
+	data, err := os.ReadFile(summary) //nolint:gosec // path is inside the test's temp dir
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "| RULE | STATUS |\n| --- | --- |\n| a\\|b | PASS |\n| multi<br>line |  |\n\n"
+	if string(data) != want {
+		t.Errorf("step summary = %q, want %q", data, want)
+	}
+
+	// Further output is appended rather than replacing the summary.
+	if err := AppendStepSummary("done\n"); err != nil {
+		t.Fatal(err)
+	}
+	data, _ = os.ReadFile(summary) //nolint:gosec // path is inside the test's temp dir
+	if !strings.HasSuffix(string(data), "\n\ndone\n") {
+		t.Errorf("expected the summary to be appended to, got %q", data)
+	}
+
+	if err := NewFormatter(FormatGHA, &buf).Format(map[string]string{}); err == nil {
+		t.Error("expected an error for data that is not a table")
+	}
+}
+
+func TestAppendStepSummary_OutsideActions(t *testing.T) {
+	t.Setenv(StepSummaryEnv, "")
+	if err := AppendStepSummary("ignored"); err != nil {
+		t.Errorf("expected no error outside GitHub Actions, got %v", err)
+	}
+}
+
+func TestFormat_Tabular(t *testing.T) {
+	for _, f := range []Format{FormatTable, FormatCSV, FormatPlain, FormatGHA} {
+		if !f.Tabular() {
+			t.Errorf("%s should be tabular", f)
+		}
+	}
+	for _, f := range []Format{FormatJSON, FormatYAML} {
+		if f.Tabular() {
+			t.Errorf("%s should not be tabular", f)
+		}
+	}
+	if f, err := ParseFormat("GHA"); err != nil || f != FormatGHA {
+		t.Errorf("ParseFormat(GHA) = %q, %v", f, err)
+	}
+}
@@ -0,0 +1,150 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Graph output formats, accepted only by commands that render graphs
+const (
+	FormatDOT     Format = "dot"     // Graphviz DOT
+	FormatMermaid Format = "mermaid" // Mermaid flowchart
+)
+
+// Kinds of alias graph nodes
+const (
+	NodeAlias   = "alias"   // an alias of one of the graphed domains
+	NodeAddress = "address" // an outside email address
+	NodeWebhook = "webhook" // an HTTP(S) webhook
+	NodeHost    = "host"    // a mail server given by host name or IP
+)
+
+// GraphNode is one alias or recipient in an alias graph.
+type GraphNode struct {
+	ID       string `json:"id" yaml:"id"` // the address, URL or host
+	Kind     string `json:"kind" yaml:"kind"`
+	Disabled bool   `json:"disabled,omitempty" yaml:"disabled,omitempty"` // a disabled alias
+}
+
+// GraphEdge forwards mail from an alias to a recipient.
+type GraphEdge struct {
+	From string `json:"from" yaml:"from"`
+	To   string `json:"to" yaml:"to"`
+	Loop bool   `json:"loop,omitempty" yaml:"loop,omitempty"` // part of a forwarding loop
+}
+
+// AliasGraph is the forwarding topology of a set of aliases. Loops lists each
+// forwarding loop as the aliases on it, in forwarding order.
+type AliasGraph struct {
+	Nodes []GraphNode `json:"nodes" yaml:"nodes"`
+	Edges []GraphEdge `json:"edges" yaml:"edges"`
+	Loops [][]string  `json:"loops" yaml:"loops"`
+}
+
+// FormatAliasGraphEdges formats the edges of an alias graph as a table
+func FormatAliasGraphEdges(g *AliasGraph, format Format) (*TableData, error) {
+	if !format.Tabular() {
+		return nil, fmt.Errorf("use direct JSON/YAML encoding for alias graphs")
+	}
+
+	kinds := make(map[string]string, len(g.Nodes))
+	for _, n := range g.Nodes {
+		kinds[n.ID] = n.Kind
+	}
+	table := NewTableData([]string{"ALIAS", "RECIPIENT", "KIND", "LOOP"})
+	for _, e := range g.Edges {
+		loop := ""
+		if e.Loop {
+			loop = "yes"
+		}
+		table.AddRow([]string{e.From, e.To, kinds[e.To], loop})
+	}
+	return table, nil
+}
+
+// WriteAliasGraphDOT writes g as a Graphviz digraph. Aliases are boxes,
+// disabled ones dashed, and loop edges are red; in accessibility mode they
+// are also labeled, so the loop is not signaled by color alone.
+func WriteAliasGraphDOT(w io.Writer, g *AliasGraph) error {
+	var b strings.Builder
+	b.WriteString("digraph aliases {\n  rankdir=LR;\n  node [fontname=\"Helvetica\"];\n")
+	for _, n := range g.Nodes {
+		attrs := []string{"label=" + dotQuote(n.ID)}
+		switch n.Kind {
+		case NodeAlias:
+			attrs = append(attrs, "shape=box")
+		case NodeWebhook:
+			attrs = append(attrs, "shape=component")
+		case NodeHost:
+			attrs = append(attrs, "shape=cylinder")
+		default:
+			attrs = append(attrs, "shape=ellipse")
+		}
+		if n.Disabled {
+			attrs = append(attrs, "style=dashed")
+		}
+		fmt.Fprintf(&b, "  %s [%s];\n", dotQuote(n.ID), strings.Join(attrs, ", "))
+	}
+	for _, e := range g.Edges {
+		attr := ""
+		if e.Loop {
+			attr = " [color=red, penwidth=2]"
+			if asciiMode {
+				attr = " [color=red, penwidth=2, label=\"loop\"]"
+			}
+		}
+		fmt.Fprintf(&b, "  %s -> %s%s;\n", dotQuote(e.From), dotQuote(e.To), attr)
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteAliasGraphMermaid writes g as a Mermaid flowchart with the same
+// conventions as WriteAliasGraphDOT.
+func WriteAliasGraphMermaid(w io.Writer, g *AliasGraph) error {
+	ids := make(map[string]string, len(g.Nodes))
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for i, n := range g.Nodes {
+		id := fmt.Sprintf("n%d", i)
+		ids[n.ID] = id
+		label := strings.ReplaceAll(n.ID, `"`, "#quot;")
+		switch n.Kind {
+		case NodeAlias:
+			fmt.Fprintf(&b, "  %s[\"%s\"]\n", id, label)
+		case NodeWebhook:
+			fmt.Fprintf(&b, "  %s[/\"%s\"/]\n", id, label)
+		case NodeHost:
+			fmt.Fprintf(&b, "  %s[(\"%s\")]\n", id, label)
+		default:
+			fmt.Fprintf(&b, "  %s([\"%s\"])\n", id, label)
+		}
+		if n.Disabled {
+			fmt.Fprintf(&b, "  class %s disabled\n", id)
+		}
+	}
+	var loopLinks []string
+	for i, e := range g.Edges {
+		if e.Loop && asciiMode {
+			fmt.Fprintf(&b, "  %s -- loop --> %s\n", ids[e.From], ids[e.To])
+		} else {
+			fmt.Fprintf(&b, "  %s --> %s\n", ids[e.From], ids[e.To])
+		}
+		if e.Loop {
+			loopLinks = append(loopLinks, fmt.Sprintf("%d", i))
+		}
+	}
+	b.WriteString("  classDef disabled stroke-dasharray: 5 5\n")
+	if len(loopLinks) > 0 {
+		fmt.Fprintf(&b, "  linkStyle %s stroke:red,stroke-width:2px\n", strings.Join(loopLinks, ","))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// dotQuote quotes s as a DOT identifier.
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
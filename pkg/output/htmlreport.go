@@ -0,0 +1,312 @@
+package output
+
+import (
+	_ "embed" // for the report template
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ginsys/forward-email/pkg/audit"
+)
+
+// Tones color a headline figure or chart bar in an HTML report.
+const (
+	ToneGood = "good"
+	ToneBad  = "bad"
+	ToneWarn = "warn"
+)
+
+// HTMLReport is a standalone HTML page for people who do not use the CLI:
+// headline figures, bar charts drawn as inline SVG, and tables.
+type HTMLReport struct {
+	Generated time.Time
+	Title     string
+	Subtitle  string
+	Stats     []HTMLStat
+	Charts    []HTMLChart
+	Tables    []HTMLTable
+}
+
+// HTMLStat is a headline figure.
+type HTMLStat struct {
+	Label string
+	Value string
+	Tone  string
+}
+
+// HTMLChart is a horizontal bar chart.
+type HTMLChart struct {
+	Title string
+	Bars  []HTMLBar
+}
+
+// HTMLBar is one bar of a chart. Display is the value as shown next to the
+// bar; empty shows Value.
+type HTMLBar struct {
+	Label   string
+	Display string
+	Value   float64
+	Tone    string
+}
+
+// HTMLTable is a table with a caption.
+type HTMLTable struct {
+	Caption string
+	Data    *TableData
+}
+
+//go:embed templates/report.html
+var reportTemplateText string
+
+var reportTemplate = template.Must(template.New("report").Parse(reportTemplateText))
+
+// Bar chart geometry, in SVG user units.
+const (
+	chartLabelWidth = 180
+	chartBarWidth   = 420
+	chartRowHeight  = 26
+	chartBarHeight  = 18
+)
+
+// chartView is an HTMLChart laid out for the template.
+type chartView struct {
+	Title     string
+	Width     int
+	Height    int
+	LabelX    int // right edge of the labels
+	BarX      int // left edge of the bars
+	BarHeight int
+	Bars      []barView
+}
+
+type barView struct {
+	Label   string
+	Display string
+	Tone    string
+	Y       int
+	TextY   int
+	Width   float64
+	ValueX  float64
+}
+
+type tableView struct {
+	Caption string
+	Headers []string
+	Rows    [][]string
+}
+
+// layout places the bars of c, scaled to its largest value.
+func (c HTMLChart) layout() chartView {
+	peak := 0.0
+	for _, b := range c.Bars {
+		peak = max(peak, b.Value)
+	}
+	v := chartView{
+		Title:     c.Title,
+		Width:     chartLabelWidth + chartBarWidth + 80,
+		Height:    max(len(c.Bars), 1) * chartRowHeight,
+		LabelX:    chartLabelWidth - 8,
+		BarX:      chartLabelWidth,
+		BarHeight: chartBarHeight,
+	}
+	for i, b := range c.Bars {
+		width := 0.0
+		if peak > 0 && b.Value > 0 {
+			width = max(b.Value/peak*chartBarWidth, 2)
+		}
+		display := b.Display
+		if display == "" {
+			display = strconv.FormatFloat(b.Value, 'f', -1, 64)
+		}
+		y := i * chartRowHeight
+		v.Bars = append(v.Bars, barView{
+			Label:   TruncateString(b.Label, 28),
+			Display: display,
+			Tone:    b.Tone,
+			Y:       y + (chartRowHeight-chartBarHeight)/2,
+			TextY:   y + chartRowHeight/2 + 4,
+			Width:   width,
+			ValueX:  float64(chartLabelWidth) + width + 6,
+		})
+	}
+	return v
+}
+
+// WriteHTMLReport renders r as a standalone HTML page with its styles and
+// charts inline, so it can be mailed or attached as a single file.
+func WriteHTMLReport(w io.Writer, r *HTMLReport) error {
+	charts := make([]chartView, 0, len(r.Charts))
+	for _, c := range r.Charts {
+		charts = append(charts, c.layout())
+	}
+	tables := make([]tableView, 0, len(r.Tables))
+	for _, t := range r.Tables {
+		if t.Data == nil {
+			continue
+		}
+		tables = append(tables, tableView{Caption: t.Caption, Headers: translateHeaders(t.Data.Headers), Rows: t.Data.Rows})
+	}
+	return reportTemplate.Execute(w, map[string]any{
+		"Title":     r.Title,
+		"Subtitle":  r.Subtitle,
+		"Generated": r.Generated.Format("2006-01-02 15:04 MST"),
+		"Stats":     r.Stats,
+		"Charts":    charts,
+		"Tables":    tables,
+	})
+}
+
+// NewEmailHTMLReport builds the HTML report of an email delivery report.
+func NewEmailHTMLReport(report *EmailReport, generated time.Time) (*HTMLReport, error) {
+	table, err := FormatEmailReport(report, FormatTable)
+	if err != nil {
+		return nil, err
+	}
+	t := report.Total
+	bounceTone := ToneGood
+	if t.BounceRate >= 5 {
+		bounceTone = ToneBad
+	} else if t.BounceRate >= 2 {
+		bounceTone = ToneWarn
+	}
+	group := strings.ReplaceAll(report.GroupBy, "-", " ")
+	sent := HTMLChart{Title: "Emails sent by " + group}
+	bounces := HTMLChart{Title: "Bounce rate by " + group}
+	for _, g := range report.Groups {
+		sent.Bars = append(sent.Bars, HTMLBar{Label: g.Group, Value: float64(g.Sent)})
+		tone := ""
+		if g.BounceRate > 0 {
+			tone = ToneBad
+		}
+		bounces.Bars = append(bounces.Bars, HTMLBar{
+			Label: g.Group, Value: g.BounceRate, Display: fmt.Sprintf("%.1f%%", g.BounceRate), Tone: tone,
+		})
+	}
+	return &HTMLReport{
+		Generated: generated,
+		Title:     "Email delivery report",
+		Subtitle:  fmt.Sprintf("%s to %s, by %s", report.Since, report.Until, group),
+		Stats: []HTMLStat{
+			{Label: "Sent", Value: FormatValue(t.Sent)},
+			{Label: "Delivered", Value: FormatValue(t.Delivered), Tone: ToneGood},
+			{Label: "Bounced", Value: FormatValue(t.Bounced)},
+			{Label: "Failed", Value: FormatValue(t.Failed)},
+			{Label: "Bounce rate", Value: fmt.Sprintf("%.1f%%", t.BounceRate), Tone: bounceTone},
+		},
+		Charts: []HTMLChart{sent, bounces},
+		Tables: []HTMLTable{{Caption: "Delivery by " + group, Data: table}},
+	}, nil
+}
+
+// NewAuditHTMLReport builds the HTML report of a domain audit against
+// minScore.
+func NewAuditHTMLReport(report *audit.Report, minScore int, generated time.Time) (*HTMLReport, error) {
+	table, err := FormatDomainAudit(report, FormatTable)
+	if err != nil {
+		return nil, err
+	}
+	scoreTone := ToneGood
+	if report.Score < minScore {
+		scoreTone = ToneBad
+	}
+	failed := report.Total - report.Passed
+	failedTone := ToneGood
+	if failed > 0 {
+		failedTone = ToneBad
+	}
+	return &HTMLReport{
+		Generated: generated,
+		Title:     "Domain audit: " + report.Domain,
+		Subtitle:  fmt.Sprintf("Checked against the baseline; the minimum score is %d%%", minScore),
+		Stats: []HTMLStat{
+			{Label: "Score", Value: fmt.Sprintf("%d%%", report.Score), Tone: scoreTone},
+			{Label: "Rules passed", Value: FormatValue(report.Passed), Tone: ToneGood},
+			{Label: "Rules failed", Value: FormatValue(failed), Tone: failedTone},
+		},
+		Charts: []HTMLChart{{Title: "Rules", Bars: []HTMLBar{
+			{Label: "Passed", Value: float64(report.Passed), Tone: ToneGood},
+			{Label: "Failed", Value: float64(failed), Tone: ToneBad},
+		}}},
+		Tables: []HTMLTable{{Caption: "Rules", Data: table}},
+	}, nil
+}
+
+// NewOwnerHTMLReport builds the HTML report of alias ownership.
+func NewOwnerHTMLReport(entries []AliasOwnership, generated time.Time) (*HTMLReport, error) {
+	table, err := FormatAliasOwnerReport(entries, FormatTable)
+	if err != nil {
+		return nil, err
+	}
+	byOwner, byTeam := map[string]int{}, map[string]int{}
+	owners, teams, domains := map[string]bool{}, map[string]bool{}, map[string]bool{}
+	unowned := 0
+	for _, e := range entries {
+		domains[e.Domain] = true
+		owner, team := e.Owner, e.Team
+		if owner == "" {
+			owner = "(unowned)"
+			unowned++
+		} else {
+			owners[owner] = true
+		}
+		if team == "" {
+			team = "(no team)"
+		} else {
+			teams[team] = true
+		}
+		byOwner[owner]++
+		byTeam[team]++
+	}
+	unownedTone := ToneGood
+	if unowned > 0 {
+		unownedTone = ToneWarn
+	}
+	return &HTMLReport{
+		Generated: generated,
+		Title:     "Alias ownership report",
+		Subtitle:  fmt.Sprintf("%d alias(es) in %d domain(s)", len(entries), len(domains)),
+		Stats: []HTMLStat{
+			{Label: "Aliases", Value: FormatValue(len(entries))},
+			{Label: "Owners", Value: FormatValue(len(owners))},
+			{Label: "Teams", Value: FormatValue(len(teams))},
+			{Label: "Unowned", Value: FormatValue(unowned), Tone: unownedTone},
+		},
+		Charts: []HTMLChart{
+			countChart("Aliases by owner", byOwner, "(unowned)"),
+			countChart("Aliases by team", byTeam, "(no team)"),
+		},
+		Tables: []HTMLTable{{Caption: "Aliases", Data: table}},
+	}, nil
+}
+
+// countChart charts counts by descending size, with the none bucket last
+// and marked as a warning.
+func countChart(title string, counts map[string]int, none string) HTMLChart {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if (a == none) != (b == none) {
+			return b == none
+		}
+		if counts[a] != counts[b] {
+			return counts[a] > counts[b]
+		}
+		return a < b
+	})
+	chart := HTMLChart{Title: title}
+	for _, k := range keys {
+		bar := HTMLBar{Label: k, Value: float64(counts[k])}
+		if k == none {
+			bar.Tone = ToneWarn
+		}
+		chart.Bars = append(chart.Bars, bar)
+	}
+	return chart
+}
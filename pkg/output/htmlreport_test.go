@@ -0,0 +1,100 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ginsys/forward-email/pkg/audit"
+)
+
+var reportTime = time.Date(2026, 5, 31, 9, 30, 0, 0, time.UTC)
+
+func TestWriteHTMLReport_EmailReport(t *testing.T) {
+	report := &EmailReport{
+		Since: "2026-05-01", Until: "2026-05-31", GroupBy: "recipient-domain",
+		Groups: []EmailReportRow{
+			{Group: "a.example", Sent: 10, Delivered: 9, Bounced: 1, BounceRate: 10},
+			{Group: "<b>.example", Sent: 5, Delivered: 5},
+		},
+		Total: EmailReportRow{Group: "TOTAL", Sent: 15, Delivered: 14, Bounced: 1, BounceRate: 6.7},
+	}
+	r, err := NewEmailHTMLReport(report, reportTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := WriteHTMLReport(&buf, r); err != nil {
+		t.Fatal(err)
+	}
+	html := buf.String()
+	for _, want := range []string{
+		"<!DOCTYPE html>",
+		"<title>Email delivery report</title>",
+		"2026-05-01 to 2026-05-31, by recipient domain",
+		`<div class="stat bad"><div class="label">Bounce rate</div><div class="value">6.7%</div></div>`,
+		"<h2>Emails sent by recipient domain</h2>",
+		// The largest bar spans the full width; the others scale to it.
+		`<rect class="" x="180" y="4" width="420.0"`,
+		`<rect class="" x="180" y="30" width="210.0"`,
+		"<th>RECIPIENT DOMAIN</th>",
+		"&lt;b&gt;.example",
+		"Generated by forward-email on 2026-05-31 09:30 UTC",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("report lacks %q", want)
+		}
+	}
+	if strings.Contains(html, "<b>.example") {
+		t.Error("expected labels to be escaped")
+	}
+}
+
+func TestNewAuditHTMLReport(t *testing.T) {
+	report := &audit.Report{
+		Domain: "example.com", Score: 50, Passed: 1, Total: 2,
+		Results: []audit.Result{
+			{Rule: "dmarc", Passed: true, Detail: "present"},
+			{Rule: "dkim", Detail: "1024 bits"},
+		},
+	}
+	r, err := NewAuditHTMLReport(report, 80, reportTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Title != "Domain audit: example.com" {
+		t.Errorf("title = %q", r.Title)
+	}
+	if r.Stats[0].Value != "50%" || r.Stats[0].Tone != ToneBad {
+		t.Errorf("expected a failing score, got %+v", r.Stats[0])
+	}
+	if len(r.Tables) != 1 || len(r.Tables[0].Data.Rows) != 2 {
+		t.Errorf("expected one row per rule, got %+v", r.Tables)
+	}
+}
+
+func TestNewOwnerHTMLReport(t *testing.T) {
+	r, err := NewOwnerHTMLReport([]AliasOwnership{
+		{Alias: "info", Domain: "example.com", Owner: "jane", Team: "ops"},
+		{Alias: "sales", Domain: "example.com", Owner: "jane"},
+		{Alias: "old", Domain: "example.org"},
+		{Alias: "hr", Domain: "example.org", Owner: "bob", Team: "ops"},
+	}, reportTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Subtitle != "4 alias(es) in 2 domain(s)" {
+		t.Errorf("subtitle = %q", r.Subtitle)
+	}
+	var labels []string
+	for _, b := range r.Charts[0].Bars {
+		labels = append(labels, b.Label)
+	}
+	if got := strings.Join(labels, ","); got != "jane,bob,(unowned)" {
+		t.Errorf("owner bars = %s, want the largest first and (unowned) last", got)
+	}
+	if last := r.Charts[0].Bars[2]; last.Tone != ToneWarn {
+		t.Errorf("expected (unowned) to be a warning, got %+v", last)
+	}
+}
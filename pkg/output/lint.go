@@ -0,0 +1,44 @@
+package output
+
+import "fmt"
+
+// Finding severities, most severe first
+const (
+	SeverityError   = "error"   // mail is lost or bounces
+	SeverityWarning = "warning" // likely a mistake
+	SeverityInfo    = "info"    // worth knowing
+)
+
+// Finding is one problem reported by a lint or audit command.
+type Finding struct {
+	Severity string `json:"severity" yaml:"severity"`
+	Check    string `json:"check" yaml:"check"`                 // name of the check that found it
+	Subject  string `json:"subject" yaml:"subject"`             // the alias or domain concerned
+	Message  string `json:"message" yaml:"message"`             // what is wrong
+	Fix      string `json:"fix,omitempty" yaml:"fix,omitempty"` // how to resolve it
+}
+
+// SeverityRank orders severities from most (0) to least severe.
+func SeverityRank(severity string) int {
+	switch severity {
+	case SeverityError:
+		return 0
+	case SeverityWarning:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// FormatFindings formats lint findings as a table, in the order given
+func FormatFindings(findings []Finding, format Format) (*TableData, error) {
+	if !format.Tabular() {
+		return nil, fmt.Errorf("use direct JSON/YAML encoding for findings")
+	}
+
+	table := NewTableData([]string{"SEVERITY", "CHECK", "SUBJECT", "MESSAGE", "FIX"})
+	for _, f := range findings {
+		table.AddRow([]string{f.Severity, f.Check, f.Subject, f.Message, f.Fix})
+	}
+	return table, nil
+}
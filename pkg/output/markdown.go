@@ -0,0 +1,55 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// formatMarkdown outputs a table as a GitHub-flavored Markdown table, to
+// paste into issues, pull requests and wikis.
+func (f *Formatter) formatMarkdown(data interface{}) error {
+	switch v := data.(type) {
+	case TableData:
+		_, err := io.WriteString(f.writer, markdownTable(&v))
+		return err
+	case *TableData:
+		_, err := io.WriteString(f.writer, markdownTable(v))
+		return err
+	default:
+		return fmt.Errorf("markdown format requires TableData struct")
+	}
+}
+
+// markdownTable renders td as a GitHub-flavored Markdown table.
+func markdownTable(td *TableData) string {
+	if len(td.Headers) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	writeRow := func(cells []string) {
+		b.WriteString("|")
+		for i := range td.Headers {
+			cell := ""
+			if i < len(cells) {
+				cell = cells[i]
+			}
+			b.WriteString(" " + markdownCell(cell) + " |")
+		}
+		b.WriteString("\n")
+	}
+	writeRow(translateHeaders(td.Headers))
+	b.WriteString("|" + strings.Repeat(" --- |", len(td.Headers)) + "\n")
+	for _, row := range td.Rows {
+		writeRow(row)
+	}
+	return b.String()
+}
+
+// markdownCell escapes a table cell, keeping line breaks as <br>.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "|", `\|`)
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
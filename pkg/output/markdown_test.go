@@ -0,0 +1,39 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFormatMarkdown(t *testing.T) {
+	td := NewTableData([]string{"NAME", "RECIPIENTS"})
+	td.AddRow([]string{"info", "a@example.org, b@example.org"})
+	td.AddRow([]string{"pipe|name", `C:\path`})
+	td.AddRow([]string{"short"})
+
+	var buf bytes.Buffer
+	if err := NewFormatter(FormatMarkdown, &buf).Format(td); err != nil {
+		t.Fatal(err)
+	}
+	want := "| NAME | RECIPIENTS |\n" +
+		"| --- | --- |\n" +
+		"| info | a@example.org, b@example.org |\n" +
+		"| pipe\\|name | C:\\\\path |\n" +
+		"| short |  |\n"
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := NewFormatter(FormatMarkdown, &buf).Format(NewTableData(nil)); err != nil || buf.Len() != 0 {
+		t.Errorf("expected no output for a table without columns, got %q, %v", buf.String(), err)
+	}
+	if err := NewFormatter(FormatMarkdown, &buf).Format([]string{"x"}); err == nil {
+		t.Error("expected an error for data that is not a table")
+	}
+	for _, s := range []string{"markdown", "md", "Markdown"} {
+		if f, err := ParseFormat(s); err != nil || f != FormatMarkdown {
+			t.Errorf("ParseFormat(%q) = %q, %v", s, f, err)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Package schema versions the JSON and YAML documents the CLI prints, so that
+// scripts can pin the shape they were written against with --schema-version.
+//
+// Within a version, fields are never renamed, removed or given another type;
+// new fields may be added. A change that breaks this adds a new version, which
+// becomes the current one, and gives the version before it a Converter per
+// affected command that rewrites the new document into the previous shape.
+// Converters chain, so every older version keeps working. Old versions stay
+// available, marked Deprecated with a note on what to move to, until they are
+// removed in a major release.
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Converter rewrites a command's document in the shape of the next version,
+// decoded into generic JSON values (map[string]any, []any, string, float64,
+// bool and nil), into the shape of its own version.
+type Converter func(doc any) (any, error)
+
+// Version is one version of the output schemas.
+type Version struct {
+	Name string
+	// Deprecated explains why the version is deprecated and what to use
+	// instead; it is empty while the version is supported.
+	Deprecated string
+	// Converters are keyed by command path without the program name, such
+	// as "alias list". Commands without one print the same document in this
+	// version as in the next.
+	Converters map[string]Converter
+}
+
+// versions lists every available version, oldest first; the last one is
+// current.
+var versions = []*Version{
+	{Name: "v1"},
+}
+
+// Current returns the name of the current version.
+func Current() string {
+	return versions[len(versions)-1].Name
+}
+
+// Versions returns the available versions, oldest first.
+func Versions() []*Version {
+	return versions
+}
+
+// Lookup returns the version with the given name.
+func Lookup(name string) (*Version, error) {
+	for _, v := range versions {
+		if strings.EqualFold(v.Name, name) {
+			return v, nil
+		}
+	}
+	names := make([]string, len(versions))
+	for i, v := range versions {
+		names[i] = v.Name
+	}
+	return nil, fmt.Errorf("unknown schema version %q (available: %s)", name, strings.Join(names, ", "))
+}
+
+// Converts reports whether the command's document in v differs from the
+// current one.
+func (v *Version) Converts(command string) bool {
+	for _, newer := range versions[v.index():] {
+		if newer.Converters[command] != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Convert rewrites the command's current document into the shape of v,
+// through every version in between.
+func (v *Version) Convert(command string, doc any) (any, error) {
+	for i := len(versions) - 1; i >= v.index(); i-- {
+		if convert := versions[i].Converters[command]; convert != nil {
+			var err error
+			if doc, err = convert(doc); err != nil {
+				return nil, fmt.Errorf("failed to convert %s output to schema %s: %w", command, versions[i].Name, err)
+			}
+		}
+	}
+	return doc, nil
+}
+
+func (v *Version) index() int {
+	for i, known := range versions {
+		if known == v {
+			return i
+		}
+	}
+	return len(versions)
+}
@@ -0,0 +1,67 @@
+package schema
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	v, err := Lookup("V1")
+	if err != nil || v.Name != "v1" {
+		t.Fatalf("Lookup(V1) = %v, %v", v, err)
+	}
+	if _, err := Lookup("v0"); err == nil || !strings.Contains(err.Error(), "available: v1") {
+		t.Errorf("expected an unknown version error, got %v", err)
+	}
+	if Current() != "v1" {
+		t.Errorf("Current() = %q", Current())
+	}
+}
+
+func TestConvert(t *testing.T) {
+	// v3 renamed "recipients" to "targets" in alias get; v2 renamed "name" to
+	// "local_part" there and in alias list.
+	rename := func(from, to string) Converter {
+		return func(doc any) (any, error) {
+			m, ok := doc.(map[string]any)
+			if !ok {
+				return nil, errors.New("expected an object")
+			}
+			m[to] = m[from]
+			delete(m, from)
+			return m, nil
+		}
+	}
+	v1 := &Version{Name: "v1", Deprecated: "use v3", Converters: map[string]Converter{
+		"alias get": rename("local_part", "name"),
+	}}
+	v2 := &Version{Name: "v2", Converters: map[string]Converter{
+		"alias get": rename("targets", "recipients"),
+	}}
+	v3 := &Version{Name: "v3"}
+	orig := versions
+	versions = []*Version{v1, v2, v3}
+	t.Cleanup(func() { versions = orig })
+
+	doc := func() any { return map[string]any{"local_part": "info", "targets": []any{"a@example.org"}} }
+	got, err := v1.Convert("alias get", doc())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]any{"name": "info", "recipients": []any{"a@example.org"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("v1 document = %v, want %v", got, want)
+	}
+	got, _ = v2.Convert("alias get", doc())
+	if want := map[string]any{"local_part": "info", "recipients": []any{"a@example.org"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("v2 document = %v, want %v", got, want)
+	}
+
+	if !v1.Converts("alias get") || v2.Converts("domain list") || v3.Converts("alias get") {
+		t.Error("unexpected Converts result")
+	}
+	if _, err := v1.Convert("alias get", []any{}); err == nil || !strings.Contains(err.Error(), "schema v2") {
+		t.Errorf("expected a conversion error naming the version, got %v", err)
+	}
+}
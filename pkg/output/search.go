@@ -0,0 +1,30 @@
+package output
+
+import "fmt"
+
+// SearchResult is one resource matched by a search term.
+type SearchResult struct {
+	Type   string `json:"type" yaml:"type"`                         // "domain", "alias" or "email"
+	ID     string `json:"id" yaml:"id"`                             // resource ID
+	Name   string `json:"name" yaml:"name"`                         // domain name, alias address or email subject
+	Field  string `json:"field" yaml:"field"`                       // field the term was found in
+	Match  string `json:"match" yaml:"match"`                       // value of that field
+	Detail string `json:"detail,omitempty" yaml:"detail,omitempty"` // extra context, e.g. an email's date
+}
+
+// FormatSearchResults formats search results as a table, in the order given
+func FormatSearchResults(results []SearchResult, format Format) (*TableData, error) {
+	if !format.Tabular() {
+		return nil, fmt.Errorf("use direct JSON/YAML encoding for search results")
+	}
+
+	table := NewTableData([]string{"TYPE", "NAME", "FIELD", "MATCH", "DETAIL"})
+	for _, r := range results {
+		name, match := r.Name, r.Match
+		if format == FormatTable {
+			name, match = TruncateString(name, 50), TruncateString(match, 50)
+		}
+		table.AddRow([]string{r.Type, name, r.Field, match, r.Detail})
+	}
+	return table, nil
+}
@@ -0,0 +1,108 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeFormat selects how timestamps are rendered in tables and CSV. JSON and
+// YAML output always carries the API's RFC 3339 timestamps.
+type TimeFormat string
+
+// Time formats accepted by --time-format
+const (
+	TimeDefault  TimeFormat = ""         // the layout each field has always used
+	TimeRelative TimeFormat = "relative" // "3h ago", "in 2d"
+	TimeLocal    TimeFormat = "local"    // "2024-01-02 15:04:05 CET"
+	TimeISO      TimeFormat = "iso"      // RFC 3339
+	TimeEpoch    TimeFormat = "epoch"    // seconds since 1970-01-01 UTC
+)
+
+// TimeFormats lists the values accepted by ParseTimeFormat.
+var TimeFormats = []string{string(TimeRelative), string(TimeLocal), string(TimeISO), string(TimeEpoch)}
+
+var (
+	timeFormat   = TimeDefault
+	timeLocation *time.Location
+	timeNow      = time.Now // replaced in tests
+)
+
+// ParseTimeFormat parses a --time-format value; an empty value selects
+// TimeDefault.
+func ParseTimeFormat(s string) (TimeFormat, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return TimeDefault, nil
+	}
+	for _, f := range TimeFormats {
+		if s == f {
+			return TimeFormat(s), nil
+		}
+	}
+	return "", fmt.Errorf("unsupported time format: %s (supported: %s)", s, strings.Join(TimeFormats, ", "))
+}
+
+// SetTimeFormat sets how FormatTime renders timestamps from now on. loc, when
+// not nil, is the time zone every timestamp is converted to; otherwise local
+// time uses the system zone and the other formats keep the API's zone.
+func SetTimeFormat(format TimeFormat, loc *time.Location) {
+	timeFormat, timeLocation = format, loc
+}
+
+// FormatTime renders t in the configured time format. layout is the layout
+// used by the default format, e.g. "2006-01-02" for a list column or
+// time.RFC3339 for a details view. The zero time renders as "".
+func FormatTime(t time.Time, layout string) string {
+	if t.IsZero() {
+		return ""
+	}
+	if timeLocation != nil {
+		t = t.In(timeLocation)
+	}
+	switch timeFormat {
+	case TimeRelative:
+		return relativeTime(t, timeNow())
+	case TimeLocal:
+		if timeLocation == nil {
+			t = t.Local()
+		}
+		return t.Format("2006-01-02 15:04:05 MST")
+	case TimeISO:
+		return t.Format(time.RFC3339)
+	case TimeEpoch:
+		return strconv.FormatInt(t.Unix(), 10)
+	default:
+		return t.Format(layout)
+	}
+}
+
+// relativeTime renders the distance from now to t in its largest unit, such
+// as "3h ago" or "in 2d".
+func relativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+	var s string
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		s = fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < 24*time.Hour:
+		s = fmt.Sprintf("%dh", int(d/time.Hour))
+	case d < 30*24*time.Hour:
+		s = fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	case d < 365*24*time.Hour:
+		s = fmt.Sprintf("%dmo", int(d/(30*24*time.Hour)))
+	default:
+		s = fmt.Sprintf("%dy", int(d/(365*24*time.Hour)))
+	}
+	if future {
+		return "in " + s
+	}
+	return s + " ago"
+}
@@ -0,0 +1,73 @@
+package output
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatTime(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	origNow := timeNow
+	timeNow = func() time.Time { return now }
+	t.Cleanup(func() {
+		timeNow = origNow
+		SetTimeFormat(TimeDefault, nil)
+	})
+	ts := time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC)
+	brussels, err := time.LoadLocation("Europe/Brussels")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	tests := []struct {
+		format TimeFormat
+		loc    *time.Location
+		want   string
+	}{
+		{TimeDefault, nil, "2024-03-10"},
+		{TimeDefault, brussels, "2024-03-10"},
+		{TimeISO, nil, "2024-03-10T09:00:00Z"},
+		{TimeISO, brussels, "2024-03-10T10:00:00+01:00"},
+		{TimeLocal, brussels, "2024-03-10 10:00:00 CET"},
+		{TimeEpoch, brussels, "1710061200"},
+		{TimeRelative, nil, "3h ago"},
+	}
+	for _, tt := range tests {
+		SetTimeFormat(tt.format, tt.loc)
+		if got := FormatTime(ts, "2006-01-02"); got != tt.want {
+			t.Errorf("FormatTime(%q, %v) = %q, want %q", tt.format, tt.loc, got, tt.want)
+		}
+	}
+	if got := FormatTime(time.Time{}, time.RFC3339); got != "" {
+		t.Errorf("expected the zero time to render empty, got %q", got)
+	}
+}
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := map[time.Duration]string{
+		-20 * time.Second:         "just now",
+		-5 * time.Minute:          "5m ago",
+		-26 * time.Hour:           "1d ago",
+		-70 * 24 * time.Hour:      "2mo ago",
+		-800 * 24 * time.Hour:     "2y ago",
+		2*time.Hour + time.Minute: "in 2h",
+	}
+	for d, want := range tests {
+		if got := relativeTime(now.Add(d), now); got != want {
+			t.Errorf("relativeTime(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestParseTimeFormat(t *testing.T) {
+	if f, err := ParseTimeFormat("Relative"); err != nil || f != TimeRelative {
+		t.Errorf("ParseTimeFormat(Relative) = %q, %v", f, err)
+	}
+	if f, err := ParseTimeFormat(""); err != nil || f != TimeDefault {
+		t.Errorf("ParseTimeFormat(\"\") = %q, %v", f, err)
+	}
+	if _, err := ParseTimeFormat("unix"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,43 @@
+package output
+
+import (
+	"fmt"
+	"time"
+)
+
+// Kinds of change reported by watch domain
+const (
+	WatchSetting       = "setting"      // a domain setting changed
+	WatchVerification  = "verification" // a DNS or verification status flipped
+	WatchAliasAdded    = "alias_added"
+	WatchAliasRemoved  = "alias_removed"
+	WatchAliasModified = "alias_changed"
+)
+
+// WatchChange is one change to a domain or its aliases seen between two polls.
+type WatchChange struct {
+	Time   time.Time `json:"time" yaml:"time"`
+	Domain string    `json:"domain" yaml:"domain"`
+	Kind   string    `json:"kind" yaml:"kind"`
+	Alias  string    `json:"alias,omitempty" yaml:"alias,omitempty"`
+	Field  string    `json:"field,omitempty" yaml:"field,omitempty"` // JSON field name; nested fields are dotted
+	Old    any       `json:"old,omitempty" yaml:"old,omitempty"`
+	New    any       `json:"new,omitempty" yaml:"new,omitempty"`
+}
+
+// FormatWatchChange renders a change as one line of the change feed.
+func FormatWatchChange(c WatchChange) string {
+	prefix := fmt.Sprintf("%s  %s  ", c.Time.Local().Format("15:04:05"), c.Domain)
+	switch c.Kind {
+	case WatchAliasAdded:
+		return prefix + fmt.Sprintf("+ alias %s added (recipients: %s)", c.Alias, FormatChangeValue(c.New))
+	case WatchAliasRemoved:
+		return prefix + fmt.Sprintf("- alias %s removed", c.Alias)
+	case WatchAliasModified:
+		return prefix + fmt.Sprintf("~ alias %s: %s: %s → %s", c.Alias, c.Field, FormatChangeValue(c.Old), FormatChangeValue(c.New))
+	case WatchVerification:
+		return prefix + fmt.Sprintf("! %s: %s → %s", c.Field, FormatChangeValue(c.Old), FormatChangeValue(c.New))
+	default:
+		return prefix + fmt.Sprintf("~ %s: %s → %s", c.Field, FormatChangeValue(c.Old), FormatChangeValue(c.New))
+	}
+}
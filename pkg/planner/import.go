@@ -0,0 +1,37 @@
+package planner
+
+import "github.com/ginsys/forward-email/pkg/api"
+
+// Entry is the desired state of one alias, as read from an import file. Nil
+// fields were not given and keep their current values.
+type Entry struct {
+	Name        string
+	Recipients  []string
+	Enabled     *bool
+	Labels      []string
+	Description *string
+	IMAP        *bool
+	PGP         *bool
+	PublicKey   *string
+	Vacation    *api.VacationResponder
+}
+
+// PlanImport returns the actions that import entries into domain, whose
+// aliases are existing: an update for every entry whose alias exists and a
+// create for every other entry, in the order of entries.
+func PlanImport(domain string, existing []api.Alias, entries []Entry) Plan {
+	current := byName(existing)
+	var plan Plan
+	for _, e := range entries {
+		a := Action{
+			Type: ActionCreate, Domain: domain, Name: e.Name,
+			Recipients: e.Recipients, Enabled: e.Enabled, Labels: e.Labels, Description: e.Description,
+			IMAP: e.IMAP, PGP: e.PGP, PublicKey: e.PublicKey, Vacation: e.Vacation,
+		}
+		if ex, ok := current[e.Name]; ok {
+			a.Type, a.AliasID, a.Current = ActionUpdate, ex.ID, &ex
+		}
+		plan.add(a)
+	}
+	return plan
+}
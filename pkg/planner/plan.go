@@ -0,0 +1,169 @@
+// Package planner works out the alias changes needed to bring domains in
+// line with each other or with an import file, without talking to the API,
+// so that plans can be tested and previewed on their own. An Executor carries
+// a plan out; Apply runs one against the API or anything else that
+// implements Executor.
+package planner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ginsys/forward-email/pkg/api"
+)
+
+// ActionType is the kind of change an Action makes.
+type ActionType string
+
+// Action types.
+const (
+	ActionCreate ActionType = "create"
+	ActionUpdate ActionType = "update"
+	ActionDelete ActionType = "delete"
+)
+
+// Action is one change to an alias. For creates and updates the fields after
+// Current hold the desired state; nil fields are left as they are, or get the
+// API's defaults on create.
+type Action struct {
+	Type    ActionType
+	Domain  string
+	AliasID string // of the alias to update or delete
+	Name    string
+	// Current is the alias as it was when planned, for updates and deletes.
+	Current *api.Alias
+
+	Recipients  []string
+	Enabled     *bool
+	Labels      []string
+	Description *string
+	IMAP        *bool
+	PGP         *bool
+	PublicKey   *string
+	Vacation    *api.VacationResponder
+}
+
+// Plan is an ordered list of actions.
+type Plan struct {
+	Actions []Action
+}
+
+// Len returns the number of actions in p.
+func (p Plan) Len() int { return len(p.Actions) }
+
+// Count returns the number of actions of type t in p.
+func (p Plan) Count(t ActionType) int {
+	n := 0
+	for _, a := range p.Actions {
+		if a.Type == t {
+			n++
+		}
+	}
+	return n
+}
+
+func (p *Plan) add(actions ...Action) {
+	p.Actions = append(p.Actions, actions...)
+}
+
+// Executor carries out actions. Execute returns the ID of the alias acted
+// on, which for a create is the ID of the new alias.
+type Executor interface {
+	Execute(ctx context.Context, a Action) (string, error)
+}
+
+// APIExecutor executes actions with the API's alias service.
+type APIExecutor struct {
+	Aliases *api.AliasService
+}
+
+// Execute creates, updates or deletes the alias of a.
+func (e APIExecutor) Execute(ctx context.Context, a Action) (string, error) {
+	switch a.Type {
+	case ActionCreate:
+		created, err := e.Aliases.CreateAlias(ctx, a.Domain, a.createRequest())
+		if err != nil {
+			return "", err
+		}
+		return created.ID, nil
+	case ActionUpdate:
+		_, err := e.Aliases.UpdateAlias(ctx, a.Domain, a.AliasID, a.updateRequest())
+		return a.AliasID, err
+	case ActionDelete:
+		return a.AliasID, e.Aliases.DeleteAlias(ctx, a.Domain, a.AliasID)
+	}
+	return "", fmt.Errorf("unknown action type: %s", a.Type)
+}
+
+// createRequest returns the request that creates the alias of a, enabled
+// unless a says otherwise.
+func (a Action) createRequest() *api.CreateAliasRequest {
+	req := &api.CreateAliasRequest{
+		Name: a.Name, Recipients: a.Recipients, Labels: a.Labels, IsEnabled: true, Vacation: a.Vacation,
+	}
+	if a.Enabled != nil {
+		req.IsEnabled = *a.Enabled
+	}
+	if a.Description != nil {
+		req.Description = *a.Description
+	}
+	if a.IMAP != nil {
+		req.HasIMAP = *a.IMAP
+	}
+	if a.PGP != nil {
+		req.HasPGP = *a.PGP
+	}
+	if a.PublicKey != nil {
+		req.PublicKey = *a.PublicKey
+	}
+	return req
+}
+
+func (a Action) updateRequest() *api.UpdateAliasRequest {
+	return &api.UpdateAliasRequest{
+		Recipients: a.Recipients, Labels: a.Labels, IsEnabled: a.Enabled, Description: a.Description,
+		HasIMAP: a.IMAP, HasPGP: a.PGP, PublicKey: a.PublicKey, Vacation: a.Vacation,
+	}
+}
+
+// ActionError is returned by Apply when an action fails.
+type ActionError struct {
+	Action Action
+	Err    error
+}
+
+func (e *ActionError) Error() string {
+	return fmt.Sprintf("%s %s@%s failed: %v", e.Action.Type, e.Action.Name, e.Action.Domain, e.Err)
+}
+
+func (e *ActionError) Unwrap() error { return e.Err }
+
+// ApplyHooks let the caller follow and record the progress of Apply.
+type ApplyHooks struct {
+	// Skip is called before each action; returning true skips it, for
+	// example because an earlier, interrupted run already applied it.
+	Skip func(a Action) bool
+	// Done is called after each action that succeeded, with the ID returned
+	// by the executor. An error stops Apply and is returned as is.
+	Done func(a Action, id string) error
+}
+
+// Apply executes the actions of p in order with exec, stopping at the first
+// failure, which is returned as an *ActionError.
+func Apply(ctx context.Context, p Plan, exec Executor, hooks ApplyHooks) error {
+	for _, a := range p.Actions {
+		if hooks.Skip != nil && hooks.Skip(a) {
+			continue
+		}
+		id, err := exec.Execute(ctx, a)
+		if err != nil {
+			return &ActionError{Action: a, Err: err}
+		}
+		if hooks.Done != nil {
+			if err := hooks.Done(a, id); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
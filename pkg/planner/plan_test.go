@@ -0,0 +1,85 @@
+package planner
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ginsys/forward-email/pkg/api"
+)
+
+// fakeExecutor records the actions it executes and fails the ones named in
+// fail.
+type fakeExecutor struct {
+	done []string
+	fail map[string]bool
+}
+
+func (f *fakeExecutor) Execute(_ context.Context, a Action) (string, error) {
+	if f.fail[a.Name] {
+		return "", errors.New("boom")
+	}
+	f.done = append(f.done, string(a.Type)+" "+a.Name)
+	return "id-" + a.Name, nil
+}
+
+func TestPlanImport(t *testing.T) {
+	existing := []api.Alias{{ID: "a1", Name: "info", Recipients: []string{"old@x"}}}
+	disabled := false
+	plan := PlanImport("example.com", existing, []Entry{
+		{Name: "sales", Recipients: []string{"s@x"}, Enabled: &disabled},
+		{Name: "info", Recipients: []string{"new@x"}},
+	})
+
+	if got := summary(plan); !reflect.DeepEqual(got, []string{"create example.com/sales s@x", "update example.com/info new@x"}) {
+		t.Fatalf("unexpected plan %q", got)
+	}
+	if plan.Count(ActionCreate) != 1 || plan.Count(ActionUpdate) != 1 || plan.Count(ActionDelete) != 0 {
+		t.Errorf("unexpected counts in %q", summary(plan))
+	}
+	update := plan.Actions[1]
+	if update.AliasID != "a1" || update.Current == nil || update.Current.Recipients[0] != "old@x" {
+		t.Errorf("update should carry the current alias, got %+v", update)
+	}
+	if req := plan.Actions[0].createRequest(); req.IsEnabled {
+		t.Error("create request should keep the entry's enabled state")
+	}
+	if req := update.updateRequest(); req.IsEnabled != nil || req.Description != nil {
+		t.Errorf("update request should leave unset fields alone, got %+v", req)
+	}
+}
+
+func TestApply(t *testing.T) {
+	plan := Plan{Actions: []Action{
+		{Type: ActionCreate, Domain: "example.com", Name: "a"},
+		{Type: ActionUpdate, Domain: "example.com", Name: "b"},
+		{Type: ActionDelete, Domain: "example.com", Name: "c"},
+		{Type: ActionCreate, Domain: "example.com", Name: "d"},
+	}}
+	exec := &fakeExecutor{fail: map[string]bool{"c": true}}
+	var ids []string
+	err := Apply(context.Background(), plan, exec, ApplyHooks{
+		Skip: func(a Action) bool { return a.Name == "a" },
+		Done: func(_ Action, id string) error { ids = append(ids, id); return nil },
+	})
+
+	var actionErr *ActionError
+	if !errors.As(err, &actionErr) || actionErr.Action.Name != "c" {
+		t.Fatalf("expected the failure of c, got %v", err)
+	}
+	if err.Error() != "delete c@example.com failed: boom" {
+		t.Errorf("unexpected message %q", err)
+	}
+	if !reflect.DeepEqual(exec.done, []string{"update b"}) || !reflect.DeepEqual(ids, []string{"id-b"}) {
+		t.Errorf("expected only b to be executed, got %v and IDs %v", exec.done, ids)
+	}
+
+	stop := errors.New("stop")
+	err = Apply(context.Background(), plan, &fakeExecutor{}, ApplyHooks{
+		Done: func(Action, string) error { return stop },
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("expected the hook's error, got %v", err)
+	}
+}
@@ -0,0 +1,235 @@
+package planner
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ginsys/forward-email/pkg/api"
+)
+
+// Sync modes.
+const (
+	// ModeMerge syncs both ways, keeping the aliases unique to either domain.
+	ModeMerge = "merge"
+	// ModeReplace makes the target a copy of the source, deleting extra aliases.
+	ModeReplace = "replace"
+	// ModePreserve copies the source to the target without deleting anything.
+	ModePreserve = "preserve"
+)
+
+// Conflict strategies, for an alias that differs between the domains.
+const (
+	// StrategyOverwrite gives the preferred side's state to the other side.
+	StrategyOverwrite = "overwrite"
+	// StrategySkip leaves both sides as they are.
+	StrategySkip = "skip"
+	// StrategyMerge gives each side the union of both sides' recipients.
+	StrategyMerge = "merge"
+)
+
+// Sides preferred by StrategyOverwrite.
+const (
+	PreferSource = "source"
+	PreferTarget = "target"
+	// PreferNewest prefers the side updated last, the source on a tie.
+	PreferNewest = "newest"
+)
+
+// SyncOptions configure PlanSync.
+type SyncOptions struct {
+	Mode   string // ModeMerge, ModeReplace or ModePreserve
+	Source string // source domain name
+	Target string // target domain name
+	// Prefer is the side that wins a conflict resolved by overwriting;
+	// empty means PreferSource.
+	Prefer string
+	// Resolve returns the strategy for the conflict on the alias name. A nil
+	// Resolve or an empty strategy uses the mode's default: merge for
+	// ModeMerge, overwrite otherwise.
+	Resolve func(name string, src, dst api.Alias) (string, error)
+}
+
+// PlanSync returns the actions that bring the target domain's aliases, src
+// and dst, in line with the source's according to opts. Aliases are visited
+// by name, so the plan is the same for the same input. An alias that is
+// changed gets at most one update.
+func PlanSync(src, dst []api.Alias, opts SyncOptions) (Plan, error) {
+	switch opts.Mode {
+	case ModeMerge, ModeReplace, ModePreserve:
+	default:
+		return Plan{}, fmt.Errorf("invalid mode: %s (valid: merge|replace|preserve)", opts.Mode)
+	}
+	switch opts.Prefer {
+	case "", PreferSource, PreferTarget, PreferNewest:
+	default:
+		return Plan{}, fmt.Errorf("invalid preference: %s (valid: source|target|newest)", opts.Prefer)
+	}
+
+	srcByName := byName(src)
+	dstByName := byName(dst)
+	var plan Plan
+
+	for _, name := range sortedNames(srcByName, dstByName) {
+		s, inSrc := srcByName[name]
+		d, inDst := dstByName[name]
+		switch {
+		case inSrc && !inDst:
+			plan.add(createAction(opts.Target, name, s))
+		case !inSrc && inDst:
+			if opts.Mode == ModeMerge {
+				plan.add(createAction(opts.Source, name, d))
+			} else if opts.Mode == ModeReplace {
+				plan.add(Action{Type: ActionDelete, Domain: opts.Target, AliasID: d.ID, Name: d.Name, Current: &d})
+			}
+		case !Differ(s, d):
+		default:
+			actions, err := resolveConflict(name, s, d, opts)
+			if err != nil {
+				return Plan{}, err
+			}
+			plan.add(actions...)
+		}
+	}
+	return plan, nil
+}
+
+// resolveConflict returns the updates for an alias that differs between the
+// source, s, and the target, d.
+func resolveConflict(name string, s, d api.Alias, opts SyncOptions) ([]Action, error) {
+	strategy := ""
+	if opts.Resolve != nil {
+		var err error
+		if strategy, err = opts.Resolve(name, s, d); err != nil {
+			return nil, err
+		}
+	}
+	if strategy == "" {
+		strategy = StrategyOverwrite
+		if opts.Mode == ModeMerge {
+			strategy = StrategyMerge
+		}
+	}
+
+	switch strategy {
+	case StrategySkip:
+		return nil, nil
+	case StrategyMerge:
+		merged := MergeRecipients(s.Recipients, d.Recipients)
+		var actions []Action
+		if opts.Mode == ModeMerge && !EqualSets(s.Recipients, merged) {
+			actions = append(actions, updateAction(opts.Source, s, withRecipients(s, merged)))
+		}
+		if !EqualSets(d.Recipients, merged) {
+			actions = append(actions, updateAction(opts.Target, d, withRecipients(d, merged)))
+		}
+		return actions, nil
+	case StrategyOverwrite:
+		if !sourceWins(s, d, opts.Prefer) {
+			// The target's state is kept; only a two-way sync passes it back
+			if opts.Mode == ModeMerge {
+				return []Action{updateAction(opts.Source, s, d)}, nil
+			}
+			return nil, nil
+		}
+		return []Action{updateAction(opts.Target, d, s)}, nil
+	}
+	return nil, fmt.Errorf("invalid conflict strategy for alias %s: %s (valid: overwrite|skip|merge)", name, strategy)
+}
+
+// sourceWins reports whether the source alias wins a conflict under prefer.
+func sourceWins(s, d api.Alias, prefer string) bool {
+	switch prefer {
+	case PreferTarget:
+		return false
+	case PreferNewest:
+		return !d.UpdatedAt.After(s.UpdatedAt)
+	}
+	return true
+}
+
+// Differ reports whether two aliases of the same name differ in what sync
+// copies: recipients, enabled state or labels.
+func Differ(a, b api.Alias) bool {
+	return !EqualSets(a.Recipients, b.Recipients) || a.IsEnabled != b.IsEnabled || !EqualSets(a.Labels, b.Labels)
+}
+
+func createAction(domain, name string, a api.Alias) Action {
+	enabled := a.IsEnabled
+	return Action{Type: ActionCreate, Domain: domain, Name: name, Recipients: a.Recipients, Enabled: &enabled, Labels: a.Labels}
+}
+
+// updateAction updates the alias current to the state of desired.
+func updateAction(domain string, current, desired api.Alias) Action {
+	enabled := desired.IsEnabled
+	return Action{
+		Type: ActionUpdate, Domain: domain, AliasID: current.ID, Name: current.Name, Current: &current,
+		Recipients: desired.Recipients, Enabled: &enabled, Labels: desired.Labels,
+	}
+}
+
+func withRecipients(a api.Alias, recipients []string) api.Alias {
+	a.Recipients = recipients
+	return a
+}
+
+func byName(list []api.Alias) map[string]api.Alias {
+	m := make(map[string]api.Alias, len(list))
+	for _, a := range list {
+		m[a.Name] = a
+	}
+	return m
+}
+
+// sortedNames returns the names in src or dst, in order.
+func sortedNames(src, dst map[string]api.Alias) []string {
+	names := make([]string, 0, len(src)+len(dst))
+	for n := range src {
+		names = append(names, n)
+	}
+	for n := range dst {
+		if _, ok := src[n]; !ok {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// EqualSets reports whether a and b hold the same entries, ignoring case,
+// surrounding space and order.
+func EqualSets(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[string]int, len(a))
+	for _, s := range a {
+		counts[normalize(s)]++
+	}
+	for _, s := range b {
+		k := normalize(s)
+		if counts[k] == 0 {
+			return false
+		}
+		counts[k]--
+	}
+	return true
+}
+
+// MergeRecipients returns the union of a and b, lowercased and sorted.
+func MergeRecipients(a, b []string) []string {
+	m := make(map[string]struct{}, len(a)+len(b))
+	for _, s := range append(append([]string(nil), a...), b...) {
+		m[normalize(s)] = struct{}{}
+	}
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func normalize(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
@@ -0,0 +1,132 @@
+package planner
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ginsys/forward-email/pkg/api"
+)
+
+var (
+	older = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer = older.Add(time.Hour)
+)
+
+// summary renders actions as "type domain/name recipients" for comparison.
+func summary(plan Plan) []string {
+	out := make([]string, plan.Len())
+	for i, a := range plan.Actions {
+		out[i] = string(a.Type) + " " + a.Domain + "/" + a.Name
+		if a.Type != ActionDelete {
+			out[i] += " " + joinRecipients(a.Recipients)
+		}
+	}
+	return out
+}
+
+func joinRecipients(r []string) string {
+	s := ""
+	for i, v := range r {
+		if i > 0 {
+			s += ","
+		}
+		s += v
+	}
+	return s
+}
+
+func TestPlanSync(t *testing.T) {
+	src := []api.Alias{
+		{ID: "s1", Name: "info", Recipients: []string{"a@x"}, IsEnabled: true, UpdatedAt: older},
+		{ID: "s2", Name: "sales", Recipients: []string{"s@x"}, IsEnabled: true},
+		{ID: "s3", Name: "team", Recipients: []string{"t@x"}, IsEnabled: true, UpdatedAt: newer},
+	}
+	dst := []api.Alias{
+		{ID: "d1", Name: "info", Recipients: []string{"b@x"}, IsEnabled: true, UpdatedAt: newer},
+		{ID: "d2", Name: "help", Recipients: []string{"h@x"}, IsEnabled: true},
+		{ID: "d3", Name: "team", Recipients: []string{"T@x"}, IsEnabled: true},
+	}
+
+	tests := []struct {
+		name     string
+		mode     string
+		strategy string
+		prefer   string
+		want     []string
+	}{
+		{name: "merge defaults to merging recipients", mode: ModeMerge, want: []string{
+			"create src.com/help h@x", "update src.com/info a@x,b@x", "update dst.com/info a@x,b@x", "create dst.com/sales s@x",
+		}},
+		{name: "merge overwrite updates only the target", mode: ModeMerge, strategy: StrategyOverwrite, want: []string{
+			"create src.com/help h@x", "update dst.com/info a@x", "create dst.com/sales s@x",
+		}},
+		{name: "merge overwrite preferring the target updates only the source", mode: ModeMerge, strategy: StrategyOverwrite,
+			prefer: PreferTarget, want: []string{
+				"create src.com/help h@x", "update src.com/info b@x", "create dst.com/sales s@x",
+			}},
+		{name: "merge overwrite preferring the newest", mode: ModeMerge, strategy: StrategyOverwrite, prefer: PreferNewest, want: []string{
+			"create src.com/help h@x", "update src.com/info b@x", "create dst.com/sales s@x",
+		}},
+		{name: "preserve defaults to overwriting", mode: ModePreserve, want: []string{
+			"update dst.com/info a@x", "create dst.com/sales s@x",
+		}},
+		{name: "preserve preferring the target leaves conflicts", mode: ModePreserve, prefer: PreferTarget, want: []string{
+			"create dst.com/sales s@x",
+		}},
+		{name: "replace deletes extra aliases", mode: ModeReplace, strategy: StrategySkip, want: []string{
+			"delete dst.com/help", "create dst.com/sales s@x",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := PlanSync(src, dst, SyncOptions{
+				Mode: tt.mode, Source: "src.com", Target: "dst.com", Prefer: tt.prefer,
+				Resolve: func(string, api.Alias, api.Alias) (string, error) { return tt.strategy, nil },
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := summary(plan); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("plan:\n  got  %q\n  want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanSync_Resolve(t *testing.T) {
+	src := []api.Alias{{Name: "a", Recipients: []string{"1@x"}}, {Name: "b", Recipients: []string{"1@x"}}}
+	dst := []api.Alias{{Name: "a", Recipients: []string{"2@x"}}, {Name: "b", Recipients: []string{"2@x"}}}
+
+	var asked []string
+	stop := errors.New("stop")
+	_, err := PlanSync(src, dst, SyncOptions{Mode: ModeMerge, Resolve: func(name string, _, _ api.Alias) (string, error) {
+		asked = append(asked, name)
+		return "", stop
+	}})
+	if !errors.Is(err, stop) || !reflect.DeepEqual(asked, []string{"a"}) {
+		t.Errorf("expected the first conflict's error, got %v after %v", err, asked)
+	}
+
+	if _, err := PlanSync(src, dst, SyncOptions{Mode: ModeMerge, Resolve: func(string, api.Alias, api.Alias) (string, error) {
+		return "swap", nil
+	}}); err == nil {
+		t.Error("expected an unknown strategy to fail")
+	}
+	if _, err := PlanSync(nil, nil, SyncOptions{Mode: "mirror"}); err == nil {
+		t.Error("expected an unknown mode to fail")
+	}
+	if _, err := PlanSync(nil, nil, SyncOptions{Mode: ModeMerge, Prefer: "oldest"}); err == nil {
+		t.Error("expected an unknown preference to fail")
+	}
+}
+
+func TestEqualSets(t *testing.T) {
+	if !EqualSets([]string{"A@x", " b@x"}, []string{"b@x", "a@x"}) {
+		t.Error("expected sets to match ignoring case, space and order")
+	}
+	if EqualSets([]string{"a@x", "a@x"}, []string{"a@x", "b@x"}) {
+		t.Error("expected different counts to differ")
+	}
+}
@@ -0,0 +1,173 @@
+// Package policy evaluates alias naming policies locally, before any alias is
+// created, so that names such as admin@ or hr@ can only be claimed by the team
+// that owns them.
+//
+// A policy file is YAML (or JSON):
+//
+//	allow:                 # every name must match at least one pattern
+//	  - '^[a-z0-9][a-z0-9.-]*$'
+//	forbidden:             # names nobody may create
+//	  - abuse
+//	reserved:              # names only aliases with one of the labels may use
+//	  hr: [team-hr]
+//	prefixes:              # aliases with the label must start with the prefix
+//	  team-hr: hr-
+//
+// Names and labels are compared case-insensitively. A reserved name satisfies
+// the prefix rule of the labels it is reserved for.
+package policy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Policy is a parsed alias naming policy. The zero value allows every name.
+type Policy struct {
+	Allow     []string            `yaml:"allow" json:"allow,omitempty"`
+	Forbidden []string            `yaml:"forbidden" json:"forbidden,omitempty"`
+	Reserved  map[string][]string `yaml:"reserved" json:"reserved,omitempty"`
+	Prefixes  map[string]string   `yaml:"prefixes" json:"prefixes,omitempty"`
+
+	allow []*regexp.Regexp
+}
+
+// Violation describes one rule an alias name breaks.
+type Violation struct {
+	Name    string // Alias name
+	Rule    string // allow, forbidden, reserved or prefix
+	Message string
+}
+
+func (v Violation) Error() string {
+	return fmt.Sprintf("alias '%s': %s", v.Name, v.Message)
+}
+
+// Violations lists every rule broken by a set of aliases.
+type Violations []Violation
+
+func (v Violations) Error() string {
+	msgs := make([]string, len(v))
+	for i, violation := range v {
+		msgs[i] = violation.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Load reads and parses the policy file at path.
+func Load(path string) (*Policy, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // the policy path is given by the user
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+	p, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy file %s: %w", path, err)
+	}
+	return p, nil
+}
+
+// Parse parses a YAML or JSON policy and compiles its allow patterns.
+func Parse(data []byte) (*Policy, error) {
+	var p Policy
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&p); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	for _, pattern := range p.Allow {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("allow pattern %q: %w", pattern, err)
+		}
+		p.allow = append(p.allow, re)
+	}
+	return &p, nil
+}
+
+// Check returns the rules the alias name with the given labels breaks, or nil.
+func (p *Policy) Check(name string, labels []string) Violations {
+	if p == nil {
+		return nil
+	}
+	lower := strings.ToLower(name)
+	has := make(map[string]bool, len(labels))
+	for _, l := range labels {
+		has[strings.ToLower(l)] = true
+	}
+
+	var out Violations
+	add := func(rule, format string, args ...any) {
+		out = append(out, Violation{Name: name, Rule: rule, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(p.allow) > 0 && !p.allowed(name) {
+		add("allow", "does not match any allowed pattern (%s)", strings.Join(p.Allow, ", "))
+	}
+	for _, f := range p.Forbidden {
+		if strings.EqualFold(f, name) {
+			add("forbidden", "name is forbidden by policy")
+			break
+		}
+	}
+
+	var owners []string
+	for reserved, labels := range p.Reserved {
+		if strings.EqualFold(reserved, name) {
+			owners = labels
+			break
+		}
+	}
+	owned := map[string]bool{}
+	for _, l := range owners {
+		owned[strings.ToLower(l)] = true
+	}
+	if owners != nil && !hasAny(has, owned) {
+		add("reserved", "name is reserved for aliases labeled %s", strings.Join(owners, " or "))
+	}
+
+	for _, label := range sortedKeys(p.Prefixes) {
+		l := strings.ToLower(label)
+		prefix := p.Prefixes[label]
+		if !has[l] || owned[l] || strings.HasPrefix(lower, strings.ToLower(prefix)) {
+			continue
+		}
+		add("prefix", "aliases labeled %s must start with '%s'", label, prefix)
+	}
+	return out
+}
+
+func (p *Policy) allowed(name string) bool {
+	for _, re := range p.allow {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAny(set, want map[string]bool) bool {
+	for k := range want {
+		if set[k] {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
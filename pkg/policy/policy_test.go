@@ -0,0 +1,67 @@
+package policy
+
+import (
+	"strings"
+	"testing"
+)
+
+const testPolicy = `
+allow:
+  - '^[a-z0-9][a-z0-9.-]*$'
+forbidden: [abuse]
+reserved:
+  hr: [team-hr]
+  admin: [it, ops]
+prefixes:
+  team-hr: hr-
+`
+
+func TestCheck(t *testing.T) {
+	p, err := Parse([]byte(testPolicy))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		labels []string
+		rules  []string
+	}{
+		{"sales", nil, nil},
+		{"Sales_Team", nil, []string{"allow"}},
+		{"abuse", nil, []string{"forbidden"}},
+		{"ABUSE", nil, []string{"allow", "forbidden"}},
+		{"hr", []string{"team-sales"}, []string{"reserved"}},
+		{"hr", []string{"Team-HR"}, nil},
+		{"admin", []string{"ops"}, nil},
+		{"payroll", []string{"team-hr"}, []string{"prefix"}},
+		{"hr-payroll", []string{"team-hr"}, nil},
+	}
+	for _, tt := range tests {
+		var rules []string
+		for _, v := range p.Check(tt.name, tt.labels) {
+			rules = append(rules, v.Rule)
+		}
+		if strings.Join(rules, ",") != strings.Join(tt.rules, ",") {
+			t.Errorf("Check(%q, %v) = %v, want %v", tt.name, tt.labels, rules, tt.rules)
+		}
+	}
+
+	msg := p.Check("hr", nil).Error()
+	if msg != "alias 'hr': name is reserved for aliases labeled team-hr" {
+		t.Errorf("unexpected message %q", msg)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	if _, err := Parse([]byte("allow: ['(']\n")); err == nil || !strings.Contains(err.Error(), `allow pattern "("`) {
+		t.Errorf("expected pattern error, got %v", err)
+	}
+	if _, err := Parse([]byte("forbiden: [admin]\n")); err == nil {
+		t.Error("expected unknown field error")
+	}
+	var nilPolicy *Policy
+	if v := nilPolicy.Check("anything", nil); v != nil {
+		t.Errorf("nil policy should allow everything, got %v", v)
+	}
+}
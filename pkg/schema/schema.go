@@ -0,0 +1,117 @@
+// Package schema embeds the JSON Schemas for the files the CLI reads, such as
+// alias import files and update patch files, and validates YAML or JSON
+// documents against them with line and column positions.
+//
+// The validator implements the subset of JSON Schema the embedded schemas use:
+// type, properties, required, additionalProperties (boolean), items,
+// minItems, minProperties, minLength, enum, pattern, minimum and maximum.
+package schema
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Schema names, as accepted by Get and Validate.
+const (
+	AliasImport    = "alias-import"
+	AliasImportCSV = "alias-import-csv"
+	AliasPatch     = "alias-patch"
+	DomainLists    = "domain-lists"
+	DomainPatch    = "domain-patch"
+)
+
+//go:embed schemas/*.json
+var files embed.FS
+
+// Names returns the names of the embedded schemas, sorted.
+func Names() []string {
+	entries, _ := files.ReadDir("schemas")
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, strings.TrimSuffix(e.Name(), ".json"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get returns the JSON Schema document with the given name.
+func Get(name string) ([]byte, error) {
+	data, err := files.ReadFile(path.Join("schemas", name+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("unknown schema %q (available: %s)", name, strings.Join(Names(), ", "))
+	}
+	return data, nil
+}
+
+// node is the parsed form of a schema document.
+type node struct {
+	Type                 any              `json:"type"`
+	Properties           map[string]*node `json:"properties"`
+	Required             []string         `json:"required"`
+	AdditionalProperties *bool            `json:"additionalProperties"`
+	Items                *node            `json:"items"`
+	MinItems             *int             `json:"minItems"`
+	MinProperties        *int             `json:"minProperties"`
+	MinLength            *int             `json:"minLength"`
+	Enum                 []any            `json:"enum"`
+	Pattern              string           `json:"pattern"`
+	Minimum              *float64         `json:"minimum"`
+	Maximum              *float64         `json:"maximum"`
+}
+
+func load(name string) (*node, error) {
+	data, err := Get(name)
+	if err != nil {
+		return nil, err
+	}
+	var n node
+	if err := json.Unmarshal(data, &n); err != nil {
+		return nil, fmt.Errorf("schema %s: %w", name, err)
+	}
+	return &n, nil
+}
+
+// Validate parses data as YAML (and therefore JSON) and checks it against the
+// named schema. Problems are returned as Errors.
+func Validate(name string, data []byte) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	if doc.Kind == 0 {
+		return Errors{{Line: 1, Column: 1, Message: "document is empty"}}
+	}
+	return ValidateNode(name, &doc)
+}
+
+// ValidateNode checks an already parsed YAML node against the named schema.
+// Callers reading other formats, such as CSV, can build nodes with their own
+// line and column positions.
+func ValidateNode(name string, doc *yaml.Node) error {
+	s, err := load(name)
+	if err != nil {
+		return err
+	}
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		doc = doc.Content[0]
+	}
+	var errs Errors
+	s.validate(doc, "", &errs)
+	if len(errs) > 0 {
+		sort.SliceStable(errs, func(i, j int) bool {
+			if errs[i].Line != errs[j].Line {
+				return errs[i].Line < errs[j].Line
+			}
+			return errs[i].Column < errs[j].Column
+		})
+		return errs
+	}
+	return nil
+}
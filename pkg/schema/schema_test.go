@@ -0,0 +1,131 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ginsys/forward-email/pkg/api"
+)
+
+func TestNamesAndGet(t *testing.T) {
+	want := []string{AliasImport, AliasImportCSV, AliasPatch, DomainLists, DomainPatch}
+	if got := Names(); !slices.Equal(got, want) {
+		t.Errorf("Names() = %v, want %v", got, want)
+	}
+	for _, name := range want {
+		data, err := Get(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var doc map[string]any
+		if err := json.Unmarshal(data, &doc); err != nil || doc["$schema"] == nil {
+			t.Errorf("%s: invalid schema document: %v", name, err)
+		}
+	}
+	if _, err := Get("apply"); err == nil || !strings.Contains(err.Error(), "available:") {
+		t.Errorf("expected unknown schema error, got %v", err)
+	}
+}
+
+// The patch schemas must list exactly the fields of the request types they describe.
+func TestPatchSchemasMatchRequestTypes(t *testing.T) {
+	for name, v := range map[string]any{DomainPatch: api.UpdateDomainRequest{}, AliasPatch: api.UpdateAliasRequest{}} {
+		s, err := load(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		typ := reflect.TypeOf(v)
+		for i := range typ.NumField() {
+			tag, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ",")
+			if s.Properties[tag] == nil {
+				t.Errorf("%s: field %s missing from schema", name, tag)
+			}
+		}
+		if len(s.Properties) != typ.NumField() {
+			t.Errorf("%s: schema has %d properties, %s has %d fields", name, len(s.Properties), typ.Name(), typ.NumField())
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		doc    string
+		want   []string // expected error lines, empty for a valid document
+	}{
+		{
+			name:   "valid import",
+			schema: AliasImport,
+			doc:    "aliases:\n  - name: sales\n    recipients: [a@example.org]\n    enabled: false\n",
+		},
+		{
+			name:   "import errors with positions",
+			schema: AliasImport,
+			doc: `aliases:
+  - name: sales
+    recipients: []
+  - name: ""
+    recipients: [b@example.org]
+    enabled: "maybe"
+    label: [x]
+`,
+			want: []string{
+				"line 3, column 17 (aliases[0].recipients): expected at least 1 item(s), got 0",
+				"line 4, column 11 (aliases[1].name): must not be empty",
+				"line 6, column 14 (aliases[1].enabled): expected boolean, got string",
+				`line 7, column 5 (aliases[1]): unknown field "label" (did you mean "labels"?)`,
+			},
+		},
+		{
+			name:   "missing required",
+			schema: AliasImport,
+			doc:    "aliases:\n  - name: sales\n",
+			want:   []string{`line 2, column 5 (aliases[0]): missing required field "recipients"`},
+		},
+		{
+			name:   "json patch",
+			schema: DomainPatch,
+			doc:    "{\n  \"retention_days\": -1,\n  \"settings\": {\"smtp_port\": 99999}\n}",
+			want: []string{
+				"line 2, column 21 (retention_days): must be at least 0",
+				"line 3, column 29 (settings.smtp_port): must be at most 65535",
+			},
+		},
+		{
+			name:   "misspelled key",
+			schema: DomainPatch,
+			doc:    "retention-days: 30\n",
+			want:   []string{`line 1, column 1: unknown field "retention-days" (did you mean "retention_days"?)`},
+		},
+		{
+			name:   "empty document",
+			schema: AliasPatch,
+			doc:    "",
+			want:   []string{"line 1, column 1: document is empty"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.schema, []byte(tt.doc))
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var errs Errors
+			if !errors.As(err, &errs) {
+				t.Fatalf("expected Errors, got %v", err)
+			}
+			got := strings.Split(errs.Error(), "\n")
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("errors:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
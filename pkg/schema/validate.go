@@ -0,0 +1,181 @@
+package schema
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ginsys/forward-email/pkg/suggest"
+)
+
+// Error is a single schema violation at a position in the input.
+type Error struct {
+	Line    int
+	Column  int
+	Path    string // dotted path such as aliases[2].recipients, empty for the root
+	Message string
+}
+
+func (e Error) Error() string {
+	where := fmt.Sprintf("line %d, column %d", e.Line, e.Column)
+	if e.Path != "" {
+		where += " (" + e.Path + ")"
+	}
+	return where + ": " + e.Message
+}
+
+// Errors lists every violation found in a document.
+type Errors []Error
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func (s *node) validate(n *yaml.Node, path string, errs *Errors) {
+	for n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	fail := func(at *yaml.Node, p, format string, args ...any) {
+		*errs = append(*errs, Error{Line: at.Line, Column: at.Column, Path: p, Message: fmt.Sprintf(format, args...)})
+	}
+
+	kind := kindOf(n)
+	if types := s.types(); len(types) > 0 && !slices.Contains(types, kind) &&
+		(kind != "integer" || !slices.Contains(types, "number")) {
+		fail(n, path, "expected %s, got %s", strings.Join(types, " or "), kind)
+		return
+	}
+
+	switch n.Kind {
+	case yaml.MappingNode:
+		seen := make(map[string]bool, len(n.Content)/2)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i], n.Content[i+1]
+			seen[key.Value] = true
+			prop, ok := s.Properties[key.Value]
+			if !ok {
+				if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+					fail(key, path, "unknown field %q%s", key.Value, s.suggest(key.Value))
+				}
+				continue
+			}
+			prop.validate(value, join(path, key.Value), errs)
+		}
+		for _, r := range s.Required {
+			if !seen[r] {
+				fail(n, path, "missing required field %q", r)
+			}
+		}
+		if s.MinProperties != nil && len(n.Content)/2 < *s.MinProperties {
+			fail(n, path, "expected at least %d field(s)", *s.MinProperties)
+		}
+	case yaml.SequenceNode:
+		if s.MinItems != nil && len(n.Content) < *s.MinItems {
+			fail(n, path, "expected at least %d item(s), got %d", *s.MinItems, len(n.Content))
+		}
+		if s.Items != nil {
+			for i, item := range n.Content {
+				s.Items.validate(item, fmt.Sprintf("%s[%d]", path, i), errs)
+			}
+		}
+	case yaml.ScalarNode:
+		s.validateScalar(n, kind, func(format string, args ...any) { fail(n, path, format, args...) })
+	}
+}
+
+func (s *node) validateScalar(n *yaml.Node, kind string, fail func(string, ...any)) {
+	if s.MinLength != nil && kind == "string" && len([]rune(n.Value)) < *s.MinLength {
+		if *s.MinLength == 1 {
+			fail("must not be empty")
+		} else {
+			fail("must be at least %d characters", *s.MinLength)
+		}
+	}
+	if len(s.Enum) > 0 {
+		found := false
+		values := make([]string, len(s.Enum))
+		for i, v := range s.Enum {
+			values[i] = fmt.Sprint(v)
+			found = found || values[i] == n.Value
+		}
+		if !found {
+			fail("invalid value %q (valid: %s)", n.Value, strings.Join(values, ", "))
+		}
+	}
+	if s.Pattern != "" && kind == "string" {
+		if re, err := regexp.Compile(s.Pattern); err == nil && !re.MatchString(n.Value) {
+			fail("invalid value %q", n.Value)
+		}
+	}
+	if kind == "integer" || kind == "number" {
+		v, _ := strconv.ParseFloat(n.Value, 64)
+		if s.Minimum != nil && v < *s.Minimum {
+			fail("must be at least %v", *s.Minimum)
+		}
+		if s.Maximum != nil && v > *s.Maximum {
+			fail("must be at most %v", *s.Maximum)
+		}
+	}
+}
+
+// types returns the allowed JSON types of s.
+func (s *node) types() []string {
+	switch t := s.Type.(type) {
+	case string:
+		return []string{t}
+	case []any:
+		out := make([]string, 0, len(t))
+		for _, v := range t {
+			out = append(out, fmt.Sprint(v))
+		}
+		return out
+	}
+	return nil
+}
+
+// suggest names a known property when key looks like a misspelling of it.
+func (s *node) suggest(key string) string {
+	if name, ok := suggest.Closest(key, slices.Sorted(maps.Keys(s.Properties))); ok {
+		return fmt.Sprintf(" (did you mean %q?)", name)
+	}
+	return ""
+}
+
+// kindOf returns the JSON type of a YAML node.
+func kindOf(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	case yaml.ScalarNode:
+		switch n.Tag {
+		case "!!bool":
+			return "boolean"
+		case "!!int":
+			return "integer"
+		case "!!float":
+			return "number"
+		case "!!null":
+			return "null"
+		}
+		return "string"
+	}
+	return "null"
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
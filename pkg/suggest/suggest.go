@@ -0,0 +1,52 @@
+// Package suggest finds the likely intended value for a mistyped name, such
+// as a column, sort field or config key, to offer as "did you mean".
+package suggest
+
+import "strings"
+
+// Closest returns the candidate with the smallest edit distance to input,
+// ignoring case. Matches further than a third of the input's length (at
+// least 2) are rejected so unrelated words are not suggested; candidates that
+// start with the input count as close. Ties go to the earlier candidate.
+func Closest(input string, candidates []string) (string, bool) {
+	input = strings.ToLower(strings.TrimSpace(input))
+	if input == "" {
+		return "", false
+	}
+	best, bestDist := "", -1
+	for _, c := range candidates {
+		lc := strings.ToLower(c)
+		d := Levenshtein(input, lc)
+		if len(input) >= 3 && strings.HasPrefix(lc, input) {
+			d = min(d, 1) // a truncated name such as created for created_at
+		}
+		if bestDist < 0 || d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	maxDist := max(2, len([]rune(input))/3)
+	return best, bestDist >= 0 && bestDist <= maxDist
+}
+
+// Levenshtein returns the number of single-rune insertions, deletions and
+// substitutions needed to turn a into b.
+func Levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,43 @@
+package suggest
+
+import "testing"
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"recipents", "recipients", 1},
+		{"naem", "name", 2},
+		{"café", "cafe", 1},
+	}
+	for _, tt := range tests {
+		if got := Levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("Levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestClosest(t *testing.T) {
+	candidates := []string{"name", "recipients", "created_at", "retention_days"}
+	tests := []struct {
+		input string
+		want  string
+		ok    bool
+	}{
+		{"RECIPENTS", "recipients", true},
+		{"created", "created_at", true},
+		{"retention-days", "retention_days", true},
+		{"colour", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := Closest(tt.input, candidates)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("Closest(%q) = %q, %v, want %q, %v", tt.input, got, ok, tt.want, tt.ok)
+		}
+	}
+}
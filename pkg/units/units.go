@@ -0,0 +1,158 @@
+// Package units parses and formats the human-friendly sizes and durations
+// accepted by CLI flags, such as 25GB, 90d or 2m.
+//
+// Sizes use binary multiples throughout: KB, MB, GB and TB are 1024-based,
+// matching FormatBytes, and the explicit KiB, MiB, ... forms are accepted too.
+// Durations accept d (days) and w (weeks) in addition to the units understood
+// by time.ParseDuration.
+package units
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Day, Week and Year are the calendar-free units used by ParseDuration. A year
+// is 365 days.
+const (
+	Day  = 24 * time.Hour
+	Week = 7 * Day
+	Year = 365 * Day
+)
+
+var sizeUnits = map[string]float64{
+	"":  1,
+	"b": 1,
+	"k": 1 << 10, "kb": 1 << 10, "kib": 1 << 10,
+	"m": 1 << 20, "mb": 1 << 20, "mib": 1 << 20,
+	"g": 1 << 30, "gb": 1 << 30, "gib": 1 << 30,
+	"t": 1 << 40, "tb": 1 << 40, "tib": 1 << 40,
+}
+
+// ParseSize parses a byte count such as 1048576, 500MB or 2.5 GB.
+func ParseSize(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+	if i < 0 {
+		i = len(s)
+	}
+	num, unit := s[:i], strings.ToLower(strings.TrimSpace(s[i:]))
+	mult, ok := sizeUnits[unit]
+	if num == "" || !ok {
+		return 0, fmt.Errorf("invalid size %q (use bytes or a unit such as 500MB, 10GB)", s)
+	}
+	n, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q: %w", s, err)
+	}
+	bytes := n * mult
+	if bytes > math.MaxInt64 {
+		return 0, fmt.Errorf("size %q is too large", s)
+	}
+	return int64(bytes), nil
+}
+
+// FormatBytes formats a byte count as a human-readable string such as 1.5 GB.
+func FormatBytes(bytes int64) string {
+	const unit = 1024 // Use 1024 for binary units (KiB, MiB, GiB, etc.)
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	units := []string{"K", "M", "G", "T", "P", "E"}
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+		if exp >= len(units) {
+			break
+		}
+	}
+	if exp >= len(units) {
+		exp = len(units) - 1
+	}
+	return fmt.Sprintf("%.1f %sB", float64(bytes)/float64(div), units[exp])
+}
+
+// ParseDuration parses a non-negative duration such as 90s, 2m, 12h, 7d, 2w
+// or 1y.
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	var d time.Duration
+	var err error
+	if n := len(s); n > 1 && (s[n-1] == 'd' || s[n-1] == 'w' || s[n-1] == 'y') {
+		var count int
+		count, err = strconv.Atoi(s[:n-1])
+		unit := Day
+		switch s[n-1] {
+		case 'w':
+			unit = Week
+		case 'y':
+			unit = Year
+		}
+		d = time.Duration(count) * unit
+	} else {
+		d, err = time.ParseDuration(s)
+	}
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid duration %q (use e.g. 30m, 12h, 7d or 2w)", s)
+	}
+	return d, nil
+}
+
+// ParseDays parses a whole number of days, given either as a bare number or
+// as a duration such as 90d or 4w.
+func ParseDays(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < 0 {
+			return 0, fmt.Errorf("invalid number of days %q", s)
+		}
+		return n, nil
+	}
+	d, err := ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d%Day != 0 {
+		return 0, fmt.Errorf("invalid number of days %q (must be whole days)", s)
+	}
+	return int(d / Day), nil
+}
+
+// FormatDuration formats d using the largest of w, d and the time.Duration
+// units that represents it exactly, so ParseDuration reads it back unchanged.
+func FormatDuration(d time.Duration) string {
+	switch {
+	case d == 0:
+		return "0s"
+	case d%Week == 0:
+		return strconv.FormatInt(int64(d/Week), 10) + "w"
+	case d%Day == 0:
+		return strconv.FormatInt(int64(d/Day), 10) + "d"
+	}
+	return d.String()
+}
+
+// Duration is a flag value that accepts the forms understood by ParseDuration.
+// It satisfies pflag.Value, so commands register it with Flags().Var.
+type Duration time.Duration
+
+// Set parses s into d.
+func (d *Duration) Set(s string) error {
+	v, err := ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// String returns the duration in time.Duration notation, which viper and
+// time.ParseDuration read back.
+func (d *Duration) String() string { return time.Duration(*d).String() }
+
+// Type names the flag value type in help output.
+func (d *Duration) Type() string { return "duration" }
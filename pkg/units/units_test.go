@@ -0,0 +1,102 @@
+package units
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{in: "1048576", want: 1 << 20},
+		{in: "500MB", want: 500 << 20},
+		{in: "10GB", want: 10 << 30},
+		{in: "2.5 gb", want: 5 << 29},
+		{in: "1TiB", want: 1 << 40},
+		{in: "64k", want: 64 << 10},
+		{in: "", wantErr: true},
+		{in: "GB", wantErr: true},
+		{in: "10XB", wantErr: true},
+		{in: "1.2.3MB", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseSize(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseSize(%q) = %d, %v; want %d, err %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestSizeRoundTrip(t *testing.T) {
+	for _, s := range []string{"25GB", "500MB", "1.5 TB"} {
+		n, err := ParseSize(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		back, err := ParseSize(FormatBytes(n))
+		if err != nil || back != n {
+			t.Errorf("%s -> %s -> %d, %v; want %d", s, FormatBytes(n), back, err, n)
+		}
+	}
+}
+
+func TestParseDurationAndDays(t *testing.T) {
+	durations := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "2m", want: 2 * time.Minute},
+		{in: "7d", want: 7 * Day},
+		{in: "2w", want: 2 * Week},
+		{in: "1y", want: 365 * Day},
+		{in: "0s", want: 0},
+		{in: "-1h", wantErr: true},
+		{in: "d", wantErr: true},
+		{in: "soon", wantErr: true},
+	}
+	for _, tt := range durations {
+		got, err := ParseDuration(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseDuration(%q) = %v, %v", tt.in, got, err)
+		}
+	}
+
+	days := []struct {
+		in      string
+		want    int
+		wantErr bool
+	}{
+		{in: "90", want: 90},
+		{in: "90d", want: 90},
+		{in: "4w", want: 28},
+		{in: "48h", want: 2},
+		{in: "36h", wantErr: true},
+		{in: "-3", wantErr: true},
+	}
+	for _, tt := range days {
+		got, err := ParseDays(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseDays(%q) = %d, %v", tt.in, got, err)
+		}
+	}
+
+	for d, want := range map[time.Duration]string{2 * Week: "2w", 3 * Day: "3d", 90 * time.Minute: "1h30m0s", 0: "0s"} {
+		if got := FormatDuration(d); got != want {
+			t.Errorf("FormatDuration(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestDurationFlagValue(t *testing.T) {
+	var d Duration
+	if err := d.Set("1d"); err != nil || time.Duration(d) != Day || d.String() != "24h0m0s" {
+		t.Errorf("Set(1d) = %v, %v", d.String(), err)
+	}
+	if err := d.Set("later"); err == nil {
+		t.Error("expected error for invalid duration")
+	}
+}
@@ -0,0 +1,128 @@
+// Package waiter repeats a check until it reports success, waiting between
+// checks with optional exponential backoff, until a deadline, a maximum number
+// of attempts or cancellation. Commands that wait for something outside the
+// CLI, such as DNS propagation, share it so they behave alike: the same flags,
+// the same progress lines and the same summary when interrupted.
+package waiter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrTimeout is returned by Wait when the check did not succeed before
+// Options.Timeout passed.
+var ErrTimeout = errors.New("timed out")
+
+// ErrAttempts is returned by Wait when the check did not succeed within
+// Options.MaxAttempts attempts.
+var ErrAttempts = errors.New("too many attempts")
+
+// Options configure Wait.
+type Options struct {
+	// Interval is the delay after the first check. It must be positive.
+	Interval time.Duration
+	// MaxInterval enables backoff: the delay doubles after every check up to
+	// MaxInterval. Zero or less than Interval keeps the delay fixed.
+	MaxInterval time.Duration
+	// Timeout bounds the whole wait; zero waits without a deadline.
+	Timeout time.Duration
+	// MaxAttempts bounds the number of checks; zero checks without limit.
+	MaxAttempts int
+
+	// Retryable reports whether a failed check is tried again. nil treats
+	// every error as final.
+	Retryable func(error) bool
+
+	// OnPending is called after a check that did not succeed, with the delay
+	// before the next one.
+	OnPending func(attempt int, next time.Duration)
+	// OnRetry is called after a check that failed with a retryable error,
+	// with the delay before the next one.
+	OnRetry func(attempt int, err error, next time.Duration)
+}
+
+// Check reports whether the wait is over. attempt counts from 1.
+type Check func(ctx context.Context, attempt int) (done bool, err error)
+
+// Result describes a wait, finished or not.
+type Result struct {
+	Attempts int
+	Elapsed  time.Duration
+}
+
+// Interrupted is returned by Wait when ctx is canceled, typically by Ctrl-C,
+// so that the caller can report how far the wait got.
+type Interrupted struct {
+	Result
+	Err error // the context's error
+}
+
+func (e *Interrupted) Error() string {
+	return fmt.Sprintf("interrupted after %d check(s)", e.Attempts)
+}
+
+func (e *Interrupted) Unwrap() error { return e.Err }
+
+// Wait runs check until it reports done, returns an error that is not
+// retryable, or the wait ends: ErrTimeout, ErrAttempts or *Interrupted,
+// after the last check. The delay before each further check is shortened to
+// fit the timeout.
+func Wait(ctx context.Context, opts Options, check Check) (Result, error) {
+	if opts.Interval <= 0 {
+		return Result{}, fmt.Errorf("interval must be positive")
+	}
+	start := time.Now()
+	var deadline time.Time
+	if opts.Timeout > 0 {
+		deadline = start.Add(opts.Timeout)
+	}
+	res := Result{}
+	delay := opts.Interval
+	for {
+		res.Attempts++
+		done, err := check(ctx, res.Attempts)
+		res.Elapsed = time.Since(start)
+		switch {
+		case ctx.Err() != nil:
+			return res, &Interrupted{Result: res, Err: ctx.Err()}
+		case err != nil && (opts.Retryable == nil || !opts.Retryable(err)):
+			return res, err
+		case err == nil && done:
+			return res, nil
+		}
+
+		if opts.MaxAttempts > 0 && res.Attempts >= opts.MaxAttempts {
+			return res, ErrAttempts
+		}
+		next := delay
+		if !deadline.IsZero() {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				return res, ErrTimeout
+			}
+			next = min(next, remaining)
+		}
+		if err != nil {
+			if opts.OnRetry != nil {
+				opts.OnRetry(res.Attempts, err, next)
+			}
+		} else if opts.OnPending != nil {
+			opts.OnPending(res.Attempts, next)
+		}
+
+		timer := time.NewTimer(next)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			res.Elapsed = time.Since(start)
+			return res, &Interrupted{Result: res, Err: ctx.Err()}
+		case <-timer.C:
+		}
+		if opts.MaxInterval > delay {
+			delay = min(delay*2, opts.MaxInterval)
+		}
+	}
+}
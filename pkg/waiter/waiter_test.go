@@ -0,0 +1,86 @@
+package waiter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWait_Backoff(t *testing.T) {
+	var delays []time.Duration
+	opts := Options{
+		Interval:    time.Millisecond,
+		MaxInterval: 4 * time.Millisecond,
+		OnPending:   func(_ int, next time.Duration) { delays = append(delays, next) },
+	}
+	res, err := Wait(context.Background(), opts, func(_ context.Context, attempt int) (bool, error) {
+		return attempt == 5, nil
+	})
+	if err != nil || res.Attempts != 5 {
+		t.Fatalf("Wait = %+v, %v; want 5 attempts", res, err)
+	}
+	want := []time.Duration{time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond, 4 * time.Millisecond}
+	if len(delays) != len(want) {
+		t.Fatalf("delays = %v; want %v", delays, want)
+	}
+	for i := range want {
+		if delays[i] != want[i] {
+			t.Errorf("delays = %v; want %v", delays, want)
+			break
+		}
+	}
+}
+
+func TestWait_Limits(t *testing.T) {
+	never := func(context.Context, int) (bool, error) { return false, nil }
+
+	res, err := Wait(context.Background(), Options{Interval: time.Millisecond, MaxAttempts: 3}, never)
+	if !errors.Is(err, ErrAttempts) || res.Attempts != 3 {
+		t.Errorf("MaxAttempts: Wait = %+v, %v", res, err)
+	}
+
+	res, err = Wait(context.Background(), Options{Interval: time.Hour, Timeout: 5 * time.Millisecond}, never)
+	if !errors.Is(err, ErrTimeout) || res.Attempts != 2 {
+		t.Errorf("Timeout: Wait = %+v, %v; want a timeout after 2 attempts", res, err)
+	}
+
+	if _, err := Wait(context.Background(), Options{}, never); err == nil {
+		t.Error("expected an error for a zero interval")
+	}
+}
+
+func TestWait_Errors(t *testing.T) {
+	transient := errors.New("transient")
+	final := errors.New("final")
+	var retries int
+	opts := Options{
+		Interval:  time.Millisecond,
+		Retryable: func(err error) bool { return errors.Is(err, transient) },
+		OnRetry:   func(int, error, time.Duration) { retries++ },
+	}
+	_, err := Wait(context.Background(), opts, func(_ context.Context, attempt int) (bool, error) {
+		if attempt < 3 {
+			return false, transient
+		}
+		return false, final
+	})
+	if !errors.Is(err, final) || retries != 2 {
+		t.Errorf("Wait = %v after %d retries; want final after 2", err, retries)
+	}
+}
+
+func TestWait_Interrupted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, err := Wait(ctx, Options{Interval: time.Hour}, func(_ context.Context, attempt int) (bool, error) {
+		if attempt == 1 {
+			cancel()
+		}
+		return false, nil
+	})
+	var interrupted *Interrupted
+	if !errors.As(err, &interrupted) || interrupted.Attempts != 1 || !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait = %v; want an interruption after 1 attempt", err)
+	}
+}
@@ -0,0 +1,193 @@
+// Package webhook checks the webhook recipients of aliases and sends them
+// test deliveries.
+//
+// Forward Email posts every message sent to an alias with a webhook
+// recipient to that URL as JSON. A mistyped or unreachable URL silently
+// drops mail, and a URL on a private network can never be reached from
+// Forward Email's servers, so aliases are checked before they are saved.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout bounds each check and test delivery.
+const DefaultTimeout = 10 * time.Second
+
+// IsWebhook reports whether an alias recipient is a webhook URL rather than
+// an email address or mail server.
+func IsWebhook(recipient string) bool {
+	r := strings.ToLower(recipient)
+	return strings.HasPrefix(r, "https://") || strings.HasPrefix(r, "http://")
+}
+
+// Validator checks webhook URLs.
+type Validator struct {
+	// AllowPrivate accepts URLs whose host is or resolves to a loopback,
+	// private or link-local address.
+	AllowPrivate bool
+	// Lookup resolves host names; nil uses net.DefaultResolver.
+	Lookup func(ctx context.Context, network, host string) ([]netip.Addr, error)
+	// Dial connects to check reachability; nil uses a net.Dialer.
+	Dial func(ctx context.Context, network, addr string) (net.Conn, error)
+}
+
+// Validate checks that rawURL is an absolute https URL whose host resolves,
+// only to public addresses unless AllowPrivate is set, and accepts TCP
+// connections. Nothing is sent to the URL.
+func (v *Validator) Validate(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("invalid webhook URL %s", rawURL)
+	}
+	if u.Scheme != "https" {
+		return fmt.Errorf("webhook URL %s must use https", rawURL)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+	defer cancel()
+
+	host := u.Hostname()
+	var addrs []netip.Addr
+	if ip, err := netip.ParseAddr(host); err == nil {
+		addrs = []netip.Addr{ip}
+	} else {
+		lookup := v.Lookup
+		if lookup == nil {
+			lookup = net.DefaultResolver.LookupNetIP
+		}
+		addrs, err = lookup(ctx, "ip", host)
+		if err != nil {
+			return fmt.Errorf("webhook host %s does not resolve: %w", host, err)
+		}
+	}
+	if !v.AllowPrivate {
+		for _, a := range addrs {
+			if IsPrivate(a) {
+				return fmt.Errorf("webhook host %s is a private address (%s); use --allow-private to accept it", host, a)
+			}
+		}
+	}
+
+	port := u.Port()
+	if port == "" {
+		port = "443"
+	}
+	dial := v.Dial
+	if dial == nil {
+		var d net.Dialer
+		dial = d.DialContext
+	}
+	conn, err := dial(ctx, "tcp", net.JoinHostPort(host, port))
+	if err != nil {
+		return fmt.Errorf("webhook %s is not reachable: %w", u.Host, err)
+	}
+	return conn.Close()
+}
+
+// cgnat is the shared address space of carrier-grade NAT (RFC 6598).
+var cgnat = netip.MustParsePrefix("100.64.0.0/10")
+
+// IsPrivate reports whether a is not reachable from the public internet:
+// loopback, private (RFC 1918, RFC 4193), carrier-grade NAT, link-local or
+// unspecified.
+func IsPrivate(a netip.Addr) bool {
+	a = a.Unmap()
+	return a.IsLoopback() || a.IsPrivate() || a.IsLinkLocalUnicast() || a.IsLinkLocalMulticast() ||
+		a.IsInterfaceLocalMulticast() || a.IsUnspecified() || cgnat.Contains(a)
+}
+
+// Result is the outcome of a test delivery.
+type Result struct {
+	URL        string        `json:"url" yaml:"url"`
+	Status     string        `json:"status" yaml:"status"`
+	StatusCode int           `json:"status_code" yaml:"status_code"`
+	Latency    time.Duration `json:"latency" yaml:"latency"`
+}
+
+// OK reports whether the webhook accepted the delivery with a 2xx status.
+func (r *Result) OK() bool {
+	return r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// Send posts payload to rawURL as JSON and measures the time to the
+// response headers. A response with any status is a Result; only a failure
+// to get a response is an error.
+func Send(ctx context.Context, client *http.Client, rawURL string, payload any) (*Result, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode payload: %w", err)
+	}
+	if client == nil {
+		client = &http.Client{Timeout: DefaultTimeout}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rawURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook URL %s: %w", rawURL, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach webhook: %w", err)
+	}
+	latency := time.Since(start)
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	_ = resp.Body.Close()
+	return &Result{URL: rawURL, Status: resp.Status, StatusCode: resp.StatusCode, Latency: latency}, nil
+}
+
+// SamplePayload is a message to address in the shape Forward Email posts to
+// webhooks: the parsed message with its headers, bodies and the SMTP session.
+func SamplePayload(address string, now time.Time) map[string]any {
+	from := "forward-email-cli@example.com"
+	subject := "Forward Email webhook test"
+	messageID := fmt.Sprintf("<webhook-test-%d@forward-email-cli>", now.UnixNano())
+	date := now.UTC().Format(time.RFC1123Z)
+	text := "This is a test delivery sent by 'forward-email alias webhook test'.\n"
+	return map[string]any{
+		"headerLines": []map[string]string{
+			{"key": "from", "line": "From: " + from},
+			{"key": "to", "line": "To: " + address},
+			{"key": "subject", "line": "Subject: " + subject},
+			{"key": "date", "line": "Date: " + date},
+			{"key": "message-id", "line": "Message-ID: " + messageID},
+		},
+		"headers":    fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nDate: %s\r\nMessage-ID: %s\r\n", from, address, subject, date, messageID),
+		"from":       addressObject(from),
+		"to":         addressObject(address),
+		"subject":    subject,
+		"date":       now.UTC().Format(time.RFC3339),
+		"messageId":  messageID,
+		"text":       text,
+		"textAsHtml": "<p>" + strings.TrimSpace(text) + "</p>",
+		"html":       false,
+		"recipients": []string{address},
+		"session": map[string]any{
+			"recipient":   address,
+			"sender":      from,
+			"arrivalDate": now.UTC().Format(time.RFC3339),
+		},
+		"attachments": []any{},
+		"test":        true,
+	}
+}
+
+// addressObject is an address field as parsed by Forward Email.
+func addressObject(address string) map[string]any {
+	return map[string]any{
+		"value": []map[string]string{{"address": address, "name": ""}},
+		"text":  address,
+	}
+}
@@ -0,0 +1,125 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+)
+
+// publicNetwork resolves every host to a documentation address and accepts
+// every connection, recording the dialed address.
+func publicNetwork(dialed *string) *Validator {
+	return &Validator{
+		Lookup: func(context.Context, string, string) ([]netip.Addr, error) {
+			return []netip.Addr{netip.MustParseAddr("203.0.113.10")}, nil
+		},
+		Dial: func(_ context.Context, _, addr string) (net.Conn, error) {
+			*dialed = addr
+			c1, c2 := net.Pipe()
+			_ = c2.Close()
+			return c1, nil
+		},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	var dialed string
+	v := publicNetwork(&dialed)
+	ctx := context.Background()
+
+	if err := v.Validate(ctx, "https://hooks.example.net/mail"); err != nil {
+		t.Fatalf("expected a public https URL to pass, got %v", err)
+	}
+	if dialed != "hooks.example.net:443" {
+		t.Errorf("dialed %q, want the default https port", dialed)
+	}
+
+	for url, want := range map[string]string{
+		"http://hooks.example.net/mail": "must use https",
+		"https:///mail":                 "invalid webhook URL",
+		"https://127.0.0.1:8443/mail":   "private address (127.0.0.1)",
+		"https://[fd00::1]/mail":        "private address",
+		"https://100.64.1.1/mail":       "private address",
+	} {
+		if err := v.Validate(ctx, url); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate(%s) = %v, want %q", url, err, want)
+		}
+	}
+
+	v.Lookup = func(context.Context, string, string) ([]netip.Addr, error) {
+		return []netip.Addr{netip.MustParseAddr("192.168.1.20")}, nil
+	}
+	if err := v.Validate(ctx, "https://nas.lan/hook"); err == nil || !strings.Contains(err.Error(), "--allow-private") {
+		t.Errorf("expected a host resolving to a private address to be rejected, got %v", err)
+	}
+	v.AllowPrivate = true
+	if err := v.Validate(ctx, "https://nas.lan:8443/hook"); err != nil || dialed != "nas.lan:8443" {
+		t.Errorf("expected --allow-private to accept it, got %v (dialed %s)", err, dialed)
+	}
+
+	v.Dial = func(context.Context, string, string) (net.Conn, error) {
+		return nil, errors.New("connection refused")
+	}
+	if err := v.Validate(ctx, "https://nas.lan/hook"); err == nil || !strings.Contains(err.Error(), "not reachable") {
+		t.Errorf("expected an unreachable host to be rejected, got %v", err)
+	}
+}
+
+func TestIsWebhook(t *testing.T) {
+	for r, want := range map[string]bool{
+		"https://hooks.example.net": true,
+		"HTTP://hooks.example.net":  true,
+		"me@example.org":            false,
+		"mx1.example.org":           false,
+	} {
+		if got := IsWebhook(r); got != want {
+			t.Errorf("IsWebhook(%s) = %v", r, got)
+		}
+	}
+}
+
+func TestSend(t *testing.T) {
+	var got map[string]any
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected content type %q", r.Header.Get("Content-Type"))
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	res, err := Send(context.Background(), srv.Client(), srv.URL, SamplePayload("sales@example.com", now))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.OK() || res.StatusCode != http.StatusAccepted || res.Status != "202 Accepted" || res.Latency <= 0 {
+		t.Errorf("unexpected result %+v", res)
+	}
+	if got["subject"] != "Forward Email webhook test" || got["test"] != true {
+		t.Errorf("unexpected payload %v", got)
+	}
+	if recipients, _ := got["recipients"].([]any); len(recipients) != 1 || recipients[0] != "sales@example.com" {
+		t.Errorf("unexpected recipients %v", got["recipients"])
+	}
+
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	res, err = Send(context.Background(), srv.Client(), srv.URL, map[string]any{})
+	if err != nil || res.OK() {
+		t.Errorf("expected a 500 to be a result that is not OK, got %+v, %v", res, err)
+	}
+
+	if _, err := Send(context.Background(), srv.Client(), "https://127.0.0.1:1/hook", map[string]any{}); err == nil {
+		t.Error("expected an error when no response arrives")
+	}
+}